@@ -0,0 +1,90 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package api declares the stable API of Cadence for embedders and tools.
+//
+// It only declares interfaces for parsing, checking, and executing programs,
+// and for converting values, which refer to external values and types (package cadence),
+// locations (package common), and the AST (package ast), but not to the internals of the interpreter.
+// Embedders and tools should depend on these interfaces,
+// so they are not affected by changes to the internals.
+//
+// The runtime provides an implementation, see NewRuntimeAPI.
+package api
+
+import (
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+)
+
+// Script is a script or transaction, with its arguments
+type Script struct {
+	Source    []byte
+	Arguments []cadence.Value
+}
+
+// Parser parses programs
+type Parser interface {
+	// Parse parses the given code.
+	//
+	// This function returns an error if the code has syntax errors.
+	Parse(code []byte) (*ast.Program, error)
+}
+
+// Checker checks programs
+type Checker interface {
+	// Check parses and checks the given code, as the program at the given location.
+	//
+	// This function returns an error if the code has syntax or semantic errors.
+	Check(code []byte, location common.Location) error
+}
+
+// Executor executes scripts and transactions
+type Executor interface {
+	// ExecuteScript executes the given script, as the program at the given location,
+	// and returns its result.
+	//
+	// This function returns an error if the script has errors (e.g syntax errors, type errors),
+	// or if the execution fails.
+	ExecuteScript(script Script, location common.Location) (cadence.Value, error)
+
+	// ExecuteTransaction executes the given transaction, as the program at the given location.
+	//
+	// This function returns an error if the transaction has errors (e.g syntax errors, type errors),
+	// or if the execution fails.
+	ExecuteTransaction(script Script, location common.Location) error
+}
+
+// ValueConverter converts values to and from their encoded form,
+// e.g. to pass them as arguments, or to return them from scripts
+type ValueConverter interface {
+	// EncodeValue encodes the given value.
+	EncodeValue(value cadence.Value) ([]byte, error)
+
+	// DecodeValue decodes the given encoded value.
+	DecodeValue(encoded []byte) (cadence.Value, error)
+}
+
+// API is the complete API
+type API interface {
+	Parser
+	Checker
+	Executor
+	ValueConverter
+}
@@ -0,0 +1,118 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/cadence/runtime"
+)
+
+// runtimeAPI implements the API using a runtime and a host interface.
+//
+// Values are encoded using the JSON-Cadence Data Interchange Format,
+// so the host interface must decode arguments in this format.
+type runtimeAPI struct {
+	runtime          runtime.Runtime
+	runtimeInterface runtime.Interface
+}
+
+var _ API = &runtimeAPI{}
+
+// NewRuntimeAPI returns the API,
+// which parses, checks, and executes programs using the given runtime and host interface.
+func NewRuntimeAPI(runtime runtime.Runtime, runtimeInterface runtime.Interface) API {
+	return &runtimeAPI{
+		runtime:          runtime,
+		runtimeInterface: runtimeInterface,
+	}
+}
+
+func (a *runtimeAPI) Parse(code []byte) (*ast.Program, error) {
+	return parser.ParseProgram(a.runtimeInterface, code, parser.Config{})
+}
+
+func (a *runtimeAPI) Check(code []byte, location common.Location) error {
+	_, err := a.runtime.ParseAndCheckProgram(
+		code,
+		runtime.Context{
+			Interface: a.runtimeInterface,
+			Location:  location,
+		},
+	)
+	return err
+}
+
+func (a *runtimeAPI) ExecuteScript(script Script, location common.Location) (cadence.Value, error) {
+	runtimeScript, err := a.script(script)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.runtime.ExecuteScript(
+		runtimeScript,
+		runtime.Context{
+			Interface: a.runtimeInterface,
+			Location:  location,
+		},
+	)
+}
+
+func (a *runtimeAPI) ExecuteTransaction(script Script, location common.Location) error {
+	runtimeScript, err := a.script(script)
+	if err != nil {
+		return err
+	}
+
+	return a.runtime.ExecuteTransaction(
+		runtimeScript,
+		runtime.Context{
+			Interface: a.runtimeInterface,
+			Location:  location,
+		},
+	)
+}
+
+// script returns the runtime script for the given script, with encoded arguments
+func (a *runtimeAPI) script(script Script) (runtime.Script, error) {
+	arguments := make([][]byte, 0, len(script.Arguments))
+	for _, argument := range script.Arguments {
+		encoded, err := a.EncodeValue(argument)
+		if err != nil {
+			return runtime.Script{}, err
+		}
+		arguments = append(arguments, encoded)
+	}
+
+	return runtime.Script{
+		Source:    script.Source,
+		Arguments: arguments,
+	}, nil
+}
+
+func (a *runtimeAPI) EncodeValue(value cadence.Value) ([]byte, error) {
+	return json.Encode(value)
+}
+
+func (a *runtimeAPI) DecodeValue(encoded []byte) (cadence.Value, error) {
+	return json.Decode(a.runtimeInterface, encoded)
+}
@@ -0,0 +1,142 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	. "github.com/onflow/cadence/api"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/encoding/json"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeAPI(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	var logs []string
+
+	runtimeInterface := &TestRuntimeInterface{
+		Storage: NewTestLedger(nil, nil),
+		OnGetSigningAccounts: func() ([]common.Address, error) {
+			return []common.Address{address}, nil
+		},
+		OnDecodeArgument: func(b []byte, _ cadence.Type) (cadence.Value, error) {
+			return json.Decode(nil, b)
+		},
+		OnProgramLog: func(message string) {
+			logs = append(logs, message)
+		},
+	}
+
+	cadenceAPI := NewRuntimeAPI(NewTestInterpreterRuntime(), runtimeInterface)
+
+	nextScriptLocation := NewScriptLocationGenerator()
+	nextTransactionLocation := NewTransactionLocationGenerator()
+
+	t.Run("parse", func(t *testing.T) {
+
+		program, err := cadenceAPI.Parse([]byte(`access(all) fun main(): Int { return 1 }`))
+		require.NoError(t, err)
+		require.Len(t, program.FunctionDeclarations(), 1)
+
+		_, err = cadenceAPI.Parse([]byte(`access(all) fun main(`))
+		require.Error(t, err)
+	})
+
+	t.Run("check", func(t *testing.T) {
+
+		err := cadenceAPI.Check(
+			[]byte(`access(all) fun main(): Int { return 1 }`),
+			nextScriptLocation(),
+		)
+		require.NoError(t, err)
+
+		err = cadenceAPI.Check(
+			[]byte(`access(all) fun main(): Int { return "1" }`),
+			nextScriptLocation(),
+		)
+		RequireError(t, err)
+		assert.ErrorContains(t, err, "mismatched types")
+	})
+
+	t.Run("execute script", func(t *testing.T) {
+
+		result, err := cadenceAPI.ExecuteScript(
+			Script{
+				Source: []byte(`
+                  access(all) fun main(a: Int, b: String): String {
+                      return b.concat(a.toString())
+                  }
+                `),
+				Arguments: []cadence.Value{
+					cadence.NewInt(42),
+					cadence.String("answer: "),
+				},
+			},
+			nextScriptLocation(),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.String("answer: 42"), result)
+	})
+
+	t.Run("execute transaction", func(t *testing.T) {
+
+		err := cadenceAPI.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction(message: String) {
+                      prepare(signer: &Account) {
+                          log(message)
+                      }
+                  }
+                `),
+				Arguments: []cadence.Value{
+					cadence.String("hello"),
+				},
+			},
+			nextTransactionLocation(),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{`"hello"`}, logs)
+	})
+
+	t.Run("convert value", func(t *testing.T) {
+
+		value := cadence.NewArray([]cadence.Value{
+			cadence.NewInt(1),
+			cadence.String("two"),
+		})
+
+		encoded, err := cadenceAPI.EncodeValue(value)
+		require.NoError(t, err)
+
+		decoded, err := cadenceAPI.DecodeValue(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, value, decoded)
+	})
+}
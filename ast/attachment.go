@@ -102,6 +102,10 @@ func (d *AttachmentDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+func (d *AttachmentDeclaration) DeclarationDocComment() DocComment {
+	return ParseDocComment(d.DeclarationDocString())
+}
+
 func (*AttachmentDeclaration) Kind() common.CompositeKind {
 	return common.CompositeKindAttachment
 }
@@ -123,6 +123,10 @@ func (d *CompositeDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+func (d *CompositeDeclaration) DeclarationDocComment() DocComment {
+	return ParseDocComment(d.DeclarationDocString())
+}
+
 func (d *CompositeDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias CompositeDeclaration
 	return json.Marshal(&struct {
@@ -378,6 +382,10 @@ func (d *FieldDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+func (d *FieldDeclaration) DeclarationDocComment() DocComment {
+	return ParseDocComment(d.DeclarationDocString())
+}
+
 func (d *FieldDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias FieldDeclaration
 	return json.Marshal(&struct {
@@ -556,6 +564,10 @@ func (d *EnumCaseDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+func (d *EnumCaseDeclaration) DeclarationDocComment() DocComment {
+	return ParseDocComment(d.DeclarationDocString())
+}
+
 func (d *EnumCaseDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias EnumCaseDeclaration
 	return json.Marshal(&struct {
@@ -35,5 +35,6 @@ type Declaration interface {
 	DeclarationAccess() Access
 	DeclarationMembers() *Members
 	DeclarationDocString() string
+	DeclarationDocComment() DocComment
 	Doc() prettier.Doc
 }
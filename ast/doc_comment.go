@@ -0,0 +1,168 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"strings"
+)
+
+const (
+	DocCommentTagParam      = "param"
+	DocCommentTagReturn     = "return"
+	DocCommentTagDeprecated = "deprecated"
+)
+
+// DocComment is the structured form of the doc string of a declaration.
+//
+// A doc comment consists of a summary, followed by tags.
+// Each tag starts on a new line with `@` and the name of the tag,
+// and extends until the next tag or the end of the doc comment:
+//
+//	/// Returns the balance of the vault.
+//	///
+//	/// @param vault: The vault to check
+//	/// @return The balance, in the smallest unit
+//	/// @deprecated Use `vault.balance` instead
+type DocComment struct {
+	// Summary is the text before the first tag
+	Summary string
+	// Params are the `@param` tags, in the order they are declared
+	Params []DocCommentParam
+	// Return is the text of the `@return` tag
+	Return string
+	// Deprecated is true if the doc comment contains a `@deprecated` tag
+	Deprecated bool
+	// DeprecationMessage is the text of the `@deprecated` tag
+	DeprecationMessage string
+	// Tags are all other tags, in the order they are declared
+	Tags []DocCommentTag
+}
+
+// DocCommentParam is the documentation of a parameter, i.e. a `@param` tag.
+// Both `@param name: description` and `@param name description` are supported
+type DocCommentParam struct {
+	Name        string
+	Description string
+}
+
+// DocCommentTag is a tag of a doc comment, e.g. `@since v1.0.0`
+type DocCommentTag struct {
+	Name string
+	Text string
+}
+
+// IsEmpty returns true if the doc comment has no summary and no tags
+func (c DocComment) IsEmpty() bool {
+	return c.Summary == "" &&
+		len(c.Params) == 0 &&
+		c.Return == "" &&
+		!c.Deprecated &&
+		len(c.Tags) == 0
+}
+
+// Param returns the description of the parameter with the given name
+func (c DocComment) Param(name string) (description string, ok bool) {
+	for _, param := range c.Params {
+		if param.Name == name {
+			return param.Description, true
+		}
+	}
+	return "", false
+}
+
+// ParseDocComment parses the given doc string into a doc comment.
+//
+// Leading and trailing whitespace is removed from each line,
+// as well as the leading `*` of lines of block comments.
+func ParseDocComment(docString string) DocComment {
+	var result DocComment
+
+	var tag DocCommentTag
+	var inTag bool
+	var text []string
+
+	finish := func() {
+		joined := strings.TrimSpace(strings.Join(text, "\n"))
+		text = text[:0]
+
+		if !inTag {
+			result.Summary = joined
+			return
+		}
+
+		tag.Text = joined
+		result.addTag(tag)
+	}
+
+	for _, line := range strings.Split(docString, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "*") && !strings.HasPrefix(line, "*/") {
+			line = strings.TrimSpace(line[1:])
+		}
+
+		if strings.HasPrefix(line, "@") {
+			name, rest, _ := strings.Cut(line[1:], " ")
+			if name != "" {
+				finish()
+
+				inTag = true
+				tag = DocCommentTag{
+					Name: name,
+				}
+				line = strings.TrimSpace(rest)
+			}
+		}
+
+		text = append(text, line)
+	}
+
+	finish()
+
+	return result
+}
+
+func (c *DocComment) addTag(tag DocCommentTag) {
+	switch tag.Name {
+	case DocCommentTagParam:
+		name := tag.Text
+		var description string
+		if index := strings.IndexAny(name, ": \n"); index >= 0 {
+			description = strings.TrimSpace(name[index:])
+			description = strings.TrimSpace(strings.TrimPrefix(description, ":"))
+			name = name[:index]
+		}
+		c.Params = append(
+			c.Params,
+			DocCommentParam{
+				Name:        name,
+				Description: strings.TrimSpace(description),
+			},
+		)
+
+	case DocCommentTagReturn:
+		c.Return = tag.Text
+
+	case DocCommentTagDeprecated:
+		c.Deprecated = true
+		c.DeprecationMessage = tag.Text
+
+	default:
+		c.Tags = append(c.Tags, tag)
+	}
+}
@@ -0,0 +1,130 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDocComment(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		docComment := ParseDocComment("")
+		assert.True(t, docComment.IsEmpty())
+	})
+
+	t.Run("summary only", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t,
+			DocComment{
+				Summary: "First line\nSecond line",
+			},
+			ParseDocComment(" First line\n Second line"),
+		)
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		t.Parallel()
+
+		docComment := ParseDocComment(
+			" Returns the sum.\n" +
+				"\n" +
+				" @param a: The first number\n" +
+				" @param b The second number,\n" +
+				"   which must be positive\n" +
+				" @return The sum of a and b\n" +
+				" @since v1.0.0\n" +
+				" @deprecated Use add instead",
+		)
+
+		assert.Equal(t,
+			DocComment{
+				Summary: "Returns the sum.",
+				Params: []DocCommentParam{
+					{
+						Name:        "a",
+						Description: "The first number",
+					},
+					{
+						Name:        "b",
+						Description: "The second number,\nwhich must be positive",
+					},
+				},
+				Return:             "The sum of a and b",
+				Deprecated:         true,
+				DeprecationMessage: "Use add instead",
+				Tags: []DocCommentTag{
+					{
+						Name: "since",
+						Text: "v1.0.0",
+					},
+				},
+			},
+			docComment,
+		)
+
+		description, ok := docComment.Param("b")
+		assert.True(t, ok)
+		assert.Equal(t, "The second number,\nwhich must be positive", description)
+
+		_, ok = docComment.Param("c")
+		assert.False(t, ok)
+	})
+
+	t.Run("block comment", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t,
+			DocComment{
+				Summary: "Creates a vault.",
+				Params: []DocCommentParam{
+					{
+						Name:        "balance",
+						Description: "The initial balance",
+					},
+				},
+				Deprecated: true,
+			},
+			ParseDocComment("\n * Creates a vault.\n *\n * @param balance: The initial balance\n * @deprecated\n "),
+		)
+	})
+
+	t.Run("declaration", func(t *testing.T) {
+		t.Parallel()
+
+		declaration := &FunctionDeclaration{
+			DocString: " Does something.\n @return Nothing",
+		}
+
+		assert.Equal(t,
+			DocComment{
+				Summary: "Does something.",
+				Return:  "Nothing",
+			},
+			declaration.DeclarationDocComment(),
+		)
+	})
+}
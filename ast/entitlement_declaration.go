@@ -88,6 +88,10 @@ func (d *EntitlementDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+func (d *EntitlementDeclaration) DeclarationDocComment() DocComment {
+	return ParseDocComment(d.DeclarationDocString())
+}
+
 func (d *EntitlementDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias EntitlementDeclaration
 	return json.Marshal(&struct {
@@ -247,6 +251,10 @@ func (d *EntitlementMappingDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+func (d *EntitlementMappingDeclaration) DeclarationDocComment() DocComment {
+	return ParseDocComment(d.DeclarationDocString())
+}
+
 func (d *EntitlementMappingDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias EntitlementMappingDeclaration
 	return json.Marshal(&struct {
@@ -179,6 +179,10 @@ func (d *FunctionDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+func (d *FunctionDeclaration) DeclarationDocComment() DocComment {
+	return ParseDocComment(d.DeclarationDocString())
+}
+
 func (d *FunctionDeclaration) Doc() prettier.Doc {
 	return FunctionDocument(
 		d.Access,
@@ -289,6 +293,10 @@ func (d *SpecialFunctionDeclaration) DeclarationDocString() string {
 	return d.FunctionDeclaration.DeclarationDocString()
 }
 
+func (d *SpecialFunctionDeclaration) DeclarationDocComment() DocComment {
+	return d.FunctionDeclaration.DeclarationDocComment()
+}
+
 func (d *SpecialFunctionDeclaration) Doc() prettier.Doc {
 	return FunctionDocument(
 		d.FunctionDeclaration.Access,
@@ -87,6 +87,10 @@ func (d *ImportDeclaration) DeclarationDocString() string {
 	return ""
 }
 
+func (d *ImportDeclaration) DeclarationDocComment() DocComment {
+	return DocComment{}
+}
+
 func (d *ImportDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias ImportDeclaration
 	return json.Marshal(&struct {
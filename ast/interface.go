@@ -99,6 +99,10 @@ func (d *InterfaceDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+func (d *InterfaceDeclaration) DeclarationDocComment() DocComment {
+	return ParseDocComment(d.DeclarationDocString())
+}
+
 func (d *InterfaceDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias InterfaceDeclaration
 	return json.Marshal(&struct {
@@ -77,6 +77,10 @@ func (d *PragmaDeclaration) DeclarationDocString() string {
 	return ""
 }
 
+func (d *PragmaDeclaration) DeclarationDocComment() DocComment {
+	return DocComment{}
+}
+
 func (d *PragmaDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias PragmaDeclaration
 	return json.Marshal(&struct {
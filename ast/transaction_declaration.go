@@ -107,6 +107,10 @@ func (d *TransactionDeclaration) DeclarationDocString() string {
 	return ""
 }
 
+func (d *TransactionDeclaration) DeclarationDocComment() DocComment {
+	return DocComment{}
+}
+
 func (d *TransactionDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias TransactionDeclaration
 	return json.Marshal(&struct {
@@ -130,6 +130,10 @@ func (d *VariableDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+func (d *VariableDeclaration) DeclarationDocComment() DocComment {
+	return ParseDocComment(d.DeclarationDocString())
+}
+
 var varKeywordDoc prettier.Doc = prettier.Text("var")
 var letKeywordDoc prettier.Doc = prettier.Text("let")
 
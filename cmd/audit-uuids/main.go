@@ -0,0 +1,162 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// A utility program that audits the resource UUIDs of an execution state snapshot.
+// It reports UUIDs which are used by more than one resource,
+// and UUIDs which were not issued yet according to the UUID counter.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/tools/snapshot"
+	"github.com/onflow/cadence/tools/uuidaudit"
+)
+
+type stringSlice []string
+
+func (s stringSlice) String() string {
+	return strings.Join(s, ", ")
+}
+
+func (s *stringSlice) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+var addressesFlag stringSlice
+
+func init() {
+	flag.Var(&addressesFlag, "addresses", "only audit the accounts with the given addresses")
+}
+
+var formatFlag = flag.String(
+	"format",
+	snapshot.FormatJSONLines,
+	fmt.Sprintf("format of the snapshot (%s)", strings.Join(snapshot.Formats(), ", ")),
+)
+var counterKeyFlag = flag.String("counter-key", "uuid", "key of the register which stores the UUID counter")
+var counterFlag = flag.Uint64("counter", 0, "value of the UUID counter, overrides the counter register")
+var storageFormatV2Flag = flag.Bool("storage-format-v2", false, "set true if accounts may be in storage format v2")
+var jsonFlag = flag.Bool("json", false, "output the report as JSON")
+
+func main() {
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		log.Fatal("missing path argument")
+	}
+
+	var addresses []common.Address
+
+	for _, hexAddress := range addressesFlag {
+		address, err := common.HexToAddress(hexAddress)
+		if err != nil {
+			log.Fatalf("Invalid address: %s", hexAddress)
+		}
+		addresses = append(addresses, address)
+	}
+
+	log.Println("Reading snapshot ...")
+
+	iterator, err := snapshot.Open(*formatFlag, args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ledger, err := uuidaudit.NewLedger(iterator)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	config := uuidaudit.Config{
+		Owners:                 addresses,
+		StorageFormatV2Enabled: *storageFormatV2Flag,
+	}
+
+	// The UUID counter is stored in a register which is not owned by any account
+
+	if *counterFlag > 0 {
+		config.Counter = counterFlag
+	} else if counter, ok := ledger.UInt64(common.ZeroAddress, *counterKeyFlag); ok {
+		config.Counter = &counter
+	} else {
+		log.Printf("UUID counter register %q not found, not checking UUIDs against counter", *counterKeyFlag)
+	}
+
+	log.Println("Auditing resources ...")
+
+	report, err := uuidaudit.Audit(ledger, config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *jsonFlag {
+		for _, err := range report.Errors {
+			log.Println(err)
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err := encoder.Encode(report)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	printReport(report, config)
+}
+
+func printReport(report *uuidaudit.Report, config uuidaudit.Config) {
+	fmt.Printf("Resources: %d\n", report.ResourceCount)
+	fmt.Printf("Greatest UUID: %d\n", report.MaxUUID)
+	if config.Counter != nil {
+		fmt.Printf("UUID counter: %d\n", *config.Counter)
+	}
+
+	fmt.Printf("\nDuplicate UUIDs: %d\n", len(report.Duplicates))
+	for _, duplicate := range report.Duplicates {
+		fmt.Printf("- %d:\n", duplicate.UUID)
+		for _, resource := range duplicate.Resources {
+			fmt.Printf("  - %s\n", resource)
+		}
+	}
+
+	if config.Counter != nil {
+		fmt.Printf("\nUUIDs not issued yet: %d\n", len(report.NotIssued))
+		for _, resource := range report.NotIssued {
+			fmt.Printf("- %s\n", resource)
+		}
+	}
+
+	if len(report.Errors) > 0 {
+		fmt.Printf("\nErrors: %d\n", len(report.Errors))
+		for _, err := range report.Errors {
+			fmt.Printf("- %s\n", err)
+		}
+	}
+}
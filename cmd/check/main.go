@@ -31,9 +31,11 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/ast"
 	"github.com/onflow/cadence/cmd"
 	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/diagnostics"
 	"github.com/onflow/cadence/pretty"
 	"github.com/onflow/cadence/sema"
 	"github.com/onflow/cadence/stdlib"
@@ -52,11 +54,49 @@ func (f *memberAccountAccessFlags) Set(value string) error {
 
 var benchFlag = flag.Bool("bench", false, "benchmark the checker")
 var jsonFlag = flag.Bool("json", false, "print the result formatted as JSON")
+var sarifFlag = flag.Bool("sarif", false, "print the diagnostics formatted as SARIF")
+
+var warningsFlag = flag.Bool("warnings", false, "report warnings, e.g. for deprecated syntax and unused declarations")
+var warningsAsErrorsFlag = flag.Bool("warnings-as-errors", false, "report all warnings as errors")
 
 var memberAccountAccessFlag memberAccountAccessFlags
 
+type diagnosticLevelFlags []string
+
+func (f *diagnosticLevelFlags) String() string {
+	return ""
+}
+
+func (f *diagnosticLevelFlags) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+var diagnosticLevelFlag diagnosticLevelFlags
+
+// warningOptions determine which warnings are reported, and with which severity
+type warningOptions struct {
+	levels   map[sema.ErrorCode]sema.Severity
+	enabled  bool
+	asErrors bool
+}
+
+func (o warningOptions) configure(config *sema.Config) {
+	if !o.enabled {
+		return
+	}
+
+	config.DeprecationRules = sema.NewDeprecationRuleSet(sema.DefaultDeprecationRules)
+	config.ViewAnnotationWarningsEnabled = true
+	config.SwitchExhaustivenessWarningsEnabled = true
+	config.UnusedDeclarationWarningsEnabled = true
+	config.DiagnosticLevels = o.levels
+	config.WarningsAsErrors = o.asErrors
+}
+
 func main() {
 	flag.Var(&memberAccountAccessFlag, "memberAccountAccess", "allow account access from:to")
+	flag.Var(&diagnosticLevelFlag, "level", "report warnings with the given code with the given severity (error, warning, or off): code=severity")
 	flag.Parse()
 
 	memberAccountAccess := map[common.Location]map[common.Location]struct{}{}
@@ -87,8 +127,28 @@ func main() {
 		nested[targetLocation] = struct{}{}
 	}
 
+	warnings := warningOptions{
+		enabled:  *warningsFlag || *warningsAsErrorsFlag || len(diagnosticLevelFlag) > 0,
+		asErrors: *warningsAsErrorsFlag,
+		levels:   map[sema.ErrorCode]sema.Severity{},
+	}
+
+	for _, value := range diagnosticLevelFlag {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) < 2 {
+			panic(fmt.Errorf("invalid level flag: got '%s', expected 'code=severity'", value))
+		}
+
+		severity, err := sema.ParseSeverity(parts[1])
+		if err != nil {
+			panic(fmt.Errorf("invalid level flag: %w", err))
+		}
+
+		warnings.levels[sema.ErrorCode(parts[0])] = severity
+	}
+
 	args := flag.Args()
-	run(args, *benchFlag, *jsonFlag, memberAccountAccess)
+	run(args, *benchFlag, *jsonFlag, *sarifFlag, memberAccountAccess, warnings)
 }
 
 type benchResult struct {
@@ -103,6 +163,8 @@ type result struct {
 	Bench    *benchResult `json:"bench,omitempty"`
 	BenchStr string       `json:"-"`
 	Error    string       `json:"error,omitempty"`
+	// Diagnostics are the checker errors and warnings
+	Diagnostics []diagnostics.Diagnostic `json:"diagnostics,omitempty"`
 }
 
 type output interface {
@@ -133,6 +195,25 @@ func (j *jsonOutput) End() {
 	}
 }
 
+type sarifOutput struct {
+	diagnostics []diagnostics.Diagnostic
+}
+
+func newSARIFOutput() *sarifOutput {
+	return &sarifOutput{}
+}
+
+func (s *sarifOutput) Append(r result) {
+	s.diagnostics = append(s.diagnostics, r.Diagnostics...)
+}
+
+func (s *sarifOutput) End() {
+	err := diagnostics.WriteSARIF(os.Stdout, cadence.Version, s.diagnostics)
+	if err != nil {
+		panic(err)
+	}
+}
+
 type stdoutOutput struct {
 	writer *tabwriter.Writer
 }
@@ -181,7 +262,9 @@ func run(
 	paths []string,
 	bench bool,
 	json bool,
+	sarif bool,
 	memberAccountAccess map[common.Location]map[common.Location]struct{},
+	warnings warningOptions,
 ) {
 	if len(paths) == 0 {
 		paths = []string{""}
@@ -190,16 +273,19 @@ func run(
 	allSucceeded := true
 
 	var out output
-	if json {
+	switch {
+	case sarif:
+		out = newSARIFOutput()
+	case json:
 		out = newJSONOutput(len(paths))
-	} else {
+	default:
 		out = newStdoutOutput()
 	}
 
-	useColor := !json
+	useColor := !json && !sarif
 
 	for _, path := range paths {
-		res, runSucceeded := runPath(path, bench, useColor, memberAccountAccess)
+		res, runSucceeded := runPath(path, bench, useColor, memberAccountAccess, warnings)
 		if !runSucceeded {
 			allSucceeded = false
 		}
@@ -219,6 +305,7 @@ func runPath(
 	bench bool,
 	useColor bool,
 	memberAccountAccess map[common.Location]map[common.Location]struct{},
+	warnings warningOptions,
 ) (res result, succeeded bool) {
 	res = result{
 		Path: path,
@@ -257,8 +344,15 @@ func runPath(
 			standardLibraryValues,
 			must,
 		)
+		warnings.configure(checker.Config)
 
 		err = checker.Check()
+
+		res.Diagnostics = append(
+			diagnostics.FromError(err, location, codes),
+			diagnostics.FromWarnings(checker.Warnings(), location, codes)...,
+		)
+
 		if err != nil {
 			var builder strings.Builder
 			printErr := pretty.NewErrorPrettyPrinter(&builder, useColor).
@@ -286,6 +380,7 @@ func runPath(
 					standardLibraryValues,
 					must,
 				)
+				warnings.configure(checker.Config)
 				must(checker.Check())
 				if err != nil {
 					panic(err)
@@ -160,7 +160,11 @@ func PrepareChecker(
 	return checker, must
 }
 
-func PrepareInterpreter(filename string, debugger *interpreter.Debugger) (*interpreter.Interpreter, *sema.Checker, func(error)) {
+func PrepareInterpreter(
+	filename string,
+	debugger *interpreter.Debugger,
+	profiler *interpreter.Profiler,
+) (*interpreter.Interpreter, *sema.Checker, func(error)) {
 
 	codes := map[common.Location][]byte{}
 
@@ -203,6 +207,7 @@ func PrepareInterpreter(filename string, debugger *interpreter.Debugger) (*inter
 			return uuid, nil
 		},
 		Debugger: debugger,
+		Profiler: profiler,
 		ImportLocationHandler: func(inter *interpreter.Interpreter, location common.Location) interpreter.Import {
 			panic("Importing programs is not supported yet")
 		},
@@ -316,6 +321,10 @@ func (*StandardLibraryHandler) GetAccountContractCode(_ common.AddressLocation)
 	return nil, goerrors.New("accounts are not supported in this environment")
 }
 
+func (*StandardLibraryHandler) GetAccountContractUpdateHeight(_ common.AddressLocation) (uint64, bool, error) {
+	return 0, false, goerrors.New("accounts are not supported in this environment")
+}
+
 func (*StandardLibraryHandler) EmitEvent(
 	_ *interpreter.Interpreter,
 	_ interpreter.LocationRange,
@@ -432,6 +441,10 @@ func (h *StandardLibraryHandler) IsContractBeingAdded(common.AddressLocation) bo
 	return false
 }
 
+func (*StandardLibraryHandler) ContractMetadataEventsEnabled() bool {
+	return false
+}
+
 func formatLocationRange(locationRange interpreter.LocationRange) string {
 	var builder strings.Builder
 	if locationRange.Location != nil {
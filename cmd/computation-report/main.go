@@ -0,0 +1,182 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// A utility program that estimates an upper bound of the computation of the functions of Cadence programs,
+// and reports unbounded loops, to give early feedback about functions
+// which are likely to exceed transaction limits.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence/cmd"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/stdlib"
+	"github.com/onflow/cadence/tools/computationestimate"
+)
+
+var jsonFlag = flag.Bool("json", false, "output the report as JSON")
+var loopIterationsFlag = flag.Uint64(
+	"loop-iterations",
+	computationestimate.DefaultConfig.DefaultLoopIterations,
+	"assumed number of iterations of unbounded loops",
+)
+var limitFlag = flag.Uint64("limit", 0, "flag functions with an estimated computation above the given limit")
+
+type weightFlags []string
+
+func (f *weightFlags) String() string {
+	return ""
+}
+
+func (f *weightFlags) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+var weightFlag weightFlags
+
+var computationKinds = map[string]common.ComputationKind{
+	"statement":  common.ComputationKindStatement,
+	"loop":       common.ComputationKindLoop,
+	"invocation": common.ComputationKindFunctionInvocation,
+}
+
+func main() {
+	flag.Var(&weightFlag, "weight", "weight of a computation kind (statement, loop, or invocation): kind=weight")
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		log.Fatal("missing path argument")
+	}
+
+	config := computationestimate.Config{
+		Weights:               maps.Clone(computationestimate.DefaultWeights),
+		DefaultLoopIterations: *loopIterationsFlag,
+	}
+
+	for _, value := range weightFlag {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) < 2 {
+			log.Fatalf("invalid weight flag: got '%s', expected 'kind=weight'", value)
+		}
+
+		kind, ok := computationKinds[parts[0]]
+		if !ok {
+			log.Fatalf("invalid weight flag: unknown computation kind '%s'", parts[0])
+		}
+
+		weight, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			log.Fatalf("invalid weight flag: %s", err)
+		}
+
+		config.Weights[kind] = weight
+	}
+
+	reports := make([]*computationestimate.Report, 0, len(paths))
+
+	for _, path := range paths {
+		codes := map[common.Location][]byte{}
+		location := common.NewStringLocation(nil, path)
+
+		program, must := cmd.PrepareProgramFromFile(location, codes)
+
+		checker, must := cmd.PrepareChecker(
+			program,
+			location,
+			codes,
+			nil,
+			stdlib.DefaultScriptStandardLibraryValues(nil),
+			must,
+		)
+		// The estimation needs the types of iterated values, e.g. of constant-sized arrays
+		checker.Config.ExtendedElaborationEnabled = true
+		must(checker.Check())
+
+		reports = append(
+			reports,
+			computationestimate.Analyze(location, program, checker.Elaboration, config),
+		)
+	}
+
+	if *jsonFlag {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err := encoder.Encode(reports)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	for _, report := range reports {
+		printReport(report, *limitFlag)
+	}
+}
+
+func printReport(report *computationestimate.Report, limit uint64) {
+	fmt.Printf("%s\n", report.Location)
+
+	for _, function := range report.Functions {
+		name := function.Name
+		if function.ContainerTypeID != "" {
+			name = fmt.Sprintf("%s.%s", function.ContainerTypeID, name)
+		}
+
+		var notes []string
+		if !function.Bounded {
+			notes = append(notes, "unbounded")
+		}
+		if limit > 0 && function.Computation > limit {
+			notes = append(notes, "exceeds limit")
+		}
+
+		var suffix string
+		if len(notes) > 0 {
+			suffix = fmt.Sprintf(" (%s)", strings.Join(notes, ", "))
+		}
+
+		fmt.Printf(
+			"- %s (%d:%d): %d%s\n",
+			name,
+			function.Position.Line,
+			function.Position.Column,
+			function.Computation,
+			suffix,
+		)
+
+		for _, loop := range function.UnboundedLoops {
+			fmt.Printf(
+				"  %d:%d: %s\n",
+				loop.Position.Line,
+				loop.Position.Column,
+				loop.Reason,
+			)
+		}
+	}
+}
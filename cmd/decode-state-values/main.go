@@ -21,7 +21,6 @@
 package main
 
 import (
-	"bufio"
 	"bytes"
 	"compress/gzip"
 	"encoding/hex"
@@ -41,6 +40,7 @@ import (
 	"github.com/onflow/cadence/common"
 	runtimeErr "github.com/onflow/cadence/errors"
 	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/tools/snapshot"
 )
 
 type stringSlice []string
@@ -495,8 +495,6 @@ func read(file *os.File, addresses []common.Address) {
 
 	log.Println("Reading file ...")
 
-	filter := len(addresses) > 0
-
 	stat, err := file.Stat()
 	if err != nil {
 		log.Fatal(err)
@@ -519,76 +517,37 @@ func read(file *os.File, addresses []common.Address) {
 		inputReader = gzipReader
 	}
 
-	reader := bufio.NewReader(inputReader)
-
-	decoder := json.NewDecoder(reader)
-
-	var emptyLines int
-	var line int
-
-payloadLoop:
-	for ; true; line++ {
-		var e encodedEntry
-
-		err = decoder.Decode(&e)
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			log.Fatal(err)
-		}
+	jsonLinesIterator := snapshot.NewJSONLinesIterator(inputReader)
 
-		currentKeyPartCount := len(e.Key.KeyParts)
-		if currentKeyPartCount < keyPartCount {
-			if currentKeyPartCount > 0 {
-				log.Fatalf("Invalid storage key parts on line %d: %#+v", line, e.Key)
+	err = snapshot.ForEach(
+		snapshot.FilterOwners(jsonLinesIterator, addresses...),
+		func(register snapshot.Register) error {
+			// Ignore empty slabs
+			if len(register.Value) == 0 {
+				return nil
 			}
-			emptyLines++
-			continue
-		}
 
-		var storageKey [keyPartCount]string
-		for i := 0; i < keyPartCount; i++ {
-			keyPart := e.Key.KeyParts[i].Value
-			k, err := hex.DecodeString(keyPart)
-			if err != nil {
-				log.Fatalf(
-					"Failed to hex-decode key part %d of %s (%s): %s",
-					i, e.Key, keyPart, err,
-				)
-			}
 			// Treat bytes as string,
 			// so resulting array of strings can be used as a map key
-			storageKey[i] = string(k)
-		}
-
-		if filter {
-			owner := common.MustBytesToAddress([]byte(storageKey[0]))
-			var found bool
-			for _, address := range addresses {
-				if owner == address {
-					found = true
-					break
-				}
+			storageKey := storageKey{
+				string(register.Owner[:]),
+				"",
+				register.Key,
 			}
-			if !found {
-				continue payloadLoop
-			}
-		}
-
-		data, err := hex.DecodeString(e.Value)
-		if err != nil {
-			log.Fatalf("Invalid value: %s", err)
-		}
+			storage[storageKey] = register.Value
 
-		// Ignore empty slabs
-		if len(data) > 0 {
-			storage[storageKey] = data
-		}
+			return nil
+		},
+	)
+	if err != nil {
+		log.Fatal(err)
 	}
 
+	lines := jsonLinesIterator.Lines
+	emptyLines := jsonLinesIterator.EmptyLines
+
 	log.Printf(
 		"read %d lines (%d empty, %f%%)",
-		line, emptyLines, float32(emptyLines*100)/float32(line),
+		lines, emptyLines, float32(emptyLines*100)/float32(lines),
 	)
 }
@@ -0,0 +1,104 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// A utility program that reports the authorization surface of Cadence contracts.
+// For each function which can be called from outside of its contract,
+// it reports the entitlements required to call the function,
+// and the entitlements the function may propagate through returned references.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/onflow/cadence/cmd"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/stdlib"
+	"github.com/onflow/cadence/tools/entitlementflow"
+)
+
+var jsonFlag = flag.Bool("json", false, "output the report as JSON")
+
+func main() {
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		log.Fatal("missing path argument")
+	}
+
+	reports := make([]*entitlementflow.Report, 0, len(paths))
+
+	for _, path := range paths {
+		codes := map[common.Location][]byte{}
+		location := common.NewStringLocation(nil, path)
+
+		program, must := cmd.PrepareProgramFromFile(location, codes)
+
+		checker, must := cmd.PrepareChecker(
+			program,
+			location,
+			codes,
+			nil,
+			stdlib.DefaultScriptStandardLibraryValues(nil),
+			must,
+		)
+		must(checker.Check())
+
+		reports = append(
+			reports,
+			entitlementflow.Analyze(location, program, checker.Elaboration),
+		)
+	}
+
+	if *jsonFlag {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err := encoder.Encode(reports)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	for _, report := range reports {
+		printReport(report)
+	}
+}
+
+func printReport(report *entitlementflow.Report) {
+	fmt.Printf("%s\n", report.Location)
+
+	for _, function := range report.Functions {
+		fmt.Printf(
+			"- %s.%s (%d:%d): %s\n",
+			function.ContainerTypeID,
+			function.Name,
+			function.Position.Line,
+			function.Position.Column,
+			function.Access,
+		)
+		for _, returned := range function.Returned {
+			fmt.Printf("  returns %s\n", returned.Type)
+		}
+	}
+}
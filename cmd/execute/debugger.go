@@ -21,11 +21,13 @@ package execute
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
 	"github.com/c-bata/go-prompt"
 
+	"github.com/onflow/cadence/common"
 	"github.com/onflow/cadence/interpreter"
 )
 
@@ -40,12 +42,17 @@ const commandShortShow = "s"
 const commandLongShow = "show"
 const commandShortWhere = "w"
 const commandLongWhere = "where"
+const commandShortBreak = "b"
+const commandLongBreak = "break"
+const commandLongWatch = "watch"
 
 var debuggerCommandSuggestions = []prompt.Suggest{
 	{Text: commandLongContinue, Description: "Continue"},
 	{Text: commandLongNext, Description: "Next / step"},
 	{Text: commandLongWhere, Description: "Location info"},
 	{Text: commandLongShow, Description: "Show variable(s)"},
+	{Text: commandLongBreak, Description: "Add breakpoint at line, with optional condition: break <line> [<condition>]"},
+	{Text: commandLongWatch, Description: "Watch variable or storage path: watch <name> | watch <address> <path>"},
 	{Text: commandLongExit, Description: "Exit"},
 	{Text: commandLongHelp, Description: "Help"},
 }
@@ -107,6 +114,78 @@ func (d *InteractiveDebugger) Show(names []string) {
 	}
 }
 
+// Break adds a breakpoint at the given line of the current location.
+// If further arguments are given, they are the condition of the breakpoint
+func (d *InteractiveDebugger) Break(arguments []string) {
+	if len(arguments) == 0 {
+		fmt.Println(colorizeError("error: missing line"))
+		return
+	}
+
+	line, err := strconv.ParseUint(arguments[0], 10, 0)
+	if err != nil {
+		fmt.Println(colorizeError(fmt.Sprintf("error: invalid line '%s'", arguments[0])))
+		return
+	}
+
+	location := d.stop.Interpreter.Location
+
+	if len(arguments) == 1 {
+		d.debugger.AddBreakpoint(location, uint(line))
+		return
+	}
+
+	condition := strings.Join(arguments[1:], " ")
+	err = d.debugger.AddConditionalBreakpoint(location, uint(line), condition)
+	if err != nil {
+		fmt.Println(colorizeError(fmt.Sprintf("error: invalid condition: %s", err)))
+	}
+}
+
+// Watch adds a watchpoint for the variable with the given name in the current location,
+// or for the given storage path in the account with the given address
+func (d *InteractiveDebugger) Watch(arguments []string) {
+	switch len(arguments) {
+	case 1:
+		d.debugger.AddVariableWatchpoint(d.stop.Interpreter.Location, arguments[0])
+
+	case 2:
+		address, err := common.HexToAddress(arguments[0])
+		if err != nil {
+			fmt.Println(colorizeError(fmt.Sprintf("error: invalid address '%s'", arguments[0])))
+			return
+		}
+
+		path, ok := parsePath(arguments[1])
+		if !ok {
+			fmt.Println(colorizeError(fmt.Sprintf("error: invalid path '%s'", arguments[1])))
+			return
+		}
+
+		d.debugger.AddStoragePathWatchpoint(address, path)
+
+	default:
+		fmt.Println(colorizeError("error: expected variable name, or address and path"))
+	}
+}
+
+func parsePath(s string) (interpreter.PathValue, bool) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 3 || parts[0] != "" || parts[2] == "" {
+		return interpreter.PathValue{}, false
+	}
+
+	domain := common.PathDomainFromIdentifier(parts[1])
+	if domain == common.PathDomainUnknown {
+		return interpreter.PathValue{}, false
+	}
+
+	return interpreter.PathValue{
+		Domain:     domain,
+		Identifier: parts[2],
+	}, true
+}
+
 func (d *InteractiveDebugger) Run() {
 
 	executor := func(in string) {
@@ -127,6 +206,10 @@ func (d *InteractiveDebugger) Run() {
 			d.Show(arguments)
 		case commandShortWhere, commandLongWhere:
 			d.Where()
+		case commandShortBreak, commandLongBreak:
+			d.Break(arguments)
+		case commandLongWatch:
+			d.Watch(arguments)
 		case commandShortHelp, commandLongHelp:
 			d.Help()
 		case commandLongExit:
@@ -182,4 +265,12 @@ func (d *InteractiveDebugger) Where() {
 		d.stop.Interpreter.Location,
 		d.stop.Statement.StartPosition().Line,
 	)
+
+	if d.stop.Watchpoint != nil {
+		fmt.Printf("watchpoint: %s\n", d.stop.Watchpoint)
+	}
+
+	if d.stop.ConditionError != nil {
+		fmt.Println(colorizeError(fmt.Sprintf("error: breakpoint condition failed: %s", d.stop.ConditionError)))
+	}
 }
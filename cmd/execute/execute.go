@@ -19,6 +19,9 @@
 package execute
 
 import (
+	"flag"
+	"os"
+
 	"github.com/onflow/cadence/cmd"
 	"github.com/onflow/cadence/interpreter"
 )
@@ -27,18 +30,51 @@ import (
 // If there are no syntax errors, the program is interpreted.
 // If after the interpretation a global function `main` is defined, it will be called.
 // The program may call the function `log` to print a value.
+//
+// If the flag `-profile <file>` is given, a profile of the execution is written
+// to the given file, in the pprof format.
 func Execute(args []string, debugger *interpreter.Debugger) {
 
+	flags := flag.NewFlagSet("execute", flag.ExitOnError)
+	profilePath := flags.String("profile", "", "write a pprof profile of the execution to the given file")
+	_ = flags.Parse(args)
+	args = flags.Args()
+
 	if len(args) < 1 {
 		cmd.ExitWithError("no input file")
 	}
 
-	inter, _, must := cmd.PrepareInterpreter(args[0], debugger)
+	var profiler *interpreter.Profiler
+	if *profilePath != "" {
+		profiler = interpreter.NewProfiler()
+	}
+
+	inter, _, must := cmd.PrepareInterpreter(args[0], debugger, profiler)
+
+	if inter.Globals.Contains("main") {
+		_, err := inter.Invoke("main")
+		must(err)
+	}
+
+	if profiler != nil {
+		writeProfile(profiler, *profilePath)
+	}
+}
+
+func writeProfile(profiler *interpreter.Profiler, path string) {
+	file, err := os.Create(path)
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
 
-	if !inter.Globals.Contains("main") {
-		return
+	err = profiler.WritePprof(file)
+	if err != nil {
+		_ = file.Close()
+		cmd.ExitWithError(err.Error())
 	}
 
-	_, err := inter.Invoke("main")
-	must(err)
+	err = file.Close()
+	if err != nil {
+		cmd.ExitWithError(err.Error())
+	}
 }
@@ -19,8 +19,10 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
+	"os"
 	"strings"
 
 	"golang.org/x/exp/slices"
@@ -32,6 +34,7 @@ import (
 	"github.com/onflow/cadence/sema"
 	"github.com/onflow/cadence/stdlib"
 	"github.com/onflow/cadence/test_utils/sema_utils"
+	"github.com/onflow/cadence/tools/editorgrammar"
 )
 
 type command struct {
@@ -41,6 +44,7 @@ type command struct {
 
 var includeNested = flag.Bool("nested", false, "include nested")
 var includeMembers = flag.Bool("members", false, "include members")
+var outputJSON = flag.Bool("json", false, "output JSON")
 
 func main() {
 	flag.Parse()
@@ -72,9 +76,21 @@ var commands = map[string]command{
 		help:    "Dumps all built-in values",
 		handler: dumpBuiltinValues,
 	},
-	"dump-hard-keywords": {
-		help:    "Dumps all hard keywords",
-		handler: dumpHardKeywords,
+	"dump-keywords": {
+		help:    "Dumps all keywords, and if they are hard or contextual keywords",
+		handler: dumpKeywords,
+	},
+	"dump-grammar": {
+		help:    "Dumps the grammar, in EBNF or JSON",
+		handler: dumpGrammar,
+	},
+	"dump-textmate-grammar": {
+		help:    "Dumps a TextMate grammar for syntax highlighting",
+		handler: dumpTextMateGrammar,
+	},
+	"dump-tree-sitter-highlights": {
+		help:    "Dumps tree-sitter highlight queries for syntax highlighting",
+		handler: dumpTreeSitterHighlights,
 	},
 }
 
@@ -296,10 +312,53 @@ func dumpBuiltinValues() {
 	}
 }
 
-func dumpHardKeywords() {
-	for _, keyword := range parser.HardKeywords {
-		fmt.Printf("- %s\n", keyword)
+func dumpKeywords() {
+	keywords := parser.NewGrammar().Keywords
+
+	if *outputJSON {
+		encoded, err := json.MarshalIndent(keywords, "", "  ")
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(string(encoded))
+		return
 	}
+
+	for _, keyword := range keywords {
+		fmt.Printf("- %s (%s)\n", keyword.Keyword, keyword.Kind)
+	}
+}
+
+func dumpGrammar() {
+	grammar := parser.NewGrammar()
+
+	if *outputJSON {
+		encoded, err := json.MarshalIndent(grammar, "", "  ")
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(string(encoded))
+		return
+	}
+
+	fmt.Print(grammar.EBNF())
+}
+
+func dumpTextMateGrammar() {
+	grammar := editorgrammar.NewTextMateGrammar(parser.NewGrammar())
+
+	// Do not escape operators like `<` and `&` in regular expressions
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(grammar)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func dumpTreeSitterHighlights() {
+	fmt.Println(editorgrammar.TreeSitterHighlights(parser.NewGrammar()))
 }
 
 func printAvailableCommands() {
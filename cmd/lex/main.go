@@ -0,0 +1,288 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/cadence/parser/lexer"
+)
+
+var jsonFlag = flag.Bool("json", false, "print the tokens as JSON, including literal values and leading trivia")
+
+func main() {
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{""}
+	}
+
+	results := make([]result, 0, len(paths))
+
+	for _, path := range paths {
+		tokens, err := lex(read(path))
+		if err != nil {
+			panic(err)
+		}
+		results = append(results,
+			result{
+				Path:   path,
+				Tokens: tokens,
+			},
+		)
+	}
+
+	if *jsonFlag {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err := encoder.Encode(results)
+		if err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	for _, result := range results {
+		if len(result.Path) > 0 {
+			_, _ = fmt.Fprintf(os.Stdout, "%s\n", result.Path)
+		}
+		for _, token := range result.Tokens {
+			_, _ = fmt.Fprintln(os.Stdout, token.String())
+		}
+	}
+}
+
+func read(path string) []byte {
+	var data []byte
+	var err error
+	if len(path) == 0 {
+		data, err = io.ReadAll(bufio.NewReader(os.Stdin))
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+type result struct {
+	Path   string  `json:"path,omitempty"`
+	Tokens []token `json:"tokens"`
+}
+
+// token is a token produced by the lexer.
+// Spaces and comments are not tokens, but the leading trivia of the following token
+type token struct {
+	// Value is the value of a literal, i.e. the decoded string for a string literal,
+	// and the decimal representation for a number literal
+	Value         any       `json:"value,omitempty"`
+	Type          string    `json:"type"`
+	Text          string    `json:"text"`
+	Error         string    `json:"error,omitempty"`
+	LeadingTrivia []trivia  `json:"leadingTrivia,omitempty"`
+	Range         ast.Range `json:"range"`
+}
+
+func (t token) String() string {
+	var builder strings.Builder
+	_, _ = fmt.Fprintf(
+		&builder,
+		"%d:%d-%d:%d\t%s\t%q",
+		t.Range.StartPos.Line,
+		t.Range.StartPos.Column,
+		t.Range.EndPos.Line,
+		t.Range.EndPos.Column,
+		t.Type,
+		t.Text,
+	)
+	if t.Value != nil {
+		_, _ = fmt.Fprintf(&builder, "\t%q", t.Value)
+	}
+	if t.Error != "" {
+		_, _ = fmt.Fprintf(&builder, "\terror: %s", t.Error)
+	}
+	return builder.String()
+}
+
+type triviaKind string
+
+const (
+	triviaKindSpace        triviaKind = "space"
+	triviaKindLineComment  triviaKind = "lineComment"
+	triviaKindBlockComment triviaKind = "blockComment"
+)
+
+// trivia is source code which has no meaning for the parser,
+// i.e. spaces and comments
+type trivia struct {
+	Kind  triviaKind `json:"kind"`
+	Text  string     `json:"text"`
+	Range ast.Range  `json:"range"`
+}
+
+// lex returns the tokens of the given code.
+// The last token is the EOF token, which has the trailing trivia of the code as its leading trivia
+func lex(code []byte) ([]token, error) {
+	tokenStream, err := lexer.Lex(code, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tokenStream.Reclaim()
+
+	var tokens []token
+	var leadingTrivia []trivia
+
+	// blockCommentDepth is the nesting depth of the current block comment.
+	// Block comments are lexed as multiple tokens, which are combined into one trivia
+	blockCommentDepth := 0
+
+	for {
+		lexerToken := tokenStream.Next()
+		text := string(code[lexerToken.StartPos.Offset:min(lexerToken.EndPos.Offset+1, len(code))])
+
+		switch lexerToken.Type {
+		case lexer.TokenSpace:
+			leadingTrivia = append(leadingTrivia, trivia{
+				Kind:  triviaKindSpace,
+				Text:  text,
+				Range: lexerToken.Range,
+			})
+			continue
+
+		case lexer.TokenLineComment:
+			leadingTrivia = append(leadingTrivia, trivia{
+				Kind:  triviaKindLineComment,
+				Text:  text,
+				Range: lexerToken.Range,
+			})
+			continue
+
+		case lexer.TokenBlockCommentStart:
+			if blockCommentDepth == 0 {
+				leadingTrivia = append(leadingTrivia, trivia{
+					Kind:  triviaKindBlockComment,
+					Range: lexerToken.Range,
+				})
+			}
+			blockCommentDepth++
+			extendBlockComment(leadingTrivia, lexerToken, code)
+			continue
+
+		case lexer.TokenBlockCommentContent:
+			extendBlockComment(leadingTrivia, lexerToken, code)
+			continue
+
+		case lexer.TokenBlockCommentEnd:
+			blockCommentDepth--
+			extendBlockComment(leadingTrivia, lexerToken, code)
+			continue
+		}
+
+		t := token{
+			Type:          lexerToken.Type.String(),
+			Range:         lexerToken.Range,
+			LeadingTrivia: leadingTrivia,
+		}
+		leadingTrivia = nil
+
+		if lexerToken.Type == lexer.TokenEOF {
+			tokens = append(tokens, t)
+			return tokens, nil
+		}
+
+		t.Text = text
+
+		if err, ok := lexerToken.SpaceOrError.(error); ok {
+			t.Error = err.Error()
+		} else {
+			t.Value = literalValue(lexerToken.Type, []byte(text))
+		}
+
+		tokens = append(tokens, t)
+	}
+}
+
+// extendBlockComment extends the block comment trivia, which is the last trivia, to the end of the given token
+func extendBlockComment(leadingTrivia []trivia, lexerToken lexer.Token, code []byte) {
+	if len(leadingTrivia) == 0 {
+		return
+	}
+
+	blockComment := &leadingTrivia[len(leadingTrivia)-1]
+	blockComment.Range.EndPos = lexerToken.EndPos
+	blockComment.Text = string(code[blockComment.Range.StartPos.Offset : lexerToken.EndPos.Offset+1])
+}
+
+// literalValue returns the value of the given literal token,
+// or nil if the token is not a literal, or the literal is invalid.
+// The literal is parsed by the parser, so the value is the same as in a program
+func literalValue(tokenType lexer.TokenType, text []byte) any {
+	switch tokenType {
+	case lexer.TokenString,
+		lexer.TokenRawString,
+		lexer.TokenBinaryIntegerLiteral,
+		lexer.TokenOctalIntegerLiteral,
+		lexer.TokenDecimalIntegerLiteral,
+		lexer.TokenHexadecimalIntegerLiteral,
+		lexer.TokenFixedPointNumberLiteral:
+
+	default:
+		return nil
+	}
+
+	expression, err := parser.ParseExpression(nil, text, parser.Config{})
+	if err != nil {
+		return nil
+	}
+
+	switch expression := expression.(type) {
+	case *ast.StringExpression:
+		return expression.Value
+
+	case *ast.IntegerExpression:
+		return expression.Value.String()
+
+	case *ast.FixedPointExpression:
+		fractional := expression.Fractional.String()
+		padding := int(expression.Scale) - len(fractional)
+		if padding > 0 {
+			fractional = strings.Repeat("0", padding) + fractional
+		}
+
+		integer := new(big.Int).Set(expression.UnsignedInteger)
+		if expression.Negative {
+			integer.Neg(integer)
+		}
+
+		return integer.String() + "." + fractional
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/ast"
+)
+
+func TestLex(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("literal values", func(t *testing.T) {
+		t.Parallel()
+
+		tokens, err := lex([]byte(`"a\nb" 0x10 1_000 1.05`))
+		require.NoError(t, err)
+
+		var values []any
+		for _, token := range tokens {
+			values = append(values, token.Value)
+		}
+
+		assert.Equal(t,
+			[]any{"a\nb", "16", "1000", "1.05", nil},
+			values,
+		)
+	})
+
+	t.Run("leading trivia", func(t *testing.T) {
+		t.Parallel()
+
+		tokens, err := lex([]byte("// a\nx /* b /* c */ */y\n"))
+		require.NoError(t, err)
+
+		require.Len(t, tokens, 3)
+
+		assert.Equal(t,
+			token{
+				Type: "identifier",
+				Text: "x",
+				LeadingTrivia: []trivia{
+					{
+						Kind: triviaKindLineComment,
+						Text: "// a",
+						Range: ast.Range{
+							StartPos: ast.Position{Offset: 0, Line: 1, Column: 0},
+							EndPos:   ast.Position{Offset: 3, Line: 1, Column: 3},
+						},
+					},
+					{
+						Kind: triviaKindSpace,
+						Text: "\n",
+						Range: ast.Range{
+							StartPos: ast.Position{Offset: 4, Line: 1, Column: 4},
+							EndPos:   ast.Position{Offset: 4, Line: 1, Column: 4},
+						},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 5, Line: 2, Column: 0},
+					EndPos:   ast.Position{Offset: 5, Line: 2, Column: 0},
+				},
+			},
+			tokens[0],
+		)
+
+		require.Len(t, tokens[1].LeadingTrivia, 2)
+		assert.Equal(t,
+			trivia{
+				Kind: triviaKindBlockComment,
+				Text: "/* b /* c */ */",
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 7, Line: 2, Column: 2},
+					EndPos:   ast.Position{Offset: 21, Line: 2, Column: 16},
+				},
+			},
+			tokens[1].LeadingTrivia[1],
+		)
+
+		// The trailing trivia is the leading trivia of the EOF token
+
+		assert.Equal(t, "EOF", tokens[2].Type)
+		require.Len(t, tokens[2].LeadingTrivia, 1)
+		assert.Equal(t, triviaKindSpace, tokens[2].LeadingTrivia[0].Kind)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		tokens, err := lex([]byte("x $"))
+		require.NoError(t, err)
+
+		require.Len(t, tokens, 3)
+		assert.Equal(t, "error", tokens[1].Type)
+		assert.Equal(t, "unrecognized character: U+0024 '$'", tokens[1].Error)
+	})
+}
@@ -36,11 +36,19 @@ func main() {
 
 		debugger := interpreter.NewDebugger()
 
+		// Run the interactive debugger when the program is interrupted,
+		// or when it stops at a breakpoint or watchpoint.
+		// The interactive debugger continues the program when it exits
+
 		go func() {
-			for range signals {
-				stop := debugger.Pause()
+			for {
+				var stop interpreter.Stop
+				select {
+				case <-signals:
+					stop = debugger.Pause()
+				case stop = <-debugger.Stops():
+				}
 				execute.NewInteractiveDebugger(debugger, stop).Run()
-				debugger.Continue()
 			}
 		}()
 
@@ -0,0 +1,122 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// A utility program that simulates a contract update against the contracts which depend on it,
+// and reports which dependents would be broken by the update.
+// The deployed contracts are read from a CSV file with the header location,code (e.g. produced by tools/get-contracts).
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/tools/snapshot"
+	"github.com/onflow/cadence/tools/updatesimulation"
+)
+
+var contractsFlag = flag.String("contracts", "", "CSV file of deployed contracts (location,code)")
+var locationFlag = flag.String("location", "", "location of the updated contract, e.g. A.0000000000001234.Foo")
+var jsonFlag = flag.Bool("json", false, "output the report as JSON")
+
+func main() {
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		log.Fatal("missing path of the proposed code")
+	}
+
+	if *contractsFlag == "" {
+		log.Fatal("missing contracts")
+	}
+
+	location, _, err := common.DecodeTypeID(nil, *locationFlag)
+	if err != nil {
+		log.Fatalf("Invalid location %s: %s", *locationFlag, err)
+	}
+	addressLocation, ok := location.(common.AddressLocation)
+	if !ok {
+		log.Fatalf("Invalid location %s: not an address location", *locationFlag)
+	}
+
+	code, err := os.ReadFile(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	codes, err := snapshot.ReadDeployedContracts(*contractsFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report, err := updatesimulation.Simulate(
+		updatesimulation.Update{
+			Location: addressLocation,
+			Code:     code,
+		},
+		updatesimulation.Config{
+			Codes: codes,
+		},
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *jsonFlag {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err := encoder.Encode(report)
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		printReport(report)
+	}
+
+	if !report.Compatible() {
+		os.Exit(1)
+	}
+}
+
+func printReport(report *updatesimulation.Report) {
+	if len(report.Errors) > 0 {
+		fmt.Printf("%s: proposed code is invalid\n", report.Location)
+		for _, message := range report.Errors {
+			fmt.Printf("  %s\n", message)
+		}
+	}
+
+	for _, result := range report.Dependents {
+		kind := "transitive"
+		if result.Direct {
+			kind = "direct"
+		}
+		fmt.Printf("%s (%s): %s\n", result.Location, kind, result.Status)
+
+		if result.Status == updatesimulation.StatusBroken {
+			for _, message := range result.Errors {
+				fmt.Printf("  %s\n", message)
+			}
+		}
+	}
+}
@@ -0,0 +1,231 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// A utility program that verifies that local contract sources reproduce deployed contracts.
+// The deployed contracts are fetched from an access node, using the REST API,
+// or read from a CSV file with the header location,code (e.g. produced by tools/get-contracts).
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/tools/contractverification"
+	"github.com/onflow/cadence/tools/snapshot"
+)
+
+type stringSlice []string
+
+func (s stringSlice) String() string {
+	return strings.Join(s, ", ")
+}
+
+func (s *stringSlice) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+var addressesFlag stringSlice
+
+func init() {
+	flag.Var(&addressesFlag, "address", "address of a contract, in the form Name=0x1234")
+}
+
+var accessNodeFlag = flag.String("access-node", "https://rest-mainnet.onflow.org", "URL of the REST API of an access node")
+var contractsFlag = flag.String("contracts", "", "CSV file of deployed contracts (location,code), instead of the access node")
+var jsonFlag = flag.Bool("json", false, "output the report as JSON")
+
+func main() {
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		log.Fatal("missing path arguments")
+	}
+
+	addresses := map[string]common.Address{}
+
+	for _, nameAndAddress := range addressesFlag {
+		name, hexAddress, ok := strings.Cut(nameAndAddress, "=")
+		if !ok {
+			log.Fatalf("Invalid contract address: %s", nameAndAddress)
+		}
+		address, err := common.HexToAddress(hexAddress)
+		if err != nil {
+			log.Fatalf("Invalid address: %s", hexAddress)
+		}
+		addresses[name] = address
+	}
+
+	sources, err := readSources(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var getDeployedCode func(location common.AddressLocation) ([]byte, error)
+	if *contractsFlag != "" {
+		codes, err := snapshot.ReadDeployedContracts(*contractsFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		getDeployedCode = func(location common.AddressLocation) ([]byte, error) {
+			return codes[location], nil
+		}
+	} else {
+		getDeployedCode = fetchDeployedContract(*accessNodeFlag)
+	}
+
+	report, err := contractverification.Verify(
+		sources,
+		contractverification.Config{
+			Addresses:       addresses,
+			GetDeployedCode: getDeployedCode,
+		},
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *jsonFlag {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err := encoder.Encode(report)
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		printReport(report)
+	}
+
+	if !report.Verified() {
+		os.Exit(1)
+	}
+}
+
+// readSources reads the Cadence files at the given paths.
+// Directories are read recursively
+func readSources(paths []string) (sources []contractverification.Source, err error) {
+	for _, root := range paths {
+		err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if entry.IsDir() || (path != root && filepath.Ext(path) != ".cdc") {
+				return nil
+			}
+
+			code, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			sources = append(
+				sources,
+				contractverification.Source{
+					Path: path,
+					Code: code,
+				},
+			)
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sources, nil
+}
+
+// fetchDeployedContract returns a function which fetches deployed contracts
+// from the REST API of the access node with the given URL
+func fetchDeployedContract(accessNodeURL string) func(location common.AddressLocation) ([]byte, error) {
+	accounts := map[common.Address]map[string]string{}
+
+	return func(location common.AddressLocation) ([]byte, error) {
+		contracts, ok := accounts[location.Address]
+		if !ok {
+			url := fmt.Sprintf(
+				"%s/v1/accounts/%s?expand=contracts",
+				strings.TrimSuffix(accessNodeURL, "/"),
+				location.Address.Hex(),
+			)
+
+			res, err := http.Get(url)
+			if err != nil {
+				return nil, fmt.Errorf("failed to send HTTP request: %w", err)
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("failed to get account %s: %s", location.Address, res.Status)
+			}
+
+			var account struct {
+				Contracts map[string]string `json:"contracts"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&account)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode account %s: %w", location.Address, err)
+			}
+
+			contracts = account.Contracts
+			accounts[location.Address] = contracts
+		}
+
+		encodedCode, ok := contracts[location.Name]
+		if !ok {
+			return nil, nil
+		}
+
+		return base64.StdEncoding.DecodeString(encodedCode)
+	}
+}
+
+func printReport(report *contractverification.Report) {
+	for _, result := range report.Results {
+		fmt.Printf("%s (%s): %s\n", result.Location, result.Path, result.Status)
+
+		switch result.Status {
+		case contractverification.StatusVerified:
+			if result.ExactMatch {
+				fmt.Println("  exact match")
+			}
+
+		case contractverification.StatusMismatch:
+			difference := result.Difference
+			fmt.Printf("  first difference in line %d of the normalized code:\n", difference.Line)
+			fmt.Printf("  - local:    %s\n", strings.TrimSpace(difference.Local))
+			fmt.Printf("  - deployed: %s\n", strings.TrimSpace(difference.Deployed))
+
+		case contractverification.StatusFailed:
+			fmt.Printf("  %s\n", result.Error)
+		}
+	}
+}
@@ -57,10 +57,10 @@ const (
 	ComputationKindCreateArrayValue
 	ComputationKindTransferArrayValue
 	ComputationKindDestroyArrayValue
-	_
-	_
-	_
-	_
+	ComputationKindArrayAppend
+	ComputationKindArrayInsert
+	ComputationKindArrayRemove
+	ComputationKindArrayContains
 	_
 	_
 	_
@@ -72,6 +72,9 @@ const (
 	ComputationKindCreateDictionaryValue
 	ComputationKindTransferDictionaryValue
 	ComputationKindDestroyDictionaryValue
+	ComputationKindDictionaryInsert
+	ComputationKindDictionaryRemove
+	ComputationKindDictionaryLookup
 	_
 	_
 	_
@@ -84,12 +87,9 @@ const (
 	_
 	_
 	_
-	_
-	_
-	_
-	_
-	_
-	_
+	ComputationKindStringConcat
+	ComputationKindStringSlice
+	ComputationKindBigIntegerOperation
 	_
 	_
 	_
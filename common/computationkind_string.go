@@ -18,9 +18,19 @@ func _() {
 	_ = x[ComputationKindCreateArrayValue-1025]
 	_ = x[ComputationKindTransferArrayValue-1026]
 	_ = x[ComputationKindDestroyArrayValue-1027]
+	_ = x[ComputationKindArrayAppend-1028]
+	_ = x[ComputationKindArrayInsert-1029]
+	_ = x[ComputationKindArrayRemove-1030]
+	_ = x[ComputationKindArrayContains-1031]
 	_ = x[ComputationKindCreateDictionaryValue-1040]
 	_ = x[ComputationKindTransferDictionaryValue-1041]
 	_ = x[ComputationKindDestroyDictionaryValue-1042]
+	_ = x[ComputationKindDictionaryInsert-1043]
+	_ = x[ComputationKindDictionaryRemove-1044]
+	_ = x[ComputationKindDictionaryLookup-1045]
+	_ = x[ComputationKindStringConcat-1058]
+	_ = x[ComputationKindStringSlice-1059]
+	_ = x[ComputationKindBigIntegerOperation-1060]
 	_ = x[ComputationKindEncodeValue-1080]
 	_ = x[ComputationKindSTDLIBPanic-1100]
 	_ = x[ComputationKindSTDLIBAssert-1101]
@@ -33,20 +43,22 @@ const (
 	_ComputationKind_name_0 = "Unknown"
 	_ComputationKind_name_1 = "StatementLoopFunctionInvocation"
 	_ComputationKind_name_2 = "CreateCompositeValueTransferCompositeValueDestroyCompositeValue"
-	_ComputationKind_name_3 = "CreateArrayValueTransferArrayValueDestroyArrayValue"
-	_ComputationKind_name_4 = "CreateDictionaryValueTransferDictionaryValueDestroyDictionaryValue"
-	_ComputationKind_name_5 = "EncodeValue"
-	_ComputationKind_name_6 = "STDLIBPanicSTDLIBAssertSTDLIBRevertibleRandom"
-	_ComputationKind_name_7 = "STDLIBRLPDecodeStringSTDLIBRLPDecodeList"
+	_ComputationKind_name_3 = "CreateArrayValueTransferArrayValueDestroyArrayValueArrayAppendArrayInsertArrayRemoveArrayContains"
+	_ComputationKind_name_4 = "CreateDictionaryValueTransferDictionaryValueDestroyDictionaryValueDictionaryInsertDictionaryRemoveDictionaryLookup"
+	_ComputationKind_name_5 = "StringConcatStringSliceBigIntegerOperation"
+	_ComputationKind_name_6 = "EncodeValue"
+	_ComputationKind_name_7 = "STDLIBPanicSTDLIBAssertSTDLIBRevertibleRandom"
+	_ComputationKind_name_8 = "STDLIBRLPDecodeStringSTDLIBRLPDecodeList"
 )
 
 var (
 	_ComputationKind_index_1 = [...]uint8{0, 9, 13, 31}
 	_ComputationKind_index_2 = [...]uint8{0, 20, 42, 63}
-	_ComputationKind_index_3 = [...]uint8{0, 16, 34, 51}
-	_ComputationKind_index_4 = [...]uint8{0, 21, 44, 66}
-	_ComputationKind_index_6 = [...]uint8{0, 11, 23, 45}
-	_ComputationKind_index_7 = [...]uint8{0, 21, 40}
+	_ComputationKind_index_3 = [...]uint8{0, 16, 34, 51, 62, 73, 84, 97}
+	_ComputationKind_index_4 = [...]uint8{0, 21, 44, 66, 82, 98, 114}
+	_ComputationKind_index_5 = [...]uint8{0, 12, 23, 42}
+	_ComputationKind_index_7 = [...]uint8{0, 11, 23, 45}
+	_ComputationKind_index_8 = [...]uint8{0, 21, 40}
 )
 
 func (i ComputationKind) String() string {
@@ -59,20 +71,23 @@ func (i ComputationKind) String() string {
 	case 1010 <= i && i <= 1012:
 		i -= 1010
 		return _ComputationKind_name_2[_ComputationKind_index_2[i]:_ComputationKind_index_2[i+1]]
-	case 1025 <= i && i <= 1027:
+	case 1025 <= i && i <= 1031:
 		i -= 1025
 		return _ComputationKind_name_3[_ComputationKind_index_3[i]:_ComputationKind_index_3[i+1]]
-	case 1040 <= i && i <= 1042:
+	case 1040 <= i && i <= 1045:
 		i -= 1040
 		return _ComputationKind_name_4[_ComputationKind_index_4[i]:_ComputationKind_index_4[i+1]]
+	case 1058 <= i && i <= 1060:
+		i -= 1058
+		return _ComputationKind_name_5[_ComputationKind_index_5[i]:_ComputationKind_index_5[i+1]]
 	case i == 1080:
-		return _ComputationKind_name_5
+		return _ComputationKind_name_6
 	case 1100 <= i && i <= 1102:
 		i -= 1100
-		return _ComputationKind_name_6[_ComputationKind_index_6[i]:_ComputationKind_index_6[i+1]]
+		return _ComputationKind_name_7[_ComputationKind_index_7[i]:_ComputationKind_index_7[i+1]]
 	case 1108 <= i && i <= 1109:
 		i -= 1108
-		return _ComputationKind_name_7[_ComputationKind_index_7[i]:_ComputationKind_index_7[i+1]]
+		return _ComputationKind_name_8[_ComputationKind_index_8[i]:_ComputationKind_index_8[i+1]]
 	default:
 		return "ComputationKind(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -0,0 +1,214 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package diagnostics provides machine-readable representations of checker errors and warnings,
+// e.g. for CI systems and code scanning platforms
+package diagnostics
+
+import (
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/cadence/sema"
+)
+
+// SyntaxErrorCode is the code of all parser errors
+const SyntaxErrorCode sema.ErrorCode = "syntax-error"
+
+// Severity is the severity of a diagnostic
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+	SeverityHint    Severity = "hint"
+)
+
+// Position is a position in the source code.
+// Lines are 1-based, columns are 0-based
+type Position struct {
+	Offset int `json:"offset"`
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+func newPosition(position ast.Position) Position {
+	return Position{
+		Offset: position.Offset,
+		Line:   position.Line,
+		Column: position.Column,
+	}
+}
+
+// Range is a range in the source code.
+// The end position is inclusive
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// TextEdit is an edit of the source code.
+// Either the text in the range is replaced with the replacement,
+// or the insertion is inserted at the start of the range
+type TextEdit struct {
+	Replacement string `json:"replacement,omitempty"`
+	Insertion   string `json:"insertion,omitempty"`
+	Range       Range  `json:"range"`
+}
+
+// SuggestedFix is a fix for a diagnostic
+type SuggestedFix struct {
+	Message   string     `json:"message"`
+	TextEdits []TextEdit `json:"textEdits"`
+}
+
+// Diagnostic is a checker error or warning
+type Diagnostic struct {
+	Code             sema.ErrorCode `json:"code"`
+	Severity         Severity       `json:"severity"`
+	Message          string         `json:"message"`
+	SecondaryMessage string         `json:"secondaryMessage,omitempty"`
+	Location         string         `json:"location,omitempty"`
+	Range            *Range         `json:"range,omitempty"`
+	SuggestedFixes   []SuggestedFix `json:"suggestedFixes,omitempty"`
+}
+
+// FromError returns the diagnostics for the given error, e.g. a checker or parser error.
+// Parent errors are flattened, i.e. a diagnostic is returned for each child error
+func FromError(
+	err error,
+	location common.Location,
+	codes map[common.Location][]byte,
+) []Diagnostic {
+	var diagnostics []Diagnostic
+	collect(&diagnostics, err, location, codes, SeverityError)
+	return diagnostics
+}
+
+// FromWarnings returns the diagnostics for the given checker warnings
+func FromWarnings(
+	warnings []error,
+	location common.Location,
+	codes map[common.Location][]byte,
+) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, warning := range warnings {
+		collect(&diagnostics, warning, location, codes, SeverityWarning)
+	}
+	return diagnostics
+}
+
+func collect(
+	diagnostics *[]Diagnostic,
+	err error,
+	location common.Location,
+	codes map[common.Location][]byte,
+	severity Severity,
+) {
+	if err == nil {
+		return
+	}
+
+	if err, ok := err.(common.HasLocation); ok {
+		importLocation := err.ImportLocation()
+		if importLocation != nil {
+			location = importLocation
+		}
+	}
+
+	if err, ok := err.(errors.ParentError); ok {
+		for _, childErr := range err.ChildErrors() {
+			collect(diagnostics, childErr, location, codes, severity)
+		}
+		return
+	}
+
+	*diagnostics = append(
+		*diagnostics,
+		newDiagnostic(err, location, codes[location], severity),
+	)
+}
+
+func newDiagnostic(
+	err error,
+	location common.Location,
+	code []byte,
+	severity Severity,
+) Diagnostic {
+
+	diagnostic := Diagnostic{
+		Code:     errorCode(err),
+		Severity: severity,
+		Message:  err.Error(),
+	}
+
+	if lintDiagnostic, ok := err.(*sema.LintDiagnostic); ok {
+		diagnostic.Severity = Severity(lintDiagnostic.Severity.String())
+	}
+
+	if location != nil {
+		diagnostic.Location = location.String()
+	}
+
+	if secondaryError, ok := err.(errors.SecondaryError); ok {
+		diagnostic.SecondaryMessage = secondaryError.SecondaryError()
+	}
+
+	if positioned, ok := err.(ast.HasPosition); ok {
+		diagnostic.Range = &Range{
+			Start: newPosition(positioned.StartPosition()),
+			End:   newPosition(positioned.EndPosition(nil)),
+		}
+	}
+
+	if fixable, ok := err.(errors.HasSuggestedFixes[ast.TextEdit]); ok {
+		for _, fix := range fixable.SuggestFixes(string(code)) {
+			textEdits := make([]TextEdit, 0, len(fix.TextEdits))
+			for _, textEdit := range fix.TextEdits {
+				textEdits = append(textEdits, TextEdit{
+					Replacement: textEdit.Replacement,
+					Insertion:   textEdit.Insertion,
+					Range: Range{
+						Start: newPosition(textEdit.StartPos),
+						End:   newPosition(textEdit.EndPos),
+					},
+				})
+			}
+
+			diagnostic.SuggestedFixes = append(
+				diagnostic.SuggestedFixes,
+				SuggestedFix{
+					Message:   fix.Message,
+					TextEdits: textEdits,
+				},
+			)
+		}
+	}
+
+	return diagnostic
+}
+
+func errorCode(err error) sema.ErrorCode {
+	if _, ok := err.(parser.ParseError); ok {
+		return SyntaxErrorCode
+	}
+
+	return sema.ErrorCodeOf(err)
+}
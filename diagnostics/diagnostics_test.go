@@ -0,0 +1,252 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package diagnostics
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/cadence/sema"
+)
+
+const testLocation = common.StringLocation("test.cdc")
+
+func checkDiagnostics(t *testing.T, code string) []Diagnostic {
+
+	codes := map[common.Location][]byte{
+		testLocation: []byte(code),
+	}
+
+	program, err := parser.ParseProgram(nil, []byte(code), parser.Config{})
+	if err != nil {
+		return FromError(err, testLocation, codes)
+	}
+
+	checker, err := sema.NewChecker(
+		program,
+		testLocation,
+		nil,
+		&sema.Config{
+			AccessCheckMode:               sema.AccessCheckModeNotSpecifiedUnrestricted,
+			ViewAnnotationWarningsEnabled: true,
+		},
+	)
+	require.NoError(t, err)
+
+	err = checker.Check()
+
+	return append(
+		FromError(err, testLocation, codes),
+		FromWarnings(checker.Warnings(), testLocation, codes)...,
+	)
+}
+
+func TestFromError(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("checker errors and warnings", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := checkDiagnostics(t, `
+          fun test(): Int {
+              return x
+          }
+        `)
+
+		assert.Equal(t,
+			[]Diagnostic{
+				{
+					Code:             "not-declared",
+					Severity:         SeverityError,
+					Message:          "cannot find variable in this scope: `x`",
+					SecondaryMessage: "not found in this scope",
+					Location:         "test.cdc",
+					Range: &Range{
+						Start: Position{Offset: 50, Line: 3, Column: 21},
+						End:   Position{Offset: 50, Line: 3, Column: 21},
+					},
+				},
+			},
+			diagnostics,
+		)
+	})
+
+	t.Run("warning with suggested fix", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := checkDiagnostics(t, `
+          fun test(): Int {
+              return 1
+          }
+        `)
+
+		require.Len(t, diagnostics, 1)
+		diagnostic := diagnostics[0]
+
+		assert.Equal(t, sema.ErrorCodeMissingViewAnnotation, diagnostic.Code)
+		assert.Equal(t, SeverityWarning, diagnostic.Severity)
+		assert.Equal(t,
+			[]SuggestedFix{
+				{
+					Message: "annotate function with `view`",
+					TextEdits: []TextEdit{
+						{
+							Insertion: "view ",
+							Range: Range{
+								Start: Position{Offset: 11, Line: 2, Column: 10},
+								End:   Position{Offset: 11, Line: 2, Column: 10},
+							},
+						},
+					},
+				},
+			},
+			diagnostic.SuggestedFixes,
+		)
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := checkDiagnostics(t, `let x = `)
+
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, SyntaxErrorCode, diagnostics[0].Code)
+		assert.Equal(t, SeverityError, diagnostics[0].Severity)
+		assert.Equal(t, "test.cdc", diagnostics[0].Location)
+	})
+
+	t.Run("no errors", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, FromError(nil, testLocation, nil))
+	})
+}
+
+func TestWriteJSON(t *testing.T) {
+
+	t.Parallel()
+
+	var buffer bytes.Buffer
+	err := WriteJSON(&buffer, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, buffer.String())
+
+	buffer.Reset()
+	err = WriteJSON(&buffer, []Diagnostic{
+		{
+			Code:     "not-declared",
+			Severity: SeverityError,
+			Message:  "cannot find variable in this scope: `x`",
+			Location: "test.cdc",
+			Range: &Range{
+				Start: Position{Offset: 1, Line: 1, Column: 1},
+				End:   Position{Offset: 2, Line: 1, Column: 2},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.JSONEq(t,
+		`
+          [
+            {
+              "code": "not-declared",
+              "severity": "error",
+              "message": "cannot find variable in this scope: `+"`x`"+`",
+              "location": "test.cdc",
+              "range": {
+                "start": {"offset": 1, "line": 1, "column": 1},
+                "end": {"offset": 2, "line": 1, "column": 2}
+              }
+            }
+          ]
+        `,
+		buffer.String(),
+	)
+}
+
+func TestWriteSARIF(t *testing.T) {
+
+	t.Parallel()
+
+	diagnostics := checkDiagnostics(t, `
+      fun test(): Int {
+          return x
+      }
+    `)
+
+	var buffer bytes.Buffer
+	err := WriteSARIF(&buffer, "v1.0.0", diagnostics)
+	require.NoError(t, err)
+
+	assert.JSONEq(t,
+		`
+          {
+            "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+            "version": "2.1.0",
+            "runs": [
+              {
+                "tool": {
+                  "driver": {
+                    "name": "cadence",
+                    "version": "v1.0.0",
+                    "rules": [{"id": "not-declared"}]
+                  }
+                },
+                "columnKind": "unicodeCodePoints",
+                "results": [
+                  {
+                    "ruleId": "not-declared",
+                    "level": "error",
+                    "message": {
+                      "text": "cannot find variable in this scope: `+"`x`"+`: not found in this scope"
+                    },
+                    "locations": [
+                      {
+                        "physicalLocation": {
+                          "artifactLocation": {"uri": "test.cdc"},
+                          "region": {
+                            "startLine": 3,
+                            "startColumn": 18,
+                            "endLine": 3,
+                            "endColumn": 19
+                          }
+                        }
+                      }
+                    ]
+                  }
+                ]
+              }
+            ]
+          }
+        `,
+		buffer.String(),
+	)
+
+	// The output must be valid JSON
+	var log map[string]any
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &log))
+}
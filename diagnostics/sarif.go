@@ -0,0 +1,190 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package diagnostics
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/onflow/cadence/sema"
+)
+
+// SARIF 2.1.0, see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+
+const (
+	sarifSchema   = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion  = "2.1.0"
+	sarifToolName = "cadence"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind"`
+	Results    []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifRegion is a region in an artifact.
+// Lines and columns are 1-based, and the end column is exclusive
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func sarifRuleID(code sema.ErrorCode) string {
+	if code == sema.ErrorCodeUnknown {
+		return "unknown"
+	}
+	return string(code)
+}
+
+// WriteSARIF writes the given diagnostics as a SARIF 2.1.0 log,
+// reported by the Cadence tool with the given version
+func WriteSARIF(writer io.Writer, toolVersion string, diagnostics []Diagnostic) error {
+
+	ruleIDs := map[string]struct{}{}
+	results := make([]sarifResult, 0, len(diagnostics))
+
+	for _, diagnostic := range diagnostics {
+		ruleID := sarifRuleID(diagnostic.Code)
+		ruleIDs[ruleID] = struct{}{}
+
+		message := diagnostic.Message
+		if diagnostic.SecondaryMessage != "" {
+			message += ": " + diagnostic.SecondaryMessage
+		}
+
+		result := sarifResult{
+			RuleID:  ruleID,
+			Level:   sarifLevel(diagnostic.Severity),
+			Message: sarifMessage{Text: message},
+		}
+
+		if diagnostic.Location != "" {
+			physicalLocation := sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{
+					URI: diagnostic.Location,
+				},
+			}
+
+			if diagnostic.Range != nil {
+				physicalLocation.Region = &sarifRegion{
+					StartLine:   diagnostic.Range.Start.Line,
+					StartColumn: diagnostic.Range.Start.Column + 1,
+					EndLine:     diagnostic.Range.End.Line,
+					EndColumn:   diagnostic.Range.End.Column + 2,
+				}
+			}
+
+			result.Locations = []sarifLocation{
+				{PhysicalLocation: physicalLocation},
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	rules := make([]sarifRule, 0, len(ruleIDs))
+	for ruleID := range ruleIDs { //nolint:maprange
+		rules = append(rules, sarifRule{ID: ruleID})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:    sarifToolName,
+						Version: toolVersion,
+						Rules:   rules,
+					},
+				},
+				// Cadence positions count columns in code points
+				ColumnKind: "unicodeCodePoints",
+				Results:    results,
+			},
+		},
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
@@ -19,10 +19,9 @@
 package errors
 
 import (
+	goErrors "errors"
 	"fmt"
 	"runtime/debug"
-
-	"golang.org/x/xerrors"
 )
 
 // NewUnreachableError creates an internal error that indicates executing an unimplemented path.
@@ -73,8 +72,8 @@ type ExternalNonError struct {
 	Recovered any
 }
 
-func NewExternalNonError(recovered error) ExternalError {
-	return ExternalError{
+func NewExternalNonError(recovered any) ExternalNonError {
+	return ExternalNonError{
 		Recovered: recovered,
 	}
 }
@@ -218,37 +217,84 @@ func (e DefaultUserError) Error() string {
 // IsInternalError Checks whether a given error was caused by an InternalError.
 // An error in an internal error, if it has at-least one InternalError in the error chain.
 func IsInternalError(err error) bool {
-	switch err := err.(type) {
-	case InternalError:
-		return true
-	case xerrors.Wrapper:
-		return IsInternalError(err.Unwrap())
-	default:
-		return false
-	}
+	var internalError InternalError
+	return goErrors.As(err, &internalError)
 }
 
 // IsUserError Checks whether a given error was caused by an UserError.
 // An error in a user error, if it has at-least one UserError in the error chain.
+//
+// NOTE: An error may be both a user error and an internal error,
+// e.g. if a user error wraps an internal error. Use Classify to determine the kind of an error
 func IsUserError(err error) bool {
-	switch err := err.(type) {
-	case UserError:
+	var userError UserError
+	return goErrors.As(err, &userError)
+}
+
+// IsExternalError Checks whether a given error was caused externally,
+// i.e. if it has at-least one ExternalError or ExternalNonError in the error chain.
+func IsExternalError(err error) bool {
+	if _, ok := GetExternalError(err); ok {
 		return true
-	case xerrors.Wrapper:
-		return IsUserError(err.Unwrap())
-	default:
-		return false
 	}
+	var externalNonError ExternalNonError
+	return goErrors.As(err, &externalNonError)
 }
 
 // GetExternalError returns the ExternalError in the error chain, if any
 func GetExternalError(err error) (ExternalError, bool) {
-	switch err := err.(type) {
-	case ExternalError:
-		return err, true
-	case xerrors.Wrapper:
-		return GetExternalError(err.Unwrap())
+	var externalError ExternalError
+	if goErrors.As(err, &externalError) {
+		return externalError, true
+	}
+	return ExternalError{}, false
+}
+
+// ErrorKind is the kind of error, see Classify
+type ErrorKind uint8
+
+const (
+	// ErrorKindUnknown is the kind of error which is neither an internal, external, nor a user error,
+	// e.g. a Go runtime error. It should be treated like an internal error
+	ErrorKindUnknown ErrorKind = iota
+	// ErrorKindInternal is the kind of error caused by an implementation error, see InternalError
+	ErrorKindInternal
+	// ErrorKindExternal is the kind of error which occurred externally, e.g. in the embedder,
+	// see ExternalError and ExternalNonError
+	ErrorKindExternal
+	// ErrorKindUser is the kind of error caused by the user, e.g. the program, see UserError
+	ErrorKindUser
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindUnknown:
+		return "unknown"
+	case ErrorKindInternal:
+		return "internal"
+	case ErrorKindExternal:
+		return "external"
+	case ErrorKindUser:
+		return "user"
+	}
+
+	panic(NewUnreachableError())
+}
+
+// Classify returns the kind of the given error,
+// so embedders can decide how to handle it, e.g. whether to charge for it, retry, or report it.
+//
+// If the error chain contains errors of multiple kinds,
+// internal errors take precedence over external errors, which take precedence over user errors.
+func Classify(err error) ErrorKind {
+	switch {
+	case IsInternalError(err):
+		return ErrorKindInternal
+	case IsExternalError(err):
+		return ErrorKindExternal
+	case IsUserError(err):
+		return ErrorKindUser
 	default:
-		return ExternalError{}, false
+		return ErrorKindUnknown
 	}
 }
@@ -7,6 +7,7 @@ require (
 	github.com/c-bata/go-prompt v0.2.6
 	github.com/dave/dst v0.27.2
 	github.com/fxamacker/cbor/v2 v2.4.1-0.20230228173756-c0c9f774e40c
+	github.com/fxamacker/circlehash v0.3.0
 	github.com/itchyny/gojq v0.12.14
 	github.com/k0kubun/pp/v3 v3.2.0
 	github.com/kr/pretty v0.3.1
@@ -34,11 +35,11 @@ require (
 	github.com/kodova/html-to-markdown v1.0.1
 	github.com/onflow/crypto v0.25.0
 	golang.org/x/exp v0.0.0-20240103183307-be819d1f06fc
+	golang.org/x/sync v0.8.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/fxamacker/circlehash v0.3.0 // indirect
 	github.com/itchyny/timefmt-go v0.1.5 // indirect
 	github.com/k0kubun/colorstring v0.0.0-20150214042306-9440f1994b88 // indirect
 	github.com/klauspost/cpuid/v2 v2.2.0 // indirect
@@ -56,7 +57,6 @@ require (
 	github.com/zeebo/assert v1.3.0 // indirect
 	github.com/zeebo/blake3 v0.2.4 // indirect
 	golang.org/x/lint v0.0.0-20200302205851-738671d3881b // indirect
-	golang.org/x/sync v0.8.0 // indirect
 	golang.org/x/sys v0.26.0 // indirect
 	golang.org/x/term v0.25.0 // indirect
 	gonum.org/v1/gonum v0.6.1 // indirect
@@ -20,6 +20,7 @@ package interpreter_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/onflow/atree"
@@ -105,9 +106,10 @@ type testAccountHandler struct {
 		*stdlib.AccountKey,
 		error,
 	)
-	revokeAccountKey       func(address common.Address, index uint32) (*stdlib.AccountKey, error)
-	getAccountContractCode func(location common.AddressLocation) ([]byte, error)
-	parseAndCheckProgram   func(
+	revokeAccountKey               func(address common.Address, index uint32) (*stdlib.AccountKey, error)
+	getAccountContractCode         func(location common.AddressLocation) ([]byte, error)
+	getAccountContractUpdateHeight func(location common.AddressLocation) (uint64, bool, error)
+	parseAndCheckProgram           func(
 		code []byte,
 		location common.Location,
 		getAndSetProgram bool,
@@ -291,6 +293,13 @@ func (t *testAccountHandler) GetAccountContractCode(location common.AddressLocat
 	return t.getAccountContractCode(location)
 }
 
+func (t *testAccountHandler) GetAccountContractUpdateHeight(location common.AddressLocation) (uint64, bool, error) {
+	if t.getAccountContractUpdateHeight == nil {
+		panic(errors.NewUnexpectedError("unexpected call to GetAccountContractUpdateHeight"))
+	}
+	return t.getAccountContractUpdateHeight(location)
+}
+
 func (t *testAccountHandler) ParseAndCheckProgram(
 	code []byte,
 	location common.Location,
@@ -387,6 +396,10 @@ func (t *testAccountHandler) IsContractBeingAdded(common.AddressLocation) bool {
 	return false
 }
 
+func (t *testAccountHandler) ContractMetadataEventsEnabled() bool {
+	return false
+}
+
 func testAccountWithErrorHandler(
 	t *testing.T,
 	address interpreter.AddressValue,
@@ -668,6 +681,85 @@ func TestInterpretAccountStorageType(t *testing.T) {
 	})
 }
 
+func TestInterpretAccountStorageSize(t *testing.T) {
+
+	t.Parallel()
+
+	address := interpreter.NewUnmeteredAddressValueFromBytes([]byte{42})
+
+	inter, _ := testAccount(t, address, true, nil, `
+          fun save(_ value: AnyStruct, _ path: StoragePath) {
+              account.storage.save(value, to: path)
+          }
+
+          fun sizeAt(_ path: StoragePath): UInt64? {
+              return account.storage.size(at: path)
+          }
+        `, sema.Config{})
+
+	size := func(t *testing.T, path string) interpreter.Value {
+		value, err := inter.Invoke(
+			"sizeAt",
+			interpreter.NewUnmeteredPathValue(common.PathDomainStorage, path),
+		)
+		require.NoError(t, err)
+		return value
+	}
+
+	save := func(t *testing.T, value interpreter.Value, path string) {
+		_, err := inter.Invoke(
+			"save",
+			value,
+			interpreter.NewUnmeteredPathValue(common.PathDomainStorage, path),
+		)
+		require.NoError(t, err)
+	}
+
+	requireSize := func(t *testing.T, path string) uint64 {
+		value := size(t, path)
+		require.IsType(t, &interpreter.SomeValue{}, value)
+
+		inner := value.(*interpreter.SomeValue).InnerValue(inter, interpreter.EmptyLocationRange)
+		require.IsType(t, interpreter.UInt64Value(0), inner)
+
+		return uint64(inner.(interpreter.UInt64Value))
+	}
+
+	// nothing stored
+
+	require.Equal(t, interpreter.Nil, size(t, "missing"))
+
+	// sizes grow with the stored value
+
+	save(t, interpreter.NewUnmeteredStringValue("a"), "short")
+	save(t, interpreter.NewUnmeteredStringValue(strings.Repeat("a", 100)), "long")
+
+	shortSize := requireSize(t, "short")
+	longSize := requireSize(t, "long")
+
+	assert.Greater(t, shortSize, uint64(0))
+	assert.Greater(t, longSize, shortSize+uint64(90))
+
+	// container
+
+	save(
+		t,
+		interpreter.NewArrayValue(
+			inter,
+			interpreter.EmptyLocationRange,
+			&interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeInt,
+			},
+			common.ZeroAddress,
+			interpreter.NewUnmeteredIntValueFromInt64(1),
+			interpreter.NewUnmeteredIntValueFromInt64(2),
+		),
+		"array",
+	)
+
+	assert.Greater(t, requireSize(t, "array"), uint64(0))
+}
+
 func TestInterpretAccountStorageLoad(t *testing.T) {
 
 	t.Parallel()
@@ -1381,3 +1473,87 @@ func TestInterpretAccountStorageFields(t *testing.T) {
 		}
 	}
 }
+
+func TestInterpretAccountStorageReferenceProvenance(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      resource R {
+          let foo: Int
+
+          init() {
+              self.foo = 42
+          }
+      }
+
+      fun test(): Int {
+          account.storage.save(<-create R(), to: /storage/r)
+          let ref = account.storage.borrow<&R>(from: /storage/r)!
+          destroy account.storage.load<@R>(from: /storage/r)
+          return ref.foo
+      }
+    `
+
+	address := interpreter.NewUnmeteredAddressValueFromBytes([]byte{42})
+
+	t.Run("tracing enabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, _ := testAccount(t, address, true, nil, code, sema.Config{})
+		inter.SharedState.Config.ReferenceTracingEnabled = true
+
+		_, err := inter.Invoke("test")
+		RequireError(t, err)
+
+		var dereferenceErr interpreter.DereferenceError
+		require.ErrorAs(t, err, &dereferenceErr)
+
+		provenance := dereferenceErr.Provenance
+		require.NotNil(t, provenance)
+
+		assert.Equal(t, address.ToAddress(), provenance.Address)
+		assert.Equal(t,
+			interpreter.NewUnmeteredPathValue(common.PathDomainStorage, "r"),
+			provenance.Path,
+		)
+		assert.Equal(t, "R", provenance.BorrowType.QualifiedString())
+
+		// The reference is created in function test,
+		// which is called by the host
+
+		trace := provenance.Trace
+		require.NotNil(t, trace)
+		require.Len(t, trace.Creation, 2)
+		assert.Equal(t, 12, trace.Creation[1].StartPosition().Line)
+
+		assert.Contains(t,
+			dereferenceErr.SecondaryError(),
+			"reference to `/storage/r` in account 0x000000000000002a, borrowed as `R`",
+		)
+		assert.Contains(t, dereferenceErr.SecondaryError(), "reference created")
+	})
+
+	t.Run("tracing disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, _ := testAccount(t, address, true, nil, code, sema.Config{})
+
+		_, err := inter.Invoke("test")
+		RequireError(t, err)
+
+		var dereferenceErr interpreter.DereferenceError
+		require.ErrorAs(t, err, &dereferenceErr)
+
+		require.NotNil(t, dereferenceErr.Provenance)
+		assert.Nil(t, dereferenceErr.Provenance.Trace)
+
+		assert.Equal(t,
+			"no value is stored at this path\n"+
+				"reference to `/storage/r` in account 0x000000000000002a, borrowed as `R`",
+			dereferenceErr.SecondaryError(),
+		)
+	})
+}
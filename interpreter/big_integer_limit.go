@@ -0,0 +1,78 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/onflow/cadence/common"
+)
+
+// checkBigIntegerOperands checks that the operands of an operation on arbitrary-precision integers (Int and UInt)
+// do not exceed the size limit, see Config.BigIntegerBitLengthLimit.
+//
+// It also reports the additional computation of operations on operands which are larger than a single word,
+// which is proportional to the size of the operands.
+// Operations on operands which fit into a single word are not reported
+func (interpreter *Interpreter) checkBigIntegerOperands(left, right *big.Int, locationRange LocationRange) {
+	leftBitLength := uint64(left.BitLen())
+	rightBitLength := uint64(right.BitLen())
+
+	interpreter.checkBigIntegerBitLength(leftBitLength, locationRange)
+	interpreter.checkBigIntegerBitLength(rightBitLength, locationRange)
+
+	wordLength := max(len(left.Bits()), len(right.Bits()))
+	if wordLength > 1 {
+		interpreter.ReportComputation(
+			common.ComputationKindBigIntegerOperation,
+			uint(wordLength-1),
+		)
+	}
+}
+
+// checkBigIntegerLeftShift checks that the result of shifting the given arbitrary-precision integer
+// by the given number of bits does not exceed the size limit, see Config.BigIntegerBitLengthLimit
+func (interpreter *Interpreter) checkBigIntegerLeftShift(value *big.Int, shift uint64, locationRange LocationRange) {
+	if value.Sign() == 0 {
+		return
+	}
+
+	bitLength := uint64(value.BitLen())
+	if shift > math.MaxUint64-bitLength {
+		bitLength = math.MaxUint64
+	} else {
+		bitLength += shift
+	}
+
+	interpreter.checkBigIntegerBitLength(bitLength, locationRange)
+}
+
+func (interpreter *Interpreter) checkBigIntegerBitLength(bitLength uint64, locationRange LocationRange) {
+	limit := interpreter.SharedState.Config.BigIntegerBitLengthLimit
+	if limit == 0 || bitLength <= limit {
+		return
+	}
+
+	panic(BigIntegerSizeLimitExceededError{
+		BitLength:     bitLength,
+		Limit:         limit,
+		LocationRange: locationRange,
+	})
+}
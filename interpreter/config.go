@@ -29,6 +29,10 @@ type Config struct {
 	ImportLocationHandler ImportLocationHandlerFunc
 	// OnInvokedFunctionReturn is triggered when an invoked function returned
 	OnInvokedFunctionReturn OnInvokedFunctionReturnFunc
+	// OnInterpretedFunctionInvoked is triggered after an interpreted function was invoked
+	OnInterpretedFunctionInvoked OnInterpretedFunctionInvokedFunc
+	// OnConditionEvaluated is triggered after a pre- or post-condition was evaluated
+	OnConditionEvaluated OnConditionEvaluatedFunc
 	// OnRecordTrace is triggered when a trace is recorded
 	OnRecordTrace OnRecordTraceFunc
 	// OnResourceOwnerChange is triggered when the owner of a resource changes
@@ -55,6 +59,8 @@ type Config struct {
 	CompositeValueFunctionsHandler CompositeValueFunctionsHandlerFunc
 	BaseActivationHandler          func(location common.Location) *VariableActivation
 	Debugger                       *Debugger
+	// Profiler is used to profile the execution, see Profiler
+	Profiler *Profiler
 	// OnStatement is triggered when a statement is about to be executed
 	OnStatement OnStatementFunc
 	// OnLoopIteration is triggered when a loop iteration is about to be executed
@@ -62,6 +68,50 @@ type Config struct {
 	// TracingEnabled determines if tracing is enabled.
 	// Tracing reports certain operations, e.g. composite value transfers
 	TracingEnabled bool
+	// ReferenceTracingEnabled determines if reference tracing is enabled.
+	// Reference tracing records where ephemeral references to resources are created and invalidated,
+	// and reports this information when an invalidated reference is used.
+	// This is a debugging aid, it is expensive and should not be enabled in production
+	ReferenceTracingEnabled bool
+	// SubtypeCheckCacheSize is the maximum number of results of subtype checks (see Interpreter.IsSubType)
+	// which are cached for the duration of the execution.
+	// When 0 (the default), results are not cached
+	SubtypeCheckCacheSize int
+	// StoredValueSizeLimit is the maximum encoded size in bytes of a single value stored in an account,
+	// e.g. a string. Arrays, dictionaries, and composites are stored element-wise,
+	// so the limit applies to each of their non-container elements.
+	// When 0 (the default), the size of stored values is not limited
+	StoredValueSizeLimit uint64
+	// BigIntegerBitLengthLimit is the maximum length in bits of the operands of arithmetic operations
+	// on arbitrary-precision integers (Int and UInt), and of the results of left shifts.
+	// When 0 (the default), the size of arbitrary-precision integers is not limited
+	BigIntegerBitLengthLimit uint64
+	// CallStackDepthLimit is the maximum depth of the call stack,
+	// i.e. the maximum number of nested invocations of interpreted functions.
+	// When 0 (the default), the depth of the call stack is not limited
+	CallStackDepthLimit uint64
+	// CompositeValueCreationLimit is the maximum number of composite values
+	// which may be created during the execution, including copies of existing values.
+	// Values are counted when they are created, and not when they are destroyed or no longer used,
+	// so the limit applies to the total number of created values, not to the number of live values.
+	// When 0 (the default), the number of created composite values is not limited
+	CompositeValueCreationLimit uint64
+	// ArrayValueCreationLimit is the maximum number of array values
+	// which may be created during the execution, including copies of existing values.
+	// Values are counted when they are created, and not when they are destroyed or no longer used,
+	// so the limit applies to the total number of created values, not to the number of live values.
+	// When 0 (the default), the number of created array values is not limited
+	ArrayValueCreationLimit uint64
+	// DictionaryValueCreationLimit is the maximum number of dictionary values
+	// which may be created during the execution, including copies of existing values.
+	// Values are counted when they are created, and not when they are destroyed or no longer used,
+	// so the limit applies to the total number of created values, not to the number of live values.
+	// When 0 (the default), the number of created dictionary values is not limited
+	DictionaryValueCreationLimit uint64
+	// ReadOnly determines if the execution is read-only.
+	// When enabled, writes to account storage, event emission, and account mutations
+	// fail with a ReadOnlyViolationError
+	ReadOnly bool
 	// AtreeStorageValidationEnabled determines if the validation of atree storage is enabled
 	AtreeStorageValidationEnabled bool
 	// AtreeValueValidationEnabled determines if the validation of atree values is enabled
@@ -70,6 +120,12 @@ type Config struct {
 	CapabilityCheckHandler CapabilityCheckHandlerFunc
 	// CapabilityBorrowHandler is used to borrow ID capabilities
 	CapabilityBorrowHandler CapabilityBorrowHandlerFunc
+	// CopyOnWriteArgumentsEnabled determines if the copies of arrays and dictionaries
+	// which are passed as arguments are deferred until they are mutated (copy-on-write).
+	// Deferring the copies changes when storage is allocated and when memory is metered,
+	// which e.g. affects the iteration order of dictionaries,
+	// so it must only be enabled together with other changes to execution
+	CopyOnWriteArgumentsEnabled bool
 	// LegacyContractUpgradeEnabled specifies whether to fall back to the old parser when attempting a contract upgrade
 	LegacyContractUpgradeEnabled bool
 	// ValidateAccountCapabilitiesGetHandler is used to handle when a capability of an account is got.
@@ -0,0 +1,172 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/sema"
+)
+
+// Copy-on-write
+//
+// Transferring an array or dictionary copies the atree container backing it.
+// When enabled, see Config.CopyOnWriteArgumentsEnabled,
+// and a variable is passed as an argument to a function, the copy is deferred: the parameter value shares the atree container with the argument value,
+// until one of the values sharing the container is mutated.
+// The mutated value then copies the container before the mutation is performed.
+//
+// Sharing is only safe if all mutations of the container go through the values sharing it.
+// This is the case if:
+//   - The value is not resource-kinded (resources are moved, not copied),
+//   - The value is not stored in an account, i.e. it is a temporary value, and
+//   - The value is the value of a variable, i.e. it is not a nested value of another container,
+//     which could be accessed and mutated through other Go instances of the value, and
+//   - The elements of the container cannot be containers themselves,
+//     which could be mutated through the shared container.
+
+// copyOnWriteShare is shared by all values which share the same atree container
+type copyOnWriteShare struct {
+	// holders is the number of values which share the atree container
+	holders int
+}
+
+// copyOnWrite tracks if the atree container backing a value is shared with other values
+type copyOnWrite struct {
+	share *copyOnWriteShare
+	// iterations is the number of active iterations over the value.
+	// It is tracked per value, as values sharing a container also share its value ID,
+	// so the value ID cannot determine which of the values is iterated over
+	iterations int
+}
+
+// isShared returns true if the atree container is shared with other values,
+// i.e. if it must be copied before it is mutated
+func (c *copyOnWrite) isShared() bool {
+	return c.share != nil && c.share.holders > 1
+}
+
+// shareWith records that the atree container is shared with the other value
+func (c *copyOnWrite) shareWith(other *copyOnWrite) {
+	share := c.share
+	if share == nil {
+		share = &copyOnWriteShare{
+			holders: 1,
+		}
+		c.share = share
+	}
+	share.holders++
+	other.share = share
+}
+
+// unshare records that the atree container is no longer shared with the other values,
+// as it was copied
+func (c *copyOnWrite) unshare() {
+	if c.share == nil {
+		return
+	}
+	c.share.holders--
+	c.share = nil
+}
+
+// checkNotIterated checks that the value is not iterated over,
+// before the shared container is copied and the copy is mutated
+func (c *copyOnWrite) checkNotIterated(locationRange LocationRange) {
+	if c.iterations == 0 {
+		return
+	}
+	panic(ContainerMutatedDuringIterationError{
+		LocationRange: locationRange,
+	})
+}
+
+func (c *copyOnWrite) withIteration(f func()) {
+	c.iterations++
+	defer func() {
+		c.iterations--
+	}()
+
+	f()
+}
+
+// isCopyOnWriteElementType returns true if values of the given static type
+// can never be containers, i.e. if they can be shared safely
+func isCopyOnWriteElementType(staticType StaticType) bool {
+	switch staticType := staticType.(type) {
+	case PrimitiveStaticType:
+		switch staticType {
+		case PrimitiveStaticTypeBool,
+			PrimitiveStaticTypeString,
+			PrimitiveStaticTypeCharacter,
+			PrimitiveStaticTypeAddress,
+			PrimitiveStaticTypePath,
+			PrimitiveStaticTypeStoragePath,
+			PrimitiveStaticTypeCapabilityPath,
+			PrimitiveStaticTypePublicPath,
+			PrimitiveStaticTypePrivatePath:
+			return true
+		}
+		return staticType.IsDefined() &&
+			!staticType.IsDeprecated() &&
+			sema.IsSubType(staticType.SemaType(), sema.NumberType)
+
+	case *OptionalStaticType:
+		return isCopyOnWriteElementType(staticType.Type)
+	}
+
+	return false
+}
+
+// transferArgument transfers the given argument of an invocation.
+//
+// If enabled, see Config.CopyOnWriteArgumentsEnabled,
+// arrays and dictionaries which are the values of variables are not copied,
+// but share the atree container with the parameter value, until either is mutated (copy-on-write)
+func (interpreter *Interpreter) transferArgument(
+	argument Value,
+	expression ast.Expression,
+	locationRange LocationRange,
+) Value {
+	if _, ok := expression.(*ast.IdentifierExpression); ok &&
+		interpreter.SharedState.Config.CopyOnWriteArgumentsEnabled {
+
+		switch argument := argument.(type) {
+		case *ArrayValue:
+			if argument.canShareCopyOnWrite(interpreter) {
+				return argument.shareCopyOnWrite(interpreter)
+			}
+
+		case *DictionaryValue:
+			if argument.canShareCopyOnWrite(interpreter) {
+				return argument.shareCopyOnWrite(interpreter)
+			}
+		}
+	}
+
+	return argument.Transfer(
+		interpreter,
+		locationRange,
+		atree.Address{},
+		false,
+		nil,
+		nil,
+		true, // argument is standalone.
+	)
+}
@@ -19,31 +19,86 @@
 package interpreter
 
 import (
+	"fmt"
+	goRuntime "runtime"
 	"sync/atomic"
 
 	"github.com/bits-and-blooms/bitset"
 
+	"github.com/onflow/cadence/activations"
 	"github.com/onflow/cadence/ast"
 	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/cadence/sema"
 )
 
 type Stop struct {
 	Interpreter *Interpreter
 	Statement   ast.Statement
+	// Watchpoint is the watchpoint which triggered the stop, if any
+	Watchpoint Watchpoint
+	// ConditionError is the error that occurred when evaluating
+	// the condition of the breakpoint which triggered the stop, if any
+	ConditionError error
+}
+
+// Watchpoint is a watchpoint of the debugger,
+// see VariableWatchpoint and StoragePathWatchpoint
+type Watchpoint interface {
+	isWatchpoint()
+	String() string
+}
+
+// VariableWatchpoint triggers a stop when a variable with the given name
+// is assigned in a program with the given location
+type VariableWatchpoint struct {
+	Location common.Location
+	Name     string
+}
+
+var _ Watchpoint = VariableWatchpoint{}
+
+func (VariableWatchpoint) isWatchpoint() {}
+
+func (w VariableWatchpoint) String() string {
+	return fmt.Sprintf("variable %s in %s", w.Name, w.Location)
+}
+
+// StoragePathWatchpoint triggers a stop when the value stored
+// at the given path in the account with the given address is written,
+// e.g. by saving a value to or loading a value from the path
+type StoragePathWatchpoint struct {
+	Address common.Address
+	Path    PathValue
+}
+
+var _ Watchpoint = StoragePathWatchpoint{}
+
+func (StoragePathWatchpoint) isWatchpoint() {}
+
+func (w StoragePathWatchpoint) String() string {
+	return fmt.Sprintf("path %s in account %s", w.Path, w.Address)
 }
 
 type Debugger struct {
-	stops          chan Stop
-	continues      chan struct{}
-	breakpoints    map[common.Location]*bitset.BitSet
-	pauseRequested uint32
+	stops                  chan Stop
+	continues              chan struct{}
+	breakpoints            map[common.Location]*bitset.BitSet
+	breakpointConditions   map[common.Location]map[uint]ast.Expression
+	variableWatchpoints    map[VariableWatchpoint]struct{}
+	storagePathWatchpoints map[StoragePathWatchpoint]struct{}
+	pauseRequested         uint32
+	evaluatingCondition    bool
 }
 
 func NewDebugger() *Debugger {
 	return &Debugger{
-		stops:       make(chan Stop),
-		continues:   make(chan struct{}),
-		breakpoints: map[common.Location]*bitset.BitSet{},
+		stops:                  make(chan Stop),
+		continues:              make(chan struct{}),
+		breakpoints:            map[common.Location]*bitset.BitSet{},
+		breakpointConditions:   map[common.Location]map[uint]ast.Expression{},
+		variableWatchpoints:    map[VariableWatchpoint]struct{}{},
+		storagePathWatchpoints: map[StoragePathWatchpoint]struct{}{},
 	}
 }
 
@@ -58,9 +113,45 @@ func (d *Debugger) AddBreakpoint(location common.Location, line uint) {
 		d.breakpoints[location] = breakpoints
 	}
 	breakpoints.Set(line)
+
+	d.removeBreakpointCondition(location, line)
+}
+
+// AddConditionalBreakpoint adds a breakpoint which only triggers a stop
+// if the given condition evaluates to true.
+//
+// The condition is a Cadence expression of type Bool.
+// It is checked and evaluated in the scope of the statement at the breakpoint,
+// i.e. it may refer to the variables which are in scope at the statement.
+// If checking or evaluating the condition fails, the breakpoint triggers a stop,
+// and the error is reported in the stop.
+// The condition should not have side effects.
+func (d *Debugger) AddConditionalBreakpoint(location common.Location, line uint, condition string) error {
+	code := []byte(condition)
+
+	expression, errs := parser.ParseExpression(nil, code, parser.Config{})
+	if len(errs) > 0 {
+		return parser.Error{
+			Code:   code,
+			Errors: errs,
+		}
+	}
+
+	d.AddBreakpoint(location, line)
+
+	conditions, ok := d.breakpointConditions[location]
+	if !ok {
+		conditions = map[uint]ast.Expression{}
+		d.breakpointConditions[location] = conditions
+	}
+	conditions[line] = expression
+
+	return nil
 }
 
 func (d *Debugger) RemoveBreakpoint(location common.Location, line uint) {
+	d.removeBreakpointCondition(location, line)
+
 	breakpoints, ok := d.breakpoints[location]
 	if !ok {
 		return
@@ -68,37 +159,273 @@ func (d *Debugger) RemoveBreakpoint(location common.Location, line uint) {
 	breakpoints.Clear(line)
 }
 
+func (d *Debugger) removeBreakpointCondition(location common.Location, line uint) {
+	conditions, ok := d.breakpointConditions[location]
+	if !ok {
+		return
+	}
+	delete(conditions, line)
+}
+
 func (d *Debugger) ClearBreakpoints() {
 	for location := range d.breakpoints { //nolint:maprange
 		delete(d.breakpoints, location)
 	}
+	for location := range d.breakpointConditions { //nolint:maprange
+		delete(d.breakpointConditions, location)
+	}
 }
 
 func (d *Debugger) ClearBreakpointsForLocation(location common.Location) {
 	delete(d.breakpoints, location)
+	delete(d.breakpointConditions, location)
+}
+
+// AddVariableWatchpoint adds a watchpoint which triggers a stop
+// after a variable with the given name is assigned in the program with the given location
+func (d *Debugger) AddVariableWatchpoint(location common.Location, name string) {
+	d.variableWatchpoints[VariableWatchpoint{
+		Location: location,
+		Name:     name,
+	}] = struct{}{}
+}
+
+func (d *Debugger) RemoveVariableWatchpoint(location common.Location, name string) {
+	delete(d.variableWatchpoints, VariableWatchpoint{
+		Location: location,
+		Name:     name,
+	})
+}
+
+// AddStoragePathWatchpoint adds a watchpoint which triggers a stop
+// after the value stored at the given path in the account with the given address is written
+func (d *Debugger) AddStoragePathWatchpoint(address common.Address, path PathValue) {
+	d.storagePathWatchpoints[StoragePathWatchpoint{
+		Address: address,
+		Path:    path,
+	}] = struct{}{}
+}
+
+func (d *Debugger) RemoveStoragePathWatchpoint(address common.Address, path PathValue) {
+	delete(d.storagePathWatchpoints, StoragePathWatchpoint{
+		Address: address,
+		Path:    path,
+	})
+}
+
+func (d *Debugger) ClearWatchpoints() {
+	for watchpoint := range d.variableWatchpoints { //nolint:maprange
+		delete(d.variableWatchpoints, watchpoint)
+	}
+	for watchpoint := range d.storagePathWatchpoints { //nolint:maprange
+		delete(d.storagePathWatchpoints, watchpoint)
+	}
 }
 
 func (d *Debugger) onStatement(interpreter *Interpreter, statement ast.Statement) {
+	if d.evaluatingCondition {
+		return
+	}
+
+	var conditionErr error
+
 	if !atomic.CompareAndSwapUint32(&d.pauseRequested, 1, 0) {
 		breakpoints, ok := d.breakpoints[interpreter.Location]
 		if !ok {
 			return
 		}
 
-		startPosition := statement.StartPosition()
-		if !breakpoints.Test(uint(startPosition.Line)) {
+		line := uint(statement.StartPosition().Line)
+		if !breakpoints.Test(line) {
 			return
 		}
+
+		condition, ok := d.breakpointConditions[interpreter.Location][line]
+		if ok {
+			var result bool
+			result, conditionErr = d.evaluateCondition(interpreter, condition)
+			if conditionErr == nil && !result {
+				return
+			}
+		}
 	}
 
+	d.stops <- Stop{
+		Interpreter:    interpreter,
+		Statement:      statement,
+		ConditionError: conditionErr,
+	}
+
+	<-d.continues
+}
+
+func (d *Debugger) onVariableWrite(interpreter *Interpreter, name string) {
+	if d.evaluatingCondition || len(d.variableWatchpoints) == 0 {
+		return
+	}
+
+	watchpoint := VariableWatchpoint{
+		Location: interpreter.Location,
+		Name:     name,
+	}
+	if _, ok := d.variableWatchpoints[watchpoint]; !ok {
+		return
+	}
+
+	d.stopAtWatchpoint(interpreter, watchpoint)
+}
+
+func (d *Debugger) onStorageWrite(
+	interpreter *Interpreter,
+	address common.Address,
+	domain common.StorageDomain,
+	key StorageMapKey,
+) {
+	if d.evaluatingCondition || len(d.storagePathWatchpoints) == 0 {
+		return
+	}
+
+	identifier, ok := key.(StringStorageMapKey)
+	if !ok {
+		return
+	}
+
+	for watchpoint := range d.storagePathWatchpoints { //nolint:maprange
+		if watchpoint.Address == address &&
+			watchpoint.Path.Domain.StorageDomain() == domain &&
+			watchpoint.Path.Identifier == string(identifier) {
+
+			d.stopAtWatchpoint(interpreter, watchpoint)
+			return
+		}
+	}
+}
+
+func (d *Debugger) stopAtWatchpoint(interpreter *Interpreter, watchpoint Watchpoint) {
+	// A pending pause request is fulfilled by this stop
+	atomic.StoreUint32(&d.pauseRequested, 0)
+
 	d.stops <- Stop{
 		Interpreter: interpreter,
-		Statement:   statement,
+		Statement:   interpreter.statement,
+		Watchpoint:  watchpoint,
 	}
 
 	<-d.continues
 }
 
+// evaluateCondition checks and evaluates the given breakpoint condition
+// in the scope of the current activation of the given interpreter
+func (d *Debugger) evaluateCondition(
+	interpreter *Interpreter,
+	condition ast.Expression,
+) (
+	result bool,
+	err error,
+) {
+	d.evaluatingCondition = true
+
+	defer func() {
+		d.evaluatingCondition = false
+
+		if r := recover(); r != nil {
+			switch r := r.(type) {
+			case goRuntime.Error:
+				panic(r)
+			case error:
+				err = r
+			default:
+				err = fmt.Errorf("%s", r)
+			}
+		}
+	}()
+
+	current := interpreter.activations.Current()
+
+	// Declare the variables which are referenced in the condition,
+	// with the types of their current values
+
+	values := sema.NewVariableActivation(nil)
+
+	ast.Inspect(condition, func(element ast.Element) bool {
+		identifierExpression, ok := element.(*ast.IdentifierExpression)
+		if !ok {
+			return true
+		}
+
+		name := identifierExpression.Identifier.Identifier
+		if values.Find(name) != nil {
+			return true
+		}
+
+		variable := current.Find(name)
+		if variable == nil {
+			return true
+		}
+
+		value := variable.GetValue(interpreter)
+		staticType := value.StaticType(interpreter)
+
+		values.Set(name, &sema.Variable{
+			Identifier:      name,
+			DeclarationKind: common.DeclarationKindConstant,
+			Type:            interpreter.MustConvertStaticToSemaType(staticType),
+			Access:          sema.PrimitiveAccess(ast.AccessAll),
+			IsConstant:      true,
+		})
+
+		return true
+	})
+
+	program := &ast.Program{}
+
+	checker, err := sema.NewChecker(
+		program,
+		interpreter.Location,
+		nil,
+		&sema.Config{
+			AccessCheckMode: sema.AccessCheckModeNone,
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = checker.CheckExpressionInScope(
+		condition,
+		&sema.Scope{
+			Values: values,
+		},
+		sema.BoolType,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	// Evaluate the condition in a new activation on top of the current activation.
+	// The evaluating interpreter is not registered in the shared state,
+	// so it does not replace the interpreter of the program with the same location
+
+	conditionInterpreter := &Interpreter{
+		Program: &Program{
+			Program:     program,
+			Elaboration: checker.Elaboration,
+		},
+		Location:    interpreter.Location,
+		SharedState: interpreter.SharedState,
+		statement:   interpreter.statement,
+	}
+	conditionInterpreter.activations = activations.NewActivations[Variable](conditionInterpreter)
+	conditionInterpreter.activations.PushNewWithParent(current)
+
+	value, ok := conditionInterpreter.evalExpression(condition).(BoolValue)
+	if !ok {
+		return false, fmt.Errorf("condition is not a boolean")
+	}
+
+	return bool(value), nil
+}
+
 func (d *Debugger) RequestPause() {
 	atomic.StoreUint32(&d.pauseRequested, 1)
 }
@@ -119,5 +446,5 @@ func (d *Debugger) Next() Stop {
 }
 
 func (d *Debugger) CurrentActivation(interpreter *Interpreter) *VariableActivation {
-	return interpreter.activations.Current()
+	return interpreter.CurrentActivation()
 }
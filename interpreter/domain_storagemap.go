@@ -20,6 +20,9 @@ package interpreter
 
 import (
 	goerrors "errors"
+	"math"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/onflow/atree"
@@ -142,6 +145,62 @@ func (s *DomainStorageMap) ReadValue(gauge common.MemoryGauge, key StorageMapKey
 	return MustConvertStoredValue(gauge, storedValue)
 }
 
+// ReadValueSize returns the approximate size, in bytes, of the value for the given key,
+// without loading the whole value.
+// For values stored in their own slabs, only the size of the root slab is returned.
+// Returns false if the key does not exist.
+func (s *DomainStorageMap) ReadValueSize(key StorageMapKey) (size uint64, exists bool) {
+	storedValue, err := s.orderedMap.Get(
+		key.AtreeValueCompare,
+		key.AtreeValueHashInput,
+		key.AtreeValue(),
+	)
+	if err != nil {
+		var keyNotFoundError *atree.KeyNotFoundError
+		if goerrors.As(err, &keyNotFoundError) {
+			return 0, false
+		}
+		panic(errors.NewExternalError(err))
+	}
+
+	return storedValueSize(s.orderedMap.Storage, s.orderedMap.Address(), storedValue), true
+}
+
+func storedValueSize(storage atree.SlabStorage, address atree.Address, value atree.Value) uint64 {
+	var slabID atree.SlabID
+
+	switch value := value.(type) {
+	case *atree.Array:
+		if !value.Inlined() {
+			slabID = value.SlabID()
+		}
+
+	case *atree.OrderedMap:
+		if !value.Inlined() {
+			slabID = value.SlabID()
+		}
+	}
+
+	if slabID != atree.SlabIDUndefined {
+		slab, found, err := storage.Retrieve(slabID)
+		if err != nil {
+			panic(errors.NewExternalError(err))
+		}
+		if !found {
+			panic(errors.NewUnexpectedError("missing slab %s", slabID))
+		}
+		return uint64(slab.ByteSize())
+	}
+
+	// The value is inlined: Determine the size of its storable,
+	// without allowing it to be moved into a separate slab
+	storable, err := value.Storable(storage, address, math.MaxUint64)
+	if err != nil {
+		panic(errors.NewExternalError(err))
+	}
+	return uint64(storable.ByteSize())
+}
+
 // WriteValue sets or removes a value in the storage map.
 // If the given value is nil, the key is removed.
 // If the given value is non-nil, the key is added/updated.
@@ -357,3 +416,68 @@ func (i DomainStorageMapIterator) NextValue() Value {
 
 	return MustConvertStoredValue(i.gauge, v)
 }
+
+// DomainStorageMapKeyRange selects the keys of a domain storage map for a scan.
+type DomainStorageMapKeyRange struct {
+	// Prefix restricts the scan to string keys which start with the given prefix.
+	// Integer keys have no prefix, so they are excluded if a prefix is given.
+	Prefix string
+	// After restricts the scan to keys which are ordered after the given key, if any.
+	// The last key of a page can be used to scan the next page.
+	After StorageMapKey
+	// Limit is the maximum number of keys to return.
+	// Zero means all keys in the range are returned.
+	Limit int
+}
+
+// ScanKeys returns the keys of the storage map in the given range,
+// in the order defined by CompareStorageMapKeys,
+// and true if there are further keys in the range after the returned keys.
+//
+// NOTE: The underlying map is ordered by the hashes of the keys, not by the keys,
+// so a scan visits all keys of the map, but only reads and sorts the keys in the range,
+// and does not load any values.
+func (s *DomainStorageMap) ScanKeys(keyRange DomainStorageMapKeyRange) (keys []StorageMapKey, more bool) {
+	if keyRange.Limit < 0 {
+		panic(errors.NewUnexpectedError("invalid domain storage map scan limit: %d", keyRange.Limit))
+	}
+
+	err := s.orderedMap.IterateReadOnlyKeys(
+		func(k atree.Value) (resume bool, err error) {
+			key := StorageMapKeyFromAtreeValue(k)
+
+			if keyRange.Prefix != "" {
+				stringKey, ok := key.(StringStorageMapKey)
+				if !ok || !strings.HasPrefix(string(stringKey), keyRange.Prefix) {
+					return true, nil
+				}
+			}
+
+			if keyRange.After != nil && CompareStorageMapKeys(key, keyRange.After) <= 0 {
+				return true, nil
+			}
+
+			keys = append(keys, key)
+
+			return true, nil
+		},
+	)
+	if err != nil {
+		panic(errors.NewExternalError(err))
+	}
+
+	slices.SortFunc(keys, CompareStorageMapKeys)
+
+	if keyRange.Limit > 0 && len(keys) > keyRange.Limit {
+		return keys[:keyRange.Limit], true
+	}
+
+	return keys, false
+}
+
+// OrderedKeys returns all keys of the storage map,
+// in the order defined by CompareStorageMapKeys.
+func (s *DomainStorageMap) OrderedKeys() []StorageMapKey {
+	keys, _ := s.ScanKeys(DomainStorageMapKeyRange{})
+	return keys
+}
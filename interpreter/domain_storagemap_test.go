@@ -19,6 +19,7 @@
 package interpreter_test
 
 import (
+	"fmt"
 	"math/rand"
 	"strconv"
 	"testing"
@@ -693,6 +694,179 @@ func TestDomainStorageMapIteratorNextValue(t *testing.T) {
 	})
 }
 
+func TestDomainStorageMapScanKeys(t *testing.T) {
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	newDomainStorageMap := func(t *testing.T, keys ...interpreter.StorageMapKey) *interpreter.DomainStorageMap {
+		ledger := NewTestLedger(nil, nil)
+		storage := runtime.NewStorage(
+			ledger,
+			nil,
+			runtime.StorageConfig{},
+		)
+
+		// Turn off AtreeStorageValidationEnabled, see TestDomainStorageMapValueExists
+		const atreeValueValidationEnabled = true
+		const atreeStorageValidationEnabled = false
+		inter := NewTestInterpreterWithStorageAndAtreeValidationConfig(
+			t,
+			storage,
+			atreeValueValidationEnabled,
+			atreeStorageValidationEnabled,
+		)
+
+		domainStorageMap := interpreter.NewDomainStorageMap(nil, storage, atree.Address(address))
+
+		for i, key := range keys {
+			domainStorageMap.WriteValue(
+				inter,
+				key,
+				interpreter.NewUnmeteredIntValueFromInt64(int64(i)),
+			)
+		}
+
+		return domainStorageMap
+	}
+
+	stringKeys := func(keys ...string) []interpreter.StorageMapKey {
+		result := make([]interpreter.StorageMapKey, 0, len(keys))
+		for _, key := range keys {
+			result = append(result, interpreter.StringStorageMapKey(key))
+		}
+		return result
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		domainStorageMap := newDomainStorageMap(t)
+
+		keys, more := domainStorageMap.ScanKeys(interpreter.DomainStorageMapKeyRange{})
+		require.Empty(t, keys)
+		require.False(t, more)
+	})
+
+	t.Run("ordered", func(t *testing.T) {
+		t.Parallel()
+
+		domainStorageMap := newDomainStorageMap(
+			t,
+			stringKeys("vault", "b", "collection", "a", "vaultB", "")...,
+		)
+
+		require.Equal(t,
+			stringKeys("", "a", "b", "collection", "vault", "vaultB"),
+			domainStorageMap.OrderedKeys(),
+		)
+	})
+
+	t.Run("integer keys", func(t *testing.T) {
+		t.Parallel()
+
+		domainStorageMap := newDomainStorageMap(
+			t,
+			interpreter.Uint64StorageMapKey(10),
+			interpreter.Uint64StorageMapKey(9),
+			interpreter.Uint64StorageMapKey(100),
+			interpreter.Uint64StorageMapKey(1),
+		)
+
+		require.Equal(t,
+			[]interpreter.StorageMapKey{
+				interpreter.Uint64StorageMapKey(1),
+				interpreter.Uint64StorageMapKey(9),
+				interpreter.Uint64StorageMapKey(10),
+				interpreter.Uint64StorageMapKey(100),
+			},
+			domainStorageMap.OrderedKeys(),
+		)
+
+		// Integer keys have no prefix
+
+		keys, more := domainStorageMap.ScanKeys(interpreter.DomainStorageMapKeyRange{
+			Prefix: "1",
+		})
+		require.Empty(t, keys)
+		require.False(t, more)
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		t.Parallel()
+
+		domainStorageMap := newDomainStorageMap(
+			t,
+			stringKeys("vault", "b", "collection", "vaultB", "vaultA", "v")...,
+		)
+
+		keys, more := domainStorageMap.ScanKeys(interpreter.DomainStorageMapKeyRange{
+			Prefix: "vault",
+		})
+		require.Equal(t, stringKeys("vault", "vaultA", "vaultB"), keys)
+		require.False(t, more)
+
+		keys, more = domainStorageMap.ScanKeys(interpreter.DomainStorageMapKeyRange{
+			Prefix: "x",
+		})
+		require.Empty(t, keys)
+		require.False(t, more)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		t.Parallel()
+
+		random := rand.New(rand.NewSource(42))
+
+		const count = 100
+
+		expectedKeys := make([]interpreter.StorageMapKey, 0, count)
+		for i := range count {
+			expectedKeys = append(
+				expectedKeys,
+				interpreter.StringStorageMapKey(fmt.Sprintf("key%03d", i)),
+			)
+		}
+
+		// Insert keys in random order, and include some keys without the prefix
+
+		insertedKeys := append(
+			stringKeys("a", "z", "ke"),
+			expectedKeys...,
+		)
+		random.Shuffle(len(insertedKeys), func(i, j int) {
+			insertedKeys[i], insertedKeys[j] = insertedKeys[j], insertedKeys[i]
+		})
+
+		domainStorageMap := newDomainStorageMap(t, insertedKeys...)
+
+		const pageSize = 7
+
+		var scannedKeys []interpreter.StorageMapKey
+		var after interpreter.StorageMapKey
+
+		for {
+			keys, more := domainStorageMap.ScanKeys(interpreter.DomainStorageMapKeyRange{
+				Prefix: "key",
+				After:  after,
+				Limit:  pageSize,
+			})
+			require.LessOrEqual(t, len(keys), pageSize)
+
+			scannedKeys = append(scannedKeys, keys...)
+
+			if !more {
+				break
+			}
+
+			require.Len(t, keys, pageSize)
+			after = keys[len(keys)-1]
+		}
+
+		require.Equal(t, expectedKeys, scannedKeys)
+	})
+}
+
 func TestDomainStorageMapLoadFromRootSlabID(t *testing.T) {
 	t.Parallel()
 
@@ -73,6 +73,12 @@ func (e Error) Error() string {
 	return sb.String()
 }
 
+// CallStack returns the frames of the call stack at the point the error occurred,
+// from the outermost to the innermost invocation
+func (e Error) CallStack() []CallStackFrame {
+	return newCallStackFrames(e.StackTrace)
+}
+
 func (e Error) ChildErrors() []error {
 	errs := make([]error, 0, 1+len(e.StackTrace))
 
@@ -244,6 +250,8 @@ type DereferenceError struct {
 	Cause        string
 	ExpectedType sema.Type
 	ActualType   sema.Type
+	// Provenance describes the dereferenced storage reference, if any
+	Provenance *StorageReferenceProvenance
 	LocationRange
 }
 
@@ -257,19 +265,27 @@ func (e DereferenceError) Error() string {
 }
 
 func (e DereferenceError) SecondaryError() string {
+	var message string
 	if e.Cause != "" {
-		return e.Cause
+		message = e.Cause
+	} else {
+		expected, actual := sema.ErrorMessageExpectedActualTypes(
+			e.ExpectedType,
+			e.ActualType,
+		)
+
+		message = fmt.Sprintf(
+			"type mismatch: expected `%s`, got `%s`",
+			expected,
+			actual,
+		)
 	}
-	expected, actual := sema.ErrorMessageExpectedActualTypes(
-		e.ExpectedType,
-		e.ActualType,
-	)
 
-	return fmt.Sprintf(
-		"type mismatch: expected `%s`, got `%s`",
-		expected,
-		actual,
-	)
+	if e.Provenance != nil {
+		message += "\n" + e.Provenance.String()
+	}
+
+	return message
 }
 
 // OverflowError
@@ -739,6 +755,44 @@ func (e ContainerMutationError) Error() string {
 	)
 }
 
+// StoredValueSizeLimitExceededError
+type StoredValueSizeLimitExceededError struct {
+	Size  uint64
+	Limit uint64
+	LocationRange
+}
+
+var _ errors.UserError = StoredValueSizeLimitExceededError{}
+
+func (StoredValueSizeLimitExceededError) IsUserError() {}
+
+func (e StoredValueSizeLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"stored value size limit exceeded: %d bytes, limit is %d bytes",
+		e.Size,
+		e.Limit,
+	)
+}
+
+// BigIntegerSizeLimitExceededError
+type BigIntegerSizeLimitExceededError struct {
+	BitLength uint64
+	Limit     uint64
+	LocationRange
+}
+
+var _ errors.UserError = BigIntegerSizeLimitExceededError{}
+
+func (BigIntegerSizeLimitExceededError) IsUserError() {}
+
+func (e BigIntegerSizeLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"integer size limit exceeded: %d bits, limit is %d bits",
+		e.BitLength,
+		e.Limit,
+	)
+}
+
 // NonStorableValueError
 type NonStorableValueError struct {
 	Value Value
@@ -915,6 +969,10 @@ func (InvalidHexLengthError) Error() string {
 // InvalidatedResourceReferenceError is reported when accessing a reference value
 // that is pointing to a moved or destroyed resource.
 type InvalidatedResourceReferenceError struct {
+	// BorrowType is the type the reference was borrowed as, if known
+	BorrowType sema.Type
+	// Trace is the trace of the reference, if reference tracing is enabled
+	Trace *ReferenceTrace
 	LocationRange
 }
 
@@ -926,6 +984,28 @@ func (e InvalidatedResourceReferenceError) Error() string {
 	return "referenced resource has been moved or destroyed after taking the reference"
 }
 
+func (e InvalidatedResourceReferenceError) SecondaryError() string {
+	var builder strings.Builder
+
+	if e.BorrowType != nil {
+		builder.WriteString(
+			fmt.Sprintf(
+				"reference borrowed as `%s`",
+				e.BorrowType.QualifiedString(),
+			),
+		)
+	}
+
+	if e.Trace != nil {
+		if builder.Len() > 0 {
+			builder.WriteByte('\n')
+		}
+		builder.WriteString(e.Trace.String())
+	}
+
+	return builder.String()
+}
+
 // DuplicateAttachmentError
 type DuplicateAttachmentError struct {
 	AttachmentType sema.Type
@@ -1050,6 +1130,37 @@ func (e EntitledCapabilityPublishingError) Error() string {
 	)
 }
 
+// CapabilityBatchPublishingError is reported when one or more of multiple capabilities
+// cannot be published at once. None of the capabilities are published
+type CapabilityBatchPublishingError struct {
+	Errors []error
+	LocationRange
+}
+
+var _ errors.UserError = CapabilityBatchPublishingError{}
+var _ errors.ParentError = CapabilityBatchPublishingError{}
+
+func (CapabilityBatchPublishingError) IsUserError() {}
+
+func (e CapabilityBatchPublishingError) Error() string {
+	var builder strings.Builder
+	builder.WriteString(
+		fmt.Sprintf(
+			"cannot publish capabilities: %d of the publications are invalid",
+			len(e.Errors),
+		),
+	)
+	for _, err := range e.Errors {
+		builder.WriteString("\n  - ")
+		builder.WriteString(err.Error())
+	}
+	return builder.String()
+}
+
+func (e CapabilityBatchPublishingError) ChildErrors() []error {
+	return e.Errors
+}
+
 // NestedReferenceError
 type NestedReferenceError struct {
 	Value ReferenceValue
@@ -1174,3 +1285,59 @@ func (GetCapabilityError) IsUserError() {}
 func (e GetCapabilityError) Error() string {
 	return "cannot get capability"
 }
+
+// CallStackDepthLimitExceededError
+
+type CallStackDepthLimitExceededError struct {
+	Limit uint64
+	LocationRange
+}
+
+var _ errors.UserError = CallStackDepthLimitExceededError{}
+
+func (CallStackDepthLimitExceededError) IsUserError() {}
+
+func (e CallStackDepthLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"call stack depth limit exceeded: %d",
+		e.Limit,
+	)
+}
+
+// ValueCreationLimitExceededError
+
+type ValueCreationLimitExceededError struct {
+	Kind  CreatedValueKind
+	Limit uint64
+	LocationRange
+}
+
+var _ errors.UserError = ValueCreationLimitExceededError{}
+
+func (ValueCreationLimitExceededError) IsUserError() {}
+
+func (e ValueCreationLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"%s value creation limit exceeded: more than %d values created",
+		e.Kind,
+		e.Limit,
+	)
+}
+
+// ReadOnlyViolationError
+
+type ReadOnlyViolationError struct {
+	Operation ReadOnlyOperation
+	LocationRange
+}
+
+var _ errors.UserError = ReadOnlyViolationError{}
+
+func (ReadOnlyViolationError) IsUserError() {}
+
+func (e ReadOnlyViolationError) Error() string {
+	return fmt.Sprintf(
+		"cannot perform %s: execution is read-only",
+		e.Operation,
+	)
+}
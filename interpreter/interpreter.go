@@ -81,6 +81,24 @@ type OnFunctionInvocationFunc func(inter *Interpreter)
 // OnInvokedFunctionReturnFunc is a function that is triggered when an invoked function returned.
 type OnInvokedFunctionReturnFunc func(inter *Interpreter)
 
+// OnInterpretedFunctionInvokedFunc is a function that is triggered after an interpreted function was invoked,
+// successfully or not, with the duration of the invocation.
+type OnInterpretedFunctionInvokedFunc func(
+	inter *Interpreter,
+	function *InterpretedFunctionValue,
+	duration time.Duration,
+)
+
+// OnConditionEvaluatedFunc is a function that is triggered after a pre- or post-condition was evaluated,
+// with the result of the evaluation and its duration.
+type OnConditionEvaluatedFunc func(
+	inter *Interpreter,
+	condition ast.Condition,
+	kind ast.ConditionKind,
+	passed bool,
+	duration time.Duration,
+)
+
 // OnRecordTraceFunc is a function that records a trace.
 type OnRecordTraceFunc func(
 	inter *Interpreter,
@@ -598,6 +616,31 @@ func (interpreter *Interpreter) CallStack() []Invocation {
 	return interpreter.SharedState.callStack.Invocations[:]
 }
 
+// CaptureCallStack returns the frames of the current call stack,
+// from the outermost to the innermost invocation.
+// Invocations by the host, which have no location, are not included
+func (interpreter *Interpreter) CaptureCallStack() []CallStackFrame {
+	return newCallStackFrames(interpreter.CallStack())
+}
+
+func newCallStackFrames(invocations []Invocation) []CallStackFrame {
+	frames := make([]CallStackFrame, 0, len(invocations))
+	for _, invocation := range invocations {
+		locationRange := invocation.LocationRange
+		// Skip invocations by the host, e.g. Interpreter.Invoke
+		if locationRange.Location == nil {
+			continue
+		}
+		frames = append(frames, NewCallStackFrame(locationRange))
+	}
+	return frames
+}
+
+// CurrentActivation returns the activation of the innermost scope
+func (interpreter *Interpreter) CurrentActivation() *VariableActivation {
+	return interpreter.activations.Current()
+}
+
 func (interpreter *Interpreter) VisitProgram(program *ast.Program) {
 
 	for _, declaration := range program.ImportDeclarations() {
@@ -887,6 +930,23 @@ func (interpreter *Interpreter) visitConditions(conditions []ast.Condition, kind
 }
 
 func (interpreter *Interpreter) visitCondition(condition ast.Condition, kind ast.ConditionKind) {
+	onConditionEvaluated := interpreter.SharedState.Config.OnConditionEvaluated
+	if onConditionEvaluated == nil {
+		interpreter.evaluateCondition(condition, kind)
+		return
+	}
+
+	start := time.Now()
+	passed := false
+	defer func() {
+		onConditionEvaluated(interpreter, condition, kind, passed, time.Since(start))
+	}()
+
+	interpreter.evaluateCondition(condition, kind)
+	passed = true
+}
+
+func (interpreter *Interpreter) evaluateCondition(condition ast.Condition, kind ast.ConditionKind) {
 
 	switch condition := condition.(type) {
 	case *ast.TestCondition:
@@ -1878,6 +1938,22 @@ func (interpreter *Interpreter) transferAndConvert(
 		true, // value is standalone.
 	)
 
+	return interpreter.convertTransferred(
+		transferredValue,
+		valueType,
+		targetType,
+		locationRange,
+	)
+}
+
+// convertTransferred converts the given transferred value to the target type,
+// see transferAndConvert
+func (interpreter *Interpreter) convertTransferred(
+	transferredValue Value,
+	valueType, targetType sema.Type,
+	locationRange LocationRange,
+) Value {
+
 	targetType = interpreter.SubstituteMappedEntitlements(targetType)
 
 	result := interpreter.ConvertAndBox(
@@ -2160,8 +2236,9 @@ func (interpreter *Interpreter) convert(value Value, valueType, targetType sema.
 				panic(errors.NewExternalError(err))
 			}
 
-			return NewArrayValueWithIterator(
+			return newArrayValueWithIterator(
 				interpreter,
+				locationRange,
 				arrayStaticType,
 				arrayValue.GetOwner(),
 				array.Count(),
@@ -2208,6 +2285,7 @@ func (interpreter *Interpreter) convert(value Value, valueType, targetType sema.
 				dictionary.Count(),
 				dictionary.Seed(),
 				common.Address(dictionary.Address()),
+				atree.SlabIDUndefined,
 				func() (Value, Value) {
 					k, v, err := iterator.Next()
 
@@ -2273,13 +2351,15 @@ func (interpreter *Interpreter) convert(value Value, valueType, targetType sema.
 		case *StorageReferenceValue:
 			if interpreter.shouldConvertReference(ref, valueType, unwrappedTargetType, targetAuthorization) {
 				checkMappedEntitlements(unwrappedTargetType, locationRange)
-				return NewStorageReferenceValue(
+				reference := NewStorageReferenceValue(
 					interpreter,
 					targetAuthorization,
 					ref.TargetStorageAddress,
 					ref.TargetPath,
 					unwrappedTargetType.Type,
 				)
+				interpreter.copyReferenceTrace(ref, reference)
+				return reference
 			}
 
 		default:
@@ -2705,14 +2785,58 @@ func (interpreter *Interpreter) ReadStored(
 	return accountStorage.ReadValue(interpreter, identifier)
 }
 
+// ReadStoredSize returns the approximate size, in bytes, of the value stored under the given key,
+// without loading the whole value. Returns false if no value is stored
+func (interpreter *Interpreter) ReadStoredSize(
+	storageAddress common.Address,
+	domain common.StorageDomain,
+	identifier StorageMapKey,
+) (size uint64, exists bool) {
+	accountStorage := interpreter.Storage().GetDomainStorageMap(interpreter, storageAddress, domain, false)
+	if accountStorage == nil {
+		return 0, false
+	}
+	return accountStorage.ReadValueSize(identifier)
+}
+
 func (interpreter *Interpreter) WriteStored(
 	storageAddress common.Address,
 	domain common.StorageDomain,
 	key StorageMapKey,
 	value Value,
 ) (existed bool) {
+	return interpreter.WriteStoredWithLocationRange(
+		storageAddress,
+		domain,
+		key,
+		value,
+		LocationRange{
+			Location:    interpreter.Location,
+			HasPosition: ast.EmptyRange,
+		},
+	)
+}
+
+// WriteStoredWithLocationRange is like WriteStored,
+// but reports a violation of the read-only mode (see Config.ReadOnly) at the given location range
+func (interpreter *Interpreter) WriteStoredWithLocationRange(
+	storageAddress common.Address,
+	domain common.StorageDomain,
+	key StorageMapKey,
+	value Value,
+	locationRange LocationRange,
+) (existed bool) {
+	interpreter.CheckReadOnly(ReadOnlyOperationStorageWrite, locationRange)
+
 	accountStorage := interpreter.Storage().GetDomainStorageMap(interpreter, storageAddress, domain, true)
-	return accountStorage.WriteValue(interpreter, key, value)
+	existed = accountStorage.WriteValue(interpreter, key, value)
+
+	debugger := interpreter.SharedState.Config.Debugger
+	if debugger != nil {
+		debugger.onStorageWrite(interpreter, storageAddress, domain, key)
+	}
+
+	return existed
 }
 
 type fromStringFunctionValue struct {
@@ -3543,24 +3667,32 @@ func dictionaryTypeFunction(invocation Invocation) Value {
 		panic(errors.NewUnreachableError())
 	}
 
-	keyType := keyTypeValue.Type
-	valueType := valueTypeValue.Type
+	return newDictionaryTypeValue(
+		invocation.Interpreter,
+		keyTypeValue.Type,
+		valueTypeValue.Type,
+	)
+}
+
+// newDictionaryTypeValue returns the optional type value for the dictionary type
+// with the given key and value types, or nil if the key type is not a valid dictionary key type
+func newDictionaryTypeValue(interpreter *Interpreter, keyType, valueType StaticType) Value {
 
 	// if the given key is not a valid dictionary key, it wouldn't make sense to create this type
 	if keyType == nil ||
 		!sema.IsSubType(
-			invocation.Interpreter.MustConvertStaticToSemaType(keyType),
+			interpreter.MustConvertStaticToSemaType(keyType),
 			sema.HashableStructType,
 		) {
 		return Nil
 	}
 
 	return NewSomeValueNonCopying(
-		invocation.Interpreter,
+		interpreter,
 		NewTypeValue(
-			invocation.Interpreter,
+			interpreter,
 			NewDictionaryStaticType(
-				invocation.Interpreter,
+				interpreter,
 				keyType,
 				valueType,
 			),
@@ -3579,17 +3711,33 @@ func referenceTypeFunction(invocation Invocation) Value {
 		panic(errors.NewUnreachableError())
 	}
 
+	return newReferenceTypeValue(
+		invocation.Interpreter,
+		entitlementValues,
+		typeValue.Type,
+		invocation.LocationRange,
+	)
+}
+
+// newReferenceTypeValue returns the optional type value for the reference type
+// with the given entitlements and referenced type, or nil if any of the entitlements is invalid
+func newReferenceTypeValue(
+	interpreter *Interpreter,
+	entitlementValues *ArrayValue,
+	referencedType StaticType,
+	locationRange LocationRange,
+) Value {
 	authorization := UnauthorizedAccess
 	errInIteration := false
 	entitlementsCount := entitlementValues.Count()
 
 	if entitlementsCount > 0 {
 		authorization = NewEntitlementSetAuthorization(
-			invocation.Interpreter,
+			interpreter,
 			func() []common.TypeID {
 				entitlements := make([]common.TypeID, 0, entitlementsCount)
 				entitlementValues.Iterate(
-					invocation.Interpreter,
+					interpreter,
 					func(element Value) (resume bool) {
 						entitlementString, isString := element.(*StringValue)
 						if !isString {
@@ -3597,7 +3745,7 @@ func referenceTypeFunction(invocation Invocation) Value {
 							return false
 						}
 
-						_, err := lookupEntitlement(invocation.Interpreter, entitlementString.Str)
+						_, err := lookupEntitlement(interpreter, entitlementString.Str)
 						if err != nil {
 							errInIteration = true
 							return false
@@ -3607,7 +3755,7 @@ func referenceTypeFunction(invocation Invocation) Value {
 						return true
 					},
 					false,
-					invocation.LocationRange,
+					locationRange,
 				)
 				return entitlements
 			},
@@ -3621,13 +3769,13 @@ func referenceTypeFunction(invocation Invocation) Value {
 	}
 
 	return NewSomeValueNonCopying(
-		invocation.Interpreter,
+		interpreter,
 		NewTypeValue(
-			invocation.Interpreter,
+			interpreter,
 			NewReferenceStaticType(
-				invocation.Interpreter,
+				interpreter,
 				authorization,
-				typeValue.Type,
+				referencedType,
 			),
 		),
 	)
@@ -4041,6 +4189,10 @@ func (interpreter *Interpreter) IsSubType(subType StaticType, superType StaticTy
 		return true
 	}
 
+	return interpreter.isSubTypeCached(subType, superType)
+}
+
+func (interpreter *Interpreter) isSubType(subType StaticType, superType StaticType) bool {
 	semaType := interpreter.MustConvertStaticToSemaType(superType)
 
 	return interpreter.IsSubTypeOfSemaType(subType, semaType)
@@ -4351,6 +4503,8 @@ func (interpreter *Interpreter) authAccountSaveFunction(
 				)
 			}
 
+			interpreter.CheckStoredValueSize(address, value, locationRange)
+
 			value = value.Transfer(
 				interpreter,
 				locationRange,
@@ -4363,11 +4517,12 @@ func (interpreter *Interpreter) authAccountSaveFunction(
 
 			// Write new value
 
-			interpreter.WriteStored(
+			interpreter.WriteStoredWithLocationRange(
 				address,
 				domain,
 				storageMapKey,
 				value,
+				locationRange,
 			)
 
 			return Void
@@ -4417,6 +4572,49 @@ func (interpreter *Interpreter) authAccountTypeFunction(
 	)
 }
 
+func (interpreter *Interpreter) authAccountSizeFunction(
+	storageValue *SimpleCompositeValue,
+	addressValue AddressValue,
+) BoundFunctionValue {
+
+	// Converted addresses can be cached and don't have to be recomputed on each function invocation
+	address := addressValue.ToAddress()
+
+	return NewBoundHostFunctionValue(
+		interpreter,
+		storageValue,
+		sema.Account_StorageTypeSizeFunctionType,
+		func(_ *SimpleCompositeValue, invocation Invocation) Value {
+			interpreter := invocation.Interpreter
+
+			path, ok := invocation.Arguments[0].(PathValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			domain := path.Domain.StorageDomain()
+			identifier := path.Identifier
+
+			storageMapKey := StringStorageMapKey(identifier)
+
+			size, exists := interpreter.ReadStoredSize(address, domain, storageMapKey)
+			if !exists {
+				return Nil
+			}
+
+			return NewSomeValueNonCopying(
+				interpreter,
+				NewUInt64Value(
+					interpreter,
+					func() uint64 {
+						return size
+					},
+				),
+			)
+		},
+	)
+}
+
 func (interpreter *Interpreter) authAccountLoadFunction(
 	storageValue *SimpleCompositeValue,
 	addressValue AddressValue,
@@ -4504,11 +4702,12 @@ func (interpreter *Interpreter) authAccountReadFunction(
 			// Remove the value from storage,
 			// but only if the type check succeeded.
 			if clear {
-				interpreter.WriteStored(
+				interpreter.WriteStoredWithLocationRange(
 					address,
 					domain,
 					storageMapKey,
 					nil,
+					locationRange,
 				)
 			}
 
@@ -4569,6 +4768,8 @@ func (interpreter *Interpreter) authAccountBorrowFunction(
 				return Nil
 			}
 
+			interpreter.recordReferenceCreation(reference, invocation.LocationRange)
+
 			return NewSomeValueNonCopying(interpreter, reference)
 		},
 	)
@@ -4953,12 +5154,9 @@ func (interpreter *Interpreter) GetInterfaceType(
 }
 
 func (interpreter *Interpreter) reportLoopIteration(pos ast.HasPosition) {
-	config := interpreter.SharedState.Config
+	interpreter.ReportComputation(common.ComputationKindLoop, 1)
 
-	onMeterComputation := config.OnMeterComputation
-	if onMeterComputation != nil {
-		onMeterComputation(common.ComputationKindLoop, 1)
-	}
+	config := interpreter.SharedState.Config
 
 	onLoopIteration := config.OnLoopIteration
 	if onLoopIteration != nil {
@@ -4968,12 +5166,9 @@ func (interpreter *Interpreter) reportLoopIteration(pos ast.HasPosition) {
 }
 
 func (interpreter *Interpreter) reportFunctionInvocation() {
-	config := interpreter.SharedState.Config
+	interpreter.ReportComputation(common.ComputationKindFunctionInvocation, 1)
 
-	onMeterComputation := config.OnMeterComputation
-	if onMeterComputation != nil {
-		onMeterComputation(common.ComputationKindFunctionInvocation, 1)
-	}
+	config := interpreter.SharedState.Config
 
 	onFunctionInvocation := config.OnFunctionInvocation
 	if onFunctionInvocation != nil {
@@ -4999,6 +5194,11 @@ func (interpreter *Interpreter) ReportComputation(compKind common.ComputationKin
 	if onMeterComputation != nil {
 		onMeterComputation(compKind, intensity)
 	}
+
+	profiler := config.Profiler
+	if profiler != nil {
+		profiler.onComputation(interpreter, intensity)
+	}
 }
 
 func (interpreter *Interpreter) getAccessOfMember(self Value, identifier string) sema.Access {
@@ -5057,7 +5257,9 @@ func (interpreter *Interpreter) mapMemberValueAuthorization(
 		case *EphemeralReferenceValue:
 			return NewEphemeralReferenceValue(interpreter, auth, refValue.Value, refValue.BorrowedType, locationRange)
 		case *StorageReferenceValue:
-			return NewStorageReferenceValue(interpreter, auth, refValue.TargetStorageAddress, refValue.TargetPath, refValue.BorrowedType)
+			reference := NewStorageReferenceValue(interpreter, auth, refValue.TargetStorageAddress, refValue.TargetPath, refValue.BorrowedType)
+			interpreter.copyReferenceTrace(refValue, reference)
+			return reference
 		case BoundFunctionValue:
 			return NewBoundFunctionValueFromSelfReference(
 				interpreter,
@@ -5196,6 +5398,63 @@ func (interpreter *Interpreter) checkContainerMutation(
 	}
 }
 
+// CheckStoredValueSize checks that the given value, which is about to be stored in the given account,
+// does not exceed the stored value size limit (see Config.StoredValueSizeLimit).
+func (interpreter *Interpreter) CheckStoredValueSize(
+	address common.Address,
+	value Value,
+	locationRange LocationRange,
+) {
+	limit := interpreter.SharedState.Config.StoredValueSizeLimit
+	if limit == 0 || address == common.ZeroAddress {
+		return
+	}
+
+	interpreter.checkStoredValueSizeWithLimit(address, value, limit, locationRange)
+}
+
+func (interpreter *Interpreter) checkStoredValueSizeWithLimit(
+	address common.Address,
+	value Value,
+	limit uint64,
+	locationRange LocationRange,
+) {
+	switch value.(type) {
+	case *ArrayValue, *DictionaryValue, *CompositeValue, *SomeValue:
+		// Containers are stored element-wise, check their elements.
+		// NOTE: Do not get the storable of an atree container,
+		// as it may inline the container
+		value.Walk(
+			interpreter,
+			func(element Value) {
+				interpreter.checkStoredValueSizeWithLimit(address, element, limit, locationRange)
+			},
+			locationRange,
+		)
+		return
+	}
+
+	// Get the storable without a maximum inline size,
+	// so large values are not moved into a separate slab
+	storable, err := value.Storable(
+		interpreter.Storage(),
+		atree.Address(address),
+		math.MaxUint64,
+	)
+	if err != nil {
+		panic(errors.NewExternalError(err))
+	}
+
+	size := uint64(storable.ByteSize())
+	if size > limit {
+		panic(StoredValueSizeLimitExceededError{
+			Size:          size,
+			Limit:         limit,
+			LocationRange: locationRange,
+		})
+	}
+}
+
 func (interpreter *Interpreter) RemoveReferencedSlab(storable atree.Storable) {
 	slabIDStorable, ok := storable.(atree.SlabIDStorable)
 	if !ok {
@@ -5443,6 +5702,7 @@ func (interpreter *Interpreter) invalidateReferencedResources(
 
 	for value := range values { //nolint:maprange
 		value.Value = nil
+		interpreter.recordReferenceInvalidation(value, locationRange)
 	}
 
 	// The old resource instances are already cleared/invalidated above.
@@ -5681,7 +5941,7 @@ func (interpreter *Interpreter) validateMutation(valueID atree.ValueID, location
 	})
 }
 
-func (interpreter *Interpreter) withMutationPrevention(valueID atree.ValueID, f func()) {
+func (interpreter *Interpreter) WithMutationPrevention(valueID atree.ValueID, f func()) {
 	if interpreter == nil {
 		f()
 		return
@@ -82,6 +82,11 @@ func (interpreter *Interpreter) identifierExpressionGetterSetter(
 				locationRange,
 				value,
 			)
+
+			debugger := interpreter.SharedState.Config.Debugger
+			if debugger != nil {
+				debugger.onVariableWrite(interpreter, identifier)
+			}
 		},
 	}
 }
@@ -103,11 +108,11 @@ func (interpreter *Interpreter) typeIndexExpressionGetterSetter(
 	return getterSetter{
 		target: target,
 		get: func(_ bool) Value {
-			interpreter.checkInvalidatedResourceOrResourceReference(target, indexExpression)
+			interpreter.CheckInvalidatedResourceOrResourceReference(target, indexExpression)
 			return target.GetTypeKey(interpreter, locationRange, attachmentType)
 		},
 		set: func(_ Value) {
-			interpreter.checkInvalidatedResourceOrResourceReference(target, indexExpression)
+			interpreter.CheckInvalidatedResourceOrResourceReference(target, indexExpression)
 			// writing to composites with indexing syntax is not supported
 			panic(errors.NewUnreachableError())
 		},
@@ -201,14 +206,14 @@ func (interpreter *Interpreter) valueIndexExpressionGetterSetter(
 
 	if isNestedResourceMove {
 		get = func(_ bool) Value {
-			interpreter.checkInvalidatedResourceOrResourceReference(target, targetExpression)
+			interpreter.CheckInvalidatedResourceOrResourceReference(target, targetExpression)
 			value := target.RemoveKey(interpreter, locationRange, transferredIndexingValue)
 			target.InsertKey(interpreter, locationRange, transferredIndexingValue, placeholder)
 			return value
 		}
 	} else {
 		get = func(_ bool) Value {
-			interpreter.checkInvalidatedResourceOrResourceReference(target, targetExpression)
+			interpreter.CheckInvalidatedResourceOrResourceReference(target, targetExpression)
 			value := target.GetKey(interpreter, locationRange, transferredIndexingValue)
 
 			// If the indexing value is a reference, then return a reference for the resulting value.
@@ -220,7 +225,7 @@ func (interpreter *Interpreter) valueIndexExpressionGetterSetter(
 		target: target,
 		get:    get,
 		set: func(value Value) {
-			interpreter.checkInvalidatedResourceOrResourceReference(target, targetExpression)
+			interpreter.CheckInvalidatedResourceOrResourceReference(target, targetExpression)
 			target.SetKey(interpreter, locationRange, transferredIndexingValue, value)
 		},
 	}
@@ -362,7 +367,7 @@ func (interpreter *Interpreter) checkMemberAccess(
 	locationRange LocationRange,
 ) {
 
-	interpreter.checkInvalidatedResourceOrResourceReference(target, memberExpression)
+	interpreter.CheckInvalidatedResourceOrResourceReference(target, memberExpression)
 
 	memberInfo, _ := interpreter.Program.Elaboration.MemberExpressionMemberAccessInfo(memberExpression)
 	expectedType := memberInfo.AccessedType
@@ -433,11 +438,11 @@ func (interpreter *Interpreter) VisitIdentifierExpression(expression *ast.Identi
 
 func (interpreter *Interpreter) evalExpression(expression ast.Expression) Value {
 	result := ast.AcceptExpression[Value](expression, interpreter)
-	interpreter.checkInvalidatedResourceOrResourceReference(result, expression)
+	interpreter.CheckInvalidatedResourceOrResourceReference(result, expression)
 	return result
 }
 
-func (interpreter *Interpreter) checkInvalidatedResourceOrResourceReference(value Value, hasPosition ast.HasPosition) {
+func (interpreter *Interpreter) CheckInvalidatedResourceOrResourceReference(value Value, hasPosition ast.HasPosition) {
 	if interpreter == nil {
 		return
 	}
@@ -462,6 +467,8 @@ func (interpreter *Interpreter) checkInvalidatedResourceOrResourceReference(valu
 	case *EphemeralReferenceValue:
 		if value.Value == nil {
 			panic(InvalidatedResourceReferenceError{
+				BorrowType: value.BorrowedType,
+				Trace:      interpreter.referenceTrace(value),
 				LocationRange: LocationRange{
 					Location:    interpreter.Location,
 					HasPosition: hasPosition,
@@ -472,7 +479,7 @@ func (interpreter *Interpreter) checkInvalidatedResourceOrResourceReference(valu
 			// This step is not really needed, since reference tracking is supposed to clear the
 			// `value.Value` if the referenced-value was moved/deleted.
 			// However, have this as a second layer of defensive.
-			interpreter.checkInvalidatedResourceOrResourceReference(value.Value, hasPosition)
+			interpreter.CheckInvalidatedResourceOrResourceReference(value.Value, hasPosition)
 		}
 	}
 }
@@ -1080,10 +1087,11 @@ func (interpreter *Interpreter) VisitDictionaryExpression(expression *ast.Dictio
 		HasPosition: expression,
 	}
 
-	return NewDictionaryValue(
+	return NewDictionaryValueFromBatch(
 		interpreter,
 		locationRange,
 		dictionaryStaticType,
+		common.ZeroAddress,
 		keyValuePairs...,
 	)
 }
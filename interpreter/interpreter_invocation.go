@@ -19,7 +19,7 @@
 package interpreter
 
 import (
-	"github.com/onflow/atree"
+	"time"
 
 	"github.com/onflow/cadence/ast"
 	"github.com/onflow/cadence/sema"
@@ -76,9 +76,11 @@ func (interpreter *Interpreter) invokeFunctionValue(
 		for i, argument := range arguments {
 			argumentType := argumentTypes[i]
 
+			var expression ast.Expression
 			var locationPos ast.HasPosition
 			if i < len(expressions) {
-				locationPos = expressions[i]
+				expression = expressions[i]
+				locationPos = expression
 			} else {
 				locationPos = invocationPosition
 			}
@@ -88,25 +90,23 @@ func (interpreter *Interpreter) invokeFunctionValue(
 				HasPosition: locationPos,
 			}
 
+			transferredArgument := interpreter.transferArgument(
+				argument,
+				expression,
+				locationRange,
+			)
+
 			if i < parameterTypeCount {
 				parameterType := parameterTypes[i]
-				transferredArguments[i] = interpreter.transferAndConvert(
-					argument,
+				transferredArgument = interpreter.convertTransferred(
+					transferredArgument,
 					argumentType,
 					parameterType,
 					locationRange,
 				)
-			} else {
-				transferredArguments[i] = argument.Transfer(
-					interpreter,
-					locationRange,
-					atree.Address{},
-					false,
-					nil,
-					nil,
-					true, // argument is standalone.
-				)
 			}
+
+			transferredArguments[i] = transferredArgument
 		}
 	}
 
@@ -168,6 +168,8 @@ func (interpreter *Interpreter) invokeInterpretedFunction(
 	current := interpreter.activations.PushNewWithParent(function.Activation)
 	current.IsFunction = true
 
+	interpreter.checkCallStackDepth(invocation.LocationRange)
+
 	interpreter.SharedState.callStack.Push(invocation)
 
 	// Make `self` available, if any
@@ -203,6 +205,20 @@ func (interpreter *Interpreter) invokeInterpretedFunctionActivated(
 	}()
 	defer interpreter.activations.Pop()
 
+	onInterpretedFunctionInvoked := interpreter.SharedState.Config.OnInterpretedFunctionInvoked
+	if onInterpretedFunctionInvoked != nil {
+		start := time.Now()
+		defer func() {
+			onInterpretedFunctionInvoked(interpreter, function, time.Since(start))
+		}()
+	}
+
+	profiler := interpreter.SharedState.Config.Profiler
+	if profiler != nil {
+		profiler.onFunctionInvocation(function)
+		defer profiler.onInvokedFunctionReturn()
+	}
+
 	if function.ParameterList != nil {
 		interpreter.bindParameterArguments(function.ParameterList, arguments)
 	}
@@ -229,3 +245,20 @@ func (interpreter *Interpreter) bindParameterArguments(
 		interpreter.declareVariable(parameter.Identifier.Identifier, argument)
 	}
 }
+
+func (interpreter *Interpreter) checkCallStackDepth(locationRange LocationRange) {
+	limit := interpreter.SharedState.Config.CallStackDepthLimit
+	if limit == 0 {
+		return
+	}
+
+	depth := uint64(len(interpreter.SharedState.callStack.Invocations))
+	if depth < limit {
+		return
+	}
+
+	panic(CallStackDepthLimitExceededError{
+		Limit:         limit,
+		LocationRange: locationRange,
+	})
+}
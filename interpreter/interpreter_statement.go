@@ -36,12 +36,9 @@ func (interpreter *Interpreter) evalStatement(statement ast.Statement) Statement
 
 	interpreter.statement = statement
 
-	config := interpreter.SharedState.Config
+	interpreter.ReportComputation(common.ComputationKindStatement, 1)
 
-	onMeterComputation := config.OnMeterComputation
-	if onMeterComputation != nil {
-		onMeterComputation(common.ComputationKindStatement, 1)
-	}
+	config := interpreter.SharedState.Config
 
 	debugger := config.Debugger
 	if debugger != nil {
@@ -368,6 +365,8 @@ func (interpreter *Interpreter) visitForStatementBody(
 
 func (interpreter *Interpreter) emitEvent(event *CompositeValue, eventType *sema.CompositeType, locationRange LocationRange) {
 
+	interpreter.CheckReadOnly(ReadOnlyOperationEventEmission, locationRange)
+
 	config := interpreter.SharedState.Config
 
 	onEventEmitted := config.OnEventEmitted
@@ -601,10 +600,10 @@ func (interpreter *Interpreter) VisitSwapStatement(swap *ast.SwapStatement) Stat
 	// Set right value to left target,
 	// and left value to right target
 
-	interpreter.checkInvalidatedResourceOrResourceReference(rightValue, swap.Right)
+	interpreter.CheckInvalidatedResourceOrResourceReference(rightValue, swap.Right)
 	transferredRightValue := interpreter.transferAndConvert(rightValue, rightType, leftType, rightLocationRange)
 
-	interpreter.checkInvalidatedResourceOrResourceReference(leftValue, swap.Left)
+	interpreter.CheckInvalidatedResourceOrResourceReference(leftValue, swap.Left)
 	transferredLeftValue := interpreter.transferAndConvert(leftValue, leftType, rightType, leftLocationRange)
 
 	leftGetterSetter.set(transferredRightValue)
@@ -19,6 +19,10 @@
 package interpreter
 
 import (
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence/ast"
 	"github.com/onflow/cadence/common"
 	"github.com/onflow/cadence/sema"
 )
@@ -73,3 +77,55 @@ func (i *CallStack) Pop() {
 	i.Invocations[depth-1] = Invocation{}
 	i.Invocations = i.Invocations[:depth-1]
 }
+
+// CallStackFrame is a frame of a captured call stack,
+// see Interpreter.CaptureCallStack
+type CallStackFrame struct {
+	// FunctionName is the name of the invoked function.
+	// It is empty if the name is unknown, e.g. for function expressions
+	FunctionName string
+	// Location is the location of the invocation
+	Location common.Location
+	// Position is the position of the invocation
+	Position ast.Position
+}
+
+func NewCallStackFrame(locationRange LocationRange) CallStackFrame {
+	frame := CallStackFrame{
+		Location: locationRange.Location,
+		Position: locationRange.StartPosition(),
+	}
+
+	if invocationExpression, ok := locationRange.HasPosition.(*ast.InvocationExpression); ok {
+		switch invokedExpression := invocationExpression.InvokedExpression.(type) {
+		case *ast.IdentifierExpression:
+			frame.FunctionName = invokedExpression.Identifier.Identifier
+		case *ast.MemberExpression:
+			frame.FunctionName = invokedExpression.Identifier.Identifier
+		}
+	}
+
+	return frame
+}
+
+func (f CallStackFrame) String() string {
+	var builder strings.Builder
+
+	functionName := f.FunctionName
+	if functionName == "" {
+		functionName = "<anonymous>"
+	}
+	builder.WriteString(functionName)
+
+	builder.WriteString(" (")
+	if f.Location != nil {
+		builder.WriteString(f.Location.String())
+		builder.WriteByte(':')
+	}
+	builder.WriteString(strconv.Itoa(f.Position.Line))
+	builder.WriteByte(':')
+	builder.WriteString(strconv.Itoa(f.Position.Column))
+	builder.WriteByte(')')
+
+	return builder.String()
+}
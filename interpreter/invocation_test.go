@@ -21,6 +21,7 @@ package interpreter_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence/activations"
@@ -171,3 +172,132 @@ func TestInterpretRejectUnboxedInvocation(t *testing.T) {
 
 	require.ErrorAs(t, err, &interpreter.MemberAccessTypeError{})
 }
+
+func TestInterpretCallStackDepthLimit(t *testing.T) {
+
+	t.Parallel()
+
+	code := `
+      fun recurse(_ n: Int): Int {
+          if n == 0 {
+              return 0
+          }
+          return recurse(n - 1) + 1
+      }
+    `
+
+	t.Run("within limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, code)
+		inter.SharedState.Config.CallStackDepthLimit = 10
+
+		result, err := inter.Invoke("recurse", interpreter.NewUnmeteredIntValueFromInt64(9))
+		require.NoError(t, err)
+
+		require.Equal(t, interpreter.NewUnmeteredIntValueFromInt64(9), result)
+	})
+
+	t.Run("exceeding limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, code)
+		inter.SharedState.Config.CallStackDepthLimit = 10
+
+		_, err := inter.Invoke("recurse", interpreter.NewUnmeteredIntValueFromInt64(10))
+		RequireError(t, err)
+
+		var limitErr interpreter.CallStackDepthLimitExceededError
+		require.ErrorAs(t, err, &limitErr)
+		require.Equal(t, uint64(10), limitErr.Limit)
+
+		var interpreterErr interpreter.Error
+		require.ErrorAs(t, err, &interpreterErr)
+		// The outermost invocation by the host has no location
+		require.Len(t, interpreterErr.CallStack(), 9)
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, code)
+
+		result, err := inter.Invoke("recurse", interpreter.NewUnmeteredIntValueFromInt64(100))
+		require.NoError(t, err)
+
+		require.Equal(t, interpreter.NewUnmeteredIntValueFromInt64(100), result)
+	})
+}
+
+func TestInterpretCaptureCallStack(t *testing.T) {
+
+	t.Parallel()
+
+	var frames []interpreter.CallStackFrame
+
+	captureFunction := stdlib.NewStandardLibraryStaticFunction(
+		"capture",
+		&sema.FunctionType{
+			ReturnTypeAnnotation: sema.VoidTypeAnnotation,
+		},
+		``,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			frames = invocation.Interpreter.CaptureCallStack()
+			return interpreter.Void
+		},
+	)
+
+	baseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
+	baseValueActivation.DeclareValue(captureFunction)
+
+	baseActivation := activations.NewActivation(nil, interpreter.BaseActivation)
+	interpreter.Declare(baseActivation, captureFunction)
+
+	inter, err := parseCheckAndInterpretWithOptions(t,
+		`
+          struct S {
+              fun foo() {
+                  let f = fun () {
+                      capture()
+                  }
+                  f()
+              }
+          }
+
+          fun test() {
+              S().foo()
+          }
+        `,
+		ParseCheckAndInterpretOptions{
+			Config: &interpreter.Config{
+				Storage: newUnmeteredInMemoryStorage(),
+				BaseActivationHandler: func(_ common.Location) *interpreter.VariableActivation {
+					return baseActivation
+				},
+			},
+			CheckerConfig: &sema.Config{
+				BaseValueActivationHandler: func(_ common.Location) *sema.VariableActivation {
+					return baseValueActivation
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("test")
+	require.NoError(t, err)
+
+	// The outermost invocation of the test function by the host is not included
+	require.Len(t, frames, 2)
+
+	assert.Equal(t, "foo", frames[0].FunctionName)
+	assert.Equal(t, TestLocation, frames[0].Location)
+	assert.Equal(t, 12, frames[0].Position.Line)
+	assert.Equal(t, "foo (test:12:14)", frames[0].String())
+
+	assert.Equal(t, "f", frames[1].FunctionName)
+	assert.Equal(t, 7, frames[1].Position.Line)
+}
@@ -6826,6 +6826,44 @@ func TestInterpretStringMetering(t *testing.T) {
 		// 1 + 4 (max UTF8 encoding)
 		assert.Equal(t, uint64(5), meter.getMemory(common.MemoryKindStringValue))
 	})
+
+	t.Run("normalization, normalized", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := `
+          fun main() {
+              let x = "a".concat("b")
+          }
+        `
+		meter := newTestMemoryGauge()
+		inter := parseCheckAndInterpretWithMemoryMetering(t, script, meter)
+
+		_, err := inter.Invoke("main")
+		require.NoError(t, err)
+
+		// already normalized, no allocation
+		assert.Equal(t, uint64(0), meter.getMemory(common.MemoryKindRawString))
+	})
+
+	t.Run("normalization, unnormalized", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := `
+          fun main() {
+              let x = "e".concat("\u{301}")
+          }
+        `
+		meter := newTestMemoryGauge()
+		inter := parseCheckAndInterpretWithMemoryMetering(t, script, meter)
+
+		_, err := inter.Invoke("main")
+		require.NoError(t, err)
+
+		// 1 + 2 (normalized é)
+		assert.Equal(t, uint64(3), meter.getMemory(common.MemoryKindRawString))
+	})
 }
 
 func TestInterpretCharacterMetering(t *testing.T) {
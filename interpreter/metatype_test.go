@@ -1411,3 +1411,50 @@ func TestInterpretMetaTypeContractName(t *testing.T) {
 		)
 	})
 }
+
+func TestInterpretMetaTypeConstructors(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      entitlement E
+
+      let optional = Type<Int>().optionalType() == Type<Int?>()
+      let variableSized = Type<String>().variableSizedArrayType() == Type<[String]>()
+      let constantSized = Type<UInt8>().constantSizedArrayType(size: 2) == Type<[UInt8; 2]>()
+      let dictionary = Type<String>().dictionaryType(value: Type<Int>()) == Type<{String: Int}>()
+      let invalidDictionary = Type<[Int]>().dictionaryType(value: Type<Int>())
+      let reference = Type<Int>().referenceType(entitlements: ["S.test.E"]) == Type<auth(E) &Int>()
+      let invalidReference = Type<Int>().referenceType(entitlements: ["S.test.X"])
+      let nested = Type<Int>().optionalType().variableSizedArrayType()
+          == VariableSizedArrayType(OptionalType(Type<Int>()))
+    `)
+
+	for _, name := range []string{
+		"optional",
+		"variableSized",
+		"constantSized",
+		"dictionary",
+		"reference",
+		"nested",
+	} {
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.TrueValue,
+			inter.Globals.Get(name).GetValue(inter),
+		)
+	}
+
+	for _, name := range []string{
+		"invalidDictionary",
+		"invalidReference",
+	} {
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.Nil,
+			inter.Globals.Get(name).GetValue(inter),
+		)
+	}
+}
@@ -660,3 +660,94 @@ func TestInterpretStdlibComputationMetering(t *testing.T) {
 		assert.Equal(t, uint(58), computationMeteredValues[common.ComputationKindLoop])
 	})
 }
+
+func TestInterpretCollectionOperationsComputationMetering(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, code string, expected map[common.ComputationKind]uint) {
+
+		computationMeteredValues := make(map[common.ComputationKind]uint)
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			code,
+			ParseCheckAndInterpretOptions{
+				Config: &interpreter.Config{
+					OnMeterComputation: func(compKind common.ComputationKind, intensity uint) {
+						computationMeteredValues[compKind] += intensity
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("main")
+		require.NoError(t, err)
+
+		for kind, intensity := range expected {
+			assert.Equal(t, intensity, computationMeteredValues[kind], kind.String())
+		}
+	}
+
+	t.Run("array", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`
+            fun main() {
+                let x = [1, 2, 3]
+                x.append(4)
+                x.insert(at: 0, 0)
+                x.remove(at: 1)
+                x.removeFirst()
+                x.contains(3)
+            }
+            `,
+			map[common.ComputationKind]uint{
+				common.ComputationKindArrayAppend:   1,
+				common.ComputationKindArrayInsert:   1,
+				common.ComputationKindArrayRemove:   2,
+				common.ComputationKindArrayContains: 2,
+			},
+		)
+	})
+
+	t.Run("dictionary", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`
+            fun main() {
+                let x: {String: Int} = {}
+                x["a"] = 1
+                x.insert(key: "b", 2)
+                x.remove(key: "a")
+                x.containsKey("b")
+                let y = x["b"]
+            }
+            `,
+			map[common.ComputationKind]uint{
+				common.ComputationKindDictionaryInsert: 2,
+				common.ComputationKindDictionaryRemove: 1,
+				common.ComputationKindDictionaryLookup: 2,
+			},
+		)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+
+		test(t,
+			`
+            fun main() {
+                let s = "abc".concat("def")
+                let t = s.slice(from: 1, upTo: 4)
+            }
+            `,
+			map[common.ComputationKind]uint{
+				common.ComputationKindLoop:         6,
+				common.ComputationKindStringConcat: 6,
+				common.ComputationKindStringSlice:  4,
+			},
+		)
+	})
+}
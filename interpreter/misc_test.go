@@ -13278,3 +13278,29 @@ func TestInterpretSomeValueChildContainerMutation(t *testing.T) {
 
 	})
 }
+
+func TestInterpretRawStrings(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      let name = "Alice"
+      let x = """
+Hello, "\(name)"!
+  \n"""
+      let y = "Hello, \(name)!".concat(x)
+    `)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewUnmeteredStringValue("Hello, \"\\(name)\"!\n  \\n"),
+		inter.Globals.Get("x").GetValue(inter),
+	)
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewUnmeteredStringValue("Hello, Alice!Hello, \"\\(name)\"!\n  \\n"),
+		inter.Globals.Get("y").GetValue(inter),
+	)
+}
@@ -0,0 +1,528 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+)
+
+// Profiler records where execution is spent, keyed by Cadence function and location.
+//
+// The profiler instruments the invocations of interpreted functions,
+// and attributes the number of invocations, the reported computation (see Config.OnMeterComputation),
+// and the elapsed wall-clock time to the call stack of Cadence functions in which they occur.
+//
+// The profile can be written in the pprof format (see Profiler.WritePprof),
+// or as folded stacks (see Profiler.WriteFoldedStacks), e.g. for flame graphs.
+//
+// A profiler is not safe for concurrent use, and should only be used for one execution at a time.
+type Profiler struct {
+	frames       []ProfileFrame
+	frameIndices map[ProfileFrame]int
+	functions    map[*Program]map[ast.Position]profiledFunction
+	root         *profileNode
+	current      *profileNode
+	lastTime     time.Time
+}
+
+// ProfileFrame is a frame of a profiled call stack
+type ProfileFrame struct {
+	Location common.Location
+	// Function is the name of the function,
+	// qualified with the names of the enclosing composite declarations, if any
+	Function string
+	// Line is the line of the function declaration
+	Line int
+}
+
+func (f ProfileFrame) String() string {
+	return fmt.Sprintf("%s (%s:%d)", f.Function, f.Location, f.Line)
+}
+
+// ProfileSample is the profile of a call stack
+type ProfileSample struct {
+	// Stack is the call stack, outermost function first
+	Stack []ProfileFrame
+	// Invocations is the number of invocations of the innermost function of the stack
+	Invocations uint64
+	// Computation is the computation reported in the innermost function of the stack,
+	// excluding the computation of functions it invoked
+	Computation uint64
+	// Duration is the time spent in the innermost function of the stack,
+	// excluding the time spent in functions it invoked
+	Duration time.Duration
+}
+
+type profiledFunction struct {
+	name string
+	line int
+}
+
+type profileNode struct {
+	parent      *profileNode
+	children    map[int]*profileNode
+	ordered     []*profileNode
+	frame       int
+	invocations uint64
+	computation uint64
+	duration    time.Duration
+}
+
+func (n *profileNode) child(frame int) *profileNode {
+	child, ok := n.children[frame]
+	if !ok {
+		child = &profileNode{
+			parent:   n,
+			children: map[int]*profileNode{},
+			frame:    frame,
+		}
+		n.children[frame] = child
+		n.ordered = append(n.ordered, child)
+	}
+	return child
+}
+
+// topLevelFunctionName is the name of the function of the frame
+// to which execution outside of functions is attributed, e.g. of top-level declarations
+const topLevelFunctionName = "<top-level>"
+
+// anonymousFunctionName is the name of the function of frames of function expressions
+const anonymousFunctionName = "<anonymous>"
+
+func NewProfiler() *Profiler {
+	root := &profileNode{
+		children: map[int]*profileNode{},
+		frame:    -1,
+	}
+	return &Profiler{
+		frameIndices: map[ProfileFrame]int{},
+		functions:    map[*Program]map[ast.Position]profiledFunction{},
+		root:         root,
+		current:      root,
+	}
+}
+
+func (p *Profiler) frameIndex(frame ProfileFrame) int {
+	index, ok := p.frameIndices[frame]
+	if !ok {
+		index = len(p.frames)
+		p.frames = append(p.frames, frame)
+		p.frameIndices[frame] = index
+	}
+	return index
+}
+
+// recordDuration attributes the time elapsed since the last call stack change
+// to the current call stack
+func (p *Profiler) recordDuration() {
+	now := time.Now()
+	if p.current != p.root {
+		p.current.duration += now.Sub(p.lastTime)
+	}
+	p.lastTime = now
+}
+
+func (p *Profiler) onFunctionInvocation(function *InterpretedFunctionValue) {
+	p.recordDuration()
+
+	frame := p.functionFrame(function)
+
+	p.current = p.current.child(p.frameIndex(frame))
+	p.current.invocations++
+}
+
+func (p *Profiler) onInvokedFunctionReturn() {
+	p.recordDuration()
+
+	if p.current != p.root {
+		p.current = p.current.parent
+	}
+}
+
+func (p *Profiler) onComputation(interpreter *Interpreter, intensity uint) {
+	node := p.current
+	if node == p.root {
+		frame := ProfileFrame{
+			Location: interpreter.Location,
+			Function: topLevelFunctionName,
+		}
+		node = node.child(p.frameIndex(frame))
+	}
+	node.computation += uint64(intensity)
+}
+
+func (p *Profiler) functionFrame(function *InterpretedFunctionValue) ProfileFrame {
+	interpreter := function.Interpreter
+
+	position := interpretedFunctionPosition(function.ParameterList, function.Statements)
+
+	functions, ok := p.functions[interpreter.Program]
+	if !ok {
+		functions = profiledFunctions(interpreter.Program.Program)
+		p.functions[interpreter.Program] = functions
+	}
+
+	profiled, ok := functions[position]
+	if !ok {
+		profiled = profiledFunction{
+			name: anonymousFunctionName,
+			line: position.Line,
+		}
+	}
+
+	return ProfileFrame{
+		Location: interpreter.Location,
+		Function: profiled.name,
+		Line:     profiled.line,
+	}
+}
+
+// interpretedFunctionPosition returns the position which identifies an interpreted function
+// with the given parameter list and statements.
+// The parameter list is not available for some functions, e.g. a transaction's execute block
+func interpretedFunctionPosition(parameterList *ast.ParameterList, statements []ast.Statement) ast.Position {
+	if parameterList != nil {
+		return parameterList.StartPos
+	}
+	if len(statements) > 0 {
+		return statements[0].StartPosition()
+	}
+	return ast.EmptyPosition
+}
+
+// profiledFunctions returns the names of all declared functions of the given program,
+// keyed by the position which identifies them (see interpretedFunctionPosition)
+func profiledFunctions(program *ast.Program) map[ast.Position]profiledFunction {
+	functions := map[ast.Position]profiledFunction{}
+
+	if program == nil {
+		return functions
+	}
+
+	addFunction := func(declaration *ast.FunctionDeclaration, name string) {
+		var statements []ast.Statement
+		if declaration.FunctionBlock != nil && declaration.FunctionBlock.Block != nil {
+			statements = declaration.FunctionBlock.Block.Statements
+		}
+
+		position := interpretedFunctionPosition(declaration.ParameterList, statements)
+		functions[position] = profiledFunction{
+			name: name,
+			line: declaration.StartPos.Line,
+		}
+	}
+
+	var addDeclarations func(declarations []ast.Declaration, prefix string)
+	addDeclarations = func(declarations []ast.Declaration, prefix string) {
+		for _, declaration := range declarations {
+			switch declaration := declaration.(type) {
+			case *ast.FunctionDeclaration:
+				addFunction(declaration, prefix+declaration.Identifier.Identifier)
+
+			case *ast.SpecialFunctionDeclaration:
+				addFunction(declaration.FunctionDeclaration, prefix+declaration.Kind.Keywords())
+
+			case *ast.TransactionDeclaration:
+				const transactionPrefix = "transaction."
+				if declaration.Prepare != nil {
+					addFunction(
+						declaration.Prepare.FunctionDeclaration,
+						transactionPrefix+declaration.Prepare.Kind.Keywords(),
+					)
+				}
+				if declaration.Execute != nil {
+					addFunction(
+						declaration.Execute.FunctionDeclaration,
+						transactionPrefix+declaration.Execute.Kind.Keywords(),
+					)
+				}
+
+			default:
+				members := declaration.DeclarationMembers()
+				identifier := declaration.DeclarationIdentifier()
+				if members == nil || identifier == nil {
+					continue
+				}
+				addDeclarations(members.Declarations(), prefix+identifier.Identifier+".")
+			}
+		}
+	}
+
+	addDeclarations(program.Declarations(), "")
+
+	return functions
+}
+
+// Samples returns the samples of the profile, one for each profiled call stack
+func (p *Profiler) Samples() []ProfileSample {
+	p.recordDuration()
+
+	var samples []ProfileSample
+
+	var stack []ProfileFrame
+
+	var addSamples func(node *profileNode)
+	addSamples = func(node *profileNode) {
+		for _, child := range node.ordered {
+			stack = append(stack, p.frames[child.frame])
+
+			samples = append(samples, ProfileSample{
+				Stack:       append([]ProfileFrame(nil), stack...),
+				Invocations: child.invocations,
+				Computation: child.computation,
+				Duration:    child.duration,
+			})
+
+			addSamples(child)
+
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	addSamples(p.root)
+
+	return samples
+}
+
+// WriteFoldedStacks writes the computation of the profile as folded stacks,
+// one line per call stack, with the frames separated by semicolons, followed by the computation.
+// This format can be used to produce flame graphs, e.g. with flamegraph.pl or speedscope
+func (p *Profiler) WriteFoldedStacks(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+
+	for _, sample := range p.Samples() {
+		if sample.Computation == 0 {
+			continue
+		}
+
+		frames := make([]string, len(sample.Stack))
+		for i, frame := range sample.Stack {
+			frames[i] = strings.ReplaceAll(frame.String(), ";", ",")
+		}
+
+		_, err := fmt.Fprintf(
+			writer,
+			"%s %d\n",
+			strings.Join(frames, ";"),
+			sample.Computation,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
+
+// WritePprof writes the profile in the gzip-compressed protocol buffer format of pprof,
+// see https://github.com/google/pprof/blob/main/proto/profile.proto.
+//
+// The profile has the sample types invocations (count), computation (units), and time (nanoseconds).
+// Computation is the default sample type
+func (p *Profiler) WritePprof(w io.Writer) error {
+	gzipWriter := gzip.NewWriter(w)
+
+	_, err := gzipWriter.Write(p.encodePprof())
+	if err != nil {
+		return err
+	}
+
+	return gzipWriter.Close()
+}
+
+// Field numbers of the pprof protocol buffer messages
+const (
+	pprofProfileSampleType        = 1
+	pprofProfileSample            = 2
+	pprofProfileLocation          = 4
+	pprofProfileFunction          = 5
+	pprofProfileStringTable       = 6
+	pprofProfileDefaultSampleType = 14
+
+	pprofValueTypeType = 1
+	pprofValueTypeUnit = 2
+
+	pprofSampleLocationID = 1
+	pprofSampleValue      = 2
+
+	pprofLocationID   = 1
+	pprofLocationLine = 4
+
+	pprofLineFunctionID = 1
+	pprofLineLine       = 2
+
+	pprofFunctionID        = 1
+	pprofFunctionName      = 2
+	pprofFunctionFilename  = 4
+	pprofFunctionStartLine = 5
+)
+
+func (p *Profiler) encodePprof() []byte {
+	samples := p.Samples()
+
+	stringTable := []string{""}
+	stringIndices := map[string]uint64{"": 0}
+
+	stringIndex := func(s string) uint64 {
+		index, ok := stringIndices[s]
+		if !ok {
+			index = uint64(len(stringTable))
+			stringTable = append(stringTable, s)
+			stringIndices[s] = index
+		}
+		return index
+	}
+
+	var encoder protobufEncoder
+
+	sampleTypes := [][2]string{
+		{"invocations", "count"},
+		{"computation", "units"},
+		{"time", "nanoseconds"},
+	}
+	for _, sampleType := range sampleTypes {
+		encoder.message(pprofProfileSampleType, func(encoder *protobufEncoder) {
+			encoder.uint64Field(pprofValueTypeType, stringIndex(sampleType[0]))
+			encoder.uint64Field(pprofValueTypeUnit, stringIndex(sampleType[1]))
+		})
+	}
+
+	for _, sample := range samples {
+		// Locations are ordered innermost first.
+		// There is one location and one function per frame,
+		// so the location IDs and function IDs are the frame indices, offset by one,
+		// as zero is not a valid ID
+		locationIDs := make([]uint64, len(sample.Stack))
+		for i, frame := range sample.Stack {
+			locationIDs[len(sample.Stack)-1-i] = uint64(p.frameIndices[frame]) + 1
+		}
+
+		encoder.message(pprofProfileSample, func(encoder *protobufEncoder) {
+			encoder.packedUint64Field(pprofSampleLocationID, locationIDs)
+			encoder.packedUint64Field(pprofSampleValue, []uint64{
+				sample.Invocations,
+				sample.Computation,
+				uint64(sample.Duration.Nanoseconds()),
+			})
+		})
+	}
+
+	for index, frame := range p.frames {
+		id := uint64(index) + 1
+
+		encoder.message(pprofProfileLocation, func(encoder *protobufEncoder) {
+			encoder.uint64Field(pprofLocationID, id)
+			encoder.message(pprofLocationLine, func(encoder *protobufEncoder) {
+				encoder.uint64Field(pprofLineFunctionID, id)
+				encoder.uint64Field(pprofLineLine, uint64(frame.Line))
+			})
+		})
+
+		var filename string
+		if frame.Location != nil {
+			filename = frame.Location.String()
+		}
+
+		encoder.message(pprofProfileFunction, func(encoder *protobufEncoder) {
+			encoder.uint64Field(pprofFunctionID, id)
+			encoder.uint64Field(pprofFunctionName, stringIndex(frame.Function))
+			encoder.uint64Field(pprofFunctionFilename, stringIndex(filename))
+			encoder.uint64Field(pprofFunctionStartLine, uint64(frame.Line))
+		})
+	}
+
+	defaultSampleType := stringIndex("computation")
+
+	for _, s := range stringTable {
+		encoder.stringField(pprofProfileStringTable, s)
+	}
+
+	encoder.uint64Field(pprofProfileDefaultSampleType, defaultSampleType)
+
+	return encoder.buf
+}
+
+// protobufEncoder is a minimal encoder for the protocol buffer wire format,
+// which supports the features used by the pprof format
+type protobufEncoder struct {
+	buf []byte
+}
+
+const (
+	protobufWireTypeVarint          = 0
+	protobufWireTypeLengthDelimited = 2
+)
+
+func (e *protobufEncoder) varint(x uint64) {
+	for x >= 0x80 {
+		e.buf = append(e.buf, byte(x)|0x80)
+		x >>= 7
+	}
+	e.buf = append(e.buf, byte(x))
+}
+
+func (e *protobufEncoder) tag(field int, wireType int) {
+	e.varint(uint64(field)<<3 | uint64(wireType))
+}
+
+// uint64Field encodes the given integer field.
+// Zero values are omitted, as they are the default
+func (e *protobufEncoder) uint64Field(field int, x uint64) {
+	if x == 0 {
+		return
+	}
+	e.tag(field, protobufWireTypeVarint)
+	e.varint(x)
+}
+
+func (e *protobufEncoder) packedUint64Field(field int, xs []uint64) {
+	if len(xs) == 0 {
+		return
+	}
+	e.message(field, func(encoder *protobufEncoder) {
+		for _, x := range xs {
+			encoder.varint(x)
+		}
+	})
+}
+
+// stringField encodes the given string field.
+// Empty strings are not omitted, as they are significant in repeated fields
+func (e *protobufEncoder) stringField(field int, s string) {
+	e.tag(field, protobufWireTypeLengthDelimited)
+	e.varint(uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func (e *protobufEncoder) message(field int, encode func(encoder *protobufEncoder)) {
+	var nested protobufEncoder
+	encode(&nested)
+
+	e.tag(field, protobufWireTypeLengthDelimited)
+	e.varint(uint64(len(nested.buf)))
+	e.buf = append(e.buf, nested.buf...)
+}
@@ -0,0 +1,176 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/interpreter"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+)
+
+func TestInterpretProfiler(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      struct S {
+          fun double(_ x: Int): Int {
+              return x * 2
+          }
+      }
+
+      fun test() {
+          let s = S()
+          s.double(1)
+          s.double(2)
+          let f = fun(): Int {
+              return s.double(3)
+          }
+          f()
+      }
+    `
+
+	profile := func(t *testing.T) *interpreter.Profiler {
+		profiler := interpreter.NewProfiler()
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			code,
+			ParseCheckAndInterpretOptions{
+				Config: &interpreter.Config{
+					Profiler: profiler,
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		require.NoError(t, err)
+
+		return profiler
+	}
+
+	testFrame := interpreter.ProfileFrame{
+		Location: TestLocation,
+		Function: "test",
+		Line:     8,
+	}
+	doubleFrame := interpreter.ProfileFrame{
+		Location: TestLocation,
+		Function: "S.double",
+		Line:     3,
+	}
+	anonymousFrame := interpreter.ProfileFrame{
+		Location: TestLocation,
+		Function: "<anonymous>",
+		Line:     12,
+	}
+
+	t.Run("samples", func(t *testing.T) {
+
+		t.Parallel()
+
+		samples := profile(t).Samples()
+
+		// Durations are not deterministic
+		type sample struct {
+			stack       []interpreter.ProfileFrame
+			invocations uint64
+			computation uint64
+		}
+
+		actual := make([]sample, 0, len(samples))
+		for _, s := range samples {
+			actual = append(actual, sample{
+				stack:       s.Stack,
+				invocations: s.Invocations,
+				computation: s.Computation,
+			})
+		}
+
+		assert.Equal(t,
+			[]sample{
+				{
+					stack:       []interpreter.ProfileFrame{testFrame},
+					invocations: 1,
+					computation: 11,
+				},
+				{
+					stack:       []interpreter.ProfileFrame{testFrame, doubleFrame},
+					invocations: 2,
+					computation: 2,
+				},
+				{
+					stack:       []interpreter.ProfileFrame{testFrame, anonymousFrame},
+					invocations: 1,
+					computation: 2,
+				},
+				{
+					stack:       []interpreter.ProfileFrame{testFrame, anonymousFrame, doubleFrame},
+					invocations: 1,
+					computation: 1,
+				},
+			},
+			actual,
+		)
+	})
+
+	t.Run("folded stacks", func(t *testing.T) {
+
+		t.Parallel()
+
+		var buffer bytes.Buffer
+		err := profile(t).WriteFoldedStacks(&buffer)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			"test (test:8) 11\n"+
+				"test (test:8);S.double (test:3) 2\n"+
+				"test (test:8);<anonymous> (test:12) 2\n"+
+				"test (test:8);<anonymous> (test:12);S.double (test:3) 1\n",
+			buffer.String(),
+		)
+	})
+
+	t.Run("pprof", func(t *testing.T) {
+
+		t.Parallel()
+
+		var buffer bytes.Buffer
+		err := profile(t).WritePprof(&buffer)
+		require.NoError(t, err)
+
+		reader, err := gzip.NewReader(&buffer)
+		require.NoError(t, err)
+
+		encoded, err := io.ReadAll(reader)
+		require.NoError(t, err)
+
+		// The string table contains the function names and the sample types
+		assert.Contains(t, string(encoded), "S.double")
+		assert.Contains(t, string(encoded), "<anonymous>")
+		assert.Contains(t, string(encoded), "computation")
+	})
+}
@@ -0,0 +1,76 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+)
+
+// ReadOnlyOperation is an operation which is prohibited when the execution is read-only,
+// see Config.ReadOnly
+type ReadOnlyOperation uint8
+
+const (
+	ReadOnlyOperationUnknown ReadOnlyOperation = iota
+	// ReadOnlyOperationStorageWrite is a write to account storage,
+	// e.g. saving or loading a value, or mutating a stored value
+	ReadOnlyOperationStorageWrite
+	// ReadOnlyOperationEventEmission is the emission of an event
+	ReadOnlyOperationEventEmission
+	// ReadOnlyOperationAccountMutation is a mutation of an account other than of its storage,
+	// e.g. the creation of an account, or the update of its keys or contracts
+	ReadOnlyOperationAccountMutation
+)
+
+func (o ReadOnlyOperation) String() string {
+	switch o {
+	case ReadOnlyOperationStorageWrite:
+		return "storage write"
+	case ReadOnlyOperationEventEmission:
+		return "event emission"
+	case ReadOnlyOperationAccountMutation:
+		return "account mutation"
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+// CheckReadOnly panics with a ReadOnlyViolationError
+// if the execution is read-only, see Config.ReadOnly
+func (interpreter *Interpreter) CheckReadOnly(operation ReadOnlyOperation, locationRange LocationRange) {
+	if !interpreter.SharedState.Config.ReadOnly {
+		return
+	}
+
+	panic(ReadOnlyViolationError{
+		Operation:     operation,
+		LocationRange: locationRange,
+	})
+}
+
+// checkStoredValueMutation checks that the value owned by the given account may be mutated,
+// i.e. that it is not stored in an account, or that the execution is not read-only
+func (interpreter *Interpreter) checkStoredValueMutation(owner common.Address, locationRange LocationRange) {
+	if owner == common.ZeroAddress {
+		return
+	}
+
+	interpreter.CheckReadOnly(ReadOnlyOperationStorageWrite, locationRange)
+}
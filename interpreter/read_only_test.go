@@ -0,0 +1,119 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/interpreter_utils"
+)
+
+func TestInterpretReadOnly(t *testing.T) {
+
+	t.Parallel()
+
+	newConfig := func() *interpreter.Config {
+		return &interpreter.Config{
+			ReadOnly: true,
+			OnEventEmitted: func(
+				_ *interpreter.Interpreter,
+				_ interpreter.LocationRange,
+				_ *interpreter.CompositeValue,
+				_ *sema.CompositeType,
+			) error {
+				return nil
+			},
+		}
+	}
+
+	t.Run("event emission", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              event E()
+
+              fun test() {
+                  emit E()
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Config: newConfig(),
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		RequireError(t, err)
+
+		var readOnlyErr interpreter.ReadOnlyViolationError
+		require.ErrorAs(t, err, &readOnlyErr)
+		assert.Equal(t, interpreter.ReadOnlyOperationEventEmission, readOnlyErr.Operation)
+		assert.Equal(t,
+			ast.Position{Offset: 71, Line: 5, Column: 18},
+			readOnlyErr.StartPosition(),
+		)
+	})
+
+	t.Run("mutation of values which are not stored", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			`
+              struct S {
+                  var values: {String: [Int]}
+
+                  init() {
+                      self.values = {}
+                  }
+              }
+
+              fun test(): Int {
+                  let s = S()
+                  s.values["a"] = [1]
+                  s.values["a"]!.append(2)
+                  return s.values["a"]!.length
+              }
+            `,
+			ParseCheckAndInterpretOptions{
+				Config: newConfig(),
+			},
+		)
+		require.NoError(t, err)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(2),
+			result,
+		)
+	})
+}
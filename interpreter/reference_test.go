@@ -3500,3 +3500,82 @@ func TestInterpretCreatingCircularDependentResource(t *testing.T) {
 		assert.ErrorAs(t, err, &interpreter.InvalidatedResourceReferenceError{})
 	})
 }
+
+func TestInterpretReferenceTracing(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      resource R {
+          fun foo(): Int {
+              return 1
+          }
+      }
+
+      fun consume(_ r: @R) {
+          destroy r
+      }
+
+      fun test(): Int {
+          let r <- create R()
+          let refs: [&R] = [&r as &R]
+          consume(<-r)
+          return refs[0].foo()
+      }
+    `
+
+	t.Run("enabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter, err := parseCheckAndInterpretWithOptions(t,
+			code,
+			ParseCheckAndInterpretOptions{
+				Config: &interpreter.Config{
+					ReferenceTracingEnabled: true,
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test")
+		RequireError(t, err)
+
+		var invalidatedReferenceErr interpreter.InvalidatedResourceReferenceError
+		require.ErrorAs(t, err, &invalidatedReferenceErr)
+
+		trace := invalidatedReferenceErr.Trace
+		require.NotNil(t, trace)
+
+		// The reference is created in function test,
+		// which is called by the host
+
+		require.Len(t, trace.Creation, 2)
+		assert.Equal(t, 14, trace.Creation[1].StartPosition().Line)
+
+		// The reference is invalidated when the resource is moved into function consume
+
+		require.Len(t, trace.Invalidation, 2)
+		assert.Equal(t, 15, trace.Invalidation[1].StartPosition().Line)
+
+		assert.Contains(t, invalidatedReferenceErr.SecondaryError(), "reference invalidated")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpret(t, code)
+
+		_, err := inter.Invoke("test")
+		RequireError(t, err)
+
+		var invalidatedReferenceErr interpreter.InvalidatedResourceReferenceError
+		require.ErrorAs(t, err, &invalidatedReferenceErr)
+		assert.Nil(t, invalidatedReferenceErr.Trace)
+		assert.Equal(t,
+			"reference borrowed as `R`",
+			invalidatedReferenceErr.SecondaryError(),
+		)
+	})
+}
@@ -0,0 +1,192 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/sema"
+)
+
+// ReferenceTrace records where a reference was created,
+// and, for ephemeral references to resources,
+// where the referenced resource was moved or destroyed, invalidating the reference.
+//
+// Traces are only recorded if reference tracing is enabled, see Config.ReferenceTracingEnabled.
+type ReferenceTrace struct {
+	// Creation is the stack trace of the creation of the reference
+	Creation []LocationRange
+	// Invalidation is the stack trace of the invalidation of the reference.
+	// Nil if the reference was not invalidated
+	Invalidation []LocationRange
+}
+
+func (t *ReferenceTrace) String() string {
+	var builder strings.Builder
+
+	writeStackTrace := func(title string, stackTrace []LocationRange) {
+		builder.WriteString(title)
+		builder.WriteString(":\n")
+
+		// Print the innermost location first
+		for i := len(stackTrace) - 1; i >= 0; i-- {
+			locationRange := stackTrace[i]
+			if locationRange.Location == nil && locationRange.HasPosition == nil {
+				continue
+			}
+			builder.WriteString("  at ")
+			if locationRange.Location != nil {
+				builder.WriteString(locationRange.Location.String())
+				builder.WriteByte(':')
+			}
+			if locationRange.HasPosition != nil {
+				startPos := locationRange.StartPosition()
+				builder.WriteString(fmt.Sprintf("%d:%d", startPos.Line, startPos.Column))
+			}
+			builder.WriteByte('\n')
+		}
+	}
+
+	writeStackTrace("reference created", t.Creation)
+	if t.Invalidation != nil {
+		writeStackTrace("reference invalidated", t.Invalidation)
+	}
+
+	return builder.String()
+}
+
+// StorageReferenceProvenance describes where a storage reference points to,
+// and how it was created
+type StorageReferenceProvenance struct {
+	Address    common.Address
+	Path       PathValue
+	BorrowType sema.Type
+	// Trace is the trace of the reference, if reference tracing is enabled
+	Trace *ReferenceTrace
+}
+
+func (p *StorageReferenceProvenance) String() string {
+	var builder strings.Builder
+
+	builder.WriteString(
+		fmt.Sprintf(
+			"reference to `%s` in account %s",
+			p.Path.String(),
+			p.Address.HexWithPrefix(),
+		),
+	)
+
+	if p.BorrowType != nil {
+		builder.WriteString(
+			fmt.Sprintf(
+				", borrowed as `%s`",
+				p.BorrowType.QualifiedString(),
+			),
+		)
+	}
+
+	if p.Trace != nil {
+		builder.WriteByte('\n')
+		builder.WriteString(p.Trace.String())
+	}
+
+	return builder.String()
+}
+
+// stackTrace returns the locations of the invocations of the call stack,
+// from the outermost to the innermost, followed by the given location
+func (interpreter *Interpreter) stackTrace(locationRange LocationRange) []LocationRange {
+	invocations := interpreter.CallStack()
+	stackTrace := make([]LocationRange, 0, len(invocations)+1)
+	for _, invocation := range invocations {
+		stackTrace = append(stackTrace, invocation.LocationRange)
+	}
+	return append(stackTrace, locationRange)
+}
+
+func (interpreter *Interpreter) referenceTracingEnabled() bool {
+	return interpreter != nil &&
+		interpreter.SharedState.Config.ReferenceTracingEnabled
+}
+
+func (interpreter *Interpreter) recordReferenceCreation(
+	reference ReferenceValue,
+	locationRange LocationRange,
+) {
+	if !interpreter.referenceTracingEnabled() {
+		return
+	}
+
+	// Only ephemeral references to resources can be invalidated,
+	// storage references are always traced, as the stored value may be moved
+	if reference, ok := reference.(*EphemeralReferenceValue); ok {
+		if _, ok := reference.Value.(ReferenceTrackedResourceKindedValue); !ok {
+			return
+		}
+	}
+
+	sharedState := interpreter.SharedState
+	if sharedState.referenceTraces == nil {
+		sharedState.referenceTraces = map[ReferenceValue]*ReferenceTrace{}
+	}
+
+	sharedState.referenceTraces[reference] = &ReferenceTrace{
+		Creation: interpreter.stackTrace(locationRange),
+	}
+}
+
+func (interpreter *Interpreter) recordReferenceInvalidation(
+	reference *EphemeralReferenceValue,
+	locationRange LocationRange,
+) {
+	if !interpreter.referenceTracingEnabled() {
+		return
+	}
+
+	trace := interpreter.SharedState.referenceTraces[reference]
+	if trace == nil || trace.Invalidation != nil {
+		return
+	}
+
+	trace.Invalidation = interpreter.stackTrace(locationRange)
+}
+
+// copyReferenceTrace records the trace of the given original reference
+// for the given new reference, which was derived from the original one,
+// e.g. by converting its type or authorization
+func (interpreter *Interpreter) copyReferenceTrace(original, derived ReferenceValue) {
+	trace := interpreter.referenceTrace(original)
+	if trace == nil {
+		return
+	}
+
+	interpreter.SharedState.referenceTraces[derived] = trace
+}
+
+// referenceTrace returns the trace of the given reference,
+// or nil if reference tracing is disabled or the reference was not traced
+func (interpreter *Interpreter) referenceTrace(reference ReferenceValue) *ReferenceTrace {
+	if !interpreter.referenceTracingEnabled() {
+		return nil
+	}
+
+	return interpreter.SharedState.referenceTraces[reference]
+}
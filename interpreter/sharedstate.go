@@ -46,6 +46,13 @@ type SharedState struct {
 	containerValueIteration                     map[atree.ValueID]struct{}
 	destroyedResources                          map[atree.ValueID]struct{}
 	currentEntitlementMappedValue               Authorization
+	// referenceTraces are the traces of references, if reference tracing is enabled
+	referenceTraces map[ReferenceValue]*ReferenceTrace
+	// subtypeCheckCache caches the results of subtype checks, if enabled
+	subtypeCheckCache *subtypeCheckCache
+	// createdValueCounts are the numbers of created values, per kind,
+	// if value creation limits are enabled
+	createdValueCounts [createdValueKindCount]uint64
 }
 
 func NewSharedState(config *Config) *SharedState {
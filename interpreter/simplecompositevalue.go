@@ -35,7 +35,7 @@ type SimpleCompositeValue struct {
 	fieldFormatters map[string]func(common.MemoryGauge, Value, SeenReferences) string
 	// stringer is an optional function that is used to produce the string representation of the value.
 	// If nil, the FieldNames are used.
-	stringer func(*Interpreter, SeenReferences, LocationRange) string
+	stringer func(ValueContext, SeenReferences, LocationRange) string
 	TypeID   sema.TypeID
 	// FieldNames are the names of the field members (i.e. not functions, and not computed fields), in order
 	FieldNames []string
@@ -56,7 +56,7 @@ func NewSimpleCompositeValue(
 	fields map[string]Value,
 	computeField func(name string, interpreter *Interpreter, locationRange LocationRange) Value,
 	fieldFormatters map[string]func(common.MemoryGauge, Value, SeenReferences) string,
-	stringer func(*Interpreter, SeenReferences, LocationRange) string,
+	stringer func(ValueContext, SeenReferences, LocationRange) string,
 ) *SimpleCompositeValue {
 
 	common.UseMemory(gauge, common.SimpleCompositeValueBaseMemoryUsage)
@@ -103,14 +103,14 @@ func (v *SimpleCompositeValue) Walk(_ *Interpreter, walkChild func(Value), _ Loc
 	})
 }
 
-func (v *SimpleCompositeValue) StaticType(_ *Interpreter) StaticType {
+func (v *SimpleCompositeValue) StaticType(_ ValueContext) StaticType {
 	return v.staticType
 }
 
-func (v *SimpleCompositeValue) IsImportable(inter *Interpreter, locationRange LocationRange) bool {
+func (v *SimpleCompositeValue) IsImportable(context ValueContext, locationRange LocationRange) bool {
 	// Check type is importable
-	staticType := v.StaticType(inter)
-	semaType := inter.MustConvertStaticToSemaType(staticType)
+	staticType := v.StaticType(context)
+	semaType := context.MustConvertStaticToSemaType(staticType)
 	if !semaType.IsImportable(map[*sema.Member]bool{}) {
 		return false
 	}
@@ -118,7 +118,7 @@ func (v *SimpleCompositeValue) IsImportable(inter *Interpreter, locationRange Lo
 	// Check all field values are importable
 	importable := true
 	v.ForEachField(func(_ string, value Value) (resume bool) {
-		if !value.IsImportable(inter, locationRange) {
+		if !value.IsImportable(context, locationRange) {
 			importable = false
 			// stop iteration
 			return false
@@ -170,10 +170,10 @@ func (v *SimpleCompositeValue) RecursiveString(seenReferences SeenReferences) st
 	return v.MeteredString(nil, seenReferences, EmptyLocationRange)
 }
 
-func (v *SimpleCompositeValue) MeteredString(interpreter *Interpreter, seenReferences SeenReferences, locationRange LocationRange) string {
+func (v *SimpleCompositeValue) MeteredString(context ValueContext, seenReferences SeenReferences, locationRange LocationRange) string {
 
 	if v.stringer != nil {
-		return v.stringer(interpreter, seenReferences, locationRange)
+		return v.stringer(context, seenReferences, locationRange)
 	}
 
 	var fields []struct {
@@ -189,11 +189,11 @@ func (v *SimpleCompositeValue) MeteredString(interpreter *Interpreter, seenRefer
 		var value string
 		if v.fieldFormatters != nil {
 			if fieldFormatter, ok := v.fieldFormatters[fieldName]; ok {
-				value = fieldFormatter(interpreter, fieldValue, seenReferences)
+				value = fieldFormatter(context, fieldValue, seenReferences)
 			}
 		}
 		if value == "" {
-			value = fieldValue.MeteredString(interpreter, seenReferences, locationRange)
+			value = fieldValue.MeteredString(context, seenReferences, locationRange)
 		}
 
 		fields = append(fields, struct {
@@ -219,7 +219,7 @@ func (v *SimpleCompositeValue) MeteredString(interpreter *Interpreter, seenRefer
 	// Value of each field is metered separately.
 	strLen = strLen + len(typeId) + len(fields)*4
 
-	common.UseMemory(interpreter, common.NewRawStringMemoryUsage(strLen))
+	common.UseMemory(context, common.NewRawStringMemoryUsage(strLen))
 
 	return format.Composite(typeId, fields)
 }
@@ -255,7 +255,7 @@ func (*SimpleCompositeValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (v *SimpleCompositeValue) IsResourceKinded(_ *Interpreter) bool {
+func (v *SimpleCompositeValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
@@ -33,6 +33,23 @@ import (
 	"github.com/onflow/cadence/common"
 )
 
+// SharedSlabStorage is a slab storage whose slabs may be shared with other, concurrent executions,
+// e.g. the slabs of an immutable execution state snapshot.
+//
+// Values which are stored inline in shared slabs are shared, too.
+// Stored values which have mutable state, e.g. lazily initialized state,
+// are copied when they are read from a shared slab storage, see atree.Storable.StoredValue
+type SharedSlabStorage interface {
+	atree.SlabStorage
+	// SharesSlabs returns true if the slabs of the storage may be shared
+	SharesSlabs() bool
+}
+
+func sharesSlabs(storage atree.SlabStorage) bool {
+	sharedStorage, ok := storage.(SharedSlabStorage)
+	return ok && sharedStorage.SharesSlabs()
+}
+
 func StoredValue(gauge common.MemoryGauge, storable atree.Storable, storage atree.SlabStorage) Value {
 	storedValue, err := storable.StoredValue(storage)
 	if err != nil {
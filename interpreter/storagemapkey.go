@@ -19,6 +19,8 @@
 package interpreter
 
 import (
+	"strings"
+
 	"github.com/onflow/atree"
 
 	"github.com/onflow/cadence/errors"
@@ -108,3 +110,52 @@ func StorageMapKeyAtreeValueComparator(slabStorage atree.SlabStorage, value atre
 
 	return smk.AtreeValueCompare(slabStorage, value, otherStorable)
 }
+
+// StorageMapKeyFromAtreeValue returns the storage map key for the given key of a storage map.
+func StorageMapKeyFromAtreeValue(value atree.Value) StorageMapKey {
+	switch value := value.(type) {
+	case StringAtreeValue:
+		return StringStorageMapKey(value)
+
+	case Uint64AtreeValue:
+		return Uint64StorageMapKey(value)
+
+	default:
+		panic(errors.NewUnexpectedError("StorageMapKeyFromAtreeValue expected StringAtreeValue or Uint64AtreeValue, got %T", value))
+	}
+}
+
+// CompareStorageMapKeys compares the given storage map keys.
+// String keys are ordered lexicographically, integer keys numerically,
+// and integer keys are ordered before string keys.
+//
+// The result is negative if a is ordered before b,
+// positive if a is ordered after b, and zero if the keys are equal.
+func CompareStorageMapKeys(a, b StorageMapKey) int {
+	switch a := a.(type) {
+	case StringStorageMapKey:
+		switch b := b.(type) {
+		case StringStorageMapKey:
+			return strings.Compare(string(a), string(b))
+		case Uint64StorageMapKey:
+			return 1
+		}
+
+	case Uint64StorageMapKey:
+		switch b := b.(type) {
+		case StringStorageMapKey:
+			return -1
+		case Uint64StorageMapKey:
+			switch {
+			case a < b:
+				return -1
+			case a > b:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+
+	panic(errors.NewUnexpectedError("CompareStorageMapKeys got unexpected keys %T and %T", a, b))
+}
@@ -0,0 +1,89 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+// subtypeCheckKey is the key of a subtype check result,
+// i.e. the pair of the subtype and supertype
+type subtypeCheckKey struct {
+	subType   StaticType
+	superType StaticType
+}
+
+// subtypeCheckCache is a bounded cache of the results of subtype checks,
+// see Config.SubtypeCheckCacheSize.
+//
+// Static types are identified by identity, i.e. pointer types by their address,
+// and all other types, e.g. primitive static types, by their value.
+// Equal types which are different instances do not share results,
+// but the key is cheap to compute, unlike the type ID, which is built on every call
+type subtypeCheckCache struct {
+	results map[subtypeCheckKey]bool
+	size    int
+}
+
+func newSubtypeCheckCache(size int) *subtypeCheckCache {
+	return &subtypeCheckCache{
+		results: make(map[subtypeCheckKey]bool, size),
+		size:    size,
+	}
+}
+
+func (c *subtypeCheckCache) get(key subtypeCheckKey) (result bool, ok bool) {
+	result, ok = c.results[key]
+	return
+}
+
+func (c *subtypeCheckCache) set(key subtypeCheckKey, result bool) {
+	// When the cache is full, clear it, instead of evicting individual results.
+	// Subtype checks are cheap to repeat, so tracking the usage of results is not worth the overhead
+	if len(c.results) >= c.size {
+		clear(c.results)
+	}
+	c.results[key] = result
+}
+
+// isSubTypeCached determines if the given subtype is a subtype of the given supertype,
+// using the subtype check cache of the execution, if enabled
+func (interpreter *Interpreter) isSubTypeCached(subType StaticType, superType StaticType) bool {
+	cacheSize := interpreter.SharedState.Config.SubtypeCheckCacheSize
+	if cacheSize <= 0 {
+		return interpreter.isSubType(subType, superType)
+	}
+
+	cache := interpreter.SharedState.subtypeCheckCache
+	if cache == nil {
+		cache = newSubtypeCheckCache(cacheSize)
+		interpreter.SharedState.subtypeCheckCache = cache
+	}
+
+	key := subtypeCheckKey{
+		subType:   subType,
+		superType: superType,
+	}
+
+	result, ok := cache.get(key)
+	if ok {
+		return result
+	}
+
+	result = interpreter.isSubType(subType, superType)
+	cache.set(key, result)
+
+	return result
+}
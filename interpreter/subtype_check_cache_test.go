@@ -0,0 +1,179 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func subtypeCheckCacheTestTypes() []StaticType {
+	var types []StaticType
+
+	for ty := PrimitiveStaticTypeUnknown + 1; ty < PrimitiveStaticType_Count; ty++ {
+		if !ty.IsDefined() || ty.IsDeprecated() { //nolint:staticcheck
+			continue
+		}
+		types = append(types, ty)
+	}
+
+	elementTypes := []StaticType{
+		PrimitiveStaticTypeNever,
+		PrimitiveStaticTypeInt,
+		PrimitiveStaticTypeInteger,
+		PrimitiveStaticTypeString,
+		PrimitiveStaticTypeAnyStruct,
+		PrimitiveStaticTypeAnyResource,
+	}
+
+	for _, elementType := range elementTypes {
+		types = append(types,
+			NewOptionalStaticType(nil, elementType),
+			NewOptionalStaticType(nil, NewOptionalStaticType(nil, elementType)),
+			NewVariableSizedStaticType(nil, elementType),
+			NewConstantSizedStaticType(nil, elementType, 2),
+			NewDictionaryStaticType(nil, PrimitiveStaticTypeString, elementType),
+			NewReferenceStaticType(nil, UnauthorizedAccess, elementType),
+		)
+	}
+
+	types = append(types,
+		NewReferenceStaticType(nil, UnauthorizedAccess, PrimitiveStaticTypeAccount),
+		NewReferenceStaticType(nil, FullyEntitledAccountAccess, PrimitiveStaticTypeAccount),
+	)
+
+	return types
+}
+
+func TestSubtypeCheckCache(t *testing.T) {
+
+	t.Parallel()
+
+	types := subtypeCheckCacheTestTypes()
+
+	uncached, err := NewInterpreter(nil, nil, &Config{})
+	require.NoError(t, err)
+
+	cached, err := NewInterpreter(
+		nil,
+		nil,
+		&Config{
+			SubtypeCheckCacheSize: 1000,
+		},
+	)
+	require.NoError(t, err)
+
+	// Check each pair twice, so the second check uses the cached result,
+	// and compare with the results of uncached checks
+
+	for i := 0; i < 2; i++ {
+		for _, subType := range types {
+			for _, superType := range types {
+				expected := uncached.IsSubType(subType, superType)
+				actual := cached.IsSubType(subType, superType)
+
+				if expected != actual {
+					assert.Failf(t,
+						"cached result differs",
+						"%s <: %s: expected %t, got %t",
+						subType.ID(),
+						superType.ID(),
+						expected,
+						actual,
+					)
+				}
+			}
+		}
+	}
+
+	cache := cached.SharedState.subtypeCheckCache
+	require.NotNil(t, cache)
+	assert.LessOrEqual(t, len(cache.results), 1000)
+	assert.Nil(t, uncached.SharedState.subtypeCheckCache)
+}
+
+func TestSubtypeCheckCacheBounded(t *testing.T) {
+
+	t.Parallel()
+
+	inter, err := NewInterpreter(
+		nil,
+		nil,
+		&Config{
+			SubtypeCheckCacheSize: 2,
+		},
+	)
+	require.NoError(t, err)
+
+	intArrayType := NewVariableSizedStaticType(nil, PrimitiveStaticTypeInt)
+	anyStructArrayType := NewVariableSizedStaticType(nil, PrimitiveStaticTypeAnyStruct)
+	stringArrayType := NewVariableSizedStaticType(nil, PrimitiveStaticTypeString)
+
+	assert.True(t, inter.IsSubType(intArrayType, anyStructArrayType))
+	assert.False(t, inter.IsSubType(anyStructArrayType, intArrayType))
+
+	cache := inter.SharedState.subtypeCheckCache
+	require.NotNil(t, cache)
+	assert.Len(t, cache.results, 2)
+
+	// The cache is full, so it is cleared before the result is cached
+
+	assert.True(t, inter.IsSubType(stringArrayType, anyStructArrayType))
+	assert.Len(t, cache.results, 1)
+
+	result, ok := cache.get(subtypeCheckKey{
+		subType:   stringArrayType,
+		superType: anyStructArrayType,
+	})
+	assert.True(t, ok)
+	assert.True(t, result)
+}
+
+func BenchmarkSubtypeCheckCache(b *testing.B) {
+
+	types := subtypeCheckCacheTestTypes()
+
+	run := func(b *testing.B, config *Config) {
+		inter, err := NewInterpreter(nil, nil, config)
+		require.NoError(b, err)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			for _, subType := range types {
+				for _, superType := range types {
+					inter.IsSubType(subType, superType)
+				}
+			}
+		}
+	}
+
+	b.Run("uncached", func(b *testing.B) {
+		run(b, &Config{})
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		run(b, &Config{
+			SubtypeCheckCacheSize: len(types) * len(types),
+		})
+	})
+}
@@ -21,6 +21,7 @@ package interpreter_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence/activations"
@@ -29,6 +30,7 @@ import (
 	"github.com/onflow/cadence/sema"
 	"github.com/onflow/cadence/stdlib"
 	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/interpreter_utils"
 )
 
 func TestInterpretTransferCheck(t *testing.T) {
@@ -171,3 +173,417 @@ func TestInterpretTransferCheck(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+// parseCheckAndInterpretWithCopyOnWrite is like parseCheckAndInterpret,
+// but defers copies of arguments, see interpreter.Config.CopyOnWriteArgumentsEnabled
+func parseCheckAndInterpretWithCopyOnWrite(t testing.TB, code string) *interpreter.Interpreter {
+	inter, err := parseCheckAndInterpretWithOptions(
+		t,
+		code,
+		ParseCheckAndInterpretOptions{
+			Config: &interpreter.Config{
+				CopyOnWriteArgumentsEnabled: true,
+			},
+		},
+	)
+	require.NoError(t, err)
+	return inter
+}
+
+func TestInterpretCopyOnWriteArgumentTransfer(t *testing.T) {
+
+	t.Parallel()
+
+	newIntArray := func(inter *interpreter.Interpreter, values ...int64) *interpreter.ArrayValue {
+		elements := make([]interpreter.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, interpreter.NewUnmeteredIntValueFromInt64(value))
+		}
+		return interpreter.NewArrayValue(
+			inter,
+			interpreter.EmptyLocationRange,
+			&interpreter.VariableSizedStaticType{
+				Type: interpreter.PrimitiveStaticTypeInt,
+			},
+			common.ZeroAddress,
+			elements...,
+		)
+	}
+
+	t.Run("array, mutation of parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpretWithCopyOnWrite(t, `
+          fun mutate(_ xs: [Int]): [Int] {
+              xs.append(4)
+              xs[0] = 0
+              return xs
+          }
+
+          fun test(): [[Int]] {
+              let xs = [1, 2, 3]
+              let ys = mutate(xs)
+              return [xs, ys]
+          }
+        `)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				&interpreter.VariableSizedStaticType{
+					Type: &interpreter.VariableSizedStaticType{
+						Type: interpreter.PrimitiveStaticTypeInt,
+					},
+				},
+				common.ZeroAddress,
+				newIntArray(inter, 1, 2, 3),
+				newIntArray(inter, 0, 2, 3, 4),
+			),
+			result,
+		)
+	})
+
+	t.Run("array, mutation of argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpretWithCopyOnWrite(t, `
+          fun capture(_ xs: [Int]): fun(): [Int] {
+              return fun(): [Int] {
+                  return xs
+              }
+          }
+
+          fun test(): [[Int]] {
+              let xs = [1, 2, 3]
+              let get = capture(xs)
+              xs.append(4)
+              xs.remove(at: 0)
+              return [xs, get()]
+          }
+        `)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewArrayValue(
+				inter,
+				interpreter.EmptyLocationRange,
+				&interpreter.VariableSizedStaticType{
+					Type: &interpreter.VariableSizedStaticType{
+						Type: interpreter.PrimitiveStaticTypeInt,
+					},
+				},
+				common.ZeroAddress,
+				newIntArray(inter, 2, 3, 4),
+				newIntArray(inter, 1, 2, 3),
+			),
+			result,
+		)
+	})
+
+	t.Run("array, mutation of argument during iteration of parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpretWithCopyOnWrite(t, `
+          let xs = [1, 2, 3]
+
+          view fun sum(_ ys: [Int]): Int {
+              var sum = 0
+              for y in ys {
+                  sum = sum + y
+              }
+              return sum
+          }
+
+          fun appendAll(_ ys: [Int]) {
+              for y in ys {
+                  xs.append(y)
+              }
+          }
+
+          fun test(): Int {
+              appendAll(xs)
+              return sum(xs)
+          }
+        `)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(12),
+			result,
+		)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			newIntArray(inter, 1, 2, 3, 1, 2, 3),
+			inter.Globals.Get("xs").GetValue(inter),
+		)
+	})
+
+	t.Run("array, mutation of parameter during its iteration", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpretWithCopyOnWrite(t, `
+          fun appendAll(_ ys: [Int]) {
+              for y in ys {
+                  ys.append(y)
+              }
+          }
+
+          fun test() {
+              let xs = [1, 2, 3]
+              appendAll(xs)
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		RequireError(t, err)
+
+		assert.ErrorAs(t, err, &interpreter.ContainerMutatedDuringIterationError{})
+	})
+
+	t.Run("array, mutation of argument during its iteration", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpretWithCopyOnWrite(t, `
+          view fun length(_ ys: [Int]): Int {
+              return ys.length
+          }
+
+          fun test() {
+              let xs = [1, 2, 3]
+              for x in xs {
+                  length(xs)
+                  xs.append(x)
+              }
+          }
+        `)
+
+		_, err := inter.Invoke("test")
+		RequireError(t, err)
+
+		assert.ErrorAs(t, err, &interpreter.ContainerMutatedDuringIterationError{})
+	})
+
+	t.Run("nested array", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpretWithCopyOnWrite(t, `
+          fun mutate(_ xs: [[Int]]) {
+              xs[0].append(2)
+          }
+
+          fun test(): [Int] {
+              let xs = [[1]]
+              mutate(xs)
+              return xs[0]
+          }
+        `)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			newIntArray(inter, 1),
+			result,
+		)
+	})
+
+	t.Run("dictionary", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpretWithCopyOnWrite(t, `
+          fun mutate(_ xs: {String: Int}): {String: Int} {
+              xs["b"] = 2
+              xs.remove(key: "a")
+              return xs
+          }
+
+          fun test(): Bool {
+              let xs = {"a": 1}
+              let ys = mutate(xs)
+              xs["c"] = 3
+              return xs == {"a": 1, "c": 3}
+                  && ys == {"b": 2}
+          }
+        `)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.TrueValue,
+			result,
+		)
+	})
+
+	t.Run("resource array", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := parseCheckAndInterpretWithCopyOnWrite(t, `
+          resource R {}
+
+          fun count(_ rs: @[R]): @[R] {
+              return <-rs
+          }
+
+          fun test(): Int {
+              let rs <- [<-create R()]
+              let moved <- count(<-rs)
+              let length = moved.length
+              destroy moved
+              return length
+          }
+        `)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		AssertValuesEqual(
+			t,
+			inter,
+			interpreter.NewUnmeteredIntValueFromInt64(1),
+			result,
+		)
+	})
+}
+
+func TestInterpretCopyOnWriteArgumentTransferMetering(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      view fun length(_ xs: [Int]): Int {
+          return xs.length
+      }
+
+      fun append(_ xs: [Int]) {
+          xs.append(4)
+      }
+
+      fun test(mutate: Bool) {
+          let xs = [1, 2, 3]
+          if mutate {
+              append(xs)
+          } else {
+              length(xs)
+          }
+      }
+    `
+
+	meterElements := func(t *testing.T, mutate bool) uint64 {
+		meter := newTestMemoryGauge()
+		inter, err := parseCheckAndInterpretWithOptionsAndMemoryMetering(
+			t,
+			code,
+			ParseCheckAndInterpretOptions{
+				Config: &interpreter.Config{
+					CopyOnWriteArgumentsEnabled: true,
+				},
+			},
+			meter,
+		)
+		require.NoError(t, err)
+
+		_, err = inter.Invoke("test", interpreter.BoolValue(mutate))
+		require.NoError(t, err)
+
+		return meter.getMemory(common.MemoryKindAtreeArrayElementOverhead)
+	}
+
+	// The array is only copied if the parameter is mutated
+	assert.Less(t, meterElements(t, false), meterElements(t, true))
+}
+
+func benchmarkCopyOnWriteArgumentTransfer(b *testing.B, code string) {
+
+	inter := parseCheckAndInterpretWithCopyOnWrite(b, code)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := inter.Invoke("test")
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkInterpretArrayArgumentTransfer(b *testing.B) {
+
+	benchmarkCopyOnWriteArgumentTransfer(b, `
+      let xs: [Int] = []
+
+      view fun first(_ xs: [Int]): Int {
+          return xs[0]
+      }
+
+      fun test() {
+          if xs.length == 0 {
+              var i = 0
+              while i < 1000 {
+                  xs.append(i)
+                  i = i + 1
+              }
+          }
+
+          var i = 0
+          while i < 100 {
+              first(xs)
+              i = i + 1
+          }
+      }
+    `)
+}
+
+func BenchmarkInterpretDictionaryArgumentTransfer(b *testing.B) {
+
+	benchmarkCopyOnWriteArgumentTransfer(b, `
+      let xs: {Int: Int} = {}
+
+      view fun first(_ xs: {Int: Int}): Int? {
+          return xs[0]
+      }
+
+      fun test() {
+          if xs.length == 0 {
+              var i = 0
+              while i < 1000 {
+                  xs[i] = i
+                  i = i + 1
+              }
+          }
+
+          var i = 0
+          while i < 100 {
+              first(xs)
+              i = i + 1
+          }
+      }
+    `)
+}
@@ -94,7 +94,7 @@ type Value interface {
 	isValue()
 	Accept(interpreter *Interpreter, visitor Visitor, locationRange LocationRange)
 	Walk(interpreter *Interpreter, walkChild func(Value), locationRange LocationRange)
-	StaticType(interpreter *Interpreter) StaticType
+	StaticType(context ValueContext) StaticType
 	// ConformsToStaticType returns true if the value (i.e. its dynamic type)
 	// conforms to its own static type.
 	// Non-container values trivially always conform to their own static type.
@@ -109,8 +109,8 @@ type Value interface {
 		results TypeConformanceResults,
 	) bool
 	RecursiveString(seenReferences SeenReferences) string
-	MeteredString(interpreter *Interpreter, seenReferences SeenReferences, locationRange LocationRange) string
-	IsResourceKinded(interpreter *Interpreter) bool
+	MeteredString(context ValueContext, seenReferences SeenReferences, locationRange LocationRange) string
+	IsResourceKinded(context ValueContext) bool
 	NeedsStoreTo(address atree.Address) bool
 	Transfer(
 		interpreter *Interpreter,
@@ -129,7 +129,7 @@ type Value interface {
 	// NOTE: not used by interpreter, but used externally (e.g. state migration)
 	// NOTE: memory metering is unnecessary for Clone methods
 	Clone(interpreter *Interpreter) Value
-	IsImportable(interpreter *Interpreter, locationRange LocationRange) bool
+	IsImportable(context ValueContext, locationRange LocationRange) bool
 }
 
 // ValueIndexableValue
@@ -42,6 +42,8 @@ func NewAccountCapabilitiesValue(
 	existsFunction BoundFunctionGenerator,
 	publishFunction BoundFunctionGenerator,
 	unpublishFunction BoundFunctionGenerator,
+	publishAllFunction BoundFunctionGenerator,
+	unpublishAllFunction BoundFunctionGenerator,
 	storageCapabilitiesConstructor func() Value,
 	accountCapabilitiesConstructor func() Value,
 ) Value {
@@ -66,6 +68,10 @@ func NewAccountCapabilitiesValue(
 			return publishFunction(capabilities)
 		case sema.Account_CapabilitiesTypeUnpublishFunctionName:
 			return unpublishFunction(capabilities)
+		case sema.Account_CapabilitiesTypePublishAllFunctionName:
+			return publishAllFunction(capabilities)
+		case sema.Account_CapabilitiesTypeUnpublishAllFunctionName:
+			return unpublishAllFunction(capabilities)
 		}
 
 		return nil
@@ -80,10 +86,10 @@ func NewAccountCapabilitiesValue(
 	}
 
 	var str string
-	stringer := func(interpreter *Interpreter, seenReferences SeenReferences, locationRange LocationRange) string {
+	stringer := func(context ValueContext, seenReferences SeenReferences, locationRange LocationRange) string {
 		if str == "" {
-			common.UseMemory(interpreter, common.AccountCapabilitiesStringMemoryUsage)
-			addressStr := address.MeteredString(interpreter, seenReferences, locationRange)
+			common.UseMemory(context, common.AccountCapabilitiesStringMemoryUsage)
+			addressStr := address.MeteredString(context, seenReferences, locationRange)
 			str = fmt.Sprintf("Account.Capabilities(%s)", addressStr)
 		}
 		return str
@@ -40,6 +40,7 @@ func NewAccountContractsValue(
 	updateFunction BoundFunctionGenerator,
 	tryUpdateFunction BoundFunctionGenerator,
 	getFunction BoundFunctionGenerator,
+	metadataFunction BoundFunctionGenerator,
 	borrowFunction BoundFunctionGenerator,
 	removeFunction BoundFunctionGenerator,
 	namesGetter ContractNamesGetter,
@@ -55,6 +56,8 @@ func NewAccountContractsValue(
 			return addFunction(accountContracts)
 		case sema.Account_ContractsTypeGetFunctionName:
 			return getFunction(accountContracts)
+		case sema.Account_ContractsTypeMetadataFunctionName:
+			return metadataFunction(accountContracts)
 		case sema.Account_ContractsTypeBorrowFunctionName:
 			return borrowFunction(accountContracts)
 		case sema.Account_ContractsTypeRemoveFunctionName:
@@ -82,10 +85,10 @@ func NewAccountContractsValue(
 	}
 
 	var str string
-	stringer := func(interpreter *Interpreter, seenReferences SeenReferences, locationRange LocationRange) string {
+	stringer := func(context ValueContext, seenReferences SeenReferences, locationRange LocationRange) string {
 		if str == "" {
-			common.UseMemory(interpreter, common.AccountContractsStringMemoryUsage)
-			addressStr := address.MeteredString(interpreter, seenReferences, locationRange)
+			common.UseMemory(context, common.AccountContractsStringMemoryUsage)
+			addressStr := address.MeteredString(context, seenReferences, locationRange)
 			str = fmt.Sprintf("Account.Contracts(%s)", addressStr)
 		}
 		return str
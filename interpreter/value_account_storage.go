@@ -66,6 +66,9 @@ func NewAccountStorageValue(
 		case sema.Account_StorageTypeTypeFunctionName:
 			return inter.authAccountTypeFunction(storageValue, address)
 
+		case sema.Account_StorageTypeSizeFunctionName:
+			return inter.authAccountSizeFunction(storageValue, address)
+
 		case sema.Account_StorageTypeLoadFunctionName:
 			return inter.authAccountLoadFunction(storageValue, address)
 
@@ -108,10 +111,10 @@ func NewAccountStorageValue(
 	}
 
 	var str string
-	stringer := func(interpreter *Interpreter, seenReferences SeenReferences, locationRange LocationRange) string {
+	stringer := func(context ValueContext, seenReferences SeenReferences, locationRange LocationRange) string {
 		if str == "" {
-			common.UseMemory(interpreter, common.AccountStorageStringMemoryUsage)
-			addressStr := address.MeteredString(interpreter, seenReferences, locationRange)
+			common.UseMemory(context, common.AccountStorageStringMemoryUsage)
+			addressStr := address.MeteredString(context, seenReferences, locationRange)
 			str = fmt.Sprintf("Account.Storage(%s)", addressStr)
 		}
 		return str
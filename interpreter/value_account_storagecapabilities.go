@@ -39,6 +39,7 @@ func NewAccountStorageCapabilitiesValue(
 	forEachControllerFunction BoundFunctionGenerator,
 	issueFunction BoundFunctionGenerator,
 	issueWithTypeFunction BoundFunctionGenerator,
+	issueAllFunction BoundFunctionGenerator,
 ) Value {
 
 	var storageCapabilities *SimpleCompositeValue
@@ -57,6 +58,8 @@ func NewAccountStorageCapabilitiesValue(
 			return issueFunction(storageCapabilities)
 		case sema.Account_StorageCapabilitiesTypeIssueWithTypeFunctionName:
 			return issueWithTypeFunction(storageCapabilities)
+		case sema.Account_StorageCapabilitiesTypeIssueAllFunctionName:
+			return issueAllFunction(storageCapabilities)
 		}
 
 		return nil
@@ -71,10 +74,10 @@ func NewAccountStorageCapabilitiesValue(
 	}
 
 	var str string
-	stringer := func(interpreter *Interpreter, seenReferences SeenReferences, locationRange LocationRange) string {
+	stringer := func(context ValueContext, seenReferences SeenReferences, locationRange LocationRange) string {
 		if str == "" {
-			common.UseMemory(interpreter, common.AccountStorageCapabilitiesStringMemoryUsage)
-			addressStr := address.MeteredString(interpreter, seenReferences, locationRange)
+			common.UseMemory(context, common.AccountStorageCapabilitiesStringMemoryUsage)
+			addressStr := address.MeteredString(context, seenReferences, locationRange)
 			str = fmt.Sprintf("Account.StorageCapabilities(%s)", addressStr)
 		}
 		return str
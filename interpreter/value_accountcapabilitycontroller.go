@@ -96,11 +96,11 @@ func (v *AccountCapabilityControllerValue) Walk(_ *Interpreter, walkChild func(V
 	walkChild(v.CapabilityID)
 }
 
-func (v *AccountCapabilityControllerValue) StaticType(_ *Interpreter) StaticType {
+func (v *AccountCapabilityControllerValue) StaticType(_ ValueContext) StaticType {
 	return PrimitiveStaticTypeAccountCapabilityController
 }
 
-func (*AccountCapabilityControllerValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (*AccountCapabilityControllerValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return false
 }
 
@@ -116,15 +116,15 @@ func (v *AccountCapabilityControllerValue) RecursiveString(seenReferences SeenRe
 }
 
 func (v *AccountCapabilityControllerValue) MeteredString(
-	interpreter *Interpreter,
+	context ValueContext,
 	seenReferences SeenReferences,
 	locationRange LocationRange,
 ) string {
-	common.UseMemory(interpreter, common.AccountCapabilityControllerValueStringMemoryUsage)
+	common.UseMemory(context, common.AccountCapabilityControllerValueStringMemoryUsage)
 
 	return format.AccountCapabilityController(
-		v.BorrowType.MeteredString(interpreter),
-		v.CapabilityID.MeteredString(interpreter, seenReferences, locationRange),
+		v.BorrowType.MeteredString(context),
+		v.CapabilityID.MeteredString(context, seenReferences, locationRange),
 	)
 }
 
@@ -169,7 +169,7 @@ func (*AccountCapabilityControllerValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (*AccountCapabilityControllerValue) IsResourceKinded(_ *Interpreter) bool {
+func (*AccountCapabilityControllerValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
@@ -203,7 +203,14 @@ func (v *AccountCapabilityControllerValue) ByteSize() uint32 {
 	return mustStorableSize(v)
 }
 
-func (v *AccountCapabilityControllerValue) StoredValue(_ atree.SlabStorage) (atree.Value, error) {
+func (v *AccountCapabilityControllerValue) StoredValue(storage atree.SlabStorage) (atree.Value, error) {
+	if sharesSlabs(storage) {
+		// The functions are injected and initialized lazily
+		return NewUnmeteredAccountCapabilityControllerValue(
+			v.BorrowType,
+			v.CapabilityID,
+		), nil
+	}
 	return v, nil
 }
 
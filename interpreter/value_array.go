@@ -24,6 +24,7 @@ import (
 
 	"github.com/onflow/atree"
 
+	"github.com/onflow/cadence/ast"
 	"github.com/onflow/cadence/common"
 	"github.com/onflow/cadence/errors"
 	"github.com/onflow/cadence/format"
@@ -39,6 +40,7 @@ type ArrayValue struct {
 	isResourceKinded *bool
 	elementSize      uint
 	isDestroyed      bool
+	copyOnWrite      copyOnWrite
 }
 
 type ArrayValueIterator struct {
@@ -83,8 +85,9 @@ func NewArrayValue(
 	var index int
 	count := len(values)
 
-	return NewArrayValueWithIterator(
+	return newArrayValueWithIterator(
 		interpreter,
+		locationRange,
 		arrayType,
 		address,
 		uint64(count),
@@ -112,14 +115,38 @@ func NewArrayValue(
 	)
 }
 
+// NewArrayValueWithIterator returns a new array with the values returned by the given function.
+// If the array value creation limit is exceeded, the error is reported at the location of the interpreter
 func NewArrayValueWithIterator(
 	interpreter *Interpreter,
 	arrayType ArrayStaticType,
 	address common.Address,
 	countOverestimate uint64,
 	values func() Value,
+) *ArrayValue {
+	return newArrayValueWithIterator(
+		interpreter,
+		LocationRange{
+			Location:    interpreter.Location,
+			HasPosition: ast.EmptyRange,
+		},
+		arrayType,
+		address,
+		countOverestimate,
+		values,
+	)
+}
+
+func newArrayValueWithIterator(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	arrayType ArrayStaticType,
+	address common.Address,
+	countOverestimate uint64,
+	values func() Value,
 ) *ArrayValue {
 	interpreter.ReportComputation(common.ComputationKindCreateArrayValue, 1)
+	interpreter.reportValueCreation(CreatedValueKindArray, locationRange)
 
 	config := interpreter.SharedState.Config
 
@@ -246,11 +273,43 @@ func (v *ArrayValue) Iterate(
 	transferElements bool,
 	locationRange LocationRange,
 ) {
+	if transferElements {
+		iterateElement := f
+		f = func(element Value) (resume bool) {
+			// Each element must be transferred before passing onto the function.
+			element = element.Transfer(
+				interpreter,
+				locationRange,
+				atree.Address{},
+				false,
+				nil,
+				nil,
+				false, // value has a parent container because it is from iterator.
+			)
+
+			return iterateElement(element)
+		}
+	}
+
 	v.iterate(
 		interpreter,
 		v.array.Iterate,
 		f,
-		transferElements,
+		locationRange,
+	)
+}
+
+// IterateReadOnly iterates over all elements of the array.
+// DO NOT perform storage mutations in the callback!
+func (v *ArrayValue) IterateReadOnly(
+	context ValueContext,
+	f func(element Value) (resume bool),
+	locationRange LocationRange,
+) {
+	v.iterate(
+		context,
+		v.array.IterateReadOnly,
+		f,
 		locationRange,
 	)
 }
@@ -258,47 +317,30 @@ func (v *ArrayValue) Iterate(
 // IterateReadOnlyLoaded iterates over all LOADED elements of the array.
 // DO NOT perform storage mutations in the callback!
 func (v *ArrayValue) IterateReadOnlyLoaded(
-	interpreter *Interpreter,
+	context ValueContext,
 	f func(element Value) (resume bool),
 	locationRange LocationRange,
 ) {
-	const transferElements = false
-
 	v.iterate(
-		interpreter,
+		context,
 		v.array.IterateReadOnlyLoadedValues,
 		f,
-		transferElements,
 		locationRange,
 	)
 }
 
 func (v *ArrayValue) iterate(
-	interpreter *Interpreter,
+	context ValueContext,
 	atreeIterate func(fn atree.ArrayIterationFunc) error,
 	f func(element Value) (resume bool),
-	transferElements bool,
 	locationRange LocationRange,
 ) {
 	iterate := func() {
 		err := atreeIterate(func(element atree.Value) (resume bool, err error) {
 			// atree.Array iteration provides low-level atree.Value,
 			// convert to high-level interpreter.Value
-			elementValue := MustConvertStoredValue(interpreter, element)
-			interpreter.checkInvalidatedResourceOrResourceReference(elementValue, locationRange)
-
-			if transferElements {
-				// Each element must be transferred before passing onto the function.
-				elementValue = elementValue.Transfer(
-					interpreter,
-					locationRange,
-					atree.Address{},
-					false,
-					nil,
-					nil,
-					false, // value has a parent container because it is from iterator.
-				)
-			}
+			elementValue := MustConvertStoredValue(context, element)
+			checkInvalidatedResourceOrResourceReference(context, elementValue, locationRange)
 
 			resume = f(elementValue)
 
@@ -309,7 +351,66 @@ func (v *ArrayValue) iterate(
 		}
 	}
 
-	interpreter.withMutationPrevention(v.ValueID(), iterate)
+	v.copyOnWrite.withIteration(func() {
+		withMutationPrevention(context, v.ValueID(), iterate)
+	})
+}
+
+// IterateBatch iterates over at most limit elements of the array, starting at the given index,
+// so that large arrays can be processed in bounded batches, e.g. across multiple calls.
+// DO NOT perform storage mutations in the callback!
+//
+// It returns the index of the element following the last visited element,
+// which can be passed to a following call to resume the iteration,
+// and whether the iteration is complete, i.e. there are no elements after it.
+func (v *ArrayValue) IterateBatch(
+	interpreter *Interpreter,
+	startIndex int,
+	limit int,
+	f func(element Value) (resume bool),
+	locationRange LocationRange,
+) (
+	nextIndex int,
+	done bool,
+) {
+	count := v.Count()
+
+	if startIndex < 0 || startIndex > count {
+		panic(ArrayIndexOutOfBoundsError{
+			Index:         startIndex,
+			Size:          count,
+			LocationRange: locationRange,
+		})
+	}
+
+	if limit <= 0 {
+		panic(errors.NewUnexpectedError("invalid array iteration limit: %d", limit))
+	}
+
+	endIndex := count
+	if limit < count-startIndex {
+		endIndex = startIndex + limit
+	}
+
+	nextIndex = startIndex
+
+	v.iterate(
+		interpreter,
+		func(fn atree.ArrayIterationFunc) error {
+			return v.array.IterateReadOnlyRange(
+				uint64(startIndex),
+				uint64(endIndex),
+				fn,
+			)
+		},
+		func(element Value) (resume bool) {
+			nextIndex++
+			return f(element)
+		},
+		locationRange,
+	)
+
+	return nextIndex, nextIndex >= count
 }
 
 func (v *ArrayValue) Walk(
@@ -328,17 +429,17 @@ func (v *ArrayValue) Walk(
 	)
 }
 
-func (v *ArrayValue) StaticType(_ *Interpreter) StaticType {
+func (v *ArrayValue) StaticType(_ ValueContext) StaticType {
 	// TODO meter
 	return v.Type
 }
 
-func (v *ArrayValue) IsImportable(inter *Interpreter, locationRange LocationRange) bool {
+func (v *ArrayValue) IsImportable(context ValueContext, locationRange LocationRange) bool {
 	importable := true
-	v.Iterate(
-		inter,
+	v.IterateReadOnly(
+		context,
 		func(element Value) (resume bool) {
-			if !element.IsImportable(inter, locationRange) {
+			if !element.IsImportable(context, locationRange) {
 				importable = false
 				// stop iteration
 				return false
@@ -347,7 +448,6 @@ func (v *ArrayValue) IsImportable(inter *Interpreter, locationRange LocationRang
 			// continue iteration
 			return true
 		},
-		false,
 		locationRange,
 	)
 
@@ -428,8 +528,9 @@ func (v *ArrayValue) Concat(interpreter *Interpreter, locationRange LocationRang
 
 	elementType := v.Type.ElementType()
 
-	return NewArrayValueWithIterator(
+	return newArrayValueWithIterator(
 		interpreter,
+		locationRange,
 		v.Type,
 		common.ZeroAddress,
 		v.array.Count()+other.array.Count(),
@@ -529,7 +630,7 @@ func (v *ArrayValue) SetKey(interpreter *Interpreter, locationRange LocationRang
 
 func (v *ArrayValue) Set(interpreter *Interpreter, locationRange LocationRange, index int, element Value) {
 
-	interpreter.validateMutation(v.ValueID(), locationRange)
+	v.prepareMutation(interpreter, locationRange)
 
 	// We only need to check the lower bound before converting from `int` (signed) to `uint64` (unsigned).
 	// atree's Array.Set function will check the upper bound and report an atree.IndexOutOfBoundsError
@@ -543,6 +644,7 @@ func (v *ArrayValue) Set(interpreter *Interpreter, locationRange LocationRange,
 	}
 
 	interpreter.checkContainerMutation(v.Type.ElementType(), element, locationRange)
+	interpreter.CheckStoredValueSize(common.Address(v.StorageAddress()), element, locationRange)
 
 	common.UseMemory(interpreter, common.AtreeArrayElementOverhead)
 
@@ -583,29 +685,28 @@ func (v *ArrayValue) RecursiveString(seenReferences SeenReferences) string {
 	return v.MeteredString(nil, seenReferences, EmptyLocationRange)
 }
 
-func (v *ArrayValue) MeteredString(interpreter *Interpreter, seenReferences SeenReferences, locationRange LocationRange) string {
+func (v *ArrayValue) MeteredString(context ValueContext, seenReferences SeenReferences, locationRange LocationRange) string {
 	// if n > 0:
 	// len = open-bracket + close-bracket + ((n-1) comma+space)
 	//     = 2 + 2n - 2
 	//     = 2n
 	// Always +2 to include empty array case (over estimate).
 	// Each elements' string value is metered individually.
-	common.UseMemory(interpreter, common.NewRawStringMemoryUsage(v.Count()*2+2))
+	common.UseMemory(context, common.NewRawStringMemoryUsage(v.Count()*2+2))
 
 	values := make([]string, v.Count())
 
 	i := 0
 
-	v.Iterate(
-		interpreter,
+	v.IterateReadOnly(
+		context,
 		func(value Value) (resume bool) {
 			// ok to not meter anything created as part of this iteration, since we will discard the result
 			// upon creating the string
-			values[i] = value.MeteredString(interpreter, seenReferences, locationRange)
+			values[i] = value.MeteredString(context, seenReferences, locationRange)
 			i++
 			return true
 		},
-		false,
 		locationRange,
 	)
 
@@ -614,7 +715,9 @@ func (v *ArrayValue) MeteredString(interpreter *Interpreter, seenReferences Seen
 
 func (v *ArrayValue) Append(interpreter *Interpreter, locationRange LocationRange, element Value) {
 
-	interpreter.validateMutation(v.ValueID(), locationRange)
+	v.prepareMutation(interpreter, locationRange)
+
+	interpreter.ReportComputation(common.ComputationKindArrayAppend, 1)
 
 	// length increases by 1
 	dataSlabs, metaDataSlabs := common.AdditionalAtreeMemoryUsage(
@@ -627,6 +730,7 @@ func (v *ArrayValue) Append(interpreter *Interpreter, locationRange LocationRang
 	common.UseMemory(interpreter, common.AtreeArrayElementOverhead)
 
 	interpreter.checkContainerMutation(v.Type.ElementType(), element, locationRange)
+	interpreter.CheckStoredValueSize(common.Address(v.StorageAddress()), element, locationRange)
 
 	element = element.Transfer(
 		interpreter,
@@ -670,7 +774,7 @@ func (v *ArrayValue) InsertWithoutTransfer(
 	index int,
 	element Value,
 ) {
-	interpreter.validateMutation(v.ValueID(), locationRange)
+	v.prepareMutation(interpreter, locationRange)
 
 	// We only need to check the lower bound before converting from `int` (signed) to `uint64` (unsigned).
 	// atree's Array.Insert function will check the upper bound and report an atree.IndexOutOfBoundsError
@@ -705,6 +809,8 @@ func (v *ArrayValue) InsertWithoutTransfer(
 
 func (v *ArrayValue) Insert(interpreter *Interpreter, locationRange LocationRange, index int, element Value) {
 
+	interpreter.ReportComputation(common.ComputationKindArrayInsert, 1)
+
 	address := v.array.Address()
 
 	preventTransfer := map[atree.ValueID]struct{}{
@@ -722,6 +828,7 @@ func (v *ArrayValue) Insert(interpreter *Interpreter, locationRange LocationRang
 	)
 
 	interpreter.checkContainerMutation(v.Type.ElementType(), element, locationRange)
+	interpreter.CheckStoredValueSize(common.Address(v.StorageAddress()), element, locationRange)
 
 	v.InsertWithoutTransfer(
 		interpreter,
@@ -742,7 +849,7 @@ func (v *ArrayValue) RemoveWithoutTransfer(
 	index int,
 ) atree.Storable {
 
-	interpreter.validateMutation(v.ValueID(), locationRange)
+	v.prepareMutation(interpreter, locationRange)
 
 	// We only need to check the lower bound before converting from `int` (signed) to `uint64` (unsigned).
 	// atree's Array.Remove function will check the upper bound and report an atree.IndexOutOfBoundsError
@@ -769,6 +876,8 @@ func (v *ArrayValue) RemoveWithoutTransfer(
 }
 
 func (v *ArrayValue) Remove(interpreter *Interpreter, locationRange LocationRange, index int) Value {
+	interpreter.ReportComputation(common.ComputationKindArrayRemove, 1)
+
 	storable := v.RemoveWithoutTransfer(interpreter, locationRange, index)
 
 	value := StoredValue(interpreter, storable, interpreter.Storage())
@@ -836,9 +945,11 @@ func (v *ArrayValue) Contains(
 	}
 
 	var result bool
+	var comparisons uint
 	v.Iterate(
 		interpreter,
 		func(element Value) (resume bool) {
+			comparisons++
 			if needleEquatable.Equal(interpreter, locationRange, element) {
 				result = true
 				// stop iteration
@@ -851,6 +962,9 @@ func (v *ArrayValue) Contains(
 		locationRange,
 	)
 
+	// Meter the number of elements that were compared to the needle
+	interpreter.ReportComputation(common.ComputationKindArrayContains, comparisons)
+
 	return AsBoolValue(result)
 }
 
@@ -1341,6 +1455,12 @@ func (v *ArrayValue) Transfer(
 		}()
 	}
 
+	if remove {
+		// The elements are removed from the atree array,
+		// so it must not be shared with other array values
+		v.unshare(interpreter, locationRange)
+	}
+
 	currentValueID := v.ValueID()
 
 	if preventTransfer == nil {
@@ -1360,54 +1480,20 @@ func (v *ArrayValue) Transfer(
 
 	if needsStoreTo || !isResourceKinded {
 
-		// Use non-readonly iterator here because iterated
-		// value can be removed if remove parameter is true.
-		iterator, err := v.array.Iterator()
-		if err != nil {
-			panic(errors.NewExternalError(err))
+		if !remove {
+			interpreter.reportValueCreation(CreatedValueKindArray, locationRange)
 		}
 
-		elementUsage, dataSlabs, metaDataSlabs := common.NewAtreeArrayMemoryUsages(
-			v.array.Count(),
-			v.elementSize,
-		)
-		common.UseMemory(interpreter, elementUsage)
-		common.UseMemory(interpreter, dataSlabs)
-		common.UseMemory(interpreter, metaDataSlabs)
-
-		array, err = atree.NewArrayFromBatchData(
-			config.Storage,
+		array = v.copyAtreeArray(
+			interpreter,
+			locationRange,
 			address,
-			v.array.Type(),
-			func() (atree.Value, error) {
-				value, err := iterator.Next()
-				if err != nil {
-					return nil, err
-				}
-				if value == nil {
-					return nil, nil
-				}
-
-				element := MustConvertStoredValue(interpreter, value).
-					Transfer(
-						interpreter,
-						locationRange,
-						address,
-						remove,
-						nil,
-						preventTransfer,
-						false, // value has a parent container because it is from iterator.
-					)
-
-				return element, nil
-			},
+			remove,
+			preventTransfer,
 		)
-		if err != nil {
-			panic(errors.NewExternalError(err))
-		}
 
 		if remove {
-			err = v.array.PopIterate(interpreter.RemoveReferencedSlab)
+			err := v.array.PopIterate(interpreter.RemoveReferencedSlab)
 			if err != nil {
 				panic(errors.NewExternalError(err))
 			}
@@ -1450,6 +1536,134 @@ func (v *ArrayValue) Transfer(
 	return res
 }
 
+// copyAtreeArray returns a copy of the atree array backing the array value,
+// with the elements transferred to the given address
+func (v *ArrayValue) copyAtreeArray(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	address atree.Address,
+	remove bool,
+	preventTransfer map[atree.ValueID]struct{},
+) *atree.Array {
+
+	config := interpreter.SharedState.Config
+
+	// Use non-readonly iterator here because iterated
+	// value can be removed if remove parameter is true.
+	iterator, err := v.array.Iterator()
+	if err != nil {
+		panic(errors.NewExternalError(err))
+	}
+
+	elementUsage, dataSlabs, metaDataSlabs := common.NewAtreeArrayMemoryUsages(
+		v.array.Count(),
+		v.elementSize,
+	)
+	common.UseMemory(interpreter, elementUsage)
+	common.UseMemory(interpreter, dataSlabs)
+	common.UseMemory(interpreter, metaDataSlabs)
+
+	array, err := atree.NewArrayFromBatchData(
+		config.Storage,
+		address,
+		v.array.Type(),
+		func() (atree.Value, error) {
+			value, err := iterator.Next()
+			if err != nil {
+				return nil, err
+			}
+			if value == nil {
+				return nil, nil
+			}
+
+			element := MustConvertStoredValue(interpreter, value).
+				Transfer(
+					interpreter,
+					locationRange,
+					address,
+					remove,
+					nil,
+					preventTransfer,
+					false, // value has a parent container because it is from iterator.
+				)
+
+			return element, nil
+		},
+	)
+	if err != nil {
+		panic(errors.NewExternalError(err))
+	}
+
+	return array
+}
+
+// canShareCopyOnWrite returns true if the atree array backing the array value
+// can be shared with a copy of the array value, see transferArgument
+func (v *ArrayValue) canShareCopyOnWrite(interpreter *Interpreter) bool {
+	return !v.IsResourceKinded(interpreter) &&
+		v.StorageAddress() == (atree.Address{}) &&
+		isCopyOnWriteElementType(v.Type.ElementType())
+}
+
+// shareCopyOnWrite returns a copy of the array value,
+// which shares the atree array with the array value.
+// The atree array is copied when one of the array values sharing it is mutated
+func (v *ArrayValue) shareCopyOnWrite(interpreter *Interpreter) *ArrayValue {
+
+	// Report the computation of the transfer,
+	// so the computation is independent of whether the copy is performed
+	interpreter.ReportComputation(
+		common.ComputationKindTransferArrayValue,
+		uint(v.Count()),
+	)
+
+	res := newArrayValueFromAtreeArray(
+		interpreter,
+		v.Type,
+		v.elementSize,
+		v.array,
+	)
+
+	res.semaType = v.semaType
+	res.isResourceKinded = v.isResourceKinded
+
+	v.copyOnWrite.shareWith(&res.copyOnWrite)
+
+	return res
+}
+
+// unshare copies the atree array backing the array value,
+// if it is shared with other array values
+func (v *ArrayValue) unshare(interpreter *Interpreter, locationRange LocationRange) {
+	if !v.copyOnWrite.isShared() {
+		v.copyOnWrite.unshare()
+		return
+	}
+
+	v.array = v.copyAtreeArray(
+		interpreter,
+		locationRange,
+		v.StorageAddress(),
+		false,
+		nil,
+	)
+
+	v.copyOnWrite.unshare()
+}
+
+// prepareMutation ensures the array value can be mutated:
+// If the atree array is shared with other array values, it is copied,
+// and the array value must not be iterated over
+func (v *ArrayValue) prepareMutation(interpreter *Interpreter, locationRange LocationRange) {
+	if v.copyOnWrite.isShared() {
+		v.copyOnWrite.checkNotIterated(locationRange)
+		v.unshare(interpreter, locationRange)
+	}
+
+	interpreter.checkStoredValueMutation(v.GetOwner(), locationRange)
+	interpreter.validateMutation(v.ValueID(), locationRange)
+}
+
 func (v *ArrayValue) Clone(interpreter *Interpreter) Value {
 	config := interpreter.SharedState.Config
 
@@ -1515,6 +1729,13 @@ func (v *ArrayValue) DeepRemove(interpreter *Interpreter, hasNoParentContainer b
 		}()
 	}
 
+	if v.copyOnWrite.isShared() {
+		// The atree array is still used by the other array values sharing it,
+		// so only stop sharing it, instead of removing it
+		v.copyOnWrite.unshare()
+		return
+	}
+
 	// Remove nested values and storables
 
 	storage := v.array.Storage
@@ -1550,10 +1771,10 @@ func (v *ArrayValue) GetOwner() common.Address {
 	return common.Address(v.StorageAddress())
 }
 
-func (v *ArrayValue) SemaType(interpreter *Interpreter) sema.ArrayType {
+func (v *ArrayValue) SemaType(context ValueContext) sema.ArrayType {
 	if v.semaType == nil {
 		// this function will panic already if this conversion fails
-		v.semaType, _ = interpreter.MustConvertStaticToSemaType(v.Type).(sema.ArrayType)
+		v.semaType, _ = context.MustConvertStaticToSemaType(v.Type).(sema.ArrayType)
 	}
 	return v.semaType
 }
@@ -1562,9 +1783,9 @@ func (v *ArrayValue) NeedsStoreTo(address atree.Address) bool {
 	return address != v.StorageAddress()
 }
 
-func (v *ArrayValue) IsResourceKinded(interpreter *Interpreter) bool {
+func (v *ArrayValue) IsResourceKinded(context ValueContext) bool {
 	if v.isResourceKinded == nil {
-		isResourceKinded := v.SemaType(interpreter).IsResourceType()
+		isResourceKinded := v.SemaType(context).IsResourceType()
 		v.isResourceKinded = &isResourceKinded
 	}
 	return *v.isResourceKinded
@@ -1617,8 +1838,9 @@ func (v *ArrayValue) Slice(
 		panic(errors.NewExternalError(err))
 	}
 
-	return NewArrayValueWithIterator(
+	return newArrayValueWithIterator(
 		interpreter,
+		locationRange,
 		NewVariableSizedStaticType(interpreter, v.Type.ElementType()),
 		common.ZeroAddress,
 		uint64(toIndex-fromIndex),
@@ -1661,8 +1883,9 @@ func (v *ArrayValue) Reverse(
 	count := v.Count()
 	index := count - 1
 
-	return NewArrayValueWithIterator(
+	return newArrayValueWithIterator(
 		interpreter,
+		locationRange,
 		v.Type,
 		common.ZeroAddress,
 		uint64(count),
@@ -1710,8 +1933,9 @@ func (v *ArrayValue) Filter(
 		panic(errors.NewExternalError(err))
 	}
 
-	return NewArrayValueWithIterator(
+	return newArrayValueWithIterator(
 		interpreter,
+		locationRange,
 		NewVariableSizedStaticType(interpreter, v.Type.ElementType()),
 		common.ZeroAddress,
 		uint64(v.Count()), // worst case estimation.
@@ -1812,8 +2036,9 @@ func (v *ArrayValue) Map(
 		panic(errors.NewExternalError(err))
 	}
 
-	return NewArrayValueWithIterator(
+	return newArrayValueWithIterator(
 		interpreter,
+		locationRange,
 		returnArrayStaticType,
 		common.ZeroAddress,
 		uint64(v.Count()),
@@ -1892,8 +2117,9 @@ func (v *ArrayValue) ToVariableSized(
 		panic(errors.NewExternalError(err))
 	}
 
-	return NewArrayValueWithIterator(
+	return newArrayValueWithIterator(
 		interpreter,
+		locationRange,
 		variableSizedType,
 		common.ZeroAddress,
 		uint64(v.Count()),
@@ -1961,8 +2187,9 @@ func (v *ArrayValue) ToConstantSized(
 		panic(errors.NewExternalError(err))
 	}
 
-	constantSizedArray := NewArrayValueWithIterator(
+	constantSizedArray := newArrayValueWithIterator(
 		interpreter,
+		locationRange,
 		constantSizedType,
 		common.ZeroAddress,
 		uint64(count),
@@ -56,11 +56,11 @@ func (BoolValue) Walk(_ *Interpreter, _ func(Value), _ LocationRange) {
 	// NO-OP
 }
 
-func (BoolValue) StaticType(interpreter *Interpreter) StaticType {
-	return NewPrimitiveStaticType(interpreter, PrimitiveStaticTypeBool)
+func (BoolValue) StaticType(context ValueContext) StaticType {
+	return NewPrimitiveStaticType(context, PrimitiveStaticTypeBool)
 }
 
-func (BoolValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (BoolValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return sema.BoolType.Importable
 }
 
@@ -136,11 +136,11 @@ func (v BoolValue) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v BoolValue) MeteredString(interpreter *Interpreter, _ SeenReferences, locationRange LocationRange) string {
+func (v BoolValue) MeteredString(context ValueContext, _ SeenReferences, locationRange LocationRange) string {
 	if v {
-		common.UseMemory(interpreter, common.TrueStringMemoryUsage)
+		common.UseMemory(context, common.TrueStringMemoryUsage)
 	} else {
-		common.UseMemory(interpreter, common.FalseStringMemoryUsage)
+		common.UseMemory(context, common.FalseStringMemoryUsage)
 	}
 
 	return v.String()
@@ -162,7 +162,7 @@ func (BoolValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (BoolValue) IsResourceKinded(_ *Interpreter) bool {
+func (BoolValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
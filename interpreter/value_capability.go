@@ -108,14 +108,14 @@ func (v *IDCapabilityValue) Walk(_ *Interpreter, walkChild func(Value), _ Locati
 	walkChild(v.address)
 }
 
-func (v *IDCapabilityValue) StaticType(inter *Interpreter) StaticType {
+func (v *IDCapabilityValue) StaticType(context ValueContext) StaticType {
 	return NewCapabilityStaticType(
-		inter,
+		context,
 		v.BorrowType,
 	)
 }
 
-func (v *IDCapabilityValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (v *IDCapabilityValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return false
 }
 
@@ -131,13 +131,13 @@ func (v *IDCapabilityValue) RecursiveString(seenReferences SeenReferences) strin
 	)
 }
 
-func (v *IDCapabilityValue) MeteredString(interpreter *Interpreter, seenReferences SeenReferences, locationRange LocationRange) string {
-	common.UseMemory(interpreter, common.IDCapabilityValueStringMemoryUsage)
+func (v *IDCapabilityValue) MeteredString(context ValueContext, seenReferences SeenReferences, locationRange LocationRange) string {
+	common.UseMemory(context, common.IDCapabilityValueStringMemoryUsage)
 
 	return format.Capability(
-		v.BorrowType.MeteredString(interpreter),
-		v.address.MeteredString(interpreter, seenReferences, locationRange),
-		v.ID.MeteredString(interpreter, seenReferences, locationRange),
+		v.BorrowType.MeteredString(context),
+		v.address.MeteredString(context, seenReferences, locationRange),
+		v.ID.MeteredString(context, seenReferences, locationRange),
 	)
 }
 
@@ -217,7 +217,7 @@ func (*IDCapabilityValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (*IDCapabilityValue) IsResourceKinded(_ *Interpreter) bool {
+func (*IDCapabilityValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
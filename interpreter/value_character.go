@@ -64,9 +64,10 @@ func NewCharacterValue(
 ) CharacterValue {
 	common.UseMemory(memoryGauge, memoryUsage)
 	character := characterConstructor()
-	// NewUnmeteredCharacterValue normalizes (= allocates)
-	common.UseMemory(memoryGauge, common.NewRawStringMemoryUsage(len(character)))
-	return NewUnmeteredCharacterValue(character)
+	return CharacterValue{
+		Str:             normalizeString(memoryGauge, character),
+		UnnormalizedStr: character,
+	}
 }
 
 var _ Value = CharacterValue{}
@@ -86,11 +87,11 @@ func (CharacterValue) Walk(_ *Interpreter, _ func(Value), _ LocationRange) {
 	// NO-OP
 }
 
-func (CharacterValue) StaticType(interpreter *Interpreter) StaticType {
-	return NewPrimitiveStaticType(interpreter, PrimitiveStaticTypeCharacter)
+func (CharacterValue) StaticType(context ValueContext) StaticType {
+	return NewPrimitiveStaticType(context, PrimitiveStaticTypeCharacter)
 }
 
-func (CharacterValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (CharacterValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return sema.CharacterType.Importable
 }
 
@@ -102,9 +103,9 @@ func (v CharacterValue) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v CharacterValue) MeteredString(interpreter *Interpreter, _ SeenReferences, locationRange LocationRange) string {
+func (v CharacterValue) MeteredString(context ValueContext, _ SeenReferences, locationRange LocationRange) string {
 	l := format.FormattedStringLength(v.Str)
-	common.UseMemory(interpreter, common.NewRawStringMemoryUsage(l))
+	common.UseMemory(context, common.NewRawStringMemoryUsage(l))
 	return v.String()
 }
 
@@ -179,7 +180,7 @@ func (CharacterValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (CharacterValue) IsResourceKinded(_ *Interpreter) bool {
+func (CharacterValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
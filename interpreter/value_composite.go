@@ -127,6 +127,7 @@ func NewCompositeValue(
 ) *CompositeValue {
 
 	interpreter.ReportComputation(common.ComputationKindCreateCompositeValue, 1)
+	interpreter.reportValueCreation(CreatedValueKindComposite, locationRange)
 
 	config := interpreter.SharedState.Config
 
@@ -269,12 +270,12 @@ func (v *CompositeValue) Walk(interpreter *Interpreter, walkChild func(Value), l
 	}, locationRange)
 }
 
-func (v *CompositeValue) StaticType(interpreter *Interpreter) StaticType {
+func (v *CompositeValue) StaticType(context ValueContext) StaticType {
 	if v.staticType == nil {
 		// NOTE: Instead of using NewCompositeStaticType, which always generates the type ID,
 		// use the TypeID accessor, which may return an already computed type ID
 		v.staticType = NewCompositeStaticType(
-			interpreter,
+			context,
 			v.Location,
 			v.QualifiedIdentifier,
 			v.TypeID(),
@@ -283,18 +284,18 @@ func (v *CompositeValue) StaticType(interpreter *Interpreter) StaticType {
 	return v.staticType
 }
 
-func (v *CompositeValue) IsImportable(inter *Interpreter, locationRange LocationRange) bool {
+func (v *CompositeValue) IsImportable(context ValueContext, locationRange LocationRange) bool {
 	// Check type is importable
-	staticType := v.StaticType(inter)
-	semaType := inter.MustConvertStaticToSemaType(staticType)
+	staticType := v.StaticType(context)
+	semaType := context.MustConvertStaticToSemaType(staticType)
 	if !semaType.IsImportable(map[*sema.Member]bool{}) {
 		return false
 	}
 
 	// Check all field values are importable
 	importable := true
-	v.ForEachField(inter, func(_ string, value Value) (resume bool) {
-		if !value.IsImportable(inter, locationRange) {
+	v.ForEachField(context, func(_ string, value Value) (resume bool) {
+		if !value.IsImportable(context, locationRange) {
 			importable = false
 			// stop iteration
 			return false
@@ -636,6 +637,8 @@ func (v *CompositeValue) RemoveMember(
 
 	config := interpreter.SharedState.Config
 
+	interpreter.checkStoredValueMutation(v.GetOwner(), locationRange)
+
 	if config.TracingEnabled {
 		startTime := time.Now()
 
@@ -702,6 +705,7 @@ func (v *CompositeValue) SetMemberWithoutTransfer(
 ) bool {
 	config := interpreter.SharedState.Config
 
+	interpreter.checkStoredValueMutation(v.GetOwner(), locationRange)
 	interpreter.enforceNotResourceDestruction(v.ValueID(), locationRange)
 
 	if config.TracingEnabled {
@@ -757,6 +761,8 @@ func (v *CompositeValue) SetMember(
 ) bool {
 	address := v.StorageAddress()
 
+	interpreter.CheckStoredValueSize(common.Address(address), value, locationRange)
+
 	value = value.Transfer(
 		interpreter,
 		locationRange,
@@ -787,10 +793,10 @@ func (v *CompositeValue) RecursiveString(seenReferences SeenReferences) string {
 
 var emptyCompositeStringLen = len(format.Composite("", nil))
 
-func (v *CompositeValue) MeteredString(interpreter *Interpreter, seenReferences SeenReferences, locationRange LocationRange) string {
+func (v *CompositeValue) MeteredString(context ValueContext, seenReferences SeenReferences, locationRange LocationRange) string {
 
 	if v.Stringer != nil {
-		return v.Stringer(interpreter, v, seenReferences)
+		return v.Stringer(context, v, seenReferences)
 	}
 
 	strLen := emptyCompositeStringLen
@@ -798,10 +804,10 @@ func (v *CompositeValue) MeteredString(interpreter *Interpreter, seenReferences
 	var fields []CompositeField
 
 	v.ForEachField(
-		interpreter,
+		context,
 		func(fieldName string, fieldValue Value) (resume bool) {
 			field := NewCompositeField(
-				interpreter,
+				context,
 				fieldName,
 				fieldValue,
 			)
@@ -826,13 +832,13 @@ func (v *CompositeValue) MeteredString(interpreter *Interpreter, seenReferences
 	//
 	strLen = strLen + len(typeId) + len(fields)*4
 
-	common.UseMemory(interpreter, common.NewRawStringMemoryUsage(strLen))
+	common.UseMemory(context, common.NewRawStringMemoryUsage(strLen))
 
-	return formatComposite(interpreter, typeId, fields, seenReferences, locationRange)
+	return formatComposite(context, typeId, fields, seenReferences, locationRange)
 }
 
 func formatComposite(
-	interpreter *Interpreter,
+	context ValueContext,
 	typeId string,
 	fields []CompositeField,
 	seenReferences SeenReferences,
@@ -855,7 +861,7 @@ func formatComposite(
 				Value string
 			}{
 				Name:  field.Name,
-				Value: field.Value.MeteredString(interpreter, seenReferences, locationRange),
+				Value: field.Value.MeteredString(context, seenReferences, locationRange),
 			},
 		)
 	}
@@ -1159,9 +1165,9 @@ func (v *CompositeValue) NeedsStoreTo(address atree.Address) bool {
 	return address != v.StorageAddress()
 }
 
-func (v *CompositeValue) IsResourceKinded(interpreter *Interpreter) bool {
+func (v *CompositeValue) IsResourceKinded(context ValueContext) bool {
 	if v.Kind == common.CompositeKindAttachment {
-		return interpreter.MustSemaTypeOfValue(v).IsResourceType()
+		return context.MustSemaTypeOfValue(v).IsResourceType()
 	}
 	return v.Kind == common.CompositeKindResource
 }
@@ -1224,6 +1230,10 @@ func (v *CompositeValue) Transfer(
 	}
 
 	if needsStoreTo || !isResourceKinded {
+		if !remove {
+			interpreter.reportValueCreation(CreatedValueKindComposite, locationRange)
+		}
+
 		// Use non-readonly iterator here because iterated
 		// value can be removed if remove parameter is true.
 		iterator, err := v.dictionary.Iterator(
@@ -1513,7 +1523,7 @@ func (v *CompositeValue) forEachFieldName(
 // ForEachField iterates over all field-name field-value pairs of the composite value.
 // It does NOT iterate over computed fields and functions!
 func (v *CompositeValue) ForEachField(
-	interpreter *Interpreter,
+	context ValueContext,
 	f func(fieldName string, fieldValue Value) (resume bool),
 	locationRange LocationRange,
 ) {
@@ -1525,7 +1535,7 @@ func (v *CompositeValue) ForEachField(
 		)
 	}
 	v.forEachField(
-		interpreter,
+		context,
 		iterate,
 		f,
 		locationRange,
@@ -1536,12 +1546,12 @@ func (v *CompositeValue) ForEachField(
 // It does NOT iterate over computed fields and functions!
 // DO NOT perform storage mutations in the callback!
 func (v *CompositeValue) ForEachReadOnlyLoadedField(
-	interpreter *Interpreter,
+	context ValueContext,
 	f func(fieldName string, fieldValue Value) (resume bool),
 	locationRange LocationRange,
 ) {
 	v.forEachField(
-		interpreter,
+		context,
 		v.dictionary.IterateReadOnlyLoadedValues,
 		f,
 		locationRange,
@@ -1549,14 +1559,14 @@ func (v *CompositeValue) ForEachReadOnlyLoadedField(
 }
 
 func (v *CompositeValue) forEachField(
-	interpreter *Interpreter,
+	context ValueContext,
 	atreeIterate func(fn atree.MapEntryIterationFunc) error,
 	f func(fieldName string, fieldValue Value) (resume bool),
 	locationRange LocationRange,
 ) {
 	err := atreeIterate(func(key atree.Value, atreeValue atree.Value) (resume bool, err error) {
-		value := MustConvertStoredValue(interpreter, atreeValue)
-		interpreter.checkInvalidatedResourceOrResourceReference(value, locationRange)
+		value := MustConvertStoredValue(context, atreeValue)
+		checkInvalidatedResourceOrResourceReference(context, value, locationRange)
 
 		resume = f(
 			string(key.(StringAtreeValue)),
@@ -1820,7 +1830,7 @@ func forEachAttachment(
 
 	for {
 		// Check that the implicit composite reference was not invalidated during iteration
-		interpreter.checkInvalidatedResourceOrResourceReference(compositeReference, locationRange)
+		interpreter.CheckInvalidatedResourceOrResourceReference(compositeReference, locationRange)
 		key, value, err := iterator.Next()
 		if err != nil {
 			panic(errors.NewExternalError(err))
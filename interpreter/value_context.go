@@ -0,0 +1,96 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/sema"
+)
+
+// ValueContext is the context in which value operations are performed.
+//
+// It only provides what value operations need, i.e. metering, storage, type conversion,
+// and the tracking of container iterations and invalidated resources,
+// so values can be used without a full interpreter instance,
+// e.g. by the compiler/VM, migrations, and tools.
+// Location ranges are still passed to value operations explicitly, as they differ per operation.
+//
+// Only the operations which inspect values accept a value context,
+// i.e. Value.StaticType, Value.IsResourceKinded, Value.MeteredString, and Value.IsImportable.
+// Operations which move, destroy, or check values, i.e. Value.Transfer, Value.DeepRemove,
+// Value.ConformsToStaticType, Value.Walk, and Value.Accept, still require an interpreter.
+type ValueContext interface {
+	common.MemoryGauge
+	ComputationReporter
+	StorageContext
+	TypeConverter
+	ValueIterationContext
+}
+
+var _ ValueContext = &Interpreter{}
+
+// ComputationReporter reports the computation used by value operations
+type ComputationReporter interface {
+	ReportComputation(compKind common.ComputationKind, intensity uint)
+}
+
+// StorageContext provides the storage of values
+type StorageContext interface {
+	Storage() Storage
+	ReadStored(storageAddress common.Address, domain common.StorageDomain, identifier StorageMapKey) Value
+}
+
+// TypeConverter converts the static types of values to sema types, and checks their subtyping
+type TypeConverter interface {
+	ConvertStaticToSemaType(staticType StaticType) (sema.Type, error)
+	MustConvertStaticToSemaType(staticType StaticType) sema.Type
+	MustSemaTypeOfValue(value Value) sema.Type
+	IsSubTypeOfSemaType(staticSubType StaticType, superType sema.Type) bool
+}
+
+// ValueIterationContext tracks the iteration of container values,
+// and checks that the iterated values are not invalidated resources or resource references
+type ValueIterationContext interface {
+	WithMutationPrevention(valueID atree.ValueID, f func())
+	CheckInvalidatedResourceOrResourceReference(value Value, hasPosition ast.HasPosition)
+}
+
+// withMutationPrevention calls f, and prevents mutations of the container value with the given ID while f is called.
+// Values may be iterated without a context, e.g. when converted to a string,
+// in which case mutations are not prevented
+func withMutationPrevention(context ValueIterationContext, valueID atree.ValueID, f func()) {
+	if context == nil {
+		f()
+		return
+	}
+	context.WithMutationPrevention(valueID, f)
+}
+
+// checkInvalidatedResourceOrResourceReference checks that the given value is not an invalidated resource or resource reference.
+// Values may be iterated without a context, e.g. when converted to a string,
+// in which case the check is skipped
+func checkInvalidatedResourceOrResourceReference(context ValueIterationContext, value Value, hasPosition ast.HasPosition) {
+	if context == nil {
+		return
+	}
+	context.CheckInvalidatedResourceOrResourceReference(value, hasPosition)
+}
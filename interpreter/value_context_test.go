@@ -0,0 +1,258 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	. "github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+)
+
+// testValueContext is a value context which is not backed by an interpreter
+type testValueContext struct {
+	storage           Storage
+	memoryUsages      map[common.MemoryKind]uint64
+	preventedValueIDs []atree.ValueID
+}
+
+var _ ValueContext = &testValueContext{}
+
+func (c *testValueContext) MeterMemory(usage common.MemoryUsage) error {
+	c.memoryUsages[usage.Kind] += usage.Amount
+	return nil
+}
+
+func (c *testValueContext) ReportComputation(_ common.ComputationKind, _ uint) {
+	// NO-OP
+}
+
+func (c *testValueContext) Storage() Storage {
+	return c.storage
+}
+
+func (c *testValueContext) ReadStored(
+	_ common.Address,
+	_ common.StorageDomain,
+	_ StorageMapKey,
+) Value {
+	return nil
+}
+
+func (c *testValueContext) ConvertStaticToSemaType(staticType StaticType) (sema.Type, error) {
+	// Only primitive and container types of primitive types are supported,
+	// so no conversion handler is needed
+	return ConvertStaticToSemaType(c, staticType, nil)
+}
+
+func (c *testValueContext) MustConvertStaticToSemaType(staticType StaticType) sema.Type {
+	semaType, err := c.ConvertStaticToSemaType(staticType)
+	if err != nil {
+		panic(err)
+	}
+	return semaType
+}
+
+func (c *testValueContext) MustSemaTypeOfValue(value Value) sema.Type {
+	return c.MustConvertStaticToSemaType(value.StaticType(c))
+}
+
+func (c *testValueContext) IsSubTypeOfSemaType(staticSubType StaticType, superType sema.Type) bool {
+	return sema.IsSubType(c.MustConvertStaticToSemaType(staticSubType), superType)
+}
+
+func (c *testValueContext) WithMutationPrevention(valueID atree.ValueID, f func()) {
+	c.preventedValueIDs = append(c.preventedValueIDs, valueID)
+	f()
+}
+
+func (c *testValueContext) CheckInvalidatedResourceOrResourceReference(_ Value, _ ast.HasPosition) {
+	// NO-OP
+}
+
+func TestValueContext(t *testing.T) {
+
+	t.Parallel()
+
+	storage := newUnmeteredInMemoryStorage()
+
+	inter, err := NewInterpreter(
+		nil,
+		TestLocation,
+		&Config{
+			Storage: storage,
+		},
+	)
+	require.NoError(t, err)
+
+	arrayType := &VariableSizedStaticType{
+		Type: PrimitiveStaticTypeInt,
+	}
+
+	array := NewArrayValue(
+		inter,
+		EmptyLocationRange,
+		arrayType,
+		common.ZeroAddress,
+		NewUnmeteredIntValueFromInt64(1),
+		NewUnmeteredIntValueFromInt64(2),
+		NewUnmeteredIntValueFromInt64(3),
+	)
+
+	context := &testValueContext{
+		storage:      storage,
+		memoryUsages: map[common.MemoryKind]uint64{},
+	}
+
+	assert.Equal(t, arrayType, array.StaticType(context))
+	assert.False(t, array.IsResourceKinded(context))
+	assert.True(t, array.IsImportable(context, EmptyLocationRange))
+
+	assert.Equal(t,
+		"[1, 2, 3]",
+		array.MeteredString(context, SeenReferences{}, EmptyLocationRange),
+	)
+	assert.Positive(t, context.memoryUsages[common.MemoryKindRawString])
+
+	// The array was iterated twice, by IsImportable and MeteredString
+	assert.Equal(t,
+		[]atree.ValueID{array.ValueID(), array.ValueID()},
+		context.preventedValueIDs,
+	)
+}
+
+func TestValueContextNestedIteration(t *testing.T) {
+
+	t.Parallel()
+
+	storage := newUnmeteredInMemoryStorage()
+
+	inter, err := NewInterpreter(
+		nil,
+		TestLocation,
+		&Config{
+			Storage: storage,
+		},
+	)
+	require.NoError(t, err)
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	arrayType := &VariableSizedStaticType{
+		Type: PrimitiveStaticTypeInt,
+	}
+
+	dictionaryType := &DictionaryStaticType{
+		KeyType:   PrimitiveStaticTypeString,
+		ValueType: arrayType,
+	}
+
+	dictionary := NewDictionaryValueWithAddress(
+		inter,
+		EmptyLocationRange,
+		dictionaryType,
+		address,
+		NewUnmeteredStringValue("a"),
+		NewArrayValue(
+			inter,
+			EmptyLocationRange,
+			arrayType,
+			common.ZeroAddress,
+			NewUnmeteredIntValueFromInt64(1),
+			NewUnmeteredIntValueFromInt64(2),
+		),
+	)
+
+	encodeSlabs := func() map[atree.SlabID][]byte {
+		encodedSlabs := map[atree.SlabID][]byte{}
+		for id, slab := range storage.Slabs {
+			encoded, err := atree.EncodeSlab(slab, CBOREncMode)
+			require.NoError(t, err)
+			encodedSlabs[id] = encoded
+		}
+		return encodedSlabs
+	}
+
+	encodedSlabs := encodeSlabs()
+
+	t.Run("string and importability", func(t *testing.T) {
+
+		context := &testValueContext{
+			storage:      storage,
+			memoryUsages: map[common.MemoryKind]uint64{},
+		}
+
+		// Iterating read-only produces the same string as iterating with an interpreter
+
+		const expected = `{"a": [1, 2]}`
+		assert.Equal(t,
+			expected,
+			dictionary.MeteredString(context, SeenReferences{}, EmptyLocationRange),
+		)
+		assert.Equal(t,
+			expected,
+			dictionary.MeteredString(inter, SeenReferences{}, EmptyLocationRange),
+		)
+
+		assert.True(t, dictionary.IsImportable(context, EmptyLocationRange))
+
+		// Mutations of the dictionary and of the nested array are prevented
+		// while they are iterated, by MeteredString and IsImportable
+		require.Len(t, context.preventedValueIDs, 4)
+		assert.Equal(t, dictionary.ValueID(), context.preventedValueIDs[0])
+		assert.Equal(t, dictionary.ValueID(), context.preventedValueIDs[2])
+
+		// Iterating read-only does not modify the stored slabs
+		assert.Equal(t, encodedSlabs, encodeSlabs())
+	})
+
+	t.Run("not importable", func(t *testing.T) {
+
+		context := &testValueContext{
+			storage:      storage,
+			memoryUsages: map[common.MemoryKind]uint64{},
+		}
+
+		capabilityArray := NewArrayValue(
+			inter,
+			EmptyLocationRange,
+			&VariableSizedStaticType{
+				Type: &CapabilityStaticType{
+					BorrowType: PrimitiveStaticTypeBool,
+				},
+			},
+			common.ZeroAddress,
+			NewUnmeteredCapabilityValue(
+				1,
+				NewAddressValue(nil, address),
+				PrimitiveStaticTypeBool,
+			),
+		)
+
+		assert.False(t, capabilityArray.IsImportable(context, EmptyLocationRange))
+	})
+}
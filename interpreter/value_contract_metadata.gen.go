@@ -0,0 +1,53 @@
+// Code generated from contract_metadata.cdc. DO NOT EDIT.
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/sema"
+)
+
+var contractMetadataTypeID = sema.ContractMetadataType.ID()
+var contractMetadataStaticType = ConvertSemaToStaticType(nil, sema.ContractMetadataType) // unmetered
+var contractMetadataFieldNames = []string{
+	sema.ContractMetadataTypeNameFieldName,
+	sema.ContractMetadataTypeCodeHashFieldName,
+	sema.ContractMetadataTypeSizeFieldName,
+	sema.ContractMetadataTypeUpdateHeightFieldName,
+}
+
+// NewContractMetadataValue returns a new value of the built-in type ContractMetadata
+func NewContractMetadataValue(gauge common.MemoryGauge, name *StringValue, codeHash *ArrayValue, size UInt64Value, updateHeight OptionalValue) Value {
+	return NewSimpleCompositeValue(
+		gauge,
+		contractMetadataTypeID,
+		contractMetadataStaticType,
+		contractMetadataFieldNames,
+		map[string]Value{
+			sema.ContractMetadataTypeNameFieldName:         name,
+			sema.ContractMetadataTypeCodeHashFieldName:     codeHash,
+			sema.ContractMetadataTypeSizeFieldName:         size,
+			sema.ContractMetadataTypeUpdateHeightFieldName: updateHeight,
+		},
+		nil,
+		nil,
+		nil,
+	)
+}
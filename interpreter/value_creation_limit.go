@@ -0,0 +1,85 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"github.com/onflow/cadence/errors"
+)
+
+// CreatedValueKind is the kind of value which is counted
+// when checking the value creation limits, see Config.CompositeValueCreationLimit,
+// Config.ArrayValueCreationLimit, and Config.DictionaryValueCreationLimit
+type CreatedValueKind uint8
+
+const (
+	CreatedValueKindComposite CreatedValueKind = iota
+	CreatedValueKindArray
+	CreatedValueKindDictionary
+	createdValueKindCount
+)
+
+func (k CreatedValueKind) String() string {
+	switch k {
+	case CreatedValueKindComposite:
+		return "composite"
+	case CreatedValueKindArray:
+		return "array"
+	case CreatedValueKindDictionary:
+		return "dictionary"
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+func (interpreter *Interpreter) valueCreationLimit(kind CreatedValueKind) uint64 {
+	config := interpreter.SharedState.Config
+
+	switch kind {
+	case CreatedValueKindComposite:
+		return config.CompositeValueCreationLimit
+	case CreatedValueKindArray:
+		return config.ArrayValueCreationLimit
+	case CreatedValueKindDictionary:
+		return config.DictionaryValueCreationLimit
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+// reportValueCreation counts the creation of a value of the given kind,
+// either by construction or by copying an existing value,
+// and panics with a ValueCreationLimitExceededError
+// if the value creation limit for the kind is exceeded
+func (interpreter *Interpreter) reportValueCreation(kind CreatedValueKind, locationRange LocationRange) {
+	limit := interpreter.valueCreationLimit(kind)
+	if limit == 0 {
+		return
+	}
+
+	counts := &interpreter.SharedState.createdValueCounts
+	if counts[kind] >= limit {
+		panic(ValueCreationLimitExceededError{
+			Kind:          kind,
+			Limit:         limit,
+			LocationRange: locationRange,
+		})
+	}
+
+	counts[kind]++
+}
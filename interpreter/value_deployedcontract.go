@@ -104,8 +104,9 @@ func newPublicTypesFunctionValue(
 					return typeValue
 				}
 
-				publicTypes = NewArrayValueWithIterator(
+				publicTypes = newArrayValueWithIterator(
 					innerInter,
+					inv.LocationRange,
 					NewVariableSizedStaticType(innerInter, PrimitiveStaticTypeMetaType),
 					common.Address{},
 					uint64(nestedTypes.Len()),
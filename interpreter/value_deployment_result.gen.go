@@ -1,3 +1,4 @@
+// Code generated from deployment_result.cdc. DO NOT EDIT.
 /*
  * Cadence - The resource-oriented smart contract programming language
  *
@@ -23,17 +24,14 @@ import (
 	"github.com/onflow/cadence/sema"
 )
 
-// DeploymentResult
-
 var deploymentResultTypeID = sema.DeploymentResultType.ID()
 var deploymentResultStaticType = ConvertSemaToStaticType(nil, sema.DeploymentResultType) // unmetered
-var deploymentResultFieldNames []string = nil
-
-func NewDeploymentResultValue(
-	gauge common.MemoryGauge,
-	deployedContract OptionalValue,
-) Value {
+var deploymentResultFieldNames = []string{
+	sema.DeploymentResultTypeDeployedContractFieldName,
+}
 
+// NewDeploymentResultValue returns a new value of the built-in type DeploymentResult
+func NewDeploymentResultValue(gauge common.MemoryGauge, deployedContract OptionalValue) Value {
 	return NewSimpleCompositeValue(
 		gauge,
 		deploymentResultTypeID,
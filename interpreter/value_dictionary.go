@@ -19,9 +19,14 @@
 package interpreter
 
 import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
 	goerrors "errors"
+	"slices"
 	"time"
 
+	"github.com/fxamacker/circlehash"
 	"github.com/onflow/atree"
 
 	"github.com/onflow/cadence/common"
@@ -39,6 +44,7 @@ type DictionaryValue struct {
 	dictionary       *atree.OrderedMap
 	isDestroyed      bool
 	elementSize      uint
+	copyOnWrite      copyOnWrite
 }
 
 func NewDictionaryValue(
@@ -64,7 +70,42 @@ func NewDictionaryValueWithAddress(
 	keysAndValues ...Value,
 ) *DictionaryValue {
 
+	config := interpreter.SharedState.Config
+
+	constructor := func() *atree.OrderedMap {
+		dictionary, err := atree.NewMap(
+			config.Storage,
+			atree.Address(address),
+			atree.NewDefaultDigesterBuilder(),
+			dictionaryType,
+		)
+		if err != nil {
+			panic(errors.NewExternalError(err))
+		}
+		return dictionary
+	}
+
+	return newDictionaryValueWithConstructor(
+		interpreter,
+		locationRange,
+		dictionaryType,
+		constructor,
+		keysAndValues,
+	)
+}
+
+// newDictionaryValueWithConstructor returns a new dictionary constructed by the given constructor,
+// and inserts the given keys and values one by one
+func newDictionaryValueWithConstructor(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	dictionaryType *DictionaryStaticType,
+	constructor func() *atree.OrderedMap,
+	keysAndValues []Value,
+) *DictionaryValue {
+
 	interpreter.ReportComputation(common.ComputationKindCreateDictionaryValue, 1)
+	interpreter.reportValueCreation(CreatedValueKindDictionary, locationRange)
 
 	var v *DictionaryValue
 
@@ -96,26 +137,13 @@ func NewDictionaryValueWithAddress(
 		panic("uneven number of keys and values")
 	}
 
-	constructor := func() *atree.OrderedMap {
-		dictionary, err := atree.NewMap(
-			config.Storage,
-			atree.Address(address),
-			atree.NewDefaultDigesterBuilder(),
-			dictionaryType,
-		)
-		if err != nil {
-			panic(errors.NewExternalError(err))
-		}
-		return dictionary
-	}
-
 	// values are added to the dictionary after creation, not here
 	v = newDictionaryValueFromConstructor(interpreter, dictionaryType, 0, constructor)
 
 	for i := 0; i < keysAndValuesCount; i += 2 {
 		key := keysAndValues[i]
 		value := keysAndValues[i+1]
-		existingValue := v.Insert(interpreter, locationRange, key, value)
+		existingValue := v.insert(interpreter, locationRange, key, value)
 		// If the dictionary already contained a value for the key,
 		// and the dictionary is resource-typed,
 		// then we need to prevent a resource loss
@@ -131,6 +159,213 @@ func NewDictionaryValueWithAddress(
 	return v
 }
 
+// NewDictionaryValueFromBatch returns a new dictionary with the given keys and values,
+// like NewDictionaryValueWithAddress.
+//
+// Instead of inserting the entries one by one,
+// the dictionary is constructed in one pass, with the storage for all entries allocated at once,
+// like NewArrayValue constructs arrays.
+// This reduces the slab churn when constructing large dictionaries, e.g. for dictionary literals.
+//
+// If keys have the same first level digest, e.g. if a key is duplicated,
+// the entries are inserted one by one instead, like NewDictionaryValueWithAddress does,
+// i.e. later entries overwrite earlier ones
+func NewDictionaryValueFromBatch(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	dictionaryType *DictionaryStaticType,
+	address common.Address,
+	keysAndValues ...Value,
+) *DictionaryValue {
+
+	keysAndValuesCount := len(keysAndValues)
+	if keysAndValuesCount%2 != 0 {
+		panic("uneven number of keys and values")
+	}
+
+	config := interpreter.SharedState.Config
+
+	// Generate the root slab ID and derive the seed from it, like atree.NewMap does,
+	// so the digests of the keys can be determined before constructing the map
+
+	slabID, err := config.Storage.GenerateSlabID(atree.Address(address))
+	if err != nil {
+		panic(errors.NewExternalError(err))
+	}
+	seed := dictionarySeed(slabID)
+
+	// atree.NewMapFromBatchData requires the entries in the order of the map.
+	// Entries are ordered by the first level digests of their keys,
+	// which are only derived from the seed
+
+	type entry struct {
+		key    Value
+		value  Value
+		digest atree.Digest
+	}
+
+	entries := make([]entry, 0, keysAndValuesCount/2)
+
+	hashInputProvider := newHashInputProvider(interpreter, locationRange)
+
+	digesterBuilder := atree.NewDefaultDigesterBuilder()
+	digesterBuilder.SetSeed(seed, 0)
+
+	for i := 0; i < keysAndValuesCount; i += 2 {
+		key := keysAndValues[i]
+
+		digester, err := digesterBuilder.Digest(hashInputProvider, key)
+		if err != nil {
+			panic(errors.NewExternalError(err))
+		}
+
+		digest, err := digester.Digest(0)
+		if err != nil {
+			panic(errors.NewExternalError(err))
+		}
+
+		entries = append(
+			entries,
+			entry{
+				key:    key,
+				value:  keysAndValues[i+1],
+				digest: digest,
+			},
+		)
+	}
+
+	slices.SortFunc(entries, func(a, b entry) int {
+		return cmp.Compare(a.digest, b.digest)
+	})
+
+	// The order of entries with the same first level digest is determined by further digests,
+	// which depend on atree internals, so insert the entries one by one instead
+
+	for i := 1; i < len(entries); i++ {
+		if entries[i].digest == entries[i-1].digest {
+			return newDictionaryValueWithSlabID(
+				interpreter,
+				locationRange,
+				dictionaryType,
+				address,
+				slabID,
+				keysAndValues,
+			)
+		}
+	}
+
+	var index int
+
+	return newDictionaryValueWithIterator(
+		interpreter,
+		locationRange,
+		dictionaryType,
+		uint64(len(entries)),
+		seed,
+		address,
+		slabID,
+		func() (Value, Value) {
+			if index >= len(entries) {
+				return nil, nil
+			}
+
+			entry := entries[index]
+			index++
+
+			key := entry.key.Transfer(
+				interpreter,
+				locationRange,
+				atree.Address(address),
+				true,
+				nil,
+				nil,
+				true, // key is standalone before it is inserted into parent container.
+			)
+
+			value := entry.value.Transfer(
+				interpreter,
+				locationRange,
+				atree.Address(address),
+				true,
+				nil,
+				nil,
+				true, // value is standalone before it is inserted into parent container.
+			)
+
+			interpreter.checkContainerMutation(dictionaryType.KeyType, key, locationRange)
+			interpreter.checkContainerMutation(dictionaryType.ValueType, value, locationRange)
+
+			return key, value
+		},
+	)
+}
+
+// newDictionaryValueWithSlabID returns a new dictionary with the given root slab ID,
+// which was already generated, and inserts the given keys and values one by one
+func newDictionaryValueWithSlabID(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	dictionaryType *DictionaryStaticType,
+	address common.Address,
+	slabID atree.SlabID,
+	keysAndValues []Value,
+) *DictionaryValue {
+
+	config := interpreter.SharedState.Config
+
+	constructor := func() *atree.OrderedMap {
+		dictionary, err := atree.NewMap(
+			&generatedSlabIDStorage{
+				SlabStorage: config.Storage,
+				slabID:      slabID,
+			},
+			atree.Address(address),
+			atree.NewDefaultDigesterBuilder(),
+			dictionaryType,
+		)
+		if err != nil {
+			panic(errors.NewExternalError(err))
+		}
+		dictionary.Storage = config.Storage
+		return dictionary
+	}
+
+	return newDictionaryValueWithConstructor(
+		interpreter,
+		locationRange,
+		dictionaryType,
+		constructor,
+		keysAndValues,
+	)
+}
+
+// dictionarySeed returns the seed for a dictionary with the given root slab ID.
+// It is derived like in atree.NewMap, so the dictionary is independent of how it is constructed
+func dictionarySeed(slabID atree.SlabID) uint64 {
+	address := slabID.Address()
+	index := slabID.Index()
+
+	a := binary.LittleEndian.Uint64(address[:])
+	b := binary.LittleEndian.Uint64(index[:])
+	return circlehash.Hash64Uint64x2(a, b, uint64(0))
+}
+
+// generatedSlabIDStorage is a slab storage which returns an already generated slab ID
+// for the first slab ID it generates, i.e. for the root slab of a new container
+type generatedSlabIDStorage struct {
+	atree.SlabStorage
+	slabID atree.SlabID
+}
+
+func (s *generatedSlabIDStorage) GenerateSlabID(address atree.Address) (atree.SlabID, error) {
+	if s.slabID != atree.SlabIDUndefined {
+		slabID := s.slabID
+		s.slabID = atree.SlabIDUndefined
+		return slabID, nil
+	}
+	return s.SlabStorage.GenerateSlabID(address)
+}
+
 func DictionaryElementSize(staticType *DictionaryStaticType) uint {
 	keySize := staticType.KeyType.elementSize()
 	valueSize := staticType.ValueType.elementSize()
@@ -147,9 +382,11 @@ func newDictionaryValueWithIterator(
 	count uint64,
 	seed uint64,
 	address common.Address,
+	rootSlabID atree.SlabID,
 	values func() (Value, Value),
 ) *DictionaryValue {
 	interpreter.ReportComputation(common.ComputationKindCreateDictionaryValue, 1)
+	interpreter.reportValueCreation(CreatedValueKindDictionary, locationRange)
 
 	var v *DictionaryValue
 
@@ -177,8 +414,18 @@ func newDictionaryValueWithIterator(
 	}
 
 	constructor := func() *atree.OrderedMap {
+		// If the root slab ID was already generated, e.g. to derive the seed from it,
+		// let atree use it, instead of generating a new one
+		var storage atree.SlabStorage = config.Storage
+		if rootSlabID != atree.SlabIDUndefined {
+			storage = &generatedSlabIDStorage{
+				SlabStorage: storage,
+				slabID:      rootSlabID,
+			}
+		}
+
 		orderedMap, err := atree.NewMapFromBatchData(
-			config.Storage,
+			storage,
 			atree.Address(address),
 			atree.NewDefaultDigesterBuilder(),
 			staticType,
@@ -193,6 +440,7 @@ func newDictionaryValueWithIterator(
 		if err != nil {
 			panic(errors.NewExternalError(err))
 		}
+		orderedMap.Storage = config.Storage
 		return orderedMap
 	}
 
@@ -310,11 +558,13 @@ func (v *DictionaryValue) iterateKeys(
 		}
 	}
 
-	interpreter.withMutationPrevention(v.ValueID(), iterate)
+	v.copyOnWrite.withIteration(func() {
+		interpreter.WithMutationPrevention(v.ValueID(), iterate)
+	})
 }
 
 func (v *DictionaryValue) IterateReadOnly(
-	interpreter *Interpreter,
+	context ValueContext,
 	locationRange LocationRange,
 	f func(key, value Value) (resume bool),
 ) {
@@ -323,7 +573,7 @@ func (v *DictionaryValue) IterateReadOnly(
 			fn,
 		)
 	}
-	v.iterate(interpreter, iterate, f, locationRange)
+	v.iterate(context, iterate, f, locationRange)
 }
 
 func (v *DictionaryValue) Iterate(
@@ -346,12 +596,12 @@ func (v *DictionaryValue) Iterate(
 // IterateReadOnlyLoaded iterates over all LOADED key-valye pairs of the array.
 // DO NOT perform storage mutations in the callback!
 func (v *DictionaryValue) IterateReadOnlyLoaded(
-	interpreter *Interpreter,
+	context ValueContext,
 	locationRange LocationRange,
 	f func(key, value Value) (resume bool),
 ) {
 	v.iterate(
-		interpreter,
+		context,
 		v.dictionary.IterateReadOnlyLoadedValues,
 		f,
 		locationRange,
@@ -359,7 +609,7 @@ func (v *DictionaryValue) IterateReadOnlyLoaded(
 }
 
 func (v *DictionaryValue) iterate(
-	interpreter *Interpreter,
+	context ValueContext,
 	atreeIterate func(fn atree.MapEntryIterationFunc) error,
 	f func(key Value, value Value) (resume bool),
 	locationRange LocationRange,
@@ -369,11 +619,11 @@ func (v *DictionaryValue) iterate(
 			// atree.OrderedMap iteration provides low-level atree.Value,
 			// convert to high-level interpreter.Value
 
-			keyValue := MustConvertStoredValue(interpreter, key)
-			valueValue := MustConvertStoredValue(interpreter, value)
+			keyValue := MustConvertStoredValue(context, key)
+			valueValue := MustConvertStoredValue(context, value)
 
-			interpreter.checkInvalidatedResourceOrResourceReference(keyValue, locationRange)
-			interpreter.checkInvalidatedResourceOrResourceReference(valueValue, locationRange)
+			checkInvalidatedResourceOrResourceReference(context, keyValue, locationRange)
+			checkInvalidatedResourceOrResourceReference(context, valueValue, locationRange)
 
 			resume = f(
 				keyValue,
@@ -387,7 +637,9 @@ func (v *DictionaryValue) iterate(
 		}
 	}
 
-	interpreter.withMutationPrevention(v.ValueID(), iterate)
+	v.copyOnWrite.withIteration(func() {
+		withMutationPrevention(context, v.ValueID(), iterate)
+	})
 }
 
 type DictionaryKeyIterator struct {
@@ -433,6 +685,193 @@ func (v *DictionaryValue) Iterator() DictionaryKeyIterator {
 	}
 }
 
+// DictionaryCursor is the position of an iteration over a dictionary, after the last visited entry.
+//
+// The cursor identifies the last visited key, not an index,
+// so inserting or removing other entries between batches
+// does not cause entries to be skipped or visited twice.
+// It only consists of plain data, so it can be kept between calls, e.g. between transactions.
+type DictionaryCursor struct {
+	// Digest is the digest of the last visited key,
+	// which determines the position of the entry in the dictionary
+	Digest uint64
+	// KeyHashInput is the hash input of the last visited key,
+	// which identifies it among the keys with the same digest
+	KeyHashInput []byte
+}
+
+// IterateBatch iterates over at most limit entries of the dictionary,
+// starting after the given cursor, or at the beginning of the dictionary, if the cursor is nil,
+// so that large dictionaries can be processed in bounded batches, e.g. across multiple calls.
+// DO NOT perform storage mutations in the callback!
+//
+// It returns the cursor after the last visited entry,
+// which can be passed to a following call to resume the iteration,
+// or nil if the iteration is complete, i.e. there are no entries after it.
+//
+// Entries which are inserted between batches are visited if they are inserted after the cursor.
+// If the key of the cursor is removed between batches, entries which have the same digest
+// as the removed key may be visited again.
+//
+// NOTE: atree does not support resuming an iteration at a key,
+// so the entries before the cursor are still read and hashed to find the position of the cursor,
+// but they are not passed to the function. Each batch therefore costs O(n) for a dictionary with n entries,
+// and iterating over the whole dictionary in batches of size k costs O(n²/k).
+// Reading an entry before the cursor is metered as a loop iteration.
+func (v *DictionaryValue) IterateBatch(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	cursor *DictionaryCursor,
+	limit int,
+	f func(key, value Value) (resume bool),
+) *DictionaryCursor {
+
+	if limit <= 0 {
+		panic(errors.NewUnexpectedError("invalid dictionary iteration limit: %d", limit))
+	}
+
+	hashInputProvider := newHashInputProvider(interpreter, locationRange)
+
+	// Entries are ordered by the first level digests of their keys,
+	// which are only derived from the stored seed of the dictionary
+
+	digesterBuilder := atree.NewDefaultDigesterBuilder()
+	digesterBuilder.SetSeed(v.dictionary.Seed(), 0)
+
+	type entry struct {
+		key    atree.Value
+		value  atree.Value
+		digest atree.Digest
+	}
+
+	var nextCursor *DictionaryCursor
+	var visitedCount int
+	var complete = true
+
+	visit := func(entry entry) (resume bool) {
+		if visitedCount >= limit {
+			// There are more entries than the limit
+			complete = false
+			return false
+		}
+
+		keyValue := MustConvertStoredValue(interpreter, entry.key)
+		valueValue := MustConvertStoredValue(interpreter, entry.value)
+
+		interpreter.CheckInvalidatedResourceOrResourceReference(keyValue, locationRange)
+		interpreter.CheckInvalidatedResourceOrResourceReference(valueValue, locationRange)
+
+		visitedCount++
+
+		nextCursor = &DictionaryCursor{
+			Digest: uint64(entry.digest),
+			KeyHashInput: keyValue.(HashableValue).
+				HashInput(interpreter, locationRange, nil),
+		}
+
+		if !f(keyValue, valueValue) {
+			complete = false
+			return false
+		}
+
+		return true
+	}
+
+	// Entries are ordered by the digests of their keys.
+	// The entries which have the same digest as the cursor, and come before the key of the cursor,
+	// were already visited, unless the key of the cursor was removed.
+	// So keep them until the key of the cursor is found, or all entries with the digest were read.
+
+	skipping := cursor != nil
+	var pending []entry
+
+	visitPending := func() (resume bool) {
+		skipping = false
+		for _, pendingEntry := range pending {
+			if !visit(pendingEntry) {
+				return false
+			}
+		}
+		pending = nil
+		return true
+	}
+
+	iterate := func() {
+		err := v.dictionary.IterateReadOnly(func(key, value atree.Value) (resume bool, err error) {
+
+			if skipping {
+				// Meter computation for reading the entries before the cursor
+				interpreter.ReportComputation(common.ComputationKindLoop, 1)
+			}
+
+			digester, err := digesterBuilder.Digest(hashInputProvider, key)
+			if err != nil {
+				return false, err
+			}
+
+			digest, err := digester.Digest(0)
+			if err != nil {
+				return false, err
+			}
+
+			current := entry{
+				key:    key,
+				value:  value,
+				digest: digest,
+			}
+
+			if skipping {
+				cursorDigest := atree.Digest(cursor.Digest)
+
+				switch {
+				case digest < cursorDigest:
+					return true, nil
+
+				case digest == cursorDigest:
+					keyValue := MustConvertStoredValue(interpreter, key)
+					keyHashInput := keyValue.(HashableValue).
+						HashInput(interpreter, locationRange, nil)
+
+					if bytes.Equal(keyHashInput, cursor.KeyHashInput) {
+						// Found the key of the cursor, all pending entries were already visited
+						skipping = false
+						pending = nil
+					} else {
+						pending = append(pending, current)
+					}
+					return true, nil
+
+				default:
+					// The key of the cursor was removed
+					if !visitPending() {
+						return false, nil
+					}
+				}
+			}
+
+			return visit(current), nil
+		})
+		if err != nil {
+			panic(errors.NewExternalError(err))
+		}
+
+		if skipping {
+			// The key of the cursor was removed, and there are no entries after it
+			visitPending()
+		}
+	}
+
+	v.copyOnWrite.withIteration(func() {
+		interpreter.WithMutationPrevention(v.ValueID(), iterate)
+	})
+
+	if complete {
+		return nil
+	}
+
+	return nextCursor
+}
+
 func (v *DictionaryValue) Walk(interpreter *Interpreter, walkChild func(Value), locationRange LocationRange) {
 	v.Iterate(
 		interpreter,
@@ -445,18 +884,18 @@ func (v *DictionaryValue) Walk(interpreter *Interpreter, walkChild func(Value),
 	)
 }
 
-func (v *DictionaryValue) StaticType(_ *Interpreter) StaticType {
+func (v *DictionaryValue) StaticType(_ ValueContext) StaticType {
 	// TODO meter
 	return v.Type
 }
 
-func (v *DictionaryValue) IsImportable(inter *Interpreter, locationRange LocationRange) bool {
+func (v *DictionaryValue) IsImportable(context ValueContext, locationRange LocationRange) bool {
 	importable := true
-	v.Iterate(
-		inter,
+	v.IterateReadOnly(
+		context,
 		locationRange,
 		func(key, value Value) (resume bool) {
-			if !key.IsImportable(inter, locationRange) || !value.IsImportable(inter, locationRange) {
+			if !key.IsImportable(context, locationRange) || !value.IsImportable(context, locationRange) {
 				importable = false
 				// stop iteration
 				return false
@@ -573,7 +1012,9 @@ func (v *DictionaryValue) ForEachKey(
 		}
 	}
 
-	interpreter.withMutationPrevention(v.ValueID(), iterate)
+	v.copyOnWrite.withIteration(func() {
+		interpreter.WithMutationPrevention(v.ValueID(), iterate)
+	})
 }
 
 func (v *DictionaryValue) ContainsKey(
@@ -582,6 +1023,8 @@ func (v *DictionaryValue) ContainsKey(
 	keyValue Value,
 ) BoolValue {
 
+	interpreter.ReportComputation(common.ComputationKindDictionaryLookup, 1)
+
 	valueComparator := newValueComparator(interpreter, locationRange)
 	hashInputProvider := newHashInputProvider(interpreter, locationRange)
 
@@ -602,6 +1045,8 @@ func (v *DictionaryValue) Get(
 	keyValue Value,
 ) (Value, bool) {
 
+	interpreter.ReportComputation(common.ComputationKindDictionaryLookup, 1)
+
 	valueComparator := newValueComparator(interpreter, locationRange)
 	hashInputProvider := newHashInputProvider(interpreter, locationRange)
 
@@ -636,7 +1081,7 @@ func (v *DictionaryValue) SetKey(
 	keyValue Value,
 	value Value,
 ) {
-	interpreter.validateMutation(v.ValueID(), locationRange)
+	v.prepareMutation(interpreter, locationRange)
 
 	interpreter.checkContainerMutation(v.Type.KeyType, keyValue, locationRange)
 	interpreter.checkContainerMutation(
@@ -647,6 +1092,10 @@ func (v *DictionaryValue) SetKey(
 		locationRange,
 	)
 
+	address := common.Address(v.StorageAddress())
+	interpreter.CheckStoredValueSize(address, keyValue, locationRange)
+	interpreter.CheckStoredValueSize(address, value, locationRange)
+
 	var existingValue Value
 	switch value := value.(type) {
 	case *SomeValue:
@@ -676,7 +1125,7 @@ func (v *DictionaryValue) RecursiveString(seenReferences SeenReferences) string
 	return v.MeteredString(nil, seenReferences, EmptyLocationRange)
 }
 
-func (v *DictionaryValue) MeteredString(interpreter *Interpreter, seenReferences SeenReferences, locationRange LocationRange) string {
+func (v *DictionaryValue) MeteredString(context ValueContext, seenReferences SeenReferences, locationRange LocationRange) string {
 
 	pairs := make([]struct {
 		Key   string
@@ -685,8 +1134,8 @@ func (v *DictionaryValue) MeteredString(interpreter *Interpreter, seenReferences
 
 	index := 0
 
-	v.Iterate(
-		interpreter,
+	v.IterateReadOnly(
+		context,
 		locationRange,
 		func(key, value Value) (resume bool) {
 			// atree.OrderedMap iteration provides low-level atree.Value,
@@ -696,8 +1145,8 @@ func (v *DictionaryValue) MeteredString(interpreter *Interpreter, seenReferences
 				Key   string
 				Value string
 			}{
-				Key:   key.MeteredString(interpreter, seenReferences, locationRange),
-				Value: value.MeteredString(interpreter, seenReferences, locationRange),
+				Key:   key.MeteredString(context, seenReferences, locationRange),
+				Value: value.MeteredString(context, seenReferences, locationRange),
 			}
 			index++
 			return true
@@ -714,7 +1163,7 @@ func (v *DictionaryValue) MeteredString(interpreter *Interpreter, seenReferences
 	// String of each key and value are metered separately.
 	strLen := len(pairs)*4 + 2
 
-	common.UseMemory(interpreter, common.NewRawStringMemoryUsage(strLen))
+	common.UseMemory(context, common.NewRawStringMemoryUsage(strLen))
 
 	return format.Dictionary(pairs)
 }
@@ -753,8 +1202,9 @@ func (v *DictionaryValue) GetMember(
 			panic(errors.NewExternalError(err))
 		}
 
-		return NewArrayValueWithIterator(
+		return newArrayValueWithIterator(
 			interpreter,
+			locationRange,
 			NewVariableSizedStaticType(interpreter, v.Type.KeyType),
 			common.ZeroAddress,
 			v.dictionary.Count(),
@@ -789,8 +1239,9 @@ func (v *DictionaryValue) GetMember(
 			panic(errors.NewExternalError(err))
 		}
 
-		return NewArrayValueWithIterator(
+		return newArrayValueWithIterator(
 			interpreter,
+			locationRange,
 			NewVariableSizedStaticType(interpreter, v.Type.ValueType),
 			common.ZeroAddress,
 			v.dictionary.Count(),
@@ -929,7 +1380,7 @@ func (v *DictionaryValue) RemoveWithoutTransfer(
 	existingValueStorable atree.Storable,
 ) {
 
-	interpreter.validateMutation(v.ValueID(), locationRange)
+	v.prepareMutation(interpreter, locationRange)
 
 	valueComparator := newValueComparator(interpreter, locationRange)
 	hashInputProvider := newHashInputProvider(interpreter, locationRange)
@@ -962,6 +1413,8 @@ func (v *DictionaryValue) Remove(
 	keyValue Value,
 ) OptionalValue {
 
+	interpreter.ReportComputation(common.ComputationKindDictionaryRemove, 1)
+
 	existingKeyStorable, existingValueStorable := v.RemoveWithoutTransfer(interpreter, locationRange, keyValue)
 
 	if existingKeyStorable == nil {
@@ -1006,7 +1459,7 @@ func (v *DictionaryValue) InsertWithoutTransfer(
 	keyValue, value atree.Value,
 ) (existingValueStorable atree.Storable) {
 
-	interpreter.validateMutation(v.ValueID(), locationRange)
+	v.prepareMutation(interpreter, locationRange)
 
 	// length increases by 1
 	dataSlabs, metaDataSlabs := common.AdditionalAtreeMemoryUsage(v.dictionary.Count(), v.elementSize, false)
@@ -1042,6 +1495,19 @@ func (v *DictionaryValue) Insert(
 	keyValue, value Value,
 ) OptionalValue {
 
+	interpreter.ReportComputation(common.ComputationKindDictionaryInsert, 1)
+
+	return v.insert(interpreter, locationRange, keyValue, value)
+}
+
+// insert inserts the key and value without metering the insertion,
+// e.g. when the dictionary is constructed
+func (v *DictionaryValue) insert(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	keyValue, value Value,
+) OptionalValue {
+
 	address := v.dictionary.Address()
 
 	preventTransfer := map[atree.ValueID]struct{}{
@@ -1071,6 +1537,9 @@ func (v *DictionaryValue) Insert(
 	interpreter.checkContainerMutation(v.Type.KeyType, keyValue, locationRange)
 	interpreter.checkContainerMutation(v.Type.ValueType, value, locationRange)
 
+	interpreter.CheckStoredValueSize(common.Address(address), keyValue, locationRange)
+	interpreter.CheckStoredValueSize(common.Address(address), value, locationRange)
+
 	existingValueStorable := v.InsertWithoutTransfer(interpreter, locationRange, keyValue, value)
 
 	if existingValueStorable == nil {
@@ -1317,6 +1786,12 @@ func (v *DictionaryValue) Transfer(
 		}()
 	}
 
+	if remove {
+		// The entries are removed from the atree map,
+		// so it must not be shared with other dictionary values
+		v.unshare(interpreter, locationRange)
+	}
+
 	currentValueID := v.ValueID()
 
 	if preventTransfer == nil {
@@ -1336,81 +1811,20 @@ func (v *DictionaryValue) Transfer(
 
 	if needsStoreTo || !isResourceKinded {
 
-		valueComparator := newValueComparator(interpreter, locationRange)
-		hashInputProvider := newHashInputProvider(interpreter, locationRange)
-
-		// Use non-readonly iterator here because iterated
-		// value can be removed if remove parameter is true.
-		iterator, err := v.dictionary.Iterator(valueComparator, hashInputProvider)
-		if err != nil {
-			panic(errors.NewExternalError(err))
+		if !remove {
+			interpreter.reportValueCreation(CreatedValueKindDictionary, locationRange)
 		}
 
-		elementCount := v.dictionary.Count()
-
-		elementOverhead, dataUse, metaDataUse := common.NewAtreeMapMemoryUsages(
-			elementCount,
-			v.elementSize,
-		)
-		common.UseMemory(interpreter, elementOverhead)
-		common.UseMemory(interpreter, dataUse)
-		common.UseMemory(interpreter, metaDataUse)
-
-		elementMemoryUse := common.NewAtreeMapPreAllocatedElementsMemoryUsage(
-			elementCount,
-			v.elementSize,
-		)
-		common.UseMemory(config.MemoryGauge, elementMemoryUse)
-
-		dictionary, err = atree.NewMapFromBatchData(
-			config.Storage,
+		dictionary = v.copyAtreeMap(
+			interpreter,
+			locationRange,
 			address,
-			atree.NewDefaultDigesterBuilder(),
-			v.dictionary.Type(),
-			valueComparator,
-			hashInputProvider,
-			v.dictionary.Seed(),
-			func() (atree.Value, atree.Value, error) {
-
-				atreeKey, atreeValue, err := iterator.Next()
-				if err != nil {
-					return nil, nil, err
-				}
-				if atreeKey == nil || atreeValue == nil {
-					return nil, nil, nil
-				}
-
-				key := MustConvertStoredValue(interpreter, atreeKey).
-					Transfer(
-						interpreter,
-						locationRange,
-						address,
-						remove,
-						nil,
-						preventTransfer,
-						false, // atreeKey has parent container because it is returned from iterator.
-					)
-
-				value := MustConvertStoredValue(interpreter, atreeValue).
-					Transfer(
-						interpreter,
-						locationRange,
-						address,
-						remove,
-						nil,
-						preventTransfer,
-						false, // atreeValue has parent container because it is returned from iterator.
-					)
-
-				return key, value, nil
-			},
+			remove,
+			preventTransfer,
 		)
-		if err != nil {
-			panic(errors.NewExternalError(err))
-		}
 
 		if remove {
-			err = v.dictionary.PopIterate(func(keyStorable atree.Storable, valueStorable atree.Storable) {
+			err := v.dictionary.PopIterate(func(keyStorable atree.Storable, valueStorable atree.Storable) {
 				interpreter.RemoveReferencedSlab(keyStorable)
 				interpreter.RemoveReferencedSlab(valueStorable)
 			})
@@ -1456,6 +1870,162 @@ func (v *DictionaryValue) Transfer(
 	return res
 }
 
+// copyAtreeMap returns a copy of the atree map backing the dictionary value,
+// with the keys and values transferred to the given address
+func (v *DictionaryValue) copyAtreeMap(
+	interpreter *Interpreter,
+	locationRange LocationRange,
+	address atree.Address,
+	remove bool,
+	preventTransfer map[atree.ValueID]struct{},
+) *atree.OrderedMap {
+
+	config := interpreter.SharedState.Config
+
+	valueComparator := newValueComparator(interpreter, locationRange)
+	hashInputProvider := newHashInputProvider(interpreter, locationRange)
+
+	// Use non-readonly iterator here because iterated
+	// value can be removed if remove parameter is true.
+	iterator, err := v.dictionary.Iterator(valueComparator, hashInputProvider)
+	if err != nil {
+		panic(errors.NewExternalError(err))
+	}
+
+	elementCount := v.dictionary.Count()
+
+	elementOverhead, dataUse, metaDataUse := common.NewAtreeMapMemoryUsages(
+		elementCount,
+		v.elementSize,
+	)
+	common.UseMemory(interpreter, elementOverhead)
+	common.UseMemory(interpreter, dataUse)
+	common.UseMemory(interpreter, metaDataUse)
+
+	elementMemoryUse := common.NewAtreeMapPreAllocatedElementsMemoryUsage(
+		elementCount,
+		v.elementSize,
+	)
+	common.UseMemory(config.MemoryGauge, elementMemoryUse)
+
+	dictionary, err := atree.NewMapFromBatchData(
+		config.Storage,
+		address,
+		atree.NewDefaultDigesterBuilder(),
+		v.dictionary.Type(),
+		valueComparator,
+		hashInputProvider,
+		v.dictionary.Seed(),
+		func() (atree.Value, atree.Value, error) {
+
+			atreeKey, atreeValue, err := iterator.Next()
+			if err != nil {
+				return nil, nil, err
+			}
+			if atreeKey == nil || atreeValue == nil {
+				return nil, nil, nil
+			}
+
+			key := MustConvertStoredValue(interpreter, atreeKey).
+				Transfer(
+					interpreter,
+					locationRange,
+					address,
+					remove,
+					nil,
+					preventTransfer,
+					false, // atreeKey has parent container because it is returned from iterator.
+				)
+
+			value := MustConvertStoredValue(interpreter, atreeValue).
+				Transfer(
+					interpreter,
+					locationRange,
+					address,
+					remove,
+					nil,
+					preventTransfer,
+					false, // atreeValue has parent container because it is returned from iterator.
+				)
+
+			return key, value, nil
+		},
+	)
+	if err != nil {
+		panic(errors.NewExternalError(err))
+	}
+
+	return dictionary
+}
+
+// canShareCopyOnWrite returns true if the atree map backing the dictionary value
+// can be shared with a copy of the dictionary value, see transferArgument
+func (v *DictionaryValue) canShareCopyOnWrite(interpreter *Interpreter) bool {
+	return !v.IsResourceKinded(interpreter) &&
+		v.StorageAddress() == (atree.Address{}) &&
+		isCopyOnWriteElementType(v.Type.KeyType) &&
+		isCopyOnWriteElementType(v.Type.ValueType)
+}
+
+// shareCopyOnWrite returns a copy of the dictionary value,
+// which shares the atree map with the dictionary value.
+// The atree map is copied when one of the dictionary values sharing it is mutated
+func (v *DictionaryValue) shareCopyOnWrite(interpreter *Interpreter) *DictionaryValue {
+
+	// Report the computation of the transfer,
+	// so the computation is independent of whether the copy is performed
+	interpreter.ReportComputation(
+		common.ComputationKindTransferDictionaryValue,
+		uint(v.Count()),
+	)
+
+	res := newDictionaryValueFromAtreeMap(
+		interpreter,
+		v.Type,
+		v.elementSize,
+		v.dictionary,
+	)
+
+	res.semaType = v.semaType
+	res.isResourceKinded = v.isResourceKinded
+
+	v.copyOnWrite.shareWith(&res.copyOnWrite)
+
+	return res
+}
+
+// unshare copies the atree map backing the dictionary value,
+// if it is shared with other dictionary values
+func (v *DictionaryValue) unshare(interpreter *Interpreter, locationRange LocationRange) {
+	if !v.copyOnWrite.isShared() {
+		v.copyOnWrite.unshare()
+		return
+	}
+
+	v.dictionary = v.copyAtreeMap(
+		interpreter,
+		locationRange,
+		v.StorageAddress(),
+		false,
+		nil,
+	)
+
+	v.copyOnWrite.unshare()
+}
+
+// prepareMutation ensures the dictionary value can be mutated:
+// If the atree map is shared with other dictionary values, it is copied,
+// and the dictionary value must not be iterated over
+func (v *DictionaryValue) prepareMutation(interpreter *Interpreter, locationRange LocationRange) {
+	if v.copyOnWrite.isShared() {
+		v.copyOnWrite.checkNotIterated(locationRange)
+		v.unshare(interpreter, locationRange)
+	}
+
+	interpreter.checkStoredValueMutation(v.GetOwner(), locationRange)
+	interpreter.validateMutation(v.ValueID(), locationRange)
+}
+
 func (v *DictionaryValue) Clone(interpreter *Interpreter) Value {
 	config := interpreter.SharedState.Config
 
@@ -1531,6 +2101,13 @@ func (v *DictionaryValue) DeepRemove(interpreter *Interpreter, hasNoParentContai
 		}()
 	}
 
+	if v.copyOnWrite.isShared() {
+		// The atree map is still used by the other dictionary values sharing it,
+		// so only stop sharing it, instead of removing it
+		v.copyOnWrite.unshare()
+		return
+	}
+
 	// Remove nested values and storables
 
 	storage := v.dictionary.Storage
@@ -1571,10 +2148,10 @@ func (v *DictionaryValue) ValueID() atree.ValueID {
 	return v.dictionary.ValueID()
 }
 
-func (v *DictionaryValue) SemaType(interpreter *Interpreter) *sema.DictionaryType {
+func (v *DictionaryValue) SemaType(context ValueContext) *sema.DictionaryType {
 	if v.semaType == nil {
 		// this function will panic already if this conversion fails
-		v.semaType, _ = interpreter.MustConvertStaticToSemaType(v.Type).(*sema.DictionaryType)
+		v.semaType, _ = context.MustConvertStaticToSemaType(v.Type).(*sema.DictionaryType)
 	}
 	return v.semaType
 }
@@ -1583,9 +2160,9 @@ func (v *DictionaryValue) NeedsStoreTo(address atree.Address) bool {
 	return address != v.StorageAddress()
 }
 
-func (v *DictionaryValue) IsResourceKinded(interpreter *Interpreter) bool {
+func (v *DictionaryValue) IsResourceKinded(context ValueContext) bool {
 	if v.isResourceKinded == nil {
-		isResourceKinded := v.SemaType(interpreter).IsResourceType()
+		isResourceKinded := v.SemaType(context).IsResourceType()
 		v.isResourceKinded = &isResourceKinded
 	}
 	return *v.isResourceKinded
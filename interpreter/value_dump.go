@@ -0,0 +1,319 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"github.com/onflow/cadence/common"
+)
+
+// DumpOptions limits the size of a value dump.
+// A limit of zero means no limit
+type DumpOptions struct {
+	// MaxDepth is the maximum nesting depth of dumped values.
+	// The elements, entries, and fields of containers at the maximum depth are omitted
+	MaxDepth int
+	// MaxItems is the maximum number of elements, entries, or fields dumped per container
+	MaxItems int
+	// MaxStringLen is the maximum number of characters of the text of a dumped value
+	MaxStringLen int
+}
+
+// DumpedValueKind is the kind of dumped value
+type DumpedValueKind string
+
+const (
+	DumpedValueKindNil        DumpedValueKind = "nil"
+	DumpedValueKindSome       DumpedValueKind = "some"
+	DumpedValueKindBool       DumpedValueKind = "bool"
+	DumpedValueKindNumber     DumpedValueKind = "number"
+	DumpedValueKindString     DumpedValueKind = "string"
+	DumpedValueKindCharacter  DumpedValueKind = "character"
+	DumpedValueKindAddress    DumpedValueKind = "address"
+	DumpedValueKindPath       DumpedValueKind = "path"
+	DumpedValueKindArray      DumpedValueKind = "array"
+	DumpedValueKindDictionary DumpedValueKind = "dictionary"
+	DumpedValueKindComposite  DumpedValueKind = "composite"
+	DumpedValueKindReference  DumpedValueKind = "reference"
+	DumpedValueKindFunction   DumpedValueKind = "function"
+	DumpedValueKindOther      DumpedValueKind = "other"
+)
+
+// DumpedValue is a JSON-serializable description of a value
+type DumpedValue struct {
+	Kind DumpedValueKind `json:"kind"`
+	// Type is the ID of the static type of the value
+	Type common.TypeID `json:"type,omitempty"`
+	// Text is the textual representation of a value which is not a container
+	Text string `json:"text,omitempty"`
+	// Count is the total number of elements, entries, or fields of a container
+	Count int `json:"count,omitempty"`
+	// Inner is the inner value of an optional
+	Inner *DumpedValue `json:"inner,omitempty"`
+	// Elements are the dumped elements of an array
+	Elements []DumpedValue `json:"elements,omitempty"`
+	// Entries are the dumped entries of a dictionary
+	Entries []DumpedEntry `json:"entries,omitempty"`
+	// Fields are the dumped fields of a composite
+	Fields []DumpedField `json:"fields,omitempty"`
+	// Truncated is true if the text was shortened,
+	// or if elements, entries, or fields were omitted
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+type DumpedEntry struct {
+	Key   DumpedValue `json:"key"`
+	Value DumpedValue `json:"value"`
+}
+
+type DumpedField struct {
+	Name  string      `json:"name"`
+	Value DumpedValue `json:"value"`
+}
+
+// DumpValue returns a description of the given value, limited by the given options.
+//
+// Containers are only iterated as far as the limits allow,
+// so large values are not fully loaded.
+// References are not dereferenced, and computed fields are not computed.
+func DumpValue(interpreter *Interpreter, value Value, options DumpOptions) DumpedValue {
+	dumper := valueDumper{
+		interpreter: interpreter,
+		options:     options,
+	}
+	return dumper.dump(value, 0)
+}
+
+type valueDumper struct {
+	interpreter *Interpreter
+	options     DumpOptions
+}
+
+func (d valueDumper) dump(value Value, depth int) DumpedValue {
+
+	var result DumpedValue
+
+	// Some host values have no static type
+	staticType := value.StaticType(d.interpreter)
+	if staticType != nil {
+		result.Type = staticType.ID()
+	}
+
+	switch value := value.(type) {
+	case NilValue:
+		result.Kind = DumpedValueKindNil
+		return result
+
+	case *SomeValue:
+		result.Kind = DumpedValueKindSome
+		if d.depthExceeded(depth) {
+			result.Truncated = true
+			return result
+		}
+		inner := d.dump(value.InnerValue(d.interpreter, EmptyLocationRange), depth+1)
+		result.Inner = &inner
+		return result
+
+	case BoolValue:
+		result.Kind = DumpedValueKindBool
+		d.setText(&result, value.String())
+
+	case NumberValue:
+		result.Kind = DumpedValueKindNumber
+		d.setText(&result, value.String())
+
+	case *StringValue:
+		result.Kind = DumpedValueKindString
+		d.setText(&result, value.Str)
+
+	case CharacterValue:
+		result.Kind = DumpedValueKindCharacter
+		d.setText(&result, value.Str)
+
+	case AddressValue:
+		result.Kind = DumpedValueKindAddress
+		d.setText(&result, value.String())
+
+	case PathValue:
+		result.Kind = DumpedValueKindPath
+		d.setText(&result, value.String())
+
+	case *ArrayValue:
+		result.Kind = DumpedValueKindArray
+		result.Count = value.Count()
+		d.dumpArray(&result, value, depth)
+
+	case *DictionaryValue:
+		result.Kind = DumpedValueKindDictionary
+		result.Count = value.Count()
+		d.dumpDictionary(&result, value, depth)
+
+	case *CompositeValue:
+		result.Kind = DumpedValueKindComposite
+		result.Count = value.FieldCount()
+		d.dumpComposite(&result, value, depth)
+
+	case *SimpleCompositeValue:
+		result.Kind = DumpedValueKindComposite
+		for _, fieldName := range value.FieldNames {
+			// Computed fields are not computed, so they are not counted
+			if _, ok := value.Fields[fieldName]; ok {
+				result.Count++
+			}
+		}
+		d.dumpSimpleComposite(&result, value, depth)
+
+	case ReferenceValue:
+		result.Kind = DumpedValueKindReference
+
+	case FunctionValue:
+		result.Kind = DumpedValueKindFunction
+
+	default:
+		result.Kind = DumpedValueKindOther
+		d.setText(&result, value.String())
+	}
+
+	return result
+}
+
+func (d valueDumper) depthExceeded(depth int) bool {
+	return d.options.MaxDepth > 0 && depth >= d.options.MaxDepth
+}
+
+// itemsExceeded returns true if the given number of items already reached the limit
+func (d valueDumper) itemsExceeded(count int) bool {
+	return d.options.MaxItems > 0 && count >= d.options.MaxItems
+}
+
+func (d valueDumper) setText(result *DumpedValue, text string) {
+	maxLength := d.options.MaxStringLen
+	if maxLength > 0 {
+		runes := []rune(text)
+		if len(runes) > maxLength {
+			text = string(runes[:maxLength])
+			result.Truncated = true
+		}
+	}
+	result.Text = text
+}
+
+func (d valueDumper) dumpArray(result *DumpedValue, value *ArrayValue, depth int) {
+	if d.depthExceeded(depth) {
+		result.Truncated = result.Count > 0
+		return
+	}
+
+	value.Iterate(
+		d.interpreter,
+		func(element Value) (resume bool) {
+			if d.itemsExceeded(len(result.Elements)) {
+				return false
+			}
+			result.Elements = append(result.Elements, d.dump(element, depth+1))
+			return true
+		},
+		false,
+		EmptyLocationRange,
+	)
+
+	result.Truncated = len(result.Elements) < result.Count
+}
+
+func (d valueDumper) dumpDictionary(result *DumpedValue, value *DictionaryValue, depth int) {
+	if d.depthExceeded(depth) {
+		result.Truncated = result.Count > 0
+		return
+	}
+
+	value.IterateReadOnly(
+		d.interpreter,
+		EmptyLocationRange,
+		func(key, value Value) (resume bool) {
+			if d.itemsExceeded(len(result.Entries)) {
+				return false
+			}
+			result.Entries = append(
+				result.Entries,
+				DumpedEntry{
+					Key:   d.dump(key, depth+1),
+					Value: d.dump(value, depth+1),
+				},
+			)
+			return true
+		},
+	)
+
+	result.Truncated = len(result.Entries) < result.Count
+}
+
+func (d valueDumper) dumpComposite(result *DumpedValue, value *CompositeValue, depth int) {
+	if d.depthExceeded(depth) {
+		result.Truncated = result.Count > 0
+		return
+	}
+
+	value.forEachField(
+		d.interpreter,
+		value.dictionary.IterateReadOnly,
+		func(fieldName string, fieldValue Value) (resume bool) {
+			if d.itemsExceeded(len(result.Fields)) {
+				return false
+			}
+			result.Fields = append(
+				result.Fields,
+				DumpedField{
+					Name:  fieldName,
+					Value: d.dump(fieldValue, depth+1),
+				},
+			)
+			return true
+		},
+		EmptyLocationRange,
+	)
+
+	result.Truncated = len(result.Fields) < result.Count
+}
+
+func (d valueDumper) dumpSimpleComposite(result *DumpedValue, value *SimpleCompositeValue, depth int) {
+	if d.depthExceeded(depth) {
+		result.Truncated = result.Count > 0
+		return
+	}
+
+	for _, fieldName := range value.FieldNames {
+		if d.itemsExceeded(len(result.Fields)) {
+			break
+		}
+
+		fieldValue, ok := value.Fields[fieldName]
+		if !ok {
+			continue
+		}
+
+		result.Fields = append(
+			result.Fields,
+			DumpedField{
+				Name:  fieldName,
+				Value: d.dump(fieldValue, depth+1),
+			},
+		)
+	}
+
+	result.Truncated = len(result.Fields) < result.Count
+}
@@ -0,0 +1,175 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/onflow/cadence/interpreter"
+)
+
+func TestDumpValue(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      access(all) struct S {
+          access(all) let name: String
+          access(all) let values: [[Int]]
+          access(all) let tags: {String: Bool}
+          access(all) let parent: S?
+
+          init(name: String, parent: S?) {
+              self.name = name
+              self.values = [[1, 2, 3], [4]]
+              self.tags = {"a": true}
+              self.parent = parent
+          }
+      }
+
+      access(all) let s = S(name: "child", parent: S(name: "root", parent: nil))
+      access(all) let numbers = [1, 2, 3, 4, 5]
+      access(all) let ref = &numbers as &[Int]
+    `)
+
+	global := func(name string) Value {
+		return inter.Globals.Get(name).GetValue(inter)
+	}
+
+	t.Run("unlimited", func(t *testing.T) {
+
+		dumped := DumpValue(inter, global("s"), DumpOptions{})
+
+		assert.Equal(t, DumpedValueKindComposite, dumped.Kind)
+		assert.Equal(t, "S.test.S", string(dumped.Type))
+		assert.Equal(t, 4, dumped.Count)
+		assert.False(t, dumped.Truncated)
+
+		fields := map[string]DumpedValue{}
+		for _, field := range dumped.Fields {
+			fields[field.Name] = field.Value
+		}
+		require.Len(t, fields, 4)
+
+		assert.Equal(t,
+			DumpedValue{
+				Kind: DumpedValueKindString,
+				Type: "String",
+				Text: "child",
+			},
+			fields["name"],
+		)
+
+		values := fields["values"]
+		assert.Equal(t, DumpedValueKindArray, values.Kind)
+		assert.Equal(t, 2, values.Count)
+		require.Len(t, values.Elements, 2)
+		require.Len(t, values.Elements[0].Elements, 3)
+		assert.Equal(t, "3", values.Elements[0].Elements[2].Text)
+
+		tags := fields["tags"]
+		assert.Equal(t, DumpedValueKindDictionary, tags.Kind)
+		require.Len(t, tags.Entries, 1)
+		assert.Equal(t, "a", tags.Entries[0].Key.Text)
+		assert.Equal(t, "true", tags.Entries[0].Value.Text)
+
+		parent := fields["parent"]
+		assert.Equal(t, DumpedValueKindSome, parent.Kind)
+		require.NotNil(t, parent.Inner)
+		assert.Equal(t, DumpedValueKindComposite, parent.Inner.Kind)
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+
+		dumped := DumpValue(inter, global("s"), DumpOptions{MaxDepth: 2})
+
+		for _, field := range dumped.Fields {
+			if field.Name != "values" {
+				continue
+			}
+
+			values := field.Value
+			require.Len(t, values.Elements, 2)
+
+			inner := values.Elements[0]
+			assert.Equal(t, 3, inner.Count)
+			assert.Empty(t, inner.Elements)
+			assert.True(t, inner.Truncated)
+		}
+	})
+
+	t.Run("max items", func(t *testing.T) {
+
+		dumped := DumpValue(inter, global("numbers"), DumpOptions{MaxItems: 2})
+
+		assert.Equal(t, 5, dumped.Count)
+		require.Len(t, dumped.Elements, 2)
+		assert.Equal(t, "1", dumped.Elements[0].Text)
+		assert.Equal(t, "2", dumped.Elements[1].Text)
+		assert.True(t, dumped.Truncated)
+	})
+
+	t.Run("max string length", func(t *testing.T) {
+
+		dumped := DumpValue(
+			inter,
+			NewUnmeteredStringValue("hello, world"),
+			DumpOptions{MaxStringLen: 5},
+		)
+
+		assert.Equal(t, "hello", dumped.Text)
+		assert.True(t, dumped.Truncated)
+	})
+
+	t.Run("reference", func(t *testing.T) {
+
+		dumped := DumpValue(inter, global("ref"), DumpOptions{})
+
+		assert.Equal(t,
+			DumpedValue{
+				Kind: DumpedValueKindReference,
+				Type: "&[Int]",
+			},
+			dumped,
+		)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+
+		dumped := DumpValue(inter, global("numbers"), DumpOptions{MaxItems: 1})
+
+		encoded, err := json.Marshal(dumped)
+		require.NoError(t, err)
+
+		assert.JSONEq(t,
+			`{
+              "kind": "array",
+              "type": "[Int]",
+              "count": 5,
+              "elements": [{"kind": "number", "type": "Int", "text": "1"}],
+              "truncated": true
+            }`,
+			string(encoded),
+		)
+	})
+}
@@ -65,6 +65,7 @@ func NewUnmeteredEphemeralReferenceValue(
 	}
 
 	interpreter.maybeTrackReferencedResourceKindedValue(ref)
+	interpreter.recordReferenceCreation(ref, locationRange)
 
 	return ref
 }
@@ -99,23 +100,23 @@ func (v *EphemeralReferenceValue) RecursiveString(seenReferences SeenReferences)
 	return v.MeteredString(nil, seenReferences, EmptyLocationRange)
 }
 
-func (v *EphemeralReferenceValue) MeteredString(interpreter *Interpreter, seenReferences SeenReferences, locationRange LocationRange) string {
+func (v *EphemeralReferenceValue) MeteredString(context ValueContext, seenReferences SeenReferences, locationRange LocationRange) string {
 	if _, ok := seenReferences[v]; ok {
-		common.UseMemory(interpreter, common.SeenReferenceStringMemoryUsage)
+		common.UseMemory(context, common.SeenReferenceStringMemoryUsage)
 		return "..."
 	}
 
 	seenReferences[v] = struct{}{}
 	defer delete(seenReferences, v)
 
-	return v.Value.MeteredString(interpreter, seenReferences, locationRange)
+	return v.Value.MeteredString(context, seenReferences, locationRange)
 }
 
-func (v *EphemeralReferenceValue) StaticType(inter *Interpreter) StaticType {
+func (v *EphemeralReferenceValue) StaticType(context ValueContext) StaticType {
 	return NewReferenceStaticType(
-		inter,
+		context,
 		v.Authorization,
-		v.Value.StaticType(inter),
+		v.Value.StaticType(context),
 	)
 }
 
@@ -123,7 +124,7 @@ func (v *EphemeralReferenceValue) GetAuthorization() Authorization {
 	return v.Authorization
 }
 
-func (*EphemeralReferenceValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (*EphemeralReferenceValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return false
 }
 
@@ -306,7 +307,7 @@ func (*EphemeralReferenceValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (*EphemeralReferenceValue) IsResourceKinded(_ *Interpreter) bool {
+func (*EphemeralReferenceValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
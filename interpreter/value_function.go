@@ -88,10 +88,10 @@ func (f *InterpretedFunctionValue) RecursiveString(_ SeenReferences) string {
 	return f.String()
 }
 
-func (f *InterpretedFunctionValue) MeteredString(interpreter *Interpreter, _ SeenReferences, locationRange LocationRange) string {
+func (f *InterpretedFunctionValue) MeteredString(context ValueContext, _ SeenReferences, locationRange LocationRange) string {
 	// TODO: Meter sema.Type String conversion
 	typeString := f.Type.String()
-	common.UseMemory(interpreter, common.NewRawStringMemoryUsage(8+len(typeString)))
+	common.UseMemory(context, common.NewRawStringMemoryUsage(8+len(typeString)))
 	return f.String()
 }
 
@@ -103,11 +103,11 @@ func (f *InterpretedFunctionValue) Walk(_ *Interpreter, _ func(Value), _ Locatio
 	// NO-OP
 }
 
-func (f *InterpretedFunctionValue) StaticType(interpreter *Interpreter) StaticType {
-	return ConvertSemaToStaticType(interpreter, f.Type)
+func (f *InterpretedFunctionValue) StaticType(context ValueContext) StaticType {
+	return ConvertSemaToStaticType(context, f.Type)
 }
 
-func (*InterpretedFunctionValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (*InterpretedFunctionValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return false
 }
 
@@ -141,7 +141,7 @@ func (*InterpretedFunctionValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (*InterpretedFunctionValue) IsResourceKinded(_ *Interpreter) bool {
+func (*InterpretedFunctionValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
@@ -186,8 +186,8 @@ func (f *HostFunctionValue) RecursiveString(_ SeenReferences) string {
 	return f.String()
 }
 
-func (f *HostFunctionValue) MeteredString(interpreter *Interpreter, _ SeenReferences, locationRange LocationRange) string {
-	common.UseMemory(interpreter, common.HostFunctionValueStringMemoryUsage)
+func (f *HostFunctionValue) MeteredString(context ValueContext, _ SeenReferences, locationRange LocationRange) string {
+	common.UseMemory(context, common.HostFunctionValueStringMemoryUsage)
 	return f.String()
 }
 
@@ -237,11 +237,11 @@ func (f *HostFunctionValue) Walk(_ *Interpreter, _ func(Value), _ LocationRange)
 	// NO-OP
 }
 
-func (f *HostFunctionValue) StaticType(interpreter *Interpreter) StaticType {
-	return ConvertSemaToStaticType(interpreter, f.Type)
+func (f *HostFunctionValue) StaticType(context ValueContext) StaticType {
+	return ConvertSemaToStaticType(context, f.Type)
 }
 
-func (*HostFunctionValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (*HostFunctionValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return false
 }
 
@@ -294,7 +294,7 @@ func (*HostFunctionValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (*HostFunctionValue) IsResourceKinded(_ *Interpreter) bool {
+func (*HostFunctionValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
@@ -401,8 +401,8 @@ func (f BoundFunctionValue) RecursiveString(seenReferences SeenReferences) strin
 	return f.Function.RecursiveString(seenReferences)
 }
 
-func (f BoundFunctionValue) MeteredString(interpreter *Interpreter, seenReferences SeenReferences, locationRange LocationRange) string {
-	return f.Function.MeteredString(interpreter, seenReferences, locationRange)
+func (f BoundFunctionValue) MeteredString(context ValueContext, seenReferences SeenReferences, locationRange LocationRange) string {
+	return f.Function.MeteredString(context, seenReferences, locationRange)
 }
 
 func (f BoundFunctionValue) Accept(interpreter *Interpreter, visitor Visitor, _ LocationRange) {
@@ -413,11 +413,11 @@ func (f BoundFunctionValue) Walk(_ *Interpreter, _ func(Value), _ LocationRange)
 	// NO-OP
 }
 
-func (f BoundFunctionValue) StaticType(inter *Interpreter) StaticType {
-	return f.Function.StaticType(inter)
+func (f BoundFunctionValue) StaticType(context ValueContext) StaticType {
+	return f.Function.StaticType(context)
 }
 
-func (BoundFunctionValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (BoundFunctionValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return false
 }
 
@@ -459,7 +459,7 @@ func (f BoundFunctionValue) invoke(invocation Invocation) Value {
 			})
 		}
 	} else {
-		inter.checkInvalidatedResourceOrResourceReference(f.SelfReference, locationRange)
+		inter.CheckInvalidatedResourceOrResourceReference(f.SelfReference, locationRange)
 	}
 
 	return f.Function.invoke(invocation)
@@ -485,7 +485,7 @@ func (BoundFunctionValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (BoundFunctionValue) IsResourceKinded(_ *Interpreter) bool {
+func (BoundFunctionValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
@@ -109,11 +109,11 @@ func (IntValue) Walk(_ *Interpreter, _ func(Value), _ LocationRange) {
 	// NO-OP
 }
 
-func (IntValue) StaticType(interpreter *Interpreter) StaticType {
-	return NewPrimitiveStaticType(interpreter, PrimitiveStaticTypeInt)
+func (IntValue) StaticType(context ValueContext) StaticType {
+	return NewPrimitiveStaticType(context, PrimitiveStaticTypeInt)
 }
 
-func (IntValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (IntValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return true
 }
 
@@ -161,11 +161,11 @@ func (v IntValue) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v IntValue) MeteredString(interpreter *Interpreter, _ SeenReferences, locationRange LocationRange) string {
+func (v IntValue) MeteredString(context ValueContext, _ SeenReferences, locationRange LocationRange) string {
 	common.UseMemory(
-		interpreter,
+		context,
 		common.NewRawStringMemoryUsage(
-			OverEstimateNumberStringLength(interpreter, v),
+			OverEstimateNumberStringLength(context, v),
 		),
 	)
 	return v.String()
@@ -192,6 +192,8 @@ func (v IntValue) Plus(interpreter *Interpreter, other NumberValue, locationRang
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewIntValueFromBigInt(
 		interpreter,
 		common.NewPlusBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -229,6 +231,8 @@ func (v IntValue) Minus(interpreter *Interpreter, other NumberValue, locationRan
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewIntValueFromBigInt(
 		interpreter,
 		common.NewMinusBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -266,6 +270,8 @@ func (v IntValue) Mod(interpreter *Interpreter, other NumberValue, locationRange
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewIntValueFromBigInt(
 		interpreter,
 		common.NewModBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -293,6 +299,8 @@ func (v IntValue) Mul(interpreter *Interpreter, other NumberValue, locationRange
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewIntValueFromBigInt(
 		interpreter,
 		common.NewMulBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -330,6 +338,8 @@ func (v IntValue) Div(interpreter *Interpreter, other NumberValue, locationRange
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewIntValueFromBigInt(
 		interpreter,
 		common.NewDivBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -462,6 +472,8 @@ func (v IntValue) BitwiseOr(interpreter *Interpreter, other IntegerValue, locati
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewIntValueFromBigInt(
 		interpreter,
 		common.NewBitwiseOrBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -483,6 +495,8 @@ func (v IntValue) BitwiseXor(interpreter *Interpreter, other IntegerValue, locat
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewIntValueFromBigInt(
 		interpreter,
 		common.NewBitwiseXorBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -504,6 +518,8 @@ func (v IntValue) BitwiseAnd(interpreter *Interpreter, other IntegerValue, locat
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewIntValueFromBigInt(
 		interpreter,
 		common.NewBitwiseAndBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -525,6 +541,8 @@ func (v IntValue) BitwiseLeftShift(interpreter *Interpreter, other IntegerValue,
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	if o.BigInt.Sign() < 0 {
 		panic(NegativeShiftError{
 			LocationRange: locationRange,
@@ -537,6 +555,8 @@ func (v IntValue) BitwiseLeftShift(interpreter *Interpreter, other IntegerValue,
 		})
 	}
 
+	interpreter.checkBigIntegerLeftShift(v.BigInt, o.BigInt.Uint64(), locationRange)
+
 	return NewIntValueFromBigInt(
 		interpreter,
 		common.NewBitwiseLeftShiftBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -558,6 +578,8 @@ func (v IntValue) BitwiseRightShift(interpreter *Interpreter, other IntegerValue
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	if o.BigInt.Sign() < 0 {
 		panic(NegativeShiftError{
 			LocationRange: locationRange,
@@ -614,7 +636,7 @@ func (IntValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (IntValue) IsResourceKinded(_ *Interpreter) bool {
+func (IntValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
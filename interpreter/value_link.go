@@ -59,7 +59,7 @@ func (v PathLinkValue) Walk(_ *Interpreter, _ func(Value), _ LocationRange) {
 	panic(errors.NewUnreachableError())
 }
 
-func (v PathLinkValue) StaticType(interpreter *Interpreter) StaticType {
+func (v PathLinkValue) StaticType(context ValueContext) StaticType {
 	// When iterating over public/private paths,
 	// the values at these paths are PathLinkValues,
 	// placed there by the `link` function.
@@ -67,10 +67,10 @@ func (v PathLinkValue) StaticType(interpreter *Interpreter) StaticType {
 	// These are loaded as links, however,
 	// for the purposes of checking their type,
 	// we treat them as capabilities
-	return NewCapabilityStaticType(interpreter, v.Type)
+	return NewCapabilityStaticType(context, v.Type)
 }
 
-func (PathLinkValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (PathLinkValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	panic(errors.NewUnreachableError())
 }
 
@@ -86,7 +86,7 @@ func (v PathLinkValue) RecursiveString(seenReferences SeenReferences) string {
 	)
 }
 
-func (v PathLinkValue) MeteredString(_ *Interpreter, _ SeenReferences, _ LocationRange) string {
+func (v PathLinkValue) MeteredString(_ ValueContext, _ SeenReferences, _ LocationRange) string {
 	panic(errors.NewUnreachableError())
 }
 
@@ -120,7 +120,7 @@ func (PathLinkValue) NeedsStoreTo(_ atree.Address) bool {
 	panic(errors.NewUnreachableError())
 }
 
-func (PathLinkValue) IsResourceKinded(_ *Interpreter) bool {
+func (PathLinkValue) IsResourceKinded(_ ValueContext) bool {
 	panic(errors.NewUnreachableError())
 }
 
@@ -184,7 +184,7 @@ func (AccountLinkValue) Walk(_ *Interpreter, _ func(Value), _ LocationRange) {
 	panic(errors.NewUnreachableError())
 }
 
-func (v AccountLinkValue) StaticType(interpreter *Interpreter) StaticType {
+func (v AccountLinkValue) StaticType(context ValueContext) StaticType {
 	// When iterating over public/private paths,
 	// the values at these paths are AccountLinkValues,
 	// placed there by the `linkAccount` function.
@@ -193,16 +193,16 @@ func (v AccountLinkValue) StaticType(interpreter *Interpreter) StaticType {
 	// for the purposes of checking their type,
 	// we treat them as capabilities
 	return NewCapabilityStaticType(
-		interpreter,
+		context,
 		NewReferenceStaticType(
-			interpreter,
+			context,
 			FullyEntitledAccountAccess,
 			PrimitiveStaticTypeAccount,
 		),
 	)
 }
 
-func (AccountLinkValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (AccountLinkValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	panic(errors.NewUnreachableError())
 }
 
@@ -214,7 +214,7 @@ func (v AccountLinkValue) RecursiveString(_ SeenReferences) string {
 	panic(errors.NewUnreachableError())
 }
 
-func (v AccountLinkValue) MeteredString(_ *Interpreter, _ SeenReferences, _ LocationRange) string {
+func (v AccountLinkValue) MeteredString(_ ValueContext, _ SeenReferences, _ LocationRange) string {
 	panic(errors.NewUnreachableError())
 }
 
@@ -243,7 +243,7 @@ func (AccountLinkValue) NeedsStoreTo(_ atree.Address) bool {
 	panic(errors.NewUnreachableError())
 }
 
-func (AccountLinkValue) IsResourceKinded(_ *Interpreter) bool {
+func (AccountLinkValue) IsResourceKinded(_ ValueContext) bool {
 	panic(errors.NewUnreachableError())
 }
 
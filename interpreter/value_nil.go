@@ -51,14 +51,14 @@ func (NilValue) Walk(_ *Interpreter, _ func(Value), _ LocationRange) {
 	// NO-OP
 }
 
-func (NilValue) StaticType(interpreter *Interpreter) StaticType {
+func (NilValue) StaticType(context ValueContext) StaticType {
 	return NewOptionalStaticType(
-		interpreter,
-		NewPrimitiveStaticType(interpreter, PrimitiveStaticTypeNever),
+		context,
+		NewPrimitiveStaticType(context, PrimitiveStaticTypeNever),
 	)
 }
 
-func (NilValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (NilValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return true
 }
 
@@ -84,8 +84,8 @@ func (v NilValue) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v NilValue) MeteredString(interpreter *Interpreter, _ SeenReferences, locationRange LocationRange) string {
-	common.UseMemory(interpreter, common.NilValueStringMemoryUsage)
+func (v NilValue) MeteredString(context ValueContext, _ SeenReferences, locationRange LocationRange) string {
+	common.UseMemory(context, common.NilValueStringMemoryUsage)
 	return v.String()
 }
 
@@ -142,7 +142,7 @@ func (NilValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (NilValue) IsResourceKinded(_ *Interpreter) bool {
+func (NilValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
@@ -65,20 +65,20 @@ func (PathValue) Walk(_ *Interpreter, _ func(Value), _ LocationRange) {
 	// NO-OP
 }
 
-func (v PathValue) StaticType(interpreter *Interpreter) StaticType {
+func (v PathValue) StaticType(context ValueContext) StaticType {
 	switch v.Domain {
 	case common.PathDomainStorage:
-		return NewPrimitiveStaticType(interpreter, PrimitiveStaticTypeStoragePath)
+		return NewPrimitiveStaticType(context, PrimitiveStaticTypeStoragePath)
 	case common.PathDomainPublic:
-		return NewPrimitiveStaticType(interpreter, PrimitiveStaticTypePublicPath)
+		return NewPrimitiveStaticType(context, PrimitiveStaticTypePublicPath)
 	case common.PathDomainPrivate:
-		return NewPrimitiveStaticType(interpreter, PrimitiveStaticTypePrivatePath)
+		return NewPrimitiveStaticType(context, PrimitiveStaticTypePrivatePath)
 	default:
 		panic(errors.NewUnreachableError())
 	}
 }
 
-func (v PathValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (v PathValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	switch v.Domain {
 	case common.PathDomainStorage:
 		return sema.StoragePathType.Importable
@@ -102,10 +102,10 @@ func (v PathValue) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v PathValue) MeteredString(interpreter *Interpreter, _ SeenReferences, locationRange LocationRange) string {
+func (v PathValue) MeteredString(context ValueContext, _ SeenReferences, locationRange LocationRange) string {
 	// len(domain) + len(identifier) + '/' x2
 	strLen := len(v.Domain.Identifier()) + len(v.Identifier) + 2
-	common.UseMemory(interpreter, common.NewRawStringMemoryUsage(strLen))
+	common.UseMemory(context, common.NewRawStringMemoryUsage(strLen))
 	return v.String()
 }
 
@@ -225,7 +225,7 @@ func (PathValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (PathValue) IsResourceKinded(_ *Interpreter) bool {
+func (PathValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
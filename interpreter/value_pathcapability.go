@@ -69,14 +69,14 @@ func (v *PathCapabilityValue) Walk(_ *Interpreter, walkChild func(Value), _ Loca
 	walkChild(v.Path)
 }
 
-func (v *PathCapabilityValue) StaticType(inter *Interpreter) StaticType {
+func (v *PathCapabilityValue) StaticType(context ValueContext) StaticType {
 	return NewCapabilityStaticType(
-		inter,
+		context,
 		v.BorrowType,
 	)
 }
 
-func (v *PathCapabilityValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (v *PathCapabilityValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return false
 }
 func (v *PathCapabilityValue) String() string {
@@ -102,25 +102,25 @@ func (v *PathCapabilityValue) RecursiveString(seenReferences SeenReferences) str
 }
 
 func (v *PathCapabilityValue) MeteredString(
-	interpreter *Interpreter,
+	context ValueContext,
 	seenReferences SeenReferences,
 	locationRange LocationRange,
 ) string {
-	common.UseMemory(interpreter, common.PathCapabilityValueStringMemoryUsage)
+	common.UseMemory(context, common.PathCapabilityValueStringMemoryUsage)
 
 	borrowType := v.BorrowType
 	if borrowType == nil {
 		return fmt.Sprintf(
 			"Capability(address: %s, path: %s)",
-			v.address.MeteredString(interpreter, seenReferences, locationRange),
-			v.Path.MeteredString(interpreter, seenReferences, locationRange),
+			v.address.MeteredString(context, seenReferences, locationRange),
+			v.Path.MeteredString(context, seenReferences, locationRange),
 		)
 	} else {
 		return fmt.Sprintf(
 			"Capability<%s>(address: %s, path: %s)",
 			borrowType.String(),
-			v.address.MeteredString(interpreter, seenReferences, locationRange),
-			v.Path.MeteredString(interpreter, seenReferences, locationRange),
+			v.address.MeteredString(context, seenReferences, locationRange),
+			v.Path.MeteredString(context, seenReferences, locationRange),
 		)
 	}
 }
@@ -240,7 +240,7 @@ func (*PathCapabilityValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (*PathCapabilityValue) IsResourceKinded(_ *Interpreter) bool {
+func (*PathCapabilityValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
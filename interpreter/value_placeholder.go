@@ -39,7 +39,7 @@ func (f placeholderValue) RecursiveString(_ SeenReferences) string {
 	return ""
 }
 
-func (f placeholderValue) MeteredString(_ *Interpreter, _ SeenReferences, _ LocationRange) string {
+func (f placeholderValue) MeteredString(_ ValueContext, _ SeenReferences, _ LocationRange) string {
 	return ""
 }
 
@@ -51,11 +51,11 @@ func (f placeholderValue) Walk(_ *Interpreter, _ func(Value), _ LocationRange) {
 	// NO-OP
 }
 
-func (f placeholderValue) StaticType(_ *Interpreter) StaticType {
+func (f placeholderValue) StaticType(_ ValueContext) StaticType {
 	return PrimitiveStaticTypeNever
 }
 
-func (placeholderValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (placeholderValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return false
 }
 
@@ -75,7 +75,7 @@ func (placeholderValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (placeholderValue) IsResourceKinded(_ *Interpreter) bool {
+func (placeholderValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
@@ -53,13 +53,13 @@ func (v *PublishedValue) Accept(interpreter *Interpreter, visitor Visitor, _ Loc
 	visitor.VisitPublishedValue(interpreter, v)
 }
 
-func (v *PublishedValue) StaticType(interpreter *Interpreter) StaticType {
+func (v *PublishedValue) StaticType(context ValueContext) StaticType {
 	// checking the static type of a published value should show us the
 	// static type of the underlying value
-	return v.Value.StaticType(interpreter)
+	return v.Value.StaticType(context)
 }
 
-func (*PublishedValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (*PublishedValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return false
 }
 
@@ -75,13 +75,13 @@ func (v *PublishedValue) RecursiveString(seenReferences SeenReferences) string {
 	)
 }
 
-func (v *PublishedValue) MeteredString(interpreter *Interpreter, seenReferences SeenReferences, locationRange LocationRange) string {
-	common.UseMemory(interpreter, common.PublishedValueStringMemoryUsage)
+func (v *PublishedValue) MeteredString(context ValueContext, seenReferences SeenReferences, locationRange LocationRange) string {
+	common.UseMemory(context, common.PublishedValueStringMemoryUsage)
 
 	return fmt.Sprintf(
 		"PublishedValue<%s>(%s)",
-		v.Recipient.MeteredString(interpreter, seenReferences, locationRange),
-		v.Value.MeteredString(interpreter, seenReferences, locationRange),
+		v.Recipient.MeteredString(context, seenReferences, locationRange),
+		v.Value.MeteredString(context, seenReferences, locationRange),
 	)
 }
 
@@ -120,7 +120,7 @@ func (v *PublishedValue) NeedsStoreTo(address atree.Address) bool {
 	return v.Value.NeedsStoreTo(address)
 }
 
-func (*PublishedValue) IsResourceKinded(_ *Interpreter) bool {
+func (*PublishedValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
@@ -91,23 +91,23 @@ func (v *SomeValue) Walk(_ *Interpreter, walkChild func(Value), _ LocationRange)
 	walkChild(v.value)
 }
 
-func (v *SomeValue) StaticType(inter *Interpreter) StaticType {
+func (v *SomeValue) StaticType(context ValueContext) StaticType {
 	if v.isDestroyed {
 		return nil
 	}
 
-	innerType := v.value.StaticType(inter)
+	innerType := v.value.StaticType(context)
 	if innerType == nil {
 		return nil
 	}
 	return NewOptionalStaticType(
-		inter,
+		context,
 		innerType,
 	)
 }
 
-func (v *SomeValue) IsImportable(inter *Interpreter, locationRange LocationRange) bool {
-	return v.value.IsImportable(inter, locationRange)
+func (v *SomeValue) IsImportable(context ValueContext, locationRange LocationRange) bool {
+	return v.value.IsImportable(context, locationRange)
 }
 
 func (*SomeValue) isOptionalValue() {}
@@ -141,8 +141,8 @@ func (v *SomeValue) RecursiveString(seenReferences SeenReferences) string {
 	return v.value.RecursiveString(seenReferences)
 }
 
-func (v *SomeValue) MeteredString(interpreter *Interpreter, seenReferences SeenReferences, locationRange LocationRange) string {
-	return v.value.MeteredString(interpreter, seenReferences, locationRange)
+func (v *SomeValue) MeteredString(context ValueContext, seenReferences SeenReferences, locationRange LocationRange) string {
+	return v.value.MeteredString(context, seenReferences, locationRange)
 }
 
 func (v *SomeValue) GetMember(interpreter *Interpreter, _ LocationRange, name string) Value {
@@ -321,13 +321,13 @@ func (v *SomeValue) NeedsStoreTo(address atree.Address) bool {
 	return v.value.NeedsStoreTo(address)
 }
 
-func (v *SomeValue) IsResourceKinded(interpreter *Interpreter) bool {
+func (v *SomeValue) IsResourceKinded(context ValueContext) bool {
 	// If the inner value is `nil`, then this is an invalidated resource.
 	if v.value == nil {
 		return true
 	}
 
-	return v.value.IsResourceKinded(interpreter)
+	return v.value.IsResourceKinded(context)
 }
 
 func (v *SomeValue) Transfer(
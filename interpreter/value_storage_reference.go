@@ -93,13 +93,13 @@ func (v *StorageReferenceValue) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v *StorageReferenceValue) MeteredString(interpreter *Interpreter, _ SeenReferences, locationRange LocationRange) string {
-	common.UseMemory(interpreter, common.StorageReferenceValueStringMemoryUsage)
+func (v *StorageReferenceValue) MeteredString(context ValueContext, _ SeenReferences, locationRange LocationRange) string {
+	common.UseMemory(context, common.StorageReferenceValueStringMemoryUsage)
 	return v.String()
 }
 
-func (v *StorageReferenceValue) StaticType(inter *Interpreter) StaticType {
-	referencedValue, err := v.dereference(inter, EmptyLocationRange)
+func (v *StorageReferenceValue) StaticType(context ValueContext) StaticType {
+	referencedValue, err := v.dereference(context, EmptyLocationRange)
 	if err != nil {
 		panic(err)
 	}
@@ -107,9 +107,9 @@ func (v *StorageReferenceValue) StaticType(inter *Interpreter) StaticType {
 	self := *referencedValue
 
 	return NewReferenceStaticType(
-		inter,
+		context,
 		v.Authorization,
-		self.StaticType(inter),
+		self.StaticType(context),
 	)
 }
 
@@ -117,18 +117,18 @@ func (v *StorageReferenceValue) GetAuthorization() Authorization {
 	return v.Authorization
 }
 
-func (*StorageReferenceValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (*StorageReferenceValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return false
 }
 
-func (v *StorageReferenceValue) dereference(interpreter *Interpreter, locationRange LocationRange) (*Value, error) {
+func (v *StorageReferenceValue) dereference(context ValueContext, locationRange LocationRange) (*Value, error) {
 	address := v.TargetStorageAddress
 	domain := v.TargetPath.Domain.StorageDomain()
 	identifier := v.TargetPath.Identifier
 
 	storageMapKey := StringStorageMapKey(identifier)
 
-	referenced := interpreter.ReadStored(address, domain, storageMapKey)
+	referenced := context.ReadStored(address, domain, storageMapKey)
 	if referenced == nil {
 		return nil, nil
 	}
@@ -141,10 +141,10 @@ func (v *StorageReferenceValue) dereference(interpreter *Interpreter, locationRa
 	}
 
 	if v.BorrowedType != nil {
-		staticType := referenced.StaticType(interpreter)
+		staticType := referenced.StaticType(context)
 
-		if !interpreter.IsSubTypeOfSemaType(staticType, v.BorrowedType) {
-			semaType := interpreter.MustConvertStaticToSemaType(staticType)
+		if !context.IsSubTypeOfSemaType(staticType, v.BorrowedType) {
+			semaType := context.MustConvertStaticToSemaType(staticType)
 
 			return nil, ForceCastTypeMismatchError{
 				ExpectedType:  v.BorrowedType,
@@ -168,6 +168,7 @@ func (v *StorageReferenceValue) ReferencedValue(interpreter *Interpreter, locati
 			panic(DereferenceError{
 				ExpectedType:  forceCastErr.ExpectedType,
 				ActualType:    forceCastErr.ActualType,
+				Provenance:    v.provenance(interpreter),
 				LocationRange: locationRange,
 			})
 		}
@@ -184,6 +185,7 @@ func (v *StorageReferenceValue) mustReferencedValue(
 	if referencedValue == nil {
 		panic(DereferenceError{
 			Cause:         "no value is stored at this path",
+			Provenance:    v.provenance(interpreter),
 			LocationRange: locationRange,
 		})
 	}
@@ -191,6 +193,17 @@ func (v *StorageReferenceValue) mustReferencedValue(
 	return *referencedValue
 }
 
+// provenance returns the description of the target of the reference,
+// and of its creation, if reference tracing is enabled
+func (v *StorageReferenceValue) provenance(interpreter *Interpreter) *StorageReferenceProvenance {
+	return &StorageReferenceProvenance{
+		Address:    v.TargetStorageAddress,
+		Path:       v.TargetPath,
+		BorrowType: v.BorrowedType,
+		Trace:      interpreter.referenceTrace(v),
+	}
+}
+
 func (v *StorageReferenceValue) GetMember(
 	interpreter *Interpreter,
 	locationRange LocationRange,
@@ -378,7 +391,7 @@ func (*StorageReferenceValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (*StorageReferenceValue) IsResourceKinded(_ *Interpreter) bool {
+func (*StorageReferenceValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
@@ -454,7 +467,7 @@ func forEachReference(
 		// The loop dereference the reference once, and hold onto that referenced-value.
 		// But the reference could get invalidated during the iteration, making that referenced-value invalid.
 		// So check the validity of the reference, before each iteration.
-		interpreter.checkInvalidatedResourceOrResourceReference(reference, locationRange)
+		interpreter.CheckInvalidatedResourceOrResourceReference(reference, locationRange)
 
 		if isResultReference {
 			value = interpreter.getReferenceValue(value, elementType, locationRange)
@@ -118,11 +118,11 @@ func (v *StorageCapabilityControllerValue) Walk(_ *Interpreter, walkChild func(V
 	walkChild(v.CapabilityID)
 }
 
-func (v *StorageCapabilityControllerValue) StaticType(_ *Interpreter) StaticType {
+func (v *StorageCapabilityControllerValue) StaticType(_ ValueContext) StaticType {
 	return PrimitiveStaticTypeStorageCapabilityController
 }
 
-func (*StorageCapabilityControllerValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (*StorageCapabilityControllerValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return false
 }
 
@@ -139,16 +139,16 @@ func (v *StorageCapabilityControllerValue) RecursiveString(seenReferences SeenRe
 }
 
 func (v *StorageCapabilityControllerValue) MeteredString(
-	interpreter *Interpreter,
+	context ValueContext,
 	seenReferences SeenReferences,
 	locationRange LocationRange,
 ) string {
-	common.UseMemory(interpreter, common.StorageCapabilityControllerValueStringMemoryUsage)
+	common.UseMemory(context, common.StorageCapabilityControllerValueStringMemoryUsage)
 
 	return format.StorageCapabilityController(
-		v.BorrowType.MeteredString(interpreter),
-		v.CapabilityID.MeteredString(interpreter, seenReferences, locationRange),
-		v.TargetPath.MeteredString(interpreter, seenReferences, locationRange),
+		v.BorrowType.MeteredString(context),
+		v.CapabilityID.MeteredString(context, seenReferences, locationRange),
+		v.TargetPath.MeteredString(context, seenReferences, locationRange),
 	)
 }
 
@@ -194,7 +194,7 @@ func (*StorageCapabilityControllerValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (*StorageCapabilityControllerValue) IsResourceKinded(_ *Interpreter) bool {
+func (*StorageCapabilityControllerValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
@@ -229,7 +229,15 @@ func (v *StorageCapabilityControllerValue) ByteSize() uint32 {
 	return mustStorableSize(v)
 }
 
-func (v *StorageCapabilityControllerValue) StoredValue(_ atree.SlabStorage) (atree.Value, error) {
+func (v *StorageCapabilityControllerValue) StoredValue(storage atree.SlabStorage) (atree.Value, error) {
+	if sharesSlabs(storage) {
+		// The functions are injected and initialized lazily
+		return NewUnmeteredStorageCapabilityControllerValue(
+			v.BorrowType,
+			v.CapabilityID,
+			v.TargetPath,
+		), nil
+	}
 	return v, nil
 }
 
@@ -332,19 +340,23 @@ func (v *StorageCapabilityControllerValue) ReferenceValue(
 	interpreter *Interpreter,
 	capabilityAddress common.Address,
 	resultBorrowType *sema.ReferenceType,
-	_ LocationRange,
+	locationRange LocationRange,
 ) ReferenceValue {
 	authorization := ConvertSemaAccessToStaticAuthorization(
 		interpreter,
 		resultBorrowType.Authorization,
 	)
-	return NewStorageReferenceValue(
+	reference := NewStorageReferenceValue(
 		interpreter,
 		authorization,
 		capabilityAddress,
 		v.TargetPath,
 		resultBorrowType.Type,
 	)
+
+	interpreter.recordReferenceCreation(reference, locationRange)
+
+	return reference
 }
 
 // checkDeleted checks if the controller is deleted,
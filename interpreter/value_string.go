@@ -79,9 +79,25 @@ func NewStringValue(
 ) *StringValue {
 	common.UseMemory(memoryGauge, memoryUsage)
 	str := stringConstructor()
-	// NewUnmeteredStringValue normalizes (= allocates)
-	common.UseMemory(memoryGauge, common.NewRawStringMemoryUsage(len(str)))
-	return NewUnmeteredStringValue(str)
+	return &StringValue{
+		Str:             normalizeString(memoryGauge, str),
+		UnnormalizedStr: str,
+		// a negative value indicates the length has not been initialized, see Length()
+		length: -1,
+	}
+}
+
+// normalizeString returns the NFC normalization of the given string.
+// Normalization only allocates if the string is not already normalized,
+// so the normalized string is only metered in that case, based on its actual length
+func normalizeString(memoryGauge common.MemoryGauge, str string) string {
+	if norm.NFC.IsNormalString(str) {
+		return str
+	}
+
+	normalized := norm.NFC.String(str)
+	common.UseMemory(memoryGauge, common.NewRawStringMemoryUsage(len(normalized)))
+	return normalized
 }
 
 var _ Value = &StringValue{}
@@ -121,11 +137,11 @@ func (*StringValue) Walk(_ *Interpreter, _ func(Value), _ LocationRange) {
 	// NO-OP
 }
 
-func (*StringValue) StaticType(interpreter *Interpreter) StaticType {
-	return NewPrimitiveStaticType(interpreter, PrimitiveStaticTypeString)
+func (*StringValue) StaticType(context ValueContext) StaticType {
+	return NewPrimitiveStaticType(context, PrimitiveStaticTypeString)
 }
 
-func (*StringValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (*StringValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return sema.StringType.Importable
 }
 
@@ -137,9 +153,9 @@ func (v *StringValue) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v *StringValue) MeteredString(interpreter *Interpreter, _ SeenReferences, locationRange LocationRange) string {
+func (v *StringValue) MeteredString(context ValueContext, _ SeenReferences, locationRange LocationRange) string {
 	l := format.FormattedStringLength(v.Str)
-	common.UseMemory(interpreter, common.NewRawStringMemoryUsage(l))
+	common.UseMemory(context, common.NewRawStringMemoryUsage(l))
 	return v.String()
 }
 
@@ -235,6 +251,7 @@ func (v *StringValue) Concat(interpreter *Interpreter, other *StringValue, locat
 
 	// Meter computation as if the two strings were iterated.
 	interpreter.ReportComputation(common.ComputationKindLoop, uint(newLength))
+	interpreter.ReportComputation(common.ComputationKindStringConcat, uint(newLength))
 
 	return NewStringValue(
 		interpreter,
@@ -252,10 +269,15 @@ func (v *StringValue) Concat(interpreter *Interpreter, other *StringValue, locat
 
 var EmptyString = NewUnmeteredStringValue("")
 
-func (v *StringValue) Slice(from IntValue, to IntValue, locationRange LocationRange) Value {
+func (v *StringValue) Slice(interpreter *Interpreter, from IntValue, to IntValue, locationRange LocationRange) Value {
 	fromIndex := from.ToInt(locationRange)
 	toIndex := to.ToInt(locationRange)
-	return v.slice(fromIndex, toIndex, locationRange)
+	result := v.slice(fromIndex, toIndex, locationRange)
+
+	// Meter computation as if the string was iterated up to the end of the slice.
+	interpreter.ReportComputation(common.ComputationKindStringSlice, uint(toIndex))
+
+	return result
 }
 
 func (v *StringValue) slice(fromIndex int, toIndex int, locationRange LocationRange) *StringValue {
@@ -393,7 +415,7 @@ func (v *StringValue) GetMember(interpreter *Interpreter, locationRange Location
 					panic(errors.NewUnreachableError())
 				}
 
-				return v.Slice(from, to, invocation.LocationRange)
+				return v.Slice(invocation.Interpreter, from, to, invocation.LocationRange)
 			},
 		)
 
@@ -589,8 +611,9 @@ func (v *StringValue) Split(inter *Interpreter, locationRange LocationRange, sep
 
 	remaining := v
 
-	return NewArrayValueWithIterator(
+	return newArrayValueWithIterator(
 		inter,
+		locationRange,
 		VarSizedArrayOfStringType,
 		common.ZeroAddress,
 		uint64(count),
@@ -637,8 +660,9 @@ func (v *StringValue) Explode(inter *Interpreter, locationRange LocationRange) *
 
 	iterator := v.Iterator(inter, locationRange)
 
-	return NewArrayValueWithIterator(
+	return newArrayValueWithIterator(
 		inter,
+		locationRange,
 		VarSizedArrayOfStringType,
 		common.ZeroAddress,
 		uint64(v.Length()),
@@ -734,7 +758,7 @@ func (*StringValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (*StringValue) IsResourceKinded(_ *Interpreter) bool {
+func (*StringValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
@@ -765,7 +789,11 @@ func (v *StringValue) ByteSize() uint32 {
 	return cborTagSize + getBytesCBORSize([]byte(v.Str))
 }
 
-func (v *StringValue) StoredValue(_ atree.SlabStorage) (atree.Value, error) {
+func (v *StringValue) StoredValue(storage atree.SlabStorage) (atree.Value, error) {
+	if sharesSlabs(storage) {
+		// The grapheme iterator and the length are initialized lazily
+		return NewStringValue_Unsafe(v.Str, v.UnnormalizedStr), nil //nolint:staticcheck
+	}
 	return v, nil
 }
 
@@ -798,8 +826,9 @@ func (v *StringValue) DecodeHex(interpreter *Interpreter, locationRange Location
 
 	i := 0
 
-	return NewArrayValueWithIterator(
+	return newArrayValueWithIterator(
 		interpreter,
+		locationRange,
 		ByteArrayStaticType,
 		common.ZeroAddress,
 		uint64(len(bs)),
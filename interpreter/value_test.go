@@ -514,6 +514,430 @@ func TestOwnerDictionary(t *testing.T) {
 	assert.Equal(t, newOwner, value.GetOwner())
 }
 
+func TestNewDictionaryValueFromBatch(t *testing.T) {
+
+	t.Parallel()
+
+	dictionaryType := &DictionaryStaticType{
+		KeyType:   PrimitiveStaticTypeString,
+		ValueType: PrimitiveStaticTypeInt,
+	}
+
+	t.Run("entries", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		owner := common.Address{0x1}
+
+		const count = 1000
+
+		keysAndValues := make([]Value, 0, count*2)
+		for i := 0; i < count; i++ {
+			keysAndValues = append(
+				keysAndValues,
+				NewUnmeteredStringValue(fmt.Sprintf("key%d", i)),
+				NewUnmeteredIntValueFromInt64(int64(i)),
+			)
+		}
+
+		dictionary := NewDictionaryValueFromBatch(
+			inter,
+			EmptyLocationRange,
+			dictionaryType,
+			owner,
+			keysAndValues...,
+		)
+
+		require.Equal(t, count, dictionary.Count())
+		assert.Equal(t, owner, dictionary.GetOwner())
+
+		for i := 0; i < count; i++ {
+			value, ok := dictionary.Get(
+				inter,
+				EmptyLocationRange,
+				NewUnmeteredStringValue(fmt.Sprintf("key%d", i)),
+			)
+			require.True(t, ok)
+			assert.Equal(t, NewUnmeteredIntValueFromInt64(int64(i)), value)
+		}
+
+		// Inserting into the dictionary must find existing entries
+
+		existing := dictionary.Insert(
+			inter,
+			EmptyLocationRange,
+			NewUnmeteredStringValue("key0"),
+			NewUnmeteredIntValueFromInt64(42),
+		)
+		assert.Equal(
+			t,
+			NewUnmeteredSomeValueNonCopying(NewUnmeteredIntValueFromInt64(0)),
+			existing,
+		)
+		assert.Equal(t, count, dictionary.Count())
+	})
+
+	t.Run("duplicate keys", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		dictionary := NewDictionaryValueFromBatch(
+			inter,
+			EmptyLocationRange,
+			dictionaryType,
+			common.ZeroAddress,
+			NewUnmeteredStringValue("a"), NewUnmeteredIntValueFromInt64(1),
+			NewUnmeteredStringValue("b"), NewUnmeteredIntValueFromInt64(2),
+			NewUnmeteredStringValue("a"), NewUnmeteredIntValueFromInt64(3),
+		)
+
+		require.Equal(t, 2, dictionary.Count())
+
+		value, ok := dictionary.Get(inter, EmptyLocationRange, NewUnmeteredStringValue("a"))
+		require.True(t, ok)
+		assert.Equal(t, NewUnmeteredIntValueFromInt64(3), value)
+
+		value, ok = dictionary.Get(inter, EmptyLocationRange, NewUnmeteredStringValue("b"))
+		require.True(t, ok)
+		assert.Equal(t, NewUnmeteredIntValueFromInt64(2), value)
+	})
+}
+
+func TestDictionaryValue_IterateBatch(t *testing.T) {
+
+	t.Parallel()
+
+	dictionaryType := &DictionaryStaticType{
+		KeyType:   PrimitiveStaticTypeString,
+		ValueType: PrimitiveStaticTypeInt,
+	}
+
+	newDictionary := func(inter *Interpreter, count int) *DictionaryValue {
+		keysAndValues := make([]Value, 0, count*2)
+		for i := 0; i < count; i++ {
+			keysAndValues = append(
+				keysAndValues,
+				NewUnmeteredStringValue(fmt.Sprintf("key%d", i)),
+				NewUnmeteredIntValueFromInt64(int64(i)),
+			)
+		}
+
+		return NewDictionaryValue(
+			inter,
+			EmptyLocationRange,
+			dictionaryType,
+			keysAndValues...,
+		)
+	}
+
+	// iterate iterates over the whole dictionary in batches,
+	// and calls the given function after each batch
+	iterate := func(
+		inter *Interpreter,
+		dictionary *DictionaryValue,
+		limit int,
+		afterBatch func(cursor *DictionaryCursor),
+	) map[string]int {
+
+		visited := map[string]int{}
+
+		var cursor *DictionaryCursor
+		for {
+			batchCount := 0
+
+			cursor = dictionary.IterateBatch(
+				inter,
+				EmptyLocationRange,
+				cursor,
+				limit,
+				func(key, _ Value) (resume bool) {
+					visited[string(key.(*StringValue).Str)]++
+					batchCount++
+					return true
+				},
+			)
+
+			require.LessOrEqual(t, batchCount, limit)
+
+			if cursor == nil {
+				return visited
+			}
+
+			require.Equal(t, limit, batchCount)
+
+			afterBatch(cursor)
+		}
+	}
+
+	t.Run("all entries", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		const count = 100
+		dictionary := newDictionary(inter, count)
+
+		batches := 0
+		visited := iterate(inter, dictionary, 7, func(_ *DictionaryCursor) {
+			batches++
+		})
+
+		assert.Equal(t, count/7, batches)
+		require.Len(t, visited, count)
+		for key, visitCount := range visited {
+			assert.Equal(t, 1, visitCount, key)
+		}
+	})
+
+	t.Run("limit equal to count", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		dictionary := newDictionary(inter, 10)
+
+		var visitCount int
+		cursor := dictionary.IterateBatch(
+			inter,
+			EmptyLocationRange,
+			nil,
+			10,
+			func(_, _ Value) (resume bool) {
+				visitCount++
+				return true
+			},
+		)
+
+		assert.Nil(t, cursor)
+		assert.Equal(t, 10, visitCount)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		dictionary := newDictionary(inter, 10)
+
+		var firstKey Value
+		cursor := dictionary.IterateBatch(
+			inter,
+			EmptyLocationRange,
+			nil,
+			5,
+			func(key, _ Value) (resume bool) {
+				firstKey = key
+				return false
+			},
+		)
+		require.NotNil(t, cursor)
+
+		// Resuming continues after the entry at which the iteration was stopped
+
+		var visited []Value
+		cursor = dictionary.IterateBatch(
+			inter,
+			EmptyLocationRange,
+			cursor,
+			20,
+			func(key, _ Value) (resume bool) {
+				visited = append(visited, key)
+				return true
+			},
+		)
+		assert.Nil(t, cursor)
+		assert.Len(t, visited, 9)
+		assert.NotContains(t, visited, firstKey)
+	})
+
+	t.Run("metering", func(t *testing.T) {
+
+		t.Parallel()
+
+		config := &Config{
+			Storage:                       newUnmeteredInMemoryStorage(),
+			AtreeValueValidationEnabled:   true,
+			AtreeStorageValidationEnabled: true,
+		}
+
+		inter, err := NewInterpreter(nil, TestLocation, config)
+		require.NoError(t, err)
+
+		dictionary := newDictionary(inter, 10)
+
+		var loops uint
+		config.OnMeterComputation = func(compKind common.ComputationKind, intensity uint) {
+			if compKind == common.ComputationKindLoop {
+				loops += intensity
+			}
+		}
+
+		noop := func(_, _ Value) (resume bool) {
+			return true
+		}
+
+		cursor := dictionary.IterateBatch(inter, EmptyLocationRange, nil, 4, noop)
+		require.NotNil(t, cursor)
+
+		// The first batch does not read any entries before the cursor
+
+		assert.Equal(t, uint(0), loops)
+
+		cursor = dictionary.IterateBatch(inter, EmptyLocationRange, cursor, 4, noop)
+		require.NotNil(t, cursor)
+
+		// The second batch reads the entries of the first batch again
+
+		assert.Equal(t, uint(4), loops)
+	})
+
+	t.Run("mutation between batches", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newTestInterpreter(t)
+
+		const count = 50
+		dictionary := newDictionary(inter, count)
+
+		inserted := 0
+
+		visited := iterate(inter, dictionary, 5, func(cursor *DictionaryCursor) {
+
+			// Remove the key of the cursor, and insert a new key
+
+			var removedKey Value
+			dictionary.Iterate(
+				inter,
+				EmptyLocationRange,
+				func(key, _ Value) (resume bool) {
+					hashInput := key.(HashableValue).HashInput(inter, EmptyLocationRange, nil)
+					if string(hashInput) == string(cursor.KeyHashInput) {
+						removedKey = key
+						return false
+					}
+					return true
+				},
+			)
+			require.NotNil(t, removedKey)
+
+			dictionary.Remove(inter, EmptyLocationRange, removedKey)
+
+			dictionary.Insert(
+				inter,
+				EmptyLocationRange,
+				NewUnmeteredStringValue(fmt.Sprintf("new%d", inserted)),
+				NewUnmeteredIntValueFromInt64(0),
+			)
+			inserted++
+		})
+
+		require.Positive(t, inserted)
+
+		// All original entries are visited exactly once
+
+		for i := 0; i < count; i++ {
+			key := fmt.Sprintf("key%d", i)
+			assert.Equal(t, 1, visited[key], key)
+		}
+
+		// Inserted entries are visited if they were inserted after the cursor,
+		// but no entry is visited more than once
+
+		for key, visitCount := range visited {
+			assert.Equal(t, 1, visitCount, key)
+		}
+	})
+}
+
+func TestArrayValue_IterateBatch(t *testing.T) {
+
+	t.Parallel()
+
+	inter := newTestInterpreter(t)
+
+	const count = 10
+
+	values := make([]Value, 0, count)
+	for i := 0; i < count; i++ {
+		values = append(values, NewUnmeteredIntValueFromInt64(int64(i)))
+	}
+
+	array := NewArrayValue(
+		inter,
+		EmptyLocationRange,
+		&VariableSizedStaticType{
+			Type: PrimitiveStaticTypeInt,
+		},
+		common.ZeroAddress,
+		values...,
+	)
+
+	var visited []Value
+	var batches int
+
+	index := 0
+	for {
+		nextIndex, done := array.IterateBatch(
+			inter,
+			index,
+			3,
+			func(element Value) (resume bool) {
+				visited = append(visited, element)
+				return true
+			},
+			EmptyLocationRange,
+		)
+		batches++
+
+		require.LessOrEqual(t, nextIndex-index, 3)
+		index = nextIndex
+
+		if done {
+			break
+		}
+	}
+
+	assert.Equal(t, 4, batches)
+	assert.Equal(t, count, index)
+	assert.Equal(t, values, visited)
+
+	// Stopping early resumes after the last visited element
+
+	nextIndex, done := array.IterateBatch(
+		inter,
+		2,
+		5,
+		func(element Value) (resume bool) {
+			return false
+		},
+		EmptyLocationRange,
+	)
+	assert.Equal(t, 3, nextIndex)
+	assert.False(t, done)
+
+	// Out of bounds
+
+	assert.Panics(t, func() {
+		array.IterateBatch(
+			inter,
+			count+1,
+			1,
+			func(element Value) (resume bool) {
+				return true
+			},
+			EmptyLocationRange,
+		)
+	})
+}
+
 func TestOwnerDictionaryCopy(t *testing.T) {
 
 	t.Parallel()
@@ -540,8 +964,9 @@ func TestOwnerDictionaryCopy(t *testing.T) {
 					common.ComputationKindTransferCompositeValue,
 					common.ComputationKindTransferDictionaryValue,
 					common.ComputationKindTransferCompositeValue,
+					common.ComputationKindDictionaryLookup,
 				},
-				[]uint{1, 1, 1, 1, 1},
+				[]uint{1, 1, 1, 1, 1, 1},
 			),
 		},
 	)
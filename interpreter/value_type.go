@@ -65,11 +65,11 @@ func (TypeValue) Walk(_ *Interpreter, _ func(Value), _ LocationRange) {
 	// NO-OP
 }
 
-func (TypeValue) StaticType(interpreter *Interpreter) StaticType {
-	return NewPrimitiveStaticType(interpreter, PrimitiveStaticTypeMetaType)
+func (TypeValue) StaticType(context ValueContext) StaticType {
+	return NewPrimitiveStaticType(context, PrimitiveStaticTypeMetaType)
 }
 
-func (TypeValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (TypeValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return sema.MetaType.Importable
 }
 
@@ -87,12 +87,12 @@ func (v TypeValue) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v TypeValue) MeteredString(interpreter *Interpreter, _ SeenReferences, locationRange LocationRange) string {
-	common.UseMemory(interpreter, common.TypeValueStringMemoryUsage)
+func (v TypeValue) MeteredString(context ValueContext, _ SeenReferences, locationRange LocationRange) string {
+	common.UseMemory(context, common.TypeValueStringMemoryUsage)
 
 	var typeString string
 	if v.Type != nil {
-		typeString = v.Type.MeteredString(interpreter)
+		typeString = v.Type.MeteredString(context)
 	}
 
 	return format.TypeValue(typeString)
@@ -254,6 +254,98 @@ func (v TypeValue) GetMember(interpreter *Interpreter, _ LocationRange, name str
 			return Nil
 		}
 
+	case sema.MetaTypeOptionalTypeFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.MetaTypeOptionalTypeFunctionType,
+			func(v TypeValue, invocation Invocation) Value {
+				interpreter := invocation.Interpreter
+
+				return NewTypeValue(
+					interpreter,
+					NewOptionalStaticType(interpreter, v.Type),
+				)
+			},
+		)
+
+	case sema.MetaTypeVariableSizedArrayTypeFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.MetaTypeVariableSizedArrayTypeFunctionType,
+			func(v TypeValue, invocation Invocation) Value {
+				interpreter := invocation.Interpreter
+
+				return NewTypeValue(
+					interpreter,
+					NewVariableSizedStaticType(interpreter, v.Type),
+				)
+			},
+		)
+
+	case sema.MetaTypeConstantSizedArrayTypeFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.MetaTypeConstantSizedArrayTypeFunctionType,
+			func(v TypeValue, invocation Invocation) Value {
+				interpreter := invocation.Interpreter
+
+				sizeValue, ok := invocation.Arguments[0].(IntValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return NewTypeValue(
+					interpreter,
+					NewConstantSizedStaticType(
+						interpreter,
+						v.Type,
+						int64(sizeValue.ToInt(invocation.LocationRange)),
+					),
+				)
+			},
+		)
+
+	case sema.MetaTypeDictionaryTypeFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.MetaTypeDictionaryTypeFunctionType,
+			func(v TypeValue, invocation Invocation) Value {
+				valueTypeValue, ok := invocation.Arguments[0].(TypeValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return newDictionaryTypeValue(
+					invocation.Interpreter,
+					v.Type,
+					valueTypeValue.Type,
+				)
+			},
+		)
+
+	case sema.MetaTypeReferenceTypeFunctionName:
+		return NewBoundHostFunctionValue(
+			interpreter,
+			v,
+			sema.MetaTypeReferenceTypeFunctionType,
+			func(v TypeValue, invocation Invocation) Value {
+				entitlementValues, ok := invocation.Arguments[0].(*ArrayValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				return newReferenceTypeValue(
+					invocation.Interpreter,
+					entitlementValues,
+					v.Type,
+					invocation.LocationRange,
+				)
+			},
+		)
 	}
 
 	return nil
@@ -294,7 +386,7 @@ func (TypeValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (TypeValue) IsResourceKinded(_ *Interpreter) bool {
+func (TypeValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
@@ -124,11 +124,11 @@ func (UIntValue) Walk(_ *Interpreter, _ func(Value), _ LocationRange) {
 	// NO-OP
 }
 
-func (UIntValue) StaticType(interpreter *Interpreter) StaticType {
-	return NewPrimitiveStaticType(interpreter, PrimitiveStaticTypeUInt)
+func (UIntValue) StaticType(context ValueContext) StaticType {
+	return NewPrimitiveStaticType(context, PrimitiveStaticTypeUInt)
 }
 
-func (v UIntValue) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (v UIntValue) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return true
 }
 
@@ -158,11 +158,11 @@ func (v UIntValue) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v UIntValue) MeteredString(interpreter *Interpreter, _ SeenReferences, locationRange LocationRange) string {
+func (v UIntValue) MeteredString(context ValueContext, _ SeenReferences, locationRange LocationRange) string {
 	common.UseMemory(
-		interpreter,
+		context,
 		common.NewRawStringMemoryUsage(
-			OverEstimateNumberStringLength(interpreter, v),
+			OverEstimateNumberStringLength(context, v),
 		),
 	)
 	return v.String()
@@ -183,6 +183,8 @@ func (v UIntValue) Plus(interpreter *Interpreter, other NumberValue, locationRan
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewUIntValueFromBigInt(
 		interpreter,
 		common.NewPlusBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -220,6 +222,8 @@ func (v UIntValue) Minus(interpreter *Interpreter, other NumberValue, locationRa
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewUIntValueFromBigInt(
 		interpreter,
 		common.NewMinusBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -274,6 +278,8 @@ func (v UIntValue) Mod(interpreter *Interpreter, other NumberValue, locationRang
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewUIntValueFromBigInt(
 		interpreter,
 		common.NewModBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -302,6 +308,8 @@ func (v UIntValue) Mul(interpreter *Interpreter, other NumberValue, locationRang
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewUIntValueFromBigInt(
 		interpreter,
 		common.NewMulBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -339,6 +347,8 @@ func (v UIntValue) Div(interpreter *Interpreter, other NumberValue, locationRang
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewUIntValueFromBigInt(
 		interpreter,
 		common.NewDivBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -470,6 +480,8 @@ func (v UIntValue) BitwiseOr(interpreter *Interpreter, other IntegerValue, locat
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewUIntValueFromBigInt(
 		interpreter,
 		common.NewBitwiseOrBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -491,6 +503,8 @@ func (v UIntValue) BitwiseXor(interpreter *Interpreter, other IntegerValue, loca
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewUIntValueFromBigInt(
 		interpreter,
 		common.NewBitwiseXorBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -512,6 +526,8 @@ func (v UIntValue) BitwiseAnd(interpreter *Interpreter, other IntegerValue, loca
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	return NewUIntValueFromBigInt(
 		interpreter,
 		common.NewBitwiseAndBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -533,6 +549,8 @@ func (v UIntValue) BitwiseLeftShift(interpreter *Interpreter, other IntegerValue
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	if o.BigInt.Sign() < 0 {
 		panic(NegativeShiftError{
 			LocationRange: locationRange,
@@ -545,6 +563,8 @@ func (v UIntValue) BitwiseLeftShift(interpreter *Interpreter, other IntegerValue
 		})
 	}
 
+	interpreter.checkBigIntegerLeftShift(v.BigInt, o.BigInt.Uint64(), locationRange)
+
 	return NewUIntValueFromBigInt(
 		interpreter,
 		common.NewBitwiseLeftShiftBigIntMemoryUsage(v.BigInt, o.BigInt),
@@ -567,6 +587,8 @@ func (v UIntValue) BitwiseRightShift(interpreter *Interpreter, other IntegerValu
 		})
 	}
 
+	interpreter.checkBigIntegerOperands(v.BigInt, o.BigInt, locationRange)
+
 	if o.BigInt.Sign() < 0 {
 		panic(NegativeShiftError{
 			LocationRange: locationRange,
@@ -622,7 +644,7 @@ func (UIntValue) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (UIntValue) IsResourceKinded(_ *Interpreter) bool {
+func (UIntValue) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
@@ -67,11 +67,11 @@ func (UInt16Value) Walk(_ *Interpreter, _ func(Value), _ LocationRange) {
 	// NO-OP
 }
 
-func (UInt16Value) StaticType(interpreter *Interpreter) StaticType {
-	return NewPrimitiveStaticType(interpreter, PrimitiveStaticTypeUInt16)
+func (UInt16Value) StaticType(context ValueContext) StaticType {
+	return NewPrimitiveStaticType(context, PrimitiveStaticTypeUInt16)
 }
 
-func (UInt16Value) IsImportable(_ *Interpreter, _ LocationRange) bool {
+func (UInt16Value) IsImportable(_ ValueContext, _ LocationRange) bool {
 	return true
 }
 
@@ -83,11 +83,11 @@ func (v UInt16Value) RecursiveString(_ SeenReferences) string {
 	return v.String()
 }
 
-func (v UInt16Value) MeteredString(interpreter *Interpreter, _ SeenReferences, locationRange LocationRange) string {
+func (v UInt16Value) MeteredString(context ValueContext, _ SeenReferences, locationRange LocationRange) string {
 	common.UseMemory(
-		interpreter,
+		context,
 		common.NewRawStringMemoryUsage(
-			OverEstimateNumberStringLength(interpreter, v),
+			OverEstimateNumberStringLength(context, v),
 		),
 	)
 	return v.String()
@@ -535,7 +535,7 @@ func (UInt16Value) NeedsStoreTo(_ atree.Address) bool {
 	return false
 }
 
-func (UInt16Value) IsResourceKinded(_ *Interpreter) bool {
+func (UInt16Value) IsResourceKinded(_ ValueContext) bool {
 	return false
 }
 
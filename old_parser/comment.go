@@ -45,10 +45,7 @@ func (p *parser) parseBlockComment() (endToken lexer.Token, ok bool) {
 			}
 
 		case lexer.TokenEOF:
-			p.reportSyntaxError(
-				"missing comment end %s",
-				lexer.TokenBlockCommentEnd,
-			)
+			// NOTE: the missing comment end is reported by the lexer
 			ok = false
 			return
 
@@ -1557,27 +1557,13 @@ func parseStringLiteral(p *parser, literal []byte) (result string) {
 		}
 	}
 
-	missingEnd := false
+	// NOTE: a missing end quote is reported by the lexer
 	endOffset := length
-	if length >= 2 {
-		lastIndex := length - 1
-		last := literal[lastIndex]
-		if last != '"' {
-			missingEnd = true
-		} else {
-			endOffset = lastIndex
-		}
-	} else {
-		missingEnd = true
+	if length >= 2 && literal[length-1] == '"' {
+		endOffset = length - 1
 	}
 
-	result = parseStringLiteralContent(p, literal[1:endOffset])
-
-	if missingEnd {
-		p.reportSyntaxError("invalid end of string literal: missing '\"'")
-	}
-
-	return
+	return parseStringLiteralContent(p, literal[1:endOffset])
 }
 
 // parseStringLiteralContent parses the string literalExpr contents, excluding start and end quotes
@@ -1113,11 +1113,11 @@ func TestParseString(t *testing.T) {
 		AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "incomplete escape sequence: missing character after escape character",
+					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Offset: 2, Line: 1, Column: 2},
 				},
 				&SyntaxError{
-					Message: "invalid end of string literal: missing '\"'",
+					Message: "incomplete escape sequence: missing character after escape character",
 					Pos:     ast.Position{Offset: 2, Line: 1, Column: 2},
 				},
 			},
@@ -1190,11 +1190,11 @@ func TestParseString(t *testing.T) {
 		AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "incomplete Unicode escape sequence: missing character '{' after escape character",
+					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Offset: 5, Line: 1, Column: 5},
 				},
 				&SyntaxError{
-					Message: "invalid end of string literal: missing '\"'",
+					Message: "incomplete Unicode escape sequence: missing character '{' after escape character",
 					Pos:     ast.Position{Offset: 5, Line: 1, Column: 5},
 				},
 			},
@@ -1221,11 +1221,11 @@ func TestParseString(t *testing.T) {
 		AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "invalid Unicode escape sequence: expected '{', got 's'",
+					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
 				},
 				&SyntaxError{
-					Message: "invalid end of string literal: missing '\"'",
+					Message: "invalid Unicode escape sequence: expected '{', got 's'",
 					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
 				},
 			},
@@ -1252,11 +1252,11 @@ func TestParseString(t *testing.T) {
 		AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "incomplete Unicode escape sequence: missing character '}' after escape character",
+					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
 				},
 				&SyntaxError{
-					Message: "invalid end of string literal: missing '\"'",
+					Message: "incomplete Unicode escape sequence: missing character '}' after escape character",
 					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
 				},
 			},
@@ -22,11 +22,13 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"runtime"
 	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/parser/lexer"
 )
 
 func BenchmarkParseDeploy(b *testing.B) {
@@ -228,3 +230,58 @@ func BenchmarkParseFungibleToken(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkParseTokenPool(b *testing.B) {
+
+	// Simulate bursts of script executions, e.g. on an access node:
+	// Many programs are parsed, and garbage is collected in between bursts,
+	// which clears the default pool
+
+	code := []byte(fungibleTokenContract)
+
+	const burstSize = 100
+
+	run := func(b *testing.B, tokenPool *lexer.TokenPool) {
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		startGCPause := stats.PauseTotalNs
+
+		config := Config{
+			TokenPool: tokenPool,
+		}
+
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < burstSize; j++ {
+				_, err := ParseProgram(nil, code, config)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			// The default pool is only cleared after two garbage collections
+			b.StopTimer()
+			runtime.GC()
+			runtime.GC()
+			b.StartTimer()
+		}
+
+		b.StopTimer()
+
+		runtime.ReadMemStats(&stats)
+		b.ReportMetric(
+			float64(stats.PauseTotalNs-startGCPause)/float64(b.N),
+			"gc-pause-ns/op",
+		)
+	}
+
+	b.Run("default pool", func(b *testing.B) {
+		run(b, nil)
+	})
+
+	b.Run("token pool", func(b *testing.B) {
+		run(b, lexer.NewTokenPool(runtime.GOMAXPROCS(0)))
+	})
+}
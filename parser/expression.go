@@ -19,6 +19,7 @@
 package parser
 
 import (
+	"bytes"
 	"math/big"
 	"strings"
 	"unicode/utf8"
@@ -116,6 +117,12 @@ func defineExpr(def any) {
 		tokenType := def.tokenType
 
 		setExprLeftBindingPower(tokenType, def.leftBindingPower)
+		recordExprOperator(GrammarOperator{
+			Kind:             GrammarOperatorKindInfix,
+			Symbol:           tokenSymbol(tokenType),
+			BindingPower:     def.leftBindingPower,
+			RightAssociative: def.rightAssociative,
+		})
 
 		rightBindingPower := def.leftBindingPower
 		if def.rightAssociative {
@@ -155,6 +162,11 @@ func defineExpr(def any) {
 
 	case prefixExpr:
 		tokenType := def.tokenType
+		recordExprOperator(GrammarOperator{
+			Kind:         GrammarOperatorKindPrefix,
+			Symbol:       tokenSymbol(tokenType),
+			BindingPower: def.bindingPower,
+		})
 		setExprNullDenotation(
 			tokenType,
 			func(parser *parser, token lexer.Token) (ast.Expression, error) {
@@ -184,6 +196,11 @@ func defineExpr(def any) {
 	case postfixExpr:
 		tokenType := def.tokenType
 		setExprLeftBindingPower(tokenType, def.bindingPower)
+		recordExprOperator(GrammarOperator{
+			Kind:         GrammarOperatorKindPostfix,
+			Symbol:       tokenSymbol(tokenType),
+			BindingPower: def.bindingPower,
+		})
 		setExprLeftDenotation(
 			tokenType,
 			func(p *parser, token lexer.Token, left ast.Expression) (ast.Expression, error) {
@@ -498,6 +515,7 @@ func init() {
 	defineInvocationExpression()
 	defineArrayExpression()
 	defineStringExpression()
+	defineRawStringExpression()
 	defineDictionaryExpression()
 	defineIndexExpression()
 	definePathExpression()
@@ -513,6 +531,12 @@ func init() {
 
 func defineLessThanOrTypeArgumentsExpression() {
 
+	recordExprOperator(GrammarOperator{
+		Kind:         GrammarOperatorKindInfix,
+		Symbol:       tokenSymbol(lexer.TokenLess),
+		BindingPower: exprLeftBindingPowerComparison,
+	})
+
 	// The less token `<` does not have a single left binding power,
 	// but one depending on the tokens following it:
 	//
@@ -693,6 +717,17 @@ func defineLessThanOrTypeArgumentsExpression() {
 // for example, `f<T<U>>()`.
 func defineGreaterThanOrBitwiseRightShiftExpression() {
 
+	recordExprOperator(GrammarOperator{
+		Kind:         GrammarOperatorKindInfix,
+		Symbol:       tokenSymbol(lexer.TokenGreater),
+		BindingPower: exprLeftBindingPowerComparison,
+	})
+	recordExprOperator(GrammarOperator{
+		Kind:         GrammarOperatorKindInfix,
+		Symbol:       ast.OperationBitwiseRightShift.Symbol(),
+		BindingPower: exprLeftBindingPowerBitwiseShift,
+	})
+
 	setExprMetaLeftDenotation(
 		lexer.TokenGreater,
 		func(p *parser, rightBindingPower int, left ast.Expression) (result ast.Expression, err error, done bool) {
@@ -865,6 +900,11 @@ func parseFunctionExpression(p *parser, token lexer.Token, purity ast.FunctionPu
 func defineIdentifierLeftDenotations() {
 
 	setExprIdentifierLeftBindingPower(KeywordAs, exprLeftBindingPowerCasting)
+	recordExprOperator(GrammarOperator{
+		Kind:         GrammarOperatorKindCast,
+		Symbol:       KeywordAs,
+		BindingPower: exprLeftBindingPowerCasting,
+	})
 	setExprLeftDenotation(
 		lexer.TokenIdentifier,
 		func(parser *parser, t lexer.Token, left ast.Expression) (ast.Expression, error) {
@@ -930,6 +970,11 @@ func defineIdentifierLeftDenotations() {
 
 		setExprLeftBindingPower(tokenType, exprLeftBindingPowerCasting)
 		setExprLeftDenotation(tokenType, leftDenotation)
+		recordExprOperator(GrammarOperator{
+			Kind:         GrammarOperatorKindCast,
+			Symbol:       tokenSymbol(tokenType),
+			BindingPower: exprLeftBindingPowerCasting,
+		})
 	}
 }
 
@@ -980,6 +1025,11 @@ func parseAttachExpressionRemainder(p *parser, token lexer.Token) (*ast.AttachEx
 //	invocation : '(' ( argument ( ',' argument )* )? ')'
 func defineInvocationExpression() {
 	setExprLeftBindingPower(lexer.TokenParenOpen, exprLeftBindingPowerAccess)
+	recordExprOperator(GrammarOperator{
+		Kind:         GrammarOperatorKindPostfix,
+		Symbol:       tokenSymbol(lexer.TokenParenOpen),
+		BindingPower: exprLeftBindingPowerAccess,
+	})
 
 	setExprLeftDenotation(
 		lexer.TokenParenOpen,
@@ -1160,9 +1210,6 @@ func defineStringExpression() {
 				}
 			}
 
-			// flag for ending " check
-			missingEnd := true
-
 			for curToken.Is(lexer.TokenString) {
 				literal = p.tokenSource(curToken)
 
@@ -1171,10 +1218,10 @@ func defineStringExpression() {
 					literal = literal[1:]
 				}
 
+				// NOTE: a missing end quote is reported by the lexer
 				length = len(literal)
 				if length >= 1 && literal[length-1] == '"' {
 					literal = literal[:length-1]
-					missingEnd = false
 				}
 
 				parsedString := parseStringLiteralContent(p, literal)
@@ -1200,15 +1247,9 @@ func defineStringExpression() {
 					curToken = p.current
 					// safely call next because this should always be a string
 					p.next()
-					missingEnd = true
 				}
 			}
 
-			// check for end " of string literal
-			if missingEnd {
-				p.reportSyntaxError("invalid end of string literal: missing '\"'")
-			}
-
 			if len(values) == 0 {
 				return ast.NewStringExpression(
 					p.memoryGauge,
@@ -1229,6 +1270,21 @@ func defineStringExpression() {
 	)
 }
 
+func defineRawStringExpression() {
+	setExprNullDenotation(
+		lexer.TokenRawString,
+		func(p *parser, token lexer.Token) (ast.Expression, error) {
+			literal := p.tokenSource(token)
+			parsedString := parseRawStringLiteral(p, literal)
+			return ast.NewStringExpression(
+				p.memoryGauge,
+				parsedString,
+				token.Range,
+			), nil
+		},
+	)
+}
+
 func defineArrayExpression() {
 	setExprNullDenotation(
 		lexer.TokenBracketOpen,
@@ -1328,6 +1384,11 @@ func defineDictionaryExpression() {
 
 func defineIndexExpression() {
 	setExprLeftBindingPower(lexer.TokenBracketOpen, exprLeftBindingPowerAccess)
+	recordExprOperator(GrammarOperator{
+		Kind:         GrammarOperatorKindPostfix,
+		Symbol:       tokenSymbol(lexer.TokenBracketOpen),
+		BindingPower: exprLeftBindingPowerAccess,
+	})
 	setExprLeftDenotation(
 		lexer.TokenBracketOpen,
 		func(p *parser, _ lexer.Token, left ast.Expression) (ast.Expression, error) {
@@ -1357,6 +1418,11 @@ func defineIndexExpression() {
 
 func defineConditionalExpression() {
 	setExprLeftBindingPower(lexer.TokenQuestionMark, exprLeftBindingPowerTernary)
+	recordExprOperator(GrammarOperator{
+		Kind:         GrammarOperatorKindConditional,
+		Symbol:       tokenSymbol(lexer.TokenQuestionMark),
+		BindingPower: exprLeftBindingPowerTernary,
+	})
 	setExprLeftDenotation(
 		lexer.TokenQuestionMark,
 		func(p *parser, _ lexer.Token, left ast.Expression) (ast.Expression, error) {
@@ -1432,6 +1498,11 @@ func defineReferenceExpression() {
 func defineMemberExpression() {
 
 	setExprLeftBindingPower(lexer.TokenDot, exprLeftBindingPowerAccess)
+	recordExprOperator(GrammarOperator{
+		Kind:         GrammarOperatorKindPostfix,
+		Symbol:       tokenSymbol(lexer.TokenDot),
+		BindingPower: exprLeftBindingPowerAccess,
+	})
 	setExprLeftDenotation(
 		lexer.TokenDot,
 		func(p *parser, token lexer.Token, left ast.Expression) (ast.Expression, error) {
@@ -1440,6 +1511,11 @@ func defineMemberExpression() {
 	)
 
 	setExprLeftBindingPower(lexer.TokenQuestionMarkDot, exprLeftBindingPowerAccess)
+	recordExprOperator(GrammarOperator{
+		Kind:         GrammarOperatorKindPostfix,
+		Symbol:       tokenSymbol(lexer.TokenQuestionMarkDot),
+		BindingPower: exprLeftBindingPowerAccess,
+	})
 	setExprLeftDenotation(
 		lexer.TokenQuestionMarkDot,
 		func(p *parser, token lexer.Token, left ast.Expression) (ast.Expression, error) {
@@ -1660,27 +1736,36 @@ func parseStringLiteral(p *parser, literal []byte) (result string) {
 		}
 	}
 
-	missingEnd := false
+	// NOTE: a missing end quote is reported by the lexer
 	endOffset := length
-	if length >= 2 {
-		lastIndex := length - 1
-		last := literal[lastIndex]
-		if last != '"' {
-			missingEnd = true
-		} else {
-			endOffset = lastIndex
-		}
-	} else {
-		missingEnd = true
+	if length >= 2 && literal[length-1] == '"' {
+		endOffset = length - 1
 	}
 
-	result = parseStringLiteralContent(p, literal[1:endOffset])
+	return parseStringLiteralContent(p, literal[1:endOffset])
+}
+
+const rawStringDelimiter = `"""`
 
-	if missingEnd {
-		p.reportSyntaxError("invalid end of string literal: missing '\"'")
+// parseRawStringLiteral parses a raw string literal, including the start and end delimiters.
+// The content of raw string literals is taken verbatim, i.e. there are no escape sequences.
+// A line break directly following the start delimiter is not part of the content.
+func parseRawStringLiteral(p *parser, literal []byte) string {
+	content, ok := bytes.CutPrefix(literal, []byte(rawStringDelimiter))
+	if !ok {
+		p.reportSyntaxError("invalid start of raw string literal: expected '%s'", rawStringDelimiter)
 	}
 
-	return
+	// NOTE: a missing end delimiter is reported by the lexer
+	content, _ = bytes.CutSuffix(content, []byte(rawStringDelimiter))
+
+	if trimmed, ok := bytes.CutPrefix(content, []byte("\r\n")); ok {
+		content = trimmed
+	} else if trimmed, ok := bytes.CutPrefix(content, []byte("\n")); ok {
+		content = trimmed
+	}
+
+	return string(content)
 }
 
 // parseStringLiteralContent parses the string literalExpr contents, excluding start and end quotes
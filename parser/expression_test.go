@@ -1205,11 +1205,11 @@ func TestParseString(t *testing.T) {
 		AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "incomplete escape sequence: missing character after escape character",
+					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Offset: 2, Line: 1, Column: 2},
 				},
 				&SyntaxError{
-					Message: "invalid end of string literal: missing '\"'",
+					Message: "incomplete escape sequence: missing character after escape character",
 					Pos:     ast.Position{Offset: 2, Line: 1, Column: 2},
 				},
 			},
@@ -1282,11 +1282,11 @@ func TestParseString(t *testing.T) {
 		AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "incomplete Unicode escape sequence: missing character '{' after escape character",
+					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Offset: 5, Line: 1, Column: 5},
 				},
 				&SyntaxError{
-					Message: "invalid end of string literal: missing '\"'",
+					Message: "incomplete Unicode escape sequence: missing character '{' after escape character",
 					Pos:     ast.Position{Offset: 5, Line: 1, Column: 5},
 				},
 			},
@@ -1313,11 +1313,11 @@ func TestParseString(t *testing.T) {
 		AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "invalid Unicode escape sequence: expected '{', got 's'",
+					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
 				},
 				&SyntaxError{
-					Message: "invalid end of string literal: missing '\"'",
+					Message: "invalid Unicode escape sequence: expected '{', got 's'",
 					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
 				},
 			},
@@ -1344,11 +1344,11 @@ func TestParseString(t *testing.T) {
 		AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "incomplete Unicode escape sequence: missing character '}' after escape character",
+					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
 				},
 				&SyntaxError{
-					Message: "invalid end of string literal: missing '\"'",
+					Message: "incomplete Unicode escape sequence: missing character '}' after escape character",
 					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
 				},
 			},
@@ -6014,6 +6014,76 @@ func TestParseStringEscapes(t *testing.T) {
 	AssertEqualWithDiff(t, expected, actual)
 }
 
+func TestParseRawString(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid, multiple lines", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseExpression("\"\"\"\n  a \\n \"b\"\n\"\"\"")
+		assert.Empty(t, errs)
+
+		AssertEqualWithDiff(t,
+			&ast.StringExpression{
+				Value: "  a \\n \"b\"\n",
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 3, Column: 2, Offset: 17},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("valid, string template is not interpolated", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseExpression(`"""\(a)"""`)
+		assert.Empty(t, errs)
+
+		AssertEqualWithDiff(t,
+			&ast.StringExpression{
+				Value: `\(a)`,
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("invalid, missing end at end of file", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := testParseExpression(`"""ab`)
+		AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Message: "invalid end of raw string literal: missing '\"\"\"'",
+					Pos:     ast.Position{Offset: 5, Line: 1, Column: 5},
+				},
+			},
+			errs,
+		)
+
+		AssertEqualWithDiff(t,
+			&ast.StringExpression{
+				Value: "ab",
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 4, Offset: 4},
+				},
+			},
+			result,
+		)
+	})
+}
+
 func TestParseStringWithUnicode(t *testing.T) {
 
 	t.Parallel()
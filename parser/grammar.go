@@ -0,0 +1,434 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/parser/lexer"
+)
+
+// GrammarKeywordKind is the kind of a keyword
+type GrammarKeywordKind string
+
+const (
+	// GrammarKeywordKindHard is the kind of keywords which are restricted from being used as identifiers
+	// in places where ambiguity can exist, e.g. as the names of declarations
+	GrammarKeywordKindHard GrammarKeywordKind = "hard"
+	// GrammarKeywordKindContextual is the kind of keywords which only have a special meaning in certain places,
+	// and can be used as identifiers anywhere
+	GrammarKeywordKindContextual GrammarKeywordKind = "contextual"
+)
+
+// GrammarKeyword is a keyword of the grammar
+type GrammarKeyword struct {
+	Keyword string
+	Kind    GrammarKeywordKind
+}
+
+// GrammarOperatorKind is the kind of an operator of the expression grammar
+type GrammarOperatorKind string
+
+const (
+	GrammarOperatorKindInfix       GrammarOperatorKind = "infix"
+	GrammarOperatorKindPrefix      GrammarOperatorKind = "prefix"
+	GrammarOperatorKindPostfix     GrammarOperatorKind = "postfix"
+	GrammarOperatorKindCast        GrammarOperatorKind = "cast"
+	GrammarOperatorKindConditional GrammarOperatorKind = "conditional"
+)
+
+// GrammarOperator is an operator of the expression grammar.
+// Operators with a higher binding power bind tighter.
+type GrammarOperator struct {
+	Kind             GrammarOperatorKind
+	Symbol           string
+	BindingPower     int
+	RightAssociative bool `json:",omitempty"`
+}
+
+// GrammarRule is a production of the grammar, in EBNF (ISO/IEC 14977) notation
+type GrammarRule struct {
+	Name       string
+	Definition string
+}
+
+// Grammar describes the syntax accepted by the parser.
+//
+// The keywords and the expression operators, including their precedence and associativity,
+// are derived from the parser's tables. The expression rules are generated from the operators.
+// The rules for declarations, statements, and types describe the recursive descent parsing functions.
+type Grammar struct {
+	// Keywords are all keywords, sorted alphabetically
+	Keywords []GrammarKeyword
+	// Operators are the expression operators, sorted by binding power
+	Operators []GrammarOperator
+	// Punctuation are the tokens with a fixed text which are not keywords, e.g. `(` and `+`
+	Punctuation           []string
+	ExpressionStartTokens []string
+	TypeStartTokens       []string
+	Rules                 []GrammarRule
+}
+
+// exprOperators are the operators of the expression grammar,
+// recorded when the expression parsing functions are defined
+var exprOperators []GrammarOperator
+
+func recordExprOperator(operator GrammarOperator) {
+	exprOperators = append(exprOperators, operator)
+}
+
+// tokenSymbol returns the source text of a token type with a fixed text, e.g. `+`,
+// or a description of the token type otherwise, e.g. `identifier`
+func tokenSymbol(tokenType lexer.TokenType) string {
+	return strings.Trim(tokenType.String(), "'")
+}
+
+// exprRuleNames are the names of the expression rules,
+// one rule for each binding power
+var exprRuleNames = map[int]string{
+	exprLeftBindingPowerTernary:        "conditionalExpression",
+	exprLeftBindingPowerLogicalOr:      "orExpression",
+	exprLeftBindingPowerLogicalAnd:     "andExpression",
+	exprLeftBindingPowerComparison:     "comparisonExpression",
+	exprLeftBindingPowerNilCoalescing:  "nilCoalescingExpression",
+	exprLeftBindingPowerBitwiseOr:      "bitwiseOrExpression",
+	exprLeftBindingPowerBitwiseXor:     "bitwiseXorExpression",
+	exprLeftBindingPowerBitwiseAnd:     "bitwiseAndExpression",
+	exprLeftBindingPowerBitwiseShift:   "bitwiseShiftExpression",
+	exprLeftBindingPowerAddition:       "additiveExpression",
+	exprLeftBindingPowerMultiplication: "multiplicativeExpression",
+	exprLeftBindingPowerMove:           "moveExpression",
+	exprLeftBindingPowerCasting:        "castingExpression",
+	exprLeftBindingPowerUnaryPrefix:    "unaryExpression",
+	exprLeftBindingPowerUnaryPostfix:   "forceExpression",
+	exprLeftBindingPowerAccess:         "accessExpression",
+}
+
+// postfixOperatorRules are the EBNF definitions of postfix operators which have operands
+var postfixOperatorRules = map[string]string{
+	tokenSymbol(lexer.TokenParenOpen):       `[ typeArguments ] , argumentList`,
+	tokenSymbol(lexer.TokenBracketOpen):     `"[" , expression , "]"`,
+	tokenSymbol(lexer.TokenDot):             `"." , identifier`,
+	tokenSymbol(lexer.TokenQuestionMarkDot): `"?." , identifier`,
+}
+
+const primaryExpressionRuleName = "primaryExpression"
+
+// staticGrammarRules are the rules which describe the recursive descent parsing functions,
+// e.g. parseDeclaration, parseStatement, parseType, etc.
+//
+// NOTE: update when changing the parsing functions.
+// TestGrammarExamples parses an example of each rule, so rules which drift from the parser fail the test
+var staticGrammarRules = []GrammarRule{
+	// Program and declarations
+	{"program", `{ declaration | ";" }`},
+	{"declaration", `pragmaDeclaration | importDeclaration | transactionDeclaration` +
+		` | [ access ] , ( variableDeclaration | functionDeclaration | compositeDeclaration` +
+		` | interfaceDeclaration | attachmentDeclaration | eventDeclaration` +
+		` | entitlementDeclaration | entitlementMappingDeclaration )`},
+	{"pragmaDeclaration", `"#" , expression`},
+	{"importDeclaration", `"import" , [ identifier , { "," , identifier } , "from" ] , location`},
+	{"location", `string | hexadecimalLiteral | identifier`},
+	{"access", `"access" , "(" , ( "all" | "self" | "account" | "contract" | "mapping" , nominalType | entitlementSet ) , ")"`},
+	{"entitlementSet", `nominalType , ( { "," , nominalType } | { "|" , nominalType } )`},
+	{"variableDeclaration", `( "let" | "var" ) , identifier , [ ":" , typeAnnotation ] , transfer , expression , [ transfer , expression ]`},
+	{"transfer", `"=" | "<-" | "<-!"`},
+	{"functionDeclaration", `functionSignature , functionBlock`},
+	{"functionSignature", `[ "view" ] , "fun" , identifier , [ typeParameterList ] , parameterList , [ ":" , typeAnnotation ]`},
+	{"typeParameterList", `"<" , [ typeParameter , { "," , typeParameter } ] , ">"`},
+	{"typeParameter", `identifier , [ ":" , typeAnnotation ]`},
+	{"parameterList", `"(" , [ parameter , { "," , parameter } ] , ")"`},
+	{"parameter", `[ identifier ] , identifier , ":" , typeAnnotation`},
+	{"functionBlock", `"{" , [ preConditions ] , [ postConditions ] , statements , "}"`},
+	{"preConditions", `"pre" , "{" , { condition } , "}"`},
+	{"postConditions", `"post" , "{" , { condition } , "}"`},
+	{"condition", `emitStatement | expression , [ ":" , expression ]`},
+	{"compositeKind", `"struct" | "resource" | "contract"`},
+	{"compositeDeclaration", `compositeKind , identifier , [ ":" , conformances ] , "{" , members , "}"` +
+		` | "enum" , identifier , ":" , nominalType , "{" , members , "}"`},
+	{"interfaceDeclaration", `compositeKind , "interface" , identifier , [ ":" , conformances ] , "{" , members , "}"`},
+	{"attachmentDeclaration", `"attachment" , identifier , "for" , nominalType , [ ":" , conformances ] , "{" , members , "}"`},
+	{"conformances", `nominalType , { "," , nominalType }`},
+	{"eventDeclaration", `"event" , identifier , parameterList`},
+	{"entitlementDeclaration", `"entitlement" , identifier`},
+	{"entitlementMappingDeclaration", `"entitlement" , "mapping" , identifier , "{" , { "include" , nominalType | nominalType , "->" , nominalType } , "}"`},
+	{"members", `{ memberDeclaration | ";" }`},
+	{"memberDeclaration", `[ access ] , ( fieldDeclaration | specialFunctionDeclaration | functionSignature , [ functionBlock ]` +
+		` | compositeDeclaration | interfaceDeclaration | attachmentDeclaration | eventDeclaration` +
+		` | entitlementDeclaration | entitlementMappingDeclaration | enumCase )`},
+	{"fieldDeclaration", `[ "let" | "var" ] , identifier , ":" , typeAnnotation`},
+	{"specialFunctionDeclaration", `( "init" | "prepare" ) , parameterList , [ functionBlock ]`},
+	{"enumCase", `"case" , identifier`},
+	{"transactionDeclaration", `"transaction" , [ parameterList ] , "{" , { ( "let" | "var" ) , identifier , ":" , typeAnnotation }` +
+		` , [ "prepare" , parameterList , functionBlock ] , [ preConditions ]` +
+		` , [ "execute" , block ] , [ postConditions ] , "}"`},
+
+	// Statements
+	{"statements", `{ statement | ";" }`},
+	{"statement", `returnStatement | breakStatement | continueStatement | ifStatement | switchStatement` +
+		` | whileStatement | forStatement | emitStatement | removeStatement | variableDeclaration` +
+		` | functionDeclaration | assignmentStatement | swapStatement | expressionStatement`},
+	{"returnStatement", `"return" , [ expression ]`},
+	{"breakStatement", `"break"`},
+	{"continueStatement", `"continue"`},
+	{"ifStatement", `"if" , ( expression | variableDeclaration ) , block , [ "else" , ( ifStatement | block ) ]`},
+	{"switchStatement", `"switch" , expression , "{" , { switchCase } , "}"`},
+	{"switchCase", `( "case" , expression | "default" ) , ":" , statements`},
+	{"whileStatement", `"while" , expression , block`},
+	{"forStatement", `"for" , identifier , [ "," , identifier ] , "in" , expression , block`},
+	{"emitStatement", `"emit" , nominalType , argumentList`},
+	{"removeStatement", `"remove" , nominalType , "from" , expression`},
+	{"assignmentStatement", `expression , transfer , expression`},
+	{"swapStatement", `expression , "<->" , expression`},
+	{"expressionStatement", `expression`},
+	{"block", `"{" , statements , "}"`},
+
+	// Types
+	{"typeAnnotation", `[ "@" ] , type`},
+	{"type", `primaryType , { "?" }`},
+	{"primaryType", `nominalType , [ typeArguments ]` +
+		` | "[" , type , [ ";" , integerLiteral ] , "]"` +
+		` | "{" , type , ":" , type , "}"` +
+		` | "{" , [ nominalType , { "," , nominalType } ] , "}"` +
+		` | [ "auth" , "(" , authorization , ")" ] , "&" , type` +
+		` | functionType` +
+		` | "(" , type , ")"`},
+	{"nominalType", `identifier , { "." , identifier }`},
+	{"typeArguments", `"<" , [ typeAnnotation , { "," , typeAnnotation } ] , ">"`},
+	{"authorization", `"mapping" , nominalType | entitlementSet`},
+	{"functionType", `[ "view" ] , "fun" , "(" , [ typeAnnotation , { "," , typeAnnotation } ] , ")" , [ ":" , typeAnnotation ]`},
+
+	// Primary expressions
+	{primaryExpressionRuleName, `literal | identifier | "(" , expression , ")" | arrayExpression | dictionaryExpression` +
+		` | pathExpression | stringTemplate | functionExpression | createExpression | destroyExpression | attachExpression`},
+	{"literal", `"true" | "false" | "nil" | integerLiteral | fixedPointLiteral | string | rawString`},
+	{"arrayExpression", `"[" , [ expression , { "," , expression } ] , "]"`},
+	{"dictionaryExpression", `"{" , [ expression , ":" , expression , { "," , expression , ":" , expression } ] , "}"`},
+	{"pathExpression", `"/" , identifier , "/" , identifier`},
+	{"stringTemplate", `'"' , { ? string character ? | "\(" , expression , ")" } , '"'`},
+	{"functionExpression", `[ "view" ] , "fun" , parameterList , [ ":" , typeAnnotation ] , functionBlock`},
+	{"createExpression", `"create" , nominalType , argumentList`},
+	{"destroyExpression", `"destroy" , expression`},
+	{"attachExpression", `"attach" , nominalType , argumentList , "to" , expression`},
+	{"argumentList", `"(" , [ argument , { "," , argument } ] , ")"`},
+	{"argument", `[ identifier , ":" ] , expression`},
+
+	// Tokens
+	{"identifier", `? identifier token ?`},
+	{"string", `? string literal token ?`},
+	{"rawString", `? raw string literal token, delimited by '"""', may span multiple lines ?`},
+	{"integerLiteral", `decimalLiteral | binaryLiteral | octalLiteral | hexadecimalLiteral`},
+	{"decimalLiteral", `? decimal integer literal token ?`},
+	{"binaryLiteral", `? binary integer literal token, prefix "0b" ?`},
+	{"octalLiteral", `? octal integer literal token, prefix "0o" ?`},
+	{"hexadecimalLiteral", `? hexadecimal integer literal token, prefix "0x" ?`},
+	{"fixedPointLiteral", `? fixed-point number literal token ?`},
+}
+
+// NewGrammar returns the grammar of the parser.
+func NewGrammar() *Grammar {
+	operators := make([]GrammarOperator, len(exprOperators))
+	copy(operators, exprOperators)
+
+	sort.SliceStable(operators, func(i, j int) bool {
+		a := operators[i]
+		b := operators[j]
+		if a.BindingPower != b.BindingPower {
+			return a.BindingPower < b.BindingPower
+		}
+		return a.Symbol < b.Symbol
+	})
+
+	rules := []GrammarRule{
+		{"expression", exprRuleNames[operators[0].BindingPower]},
+	}
+	rules = append(rules, exprGrammarRules(operators)...)
+	rules = append(rules, staticGrammarRules...)
+
+	expressionStartTokens := startTokens(func(tokenType lexer.TokenType) bool {
+		return exprNullDenotations[tokenType] != nil
+	})
+
+	typeStartTokens := startTokens(func(tokenType lexer.TokenType) bool {
+		return typeNullDenotations[tokenType] != nil
+	})
+
+	return &Grammar{
+		Keywords:              grammarKeywords(),
+		Operators:             operators,
+		Punctuation:           punctuation(),
+		ExpressionStartTokens: expressionStartTokens,
+		TypeStartTokens:       typeStartTokens,
+		Rules:                 rules,
+	}
+}
+
+// grammarKeywords returns all keywords, classified as hard or contextual keywords
+func grammarKeywords() []GrammarKeyword {
+	keywords := make([]GrammarKeyword, 0, len(allKeywords))
+	for _, keyword := range allKeywords {
+		kind := GrammarKeywordKindHard
+		if _, ok := softKeywordsTable.Lookup(keyword); ok {
+			kind = GrammarKeywordKindContextual
+		}
+		keywords = append(keywords, GrammarKeyword{
+			Keyword: keyword,
+			Kind:    kind,
+		})
+	}
+
+	sort.Slice(keywords, func(i, j int) bool {
+		return keywords[i].Keyword < keywords[j].Keyword
+	})
+
+	return keywords
+}
+
+// punctuation returns the symbols of the tokens which have a fixed text,
+// excluding keyword-like tokens, e.g. `as?`, and comment delimiters
+func punctuation() []string {
+	var symbols []string
+	for tokenType := lexer.TokenType(0); tokenType < lexer.TokenMax; tokenType++ {
+		switch tokenType {
+		case lexer.TokenBlockCommentStart,
+			lexer.TokenBlockCommentEnd,
+			lexer.TokenAsExclamationMark,
+			lexer.TokenAsQuestionMark:
+
+			continue
+		}
+
+		description := tokenType.String()
+		if !strings.HasPrefix(description, "'") {
+			continue
+		}
+
+		symbols = append(symbols, tokenSymbol(tokenType))
+	}
+	return symbols
+}
+
+// startTokens returns the tokens which have a null denotation, i.e. which can start an expression or type
+func startTokens(hasNullDenotation func(lexer.TokenType) bool) []string {
+	var tokens []string
+	for tokenType := lexer.TokenType(0); tokenType < lexer.TokenMax; tokenType++ {
+		if tokenType == lexer.TokenEOF || !hasNullDenotation(tokenType) {
+			continue
+		}
+		tokens = append(tokens, tokenSymbol(tokenType))
+	}
+	return tokens
+}
+
+// exprGrammarRules generates one rule for each binding power of the given operators,
+// which must be sorted by binding power
+func exprGrammarRules(operators []GrammarOperator) []GrammarRule {
+
+	var rules []GrammarRule
+
+	for i := 0; i < len(operators); {
+
+		// Gather all operators with the same binding power
+
+		bindingPower := operators[i].BindingPower
+		kind := operators[i].Kind
+		rightAssociative := operators[i].RightAssociative
+
+		var symbols []string
+		for ; i < len(operators) && operators[i].BindingPower == bindingPower; i++ {
+			operator := operators[i]
+			if operator.Kind != kind || operator.RightAssociative != rightAssociative {
+				panic(errors.NewUnexpectedError(
+					"operators with binding power %d have different kinds",
+					bindingPower,
+				))
+			}
+
+			symbol := fmt.Sprintf("%q", operator.Symbol)
+			if kind == GrammarOperatorKindPostfix {
+				if rule, ok := postfixOperatorRules[operator.Symbol]; ok {
+					symbol = rule
+				}
+			}
+			symbols = append(symbols, symbol)
+		}
+
+		name, ok := exprRuleNames[bindingPower]
+		if !ok {
+			panic(errors.NewUnexpectedError("missing rule name for binding power %d", bindingPower))
+		}
+
+		next := primaryExpressionRuleName
+		if i < len(operators) {
+			next = exprRuleNames[operators[i].BindingPower]
+		}
+
+		alternatives := strings.Join(symbols, " | ")
+		if len(symbols) > 1 {
+			alternatives = "( " + alternatives + " )"
+		}
+
+		var definition string
+		switch kind {
+		case GrammarOperatorKindConditional:
+			definition = fmt.Sprintf(`%s , [ %s , expression , ":" , expression ]`, next, alternatives)
+
+		case GrammarOperatorKindInfix:
+			if rightAssociative {
+				definition = fmt.Sprintf(`%s , [ %s , %s ]`, next, alternatives, name)
+			} else {
+				definition = fmt.Sprintf(`%s , { %s , %s }`, next, alternatives, next)
+			}
+
+		case GrammarOperatorKindCast:
+			definition = fmt.Sprintf(`%s , { %s , typeAnnotation }`, next, alternatives)
+
+		case GrammarOperatorKindPrefix:
+			definition = fmt.Sprintf(`{ %s } , %s`, alternatives, next)
+
+		case GrammarOperatorKindPostfix:
+			definition = fmt.Sprintf(`%s , { %s }`, next, alternatives)
+
+		default:
+			panic(errors.NewUnreachableError())
+		}
+
+		rules = append(rules, GrammarRule{
+			Name:       name,
+			Definition: definition,
+		})
+	}
+
+	return rules
+}
+
+// EBNF returns the rules of the grammar in EBNF (ISO/IEC 14977) notation.
+func (g *Grammar) EBNF() string {
+	var builder strings.Builder
+	for _, rule := range g.Rules {
+		builder.WriteString(rule.Name)
+		builder.WriteString(" = ")
+		builder.WriteString(rule.Definition)
+		builder.WriteString(" ;\n")
+	}
+	return builder.String()
+}
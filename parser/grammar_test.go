@@ -0,0 +1,645 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrammar(t *testing.T) {
+
+	t.Parallel()
+
+	grammar := NewGrammar()
+
+	ruleNames := map[string]struct{}{}
+	for _, rule := range grammar.Rules {
+		require.NotContains(t, ruleNames, rule.Name, "duplicate rule %s", rule.Name)
+		ruleNames[rule.Name] = struct{}{}
+	}
+
+	keywords := map[string]struct{}{}
+	for _, keyword := range allKeywords {
+		keywords[keyword] = struct{}{}
+	}
+
+	// Strip special sequences and terminals before looking for non-terminals
+	specialSequencePattern := regexp.MustCompile(`\?[^?]*\?`)
+	terminalPattern := regexp.MustCompile(`"[^"]*"|'[^']*'`)
+	nonTerminalPattern := regexp.MustCompile(`[A-Za-z]+`)
+	identifierPattern := regexp.MustCompile(`^[A-Za-z]+$`)
+
+	t.Run("rules", func(t *testing.T) {
+		t.Parallel()
+
+		for _, rule := range grammar.Rules {
+			definition := specialSequencePattern.ReplaceAllString(rule.Definition, "")
+
+			for _, terminal := range terminalPattern.FindAllString(definition, -1) {
+				word := terminal[1 : len(terminal)-1]
+				if identifierPattern.MatchString(word) {
+					assert.Contains(t, keywords, word, "rule %s: unknown keyword %s", rule.Name, word)
+				}
+			}
+
+			definition = terminalPattern.ReplaceAllString(definition, "")
+
+			for _, name := range nonTerminalPattern.FindAllString(definition, -1) {
+				assert.Contains(t, ruleNames, name, "rule %s: undefined rule %s", rule.Name, name)
+			}
+		}
+	})
+
+	t.Run("operators", func(t *testing.T) {
+		t.Parallel()
+
+		ebnf := grammar.EBNF()
+
+		for _, symbol := range []string{
+			"?", "||", "&&", "==", "!=", "<", "<=", ">", ">=", "??",
+			"|", "^", "&", "<<", ">>", "+", "-", "*", "/", "%",
+			"<-", "as", "as!", "as?", "!", "?.", ".", "[",
+		} {
+			assert.Containsf(t, ebnf, `"`+symbol+`"`, "missing operator %s", symbol)
+		}
+
+		nilCoalescing := false
+		for _, operator := range grammar.Operators {
+			if operator.Symbol == "??" {
+				nilCoalescing = true
+				assert.True(t, operator.RightAssociative)
+				assert.Equal(t, exprLeftBindingPowerNilCoalescing, operator.BindingPower)
+			}
+		}
+		assert.True(t, nilCoalescing)
+	})
+
+	t.Run("keywords", func(t *testing.T) {
+		t.Parallel()
+
+		require.Len(t, grammar.Keywords, len(allKeywords))
+
+		for _, keyword := range grammar.Keywords {
+			if IsHardKeyword(keyword.Keyword) {
+				assert.Equal(t, GrammarKeywordKindHard, keyword.Kind, keyword.Keyword)
+			} else {
+				assert.Equal(t, GrammarKeywordKindContextual, keyword.Kind, keyword.Keyword)
+			}
+		}
+
+		assert.Contains(t,
+			grammar.Keywords,
+			GrammarKeyword{Keyword: KeywordFun, Kind: GrammarKeywordKindHard},
+		)
+		assert.Contains(t,
+			grammar.Keywords,
+			GrammarKeyword{Keyword: KeywordFrom, Kind: GrammarKeywordKindContextual},
+		)
+	})
+
+	t.Run("punctuation", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Contains(t, grammar.Punctuation, "(")
+		assert.Contains(t, grammar.Punctuation, "<-!")
+		assert.NotContains(t, grammar.Punctuation, "/*")
+		assert.NotContains(t, grammar.Punctuation, "as?")
+		assert.NotContains(t, grammar.Punctuation, "identifier")
+	})
+
+	t.Run("start tokens", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Contains(t, grammar.ExpressionStartTokens, "identifier")
+		assert.Contains(t, grammar.ExpressionStartTokens, "(")
+		assert.Contains(t, grammar.TypeStartTokens, "[")
+		assert.NotContains(t, grammar.ExpressionStartTokens, "EOF")
+	})
+}
+
+// ebnfNode is a node of a rule definition, in EBNF (ISO/IEC 14977) notation
+type ebnfNode interface {
+	isEBNFNode()
+}
+
+type ebnfAlternation []ebnfNode
+type ebnfSequence []ebnfNode
+type ebnfOptional struct{ node ebnfNode }
+type ebnfRepetition struct{ node ebnfNode }
+type ebnfTerminal string
+type ebnfNonTerminal string
+type ebnfSpecialSequence string
+
+func (ebnfAlternation) isEBNFNode()     {}
+func (ebnfSequence) isEBNFNode()        {}
+func (ebnfOptional) isEBNFNode()        {}
+func (ebnfRepetition) isEBNFNode()      {}
+func (ebnfTerminal) isEBNFNode()        {}
+func (ebnfNonTerminal) isEBNFNode()     {}
+func (ebnfSpecialSequence) isEBNFNode() {}
+
+var ebnfTokenPattern = regexp.MustCompile(`\s*("[^"]*"|'[^']*'|\?[^?]*\?|[A-Za-z]+|[,|\[\]{}()])`)
+
+// parseEBNF parses a rule definition
+func parseEBNF(definition string) (ebnfNode, error) {
+	var tokens []string
+	end := 0
+	for _, match := range ebnfTokenPattern.FindAllStringSubmatchIndex(definition, -1) {
+		if match[0] != end {
+			return nil, fmt.Errorf("invalid definition: %s", definition)
+		}
+		end = match[1]
+		tokens = append(tokens, definition[match[2]:match[3]])
+	}
+	if strings.TrimSpace(definition[end:]) != "" {
+		return nil, fmt.Errorf("invalid definition: %s", definition)
+	}
+
+	p := &ebnfParser{tokens: tokens}
+	node, err := p.parseAlternation()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(tokens) {
+		return nil, fmt.Errorf("unexpected %s", tokens[p.pos])
+	}
+	return node, nil
+}
+
+type ebnfParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *ebnfParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *ebnfParser) parseAlternation() (ebnfNode, error) {
+	var alternatives ebnfAlternation
+	for {
+		sequence, err := p.parseSequence()
+		if err != nil {
+			return nil, err
+		}
+		alternatives = append(alternatives, sequence)
+
+		if p.peek() != "|" {
+			break
+		}
+		p.pos++
+	}
+
+	if len(alternatives) == 1 {
+		return alternatives[0], nil
+	}
+	return alternatives, nil
+}
+
+func (p *ebnfParser) parseSequence() (ebnfNode, error) {
+	var sequence ebnfSequence
+	for {
+		factor, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		sequence = append(sequence, factor)
+
+		if p.peek() != "," {
+			break
+		}
+		p.pos++
+	}
+
+	if len(sequence) == 1 {
+		return sequence[0], nil
+	}
+	return sequence, nil
+}
+
+func (p *ebnfParser) parseFactor() (ebnfNode, error) {
+	token := p.peek()
+	p.pos++
+
+	parseGroup := func(end string) (ebnfNode, error) {
+		node, err := p.parseAlternation()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != end {
+			return nil, fmt.Errorf("expected %s, got %q", end, p.peek())
+		}
+		p.pos++
+		return node, nil
+	}
+
+	switch {
+	case token == "(":
+		return parseGroup(")")
+
+	case token == "[":
+		node, err := parseGroup("]")
+		if err != nil {
+			return nil, err
+		}
+		return ebnfOptional{node: node}, nil
+
+	case token == "{":
+		node, err := parseGroup("}")
+		if err != nil {
+			return nil, err
+		}
+		return ebnfRepetition{node: node}, nil
+
+	case strings.HasPrefix(token, `"`), strings.HasPrefix(token, `'`):
+		return ebnfTerminal(token[1 : len(token)-1]), nil
+
+	case strings.HasPrefix(token, "?"):
+		return ebnfSpecialSequence(strings.TrimSpace(token[1 : len(token)-1])), nil
+
+	case token != "" && strings.IndexAny(token, ",|[]{}()") < 0:
+		return ebnfNonTerminal(token), nil
+
+	default:
+		return nil, fmt.Errorf("unexpected %q", token)
+	}
+}
+
+// grammarExampleGenerator generates examples for the rules of a grammar
+type grammarExampleGenerator struct {
+	rules map[string]ebnfNode
+	// costs are the numbers of tokens of the shortest derivations of the rules
+	costs                   map[string]int
+	specialSequenceExamples map[ebnfSpecialSequence]string
+}
+
+const infiniteGrammarExampleCost = 1 << 30
+
+func newGrammarExampleGenerator(
+	rules map[string]ebnfNode,
+	specialSequenceExamples map[ebnfSpecialSequence]string,
+) *grammarExampleGenerator {
+	g := &grammarExampleGenerator{
+		rules:                   rules,
+		costs:                   map[string]int{},
+		specialSequenceExamples: specialSequenceExamples,
+	}
+
+	for name := range rules {
+		g.costs[name] = infiniteGrammarExampleCost
+	}
+
+	// Compute the costs of the shortest derivations by iterating to a fixed point
+
+	for changed := true; changed; {
+		changed = false
+		for name, node := range rules {
+			cost := g.cost(node)
+			if cost < g.costs[name] {
+				g.costs[name] = cost
+				changed = true
+			}
+		}
+	}
+
+	return g
+}
+
+func (g *grammarExampleGenerator) cost(node ebnfNode) int {
+	switch node := node.(type) {
+	case ebnfAlternation:
+		result := infiniteGrammarExampleCost
+		for _, alternative := range node {
+			result = min(result, g.cost(alternative))
+		}
+		return result
+
+	case ebnfSequence:
+		result := 0
+		for _, element := range node {
+			result = min(result+g.cost(element), infiniteGrammarExampleCost)
+		}
+		return result
+
+	case ebnfOptional, ebnfRepetition:
+		return 0
+
+	case ebnfTerminal, ebnfSpecialSequence:
+		return 1
+
+	case ebnfNonTerminal:
+		cost, ok := g.costs[string(node)]
+		if !ok {
+			return infiniteGrammarExampleCost
+		}
+		return cost
+
+	default:
+		panic(fmt.Errorf("unexpected node: %T", node))
+	}
+}
+
+// cheapestAlternative returns the alternative with the shortest derivation,
+// preferring earlier alternatives
+func (g *grammarExampleGenerator) cheapestAlternative(alternation ebnfAlternation) ebnfNode {
+	result := alternation[0]
+	for _, alternative := range alternation[1:] {
+		if g.cost(alternative) < g.cost(result) {
+			result = alternative
+		}
+	}
+	return result
+}
+
+// shortest returns the tokens of the shortest derivation of the given node
+func (g *grammarExampleGenerator) shortest(node ebnfNode) []string {
+	switch node := node.(type) {
+	case ebnfAlternation:
+		return g.shortest(g.cheapestAlternative(node))
+
+	case ebnfSequence:
+		var tokens []string
+		for _, element := range node {
+			tokens = append(tokens, g.shortest(element)...)
+		}
+		return tokens
+
+	case ebnfOptional, ebnfRepetition:
+		return nil
+
+	case ebnfNonTerminal:
+		return g.shortest(g.rules[string(node)])
+
+	default:
+		return g.terminal(node)
+	}
+}
+
+// full returns the tokens of a derivation of the given node
+// which includes all optional parts and one repetition of all repeated parts.
+// Rules referenced by the node are derived using their shortest derivation
+func (g *grammarExampleGenerator) full(node ebnfNode) []string {
+	switch node := node.(type) {
+	case ebnfAlternation:
+		return g.full(g.cheapestAlternative(node))
+
+	case ebnfSequence:
+		var tokens []string
+		for _, element := range node {
+			tokens = append(tokens, g.full(element)...)
+		}
+		return tokens
+
+	case ebnfOptional:
+		return g.full(node.node)
+
+	case ebnfRepetition:
+		return g.full(node.node)
+
+	case ebnfNonTerminal:
+		return g.shortest(g.rules[string(node)])
+
+	default:
+		return g.terminal(node)
+	}
+}
+
+func (g *grammarExampleGenerator) terminal(node ebnfNode) []string {
+	switch node := node.(type) {
+	case ebnfTerminal:
+		return []string{string(node)}
+
+	case ebnfSpecialSequence:
+		example, ok := g.specialSequenceExamples[node]
+		if !ok {
+			panic(fmt.Errorf("missing example for special sequence: %s", node))
+		}
+		return []string{example}
+
+	default:
+		panic(fmt.Errorf("unexpected node: %T", node))
+	}
+}
+
+// examples returns one example for each alternative of the given rule
+func (g *grammarExampleGenerator) examples(name string) []string {
+	node := g.rules[name]
+
+	alternatives, ok := node.(ebnfAlternation)
+	if !ok {
+		alternatives = ebnfAlternation{node}
+	}
+
+	examples := make([]string, 0, len(alternatives))
+	for _, alternative := range alternatives {
+		examples = append(examples, joinGrammarExampleTokens(g.full(alternative)))
+	}
+	return examples
+}
+
+// joinGrammarExampleTokens joins the given tokens,
+// separating them with a space only where they would otherwise be merged into one token,
+// and before braces, as a nominal type directly followed by a brace is an invalid restricted type
+func joinGrammarExampleTokens(tokens []string) string {
+	isWordByte := func(b byte) bool {
+		return b == '_' ||
+			'a' <= b && b <= 'z' ||
+			'A' <= b && b <= 'Z' ||
+			'0' <= b && b <= '9'
+	}
+
+	var builder strings.Builder
+	for i, token := range tokens {
+		if i > 0 {
+			previous := tokens[i-1]
+			if isWordByte(previous[len(previous)-1]) && isWordByte(token[0]) || token == "{" {
+				builder.WriteByte(' ')
+			}
+		}
+		builder.WriteString(token)
+	}
+	return builder.String()
+}
+
+// TestGrammarExamples ensures the grammar does not drift from the parser:
+// For each alternative of each rule, an example is generated from the grammar,
+// and parsed with the parser
+func TestGrammarExamples(t *testing.T) {
+
+	t.Parallel()
+
+	grammar := NewGrammar()
+
+	const (
+		declarationContext = `%s`
+		memberContext      = `struct S { %s }`
+		statementContext   = `fun f() { %s }`
+		expressionContext  = `let x = %s`
+		typeContext        = `let x: %s = y`
+	)
+
+	// ruleContexts are the programs in which the examples of the rules are embedded
+	ruleContexts := map[string]string{
+		"program":                       declarationContext,
+		"declaration":                   declarationContext,
+		"pragmaDeclaration":             declarationContext,
+		"importDeclaration":             declarationContext,
+		"location":                      `import %s`,
+		"access":                        `%s let x = y`,
+		"entitlementSet":                `access(%s) let x = y`,
+		"variableDeclaration":           declarationContext,
+		"transfer":                      `let x %s y`,
+		"functionDeclaration":           declarationContext,
+		"functionSignature":             memberContext,
+		"typeParameterList":             `fun f%s() {}`,
+		"typeParameter":                 `fun f<%s>() {}`,
+		"parameterList":                 `fun f%s {}`,
+		"parameter":                     `fun f(%s) {}`,
+		"functionBlock":                 `fun f() %s`,
+		"preConditions":                 statementContext,
+		"postConditions":                statementContext,
+		"condition":                     `fun f() { pre { %s } }`,
+		"compositeKind":                 `%s S {}`,
+		"compositeDeclaration":          declarationContext,
+		"interfaceDeclaration":          declarationContext,
+		"attachmentDeclaration":         declarationContext,
+		"conformances":                  `struct S: %s {}`,
+		"eventDeclaration":              declarationContext,
+		"entitlementDeclaration":        declarationContext,
+		"entitlementMappingDeclaration": declarationContext,
+		"members":                       memberContext,
+		"memberDeclaration":             memberContext,
+		"fieldDeclaration":              memberContext,
+		"specialFunctionDeclaration":    memberContext,
+		"enumCase":                      `enum E: UInt8 { %s }`,
+		"transactionDeclaration":        declarationContext,
+
+		"statements":          statementContext,
+		"statement":           statementContext,
+		"returnStatement":     statementContext,
+		"breakStatement":      statementContext,
+		"continueStatement":   statementContext,
+		"ifStatement":         statementContext,
+		"switchStatement":     statementContext,
+		"switchCase":          `fun f() { switch x { %s } }`,
+		"whileStatement":      statementContext,
+		"forStatement":        statementContext,
+		"emitStatement":       statementContext,
+		"removeStatement":     statementContext,
+		"assignmentStatement": statementContext,
+		"swapStatement":       statementContext,
+		"expressionStatement": statementContext,
+		"block":               `fun f() { while true %s }`,
+
+		"typeAnnotation": typeContext,
+		"type":           typeContext,
+		"primaryType":    typeContext,
+		"nominalType":    typeContext,
+		"typeArguments":  `let x = f%s()`,
+		"authorization":  `let x: auth(%s) &Int = y`,
+		"functionType":   typeContext,
+
+		primaryExpressionRuleName: expressionContext,
+		"literal":                 expressionContext,
+		"arrayExpression":         expressionContext,
+		"dictionaryExpression":    expressionContext,
+		"pathExpression":          expressionContext,
+		"stringTemplate":          expressionContext,
+		"functionExpression":      expressionContext,
+		"createExpression":        expressionContext,
+		"destroyExpression":       expressionContext,
+		"attachExpression":        expressionContext,
+		"argumentList":            `let x = f%s`,
+		"argument":                `let x = f(%s)`,
+
+		"identifier":         expressionContext,
+		"string":             expressionContext,
+		"rawString":          expressionContext,
+		"integerLiteral":     expressionContext,
+		"decimalLiteral":     expressionContext,
+		"binaryLiteral":      expressionContext,
+		"octalLiteral":       expressionContext,
+		"hexadecimalLiteral": expressionContext,
+		"fixedPointLiteral":  expressionContext,
+	}
+
+	// The expression rules are generated from the operators
+	ruleContexts["expression"] = expressionContext
+	for _, name := range exprRuleNames {
+		ruleContexts[name] = expressionContext
+	}
+
+	specialSequenceExamples := map[ebnfSpecialSequence]string{
+		"identifier token":                                                      `a`,
+		"string literal token":                                                  `"s"`,
+		"string character":                                                      `c`,
+		"decimal integer literal token":                                         `1`,
+		"binary integer literal token, prefix \"0b\"":                           `0b1`,
+		"octal integer literal token, prefix \"0o\"":                            `0o1`,
+		"hexadecimal integer literal token, prefix \"0x\"":                      `0x1`,
+		"fixed-point number literal token":                                      `1.0`,
+		`raw string literal token, delimited by '"""', may span multiple lines`: `"""r"""`,
+	}
+
+	rules := map[string]ebnfNode{}
+	for _, rule := range grammar.Rules {
+		node, err := parseEBNF(rule.Definition)
+		require.NoError(t, err, "rule %s", rule.Name)
+		rules[rule.Name] = node
+	}
+
+	generator := newGrammarExampleGenerator(rules, specialSequenceExamples)
+
+	for _, rule := range grammar.Rules {
+		require.Less(t,
+			generator.costs[rule.Name],
+			infiniteGrammarExampleCost,
+			"rule %s has no finite derivation",
+			rule.Name,
+		)
+	}
+
+	for _, rule := range grammar.Rules {
+
+		context, ok := ruleContexts[rule.Name]
+		require.True(t, ok, "missing context for rule %s", rule.Name)
+
+		for _, example := range generator.examples(rule.Name) {
+			code := fmt.Sprintf(context, example)
+
+			_, err := ParseProgram(
+				nil,
+				[]byte(code),
+				Config{
+					TypeParametersEnabled: true,
+				},
+			)
+			assert.NoError(t, err, "rule %s: %s", rule.Name, code)
+		}
+	}
+}
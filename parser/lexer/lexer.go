@@ -19,6 +19,7 @@
 package lexer
 
 import (
+	"bytes"
 	"fmt"
 	"sync"
 	"unicode/utf8"
@@ -85,6 +86,9 @@ type lexer struct {
 	mode lexerMode
 	// counts the number of unclosed brackets for string templates \((()))
 	openBrackets int
+	// pool is the token pool the lexer is returned to when reclaimed.
+	// If nil, the lexer is returned to the default pool
+	pool *TokenPool
 }
 
 var _ TokenStream = &lexer{}
@@ -146,19 +150,27 @@ func (l *lexer) clear() {
 }
 
 func (l *lexer) Reclaim() {
+	if l.pool != nil {
+		l.pool.put(l)
+		return
+	}
 	pool.Put(l)
 }
 
 var pool = sync.Pool{
 	New: func() any {
-		return &lexer{
-			tokens: make([]Token, 0, 2048),
-		}
+		return newLexer()
 	},
 }
 
+// Lex scans the given input into a token stream.
+// The token stream should be reclaimed after use, see TokenStream.Reclaim.
+// Lexers are reused from a default pool, see TokenPool for a pool maintained by the caller
 func Lex(input []byte, memoryGauge common.MemoryGauge) (TokenStream, error) {
-	l := pool.Get().(*lexer)
+	return lex(pool.Get().(*lexer), input, memoryGauge)
+}
+
+func lex(l *lexer, input []byte, memoryGauge common.MemoryGauge) (TokenStream, error) {
 	l.clear()
 	l.memoryGauge = memoryGauge
 	l.input = input
@@ -334,6 +346,28 @@ func (l *lexer) emitType(ty TokenType) {
 	l.emit(ty, nil, l.startPosition(), true)
 }
 
+// emitMissingError emits an error token for missing input at the current position,
+// e.g. the missing end of a literal. No input is consumed
+func (l *lexer) emitMissingError(err error) {
+	common.UseMemory(l.memoryGauge, common.ErrorTokenMemoryUsage)
+
+	if len(l.tokens) >= tokenLimit {
+		panic(TokenLimitReachedError{})
+	}
+
+	pos := l.startPosition()
+
+	l.tokens = append(
+		l.tokens,
+		Token{
+			Type:         TokenError,
+			SpaceOrError: err,
+			Range:        ast.NewRange(l.memoryGauge, pos, pos),
+		},
+	)
+	l.tokenCount = len(l.tokens)
+}
+
 func (l *lexer) emitError(err error) {
 	common.UseMemory(l.memoryGauge, common.ErrorTokenMemoryUsage)
 
@@ -408,14 +442,16 @@ func (l *lexer) acceptWhile(f func(rune) bool) {
 	}
 }
 
-func (l *lexer) scanString(quote rune) {
+// scanString scans a string literal until the given quote.
+// It returns false if the string literal is not terminated,
+// i.e. the end of the line or input is reached before the quote
+func (l *lexer) scanString(quote rune) (terminated bool) {
 	r := l.next()
 	for r != quote {
 		switch r {
 		case '\n', EOF:
-			// NOTE: invalid end of string handled by parser
 			l.backupOne()
-			return
+			return false
 		case '\\':
 			// might have to backup twice due to string template
 			tmpBackupOffset := l.prevEndOffset
@@ -429,15 +465,52 @@ func (l *lexer) scanString(quote rune) {
 				l.endOffset = tmpBackupOffset
 				l.current = tmpBackup
 				l.canBackup = false
-				return
+				return true
 			case '\n', EOF:
-				// NOTE: invalid end of string handled by parser
 				l.backupOne()
-				return
+				return false
 			}
 		}
 		r = l.next()
 	}
+	return true
+}
+
+// rawStringDelimiterRemainder is the remainder of the raw string delimiter `"""`,
+// after the first quote
+var rawStringDelimiterRemainder = []byte(`""`)
+
+// acceptRawStringDelimiterRemainder reads the remainder of a raw string delimiter,
+// i.e. the two quotes following an already consumed quote.
+// It returns true if the remainder was read, otherwise it does not advance.
+func (l *lexer) acceptRawStringDelimiterRemainder() bool {
+	if !bytes.HasPrefix(l.input[l.endOffset:], rawStringDelimiterRemainder) {
+		return false
+	}
+
+	l.endOffset += len(rawStringDelimiterRemainder)
+	l.current = '"'
+	l.canBackup = false
+
+	return true
+}
+
+// scanRawString scans the content and closing delimiter of a raw string literal.
+// Raw strings may span multiple lines and have no escape sequences or string templates.
+// It returns false if the raw string literal is not terminated,
+// i.e. the end of the input is reached before the end delimiter
+func (l *lexer) scanRawString() (terminated bool) {
+	for {
+		switch l.next() {
+		case EOF:
+			l.backupOne()
+			return false
+		case '"':
+			if l.acceptRawStringDelimiterRemainder() {
+				return true
+			}
+		}
+	}
 }
 
 func (l *lexer) scanBinaryRemainder() {
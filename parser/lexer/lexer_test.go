@@ -72,7 +72,9 @@ func testLex(t *testing.T, input string, expected []token) {
 		require.Len(t, actualTokens, len(expectedTokens))
 		for i, expectedToken := range expected {
 			actualToken := actualTokens[i]
-			if actualToken.Type == TokenEOF {
+			// NOTE: EOF tokens, and error tokens for missing input, have no source
+			if actualToken.Type == TokenEOF ||
+				(actualToken.Type == TokenError && expectedToken.Source == "") {
 				continue
 			}
 			assert.Equal(t,
@@ -1473,6 +1475,16 @@ func TestLexString(t *testing.T) {
 					},
 					Source: "\"",
 				},
+				{
+					Token: Token{
+						Type:         TokenError,
+						SpaceOrError: errors.New(`invalid end of string literal: missing '"'`),
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+							EndPos:   ast.Position{Line: 1, Column: 1, Offset: 1},
+						},
+					},
+				},
 				{
 					Token: Token{
 						Type: TokenSpace,
@@ -1513,6 +1525,16 @@ func TestLexString(t *testing.T) {
 					},
 					Source: "\"te",
 				},
+				{
+					Token: Token{
+						Type:         TokenError,
+						SpaceOrError: errors.New(`invalid end of string literal: missing '"'`),
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+							EndPos:   ast.Position{Line: 1, Column: 3, Offset: 3},
+						},
+					},
+				},
 				{
 					Token: Token{
 						Type: TokenSpace,
@@ -1553,6 +1575,16 @@ func TestLexString(t *testing.T) {
 					},
 					Source: "\"",
 				},
+				{
+					Token: Token{
+						Type:         TokenError,
+						SpaceOrError: errors.New(`invalid end of string literal: missing '"'`),
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+							EndPos:   ast.Position{Line: 1, Column: 1, Offset: 1},
+						},
+					},
+				},
 				{
 					Token: Token{
 						Type: TokenEOF,
@@ -1580,6 +1612,16 @@ func TestLexString(t *testing.T) {
 					},
 					Source: "\"te",
 				},
+				{
+					Token: Token{
+						Type:         TokenError,
+						SpaceOrError: errors.New(`invalid end of string literal: missing '"'`),
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+							EndPos:   ast.Position{Line: 1, Column: 3, Offset: 3},
+						},
+					},
+				},
 				{
 					Token: Token{
 						Type: TokenEOF,
@@ -1607,6 +1649,16 @@ func TestLexString(t *testing.T) {
 					},
 					Source: "\"\\",
 				},
+				{
+					Token: Token{
+						Type:         TokenError,
+						SpaceOrError: errors.New(`invalid end of string literal: missing '"'`),
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 2, Offset: 2},
+							EndPos:   ast.Position{Line: 1, Column: 2, Offset: 2},
+						},
+					},
+				},
 				{
 					Token: Token{
 						Type: TokenSpace,
@@ -1634,6 +1686,75 @@ func TestLexString(t *testing.T) {
 	})
 }
 
+func TestLexRawString(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid, multiple lines", func(t *testing.T) {
+		testLex(t,
+			"\"\"\"a\n\"b\"\n\"\"\"",
+			[]token{
+				{
+					Token: Token{
+						Type: TokenRawString,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+							EndPos:   ast.Position{Line: 3, Column: 2, Offset: 11},
+						},
+					},
+					Source: "\"\"\"a\n\"b\"\n\"\"\"",
+				},
+				{
+					Token: Token{
+						Type: TokenEOF,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 3, Column: 3, Offset: 12},
+							EndPos:   ast.Position{Line: 3, Column: 3, Offset: 12},
+						},
+					},
+				},
+			},
+		)
+	})
+
+	t.Run("invalid, missing end at end of file", func(t *testing.T) {
+		testLex(t,
+			`"""ab`,
+			[]token{
+				{
+					Token: Token{
+						Type: TokenRawString,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+							EndPos:   ast.Position{Line: 1, Column: 4, Offset: 4},
+						},
+					},
+					Source: `"""ab`,
+				},
+				{
+					Token: Token{
+						Type:         TokenError,
+						SpaceOrError: errors.New(`invalid end of raw string literal: missing '"""'`),
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 5, Offset: 5},
+							EndPos:   ast.Position{Line: 1, Column: 5, Offset: 5},
+						},
+					},
+				},
+				{
+					Token: Token{
+						Type: TokenEOF,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 5, Offset: 5},
+							EndPos:   ast.Position{Line: 1, Column: 5, Offset: 5},
+						},
+					},
+				},
+			},
+		)
+	})
+}
+
 func TestLexBlockComment(t *testing.T) {
 
 	t.Parallel()
@@ -1692,6 +1813,16 @@ func TestLexBlockComment(t *testing.T) {
 					},
 					Source: "*/",
 				},
+				{
+					Token: Token{
+						Type:         TokenError,
+						SpaceOrError: errors.New(`missing comment end '*/'`),
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 20, Offset: 20},
+							EndPos:   ast.Position{Line: 1, Column: 20, Offset: 20},
+						},
+					},
+				},
 				{
 					Token: Token{
 						Type: TokenEOF,
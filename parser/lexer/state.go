@@ -128,6 +128,9 @@ func rootState(l *lexer) stateFn {
 		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			return numberState
 		case '"':
+			if l.acceptRawStringDelimiterRemainder() {
+				return rawStringState
+			}
 			return stringState
 		case '\\':
 			if l.mode == lexerModeStringInterpolation {
@@ -322,8 +325,20 @@ func identifierState(l *lexer) stateFn {
 }
 
 func stringState(l *lexer) stateFn {
-	l.scanString('"')
+	terminated := l.scanString('"')
 	l.emitType(TokenString)
+	if !terminated {
+		l.emitMissingError(fmt.Errorf("invalid end of string literal: missing '\"'"))
+	}
+	return rootState
+}
+
+func rawStringState(l *lexer) stateFn {
+	terminated := l.scanRawString()
+	l.emitType(TokenRawString)
+	if !terminated {
+		l.emitMissingError(fmt.Errorf(`invalid end of raw string literal: missing '"""'`))
+	}
 	return rootState
 }
 
@@ -342,6 +357,13 @@ func blockCommentState(nesting int) stateFn {
 		r := l.next()
 		switch r {
 		case EOF:
+			l.backupOne()
+			if l.endOffset > l.startOffset {
+				l.emitType(TokenBlockCommentContent)
+			}
+			l.emitMissingError(fmt.Errorf("missing comment end %s", TokenBlockCommentEnd))
+			// consume the EOF again, like the root state
+			l.next()
 			return nil
 		case '/':
 			beforeSlashOffset := l.prevEndOffset
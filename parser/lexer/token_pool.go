@@ -0,0 +1,96 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lexer
+
+import (
+	"github.com/onflow/cadence/common"
+)
+
+// defaultTokenBufferCapacity is the initial capacity of the token buffer of a lexer
+const defaultTokenBufferCapacity = 2048
+
+// maxRetainedTokenBufferCapacity is the capacity above which the token buffer of a lexer
+// is not retained by a TokenPool, so a single large input does not keep a large buffer alive
+const maxRetainedTokenBufferCapacity = 1 << 16
+
+// TokenPool is a pool of lexers and their token buffers,
+// which can be maintained by an embedder to reuse token buffers across many parses,
+// e.g. when executing bursts of scripts.
+//
+// Unlike the default pool used by Lex, which is cleared by the garbage collector,
+// a TokenPool retains up to a fixed number of lexers until it is discarded.
+// This reduces the allocations and the garbage collection pressure of repeated parses.
+//
+// A TokenPool is safe for concurrent use.
+type TokenPool struct {
+	lexers chan *lexer
+}
+
+// NewTokenPool returns a new token pool which retains up to the given number of lexers.
+// The size should be the maximum number of concurrent parses
+func NewTokenPool(size int) *TokenPool {
+	return &TokenPool{
+		lexers: make(chan *lexer, size),
+	}
+}
+
+func newLexer() *lexer {
+	return &lexer{
+		tokens: make([]Token, 0, defaultTokenBufferCapacity),
+	}
+}
+
+func (p *TokenPool) get() *lexer {
+	select {
+	case l := <-p.lexers:
+		return l
+	default:
+		l := newLexer()
+		l.pool = p
+		return l
+	}
+}
+
+func (p *TokenPool) put(l *lexer) {
+	if cap(l.tokens) > maxRetainedTokenBufferCapacity {
+		return
+	}
+
+	// Do not retain the input and the gauge of the last parse
+	l.input = nil
+	l.memoryGauge = nil
+
+	select {
+	case p.lexers <- l:
+	default:
+		// The pool is full, discard the lexer
+	}
+}
+
+// Size returns the number of lexers currently retained by the pool
+func (p *TokenPool) Size() int {
+	return len(p.lexers)
+}
+
+// Lex scans the given input into a token stream, like the function Lex,
+// but uses a lexer of this pool.
+// The token stream must be reclaimed after use, which returns the lexer to this pool
+func (p *TokenPool) Lex(input []byte, memoryGauge common.MemoryGauge) (TokenStream, error) {
+	return lex(p.get(), input, memoryGauge)
+}
@@ -0,0 +1,116 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lexer
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenPool(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("reuse", func(t *testing.T) {
+		t.Parallel()
+
+		pool := NewTokenPool(1)
+
+		tokens, err := pool.Lex([]byte("a b"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, pool.Size())
+
+		tokens.Reclaim()
+		assert.Equal(t, 1, pool.Size())
+
+		reusedTokens, err := pool.Lex([]byte("c"), nil)
+		require.NoError(t, err)
+		assert.Same(t, tokens, reusedTokens)
+		assert.Equal(t, 0, pool.Size())
+
+		// The reused lexer must not return tokens of the previous input
+
+		assert.Equal(t, TokenIdentifier, reusedTokens.Next().Type)
+		assert.Equal(t, TokenEOF, reusedTokens.Next().Type)
+
+		reusedTokens.Reclaim()
+	})
+
+	t.Run("size", func(t *testing.T) {
+		t.Parallel()
+
+		pool := NewTokenPool(1)
+
+		tokens1, err := pool.Lex([]byte("a"), nil)
+		require.NoError(t, err)
+
+		tokens2, err := pool.Lex([]byte("b"), nil)
+		require.NoError(t, err)
+
+		tokens1.Reclaim()
+		tokens2.Reclaim()
+
+		assert.Equal(t, 1, pool.Size())
+	})
+
+	t.Run("large buffers are not retained", func(t *testing.T) {
+		t.Parallel()
+
+		pool := NewTokenPool(1)
+
+		input := strings.Repeat("a ", maxRetainedTokenBufferCapacity)
+
+		tokens, err := pool.Lex([]byte(input), nil)
+		require.NoError(t, err)
+
+		tokens.Reclaim()
+		assert.Equal(t, 0, pool.Size())
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		t.Parallel()
+
+		const concurrency = 8
+
+		pool := NewTokenPool(concurrency)
+
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 100; j++ {
+					tokens, err := pool.Lex([]byte("let x = 1"), nil)
+					if !assert.NoError(t, err) {
+						return
+					}
+					tokens.Reclaim()
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.LessOrEqual(t, pool.Size(), concurrency)
+	})
+}
@@ -83,6 +83,7 @@ const (
 	TokenAsQuestionMark
 	TokenPragma
 	TokenStringTemplate
+	TokenRawString
 	// NOTE: not an actual token, must be last item
 	TokenMax
 )
@@ -208,6 +209,8 @@ func (t TokenType) String() string {
 		return `'#'`
 	case TokenStringTemplate:
 		return `'\('`
+	case TokenRawString:
+		return "raw string"
 	default:
 		panic(errors.NewUnreachableError())
 	}
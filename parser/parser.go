@@ -57,6 +57,9 @@ type Config struct {
 	IgnoreLeadingIdentifierEnabled bool
 	// TypeParametersEnabled determines if type parameters are enabled
 	TypeParametersEnabled bool
+	// TokenPool is used to reuse the token buffers of the lexer across parses, if set.
+	// Otherwise, a default pool is used. See lexer.TokenPool for details
+	TokenPool *lexer.TokenPool
 }
 
 type parser struct {
@@ -86,6 +89,9 @@ type parser struct {
 	typeDepth int
 	// config enables certain features
 	config Config
+	// identifiers are the interned identifiers of the parse,
+	// so that repeated occurrences of an identifier share the same string
+	identifiers map[string]string
 }
 
 // Parse creates a lexer to scan the given input string,
@@ -100,7 +106,7 @@ func Parse[T any](
 	config Config,
 ) (result T, errors []error) {
 	// create a lexer, which turns the input string into tokens
-	tokens, err := lexer.Lex(input, memoryGauge)
+	tokens, err := lex(input, memoryGauge, config)
 	if err != nil {
 		errors = append(errors, err)
 		return
@@ -115,6 +121,15 @@ func Parse[T any](
 	)
 }
 
+// lex creates a lexer, which turns the input string into tokens.
+// The lexer is taken from the configured token pool, if any
+func lex(input []byte, memoryGauge common.MemoryGauge, config Config) (lexer.TokenStream, error) {
+	if config.TokenPool != nil {
+		return config.TokenPool.Lex(input, memoryGauge)
+	}
+	return lexer.Lex(input, memoryGauge)
+}
+
 func ParseTokenStream[T any](
 	memoryGauge common.MemoryGauge,
 	tokens lexer.TokenStream,
@@ -558,11 +573,31 @@ func (p *parser) nonReservedIdentifier(errMsgContext string) (ast.Identifier, er
 func (p *parser) tokenToIdentifier(token lexer.Token) ast.Identifier {
 	return ast.NewIdentifier(
 		p.memoryGauge,
-		string(p.tokenSource(token)),
+		p.internIdentifier(p.tokenSource(token)),
 		token.StartPos,
 	)
 }
 
+// internIdentifier returns the string for the given identifier source.
+// The string is only allocated for the first occurrence of the identifier in the parse.
+// Interning does not change the metered memory: the string is not metered separately,
+// it is covered by the metering of the identifier, see ast.NewIdentifier
+func (p *parser) internIdentifier(source []byte) string {
+	// NOTE: the lookup does not allocate a string for the key
+	if identifier, ok := p.identifiers[string(source)]; ok {
+		return identifier
+	}
+
+	identifier := string(source)
+
+	if p.identifiers == nil {
+		p.identifiers = map[string]string{}
+	}
+	p.identifiers[identifier] = identifier
+
+	return identifier
+}
+
 func (p *parser) startAmbiguity() {
 	if p.ambiguityLevel == 0 {
 		p.localReplayedTokensCount = 0
@@ -687,7 +722,7 @@ func ParseArgumentList(
 }
 
 func ParseProgram(memoryGauge common.MemoryGauge, code []byte, config Config) (program *ast.Program, err error) {
-	tokens, err := lexer.Lex(code, memoryGauge)
+	tokens, err := lex(code, memoryGauge, config)
 	if err != nil {
 		return
 	}
@@ -23,6 +23,7 @@ import (
 	"math/big"
 	"strings"
 	"testing"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1007,3 +1008,64 @@ func TestParseWhitespaceAtEnd(t *testing.T) {
 
 	assert.Empty(t, errs)
 }
+
+func TestParseIdentifierInterning(t *testing.T) {
+
+	t.Parallel()
+
+	program, err := ParseProgram(
+		nil,
+		[]byte(`
+          fun foo(bar: Int): Int {
+              return bar + bar
+          }
+        `),
+		Config{},
+	)
+	require.NoError(t, err)
+
+	functionDeclarations := program.FunctionDeclarations()
+	require.Len(t, functionDeclarations, 1)
+	functionDeclaration := functionDeclarations[0]
+
+	parameter := functionDeclaration.ParameterList.Parameters[0]
+
+	statements := functionDeclaration.FunctionBlock.Block.Statements
+	require.Len(t, statements, 1)
+	binaryExpression := statements[0].(*ast.ReturnStatement).Expression.(*ast.BinaryExpression)
+
+	left := binaryExpression.Left.(*ast.IdentifierExpression).Identifier.Identifier
+	right := binaryExpression.Right.(*ast.IdentifierExpression).Identifier.Identifier
+
+	// All occurrences of the identifier share the same string data
+
+	assert.Equal(t, "bar", left)
+	assert.Equal(t, unsafe.StringData(parameter.Identifier.Identifier), unsafe.StringData(left))
+	assert.Equal(t, unsafe.StringData(left), unsafe.StringData(right))
+}
+
+func TestParseTokenPool(t *testing.T) {
+
+	t.Parallel()
+
+	pool := lexer.NewTokenPool(1)
+
+	config := Config{
+		TokenPool: pool,
+	}
+
+	const code = `let x = 1`
+
+	expected, err := ParseProgram(nil, []byte(code), Config{})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		program, err := ParseProgram(nil, []byte(code), config)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, program)
+
+		// The lexer is returned to the pool after parsing
+		assert.Equal(t, 1, pool.Size())
+	}
+}
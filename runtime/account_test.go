@@ -26,6 +26,7 @@ import (
 	"github.com/onflow/atree"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/common"
@@ -2553,6 +2554,131 @@ func TestRuntimePublicAccountContracts(t *testing.T) {
 		assert.Equal(t, cadence.String("foo"), array.Values[0])
 		assert.Equal(t, cadence.String("bar"), array.Values[1])
 	})
+
+	t.Run("metadata of existing contract", func(t *testing.T) {
+		t.Parallel()
+
+		rt := NewTestInterpreterRuntime()
+
+		script := []byte(`
+            access(all) fun main(): [AnyStruct] {
+                let acc = getAccount(0x02)
+                let metadata = acc.contracts.metadata(name: "foo")!
+
+                return [metadata.name, metadata.codeHash, metadata.size, metadata.updateHeight]
+            }
+        `)
+
+		code := []byte{1, 2}
+
+		runtimeInterface := &TestRuntimeInterface{
+			OnGetAccountContractCode: func(location common.AddressLocation) ([]byte, error) {
+				require.Equal(t, "foo", location.Name)
+				return code, nil
+			},
+			OnGetAccountContractUpdateHeight: func(location common.AddressLocation) (uint64, bool, error) {
+				require.Equal(t, "foo", location.Name)
+				return 42, true, nil
+			},
+		}
+
+		result, err := rt.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		require.IsType(t, cadence.Array{}, result)
+		array := result.(cadence.Array)
+
+		require.Len(t, array.Values, 4)
+
+		assert.Equal(t, cadence.String("foo"), array.Values[0])
+
+		codeHash := sha3.Sum256(code)
+		expectedCodeHash := make([]cadence.Value, len(codeHash))
+		for i, b := range codeHash {
+			expectedCodeHash[i] = cadence.UInt8(b)
+		}
+		assert.Equal(t,
+			cadence.NewArray(expectedCodeHash).
+				WithType(cadence.NewConstantSizedArrayType(32, cadence.UInt8Type)),
+			array.Values[1],
+		)
+
+		assert.Equal(t, cadence.UInt64(2), array.Values[2])
+		assert.Equal(t, cadence.NewOptional(cadence.UInt64(42)), array.Values[3])
+	})
+
+	t.Run("metadata with unknown update height", func(t *testing.T) {
+		t.Parallel()
+
+		rt := NewTestInterpreterRuntime()
+
+		script := []byte(`
+            access(all) fun main() {
+                let acc = getAccount(0x02)
+                let metadata = acc.contracts.metadata(name: "foo")!
+                assert(metadata.size == 3)
+                assert(metadata.updateHeight == nil)
+            }
+        `)
+
+		runtimeInterface := &TestRuntimeInterface{
+			OnGetAccountContractCode: func(_ common.AddressLocation) ([]byte, error) {
+				return []byte{1, 2, 3}, nil
+			},
+			OnGetAccountContractUpdateHeight: func(_ common.AddressLocation) (uint64, bool, error) {
+				return 0, false, nil
+			},
+		}
+
+		_, err := rt.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("metadata of non-existing contract", func(t *testing.T) {
+		t.Parallel()
+
+		rt := NewTestInterpreterRuntime()
+
+		script := []byte(`
+            access(all) fun main() {
+                let acc = getAccount(0x02)
+                assert(acc.contracts.metadata(name: "foo") == nil)
+            }
+        `)
+
+		runtimeInterface := &TestRuntimeInterface{
+			OnGetAccountContractCode: func(_ common.AddressLocation) ([]byte, error) {
+				return nil, nil
+			},
+		}
+
+		_, err := rt.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+	})
 }
 
 func TestRuntimeGetAuthAccount(t *testing.T) {
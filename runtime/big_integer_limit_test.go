@@ -0,0 +1,166 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	. "github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeBigIntegerLimits(t *testing.T) {
+
+	t.Parallel()
+
+	const limit = 128
+
+	execute := func(config Config, script string) (map[common.ComputationKind]uint, error) {
+
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		computation := map[common.ComputationKind]uint{}
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage: NewTestLedger(nil, nil),
+			OnMeterComputation: func(kind common.ComputationKind, intensity uint) error {
+				computation[kind] += intensity
+				return nil
+			},
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(script),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		return computation, err
+	}
+
+	// Repeated squaring doubles the size of the integer in each iteration
+	const squaringScript = `
+      access(all) fun main() {
+          var x: Int = 3
+          var i = 0
+          while i < 10 {
+              x = x * x
+              i = i + 1
+          }
+      }
+    `
+
+	requireSizeLimitExceededError := func(t *testing.T, err error) {
+		RequireError(t, err)
+
+		var limitErr interpreter.BigIntegerSizeLimitExceededError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, uint64(limit), limitErr.Limit)
+		assert.Greater(t, limitErr.BitLength, uint64(limit))
+	}
+
+	t.Run("operands, unlimited", func(t *testing.T) {
+
+		t.Parallel()
+
+		computation, err := execute(DefaultTestInterpreterConfig, squaringScript)
+		require.NoError(t, err)
+
+		// The computation is proportional to the size of the operands
+		assert.Greater(t, computation[common.ComputationKindBigIntegerOperation], uint(0))
+	})
+
+	t.Run("operands, exceeds limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		config := DefaultTestInterpreterConfig
+		config.BigIntegerBitLengthLimit = limit
+
+		_, err := execute(config, squaringScript)
+		requireSizeLimitExceededError(t, err)
+	})
+
+	t.Run("left shift, exceeds limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		config := DefaultTestInterpreterConfig
+		config.BigIntegerBitLengthLimit = limit
+
+		_, err := execute(
+			config,
+			`
+              access(all) fun main() {
+                  let x: UInt = 1 << 1000
+              }
+            `,
+		)
+		requireSizeLimitExceededError(t, err)
+	})
+
+	t.Run("left shift, within limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		config := DefaultTestInterpreterConfig
+		config.BigIntegerBitLengthLimit = limit
+
+		_, err := execute(
+			config,
+			`
+              access(all) fun main() {
+                  let x: UInt = 1 << 127
+              }
+            `,
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("literal, exceeds limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		config := DefaultTestInterpreterConfig
+		config.IntegerLiteralBitLengthLimit = 64
+
+		_, err := execute(
+			config,
+			`
+              access(all) fun main() {
+                  let x = 18446744073709551616
+              }
+            `,
+		)
+		RequireError(t, err)
+
+		var sizeErr *sema.IntegerLiteralSizeLimitExceededError
+		require.ErrorAs(t, err, &sizeErr)
+	})
+}
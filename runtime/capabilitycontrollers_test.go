@@ -1366,6 +1366,115 @@ func TestRuntimeCapabilityControllers(t *testing.T) {
 						nonDeploymentEventStrings(events),
 					)
 				})
+
+				t.Run("issueAll, publishAll, and unpublishAll", func(t *testing.T) {
+
+					t.Parallel()
+
+					err, _, events := test(
+						t,
+						// language=cadence
+						`
+                          import Test from 0x1
+
+                          transaction {
+                              prepare(signer: auth(Capabilities) &Account) {
+                                  // Act
+                                  let caps: [Capability<&Test.R>] =
+                                      signer.capabilities.storage.issueAll<&Test.R>([/storage/r1, /storage/r2])
+                                  signer.capabilities.publishAll(caps, at: [/public/r1, /public/r2])
+
+                                  // Assert
+                                  assert(caps.length == 2)
+                                  assert(caps[0].id == 1)
+                                  assert(caps[1].id == 2)
+                                  assert(signer.capabilities.exists(/public/r1))
+                                  assert(signer.capabilities.exists(/public/r2))
+
+                                  // Act
+                                  let unpublishedCaps = signer.capabilities.unpublishAll([/public/r1, /public/r2, /public/r3])
+
+                                  // Assert
+                                  assert(unpublishedCaps.length == 3)
+                                  assert(unpublishedCaps[0]!.id == 1)
+                                  assert(unpublishedCaps[1]!.id == 2)
+                                  assert(unpublishedCaps[2] == nil)
+                                  assert(!signer.capabilities.exists(/public/r1))
+                                  assert(!signer.capabilities.exists(/public/r2))
+                              }
+                          }
+                        `,
+					)
+					require.NoError(t, err)
+
+					require.Equal(t,
+						[]string{
+							`flow.StorageCapabilityControllerIssued(id: 1, address: 0x0000000000000001, type: Type<&A.0000000000000001.Test.R>(), path: /storage/r1)`,
+							`flow.StorageCapabilityControllerIssued(id: 2, address: 0x0000000000000001, type: Type<&A.0000000000000001.Test.R>(), path: /storage/r2)`,
+							`flow.CapabilityPublished(address: 0x0000000000000001, path: /public/r1, capability: Capability<&A.0000000000000001.Test.R>(address: 0x0000000000000001, id: 1))`,
+							`flow.CapabilityPublished(address: 0x0000000000000001, path: /public/r2, capability: Capability<&A.0000000000000001.Test.R>(address: 0x0000000000000001, id: 2))`,
+							`flow.CapabilityUnpublished(address: 0x0000000000000001, path: /public/r1)`,
+							`flow.CapabilityUnpublished(address: 0x0000000000000001, path: /public/r2)`,
+						},
+						nonDeploymentEventStrings(events),
+					)
+				})
+
+				t.Run("publishAll, invalid", func(t *testing.T) {
+
+					t.Parallel()
+
+					err, _, events := test(
+						t,
+						// language=cadence
+						`
+                          import Test from 0x1
+
+                          transaction {
+                              prepare(signer: auth(Capabilities) &Account) {
+                                  // Arrange
+                                  let cap: Capability<&Test.R> =
+                                      signer.capabilities.storage.issue<&Test.R>(/storage/r)
+                                  signer.capabilities.publish(cap, at: /public/existing)
+
+                                  // Act
+                                  signer.capabilities.publishAll(
+                                      [cap, cap, cap, cap],
+                                      at: [/public/a, /public/existing, /public/b, /public/a]
+                                  )
+                              }
+                          }
+                        `,
+					)
+					RequireError(t, err)
+
+					var batchErr interpreter.CapabilityBatchPublishingError
+					require.ErrorAs(t, err, &batchErr)
+					require.Len(t, batchErr.Errors, 2)
+
+					for _, publishingErr := range batchErr.Errors {
+						require.IsType(t, interpreter.OverwriteError{}, publishingErr)
+					}
+
+					assert.Equal(t,
+						interpreter.NewUnmeteredPathValue(common.PathDomainPublic, "existing"),
+						batchErr.Errors[0].(interpreter.OverwriteError).Path,
+					)
+					assert.Equal(t,
+						interpreter.NewUnmeteredPathValue(common.PathDomainPublic, "a"),
+						batchErr.Errors[1].(interpreter.OverwriteError).Path,
+					)
+
+					// None of the capabilities were published
+
+					require.Equal(t,
+						[]string{
+							`flow.StorageCapabilityControllerIssued(id: 1, address: 0x0000000000000001, type: Type<&A.0000000000000001.Test.R>(), path: /storage/r)`,
+							`flow.CapabilityPublished(address: 0x0000000000000001, path: /public/existing, capability: Capability<&A.0000000000000001.Test.R>(address: 0x0000000000000001, id: 1))`,
+						},
+						nonDeploymentEventStrings(events),
+					)
+				})
 			}
 		})
 	}
@@ -0,0 +1,108 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	. "github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeCheckedArithmetic(t *testing.T) {
+
+	t.Parallel()
+
+	execute := func(script string, enabled bool) (cadence.Value, error) {
+
+		config := DefaultTestInterpreterConfig
+		config.CheckedArithmeticEnabled = enabled
+
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage: NewTestLedger(nil, nil),
+		}
+
+		nextScriptLocation := NewScriptLocationGenerator()
+
+		return runtime.ExecuteScript(
+			Script{
+				Source: []byte(script),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextScriptLocation(),
+			},
+		)
+	}
+
+	const checkedScript = `
+      access(all) fun main(): UInt8? {
+          return checkedAdd(UInt8.max, 1)
+      }
+    `
+
+	const declaringScript = `
+      access(all) fun checkedAdd(_ a: UInt8, _ b: UInt8): UInt8? {
+          return a + b
+      }
+
+      access(all) fun main(): UInt8? {
+          return checkedAdd(1, 2)
+      }
+    `
+
+	t.Run("enabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		value, err := execute(checkedScript, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewOptional(nil), value)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(checkedScript, false)
+		RequireError(t, err)
+
+		var notDeclaredErr *sema.NotDeclaredError
+		require.ErrorAs(t, err, &notDeclaredErr)
+	})
+
+	t.Run("disabled, declared by program", func(t *testing.T) {
+
+		t.Parallel()
+
+		value, err := execute(declaringScript, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewOptional(cadence.UInt8(3)), value)
+	})
+}
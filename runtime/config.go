@@ -19,18 +19,40 @@
 package runtime
 
 import (
+	"github.com/onflow/cadence/ast"
 	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/parser/lexer"
+	"github.com/onflow/cadence/stdlib"
 )
 
 // Config is a constant/read-only configuration of an environment.
 type Config struct {
 	Debugger *interpreter.Debugger
+	// Profiler configures the profiler which records where the execution is spent, if any
+	Profiler *interpreter.Profiler
 	// StackDepthLimit specifies the maximum depth for call stacks
 	StackDepthLimit uint64
+	// DebugUtilsEnabled specifies whether the DebugUtils contract is available to programs,
+	// e.g. to inspect the call stack. It should only be enabled in test environments
+	DebugUtilsEnabled bool
+	// ReentrancyGuardEnabled specifies whether the withReentrancyGuard function is available to programs,
+	// see stdlib.NewWithReentrancyGuardFunction
+	ReentrancyGuardEnabled bool
+	// CheckedArithmeticEnabled specifies whether the checked arithmetic functions, e.g. checkedAdd,
+	// are available to programs, see stdlib.CheckedArithmeticFunctions
+	CheckedArithmeticEnabled bool
 	// AtreeValidationEnabled configures if atree validation is enabled
 	AtreeValidationEnabled bool
 	// TracingEnabled configures if tracing is enabled
 	TracingEnabled bool
+	// Tracer configures the tracer which records spans for the execution phases, if any
+	Tracer Tracer
+	// ReferenceTracingEnabled configures if reference tracing is enabled,
+	// see interpreter.Config.ReferenceTracingEnabled
+	ReferenceTracingEnabled bool
+	// SubtypeCheckCacheSize configures the size of the cache of subtype check results,
+	// see interpreter.Config.SubtypeCheckCacheSize
+	SubtypeCheckCacheSize int
 	// ResourceOwnerChangeCallbackEnabled configures if the resource owner change callback is enabled
 	ResourceOwnerChangeHandlerEnabled bool
 	// CoverageReport enables and collects coverage reporting metrics
@@ -39,4 +61,77 @@ type Config struct {
 	LegacyContractUpgradeEnabled bool
 	// StorageFormatV2Enabled specifies whether storage format V2 is enabled
 	StorageFormatV2Enabled bool
+	// EventCountLimit specifies the maximum number of events a transaction or script may emit.
+	// Zero means unlimited
+	EventCountLimit uint64
+	// EventSizeLimit specifies the maximum total size in bytes of the encoded events
+	// a transaction or script may emit. Zero means unlimited.
+	// The sizes of the encoded events are reported by the runtime interface before the events are emitted,
+	// see EventSizer. If the runtime interface does not report the size of an event, the execution fails
+	EventSizeLimit uint64
+	// IntegerLiteralBitLengthLimit specifies the maximum length in bits of integer literals,
+	// see sema.Config.IntegerLiteralBitLengthLimit. Zero means unlimited
+	IntegerLiteralBitLengthLimit int
+	// BigIntegerBitLengthLimit specifies the maximum length in bits of the operands of Int and UInt arithmetic,
+	// see interpreter.Config.BigIntegerBitLengthLimit. Zero means unlimited
+	BigIntegerBitLengthLimit uint64
+	// StoredValueSizeLimit specifies the maximum encoded size in bytes of a single value stored in an account,
+	// see interpreter.Config.StoredValueSizeLimit. Zero means unlimited
+	StoredValueSizeLimit uint64
+	// CompositeValueCreationLimit specifies the maximum number of composite values a transaction or script may create,
+	// including copies, regardless of how many of them are still live,
+	// see interpreter.Config.CompositeValueCreationLimit. Zero means unlimited
+	CompositeValueCreationLimit uint64
+	// ArrayValueCreationLimit specifies the maximum number of array values a transaction or script may create,
+	// including copies, regardless of how many of them are still live,
+	// see interpreter.Config.ArrayValueCreationLimit. Zero means unlimited
+	ArrayValueCreationLimit uint64
+	// DictionaryValueCreationLimit specifies the maximum number of dictionary values a transaction or script may create,
+	// including copies, regardless of how many of them are still live,
+	// see interpreter.Config.DictionaryValueCreationLimit. Zero means unlimited
+	DictionaryValueCreationLimit uint64
+	// CopyOnWriteArgumentsEnabled specifies whether the copies of arrays and dictionaries
+	// which are passed as arguments are deferred until they are mutated,
+	// see interpreter.Config.CopyOnWriteArgumentsEnabled
+	CopyOnWriteArgumentsEnabled bool
+	// ReadOnlyScriptsEnabled specifies whether scripts are executed in read-only mode,
+	// i.e. whether storage writes, event emission, and account mutations fail in scripts,
+	// see interpreter.Config.ReadOnly
+	ReadOnlyScriptsEnabled bool
+	// StorageWriteLimits specifies the maximum number of registers and bytes
+	// a transaction or script may write to storage, overall and per account
+	StorageWriteLimits StorageWriteLimits
+	// ContractCodeSizeLimit specifies the maximum size in bytes of the code of a deployed contract.
+	// Zero means unlimited
+	ContractCodeSizeLimit uint64
+	// ContractDeploymentValidators are additional validators for contract deployments,
+	// e.g. to enforce network policies. They are run after the built-in validators
+	ContractDeploymentValidators []stdlib.ContractDeploymentValidator
+	// ContractMetadataEventsEnabled specifies whether metadata events, which describe the declared types,
+	// are emitted in addition to the standard events, when contracts are added, updated, or removed
+	ContractMetadataEventsEnabled bool
+	// ExecutionSuspensionEnabled specifies whether executions can be suspended at statement boundaries,
+	// and be resumed by replaying them up to the statement, see Context.Suspension
+	ExecutionSuspensionEnabled bool
+	// UUIDGeneratorProvider, if set, provides the generator of the UUIDs of resources
+	// for each execution of a transaction or script, instead of Interface.GenerateUUID,
+	// e.g. to generate deterministic UUIDs, see NewDeterministicUUIDGeneratorProvider
+	UUIDGeneratorProvider UUIDGeneratorProvider
+	// TestingEnabled specifies whether the environment is used for testing, e.g. by a test framework or an emulator.
+	// Features which must not be used in production, e.g. ProgramTransformer, require it
+	TestingEnabled bool
+	// ProgramTransformer, if set, transforms the programs of imported locations after parsing and before checking,
+	// e.g. to inject instrumentation for coverage or tracing. Requires TestingEnabled
+	ProgramTransformer ProgramTransformer
+	// PartialResultsEnabled specifies whether the logs and events of an execution are recorded,
+	// so they can be attached to the error if the execution fails, see Error.PartialResult.
+	// The diagnostics of an error are always available
+	PartialResultsEnabled bool
+	// TokenPool is used to reuse the token buffers of the lexer across the parses of programs.
+	// It can be shared by all environments of an embedder. If nil, a default pool is used
+	TokenPool *lexer.TokenPool
 }
+
+// ProgramTransformer transforms the program at the given location, see Config.ProgramTransformer.
+// It may modify the given program in-place, or return a new program
+type ProgramTransformer func(program *ast.Program, location Location) (*ast.Program, error)
@@ -27,6 +27,20 @@ type Context struct {
 	Location       Location
 	Environment    Environment
 	CoverageReport *CoverageReport
+	// ExecutionTrace, if set, records the execution of a script or transaction,
+	// see ExecutionTrace
+	ExecutionTrace *ExecutionTrace
+	// Suspension, if set, allows the execution of a script or transaction to be suspended,
+	// or resumes a suspended execution by replaying it, see ExecutionSuspension.
+	// Requires Config.ExecutionSuspensionEnabled
+	Suspension *ExecutionSuspension
+	// SharedSlabCache, if set, is the cache of decoded slabs shared by concurrent executions of scripts
+	// against the same execution state snapshot, see SharedSlabCache.
+	// It is only used for scripts, and only if Config.ReadOnlyScriptsEnabled is set
+	SharedSlabCache *SharedSlabCache
+	// Progress, if set, is notified about the progress of the execution of a script or transaction,
+	// see ExecutionProgress
+	Progress *ExecutionProgress
 }
 
 // CodesAndPrograms collects the source code and AST for each location.
@@ -0,0 +1,291 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	. "github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/sema"
+	"github.com/onflow/cadence/stdlib"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeContractDeploymentValidation(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	const contractCode = `
+      access(all) contract Test {}
+    `
+
+	const updatedContractCode = `
+      access(all) contract Test {
+          access(all) fun test() {}
+      }
+    `
+
+	newRuntime := func(config Config) (
+		deploy func(code string) error,
+		update func(code string) error,
+	) {
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		accountCodes := map[Location][]byte{}
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage:           NewTestLedger(nil, nil),
+			OnResolveLocation: NewSingleIdentifierLocationResolver(t),
+			OnGetSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			OnUpdateAccountContractCode: func(location common.AddressLocation, code []byte) error {
+				accountCodes[location] = code
+				return nil
+			},
+			OnGetAccountContractCode: func(location common.AddressLocation) (code []byte, err error) {
+				return accountCodes[location], nil
+			},
+			OnEmitEvent: func(_ cadence.Event) error {
+				return nil
+			},
+		}
+
+		nextTransactionLocation := NewTransactionLocationGenerator()
+
+		execute := func(tx []byte) error {
+			return runtime.ExecuteTransaction(
+				Script{
+					Source: tx,
+				},
+				Context{
+					Interface: runtimeInterface,
+					Location:  nextTransactionLocation(),
+				},
+			)
+		}
+
+		deploy = func(code string) error {
+			return execute(DeploymentTransaction("Test", []byte(code)))
+		}
+
+		update = func(code string) error {
+			return execute(UpdateTransaction("Test", []byte(code)))
+		}
+
+		return
+	}
+
+	requireValidationErrors := func(t *testing.T, err error) []error {
+		RequireError(t, err)
+
+		var validationErr *stdlib.ContractDeploymentValidationError
+		require.ErrorAs(t, err, &validationErr)
+
+		return validationErr.Errors
+	}
+
+	t.Run("code size limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		config := DefaultTestInterpreterConfig
+		config.ContractCodeSizeLimit = uint64(len(contractCode))
+
+		deploy, update := newRuntime(config)
+
+		err := deploy(contractCode)
+		require.NoError(t, err)
+
+		err = update(updatedContractCode)
+		errs := requireValidationErrors(t, err)
+		require.Len(t, errs, 1)
+
+		var limitErr *stdlib.ContractCodeSizeLimitExceededError
+		require.ErrorAs(t, errs[0], &limitErr)
+		assert.Equal(t, uint64(len(contractCode)), limitErr.Limit)
+		assert.Equal(t, uint64(len(updatedContractCode)), limitErr.Size)
+	})
+
+	t.Run("custom validator", func(t *testing.T) {
+
+		t.Parallel()
+
+		var deployments []stdlib.ContractDeployment
+
+		config := DefaultTestInterpreterConfig
+		config.ContractDeploymentValidators = []stdlib.ContractDeploymentValidator{
+			stdlib.NewContractDeploymentValidator(
+				"recording",
+				func(deployment *stdlib.ContractDeployment) error {
+					deployments = append(deployments, *deployment)
+					return nil
+				},
+			),
+			stdlib.NewContractDeploymentValidator(
+				"no functions",
+				func(deployment *stdlib.ContractDeployment) error {
+					if strings.Contains(string(deployment.Code), "fun") {
+						return errors.NewDefaultUserError("contract must not declare functions")
+					}
+					return nil
+				},
+			),
+		}
+
+		deploy, update := newRuntime(config)
+
+		err := deploy(contractCode)
+		require.NoError(t, err)
+
+		err = update(updatedContractCode)
+		errs := requireValidationErrors(t, err)
+		require.Len(t, errs, 1)
+
+		var validatorErr *stdlib.ContractDeploymentValidatorError
+		require.ErrorAs(t, errs[0], &validatorErr)
+		assert.Equal(t, "no functions", validatorErr.Validator)
+		assert.ErrorContains(t, err, "contract must not declare functions")
+
+		require.Len(t, deployments, 2)
+
+		added := deployments[0]
+		assert.False(t, added.IsUpdate)
+		assert.Equal(t, "Test", added.Name)
+		assert.Nil(t, added.OldCode)
+		require.NotNil(t, added.Program)
+		require.NotNil(t, added.ContractType)
+		assert.Equal(t, "Test", added.ContractType.Identifier)
+
+		updated := deployments[1]
+		assert.True(t, updated.IsUpdate)
+		assert.Equal(t, []byte(contractCode), updated.OldCode)
+		assert.Equal(t, []byte(updatedContractCode), updated.Code)
+	})
+
+	t.Run("combined errors", func(t *testing.T) {
+
+		t.Parallel()
+
+		const invalidContractCode = `
+          access(all) contract Test {
+              access(all) fun test(): Int {
+                  return "x"
+              }
+          }
+        `
+
+		config := DefaultTestInterpreterConfig
+		config.ContractCodeSizeLimit = 10
+
+		var programChecked bool
+
+		config.ContractDeploymentValidators = []stdlib.ContractDeploymentValidator{
+			stdlib.NewContractDeploymentValidator(
+				"checked",
+				func(deployment *stdlib.ContractDeployment) error {
+					programChecked = deployment.Program != nil
+					return nil
+				},
+			),
+		}
+
+		deploy, _ := newRuntime(config)
+
+		err := deploy(invalidContractCode)
+		errs := requireValidationErrors(t, err)
+		require.Len(t, errs, 2)
+
+		var validatorErr *stdlib.ContractDeploymentValidatorError
+
+		require.ErrorAs(t, errs[0], &validatorErr)
+		assert.Equal(t, "program", validatorErr.Validator)
+
+		var checkerErr *sema.CheckerError
+		require.ErrorAs(t, errs[0], &checkerErr)
+
+		require.ErrorAs(t, errs[1], &validatorErr)
+		assert.Equal(t, "code size", validatorErr.Validator)
+
+		var limitErr *stdlib.ContractCodeSizeLimitExceededError
+		require.ErrorAs(t, errs[1], &limitErr)
+
+		assert.False(t, programChecked)
+	})
+
+	t.Run("built-in errors", func(t *testing.T) {
+
+		t.Parallel()
+
+		config := DefaultTestInterpreterConfig
+		config.ContractDeploymentValidators = []stdlib.ContractDeploymentValidator{
+			stdlib.NewContractDeploymentValidator(
+				"accepting",
+				func(_ *stdlib.ContractDeployment) error {
+					return nil
+				},
+			),
+		}
+
+		deploy, _ := newRuntime(config)
+
+		// If only a built-in validator rejects the deployment,
+		// its error is reported as is, and not combined
+
+		err := deploy(`
+          access(all) contract Test {
+              access(all) fun test(): Int {
+                  return "x"
+              }
+          }
+        `)
+		RequireError(t, err)
+
+		var validationErr *stdlib.ContractDeploymentValidationError
+		require.NotErrorAs(t, err, &validationErr)
+
+		var invalidDeploymentErr *stdlib.InvalidContractDeploymentError
+		require.ErrorAs(t, err, &invalidDeploymentErr)
+
+		var parsingCheckingErr *ParsingCheckingError
+		require.ErrorAs(t, invalidDeploymentErr.Err, &parsingCheckingErr)
+
+		err = deploy(`
+          access(all) contract Other {}
+        `)
+		RequireError(t, err)
+
+		require.NotErrorAs(t, err, &validationErr)
+		require.NotErrorAs(t, err, &invalidDeploymentErr)
+
+		var userErr errors.DefaultUserError
+		require.ErrorAs(t, err, &userErr)
+		assert.ErrorContains(t, err, "the name argument must match the name of the declaration")
+	})
+}
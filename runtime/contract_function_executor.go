@@ -104,12 +104,19 @@ func (executor *interpreterContractFunctionExecutor) preprocess() (err error) {
 	)
 
 	runtimeInterface := context.Interface
+	if uuidGeneratorProvider := interpreterRuntime.defaultConfig.UUIDGeneratorProvider; uuidGeneratorProvider != nil {
+		runtimeInterface = newUUIDGeneratorInterface(
+			uuidGeneratorProvider(location),
+			runtimeInterface,
+		)
+	}
 
 	storage := NewStorage(
 		runtimeInterface,
 		runtimeInterface,
 		StorageConfig{
 			StorageFormatV2Enabled: interpreterRuntime.defaultConfig.StorageFormatV2Enabled,
+			WriteLimits:            interpreterRuntime.defaultConfig.StorageWriteLimits,
 		},
 	)
 	executor.storage = storage
@@ -217,7 +224,17 @@ func (executor *interpreterContractFunctionExecutor) execute() (val cadence.Valu
 		return nil, newError(err, location, codesAndPrograms)
 	}
 
-	value, err := inter.InvokeFunction(contractFunction, invocation)
+	var value interpreter.Value
+	traceSpan(
+		interpreterRuntime.defaultConfig.Tracer,
+		context.Interface,
+		TracerSpanInvokeContractFunction,
+		executor.contractLocation,
+		func() {
+			value, err = inter.InvokeFunction(contractFunction, invocation)
+		},
+		TracerAttributeFunction.String(executor.functionName),
+	)
 	if err != nil {
 		return nil, newError(err, location, codesAndPrograms)
 	}
@@ -0,0 +1,264 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	. "github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/sema"
+	"github.com/onflow/cadence/stdlib"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeContractMetadataEvents(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	const contractCode = `
+      access(all) contract Test {
+
+          access(all) event Created(id: UInt64)
+
+          access(all) resource R {}
+
+          access(all) struct interface I {}
+      }
+    `
+
+	const updatedContractCode = `
+      access(all) contract Test {
+
+          access(all) event Created(id: UInt64)
+
+          access(all) resource R {}
+
+          access(all) struct interface I {}
+
+          access(all) entitlement E
+
+          access(all) event Destroyed(id: UInt64)
+      }
+    `
+
+	const removalTransaction = `
+      transaction {
+          prepare(signer: auth(Contracts) &Account) {
+              signer.contracts.remove(name: "Test")
+          }
+      }
+    `
+
+	newRuntime := func(metadataEventsEnabled bool) (
+		execute func(tx []byte) error,
+		events *[]cadence.Event,
+	) {
+		config := DefaultTestInterpreterConfig
+		config.ContractMetadataEventsEnabled = metadataEventsEnabled
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		accountCodes := map[Location][]byte{}
+		events = &[]cadence.Event{}
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage:           NewTestLedger(nil, nil),
+			OnResolveLocation: NewSingleIdentifierLocationResolver(t),
+			OnGetSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			OnUpdateAccountContractCode: func(location common.AddressLocation, code []byte) error {
+				accountCodes[location] = code
+				return nil
+			},
+			OnGetAccountContractCode: func(location common.AddressLocation) (code []byte, err error) {
+				return accountCodes[location], nil
+			},
+			OnRemoveAccountContractCode: func(location common.AddressLocation) error {
+				delete(accountCodes, location)
+				return nil
+			},
+			OnEmitEvent: func(event cadence.Event) error {
+				*events = append(*events, event)
+				return nil
+			},
+		}
+
+		nextTransactionLocation := NewTransactionLocationGenerator()
+
+		execute = func(tx []byte) error {
+			return runtime.ExecuteTransaction(
+				Script{
+					Source: tx,
+				},
+				Context{
+					Interface: runtimeInterface,
+					Location:  nextTransactionLocation(),
+				},
+			)
+		}
+
+		return
+	}
+
+	typeIDs := func(value cadence.Value) []string {
+		var result []string
+		for _, element := range value.(cadence.Array).Values {
+			result = append(result, string(element.(cadence.String)))
+		}
+		return result
+	}
+
+	requireMetadataEvent := func(
+		t *testing.T,
+		event cadence.Event,
+		eventType *cadence.EventType,
+	) (
+		declaredTypes []string,
+		addedEventTypes []string,
+		removedEventTypes []string,
+	) {
+		require.Equal(t, eventType.ID(), event.EventType.ID())
+
+		fields := cadence.FieldsMappedByName(event)
+		assert.Equal(t, cadence.String("Test"), fields["contract"])
+		assert.Equal(t, cadence.Address(address), fields["address"])
+
+		return typeIDs(fields["declaredTypes"]),
+			typeIDs(fields["addedEventTypes"]),
+			typeIDs(fields["removedEventTypes"])
+	}
+
+	exportedEventType := func(eventType *sema.CompositeType) *cadence.EventType {
+		return ExportType(eventType, map[sema.TypeID]cadence.Type{}).(*cadence.EventType)
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		execute, events := newRuntime(true)
+
+		// Add
+
+		err := execute(DeploymentTransaction("Test", []byte(contractCode)))
+		require.NoError(t, err)
+
+		require.Len(t, *events, 2)
+		assert.Equal(t, "flow.AccountContractAdded", (*events)[0].EventType.ID())
+
+		declaredTypes, addedEventTypes, removedEventTypes := requireMetadataEvent(
+			t,
+			(*events)[1],
+			exportedEventType(stdlib.AccountContractAddedMetadataEventType),
+		)
+		assert.Equal(t,
+			[]string{
+				"A.0000000000000001.Test",
+				"A.0000000000000001.Test.Created",
+				"A.0000000000000001.Test.R",
+				"A.0000000000000001.Test.I",
+			},
+			declaredTypes,
+		)
+		assert.Equal(t, []string{"A.0000000000000001.Test.Created"}, addedEventTypes)
+		assert.Empty(t, removedEventTypes)
+
+		// Update
+
+		*events = nil
+
+		err = execute(UpdateTransaction("Test", []byte(updatedContractCode)))
+		require.NoError(t, err)
+
+		require.Len(t, *events, 2)
+		assert.Equal(t, "flow.AccountContractUpdated", (*events)[0].EventType.ID())
+
+		declaredTypes, addedEventTypes, removedEventTypes = requireMetadataEvent(
+			t,
+			(*events)[1],
+			exportedEventType(stdlib.AccountContractUpdatedMetadataEventType),
+		)
+		assert.Equal(t,
+			[]string{
+				"A.0000000000000001.Test",
+				"A.0000000000000001.Test.Created",
+				"A.0000000000000001.Test.R",
+				"A.0000000000000001.Test.I",
+				"A.0000000000000001.Test.E",
+				"A.0000000000000001.Test.Destroyed",
+			},
+			declaredTypes,
+		)
+		assert.Equal(t, []string{"A.0000000000000001.Test.Destroyed"}, addedEventTypes)
+		assert.Empty(t, removedEventTypes)
+
+		// Remove
+
+		*events = nil
+
+		err = execute([]byte(removalTransaction))
+		require.NoError(t, err)
+
+		require.Len(t, *events, 2)
+		assert.Equal(t, "flow.AccountContractRemoved", (*events)[0].EventType.ID())
+
+		declaredTypes, addedEventTypes, removedEventTypes = requireMetadataEvent(
+			t,
+			(*events)[1],
+			exportedEventType(stdlib.AccountContractRemovedMetadataEventType),
+		)
+		assert.Len(t, declaredTypes, 6)
+		assert.Empty(t, addedEventTypes)
+		assert.Equal(t,
+			[]string{
+				"A.0000000000000001.Test.Created",
+				"A.0000000000000001.Test.Destroyed",
+			},
+			removedEventTypes,
+		)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		execute, events := newRuntime(false)
+
+		err := execute(DeploymentTransaction("Test", []byte(contractCode)))
+		require.NoError(t, err)
+
+		err = execute(UpdateTransaction("Test", []byte(updatedContractCode)))
+		require.NoError(t, err)
+
+		err = execute([]byte(removalTransaction))
+		require.NoError(t, err)
+
+		require.Len(t, *events, 3)
+		assert.Equal(t, "flow.AccountContractAdded", (*events)[0].EventType.ID())
+		assert.Equal(t, "flow.AccountContractUpdated", (*events)[1].EventType.ID())
+		assert.Equal(t, "flow.AccountContractRemoved", (*events)[2].EventType.ID())
+	})
+}
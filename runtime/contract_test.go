@@ -1387,3 +1387,98 @@ func TestRuntimeContractTryUpdate(t *testing.T) {
 		assert.True(t, didPanic)
 	})
 }
+
+func TestRuntimeContractDeclaredTypes(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	contract := []byte(`
+      access(all) contract Test {
+
+          access(all) entitlement E
+
+          access(all) event Created(id: UInt64)
+
+          access(all) resource R {}
+
+          access(all) struct interface I {}
+      }
+    `)
+
+	runtime := NewTestInterpreterRuntime()
+
+	accountCodes := map[Location][]byte{}
+
+	runtimeInterface := &TestRuntimeInterface{
+		Storage:           NewTestLedger(nil, nil),
+		OnResolveLocation: NewSingleIdentifierLocationResolver(t),
+		OnGetSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+		OnUpdateAccountContractCode: func(location common.AddressLocation, code []byte) error {
+			accountCodes[location] = code
+			return nil
+		},
+		OnGetAccountContractCode: func(location common.AddressLocation) (code []byte, err error) {
+			return accountCodes[location], nil
+		},
+		OnEmitEvent: func(event cadence.Event) error {
+			return nil
+		},
+	}
+
+	location := common.AddressLocation{
+		Address: address,
+		Name:    "Test",
+	}
+
+	// Not deployed yet
+
+	declaredTypes, err := runtime.ContractDeclaredTypes(
+		location,
+		Context{
+			Interface: runtimeInterface,
+		},
+	)
+	require.NoError(t, err)
+	require.Nil(t, declaredTypes)
+
+	nextTransactionLocation := NewTransactionLocationGenerator()
+
+	err = runtime.ExecuteTransaction(
+		Script{
+			Source: DeploymentTransaction("Test", contract),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	declaredTypes, err = runtime.ContractDeclaredTypes(
+		location,
+		Context{
+			Interface: runtimeInterface,
+		},
+	)
+	require.NoError(t, err)
+
+	kinds := map[common.TypeID]common.DeclarationKind{}
+	for _, declaredType := range declaredTypes {
+		kinds[declaredType.ID()] = declaredType.Kind
+	}
+
+	assert.Equal(t,
+		map[common.TypeID]common.DeclarationKind{
+			"A.0000000000000001.Test":         common.DeclarationKindContract,
+			"A.0000000000000001.Test.E":       common.DeclarationKindEntitlement,
+			"A.0000000000000001.Test.Created": common.DeclarationKindEvent,
+			"A.0000000000000001.Test.R":       common.DeclarationKindResource,
+			"A.0000000000000001.Test.I":       common.DeclarationKindStructureInterface,
+		},
+		kinds,
+	)
+}
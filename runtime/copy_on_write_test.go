@@ -0,0 +1,104 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeCopyOnWriteArguments(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      access(all) fun mutate(_ xs: [Int]): [Int] {
+          xs.append(4)
+          xs[0] = 0
+          return xs
+      }
+
+      access(all) fun main(): [[Int]] {
+          let account = getAuthAccount<auth(Storage) &Account>(0x1)
+          account.storage.save([1, 2, 3], to: /storage/xs)
+
+          let xs = account.storage.copy<[Int]>(from: /storage/xs)!
+          let ys = mutate(xs)
+          let stored = account.storage.load<[Int]>(from: /storage/xs)!
+
+          return [xs, ys, stored]
+      }
+    `)
+
+	newIntArray := func(values ...int) cadence.Array {
+		elements := make([]cadence.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, cadence.NewInt(value))
+		}
+		return cadence.NewArray(elements).
+			WithType(cadence.NewVariableSizedArrayType(cadence.IntType))
+	}
+
+	expected := cadence.NewArray([]cadence.Value{
+		newIntArray(1, 2, 3),
+		newIntArray(0, 2, 3, 4),
+		newIntArray(1, 2, 3),
+	}).WithType(cadence.NewVariableSizedArrayType(
+		cadence.NewVariableSizedArrayType(cadence.IntType),
+	))
+
+	for _, enabled := range []bool{true, false} {
+		t.Run(fmt.Sprintf("enabled: %t", enabled), func(t *testing.T) {
+
+			t.Parallel()
+
+			config := DefaultTestInterpreterConfig
+			config.CopyOnWriteArgumentsEnabled = enabled
+
+			runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+			runtimeInterface := &TestRuntimeInterface{
+				Storage: NewTestLedger(nil, nil),
+			}
+
+			result, err := runtime.ExecuteScript(
+				Script{
+					Source: script,
+				},
+				Context{
+					Interface: runtimeInterface,
+					Location:  common.ScriptLocation{},
+				},
+			)
+			require.NoError(t, err)
+
+			// The argument is copied when it is mutated,
+			// so neither the caller's array nor the stored array are affected
+			assert.Equal(t, expected, result)
+		})
+	}
+}
@@ -23,11 +23,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/onflow/cadence/ast"
 	"github.com/onflow/cadence/common"
 )
 
+// ConditionCoverage records how often the pre/post conditions on a line
+// were evaluated, how often they failed, and how long the evaluations took in total.
+type ConditionCoverage struct {
+	Kind        string        `json:"kind"`
+	Evaluations int           `json:"evaluations"`
+	Failures    int           `json:"failures"`
+	Duration    time.Duration `json:"duration_ns"`
+}
+
+// FunctionCoverage records how often a function was invoked,
+// and how long the invocations took in total.
+type FunctionCoverage struct {
+	Name        string        `json:"name"`
+	Invocations int           `json:"invocations"`
+	Duration    time.Duration `json:"duration_ns"`
+}
+
 // LocationCoverage records coverage information for a location.
 type LocationCoverage struct {
 	// Contains hit count for each line on a given location.
@@ -35,6 +53,13 @@ type LocationCoverage struct {
 	LineHits map[int]int
 	// Total number of statements on a given location.
 	Statements int
+	// Contains the coverage of the pre/post conditions on each line.
+	// Only recorded if condition coverage is enabled.
+	Conditions map[int]*ConditionCoverage
+	// Contains the coverage of each view function, keyed by the line
+	// of the function's parameter list, i.e. usually the line of the declaration.
+	// Only recorded if condition coverage is enabled.
+	ViewFunctions map[int]*FunctionCoverage
 }
 
 // AddLineHit increments the hit count for the given line.
@@ -46,6 +71,73 @@ func (c *LocationCoverage) AddLineHit(line int) {
 	c.LineHits[line]++
 }
 
+// AddConditionEvaluation records the evaluation of the condition on the given line.
+func (c *LocationCoverage) AddConditionEvaluation(
+	line int,
+	kind ast.ConditionKind,
+	passed bool,
+	duration time.Duration,
+) {
+	// Lines below 1 are dropped.
+	if line < 1 {
+		return
+	}
+	if c.Conditions == nil {
+		c.Conditions = map[int]*ConditionCoverage{}
+	}
+	conditionCoverage, ok := c.Conditions[line]
+	if !ok {
+		conditionCoverage = &ConditionCoverage{
+			Kind: kind.Keyword(),
+		}
+		c.Conditions[line] = conditionCoverage
+	}
+	conditionCoverage.Evaluations++
+	if !passed {
+		conditionCoverage.Failures++
+	}
+	conditionCoverage.Duration += duration
+}
+
+// AddViewFunctionInvocation records the invocation of the view function
+// with the parameter list on the given line.
+// Invocations of functions which were not found during inspection,
+// e.g. function expressions, are dropped.
+func (c *LocationCoverage) AddViewFunctionInvocation(line int, duration time.Duration) {
+	functionCoverage, ok := c.ViewFunctions[line]
+	if !ok {
+		return
+	}
+	functionCoverage.Invocations++
+	functionCoverage.Duration += duration
+}
+
+// MissedConditions returns the lines of the conditions which were
+// never evaluated. The resulting array is sorted in ascending order.
+func (c *LocationCoverage) MissedConditions() []int {
+	missedConditions := make([]int, 0)
+	for line, conditionCoverage := range c.Conditions { //nolint:maprange
+		if conditionCoverage.Evaluations == 0 {
+			missedConditions = append(missedConditions, line)
+		}
+	}
+	sort.Ints(missedConditions)
+	return missedConditions
+}
+
+// MissedViewFunctions returns the names of the view functions which
+// were never invoked. The resulting array is sorted in ascending order.
+func (c *LocationCoverage) MissedViewFunctions() []string {
+	missedFunctions := make([]string, 0)
+	for _, functionCoverage := range c.ViewFunctions { //nolint:maprange
+		if functionCoverage.Invocations == 0 {
+			missedFunctions = append(missedFunctions, functionCoverage.Name)
+		}
+	}
+	sort.Strings(missedFunctions)
+	return missedFunctions
+}
+
 // Percentage returns a string representation of the covered
 // statements percentage. It is defined as the ratio of covered
 // lines over the total statements for a given location.
@@ -123,6 +215,9 @@ type CoverageReport struct {
 	// Contains a mapping with source paths for each
 	// location.
 	locationMappings map[string]string
+	// Determines if the evaluations of pre/post conditions
+	// and the invocations of view functions are recorded.
+	conditionCoverageEnabled bool
 }
 
 // WithLocationFilter sets the LocationFilter for the current
@@ -141,6 +236,19 @@ func (r *CoverageReport) WithLocationMappings(
 	r.locationMappings = locationMappings
 }
 
+// WithConditionCoverage enables the recording of pre/post condition
+// evaluations and view function invocations, including their durations,
+// for the current CoverageReport.
+func (r *CoverageReport) WithConditionCoverage() {
+	r.conditionCoverageEnabled = true
+}
+
+// IsConditionCoverageEnabled checks whether the evaluations of pre/post
+// conditions and the invocations of view functions are recorded or not.
+func (r *CoverageReport) IsConditionCoverageEnabled() bool {
+	return r.conditionCoverageEnabled
+}
+
 // ExcludeLocation adds the given location to the map of excluded
 // locations.
 func (r *CoverageReport) ExcludeLocation(location Location) {
@@ -171,6 +279,46 @@ func (r *CoverageReport) AddLineHit(location Location, line int) {
 	locationCoverage.AddLineHit(line)
 }
 
+// AddConditionEvaluation records the evaluation of the condition on the given line,
+// on the given location. The method call is a NO-OP in the same cases as AddLineHit,
+// and if condition coverage is not enabled.
+func (r *CoverageReport) AddConditionEvaluation(
+	location Location,
+	line int,
+	kind ast.ConditionKind,
+	passed bool,
+	duration time.Duration,
+) {
+	if !r.conditionCoverageEnabled ||
+		r.IsLocationExcluded(location) ||
+		!r.IsLocationInspected(location) {
+
+		return
+	}
+
+	locationCoverage := r.Coverage[location]
+	locationCoverage.AddConditionEvaluation(line, kind, passed, duration)
+}
+
+// AddViewFunctionInvocation records the invocation of the view function with
+// the parameter list on the given line, on the given location. The method call
+// is a NO-OP in the same cases as AddLineHit, and if condition coverage is not enabled.
+func (r *CoverageReport) AddViewFunctionInvocation(
+	location Location,
+	line int,
+	duration time.Duration,
+) {
+	if !r.conditionCoverageEnabled ||
+		r.IsLocationExcluded(location) ||
+		!r.IsLocationInspected(location) {
+
+		return
+	}
+
+	locationCoverage := r.Coverage[location]
+	locationCoverage.AddViewFunctionInvocation(line, duration)
+}
+
 // InspectProgram inspects the elements of the given *ast.Program, and counts its
 // statements. If inspection is successful, the location is marked as inspected.
 // If the given location is excluded from coverage collection, the method call
@@ -190,6 +338,28 @@ func (r *CoverageReport) InspectProgram(location Location, program *ast.Program)
 		line := hasPosition.StartPosition().Line
 		lineHits[line] = 0
 	}
+
+	var conditions map[int]*ConditionCoverage
+	var viewFunctions map[int]*FunctionCoverage
+	if r.conditionCoverageEnabled {
+		conditions = map[int]*ConditionCoverage{}
+		viewFunctions = map[int]*FunctionCoverage{}
+	}
+	recordConditions := func(conditionList *ast.Conditions, kind ast.ConditionKind) {
+		if conditionList == nil {
+			return
+		}
+		for _, condition := range conditionList.Conditions {
+			recordLine(condition.CodeElement())
+
+			if conditions != nil {
+				line := condition.CodeElement().StartPosition().Line
+				conditions[line] = &ConditionCoverage{
+					Kind: kind.Keyword(),
+				}
+			}
+		}
+	}
 	var depth int
 
 	inspector := ast.NewInspector(program)
@@ -215,15 +385,20 @@ func (r *CoverageReport) InspectProgram(location Location, program *ast.Program)
 				functionBlock, isFunctionBlock := element.(*ast.FunctionBlock)
 				// Track also pre/post conditions defined inside functions.
 				if isFunctionBlock {
-					if functionBlock.PreConditions != nil {
-						for _, condition := range functionBlock.PreConditions.Conditions {
-							recordLine(condition.CodeElement())
-						}
-					}
-					if functionBlock.PostConditions != nil {
-						for _, condition := range functionBlock.PostConditions.Conditions {
-							recordLine(condition.CodeElement())
-						}
+					recordConditions(functionBlock.PreConditions, ast.ConditionKindPre)
+					recordConditions(functionBlock.PostConditions, ast.ConditionKindPost)
+				}
+
+				functionDeclaration, isFunctionDeclaration := element.(*ast.FunctionDeclaration)
+				// Track view functions, if condition coverage is enabled.
+				if isFunctionDeclaration &&
+					viewFunctions != nil &&
+					functionDeclaration.Purity == ast.FunctionPurityView &&
+					functionDeclaration.ParameterList != nil {
+
+					line := functionDeclaration.ParameterList.StartPos.Line
+					viewFunctions[line] = &FunctionCoverage{
+						Name: functionDeclaration.Identifier.Identifier,
 					}
 				}
 			} else {
@@ -233,7 +408,10 @@ func (r *CoverageReport) InspectProgram(location Location, program *ast.Program)
 			return true
 		})
 
-	r.Coverage[location] = NewLocationCoverage(lineHits)
+	locationCoverage := NewLocationCoverage(lineHits)
+	locationCoverage.Conditions = conditions
+	locationCoverage.ViewFunctions = viewFunctions
+	r.Coverage[location] = locationCoverage
 }
 
 // IsLocationInspected checks whether the given location,
@@ -420,10 +598,12 @@ func NewCoverageReport() *CoverageReport {
 // as fields in the LocationCoverage struct, we simply populate
 // this lcAlias struct, with the corresponding methods, upon marshalling.
 type lcAlias struct {
-	LineHits    map[int]int `json:"line_hits"`
-	MissedLines []int       `json:"missed_lines"`
-	Statements  int         `json:"statements"`
-	Percentage  string      `json:"percentage"`
+	LineHits      map[int]int                `json:"line_hits"`
+	MissedLines   []int                      `json:"missed_lines"`
+	Statements    int                        `json:"statements"`
+	Percentage    string                     `json:"percentage"`
+	Conditions    map[int]*ConditionCoverage `json:"conditions,omitempty"`
+	ViewFunctions map[int]*FunctionCoverage  `json:"view_functions,omitempty"`
 }
 
 // MarshalJSON serializes each common.Location/*LocationCoverage
@@ -434,10 +614,12 @@ func (r *CoverageReport) MarshalJSON() ([]byte, error) {
 	for location, locationCoverage := range r.Coverage { // nolint:maprange
 		locationSource := r.sourcePathForLocation(location)
 		coverage[locationSource] = lcAlias{
-			LineHits:    locationCoverage.LineHits,
-			MissedLines: locationCoverage.MissedLines(),
-			Statements:  locationCoverage.Statements,
-			Percentage:  locationCoverage.Percentage(),
+			LineHits:      locationCoverage.LineHits,
+			MissedLines:   locationCoverage.MissedLines(),
+			Statements:    locationCoverage.Statements,
+			Percentage:    locationCoverage.Percentage(),
+			Conditions:    locationCoverage.Conditions,
+			ViewFunctions: locationCoverage.ViewFunctions,
 		}
 	}
 	return json.Marshal(&struct {
@@ -471,8 +653,10 @@ func (r *CoverageReport) UnmarshalJSON(data []byte) error {
 			return fmt.Errorf("invalid Location ID: %s", locationID)
 		}
 		r.Coverage[location] = &LocationCoverage{
-			LineHits:   locationCoverage.LineHits,
-			Statements: locationCoverage.Statements,
+			LineHits:      locationCoverage.LineHits,
+			Statements:    locationCoverage.Statements,
+			Conditions:    locationCoverage.Conditions,
+			ViewFunctions: locationCoverage.ViewFunctions,
 		}
 		r.Locations[location] = struct{}{}
 	}
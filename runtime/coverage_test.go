@@ -1486,6 +1486,170 @@ func TestRuntimeCoverage(t *testing.T) {
 	)
 }
 
+func TestRuntimeConditionCoverage(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+	  access(all) view fun isPositive(_ n: Int): Bool {
+	    return n > 0
+	  }
+
+	  access(all) view fun isNegative(_ n: Int): Bool {
+	    return n < 0
+	  }
+
+	  access(all) fun double(_ n: Int): Int {
+	    pre {
+	      isPositive(n): "n must be positive"
+	    }
+	    post {
+	      result > n: "result must be greater than n"
+	    }
+	    return n * 2
+	  }
+
+	  access(all) fun main(): Int {
+	    return double(1) + double(2)
+	  }
+	`)
+
+	coverageReport := NewCoverageReport()
+	coverageReport.WithConditionCoverage()
+
+	config := DefaultTestInterpreterConfig
+	config.CoverageReport = coverageReport
+	runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+	location := common.ScriptLocation{}
+
+	value, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface:      &TestRuntimeInterface{},
+			Location:       location,
+			CoverageReport: coverageReport,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, cadence.NewInt(6), value)
+
+	locationCoverage := coverageReport.Coverage[location]
+	require.NotNil(t, locationCoverage)
+
+	require.Len(t, locationCoverage.Conditions, 2)
+
+	preCondition := locationCoverage.Conditions[12]
+	require.NotNil(t, preCondition)
+	assert.Equal(t, "pre", preCondition.Kind)
+	assert.Equal(t, 2, preCondition.Evaluations)
+	assert.Equal(t, 0, preCondition.Failures)
+
+	postCondition := locationCoverage.Conditions[15]
+	require.NotNil(t, postCondition)
+	assert.Equal(t, "post", postCondition.Kind)
+	assert.Equal(t, 2, postCondition.Evaluations)
+
+	assert.Empty(t, locationCoverage.MissedConditions())
+
+	require.Len(t, locationCoverage.ViewFunctions, 2)
+
+	isPositive := locationCoverage.ViewFunctions[2]
+	require.NotNil(t, isPositive)
+	assert.Equal(t, "isPositive", isPositive.Name)
+	assert.Equal(t, 2, isPositive.Invocations)
+
+	assert.Equal(t, []string{"isNegative"}, locationCoverage.MissedViewFunctions())
+}
+
+func TestRuntimeConditionCoverageUncalledFunctions(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+	  access(all) struct interface HasCount {
+	    access(all) fun count(): Int {
+	      pre {
+	        true: "default function precondition"
+	      }
+	      return 0
+	    }
+	  }
+
+	  access(all) struct Counter: HasCount {
+	    access(all) fun increment(_ n: Int): Int {
+	      pre {
+	        n > 0: "member function precondition"
+	      }
+	      return n + 1
+	    }
+	  }
+
+	  access(all) fun main(): Int {
+	    fun nested(_ n: Int): Int {
+	      post {
+	        result > 0: "nested function postcondition"
+	      }
+	      return n
+	    }
+
+	    let f = fun (_ n: Int): Int {
+	      pre {
+	        n > 0: "function expression precondition"
+	      }
+	      return n
+	    }
+
+	    return 1
+	  }
+	`)
+
+	coverageReport := NewCoverageReport()
+	coverageReport.WithConditionCoverage()
+
+	config := DefaultTestInterpreterConfig
+	config.CoverageReport = coverageReport
+	runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+	location := common.ScriptLocation{}
+
+	value, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface:      &TestRuntimeInterface{},
+			Location:       location,
+			CoverageReport: coverageReport,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, cadence.NewInt(1), value)
+
+	locationCoverage := coverageReport.Coverage[location]
+	require.NotNil(t, locationCoverage)
+
+	// The conditions of the interface default function, the member function,
+	// the nested function, and the function expression were never evaluated
+
+	assert.Equal(t,
+		[]int{5, 14, 23, 30},
+		locationCoverage.MissedConditions(),
+	)
+
+	defaultFunctionCondition := locationCoverage.Conditions[5]
+	require.NotNil(t, defaultFunctionCondition)
+	assert.Equal(t, "pre", defaultFunctionCondition.Kind)
+
+	nestedFunctionCondition := locationCoverage.Conditions[23]
+	require.NotNil(t, nestedFunctionCondition)
+	assert.Equal(t, "post", nestedFunctionCondition.Kind)
+}
+
 func TestRuntimeCoverageWithExcludedLocation(t *testing.T) {
 
 	t.Parallel()
@@ -0,0 +1,137 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	. "github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeDebugUtils(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      access(all) fun foo(): [String] {
+          return DebugUtils.callStack()
+      }
+
+      access(all) fun main(): [String] {
+          return foo()
+      }
+    `)
+
+	execute := func(enabled bool) (cadence.Value, error) {
+
+		config := DefaultTestInterpreterConfig
+		config.DebugUtilsEnabled = enabled
+
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage: NewTestLedger(nil, nil),
+		}
+
+		nextScriptLocation := NewScriptLocationGenerator()
+
+		return runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextScriptLocation(),
+			},
+		)
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		value, err := execute(true)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			cadence.NewArray([]cadence.Value{
+				cadence.String("foo (0100000000000000000000000000000000000000000000000000000000000000:7:17)"),
+			}).WithType(cadence.NewVariableSizedArrayType(cadence.StringType)),
+			value,
+		)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(false)
+		RequireError(t, err)
+
+		var notDeclaredErr *sema.NotDeclaredError
+		require.ErrorAs(t, err, &notDeclaredErr)
+	})
+}
+
+func TestRuntimeErrorCallStack(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := NewTestInterpreterRuntime()
+
+	runtimeInterface := &TestRuntimeInterface{
+		Storage: NewTestLedger(nil, nil),
+	}
+
+	nextScriptLocation := NewScriptLocationGenerator()
+
+	_, err := runtime.ExecuteScript(
+		Script{
+			Source: []byte(`
+              access(all) fun fail() {
+                  panic("test")
+              }
+
+              access(all) fun main() {
+                  fail()
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextScriptLocation(),
+		},
+	)
+	RequireError(t, err)
+
+	var runtimeErr Error
+	require.ErrorAs(t, err, &runtimeErr)
+
+	callStack := runtimeErr.CallStack()
+	require.Len(t, callStack, 1)
+	assert.Equal(t, "fail", callStack[0].FunctionName)
+	assert.Equal(t, 7, callStack[0].Position.Line)
+}
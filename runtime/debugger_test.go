@@ -28,6 +28,7 @@ import (
 	"github.com/onflow/cadence/common"
 	"github.com/onflow/cadence/interpreter"
 	. "github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/sema"
 	. "github.com/onflow/cadence/test_utils/runtime_utils"
 )
 
@@ -206,3 +207,246 @@ func TestRuntimeDebuggerBreakpoints(t *testing.T) {
 
 	require.True(t, logged)
 }
+
+func TestRuntimeDebuggerConditionalBreakpoints(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      transaction {
+          prepare(signer: &Account) {
+              var i = 0
+              while i < 5 {
+                  i = i + 1
+              }
+          }
+      }
+    `
+
+	runTransaction := func(debugger *interpreter.Debugger, location common.Location, wg *sync.WaitGroup) {
+		defer wg.Done()
+
+		config := DefaultTestInterpreterConfig
+		config.Debugger = debugger
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		address := common.MustBytesToAddress([]byte{0x1})
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage: NewTestLedger(nil, nil),
+			OnGetSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+		}
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(code),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  location,
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	t.Run("condition", func(t *testing.T) {
+
+		t.Parallel()
+
+		location := NewTransactionLocationGenerator()()
+
+		debugger := interpreter.NewDebugger()
+
+		err := debugger.AddConditionalBreakpoint(location, 6, "i == 3")
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		go runTransaction(debugger, location, &wg)
+
+		// Wait for the transaction to run into the breakpoint
+		stop := <-debugger.Stops()
+
+		require.NoError(t, stop.ConditionError)
+		require.IsType(t, &ast.AssignmentStatement{}, stop.Statement)
+
+		variable := debugger.CurrentActivation(stop.Interpreter).Find("i")
+		require.NotNil(t, variable)
+		require.Equal(
+			t,
+			interpreter.NewUnmeteredIntValueFromInt64(3),
+			variable.GetValue(stop.Interpreter),
+		)
+
+		debugger.Continue()
+
+		// Wait for the transaction to finish execution.
+		// The condition is not satisfied again, so there are no further stops
+		wg.Wait()
+	})
+
+	t.Run("invalid condition", func(t *testing.T) {
+
+		t.Parallel()
+
+		location := NewTransactionLocationGenerator()()
+
+		debugger := interpreter.NewDebugger()
+
+		err := debugger.AddConditionalBreakpoint(location, 6, `i == "3"`)
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		go runTransaction(debugger, location, &wg)
+
+		// The condition cannot be checked, so the breakpoint stops
+		// at every execution of the statement, and reports the error
+		for i := 0; i < 5; i++ {
+			stop := <-debugger.Stops()
+
+			var checkerErr *sema.CheckerError
+			require.ErrorAs(t, stop.ConditionError, &checkerErr)
+
+			debugger.Continue()
+		}
+
+		wg.Wait()
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+
+		t.Parallel()
+
+		location := NewTransactionLocationGenerator()()
+
+		debugger := interpreter.NewDebugger()
+
+		err := debugger.AddConditionalBreakpoint(location, 6, "i ==")
+		require.Error(t, err)
+	})
+}
+
+func TestRuntimeDebuggerWatchpoints(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	runTransaction := func(debugger *interpreter.Debugger, location common.Location, wg *sync.WaitGroup) {
+		defer wg.Done()
+
+		config := DefaultTestInterpreterConfig
+		config.Debugger = debugger
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage: NewTestLedger(nil, nil),
+			OnGetSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+		}
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: auth(Storage) &Account) {
+                          var x = 1
+                          x = 2
+                          signer.storage.save(x, to: /storage/x)
+                          let y = x + 1
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  location,
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	t.Run("variable", func(t *testing.T) {
+
+		t.Parallel()
+
+		location := NewTransactionLocationGenerator()()
+
+		debugger := interpreter.NewDebugger()
+		debugger.AddVariableWatchpoint(location, "x")
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		go runTransaction(debugger, location, &wg)
+
+		// Wait for the transaction to assign the variable.
+		// The declaration of the variable is not a write
+		stop := <-debugger.Stops()
+
+		require.Equal(
+			t,
+			interpreter.VariableWatchpoint{
+				Location: location,
+				Name:     "x",
+			},
+			stop.Watchpoint,
+		)
+		require.Equal(t, 5, stop.Statement.StartPosition().Line)
+
+		variable := debugger.CurrentActivation(stop.Interpreter).Find("x")
+		require.NotNil(t, variable)
+		require.Equal(
+			t,
+			interpreter.NewUnmeteredIntValueFromInt64(2),
+			variable.GetValue(stop.Interpreter),
+		)
+
+		debugger.Continue()
+
+		wg.Wait()
+	})
+
+	t.Run("storage path", func(t *testing.T) {
+
+		t.Parallel()
+
+		location := NewTransactionLocationGenerator()()
+
+		path := interpreter.PathValue{
+			Domain:     common.PathDomainStorage,
+			Identifier: "x",
+		}
+
+		debugger := interpreter.NewDebugger()
+		debugger.AddStoragePathWatchpoint(address, path)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		go runTransaction(debugger, location, &wg)
+
+		// Wait for the transaction to save the value
+		stop := <-debugger.Stops()
+
+		require.Equal(
+			t,
+			interpreter.StoragePathWatchpoint{
+				Address: address,
+				Path:    path,
+			},
+			stop.Watchpoint,
+		)
+		require.Equal(t, 6, stop.Statement.StartPosition().Line)
+
+		debugger.Continue()
+
+		wg.Wait()
+	})
+}
@@ -54,6 +54,10 @@ func (EmptyRuntimeInterface) GetAccountContractCode(_ common.AddressLocation) (c
 	panic("unexpected call to GetAccountContractCode")
 }
 
+func (EmptyRuntimeInterface) GetAccountContractUpdateHeight(_ common.AddressLocation) (height uint64, known bool, err error) {
+	panic("unexpected call to GetAccountContractUpdateHeight")
+}
+
 func (EmptyRuntimeInterface) MeterComputation(_ common.ComputationKind, _ uint) error {
 	// NO-OP
 	return nil
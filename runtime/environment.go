@@ -84,9 +84,12 @@ type Environment interface {
 // that gets reconfigured by interpreterEnvironment.Configure
 type interpreterEnvironmentReconfigured struct {
 	runtimeInterface Interface
-	storage          *Storage
-	coverageReport   *CoverageReport
-	codesAndPrograms CodesAndPrograms
+	// executionSuspender is the suspender wrapped around the runtime interface, if any,
+	// see Context.Suspension
+	executionSuspender *executionSuspender
+	storage            *Storage
+	coverageReport     *CoverageReport
+	codesAndPrograms   CodesAndPrograms
 }
 
 type interpreterEnvironment struct {
@@ -126,6 +129,7 @@ type interpreterEnvironment struct {
 	CheckerConfig                         *sema.Config
 	deployedContractConstructorInvocation *stdlib.DeployedContractConstructorInvocation
 	stackDepthLimiter                     *stackDepthLimiter
+	eventLimiter                          *eventLimiter
 	checkedImports                        importResolutionResults
 	compositeValueFunctionsHandlers       stdlib.CompositeValueFunctionsHandlers
 	config                                Config
@@ -134,6 +138,7 @@ type interpreterEnvironment struct {
 
 var _ Environment = &interpreterEnvironment{}
 var _ stdlib.Logger = &interpreterEnvironment{}
+var _ stdlib.ContractDeploymentValidatorProvider = &interpreterEnvironment{}
 var _ stdlib.RandomGenerator = &interpreterEnvironment{}
 var _ stdlib.BlockAtHeightProvider = &interpreterEnvironment{}
 var _ stdlib.CurrentBlockProvider = &interpreterEnvironment{}
@@ -150,6 +155,11 @@ var _ ArgumentDecoder = &interpreterEnvironment{}
 var _ common.MemoryGauge = &interpreterEnvironment{}
 
 func newInterpreterEnvironment(config Config) *interpreterEnvironment {
+	// Program transformations must never be used in production
+	if config.ProgramTransformer != nil && !config.TestingEnabled {
+		panic(errors.NewUnexpectedError("program transformer requires a testing environment"))
+	}
+
 	defaultBaseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
 	defaultBaseTypeActivation := sema.NewVariableActivation(sema.BaseTypeActivation)
 	defaultBaseActivation := activations.NewActivation(nil, interpreter.BaseActivation)
@@ -160,6 +170,7 @@ func newInterpreterEnvironment(config Config) *interpreterEnvironment {
 		defaultBaseTypeActivation:  defaultBaseTypeActivation,
 		defaultBaseActivation:      defaultBaseActivation,
 		stackDepthLimiter:          newStackDepthLimiter(config.StackDepthLimit),
+		eventLimiter:               newEventLimiter(config.EventCountLimit, config.EventSizeLimit),
 	}
 	env.InterpreterConfig = env.newInterpreterConfig()
 	env.CheckerConfig = env.newCheckerConfig()
@@ -182,6 +193,13 @@ func (e *interpreterEnvironment) newInterpreterConfig() *interpreter.Config {
 		CompositeTypeHandler:           e.newCompositeTypeHandler(),
 		CompositeValueFunctionsHandler: e.newCompositeValueFunctionsHandler(),
 		TracingEnabled:                 e.config.TracingEnabled,
+		ReferenceTracingEnabled:        e.config.ReferenceTracingEnabled,
+		SubtypeCheckCacheSize:          e.config.SubtypeCheckCacheSize,
+		StoredValueSizeLimit:           e.config.StoredValueSizeLimit,
+		BigIntegerBitLengthLimit:       e.config.BigIntegerBitLengthLimit,
+		CompositeValueCreationLimit:    e.config.CompositeValueCreationLimit,
+		ArrayValueCreationLimit:        e.config.ArrayValueCreationLimit,
+		DictionaryValueCreationLimit:   e.config.DictionaryValueCreationLimit,
 		AtreeValueValidationEnabled:    e.config.AtreeValidationEnabled,
 		// NOTE: ignore e.config.AtreeValidationEnabled here,
 		// and disable storage validation after each value modification.
@@ -189,13 +207,17 @@ func (e *interpreterEnvironment) newInterpreterConfig() *interpreter.Config {
 		// see interpreterEnvironment.CommitStorage
 		AtreeStorageValidationEnabled:             false,
 		Debugger:                                  e.config.Debugger,
+		Profiler:                                  e.config.Profiler,
 		OnStatement:                               e.newOnStatementHandler(),
+		OnConditionEvaluated:                      e.newOnConditionEvaluatedHandler(),
+		OnInterpretedFunctionInvoked:              e.newOnInterpretedFunctionInvokedHandler(),
 		OnMeterComputation:                        e.newOnMeterComputation(),
 		OnFunctionInvocation:                      e.newOnFunctionInvocationHandler(),
 		OnInvokedFunctionReturn:                   e.newOnInvokedFunctionReturnHandler(),
 		CapabilityBorrowHandler:                   e.newCapabilityBorrowHandler(),
 		CapabilityCheckHandler:                    e.newCapabilityCheckHandler(),
 		LegacyContractUpgradeEnabled:              e.config.LegacyContractUpgradeEnabled,
+		CopyOnWriteArgumentsEnabled:               e.config.CopyOnWriteArgumentsEnabled,
 		ValidateAccountCapabilitiesGetHandler:     e.newValidateAccountCapabilitiesGetHandler(),
 		ValidateAccountCapabilitiesPublishHandler: e.newValidateAccountCapabilitiesPublishHandler(),
 	}
@@ -210,6 +232,13 @@ func (e *interpreterEnvironment) newCheckerConfig() *sema.Config {
 		LocationHandler:                  e.ResolveLocation,
 		ImportHandler:                    e.resolveImport,
 		CheckHandler:                     e.newCheckHandler(),
+		IntegerLiteralBitLengthLimit:     e.config.IntegerLiteralBitLengthLimit,
+	}
+}
+
+func (e *interpreterEnvironment) parserConfig() parser.Config {
+	return parser.Config{
+		TokenPool: e.config.TokenPool,
 	}
 }
 
@@ -218,29 +247,66 @@ func NewBaseInterpreterEnvironment(config Config) *interpreterEnvironment {
 	for _, valueDeclaration := range stdlib.DefaultStandardLibraryValues(env) {
 		env.DeclareValue(valueDeclaration, nil)
 	}
+	env.declareDebugUtils()
+	env.declareReentrancyGuard()
+	env.declareCheckedArithmetic()
 	return env
 }
 
 func NewScriptInterpreterEnvironment(config Config) Environment {
 	env := newInterpreterEnvironment(config)
+	env.InterpreterConfig.ReadOnly = config.ReadOnlyScriptsEnabled
 	for _, valueDeclaration := range stdlib.DefaultScriptStandardLibraryValues(env) {
 		env.DeclareValue(valueDeclaration, nil)
 	}
+	env.declareDebugUtils()
+	env.declareReentrancyGuard()
+	env.declareCheckedArithmetic()
 	return env
 }
 
+func (e *interpreterEnvironment) declareDebugUtils() {
+	if !e.config.DebugUtilsEnabled {
+		return
+	}
+	e.DeclareValue(stdlib.DebugUtilsContract, nil)
+}
+
+func (e *interpreterEnvironment) declareReentrancyGuard() {
+	if !e.config.ReentrancyGuardEnabled {
+		return
+	}
+	e.DeclareValue(stdlib.NewWithReentrancyGuardFunction(), nil)
+}
+
+func (e *interpreterEnvironment) declareCheckedArithmetic() {
+	if !e.config.CheckedArithmeticEnabled {
+		return
+	}
+	for _, valueDeclaration := range stdlib.CheckedArithmeticFunctions {
+		e.DeclareValue(valueDeclaration, nil)
+	}
+}
+
 func (e *interpreterEnvironment) Configure(
 	runtimeInterface Interface,
 	codesAndPrograms CodesAndPrograms,
 	storage *Storage,
 	coverageReport *CoverageReport,
 ) {
+	// Shared slabs must never be modified
+	if storage != nil && storage.SharesSlabs() && !e.InterpreterConfig.ReadOnly {
+		panic(errors.NewUnexpectedError("storage which shares slabs requires a read-only environment"))
+	}
+
 	e.runtimeInterface = runtimeInterface
+	e.executionSuspender = findExecutionSuspender(runtimeInterface)
 	e.codesAndPrograms = codesAndPrograms
 	e.storage = storage
 	e.InterpreterConfig.Storage = storage
 	e.coverageReport = coverageReport
 	e.stackDepthLimiter.depth = 0
+	e.eventLimiter.reset()
 
 	e.configureVersionedFeatures()
 }
@@ -368,6 +434,16 @@ func (e *interpreterEnvironment) GetAccountContractCode(location common.AddressL
 	return e.runtimeInterface.GetAccountContractCode(location)
 }
 
+func (e *interpreterEnvironment) GetAccountContractUpdateHeight(
+	location common.AddressLocation,
+) (
+	height uint64,
+	known bool,
+	err error,
+) {
+	return e.runtimeInterface.GetAccountContractUpdateHeight(location)
+}
+
 func (e *interpreterEnvironment) CreateAccount(payer common.Address) (address common.Address, err error) {
 	return e.runtimeInterface.CreateAccount(payer)
 }
@@ -382,15 +458,42 @@ func (e *interpreterEnvironment) EmitEvent(
 	eventType *sema.CompositeType,
 	values []interpreter.Value,
 ) {
+	inter.CheckReadOnly(interpreter.ReadOnlyOperationEventEmission, locationRange)
+
 	EmitEventFields(
 		inter,
 		locationRange,
 		eventType,
 		values,
-		e.runtimeInterface.EmitEvent,
+		e.emitEvent,
 	)
 }
 
+func (e *interpreterEnvironment) emitEvent(event cadence.Event) error {
+	err := e.eventLimiter.onEventEmitting()
+	if err != nil {
+		return err
+	}
+
+	// The runtime interface reports the size of the encoded event,
+	// so the size limit is enforced before the event is emitted,
+	// without encoding the event again
+
+	if e.eventLimiter.sizeLimit > 0 {
+		size, err := eventSize(e.runtimeInterface, event)
+		if err != nil {
+			return err
+		}
+
+		err = e.eventLimiter.onEventEncoded(size)
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.runtimeInterface.EmitEvent(event)
+}
+
 func (e *interpreterEnvironment) AddAccountKey(
 	address common.Address,
 	key *stdlib.PublicKey,
@@ -444,6 +547,20 @@ func (e *interpreterEnvironment) IsContractBeingAdded(location common.AddressLoc
 	return contains
 }
 
+func (e *interpreterEnvironment) ContractDeploymentValidators() []stdlib.ContractDeploymentValidator {
+	validators := make([]stdlib.ContractDeploymentValidator, 0, len(e.config.ContractDeploymentValidators)+1)
+	if e.config.ContractCodeSizeLimit > 0 {
+		validators = append(validators, stdlib.ContractCodeSizeValidator{
+			Limit: e.config.ContractCodeSizeLimit,
+		})
+	}
+	return append(validators, e.config.ContractDeploymentValidators...)
+}
+
+func (e *interpreterEnvironment) ContractMetadataEventsEnabled() bool {
+	return e.config.ContractMetadataEventsEnabled
+}
+
 func (e *interpreterEnvironment) TemporarilyRecordCode(location common.AddressLocation, code []byte) {
 	e.codesAndPrograms.setCode(location, code)
 }
@@ -462,6 +579,7 @@ func (e *interpreterEnvironment) ParseAndCheckProgram(
 			return code, nil
 		},
 		getAndSetProgram,
+		false,
 		importResolutionResults{
 			// Current program is already in check.
 			// So mark it also as 'already seen'.
@@ -480,6 +598,7 @@ func (e *interpreterEnvironment) ParseAndCheckProgram(
 func (e *interpreterEnvironment) parseAndCheckProgramWithRecovery(
 	code []byte,
 	location common.Location,
+	transform bool,
 	checkedImports importResolutionResults,
 ) (
 	program *ast.Program,
@@ -490,6 +609,7 @@ func (e *interpreterEnvironment) parseAndCheckProgramWithRecovery(
 	program, elaboration, err = e.parseAndCheckProgram(
 		code,
 		location,
+		transform,
 		checkedImports,
 	)
 	if err == nil {
@@ -516,9 +636,11 @@ func (e *interpreterEnvironment) parseAndCheckProgramWithRecovery(
 }
 
 // parseAndCheckProgram parses and checks the given program.
+// If transform is true, the program is transformed before checking, see Config.ProgramTransformer
 func (e *interpreterEnvironment) parseAndCheckProgram(
 	code []byte,
 	location common.Location,
+	transform bool,
 	checkedImports importResolutionResults,
 ) (
 	program *ast.Program,
@@ -540,19 +662,37 @@ func (e *interpreterEnvironment) parseAndCheckProgram(
 
 	// Parse
 
-	reportMetric(
+	e.traceSpan(
+		TracerSpanParse,
+		location,
 		func() {
-			program, err = parser.ParseProgram(e, code, parser.Config{})
-		},
-		e.runtimeInterface,
-		func(metrics Metrics, duration time.Duration) {
-			metrics.ProgramParsed(location, duration)
+			reportMetric(
+				func() {
+					program, err = parser.ParseProgram(e, code, e.parserConfig())
+				},
+				e.runtimeInterface,
+				func(metrics Metrics, duration time.Duration) {
+					metrics.ProgramParsed(location, duration)
+				},
+			)
 		},
 	)
 	if err != nil {
 		return nil, nil, wrapParsingCheckingError(err)
 	}
 
+	// Transform
+
+	programTransformer := e.config.ProgramTransformer
+	if transform && programTransformer != nil {
+		errors.WrapPanic(func() {
+			program, err = programTransformer(program, location)
+		})
+		if err != nil {
+			return nil, nil, interpreter.WrappedExternalError(err)
+		}
+	}
+
 	// Check
 
 	elaboration, err = e.check(location, program, checkedImports)
@@ -601,7 +741,7 @@ func (e *interpreterEnvironment) recoverProgram(
 
 	// Parse and check the recovered program
 
-	program, err = parser.ParseProgram(e, newCode, parser.Config{})
+	program, err = parser.ParseProgram(e, newCode, e.parserConfig())
 	if err != nil {
 		return nil, nil
 	}
@@ -664,11 +804,17 @@ func (e *interpreterEnvironment) ResolveLocation(
 
 func (e *interpreterEnvironment) newCheckHandler() sema.CheckHandlerFunc {
 	return func(checker *sema.Checker, check func()) {
-		reportMetric(
-			check,
-			e.runtimeInterface,
-			func(metrics Metrics, duration time.Duration) {
-				metrics.ProgramChecked(checker.Location, duration)
+		e.traceSpan(
+			TracerSpanCheck,
+			checker.Location,
+			func() {
+				reportMetric(
+					check,
+					e.runtimeInterface,
+					func(metrics Metrics, duration time.Duration) {
+						metrics.ProgramChecked(checker.Location, duration)
+					},
+				)
 			},
 		)
 	}
@@ -720,16 +866,19 @@ func (e *interpreterEnvironment) GetProgram(
 			return e.getCode(location)
 		},
 		storeProgram,
+		true,
 		checkedImports,
 	)
 }
 
 // getProgram returns the existing program at the given location, if available.
 // If it is not available, it loads the code, and then parses and checks it.
+// If isImport is true, the program is transformed before checking, see Config.ProgramTransformer
 func (e *interpreterEnvironment) getProgram(
 	location Location,
 	getCode func() ([]byte, error),
 	getAndSetProgram bool,
+	isImport bool,
 	checkedImports importResolutionResults,
 ) (
 	program *interpreter.Program,
@@ -746,6 +895,7 @@ func (e *interpreterEnvironment) getProgram(
 		parsedProgram, elaboration, err := e.parseAndCheckProgramWithRecovery(
 			code,
 			location,
+			isImport,
 			checkedImports,
 		)
 		if parsedProgram != nil {
@@ -840,19 +990,82 @@ func (e *interpreterEnvironment) newInterpreter(
 }
 
 func (e *interpreterEnvironment) newOnStatementHandler() interpreter.OnStatementFunc {
-	if e.config.CoverageReport == nil {
+	coverageEnabled := e.config.CoverageReport != nil
+	suspensionEnabled := e.config.ExecutionSuspensionEnabled
+
+	if !coverageEnabled && !suspensionEnabled {
 		return nil
 	}
 
 	return func(inter *interpreter.Interpreter, statement ast.Statement) {
-		location := inter.Location
-		if !e.coverageReport.IsLocationInspected(location) {
-			program := inter.Program.Program
-			e.coverageReport.InspectProgram(location, program)
+		if coverageEnabled {
+			e.inspectCoverageLocation(inter)
+
+			line := statement.StartPosition().Line
+			e.coverageReport.AddLineHit(inter.Location, line)
+		}
+
+		if suspensionEnabled {
+			// The runtime interface is wrapped in a suspender
+			// if the execution may be suspended or is resumed, see Context.Suspension
+			if e.executionSuspender != nil {
+				e.executionSuspender.onStatement(inter, statement)
+			}
+		}
+	}
+}
+
+func (e *interpreterEnvironment) inspectCoverageLocation(inter *interpreter.Interpreter) {
+	location := inter.Location
+	if !e.coverageReport.IsLocationInspected(location) {
+		program := inter.Program.Program
+		e.coverageReport.InspectProgram(location, program)
+	}
+}
+
+func (e *interpreterEnvironment) newOnConditionEvaluatedHandler() interpreter.OnConditionEvaluatedFunc {
+	if e.config.CoverageReport == nil ||
+		!e.config.CoverageReport.IsConditionCoverageEnabled() {
+
+		return nil
+	}
+
+	return func(
+		inter *interpreter.Interpreter,
+		condition ast.Condition,
+		kind ast.ConditionKind,
+		passed bool,
+		duration time.Duration,
+	) {
+		e.inspectCoverageLocation(inter)
+
+		line := condition.CodeElement().StartPosition().Line
+		e.coverageReport.AddConditionEvaluation(inter.Location, line, kind, passed, duration)
+	}
+}
+
+func (e *interpreterEnvironment) newOnInterpretedFunctionInvokedHandler() interpreter.OnInterpretedFunctionInvokedFunc {
+	if e.config.CoverageReport == nil ||
+		!e.config.CoverageReport.IsConditionCoverageEnabled() {
+
+		return nil
+	}
+
+	return func(
+		inter *interpreter.Interpreter,
+		function *interpreter.InterpretedFunctionValue,
+		duration time.Duration,
+	) {
+		if function.Type.Purity != sema.FunctionPurityView ||
+			function.ParameterList == nil {
+
+			return
 		}
 
-		line := statement.StartPosition().Line
-		e.coverageReport.AddLineHit(location, line)
+		e.inspectCoverageLocation(inter)
+
+		line := function.ParameterList.StartPos.Line
+		e.coverageReport.AddViewFunctionInvocation(inter.Location, line, duration)
 	}
 }
 
@@ -986,7 +1199,7 @@ func (e *interpreterEnvironment) newOnEventEmittedHandler() interpreter.OnEventE
 			locationRange,
 			eventType,
 			eventValue,
-			e.runtimeInterface.EmitEvent,
+			e.emitEvent,
 		)
 
 		return nil
@@ -1199,17 +1412,23 @@ func (e *interpreterEnvironment) Interpret(
 
 	var result interpreter.Value
 
-	reportMetric(
+	e.traceSpan(
+		TracerSpanInterpret,
+		location,
 		func() {
-			err = inter.Interpret()
-			if err != nil || f == nil {
-				return
-			}
-			result, err = f(inter)
-		},
-		e.runtimeInterface,
-		func(metrics Metrics, duration time.Duration) {
-			metrics.ProgramInterpreted(location, duration)
+			reportMetric(
+				func() {
+					err = inter.Interpret()
+					if err != nil || f == nil {
+						return
+					}
+					result, err = f(inter)
+				},
+				e.runtimeInterface,
+				func(metrics Metrics, duration time.Duration) {
+					metrics.ProgramInterpreted(location, duration)
+				},
+			)
 		},
 	)
 	if err != nil {
@@ -1241,7 +1460,18 @@ func (e *interpreterEnvironment) newResourceOwnerChangedHandler() interpreter.On
 	}
 }
 
-func (e *interpreterEnvironment) CommitStorage(inter *interpreter.Interpreter) error {
+func (e *interpreterEnvironment) CommitStorage(inter *interpreter.Interpreter) (err error) {
+	e.traceSpan(
+		TracerSpanCommitStorage,
+		inter.Location,
+		func() {
+			err = e.commitStorage(inter)
+		},
+	)
+	return
+}
+
+func (e *interpreterEnvironment) commitStorage(inter *interpreter.Interpreter) error {
 	const commitContractUpdates = true
 	err := e.storage.Commit(inter, commitContractUpdates)
 	if err != nil {
@@ -1258,6 +1488,23 @@ func (e *interpreterEnvironment) CommitStorage(inter *interpreter.Interpreter) e
 	return nil
 }
 
+// traceSpan calls the given function in a span, if a tracer is configured
+func (e *interpreterEnvironment) traceSpan(
+	name string,
+	location common.Location,
+	f func(),
+	attrs ...attribute.KeyValue,
+) {
+	traceSpan(
+		e.config.Tracer,
+		e.runtimeInterface,
+		name,
+		location,
+		f,
+		attrs...,
+	)
+}
+
 // getBaseValueActivation returns the base activation for the given location.
 // If a value was declared for the location (using DeclareValue),
 // then the specific base value activation for this location is returned.
@@ -19,6 +19,7 @@
 package runtime
 
 import (
+	goerrors "errors"
 	"fmt"
 	"strings"
 
@@ -35,6 +36,12 @@ type Error struct {
 	Location Location
 	Codes    map[Location][]byte
 	Programs map[Location]*ast.Program
+	// Phase is the phase of the execution in which the error occurred.
+	// It is only set for errors of script and transaction executions
+	Phase ExecutionPhase
+	// PartialResult contains the artifacts produced before the error occurred.
+	// It is only set for errors of script and transaction executions
+	PartialResult PartialResult
 }
 
 func newError(err error, location Location, codesAndPrograms CodesAndPrograms) Error {
@@ -50,6 +57,16 @@ func (e Error) Unwrap() error {
 	return e.Err
 }
 
+// CallStack returns the frames of the Cadence call stack at the point the error occurred,
+// from the outermost to the innermost invocation, if the error occurred during interpretation
+func (e Error) CallStack() []interpreter.CallStackFrame {
+	var interpreterErr interpreter.Error
+	if !goerrors.As(e.Err, &interpreterErr) {
+		return nil
+	}
+	return interpreterErr.CallStack()
+}
+
 func (e Error) Error() string {
 	var sb strings.Builder
 	sb.WriteString("Execution failed:\n")
@@ -78,6 +95,118 @@ func (e CallStackLimitExceededError) Error() string {
 	)
 }
 
+// StorageWriteCountLimitExceededError
+
+type StorageWriteCountLimitExceededError struct {
+	Limit uint64
+}
+
+var _ errors.UserError = StorageWriteCountLimitExceededError{}
+
+func (StorageWriteCountLimitExceededError) IsUserError() {}
+
+func (e StorageWriteCountLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"storage write count limit exceeded: %d",
+		e.Limit,
+	)
+}
+
+// StorageWriteSizeLimitExceededError
+
+type StorageWriteSizeLimitExceededError struct {
+	Limit uint64
+	Size  uint64
+}
+
+var _ errors.UserError = StorageWriteSizeLimitExceededError{}
+
+func (StorageWriteSizeLimitExceededError) IsUserError() {}
+
+func (e StorageWriteSizeLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"storage write size limit exceeded: %d bytes, limit is %d bytes",
+		e.Size,
+		e.Limit,
+	)
+}
+
+// AccountStorageWriteCountLimitExceededError
+
+type AccountStorageWriteCountLimitExceededError struct {
+	Address Address
+	Limit   uint64
+}
+
+var _ errors.UserError = AccountStorageWriteCountLimitExceededError{}
+
+func (AccountStorageWriteCountLimitExceededError) IsUserError() {}
+
+func (e AccountStorageWriteCountLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"storage write count limit of account %s exceeded: %d",
+		e.Address,
+		e.Limit,
+	)
+}
+
+// AccountStorageWriteSizeLimitExceededError
+
+type AccountStorageWriteSizeLimitExceededError struct {
+	Address Address
+	Limit   uint64
+	Size    uint64
+}
+
+var _ errors.UserError = AccountStorageWriteSizeLimitExceededError{}
+
+func (AccountStorageWriteSizeLimitExceededError) IsUserError() {}
+
+func (e AccountStorageWriteSizeLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"storage write size limit of account %s exceeded: %d bytes, limit is %d bytes",
+		e.Address,
+		e.Size,
+		e.Limit,
+	)
+}
+
+// EventCountLimitExceededError
+
+type EventCountLimitExceededError struct {
+	Limit uint64
+}
+
+var _ errors.UserError = EventCountLimitExceededError{}
+
+func (EventCountLimitExceededError) IsUserError() {}
+
+func (e EventCountLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"event count limit exceeded: %d",
+		e.Limit,
+	)
+}
+
+// EventSizeLimitExceededError
+
+type EventSizeLimitExceededError struct {
+	Limit uint64
+	Size  uint64
+}
+
+var _ errors.UserError = EventSizeLimitExceededError{}
+
+func (EventSizeLimitExceededError) IsUserError() {}
+
+func (e EventSizeLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"event size limit exceeded: %d bytes, limit is %d bytes",
+		e.Size,
+		e.Limit,
+	)
+}
+
 // InvalidTransactionCountError
 
 type InvalidTransactionCountError struct {
@@ -312,3 +441,22 @@ func (e *ParsingCheckingError) Unwrap() error {
 func (e *ParsingCheckingError) ImportLocation() Location {
 	return e.Location
 }
+
+// ExecutionTraceDivergenceError is reported when a replayed execution
+// performs an interaction which was not recorded in the execution trace
+type ExecutionTraceDivergenceError struct {
+	Operation string
+	Input     string
+}
+
+var _ errors.InternalError = ExecutionTraceDivergenceError{}
+
+func (ExecutionTraceDivergenceError) IsInternalError() {}
+
+func (e ExecutionTraceDivergenceError) Error() string {
+	return fmt.Sprintf(
+		"execution diverged from trace: no recorded %s with input %s",
+		e.Operation,
+		e.Input,
+	)
+}
@@ -19,6 +19,7 @@
 package runtime_test
 
 import (
+	goErrors "errors"
 	"fmt"
 	"go/types"
 	"reflect"
@@ -29,9 +30,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
 	"github.com/onflow/cadence/interpreter"
 	"github.com/onflow/cadence/parser"
 	"github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
 )
 
 // TestErrorInterfaceConformance checks whether all the error structs implement
@@ -145,3 +149,105 @@ func TestErrorInterfaceConformance(t *testing.T) {
 		}
 	}
 }
+
+func TestRuntimeErrorClassification(t *testing.T) {
+
+	t.Parallel()
+
+	execute := func(script string, onLog func(string)) error {
+		rt := NewTestInterpreterRuntime()
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage:      NewTestLedger(nil, nil),
+			OnProgramLog: onLog,
+		}
+
+		_, err := rt.ExecuteScript(
+			runtime.Script{
+				Source: []byte(script),
+			},
+			runtime.Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		return err
+	}
+
+	const logScript = `
+      access(all) fun main() {
+          log("test")
+      }
+    `
+
+	type logPanic struct{}
+
+	for name, test := range map[string]struct {
+		script   string
+		onLog    func(string)
+		expected errors.ErrorKind
+	}{
+		"checking error": {
+			script:   `access(all) fun main(): Int { return true }`,
+			expected: errors.ErrorKindUser,
+		},
+		"panic": {
+			script:   `access(all) fun main() { panic("test") }`,
+			expected: errors.ErrorKindUser,
+		},
+		"external error": {
+			script: logScript,
+			onLog: func(_ string) {
+				panic(goErrors.New("test"))
+			},
+			expected: errors.ErrorKindExternal,
+		},
+		// Non-error panics of the host are considered implementation errors
+		"external non-error": {
+			script: logScript,
+			onLog: func(_ string) {
+				panic(logPanic{})
+			},
+			expected: errors.ErrorKindInternal,
+		},
+		"internal error": {
+			script: logScript,
+			onLog: func(_ string) {
+				panic(errors.NewUnexpectedError("test"))
+			},
+			expected: errors.ErrorKindInternal,
+		},
+	} {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			err := execute(test.script, test.onLog)
+			require.Error(t, err)
+
+			assert.Equal(t, test.expected, errors.Classify(err))
+		})
+	}
+
+	t.Run("internal error takes precedence", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := errors.DefaultUserError{
+			Err: errors.NewUnexpectedError("test"),
+		}
+
+		assert.True(t, errors.IsUserError(err))
+		assert.True(t, errors.IsInternalError(err))
+		assert.Equal(t, errors.ErrorKindInternal, errors.Classify(err))
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Equal(t, errors.ErrorKindUnknown, errors.Classify(goErrors.New("test")))
+	})
+}
@@ -0,0 +1,84 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import "math"
+
+// eventLimiter enforces the limits on the events emitted in a transaction or script,
+// see Config.EventCountLimit and Config.EventSizeLimit
+type eventLimiter struct {
+	count      uint64
+	size       uint64
+	countLimit uint64
+	sizeLimit  uint64
+}
+
+func newEventLimiter(countLimit uint64, sizeLimit uint64) *eventLimiter {
+	return &eventLimiter{
+		countLimit: countLimit,
+		sizeLimit:  sizeLimit,
+	}
+}
+
+func (limiter *eventLimiter) reset() {
+	limiter.count = 0
+	limiter.size = 0
+}
+
+// onEventEmitting accounts for an event before it is emitted,
+// and returns an error if emitting it would exceed the count limit
+func (limiter *eventLimiter) onEventEmitting() error {
+	if limiter.countLimit == 0 {
+		return nil
+	}
+
+	count := limiter.count + 1
+	if count > limiter.countLimit {
+		return EventCountLimitExceededError{
+			Limit: limiter.countLimit,
+		}
+	}
+	limiter.count = count
+
+	return nil
+}
+
+// onEventEncoded accounts for the given size of an encoded event before it is emitted,
+// and returns an error if emitting it would exceed the size limit
+func (limiter *eventLimiter) onEventEncoded(size uint64) error {
+	if limiter.sizeLimit == 0 {
+		return nil
+	}
+
+	// The size limit is never exceeded, so the remaining size does not underflow,
+	// and comparing against it does not overflow
+	if size > limiter.sizeLimit-limiter.size {
+		total := limiter.size + size
+		if total < size {
+			total = math.MaxUint64
+		}
+		return EventSizeLimitExceededError{
+			Limit: limiter.sizeLimit,
+			Size:  total,
+		}
+	}
+	limiter.size += size
+
+	return nil
+}
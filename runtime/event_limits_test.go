@@ -0,0 +1,265 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/encoding/ccf"
+	"github.com/onflow/cadence/errors"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeEventLimits(t *testing.T) {
+
+	t.Parallel()
+
+	const contract = `
+      access(all) contract Test {
+
+          access(all) event E(value: String)
+
+          access(all) fun emitEvents(count: Int, value: String) {
+              var i = 0
+              while i < count {
+                  emit E(value: value)
+                  i = i + 1
+              }
+          }
+      }
+    `
+
+	newTransaction := func(count int, value string) []byte {
+		return []byte(fmt.Sprintf(
+			`
+              import Test from 0x1
+
+              transaction {
+                  prepare(signer: &Account) {
+                      Test.emitEvents(count: %d, value: "%s")
+                  }
+              }
+            `,
+			count,
+			value,
+		))
+	}
+
+	type execution struct {
+		execute          func(transaction []byte) error
+		events           *[]cadence.Event
+		runtimeInterface *TestRuntimeInterface
+	}
+
+	newExecution := func(t *testing.T, config Config, reportSizes bool) execution {
+
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		var events []cadence.Event
+		accountCodes := map[common.Location][]byte{}
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage: NewTestLedger(nil, nil),
+			OnGetSigningAccounts: func() ([]Address, error) {
+				return []Address{common.MustBytesToAddress([]byte{0x1})}, nil
+			},
+			OnResolveLocation: NewSingleIdentifierLocationResolver(t),
+			OnUpdateAccountContractCode: func(location common.AddressLocation, code []byte) error {
+				accountCodes[location] = code
+				return nil
+			},
+			OnGetAccountContractCode: func(location common.AddressLocation) (code []byte, err error) {
+				return accountCodes[location], nil
+			},
+			OnEmitEvent: func(event cadence.Event) error {
+				events = append(events, event)
+				return nil
+			},
+		}
+
+		runtimeInterface.OnEventSize = func(event cadence.Event) (uint64, error) {
+			encoded, err := ccf.EventsEncMode.Encode(event)
+			if err != nil {
+				return 0, err
+			}
+			return uint64(len(encoded)), nil
+		}
+
+		var contextInterface Interface = runtimeInterface
+
+		nextTransactionLocation := NewTransactionLocationGenerator()
+
+		execute := func(transaction []byte) error {
+			// NOTE: TestInterpreterRuntime only supports TestRuntimeInterface,
+			// so invalidate the updated programs like it does, and use the runtime directly
+			runtimeInterface.InvalidateUpdatedPrograms()
+
+			return runtime.Runtime.ExecuteTransaction(
+				Script{
+					Source: transaction,
+				},
+				Context{
+					Interface: contextInterface,
+					Location:  nextTransactionLocation(),
+				},
+			)
+		}
+
+		err := execute(DeploymentTransaction("Test", []byte(contract)))
+		require.NoError(t, err)
+
+		// The deployment emits an event, so only stop reporting sizes after it
+
+		if !reportSizes {
+			contextInterface = unsizedEventRuntimeInterface{
+				Interface: runtimeInterface,
+			}
+		}
+
+		events = nil
+
+		return execution{
+			execute:          execute,
+			events:           &events,
+			runtimeInterface: runtimeInterface,
+		}
+	}
+
+	t.Run("count", func(t *testing.T) {
+
+		t.Parallel()
+
+		config := DefaultTestInterpreterConfig
+		config.EventCountLimit = 2
+
+		execution := newExecution(t, config, false)
+
+		// The limit applies per transaction
+
+		err := execution.execute(newTransaction(2, "a"))
+		require.NoError(t, err)
+
+		err = execution.execute(newTransaction(2, "b"))
+		require.NoError(t, err)
+
+		assert.Len(t, *execution.events, 4)
+
+		err = execution.execute(newTransaction(3, "c"))
+		RequireError(t, err)
+
+		var eventCountLimitExceededErr EventCountLimitExceededError
+		require.ErrorAs(t, err, &eventCountLimitExceededErr)
+		assert.Equal(t, uint64(2), eventCountLimitExceededErr.Limit)
+
+		// The event exceeding the limit was not emitted
+		assert.Len(t, *execution.events, 6)
+	})
+
+	t.Run("size", func(t *testing.T) {
+
+		t.Parallel()
+
+		config := DefaultTestInterpreterConfig
+		config.EventSizeLimit = 1000
+
+		execution := newExecution(t, config, true)
+
+		err := execution.execute(newTransaction(2, "a"))
+		require.NoError(t, err)
+
+		assert.Len(t, *execution.events, 2)
+
+		err = execution.execute(newTransaction(1, strings.Repeat("a", 1000)))
+		RequireError(t, err)
+
+		var eventSizeLimitExceededErr EventSizeLimitExceededError
+		require.ErrorAs(t, err, &eventSizeLimitExceededErr)
+		assert.Equal(t, uint64(1000), eventSizeLimitExceededErr.Limit)
+		assert.Greater(t, eventSizeLimitExceededErr.Size, uint64(1000))
+
+		// The event exceeding the limit was not emitted
+		assert.Len(t, *execution.events, 2)
+	})
+
+	t.Run("size, overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		config := DefaultTestInterpreterConfig
+		config.EventSizeLimit = 1000
+
+		execution := newExecution(t, config, true)
+
+		var reported bool
+		execution.runtimeInterface.OnEventSize = func(event cadence.Event) (uint64, error) {
+			if reported {
+				return math.MaxUint64, nil
+			}
+			reported = true
+			return 1, nil
+		}
+
+		err := execution.execute(newTransaction(2, "a"))
+		RequireError(t, err)
+
+		var eventSizeLimitExceededErr EventSizeLimitExceededError
+		require.ErrorAs(t, err, &eventSizeLimitExceededErr)
+
+		assert.Equal(t, uint64(math.MaxUint64), eventSizeLimitExceededErr.Size)
+
+		assert.Len(t, *execution.events, 1)
+	})
+
+	t.Run("size, not reported", func(t *testing.T) {
+
+		t.Parallel()
+
+		config := DefaultTestInterpreterConfig
+		config.EventSizeLimit = 1000
+
+		execution := newExecution(t, config, false)
+
+		// The execution fails before the event is emitted,
+		// if the runtime interface does not report the sizes of events
+
+		err := execution.execute(newTransaction(1, "a"))
+		RequireError(t, err)
+
+		var unexpectedErr errors.UnexpectedError
+		require.ErrorAs(t, err, &unexpectedErr)
+
+		assert.Empty(t, *execution.events)
+	})
+}
+
+// unsizedEventRuntimeInterface is a runtime interface which does not report
+// the sizes of the emitted events, i.e. does not implement EventSizer
+type unsizedEventRuntimeInterface struct {
+	Interface
+}
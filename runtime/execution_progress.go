@@ -0,0 +1,117 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+)
+
+// ExecutionProgress allows the host to observe the progress of the execution of a script or transaction,
+// e.g. to implement soft limits, logging, or adaptive throttling of long-running scripts.
+type ExecutionProgress struct {
+	// Interval is the amount of metered computation, i.e. the sum of the intensities
+	// passed to Interface.MeterComputation, after which OnProgress is called again.
+	// When 0, OnProgress is called after each metered computation
+	Interval uint64
+	// OnProgress is called with the computation used so far (see Interface.ComputationUsed),
+	// each time another interval of computation was metered.
+	// If it returns an error, the execution is aborted with the error
+	OnProgress func(computationUsed uint64) error
+}
+
+// progressReporter is a runtime interface which reports the progress of the execution,
+// see ExecutionProgress.
+//
+// The metered computation is tallied locally, so the computation used
+// is only requested from the wrapped runtime interface when the progress is reported
+type progressReporter struct {
+	Interface
+	progress *ExecutionProgress
+	// metered is the sum of the intensities metered so far
+	metered uint64
+	// next is the metered computation at which the progress is reported next
+	next uint64
+}
+
+var _ Interface = &progressReporter{}
+var _ Metrics = &progressReporter{}
+var _ EventSizer = &progressReporter{}
+
+func newProgressReporter(progress *ExecutionProgress, runtimeInterface Interface) *progressReporter {
+	return &progressReporter{
+		Interface: runtimeInterface,
+		progress:  progress,
+		next:      progress.Interval,
+	}
+}
+
+func (r *progressReporter) unwrap() Interface {
+	return r.Interface
+}
+
+func (r *progressReporter) MeterComputation(operationType common.ComputationKind, intensity uint) error {
+	err := r.Interface.MeterComputation(operationType, intensity)
+	if err != nil {
+		return err
+	}
+
+	r.metered += uint64(intensity)
+	if r.metered < r.next {
+		return nil
+	}
+
+	interval := r.progress.Interval
+	if interval == 0 {
+		r.next = r.metered + 1
+	} else {
+		r.next = (r.metered/interval + 1) * interval
+	}
+
+	used, err := r.Interface.ComputationUsed()
+	if err != nil {
+		return err
+	}
+
+	return r.progress.OnProgress(used)
+}
+
+func (r *progressReporter) ProgramParsed(location Location, duration time.Duration) {
+	if metrics, ok := r.Interface.(Metrics); ok {
+		metrics.ProgramParsed(location, duration)
+	}
+}
+
+func (r *progressReporter) ProgramChecked(location Location, duration time.Duration) {
+	if metrics, ok := r.Interface.(Metrics); ok {
+		metrics.ProgramChecked(location, duration)
+	}
+}
+
+func (r *progressReporter) ProgramInterpreted(location Location, duration time.Duration) {
+	if metrics, ok := r.Interface.(Metrics); ok {
+		metrics.ProgramInterpreted(location, duration)
+	}
+}
+
+func (r *progressReporter) EventSize(event cadence.Event) (uint64, error) {
+	return eventSize(r.Interface, event)
+}
@@ -0,0 +1,185 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeExecutionProgress(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      access(all) fun main() {
+          var i = 0
+          while i < 100 {
+              i = i + 1
+          }
+      }
+    `)
+
+	execute := func(progress *ExecutionProgress) (uint64, error) {
+
+		runtime := NewTestInterpreterRuntime()
+
+		var computationUsed uint64
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage: NewTestLedger(nil, nil),
+			OnMeterComputation: func(_ common.ComputationKind, intensity uint) error {
+				computationUsed += uint64(intensity)
+				return nil
+			},
+			OnComputationUsed: func() (uint64, error) {
+				return computationUsed, nil
+			},
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+				Progress:  progress,
+			},
+		)
+		return computationUsed, err
+	}
+
+	t.Run("interval", func(t *testing.T) {
+
+		t.Parallel()
+
+		const interval = 10
+
+		var reported []uint64
+
+		computationUsed, err := execute(&ExecutionProgress{
+			Interval: interval,
+			OnProgress: func(computationUsed uint64) error {
+				reported = append(reported, computationUsed)
+				return nil
+			},
+		})
+		require.NoError(t, err)
+
+		require.NotEmpty(t, reported)
+		assert.Equal(t, int(computationUsed/interval), len(reported))
+
+		var previous uint64
+		for _, used := range reported {
+			assert.GreaterOrEqual(t, used, previous+interval)
+			previous = used / interval * interval
+		}
+	})
+
+	t.Run("zero interval", func(t *testing.T) {
+
+		t.Parallel()
+
+		var calls int
+
+		computationUsed, err := execute(&ExecutionProgress{
+			OnProgress: func(_ uint64) error {
+				calls++
+				return nil
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Greater(t, calls, 0)
+		assert.LessOrEqual(t, uint64(calls), computationUsed)
+	})
+
+	t.Run("error", func(t *testing.T) {
+
+		t.Parallel()
+
+		progressErr := errors.New("soft limit exceeded")
+
+		computationUsed, err := execute(&ExecutionProgress{
+			Interval: 50,
+			OnProgress: func(computationUsed uint64) error {
+				return progressErr
+			},
+		})
+		RequireError(t, err)
+		require.ErrorIs(t, err, progressErr)
+
+		assert.Less(t, computationUsed, uint64(100))
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := NewTestInterpreterRuntime()
+
+		var parsed, checked, interpreted int
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage: NewTestLedger(nil, nil),
+			OnProgramParsed: func(_ common.Location, _ time.Duration) {
+				parsed++
+			},
+			OnProgramChecked: func(_ common.Location, _ time.Duration) {
+				checked++
+			},
+			OnProgramInterpreted: func(_ common.Location, _ time.Duration) {
+				interpreted++
+			},
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+				Progress: &ExecutionProgress{
+					Interval: 10,
+					OnProgress: func(_ uint64) error {
+						return nil
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		// The metrics are reported to the wrapped runtime interface
+
+		assert.Equal(t, 1, parsed)
+		assert.Equal(t, 1, checked)
+		assert.Equal(t, 1, interpreted)
+	})
+}
@@ -0,0 +1,343 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/interpreter"
+)
+
+// ExecutionSnapshotVersion is the current version of the execution snapshot format
+const ExecutionSnapshotVersion = 1
+
+// ExecutionSuspension allows the execution of a script or transaction to be stopped at a statement boundary,
+// and to be deterministically replayed up to that statement later, e.g. in another process,
+// from where it continues normally.
+//
+// When the suspension is requested, the execution is aborted before the next statement is executed,
+// and fails with an ExecutionSuspendedError, which contains a snapshot of the execution.
+//
+// The snapshot does not capture the state of the interpreter, which is kept on the Go stack.
+// Instead, a suspended execution is resumed by re-executing it from the start:
+// Until the statement at which the execution was suspended is reached,
+// all interactions with the runtime interface are answered from the snapshot, see ExecutionTraceReplay,
+// so they do not have any effects, and are not metered again.
+// Afterwards, the execution continues with the runtime interface of the resumed execution.
+//
+// Resuming therefore costs as much computation as everything executed before the suspension.
+// Repeatedly suspending and resuming an execution, e.g. to time-slice it,
+// costs quadratically more than executing it at once.
+//
+// The runtime interface of the resumed execution must provide the state at the suspension,
+// i.e. it must retain the uncommitted changes of the suspended execution
+// (e.g. allocated slab indices, generated UUIDs, and created accounts), if any.
+//
+// A resumed execution can be suspended again.
+type ExecutionSuspension struct {
+	requested atomic.Bool
+	// Snapshot, if set, is the snapshot of a suspended execution, which is resumed
+	Snapshot *ExecutionSnapshot
+}
+
+// Suspend requests the suspension of the execution.
+// It may be called concurrently, while the execution is in progress.
+func (s *ExecutionSuspension) Suspend() {
+	s.requested.Store(true)
+}
+
+// ExecutionSnapshot is the state of a suspended execution
+type ExecutionSnapshot struct {
+	Version uint16
+	// Trace is the trace of the execution up to the suspension
+	Trace ExecutionTrace
+	// Statements is the number of statements which were executed before the suspension
+	Statements uint64
+	// CallStack are the frames of the call stack at the suspension, outermost first.
+	// The last frame is the position of the statement at which the execution was suspended
+	CallStack []ExecutionSnapshotFrame
+	// Locals are the values of the local variables of the innermost function, by name,
+	// in their string representation. They are only provided for inspection,
+	// and are not used when the execution is resumed
+	Locals map[string]string `json:",omitempty"`
+}
+
+// ExecutionSnapshotFrame is a position in the call stack of a suspended execution
+type ExecutionSnapshotFrame struct {
+	Location string
+	Line     int
+	Column   int
+}
+
+// Encode writes the execution snapshot to the given writer, in JSON format.
+func (s *ExecutionSnapshot) Encode(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+// DecodeExecutionSnapshot reads an execution snapshot from the given reader, in JSON format.
+func DecodeExecutionSnapshot(r io.Reader) (*ExecutionSnapshot, error) {
+	var snapshot ExecutionSnapshot
+	err := json.NewDecoder(r).Decode(&snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot.Version != ExecutionSnapshotVersion {
+		return nil, errors.NewDefaultUserError(
+			"unsupported execution snapshot version: %d",
+			snapshot.Version,
+		)
+	}
+
+	return &snapshot, nil
+}
+
+// Script returns the script or transaction of the suspended execution
+func (s *ExecutionSnapshot) Script() Script {
+	return Script{
+		Source:    s.Trace.Source,
+		Arguments: s.Trace.Arguments,
+	}
+}
+
+// Location returns the location of the script or transaction of the suspended execution
+func (s *ExecutionSnapshot) Location() (Location, error) {
+	return decodeExecutionTraceLocation(s.Trace.Location)
+}
+
+// ExecutionSuspendedError is reported when the execution of a script or transaction was suspended,
+// see ExecutionSuspension
+type ExecutionSuspendedError struct {
+	Snapshot *ExecutionSnapshot
+}
+
+var _ errors.UserError = ExecutionSuspendedError{}
+
+func (ExecutionSuspendedError) IsUserError() {}
+
+func (e ExecutionSuspendedError) Error() string {
+	return fmt.Sprintf(
+		"execution suspended after %d statements",
+		e.Snapshot.Statements,
+	)
+}
+
+// executionSuspender is a runtime interface which allows the suspension of an execution,
+// and the resumption of a suspended execution.
+//
+// It records all interactions with the wrapped runtime interface, so a snapshot can be created.
+// When resuming, the interactions are first answered from the snapshot.
+type executionSuspender struct {
+	// Interface is the current runtime interface:
+	// the replay of the snapshot while resuming, the recorder otherwise
+	Interface
+	suspension *ExecutionSuspension
+	recorder   *executionTraceRecorder
+	// statements is the number of statements executed so far
+	statements uint64
+	// resuming is true while the statements before the suspension are re-executed
+	resuming bool
+}
+
+var _ Interface = &executionSuspender{}
+var _ Metrics = &executionSuspender{}
+var _ EventSizer = &executionSuspender{}
+
+func newExecutionSuspender(
+	suspension *ExecutionSuspension,
+	kind ExecutionTraceKind,
+	location Location,
+	script Script,
+	runtimeInterface Interface,
+) (*executionSuspender, error) {
+
+	recorder := newExecutionTraceRecorder(
+		&ExecutionTrace{},
+		kind,
+		location,
+		script,
+		runtimeInterface,
+	)
+
+	suspender := &executionSuspender{
+		Interface:  recorder,
+		suspension: suspension,
+		recorder:   recorder,
+	}
+
+	snapshot := suspension.Snapshot
+	if snapshot != nil {
+		trace := &snapshot.Trace
+
+		if trace.Kind != kind ||
+			trace.Location != location.ID() ||
+			!bytes.Equal(trace.Source, script.Source) {
+
+			return nil, errors.NewDefaultUserError(
+				"cannot resume execution: snapshot is of a different %s",
+				kind,
+			)
+		}
+
+		// Keep the interactions of the suspended execution,
+		// so the resumed execution can be suspended again
+
+		recorder.trace.Entries = append(
+			recorder.trace.Entries,
+			trace.Entries...,
+		)
+
+		if snapshot.Statements > 0 {
+			suspender.Interface = NewExecutionTraceReplay(
+				trace,
+				runtimeInterface.DecodeArgument,
+			)
+			suspender.resuming = true
+		}
+	}
+
+	return suspender, nil
+}
+
+// findExecutionSuspender returns the suspender among the given runtime interface
+// and the runtime interfaces it wraps, if any
+func findExecutionSuspender(runtimeInterface Interface) *executionSuspender {
+	for {
+		switch wrapper := runtimeInterface.(type) {
+		case *executionSuspender:
+			return wrapper
+		case interfaceWrapper:
+			runtimeInterface = wrapper.unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
+func (s *executionSuspender) unwrap() Interface {
+	return s.recorder.Interface
+}
+
+// onStatement is called before each statement is executed
+func (s *executionSuspender) onStatement(inter *interpreter.Interpreter, statement ast.Statement) {
+	if s.resuming {
+		if s.statements < s.suspension.Snapshot.Statements {
+			s.statements++
+			return
+		}
+
+		// The statement at which the execution was suspended is reached,
+		// continue with the actual runtime interface
+
+		s.resuming = false
+		s.Interface = s.recorder
+	}
+
+	if s.suspension.requested.Load() {
+		snapshot := s.snapshot(inter, statement)
+		panic(interpreter.WrappedExternalError(ExecutionSuspendedError{
+			Snapshot: snapshot,
+		}))
+	}
+
+	s.statements++
+}
+
+func (s *executionSuspender) snapshot(inter *interpreter.Interpreter, statement ast.Statement) *ExecutionSnapshot {
+	trace := *s.recorder.trace
+	trace.Entries = append([]ExecutionTraceEntry(nil), trace.Entries...)
+
+	callStack := inter.CallStack()
+
+	frames := make([]ExecutionSnapshotFrame, 0, len(callStack)+1)
+	for _, invocation := range callStack {
+		locationRange := invocation.LocationRange
+		frames = append(frames, newExecutionSnapshotFrame(
+			locationRange.Location,
+			locationRange.StartPosition(),
+		))
+	}
+	frames = append(frames, newExecutionSnapshotFrame(
+		inter.Location,
+		statement.StartPosition(),
+	))
+
+	// Only capture the locals of functions.
+	// On the top-level, the activation only contains global declarations
+
+	var locals map[string]string
+	if len(callStack) > 0 {
+		variables := inter.CurrentActivation().FunctionValues()
+		locals = make(map[string]string, len(variables))
+		for name, variable := range variables { //nolint:maprange
+			locals[name] = variable.GetValue(inter).String()
+		}
+	}
+
+	return &ExecutionSnapshot{
+		Version:    ExecutionSnapshotVersion,
+		Trace:      trace,
+		Statements: s.statements,
+		CallStack:  frames,
+		Locals:     locals,
+	}
+}
+
+func newExecutionSnapshotFrame(location Location, position ast.Position) ExecutionSnapshotFrame {
+	var locationID string
+	if location != nil {
+		locationID = location.ID()
+	}
+
+	return ExecutionSnapshotFrame{
+		Location: locationID,
+		Line:     position.Line,
+		Column:   position.Column,
+	}
+}
+
+func (s *executionSuspender) ProgramParsed(location Location, duration time.Duration) {
+	if metrics, ok := s.Interface.(Metrics); ok {
+		metrics.ProgramParsed(location, duration)
+	}
+}
+
+func (s *executionSuspender) ProgramChecked(location Location, duration time.Duration) {
+	if metrics, ok := s.Interface.(Metrics); ok {
+		metrics.ProgramChecked(location, duration)
+	}
+}
+
+func (s *executionSuspender) ProgramInterpreted(location Location, duration time.Duration) {
+	if metrics, ok := s.Interface.(Metrics); ok {
+		metrics.ProgramInterpreted(location, duration)
+	}
+}
+
+func (s *executionSuspender) EventSize(event cadence.Event) (uint64, error) {
+	return eventSize(s.Interface, event)
+}
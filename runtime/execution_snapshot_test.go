@@ -0,0 +1,223 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeExecutionSuspension(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	tx := []byte(`
+      transaction {
+          prepare(signer: auth(Storage) &Account) {
+              var i = 0
+              while i < 4 {
+                  log(i)
+                  i = i + 1
+              }
+              signer.storage.save(i, to: /storage/count)
+          }
+      }
+    `)
+
+	config := DefaultTestInterpreterConfig
+	config.ExecutionSuspensionEnabled = true
+	runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+	var logs []string
+	var suspension *ExecutionSuspension
+	var writes int
+
+	runtimeInterface := &TestRuntimeInterface{
+		Storage: NewTestLedger(
+			nil,
+			func(_, _, _ []byte) {
+				writes++
+			},
+		),
+		OnGetSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+		OnProgramLog: func(message string) {
+			logs = append(logs, message)
+			// Suspend after the second and third log
+			if len(logs) == 2 || len(logs) == 3 {
+				suspension.Suspend()
+			}
+		},
+	}
+
+	location := NewTransactionLocationGenerator()()
+
+	execute := func(snapshot *ExecutionSnapshot) error {
+		suspension = &ExecutionSuspension{
+			Snapshot: snapshot,
+		}
+
+		return runtime.ExecuteTransaction(
+			Script{
+				Source: tx,
+			},
+			Context{
+				Interface:  runtimeInterface,
+				Location:   location,
+				Suspension: suspension,
+			},
+		)
+	}
+
+	requireSuspended := func(t *testing.T, err error) *ExecutionSnapshot {
+		RequireError(t, err)
+
+		var suspendedErr ExecutionSuspendedError
+		require.ErrorAs(t, err, &suspendedErr)
+
+		// Round-trip the snapshot
+
+		var buffer bytes.Buffer
+		err = suspendedErr.Snapshot.Encode(&buffer)
+		require.NoError(t, err)
+
+		snapshot, err := DecodeExecutionSnapshot(&buffer)
+		require.NoError(t, err)
+
+		return snapshot
+	}
+
+	// Suspend after the second log
+
+	err := execute(nil)
+	snapshot := requireSuspended(t, err)
+
+	assert.Equal(t, []string{"0", "1"}, logs)
+	assert.Equal(t, "1", snapshot.Locals["i"])
+	require.NotEmpty(t, snapshot.CallStack)
+	assert.Equal(t, 7, snapshot.CallStack[len(snapshot.CallStack)-1].Line)
+	assert.Zero(t, writes)
+
+	// Resume, and suspend again after the third log
+
+	err = execute(snapshot)
+	snapshot = requireSuspended(t, err)
+
+	assert.Equal(t, []string{"0", "1", "2"}, logs)
+	assert.Equal(t, "2", snapshot.Locals["i"])
+	assert.Zero(t, writes)
+
+	// Resume until completion
+
+	err = execute(snapshot)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"0", "1", "2", "3"}, logs)
+	assert.NotZero(t, writes)
+
+	value, err := runtime.ReadStored(
+		address,
+		cadence.MustNewPath(common.PathDomainStorage, "count"),
+		Context{
+			Interface: runtimeInterface,
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewInt(4), value)
+
+	t.Run("different transaction", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(`transaction {}`),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  location,
+				Suspension: &ExecutionSuspension{
+					Snapshot: snapshot,
+				},
+			},
+		)
+		RequireError(t, err)
+		require.ErrorContains(t, err, "cannot resume execution")
+	})
+}
+
+func TestRuntimeExecutionSuspensionWithProgress(t *testing.T) {
+
+	t.Parallel()
+
+	config := DefaultTestInterpreterConfig
+	config.ExecutionSuspensionEnabled = true
+	runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+	runtimeInterface := &TestRuntimeInterface{
+		Storage: NewTestLedger(nil, nil),
+	}
+
+	// The suspender is wrapped in the progress reporter,
+	// and must still suspend the execution
+
+	suspension := &ExecutionSuspension{}
+	suspension.Suspend()
+
+	var reported bool
+
+	_, err := runtime.ExecuteScript(
+		Script{
+			Source: []byte(`
+              access(all) fun main(): Int {
+                  return 1
+              }
+            `),
+		},
+		Context{
+			Interface:  runtimeInterface,
+			Location:   common.ScriptLocation{},
+			Suspension: suspension,
+			Progress: &ExecutionProgress{
+				OnProgress: func(_ uint64) error {
+					reported = true
+					return nil
+				},
+			},
+		},
+	)
+	RequireError(t, err)
+
+	var suspendedErr ExecutionSuspendedError
+	require.ErrorAs(t, err, &suspendedErr)
+
+	assert.True(t, reported)
+}
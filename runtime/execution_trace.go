@@ -0,0 +1,1628 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"encoding/json"
+	goerrors "errors"
+	"io"
+	"time"
+
+	"github.com/onflow/atree"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/sema"
+)
+
+// ExecutionTraceVersion is the current version of the execution trace format
+const ExecutionTraceVersion = 1
+
+// ExecutionTraceKind is the kind of execution recorded in an execution trace
+type ExecutionTraceKind string
+
+const (
+	ExecutionTraceKindScript      ExecutionTraceKind = "script"
+	ExecutionTraceKindTransaction ExecutionTraceKind = "transaction"
+)
+
+// ExecutionTrace is a recording of the execution of a script or transaction.
+//
+// It contains the executed program, and all interactions with the runtime interface
+// which provide data to the execution or observe its effects,
+// e.g. storage reads and writes, random values, block information, logs, and events.
+// An execution trace can be replayed without access to the state of the network,
+// see ExecutionTraceReplay.
+//
+// Interactions which do not influence the result of the execution,
+// like metering, program caching, and tracing, are not recorded.
+type ExecutionTrace struct {
+	Version   uint16
+	Kind      ExecutionTraceKind
+	Location  string
+	Source    []byte
+	Arguments [][]byte
+	Entries   []ExecutionTraceEntry
+}
+
+// ExecutionTraceEntry is an interaction with the runtime interface.
+//
+// The input and the output are the JSON encoded arguments and results of the call.
+// If the call failed, Error is the message of the error.
+type ExecutionTraceEntry struct {
+	Operation string
+	Input     json.RawMessage
+	Output    json.RawMessage `json:",omitempty"`
+	Error     string          `json:",omitempty"`
+}
+
+// Encode writes the execution trace to the given writer, in JSON format.
+func (t *ExecutionTrace) Encode(w io.Writer) error {
+	return json.NewEncoder(w).Encode(t)
+}
+
+// DecodeExecutionTrace reads an execution trace from the given reader, in JSON format.
+func DecodeExecutionTrace(r io.Reader) (*ExecutionTrace, error) {
+	var trace ExecutionTrace
+	err := json.NewDecoder(r).Decode(&trace)
+	if err != nil {
+		return nil, err
+	}
+
+	if trace.Version != ExecutionTraceVersion {
+		return nil, errors.NewDefaultUserError(
+			"unsupported execution trace version: %d",
+			trace.Version,
+		)
+	}
+
+	return &trace, nil
+}
+
+const (
+	executionTraceOperationResolveLocation                = "ResolveLocation"
+	executionTraceOperationGetCode                        = "GetCode"
+	executionTraceOperationGetValue                       = "GetValue"
+	executionTraceOperationSetValue                       = "SetValue"
+	executionTraceOperationValueExists                    = "ValueExists"
+	executionTraceOperationAllocateSlabIndex              = "AllocateSlabIndex"
+	executionTraceOperationCreateAccount                  = "CreateAccount"
+	executionTraceOperationAddAccountKey                  = "AddAccountKey"
+	executionTraceOperationGetAccountKey                  = "GetAccountKey"
+	executionTraceOperationAccountKeysCount               = "AccountKeysCount"
+	executionTraceOperationRevokeAccountKey               = "RevokeAccountKey"
+	executionTraceOperationUpdateAccountContractCode      = "UpdateAccountContractCode"
+	executionTraceOperationGetAccountContractCode         = "GetAccountContractCode"
+	executionTraceOperationGetAccountContractUpdateHeight = "GetAccountContractUpdateHeight"
+	executionTraceOperationRemoveAccountContractCode      = "RemoveAccountContractCode"
+	executionTraceOperationGetSigningAccounts             = "GetSigningAccounts"
+	executionTraceOperationProgramLog                     = "ProgramLog"
+	executionTraceOperationEmitEvent                      = "EmitEvent"
+	executionTraceOperationEventSize                      = "EventSize"
+	executionTraceOperationGenerateUUID                   = "GenerateUUID"
+	executionTraceOperationDecodeArgument                 = "DecodeArgument"
+	executionTraceOperationGetCurrentBlockHeight          = "GetCurrentBlockHeight"
+	executionTraceOperationGetBlockAtHeight               = "GetBlockAtHeight"
+	executionTraceOperationReadRandom                     = "ReadRandom"
+	executionTraceOperationVerifySignature                = "VerifySignature"
+	executionTraceOperationHash                           = "Hash"
+	executionTraceOperationGetAccountBalance              = "GetAccountBalance"
+	executionTraceOperationGetAccountAvailableBalance     = "GetAccountAvailableBalance"
+	executionTraceOperationGetStorageUsed                 = "GetStorageUsed"
+	executionTraceOperationGetStorageCapacity             = "GetStorageCapacity"
+	executionTraceOperationValidatePublicKey              = "ValidatePublicKey"
+	executionTraceOperationGetAccountContractNames        = "GetAccountContractNames"
+	executionTraceOperationBLSVerifyPOP                   = "BLSVerifyPOP"
+	executionTraceOperationBLSAggregateSignatures         = "BLSAggregateSignatures"
+	executionTraceOperationBLSAggregatePublicKeys         = "BLSAggregatePublicKeys"
+	executionTraceOperationGenerateAccountID              = "GenerateAccountID"
+	executionTraceOperationRecoverProgram                 = "RecoverProgram"
+	executionTraceOperationValidateCapabilitiesGet        = "ValidateAccountCapabilitiesGet"
+	executionTraceOperationValidateCapabilitiesPublish    = "ValidateAccountCapabilitiesPublish"
+	executionTraceOperationMinimumRequiredVersion         = "MinimumRequiredVersion"
+)
+
+// The inputs and outputs of the recorded operations, which are not a single value
+
+type executionTraceStorageKey struct {
+	Owner []byte
+	Key   []byte
+}
+
+type executionTraceStorageWrite struct {
+	Owner []byte
+	Key   []byte
+	Value []byte
+}
+
+type executionTraceResolvedLocation struct {
+	Location    string
+	Identifiers []string
+}
+
+type executionTraceResolveLocationInput struct {
+	Identifiers []string
+	Location    string
+}
+
+type executionTraceAccountKeyInput struct {
+	Address common.Address
+	Index   uint32
+}
+
+type executionTraceAddAccountKeyInput struct {
+	Address   common.Address
+	PublicKey *PublicKey
+	HashAlgo  HashAlgorithm
+	Weight    int
+}
+
+type executionTraceContractCodeUpdate struct {
+	Location string
+	Code     []byte
+}
+
+type executionTraceContractUpdateHeight struct {
+	Height uint64
+	Known  bool
+}
+
+type executionTraceArgument struct {
+	Argument []byte
+	Type     string
+}
+
+type executionTraceBlock struct {
+	Block  Block
+	Exists bool
+}
+
+type executionTraceSignatureVerification struct {
+	Signature          []byte
+	Tag                string
+	SignedData         []byte
+	PublicKey          []byte
+	SignatureAlgorithm SignatureAlgorithm
+	HashAlgorithm      HashAlgorithm
+}
+
+type executionTraceHashInput struct {
+	Data          []byte
+	Tag           string
+	HashAlgorithm HashAlgorithm
+}
+
+type executionTraceBLSVerifyPOPInput struct {
+	PublicKey *PublicKey
+	Signature []byte
+}
+
+type executionTraceCapabilitiesGetInput struct {
+	Address              common.Address
+	Path                 string
+	WantedBorrowType     string
+	CapabilityBorrowType string
+}
+
+type executionTraceCapabilitiesPublishInput struct {
+	Address              common.Address
+	Path                 string
+	CapabilityBorrowType string
+}
+
+func encodeExecutionTraceJSON(value any) json.RawMessage {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		panic(errors.NewUnexpectedErrorFromCause(err))
+	}
+	return encoded
+}
+
+func decodeExecutionTraceLocation(id string) (Location, error) {
+	location, _, err := common.DecodeTypeID(nil, id)
+	if err != nil {
+		return nil, err
+	}
+	if location == nil {
+		return nil, errors.NewDefaultUserError("invalid location in execution trace: %s", id)
+	}
+	return location, nil
+}
+
+func executionTraceIdentifiers(identifiers []Identifier) []string {
+	names := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		names = append(names, identifier.Identifier)
+	}
+	return names
+}
+
+func executionTracePublicKey(publicKey *PublicKey) *PublicKey {
+	if publicKey == nil {
+		return nil
+	}
+	return &PublicKey{
+		PublicKey: publicKey.PublicKey,
+		SignAlgo:  publicKey.SignAlgo,
+	}
+}
+
+// executionTraceRecorder is a runtime interface which records all interactions
+// with the wrapped runtime interface into an execution trace
+type executionTraceRecorder struct {
+	Interface
+	trace    *ExecutionTrace
+	location Location
+	// recordedCodes is the set of locations for which the code was recorded
+	recordedCodes map[Location]struct{}
+}
+
+var _ Interface = &executionTraceRecorder{}
+var _ Metrics = &executionTraceRecorder{}
+var _ EventSizer = &executionTraceRecorder{}
+
+// newExecutionTraceRecorder starts the recording of the given execution into the given trace,
+// and returns a runtime interface which records all interactions with the given runtime interface.
+func newExecutionTraceRecorder(
+	trace *ExecutionTrace,
+	kind ExecutionTraceKind,
+	location Location,
+	script Script,
+	runtimeInterface Interface,
+) *executionTraceRecorder {
+	*trace = ExecutionTrace{
+		Version:   ExecutionTraceVersion,
+		Kind:      kind,
+		Location:  location.ID(),
+		Source:    script.Source,
+		Arguments: script.Arguments,
+	}
+
+	return &executionTraceRecorder{
+		Interface:     runtimeInterface,
+		trace:         trace,
+		location:      location,
+		recordedCodes: map[Location]struct{}{},
+	}
+}
+
+func (r *executionTraceRecorder) unwrap() Interface {
+	return r.Interface
+}
+
+func (r *executionTraceRecorder) record(operation string, input any, output any, err error) {
+	entry := ExecutionTraceEntry{
+		Operation: operation,
+		Input:     encodeExecutionTraceJSON(input),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	} else if output != nil {
+		entry.Output = encodeExecutionTraceJSON(output)
+	}
+	r.trace.Entries = append(r.trace.Entries, entry)
+}
+
+func recordExecutionTraceCall[T any](
+	r *executionTraceRecorder,
+	operation string,
+	input any,
+	call func() (T, error),
+) (T, error) {
+	result, err := call()
+	r.record(operation, input, result, err)
+	return result, err
+}
+
+func (r *executionTraceRecorder) ProgramParsed(location Location, duration time.Duration) {
+	if metrics, ok := r.Interface.(Metrics); ok {
+		metrics.ProgramParsed(location, duration)
+	}
+}
+
+func (r *executionTraceRecorder) ProgramChecked(location Location, duration time.Duration) {
+	if metrics, ok := r.Interface.(Metrics); ok {
+		metrics.ProgramChecked(location, duration)
+	}
+}
+
+func (r *executionTraceRecorder) ProgramInterpreted(location Location, duration time.Duration) {
+	if metrics, ok := r.Interface.(Metrics); ok {
+		metrics.ProgramInterpreted(location, duration)
+	}
+}
+
+func (r *executionTraceRecorder) ResolveLocation(
+	identifiers []Identifier,
+	location Location,
+) (
+	[]ResolvedLocation,
+	error,
+) {
+	input := executionTraceResolveLocationInput{
+		Identifiers: executionTraceIdentifiers(identifiers),
+		Location:    location.ID(),
+	}
+
+	resolvedLocations, err := r.Interface.ResolveLocation(identifiers, location)
+
+	var output []executionTraceResolvedLocation
+	for _, resolvedLocation := range resolvedLocations {
+		output = append(output, executionTraceResolvedLocation{
+			Location:    resolvedLocation.Location.ID(),
+			Identifiers: executionTraceIdentifiers(resolvedLocation.Identifiers),
+		})
+	}
+	r.record(executionTraceOperationResolveLocation, input, output, err)
+
+	return resolvedLocations, err
+}
+
+func (r *executionTraceRecorder) GetCode(location Location) ([]byte, error) {
+	r.recordedCodes[location] = struct{}{}
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationGetCode,
+		location.ID(),
+		func() ([]byte, error) {
+			return r.Interface.GetCode(location)
+		},
+	)
+}
+
+// GetOrLoadProgram ensures the code of each imported program is recorded,
+// even if the wrapped runtime interface provides an existing program without loading it,
+// so the program can be loaded when the trace is replayed.
+// The code of the executed program is part of the trace.
+func (r *executionTraceRecorder) GetOrLoadProgram(
+	location Location,
+	load func() (*interpreter.Program, error),
+) (
+	*interpreter.Program,
+	error,
+) {
+	var loaded bool
+	program, err := r.Interface.GetOrLoadProgram(
+		location,
+		func() (*interpreter.Program, error) {
+			loaded = true
+			return load()
+		},
+	)
+
+	if !loaded && location != r.location {
+		if _, ok := r.recordedCodes[location]; !ok {
+			if addressLocation, ok := location.(common.AddressLocation); ok {
+				_, _ = r.GetAccountContractCode(addressLocation)
+			} else {
+				_, _ = r.GetCode(location)
+			}
+		}
+	}
+
+	return program, err
+}
+
+func (r *executionTraceRecorder) GetValue(owner, key []byte) ([]byte, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationGetValue,
+		executionTraceStorageKey{
+			Owner: owner,
+			Key:   key,
+		},
+		func() ([]byte, error) {
+			return r.Interface.GetValue(owner, key)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) SetValue(owner, key, value []byte) error {
+	err := r.Interface.SetValue(owner, key, value)
+	r.record(
+		executionTraceOperationSetValue,
+		executionTraceStorageWrite{
+			Owner: owner,
+			Key:   key,
+			Value: value,
+		},
+		nil,
+		err,
+	)
+	return err
+}
+
+func (r *executionTraceRecorder) ValueExists(owner, key []byte) (bool, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationValueExists,
+		executionTraceStorageKey{
+			Owner: owner,
+			Key:   key,
+		},
+		func() (bool, error) {
+			return r.Interface.ValueExists(owner, key)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) AllocateSlabIndex(owner []byte) (atree.SlabIndex, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationAllocateSlabIndex,
+		owner,
+		func() (atree.SlabIndex, error) {
+			return r.Interface.AllocateSlabIndex(owner)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) CreateAccount(payer Address) (Address, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationCreateAccount,
+		payer,
+		func() (Address, error) {
+			return r.Interface.CreateAccount(payer)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) AddAccountKey(
+	address Address,
+	publicKey *PublicKey,
+	hashAlgo HashAlgorithm,
+	weight int,
+) (
+	*AccountKey,
+	error,
+) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationAddAccountKey,
+		executionTraceAddAccountKeyInput{
+			Address:   address,
+			PublicKey: executionTracePublicKey(publicKey),
+			HashAlgo:  hashAlgo,
+			Weight:    weight,
+		},
+		func() (*AccountKey, error) {
+			return r.Interface.AddAccountKey(address, publicKey, hashAlgo, weight)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) GetAccountKey(address Address, index uint32) (*AccountKey, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationGetAccountKey,
+		executionTraceAccountKeyInput{
+			Address: address,
+			Index:   index,
+		},
+		func() (*AccountKey, error) {
+			return r.Interface.GetAccountKey(address, index)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) AccountKeysCount(address Address) (uint32, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationAccountKeysCount,
+		address,
+		func() (uint32, error) {
+			return r.Interface.AccountKeysCount(address)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) RevokeAccountKey(address Address, index uint32) (*AccountKey, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationRevokeAccountKey,
+		executionTraceAccountKeyInput{
+			Address: address,
+			Index:   index,
+		},
+		func() (*AccountKey, error) {
+			return r.Interface.RevokeAccountKey(address, index)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) UpdateAccountContractCode(location common.AddressLocation, code []byte) error {
+	err := r.Interface.UpdateAccountContractCode(location, code)
+	r.record(
+		executionTraceOperationUpdateAccountContractCode,
+		executionTraceContractCodeUpdate{
+			Location: location.ID(),
+			Code:     code,
+		},
+		nil,
+		err,
+	)
+	return err
+}
+
+func (r *executionTraceRecorder) GetAccountContractCode(location common.AddressLocation) ([]byte, error) {
+	r.recordedCodes[location] = struct{}{}
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationGetAccountContractCode,
+		location.ID(),
+		func() ([]byte, error) {
+			return r.Interface.GetAccountContractCode(location)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) GetAccountContractUpdateHeight(
+	location common.AddressLocation,
+) (
+	height uint64,
+	known bool,
+	err error,
+) {
+	height, known, err = r.Interface.GetAccountContractUpdateHeight(location)
+	r.record(
+		executionTraceOperationGetAccountContractUpdateHeight,
+		location.ID(),
+		executionTraceContractUpdateHeight{
+			Height: height,
+			Known:  known,
+		},
+		err,
+	)
+	return
+}
+
+func (r *executionTraceRecorder) RemoveAccountContractCode(location common.AddressLocation) error {
+	err := r.Interface.RemoveAccountContractCode(location)
+	r.record(executionTraceOperationRemoveAccountContractCode, location.ID(), nil, err)
+	return err
+}
+
+func (r *executionTraceRecorder) GetSigningAccounts() ([]Address, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationGetSigningAccounts,
+		nil,
+		r.Interface.GetSigningAccounts,
+	)
+}
+
+func (r *executionTraceRecorder) ProgramLog(message string) error {
+	err := r.Interface.ProgramLog(message)
+	r.record(executionTraceOperationProgramLog, message, nil, err)
+	return err
+}
+
+func (r *executionTraceRecorder) EmitEvent(event cadence.Event) error {
+	err := r.Interface.EmitEvent(event)
+	r.record(executionTraceOperationEmitEvent, event.String(), nil, err)
+	return err
+}
+
+func (r *executionTraceRecorder) EventSize(event cadence.Event) (uint64, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationEventSize,
+		event.String(),
+		func() (uint64, error) {
+			return eventSize(r.Interface, event)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) GenerateUUID() (uint64, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationGenerateUUID,
+		nil,
+		r.Interface.GenerateUUID,
+	)
+}
+
+func (r *executionTraceRecorder) DecodeArgument(argument []byte, argumentType cadence.Type) (cadence.Value, error) {
+	value, err := r.Interface.DecodeArgument(argument, argumentType)
+
+	r.record(
+		executionTraceOperationDecodeArgument,
+		executionTraceArgument{
+			Argument: argument,
+			Type:     argumentType.ID(),
+		},
+		nil,
+		err,
+	)
+
+	return value, err
+}
+
+func (r *executionTraceRecorder) GetCurrentBlockHeight() (uint64, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationGetCurrentBlockHeight,
+		nil,
+		r.Interface.GetCurrentBlockHeight,
+	)
+}
+
+func (r *executionTraceRecorder) GetBlockAtHeight(height uint64) (block Block, exists bool, err error) {
+	block, exists, err = r.Interface.GetBlockAtHeight(height)
+	r.record(
+		executionTraceOperationGetBlockAtHeight,
+		height,
+		executionTraceBlock{
+			Block:  block,
+			Exists: exists,
+		},
+		err,
+	)
+	return
+}
+
+func (r *executionTraceRecorder) ReadRandom(buffer []byte) error {
+	err := r.Interface.ReadRandom(buffer)
+	r.record(executionTraceOperationReadRandom, len(buffer), buffer, err)
+	return err
+}
+
+func (r *executionTraceRecorder) VerifySignature(
+	signature []byte,
+	tag string,
+	signedData []byte,
+	publicKey []byte,
+	signatureAlgorithm SignatureAlgorithm,
+	hashAlgorithm HashAlgorithm,
+) (
+	bool,
+	error,
+) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationVerifySignature,
+		executionTraceSignatureVerification{
+			Signature:          signature,
+			Tag:                tag,
+			SignedData:         signedData,
+			PublicKey:          publicKey,
+			SignatureAlgorithm: signatureAlgorithm,
+			HashAlgorithm:      hashAlgorithm,
+		},
+		func() (bool, error) {
+			return r.Interface.VerifySignature(
+				signature,
+				tag,
+				signedData,
+				publicKey,
+				signatureAlgorithm,
+				hashAlgorithm,
+			)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) Hash(data []byte, tag string, hashAlgorithm HashAlgorithm) ([]byte, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationHash,
+		executionTraceHashInput{
+			Data:          data,
+			Tag:           tag,
+			HashAlgorithm: hashAlgorithm,
+		},
+		func() ([]byte, error) {
+			return r.Interface.Hash(data, tag, hashAlgorithm)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) GetAccountBalance(address common.Address) (uint64, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationGetAccountBalance,
+		address,
+		func() (uint64, error) {
+			return r.Interface.GetAccountBalance(address)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) GetAccountAvailableBalance(address common.Address) (uint64, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationGetAccountAvailableBalance,
+		address,
+		func() (uint64, error) {
+			return r.Interface.GetAccountAvailableBalance(address)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) GetStorageUsed(address Address) (uint64, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationGetStorageUsed,
+		address,
+		func() (uint64, error) {
+			return r.Interface.GetStorageUsed(address)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) GetStorageCapacity(address Address) (uint64, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationGetStorageCapacity,
+		address,
+		func() (uint64, error) {
+			return r.Interface.GetStorageCapacity(address)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) ValidatePublicKey(key *PublicKey) error {
+	err := r.Interface.ValidatePublicKey(key)
+	r.record(executionTraceOperationValidatePublicKey, executionTracePublicKey(key), nil, err)
+	return err
+}
+
+func (r *executionTraceRecorder) GetAccountContractNames(address Address) ([]string, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationGetAccountContractNames,
+		address,
+		func() ([]string, error) {
+			return r.Interface.GetAccountContractNames(address)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) BLSVerifyPOP(publicKey *PublicKey, signature []byte) (bool, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationBLSVerifyPOP,
+		executionTraceBLSVerifyPOPInput{
+			PublicKey: executionTracePublicKey(publicKey),
+			Signature: signature,
+		},
+		func() (bool, error) {
+			return r.Interface.BLSVerifyPOP(publicKey, signature)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) BLSAggregateSignatures(signatures [][]byte) ([]byte, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationBLSAggregateSignatures,
+		signatures,
+		func() ([]byte, error) {
+			return r.Interface.BLSAggregateSignatures(signatures)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) BLSAggregatePublicKeys(publicKeys []*PublicKey) (*PublicKey, error) {
+	input := make([]*PublicKey, 0, len(publicKeys))
+	for _, publicKey := range publicKeys {
+		input = append(input, executionTracePublicKey(publicKey))
+	}
+
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationBLSAggregatePublicKeys,
+		input,
+		func() (*PublicKey, error) {
+			return r.Interface.BLSAggregatePublicKeys(publicKeys)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) GenerateAccountID(address common.Address) (uint64, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationGenerateAccountID,
+		address,
+		func() (uint64, error) {
+			return r.Interface.GenerateAccountID(address)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) RecoverProgram(program *ast.Program, location common.Location) ([]byte, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationRecoverProgram,
+		location.ID(),
+		func() ([]byte, error) {
+			return r.Interface.RecoverProgram(program, location)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) ValidateAccountCapabilitiesGet(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	address interpreter.AddressValue,
+	path interpreter.PathValue,
+	wantedBorrowType *sema.ReferenceType,
+	capabilityBorrowType *sema.ReferenceType,
+) (
+	bool,
+	error,
+) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationValidateCapabilitiesGet,
+		executionTraceCapabilitiesGetInput{
+			Address:              address.ToAddress(),
+			Path:                 path.String(),
+			WantedBorrowType:     string(wantedBorrowType.ID()),
+			CapabilityBorrowType: string(capabilityBorrowType.ID()),
+		},
+		func() (bool, error) {
+			return r.Interface.ValidateAccountCapabilitiesGet(
+				inter,
+				locationRange,
+				address,
+				path,
+				wantedBorrowType,
+				capabilityBorrowType,
+			)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) ValidateAccountCapabilitiesPublish(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	address interpreter.AddressValue,
+	path interpreter.PathValue,
+	capabilityBorrowType *interpreter.ReferenceStaticType,
+) (
+	bool,
+	error,
+) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationValidateCapabilitiesPublish,
+		executionTraceCapabilitiesPublishInput{
+			Address:              address.ToAddress(),
+			Path:                 path.String(),
+			CapabilityBorrowType: string(capabilityBorrowType.ID()),
+		},
+		func() (bool, error) {
+			return r.Interface.ValidateAccountCapabilitiesPublish(
+				inter,
+				locationRange,
+				address,
+				path,
+				capabilityBorrowType,
+			)
+		},
+	)
+}
+
+func (r *executionTraceRecorder) MinimumRequiredVersion() (string, error) {
+	return recordExecutionTraceCall(
+		r,
+		executionTraceOperationMinimumRequiredVersion,
+		nil,
+		r.Interface.MinimumRequiredVersion,
+	)
+}
+
+// ExecutionTraceReplay is a runtime interface which replays an execution trace.
+//
+// All interactions are answered from the trace, so the recorded script or transaction
+// can be re-executed without access to the state of the network.
+// Each interaction must match a recorded interaction with the same operation and input,
+// which are consumed in the recorded order.
+// If the execution diverges from the trace, the interaction fails with an ExecutionTraceDivergenceError.
+//
+// Metering is not replayed, i.e. the replayed execution is not limited.
+type ExecutionTraceReplay struct {
+	trace *ExecutionTrace
+	// entries are the remaining recorded entries, by operation and input
+	entries  map[string][]ExecutionTraceEntry
+	programs map[Location]executionTraceReplayProgram
+	// decodeArgument decodes the arguments of the execution
+	decodeArgument ExecutionTraceArgumentDecoder
+	// Logs are the messages logged by the replayed execution
+	Logs []string
+	// Events are the events emitted by the replayed execution
+	Events []cadence.Event
+}
+
+type executionTraceReplayProgram struct {
+	program *interpreter.Program
+	err     error
+}
+
+var _ Interface = &ExecutionTraceReplay{}
+var _ EventSizer = &ExecutionTraceReplay{}
+
+// ExecutionTraceArgumentDecoder decodes an argument of a replayed execution against the given type,
+// like the runtime interface of the recorded execution, see Interface.DecodeArgument.
+type ExecutionTraceArgumentDecoder func(argument []byte, argumentType cadence.Type) (cadence.Value, error)
+
+// NewExecutionTraceReplay returns a runtime interface which replays the given execution trace.
+// The arguments of the execution are decoded using the given decoder.
+func NewExecutionTraceReplay(
+	trace *ExecutionTrace,
+	decodeArgument ExecutionTraceArgumentDecoder,
+) *ExecutionTraceReplay {
+	entries := map[string][]ExecutionTraceEntry{}
+	for _, entry := range trace.Entries {
+		key := executionTraceEntryKey(entry.Operation, entry.Input)
+		entries[key] = append(entries[key], entry)
+	}
+
+	return &ExecutionTraceReplay{
+		trace:          trace,
+		entries:        entries,
+		programs:       map[Location]executionTraceReplayProgram{},
+		decodeArgument: decodeArgument,
+	}
+}
+
+func executionTraceEntryKey(operation string, input json.RawMessage) string {
+	return operation + ":" + string(input)
+}
+
+// Execute re-executes the script or transaction of the execution trace using the given runtime.
+// For scripts, the result of the script is returned.
+func (r *ExecutionTraceReplay) Execute(runtime Runtime) (cadence.Value, error) {
+	trace := r.trace
+
+	location, err := decodeExecutionTraceLocation(trace.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	script := Script{
+		Source:    trace.Source,
+		Arguments: trace.Arguments,
+	}
+
+	context := Context{
+		Interface: r,
+		Location:  location,
+	}
+
+	switch trace.Kind {
+	case ExecutionTraceKindScript:
+		return runtime.ExecuteScript(script, context)
+
+	case ExecutionTraceKindTransaction:
+		return nil, runtime.ExecuteTransaction(script, context)
+
+	default:
+		return nil, errors.NewDefaultUserError(
+			"unsupported execution trace kind: %s",
+			trace.Kind,
+		)
+	}
+}
+
+// next consumes the next recorded entry for the given operation and input
+func (r *ExecutionTraceReplay) next(operation string, input any) (ExecutionTraceEntry, error) {
+	encodedInput := encodeExecutionTraceJSON(input)
+	key := executionTraceEntryKey(operation, encodedInput)
+
+	entries := r.entries[key]
+	if len(entries) == 0 {
+		return ExecutionTraceEntry{}, ExecutionTraceDivergenceError{
+			Operation: operation,
+			Input:     string(encodedInput),
+		}
+	}
+
+	entry := entries[0]
+	r.entries[key] = entries[1:]
+
+	if entry.Error != "" {
+		return entry, goerrors.New(entry.Error)
+	}
+
+	return entry, nil
+}
+
+func replayExecutionTraceCall[T any](
+	r *ExecutionTraceReplay,
+	operation string,
+	input any,
+) (
+	result T,
+	err error,
+) {
+	entry, err := r.next(operation, input)
+	if err != nil {
+		return
+	}
+
+	if entry.Output != nil {
+		err = json.Unmarshal(entry.Output, &result)
+	}
+
+	return
+}
+
+func (r *ExecutionTraceReplay) replay(operation string, input any) error {
+	_, err := r.next(operation, input)
+	return err
+}
+
+func (*ExecutionTraceReplay) MeterMemory(_ common.MemoryUsage) error {
+	return nil
+}
+
+func (*ExecutionTraceReplay) MeterComputation(_ common.ComputationKind, _ uint) error {
+	return nil
+}
+
+func (*ExecutionTraceReplay) ComputationUsed() (uint64, error) {
+	return 0, nil
+}
+
+func (*ExecutionTraceReplay) MemoryUsed() (uint64, error) {
+	return 0, nil
+}
+
+func (*ExecutionTraceReplay) InteractionUsed() (uint64, error) {
+	return 0, nil
+}
+
+func (r *ExecutionTraceReplay) ResolveLocation(
+	identifiers []Identifier,
+	location Location,
+) (
+	[]ResolvedLocation,
+	error,
+) {
+	output, err := replayExecutionTraceCall[[]executionTraceResolvedLocation](
+		r,
+		executionTraceOperationResolveLocation,
+		executionTraceResolveLocationInput{
+			Identifiers: executionTraceIdentifiers(identifiers),
+			Location:    location.ID(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reuse the given identifiers, so their positions are preserved
+
+	identifiersByName := make(map[string]Identifier, len(identifiers))
+	for _, identifier := range identifiers {
+		identifiersByName[identifier.Identifier] = identifier
+	}
+
+	resolvedLocations := make([]ResolvedLocation, 0, len(output))
+	for _, resolved := range output {
+		resolvedLocation, err := decodeExecutionTraceLocation(resolved.Location)
+		if err != nil {
+			return nil, err
+		}
+
+		resolvedIdentifiers := make([]Identifier, 0, len(resolved.Identifiers))
+		for _, name := range resolved.Identifiers {
+			identifier, ok := identifiersByName[name]
+			if !ok {
+				identifier = Identifier{
+					Identifier: name,
+				}
+			}
+			resolvedIdentifiers = append(resolvedIdentifiers, identifier)
+		}
+
+		resolvedLocations = append(resolvedLocations, ResolvedLocation{
+			Location:    resolvedLocation,
+			Identifiers: resolvedIdentifiers,
+		})
+	}
+
+	return resolvedLocations, nil
+}
+
+func (r *ExecutionTraceReplay) GetCode(location Location) ([]byte, error) {
+	return replayExecutionTraceCall[[]byte](
+		r,
+		executionTraceOperationGetCode,
+		location.ID(),
+	)
+}
+
+func (r *ExecutionTraceReplay) GetOrLoadProgram(
+	location Location,
+	load func() (*interpreter.Program, error),
+) (
+	*interpreter.Program,
+	error,
+) {
+	if program, ok := r.programs[location]; ok {
+		return program.program, program.err
+	}
+
+	program, err := load()
+
+	// NOTE: important: still set the program, even if loading failed
+
+	r.programs[location] = executionTraceReplayProgram{
+		program: program,
+		err:     err,
+	}
+
+	return program, err
+}
+
+func (*ExecutionTraceReplay) SetInterpreterSharedState(_ *interpreter.SharedState) {
+	// NO-OP
+}
+
+func (*ExecutionTraceReplay) GetInterpreterSharedState() *interpreter.SharedState {
+	return nil
+}
+
+func (r *ExecutionTraceReplay) GetValue(owner, key []byte) ([]byte, error) {
+	return replayExecutionTraceCall[[]byte](
+		r,
+		executionTraceOperationGetValue,
+		executionTraceStorageKey{
+			Owner: owner,
+			Key:   key,
+		},
+	)
+}
+
+func (r *ExecutionTraceReplay) SetValue(owner, key, value []byte) error {
+	return r.replay(
+		executionTraceOperationSetValue,
+		executionTraceStorageWrite{
+			Owner: owner,
+			Key:   key,
+			Value: value,
+		},
+	)
+}
+
+func (r *ExecutionTraceReplay) ValueExists(owner, key []byte) (bool, error) {
+	return replayExecutionTraceCall[bool](
+		r,
+		executionTraceOperationValueExists,
+		executionTraceStorageKey{
+			Owner: owner,
+			Key:   key,
+		},
+	)
+}
+
+func (r *ExecutionTraceReplay) AllocateSlabIndex(owner []byte) (atree.SlabIndex, error) {
+	return replayExecutionTraceCall[atree.SlabIndex](
+		r,
+		executionTraceOperationAllocateSlabIndex,
+		owner,
+	)
+}
+
+func (r *ExecutionTraceReplay) CreateAccount(payer Address) (Address, error) {
+	return replayExecutionTraceCall[Address](
+		r,
+		executionTraceOperationCreateAccount,
+		payer,
+	)
+}
+
+func (r *ExecutionTraceReplay) AddAccountKey(
+	address Address,
+	publicKey *PublicKey,
+	hashAlgo HashAlgorithm,
+	weight int,
+) (
+	*AccountKey,
+	error,
+) {
+	return replayExecutionTraceCall[*AccountKey](
+		r,
+		executionTraceOperationAddAccountKey,
+		executionTraceAddAccountKeyInput{
+			Address:   address,
+			PublicKey: executionTracePublicKey(publicKey),
+			HashAlgo:  hashAlgo,
+			Weight:    weight,
+		},
+	)
+}
+
+func (r *ExecutionTraceReplay) GetAccountKey(address Address, index uint32) (*AccountKey, error) {
+	return replayExecutionTraceCall[*AccountKey](
+		r,
+		executionTraceOperationGetAccountKey,
+		executionTraceAccountKeyInput{
+			Address: address,
+			Index:   index,
+		},
+	)
+}
+
+func (r *ExecutionTraceReplay) AccountKeysCount(address Address) (uint32, error) {
+	return replayExecutionTraceCall[uint32](
+		r,
+		executionTraceOperationAccountKeysCount,
+		address,
+	)
+}
+
+func (r *ExecutionTraceReplay) RevokeAccountKey(address Address, index uint32) (*AccountKey, error) {
+	return replayExecutionTraceCall[*AccountKey](
+		r,
+		executionTraceOperationRevokeAccountKey,
+		executionTraceAccountKeyInput{
+			Address: address,
+			Index:   index,
+		},
+	)
+}
+
+func (r *ExecutionTraceReplay) UpdateAccountContractCode(location common.AddressLocation, code []byte) error {
+	return r.replay(
+		executionTraceOperationUpdateAccountContractCode,
+		executionTraceContractCodeUpdate{
+			Location: location.ID(),
+			Code:     code,
+		},
+	)
+}
+
+func (r *ExecutionTraceReplay) GetAccountContractCode(location common.AddressLocation) ([]byte, error) {
+	return replayExecutionTraceCall[[]byte](
+		r,
+		executionTraceOperationGetAccountContractCode,
+		location.ID(),
+	)
+}
+
+func (r *ExecutionTraceReplay) GetAccountContractUpdateHeight(
+	location common.AddressLocation,
+) (
+	uint64,
+	bool,
+	error,
+) {
+	output, err := replayExecutionTraceCall[executionTraceContractUpdateHeight](
+		r,
+		executionTraceOperationGetAccountContractUpdateHeight,
+		location.ID(),
+	)
+	return output.Height, output.Known, err
+}
+
+func (r *ExecutionTraceReplay) RemoveAccountContractCode(location common.AddressLocation) error {
+	return r.replay(
+		executionTraceOperationRemoveAccountContractCode,
+		location.ID(),
+	)
+}
+
+func (r *ExecutionTraceReplay) GetSigningAccounts() ([]Address, error) {
+	return replayExecutionTraceCall[[]Address](
+		r,
+		executionTraceOperationGetSigningAccounts,
+		nil,
+	)
+}
+
+func (r *ExecutionTraceReplay) ProgramLog(message string) error {
+	err := r.replay(executionTraceOperationProgramLog, message)
+	if err != nil {
+		return err
+	}
+
+	r.Logs = append(r.Logs, message)
+
+	return nil
+}
+
+func (r *ExecutionTraceReplay) EmitEvent(event cadence.Event) error {
+	err := r.replay(executionTraceOperationEmitEvent, event.String())
+	if err != nil {
+		return err
+	}
+
+	r.Events = append(r.Events, event)
+
+	return nil
+}
+
+func (r *ExecutionTraceReplay) EventSize(event cadence.Event) (uint64, error) {
+	return replayExecutionTraceCall[uint64](
+		r,
+		executionTraceOperationEventSize,
+		event.String(),
+	)
+}
+
+func (r *ExecutionTraceReplay) GenerateUUID() (uint64, error) {
+	return replayExecutionTraceCall[uint64](
+		r,
+		executionTraceOperationGenerateUUID,
+		nil,
+	)
+}
+
+func (r *ExecutionTraceReplay) DecodeArgument(argument []byte, argumentType cadence.Type) (cadence.Value, error) {
+	err := r.replay(
+		executionTraceOperationDecodeArgument,
+		executionTraceArgument{
+			Argument: argument,
+			Type:     argumentType.ID(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.decodeArgument(argument, argumentType)
+}
+
+func (r *ExecutionTraceReplay) GetCurrentBlockHeight() (uint64, error) {
+	return replayExecutionTraceCall[uint64](
+		r,
+		executionTraceOperationGetCurrentBlockHeight,
+		nil,
+	)
+}
+
+func (r *ExecutionTraceReplay) GetBlockAtHeight(height uint64) (Block, bool, error) {
+	output, err := replayExecutionTraceCall[executionTraceBlock](
+		r,
+		executionTraceOperationGetBlockAtHeight,
+		height,
+	)
+	return output.Block, output.Exists, err
+}
+
+func (r *ExecutionTraceReplay) ReadRandom(buffer []byte) error {
+	random, err := replayExecutionTraceCall[[]byte](
+		r,
+		executionTraceOperationReadRandom,
+		len(buffer),
+	)
+	if err != nil {
+		return err
+	}
+
+	copy(buffer, random)
+
+	return nil
+}
+
+func (r *ExecutionTraceReplay) VerifySignature(
+	signature []byte,
+	tag string,
+	signedData []byte,
+	publicKey []byte,
+	signatureAlgorithm SignatureAlgorithm,
+	hashAlgorithm HashAlgorithm,
+) (
+	bool,
+	error,
+) {
+	return replayExecutionTraceCall[bool](
+		r,
+		executionTraceOperationVerifySignature,
+		executionTraceSignatureVerification{
+			Signature:          signature,
+			Tag:                tag,
+			SignedData:         signedData,
+			PublicKey:          publicKey,
+			SignatureAlgorithm: signatureAlgorithm,
+			HashAlgorithm:      hashAlgorithm,
+		},
+	)
+}
+
+func (r *ExecutionTraceReplay) Hash(data []byte, tag string, hashAlgorithm HashAlgorithm) ([]byte, error) {
+	return replayExecutionTraceCall[[]byte](
+		r,
+		executionTraceOperationHash,
+		executionTraceHashInput{
+			Data:          data,
+			Tag:           tag,
+			HashAlgorithm: hashAlgorithm,
+		},
+	)
+}
+
+func (r *ExecutionTraceReplay) GetAccountBalance(address common.Address) (uint64, error) {
+	return replayExecutionTraceCall[uint64](
+		r,
+		executionTraceOperationGetAccountBalance,
+		address,
+	)
+}
+
+func (r *ExecutionTraceReplay) GetAccountAvailableBalance(address common.Address) (uint64, error) {
+	return replayExecutionTraceCall[uint64](
+		r,
+		executionTraceOperationGetAccountAvailableBalance,
+		address,
+	)
+}
+
+func (r *ExecutionTraceReplay) GetStorageUsed(address Address) (uint64, error) {
+	return replayExecutionTraceCall[uint64](
+		r,
+		executionTraceOperationGetStorageUsed,
+		address,
+	)
+}
+
+func (r *ExecutionTraceReplay) GetStorageCapacity(address Address) (uint64, error) {
+	return replayExecutionTraceCall[uint64](
+		r,
+		executionTraceOperationGetStorageCapacity,
+		address,
+	)
+}
+
+func (*ExecutionTraceReplay) ImplementationDebugLog(_ string) error {
+	return nil
+}
+
+func (r *ExecutionTraceReplay) ValidatePublicKey(key *PublicKey) error {
+	return r.replay(
+		executionTraceOperationValidatePublicKey,
+		executionTracePublicKey(key),
+	)
+}
+
+func (r *ExecutionTraceReplay) GetAccountContractNames(address Address) ([]string, error) {
+	return replayExecutionTraceCall[[]string](
+		r,
+		executionTraceOperationGetAccountContractNames,
+		address,
+	)
+}
+
+func (*ExecutionTraceReplay) RecordTrace(
+	_ string,
+	_ Location,
+	_ time.Duration,
+	_ []attribute.KeyValue,
+) {
+	// NO-OP
+}
+
+func (r *ExecutionTraceReplay) BLSVerifyPOP(publicKey *PublicKey, signature []byte) (bool, error) {
+	return replayExecutionTraceCall[bool](
+		r,
+		executionTraceOperationBLSVerifyPOP,
+		executionTraceBLSVerifyPOPInput{
+			PublicKey: executionTracePublicKey(publicKey),
+			Signature: signature,
+		},
+	)
+}
+
+func (r *ExecutionTraceReplay) BLSAggregateSignatures(signatures [][]byte) ([]byte, error) {
+	return replayExecutionTraceCall[[]byte](
+		r,
+		executionTraceOperationBLSAggregateSignatures,
+		signatures,
+	)
+}
+
+func (r *ExecutionTraceReplay) BLSAggregatePublicKeys(publicKeys []*PublicKey) (*PublicKey, error) {
+	input := make([]*PublicKey, 0, len(publicKeys))
+	for _, publicKey := range publicKeys {
+		input = append(input, executionTracePublicKey(publicKey))
+	}
+
+	return replayExecutionTraceCall[*PublicKey](
+		r,
+		executionTraceOperationBLSAggregatePublicKeys,
+		input,
+	)
+}
+
+func (*ExecutionTraceReplay) ResourceOwnerChanged(
+	_ *interpreter.Interpreter,
+	_ *interpreter.CompositeValue,
+	_ common.Address,
+	_ common.Address,
+) {
+	// NO-OP
+}
+
+func (r *ExecutionTraceReplay) GenerateAccountID(address common.Address) (uint64, error) {
+	return replayExecutionTraceCall[uint64](
+		r,
+		executionTraceOperationGenerateAccountID,
+		address,
+	)
+}
+
+func (r *ExecutionTraceReplay) RecoverProgram(_ *ast.Program, location common.Location) ([]byte, error) {
+	return replayExecutionTraceCall[[]byte](
+		r,
+		executionTraceOperationRecoverProgram,
+		location.ID(),
+	)
+}
+
+func (r *ExecutionTraceReplay) ValidateAccountCapabilitiesGet(
+	_ *interpreter.Interpreter,
+	_ interpreter.LocationRange,
+	address interpreter.AddressValue,
+	path interpreter.PathValue,
+	wantedBorrowType *sema.ReferenceType,
+	capabilityBorrowType *sema.ReferenceType,
+) (
+	bool,
+	error,
+) {
+	return replayExecutionTraceCall[bool](
+		r,
+		executionTraceOperationValidateCapabilitiesGet,
+		executionTraceCapabilitiesGetInput{
+			Address:              address.ToAddress(),
+			Path:                 path.String(),
+			WantedBorrowType:     string(wantedBorrowType.ID()),
+			CapabilityBorrowType: string(capabilityBorrowType.ID()),
+		},
+	)
+}
+
+func (r *ExecutionTraceReplay) ValidateAccountCapabilitiesPublish(
+	_ *interpreter.Interpreter,
+	_ interpreter.LocationRange,
+	address interpreter.AddressValue,
+	path interpreter.PathValue,
+	capabilityBorrowType *interpreter.ReferenceStaticType,
+) (
+	bool,
+	error,
+) {
+	return replayExecutionTraceCall[bool](
+		r,
+		executionTraceOperationValidateCapabilitiesPublish,
+		executionTraceCapabilitiesPublishInput{
+			Address:              address.ToAddress(),
+			Path:                 path.String(),
+			CapabilityBorrowType: string(capabilityBorrowType.ID()),
+		},
+	)
+}
+
+func (r *ExecutionTraceReplay) MinimumRequiredVersion() (string, error) {
+	return replayExecutionTraceCall[string](
+		r,
+		executionTraceOperationMinimumRequiredVersion,
+		nil,
+	)
+}
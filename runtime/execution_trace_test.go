@@ -0,0 +1,223 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/encoding/json"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeExecutionTrace(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	contract := []byte(`
+      access(all) contract Counter {
+
+          access(all) event Incremented(count: Int)
+
+          access(all) var count: Int
+
+          access(all) fun increment(by amount: Int) {
+              self.count = self.count + amount
+              emit Incremented(count: self.count)
+          }
+
+          init() {
+              self.count = 0
+          }
+      }
+    `)
+
+	tx := []byte(`
+      import Counter from 0x1
+
+      transaction(amount: Int) {
+          prepare(signer: auth(Storage) &Account) {
+              Counter.increment(by: amount)
+              let random = revertibleRandom<UInt8>()
+              signer.storage.save(random, to: /storage/random)
+              log(getCurrentBlock().height)
+          }
+      }
+    `)
+
+	script := []byte(`
+      import Counter from 0x1
+
+      access(all) fun main(): [AnyStruct] {
+          let account = getAuthAccount<auth(Storage) &Account>(0x1)
+          return [Counter.count, account.storage.load<UInt8>(from: /storage/random)!]
+      }
+    `)
+
+	runtime := NewTestInterpreterRuntime()
+
+	decodeArgument := func(b []byte, _ cadence.Type) (cadence.Value, error) {
+		return json.Decode(nil, b)
+	}
+
+	var logs []string
+	var events []cadence.Event
+	accountCodes := map[common.Location][]byte{}
+
+	runtimeInterface := &TestRuntimeInterface{
+		Storage: NewTestLedger(nil, nil),
+		OnGetSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+		OnResolveLocation: NewSingleIdentifierLocationResolver(t),
+		OnUpdateAccountContractCode: func(location common.AddressLocation, code []byte) error {
+			accountCodes[location] = code
+			return nil
+		},
+		OnGetAccountContractCode: func(location common.AddressLocation) ([]byte, error) {
+			return accountCodes[location], nil
+		},
+		OnEmitEvent: func(event cadence.Event) error {
+			events = append(events, event)
+			return nil
+		},
+		OnProgramLog: func(message string) {
+			logs = append(logs, message)
+		},
+		OnReadRandom: func(buffer []byte) error {
+			for i := range buffer {
+				buffer[i] = 42
+			}
+			return nil
+		},
+		OnDecodeArgument: decodeArgument,
+	}
+
+	nextTransactionLocation := NewTransactionLocationGenerator()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: DeploymentTransaction("Counter", contract),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	events = nil
+
+	// Record the transaction
+
+	var transactionTrace ExecutionTrace
+
+	err = runtime.ExecuteTransaction(
+		Script{
+			Source: tx,
+			Arguments: encodeArgs([]cadence.Value{
+				cadence.NewInt(3),
+			}),
+		},
+		Context{
+			Interface:      runtimeInterface,
+			Location:       nextTransactionLocation(),
+			ExecutionTrace: &transactionTrace,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, ExecutionTraceKindTransaction, transactionTrace.Kind)
+	assert.Equal(t, tx, transactionTrace.Source)
+	require.Len(t, events, 1)
+	require.Len(t, logs, 1)
+
+	// Record the script
+
+	var scriptTrace ExecutionTrace
+
+	result, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface:      runtimeInterface,
+			Location:       common.ScriptLocation{},
+			ExecutionTrace: &scriptTrace,
+		},
+	)
+	require.NoError(t, err)
+
+	expectedResult := cadence.NewArray([]cadence.Value{
+		cadence.NewInt(3),
+		cadence.UInt8(42),
+	}).WithType(cadence.NewVariableSizedArrayType(cadence.AnyStructType))
+
+	assert.Equal(t, expectedResult, result)
+
+	// Replay the transaction, from the decoded trace
+
+	var buffer bytes.Buffer
+	err = transactionTrace.Encode(&buffer)
+	require.NoError(t, err)
+
+	decodedTransactionTrace, err := DecodeExecutionTrace(&buffer)
+	require.NoError(t, err)
+
+	transactionReplay := NewExecutionTraceReplay(decodedTransactionTrace, decodeArgument)
+	_, err = transactionReplay.Execute(NewInterpreterRuntime(DefaultTestInterpreterConfig))
+	require.NoError(t, err)
+
+	assert.Equal(t, logs, transactionReplay.Logs)
+	require.Len(t, transactionReplay.Events, 1)
+	assert.Equal(t, events[0].String(), transactionReplay.Events[0].String())
+
+	// Replay the script
+
+	scriptReplay := NewExecutionTraceReplay(&scriptTrace, decodeArgument)
+	replayedResult, err := scriptReplay.Execute(NewInterpreterRuntime(DefaultTestInterpreterConfig))
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedResult, replayedResult)
+
+	t.Run("divergence", func(t *testing.T) {
+
+		t.Parallel()
+
+		divergedTrace := transactionTrace
+		divergedTrace.Source = bytes.Replace(tx, []byte("/storage/random"), []byte("/storage/other"), 1)
+
+		replay := NewExecutionTraceReplay(&divergedTrace, decodeArgument)
+		_, err := replay.Execute(NewInterpreterRuntime(DefaultTestInterpreterConfig))
+		RequireError(t, err)
+
+		var divergenceErr ExecutionTraceDivergenceError
+		require.ErrorAs(t, err, &divergenceErr)
+		assert.Equal(t, "SetValue", divergenceErr.Operation)
+	})
+}
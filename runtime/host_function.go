@@ -0,0 +1,479 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/sema"
+	"github.com/onflow/cadence/stdlib"
+)
+
+var (
+	hostFunctionErrorType            = reflect.TypeOf((*error)(nil)).Elem()
+	hostFunctionInterpreterValueType = reflect.TypeOf((*interpreter.Value)(nil)).Elem()
+	hostFunctionCadenceValueType     = reflect.TypeOf((*cadence.Value)(nil)).Elem()
+	hostFunctionBigIntType           = reflect.TypeOf((*big.Int)(nil))
+	hostFunctionAddressType          = reflect.TypeOf(common.Address{})
+)
+
+// hostFunctionFixedSizeIntegerType describes how a fixed-size integer type is marshalled
+type hostFunctionFixedSizeIntegerType struct {
+	kind     reflect.Kind
+	newValue func(gauge common.MemoryGauge, value reflect.Value) interpreter.Value
+}
+
+var hostFunctionFixedSizeIntegerTypes = map[sema.Type]hostFunctionFixedSizeIntegerType{
+	sema.Int8Type: {
+		kind: reflect.Int8,
+		newValue: func(gauge common.MemoryGauge, value reflect.Value) interpreter.Value {
+			return interpreter.NewInt8Value(gauge, func() int8 { return int8(value.Int()) })
+		},
+	},
+	sema.Int16Type: {
+		kind: reflect.Int16,
+		newValue: func(gauge common.MemoryGauge, value reflect.Value) interpreter.Value {
+			return interpreter.NewInt16Value(gauge, func() int16 { return int16(value.Int()) })
+		},
+	},
+	sema.Int32Type: {
+		kind: reflect.Int32,
+		newValue: func(gauge common.MemoryGauge, value reflect.Value) interpreter.Value {
+			return interpreter.NewInt32Value(gauge, func() int32 { return int32(value.Int()) })
+		},
+	},
+	sema.Int64Type: {
+		kind: reflect.Int64,
+		newValue: func(gauge common.MemoryGauge, value reflect.Value) interpreter.Value {
+			return interpreter.NewInt64Value(gauge, func() int64 { return value.Int() })
+		},
+	},
+	sema.UInt8Type: {
+		kind: reflect.Uint8,
+		newValue: func(gauge common.MemoryGauge, value reflect.Value) interpreter.Value {
+			return interpreter.NewUInt8Value(gauge, func() uint8 { return uint8(value.Uint()) })
+		},
+	},
+	sema.UInt16Type: {
+		kind: reflect.Uint16,
+		newValue: func(gauge common.MemoryGauge, value reflect.Value) interpreter.Value {
+			return interpreter.NewUInt16Value(gauge, func() uint16 { return uint16(value.Uint()) })
+		},
+	},
+	sema.UInt32Type: {
+		kind: reflect.Uint32,
+		newValue: func(gauge common.MemoryGauge, value reflect.Value) interpreter.Value {
+			return interpreter.NewUInt32Value(gauge, func() uint32 { return uint32(value.Uint()) })
+		},
+	},
+	sema.UInt64Type: {
+		kind: reflect.Uint64,
+		newValue: func(gauge common.MemoryGauge, value reflect.Value) interpreter.Value {
+			return interpreter.NewUInt64Value(gauge, func() uint64 { return value.Uint() })
+		},
+	},
+	sema.Word8Type: {
+		kind: reflect.Uint8,
+		newValue: func(gauge common.MemoryGauge, value reflect.Value) interpreter.Value {
+			return interpreter.NewWord8Value(gauge, func() uint8 { return uint8(value.Uint()) })
+		},
+	},
+	sema.Word16Type: {
+		kind: reflect.Uint16,
+		newValue: func(gauge common.MemoryGauge, value reflect.Value) interpreter.Value {
+			return interpreter.NewWord16Value(gauge, func() uint16 { return uint16(value.Uint()) })
+		},
+	},
+	sema.Word32Type: {
+		kind: reflect.Uint32,
+		newValue: func(gauge common.MemoryGauge, value reflect.Value) interpreter.Value {
+			return interpreter.NewWord32Value(gauge, func() uint32 { return uint32(value.Uint()) })
+		},
+	},
+	sema.Word64Type: {
+		kind: reflect.Uint64,
+		newValue: func(gauge common.MemoryGauge, value reflect.Value) interpreter.Value {
+			return interpreter.NewWord64Value(gauge, func() uint64 { return value.Uint() })
+		},
+	},
+}
+
+type hostFunctionArgumentConverter func(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	value interpreter.Value,
+) (reflect.Value, error)
+
+type hostFunctionResultConverter func(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	value reflect.Value,
+) (interpreter.Value, error)
+
+// NewHostFunctionValue returns a standard library value for a function with the given Cadence function type,
+// which is implemented by the given Go function.
+//
+// The parameters of the Go function must correspond to the parameters of the Cadence function type.
+// The Go function may return a result which corresponds to the return type of the Cadence function type,
+// and/or an error, which aborts the program.
+//
+// The Go types for Cadence types are:
+//   - `bool` for `Bool`
+//   - `string` for `String`
+//   - `common.Address` for `Address`
+//   - `*big.Int` for `Int` and `UInt`
+//   - the Go integer type of the same size and signedness for fixed-size integer types,
+//     e.g. `int8` for `Int8`, and `uint64` for `UInt64` and `Word64`
+//   - `cadence.Value` or `interpreter.Value` for any type.
+//     Public keys and hash algorithms cannot be returned as `cadence.Value`
+//
+// An error is returned if the Go function does not match the Cadence function type.
+func NewHostFunctionValue(
+	name string,
+	functionType *sema.FunctionType,
+	docString string,
+	function any,
+) (
+	stdlib.StandardLibraryValue,
+	error,
+) {
+	invalid := func(format string, args ...any) (stdlib.StandardLibraryValue, error) {
+		return stdlib.StandardLibraryValue{}, fmt.Errorf(
+			"invalid host function `%s`: %s",
+			name,
+			fmt.Sprintf(format, args...),
+		)
+	}
+
+	if function == nil {
+		return invalid("expected a Go function")
+	}
+
+	functionValue := reflect.ValueOf(function)
+	goFunctionType := functionValue.Type()
+	if goFunctionType.Kind() != reflect.Func || goFunctionType.IsVariadic() {
+		return invalid("expected a non-variadic Go function, got %s", goFunctionType)
+	}
+
+	if len(functionType.TypeParameters) > 0 || functionType.Arity != nil {
+		return invalid("type parameters and optional parameters are not supported")
+	}
+
+	// Parameters
+
+	parameters := functionType.Parameters
+	if goFunctionType.NumIn() != len(parameters) {
+		return invalid(
+			"expected %d parameters, got %d",
+			len(parameters),
+			goFunctionType.NumIn(),
+		)
+	}
+
+	argumentConverters := make([]hostFunctionArgumentConverter, len(parameters))
+	for i, parameter := range parameters {
+		parameterType := parameter.TypeAnnotation.Type
+		goParameterType := goFunctionType.In(i)
+
+		converter := newHostFunctionArgumentConverter(parameterType, goParameterType)
+		if converter == nil {
+			return invalid(
+				"parameter `%s` of type `%s` cannot be passed as %s",
+				parameter.Identifier,
+				parameterType,
+				goParameterType,
+			)
+		}
+		argumentConverters[i] = converter
+	}
+
+	// Results
+
+	returnType := functionType.ReturnTypeAnnotation.Type
+
+	resultCount := goFunctionType.NumOut()
+	hasError := resultCount > 0 &&
+		goFunctionType.Out(resultCount-1) == hostFunctionErrorType
+	if hasError {
+		resultCount--
+	}
+
+	var resultConverter hostFunctionResultConverter
+
+	switch resultCount {
+	case 0:
+		if returnType != sema.VoidType {
+			return invalid("expected a result of type `%s`", returnType)
+		}
+
+	case 1:
+		goResultType := goFunctionType.Out(0)
+		resultConverter = newHostFunctionResultConverter(returnType, goResultType)
+		if resultConverter == nil {
+			return invalid(
+				"result of type `%s` cannot be returned as %s",
+				returnType,
+				goResultType,
+			)
+		}
+
+	default:
+		return invalid("expected at most one result, and optionally an error")
+	}
+
+	return stdlib.NewStandardLibraryStaticFunction(
+		name,
+		functionType,
+		docString,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			arguments := make([]reflect.Value, len(argumentConverters))
+			for i, converter := range argumentConverters {
+				argument, err := converter(inter, locationRange, invocation.Arguments[i])
+				if err != nil {
+					panic(err)
+				}
+				arguments[i] = argument
+			}
+
+			var results []reflect.Value
+			errors.WrapPanic(func() {
+				results = functionValue.Call(arguments)
+			})
+
+			if hasError {
+				errValue := results[len(results)-1]
+				if !errValue.IsNil() {
+					panic(interpreter.WrappedExternalError(errValue.Interface().(error)))
+				}
+			}
+
+			if resultConverter == nil {
+				return interpreter.Void
+			}
+
+			result, err := resultConverter(inter, locationRange, results[0])
+			if err != nil {
+				panic(err)
+			}
+			return result
+		},
+	), nil
+}
+
+func newHostFunctionArgumentConverter(
+	parameterType sema.Type,
+	goType reflect.Type,
+) hostFunctionArgumentConverter {
+
+	switch goType {
+	case hostFunctionInterpreterValueType:
+		return func(_ *interpreter.Interpreter, _ interpreter.LocationRange, value interpreter.Value) (reflect.Value, error) {
+			return reflect.ValueOf(&value).Elem(), nil
+		}
+
+	case hostFunctionCadenceValueType:
+		return func(
+			inter *interpreter.Interpreter,
+			locationRange interpreter.LocationRange,
+			value interpreter.Value,
+		) (reflect.Value, error) {
+			exportedValue, err := ExportValue(value, inter, locationRange)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(&exportedValue).Elem(), nil
+		}
+	}
+
+	switch parameterType {
+	case sema.BoolType:
+		if goType.Kind() != reflect.Bool {
+			return nil
+		}
+		return convertHostFunctionArgument(goType)
+
+	case sema.TheAddressType:
+		if goType != hostFunctionAddressType {
+			return nil
+		}
+		return convertHostFunctionArgument(goType)
+
+	case sema.StringType:
+		if goType.Kind() != reflect.String {
+			return nil
+		}
+		return func(_ *interpreter.Interpreter, _ interpreter.LocationRange, value interpreter.Value) (reflect.Value, error) {
+			stringValue, ok := value.(*interpreter.StringValue)
+			if !ok {
+				return reflect.Value{}, errors.NewUnreachableError()
+			}
+			return reflect.ValueOf(stringValue.Str).Convert(goType), nil
+		}
+
+	case sema.IntType, sema.UIntType:
+		if goType != hostFunctionBigIntType {
+			return nil
+		}
+		return func(inter *interpreter.Interpreter, _ interpreter.LocationRange, value interpreter.Value) (reflect.Value, error) {
+			bigNumberValue, ok := value.(interpreter.BigNumberValue)
+			if !ok {
+				return reflect.Value{}, errors.NewUnreachableError()
+			}
+			return reflect.ValueOf(bigNumberValue.ToBigInt(inter)), nil
+		}
+	}
+
+	integerType, ok := hostFunctionFixedSizeIntegerTypes[parameterType]
+	if !ok || goType.Kind() != integerType.kind {
+		return nil
+	}
+	return convertHostFunctionArgument(goType)
+}
+
+// convertHostFunctionArgument returns an argument converter for values
+// which are defined as the Go type, e.g. `interpreter.BoolValue` and `bool`
+func convertHostFunctionArgument(goType reflect.Type) hostFunctionArgumentConverter {
+	return func(_ *interpreter.Interpreter, _ interpreter.LocationRange, value interpreter.Value) (reflect.Value, error) {
+		return reflect.ValueOf(value).Convert(goType), nil
+	}
+}
+
+func newHostFunctionResultConverter(
+	returnType sema.Type,
+	goType reflect.Type,
+) hostFunctionResultConverter {
+
+	switch goType {
+	case hostFunctionInterpreterValueType:
+		return func(_ *interpreter.Interpreter, _ interpreter.LocationRange, value reflect.Value) (interpreter.Value, error) {
+			if value.IsNil() {
+				return nil, errors.NewUnexpectedError("host function returned no value")
+			}
+			return value.Interface().(interpreter.Value), nil
+		}
+
+	case hostFunctionCadenceValueType:
+		return func(
+			inter *interpreter.Interpreter,
+			locationRange interpreter.LocationRange,
+			value reflect.Value,
+		) (interpreter.Value, error) {
+			if value.IsNil() {
+				return nil, errors.NewUnexpectedError("host function returned no value")
+			}
+			return ImportValue(
+				inter,
+				locationRange,
+				nil,
+				nil,
+				value.Interface().(cadence.Value),
+				returnType,
+			)
+		}
+	}
+
+	switch returnType {
+	case sema.BoolType:
+		if goType.Kind() != reflect.Bool {
+			return nil
+		}
+		return func(_ *interpreter.Interpreter, _ interpreter.LocationRange, value reflect.Value) (interpreter.Value, error) {
+			return interpreter.BoolValue(value.Bool()), nil
+		}
+
+	case sema.TheAddressType:
+		if goType != hostFunctionAddressType {
+			return nil
+		}
+		return func(inter *interpreter.Interpreter, _ interpreter.LocationRange, value reflect.Value) (interpreter.Value, error) {
+			return interpreter.NewAddressValue(inter, value.Interface().(common.Address)), nil
+		}
+
+	case sema.StringType:
+		if goType.Kind() != reflect.String {
+			return nil
+		}
+		return func(inter *interpreter.Interpreter, _ interpreter.LocationRange, value reflect.Value) (interpreter.Value, error) {
+			str := value.String()
+			return interpreter.NewStringValue(
+				inter,
+				common.NewStringMemoryUsage(len(str)),
+				func() string {
+					return str
+				},
+			), nil
+		}
+
+	case sema.IntType:
+		if goType != hostFunctionBigIntType {
+			return nil
+		}
+		return func(inter *interpreter.Interpreter, _ interpreter.LocationRange, value reflect.Value) (interpreter.Value, error) {
+			bigInt := value.Interface().(*big.Int)
+			if bigInt == nil {
+				return nil, errors.NewUnexpectedError("host function returned no value")
+			}
+			return interpreter.NewIntValueFromBigInt(
+				inter,
+				common.NewBigIntMemoryUsage(common.BigIntByteLength(bigInt)),
+				func() *big.Int {
+					return bigInt
+				},
+			), nil
+		}
+
+	case sema.UIntType:
+		if goType != hostFunctionBigIntType {
+			return nil
+		}
+		return func(inter *interpreter.Interpreter, _ interpreter.LocationRange, value reflect.Value) (interpreter.Value, error) {
+			bigInt := value.Interface().(*big.Int)
+			if bigInt == nil {
+				return nil, errors.NewUnexpectedError("host function returned no value")
+			}
+			if bigInt.Sign() < 0 {
+				return nil, errors.NewUnexpectedError("host function returned a negative `UInt`")
+			}
+			return interpreter.NewUIntValueFromBigInt(
+				inter,
+				common.NewBigIntMemoryUsage(common.BigIntByteLength(bigInt)),
+				func() *big.Int {
+					return bigInt
+				},
+			), nil
+		}
+	}
+
+	integerType, ok := hostFunctionFixedSizeIntegerTypes[returnType]
+	if !ok || goType.Kind() != integerType.kind {
+		return nil
+	}
+	return func(inter *interpreter.Interpreter, _ interpreter.LocationRange, value reflect.Value) (interpreter.Value, error) {
+		return integerType.newValue(inter, value), nil
+	}
+}
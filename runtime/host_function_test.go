@@ -0,0 +1,259 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	. "github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/sema"
+	"github.com/onflow/cadence/stdlib"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeHostFunction(t *testing.T) {
+
+	t.Parallel()
+
+	newFunctionType := func(returnType sema.Type, parameterTypes ...sema.Type) *sema.FunctionType {
+		parameters := make([]sema.Parameter, len(parameterTypes))
+		for i, parameterType := range parameterTypes {
+			parameters[i] = sema.Parameter{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     string(rune('a' + i)),
+				TypeAnnotation: sema.NewTypeAnnotation(parameterType),
+			}
+		}
+		return &sema.FunctionType{
+			Parameters:           parameters,
+			ReturnTypeAnnotation: sema.NewTypeAnnotation(returnType),
+		}
+	}
+
+	executeScript := func(script string, valueDeclarations ...stdlib.StandardLibraryValue) (cadence.Value, error) {
+		runtime := NewTestInterpreterRuntime()
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage: NewTestLedger(nil, nil),
+		}
+
+		environment := NewScriptInterpreterEnvironment(Config{})
+		for _, valueDeclaration := range valueDeclarations {
+			environment.DeclareValue(valueDeclaration, nil)
+		}
+
+		return runtime.ExecuteScript(
+			Script{
+				Source: []byte(script),
+			},
+			Context{
+				Interface:   runtimeInterface,
+				Location:    common.ScriptLocation{},
+				Environment: environment,
+			},
+		)
+	}
+
+	t.Run("primitive types", func(t *testing.T) {
+
+		t.Parallel()
+
+		repeat, err := NewHostFunctionValue(
+			"repeat",
+			newFunctionType(sema.StringType, sema.StringType, sema.UInt8Type, sema.BoolType),
+			"",
+			func(s string, count uint8, upper bool) string {
+				var result string
+				for i := uint8(0); i < count; i++ {
+					result += s
+				}
+				if upper {
+					result += "!"
+				}
+				return result
+			},
+		)
+		require.NoError(t, err)
+
+		double, err := NewHostFunctionValue(
+			"double",
+			newFunctionType(sema.IntType, sema.IntType),
+			"",
+			func(i *big.Int) *big.Int {
+				return new(big.Int).Mul(i, big.NewInt(2))
+			},
+		)
+		require.NoError(t, err)
+
+		owner, err := NewHostFunctionValue(
+			"owner",
+			newFunctionType(sema.TheAddressType),
+			"",
+			func() common.Address {
+				return common.MustBytesToAddress([]byte{0x42})
+			},
+		)
+		require.NoError(t, err)
+
+		result, err := executeScript(
+			`
+              access(all) fun main(): [AnyStruct] {
+                  return [repeat("ab", 3, true), double(21), owner()]
+              }
+            `,
+			repeat,
+			double,
+			owner,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]cadence.Value{
+				cadence.String("ababab!"),
+				cadence.NewInt(42),
+				cadence.Address(common.MustBytesToAddress([]byte{0x42})),
+			},
+			result.(cadence.Array).Values,
+		)
+	})
+
+	t.Run("Cadence and interpreter values", func(t *testing.T) {
+
+		t.Parallel()
+
+		stringArrayType := sema.NewVariableSizedType(nil, sema.StringType)
+
+		reverse, err := NewHostFunctionValue(
+			"reverse",
+			newFunctionType(stringArrayType, stringArrayType),
+			"",
+			func(value cadence.Value) (cadence.Value, error) {
+				values := value.(cadence.Array).Values
+				reversed := make([]cadence.Value, len(values))
+				for i, element := range values {
+					reversed[len(values)-1-i] = element
+				}
+				return cadence.NewArray(reversed), nil
+			},
+		)
+		require.NoError(t, err)
+
+		identity, err := NewHostFunctionValue(
+			"identity",
+			newFunctionType(sema.AnyStructType, sema.AnyStructType),
+			"",
+			func(value interpreter.Value) interpreter.Value {
+				return value
+			},
+		)
+		require.NoError(t, err)
+
+		result, err := executeScript(
+			`
+              access(all) fun main(): [String] {
+                  return identity(reverse(["a", "b", "c"])) as! [String]
+              }
+            `,
+			reverse,
+			identity,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]cadence.Value{
+				cadence.String("c"),
+				cadence.String("b"),
+				cadence.String("a"),
+			},
+			result.(cadence.Array).Values,
+		)
+	})
+
+	t.Run("error", func(t *testing.T) {
+
+		t.Parallel()
+
+		hostErr := errors.New("host failure")
+
+		fail, err := NewHostFunctionValue(
+			"fail",
+			newFunctionType(sema.VoidType),
+			"",
+			func() error {
+				return hostErr
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = executeScript(
+			`
+              access(all) fun main() {
+                  fail()
+              }
+            `,
+			fail,
+		)
+		RequireError(t, err)
+
+		require.ErrorIs(t, err, hostErr)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		for name, test := range map[string]struct {
+			functionType *sema.FunctionType
+			function     any
+		}{
+			"not a function": {
+				functionType: newFunctionType(sema.VoidType),
+				function:     42,
+			},
+			"parameter count": {
+				functionType: newFunctionType(sema.VoidType, sema.IntType),
+				function:     func() {},
+			},
+			"parameter type": {
+				functionType: newFunctionType(sema.VoidType, sema.Int8Type),
+				function:     func(_ uint8) {},
+			},
+			"missing result": {
+				functionType: newFunctionType(sema.BoolType),
+				function:     func() {},
+			},
+			"result type": {
+				functionType: newFunctionType(sema.BoolType),
+				function:     func() string { return "" },
+			},
+		} {
+			_, err := NewHostFunctionValue("test", test.functionType, "", test.function)
+			assert.Error(t, err, name)
+		}
+	})
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/ast"
 	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
 	"github.com/onflow/cadence/interpreter"
 	"github.com/onflow/cadence/sema"
 )
@@ -84,6 +85,9 @@ type Interface interface {
 	UpdateAccountContractCode(location common.AddressLocation, code []byte) (err error)
 	// GetAccountContractCode returns the code associated with an account contract.
 	GetAccountContractCode(location common.AddressLocation) (code []byte, err error)
+	// GetAccountContractUpdateHeight returns the height of the block
+	// in which an account contract was last added or updated, if known.
+	GetAccountContractUpdateHeight(location common.AddressLocation) (height uint64, known bool, err error)
 	// RemoveAccountContractCode removes the code associated with an account contract.
 	RemoveAccountContractCode(location common.AddressLocation) (err error)
 	// GetSigningAccounts returns the signing accounts.
@@ -184,3 +188,31 @@ type Metrics interface {
 	ProgramChecked(location Location, duration time.Duration)
 	ProgramInterpreted(location Location, duration time.Duration)
 }
+
+// interfaceWrapper is implemented by the runtime interfaces which wrap another runtime interface,
+// e.g. to record the interactions with it
+type interfaceWrapper interface {
+	unwrap() Interface
+}
+
+// EventSizer may be implemented by a runtime interface which encodes the emitted events.
+// It is required if Config.EventSizeLimit is set:
+// The reported sizes are used to reject an event exceeding the limit before it is emitted
+type EventSizer interface {
+	// EventSize returns the size in bytes of the given event, encoded like when it is emitted.
+	EventSize(cadence.Event) (uint64, error)
+}
+
+// eventSize returns the size of the given encoded event, as reported by the given runtime interface,
+// or an error if the runtime interface does not report the sizes of events, see EventSizer
+func eventSize(runtimeInterface Interface, event cadence.Event) (uint64, error) {
+	eventSizer, ok := runtimeInterface.(EventSizer)
+	if !ok {
+		return 0, errors.NewUnexpectedError(
+			"runtime interface does not report the size of event %s",
+			event.EventType.ID(),
+		)
+	}
+
+	return eventSizer.EventSize(event)
+}
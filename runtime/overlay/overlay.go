@@ -0,0 +1,209 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package overlay provides a copy-on-write ledger:
+// Reads are served from a read-only base snapshot, and writes are recorded in an in-memory overlay,
+// which shadows the snapshot.
+//
+// The changes recorded in the overlay can be extracted after execution, see Ledger.Diff,
+// e.g. to inspect the effects of a transaction without committing them,
+// or to validate the effects of a migration.
+package overlay
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/common"
+)
+
+// Snapshot is a read-only view of the registers of an execution state
+type Snapshot interface {
+	// GetValue returns the value of the register with the given owner and key.
+	// It returns an empty value if the register does not exist.
+	GetValue(owner, key []byte) ([]byte, error)
+	// NextSlabIndex returns the first slab index of the given account
+	// which is not used by the snapshot
+	NextSlabIndex(owner common.Address) (atree.SlabIndex, error)
+}
+
+// Register is a register written to the overlay
+type Register struct {
+	Owner common.Address
+	Key   string
+	// Value is the written value. It is empty if the register was removed
+	Value []byte
+}
+
+// Change is a difference between the overlay and the snapshot
+type Change struct {
+	Owner common.Address
+	Key   string
+	// OldValue is the value of the register in the snapshot.
+	// It is empty if the register was added
+	OldValue []byte
+	// NewValue is the value of the register in the overlay.
+	// It is empty if the register was removed
+	NewValue []byte
+}
+
+type registerKey struct {
+	owner common.Address
+	key   string
+}
+
+// Ledger is a ledger which reads from a snapshot, and writes to an overlay.
+// The snapshot is never modified.
+//
+// A ledger is itself a snapshot, so it can be used as the base of another ledger, see Fork.
+type Ledger struct {
+	base            Snapshot
+	writes          map[registerKey][]byte
+	nextSlabIndices map[common.Address]atree.SlabIndex
+}
+
+var _ atree.Ledger = &Ledger{}
+var _ Snapshot = &Ledger{}
+
+// NewLedger returns a new ledger with an empty overlay on top of the given snapshot
+func NewLedger(base Snapshot) *Ledger {
+	return &Ledger{
+		base:            base,
+		writes:          map[registerKey][]byte{},
+		nextSlabIndices: map[common.Address]atree.SlabIndex{},
+	}
+}
+
+// Base returns the snapshot of the ledger
+func (l *Ledger) Base() Snapshot {
+	return l.base
+}
+
+// Fork returns a new ledger on top of this ledger.
+// Writes to the new ledger do not affect this ledger
+func (l *Ledger) Fork() *Ledger {
+	return NewLedger(l)
+}
+
+func (l *Ledger) GetValue(owner, key []byte) ([]byte, error) {
+	value, ok := l.writes[registerKey{
+		owner: common.MustBytesToAddress(owner),
+		key:   string(key),
+	}]
+	if ok {
+		return value, nil
+	}
+
+	return l.base.GetValue(owner, key)
+}
+
+func (l *Ledger) ValueExists(owner, key []byte) (bool, error) {
+	value, err := l.GetValue(owner, key)
+	if err != nil {
+		return false, err
+	}
+	return len(value) > 0, nil
+}
+
+func (l *Ledger) SetValue(owner, key, value []byte) error {
+	l.writes[registerKey{
+		owner: common.MustBytesToAddress(owner),
+		key:   string(key),
+	}] = value
+	return nil
+}
+
+func (l *Ledger) AllocateSlabIndex(owner []byte) (atree.SlabIndex, error) {
+	address := common.MustBytesToAddress(owner)
+
+	slabIndex, err := l.NextSlabIndex(address)
+	if err != nil {
+		return atree.SlabIndexUndefined, err
+	}
+
+	l.nextSlabIndices[address] = slabIndex.Next()
+
+	return slabIndex, nil
+}
+
+func (l *Ledger) NextSlabIndex(owner common.Address) (atree.SlabIndex, error) {
+	slabIndex, ok := l.nextSlabIndices[owner]
+	if ok {
+		return slabIndex, nil
+	}
+
+	return l.base.NextSlabIndex(owner)
+}
+
+// Writes returns the registers written to the overlay, sorted by owner and key
+func (l *Ledger) Writes() []Register {
+	registers := make([]Register, 0, len(l.writes))
+	for key, value := range l.writes { //nolint:maprange
+		registers = append(registers, Register{
+			Owner: key.owner,
+			Key:   key.key,
+			Value: value,
+		})
+	}
+
+	sort.Slice(registers, func(i, j int) bool {
+		a := registers[i]
+		b := registers[j]
+		if c := a.Owner.Compare(b.Owner); c != 0 {
+			return c < 0
+		}
+		return a.Key < b.Key
+	})
+
+	return registers
+}
+
+// Diff returns the changes of the overlay compared to the snapshot, sorted by owner and key.
+// Writes which did not change the value of a register are omitted
+func (l *Ledger) Diff() ([]Change, error) {
+	var changes []Change
+
+	for _, register := range l.Writes() {
+		oldValue, err := l.base.GetValue(register.Owner[:], []byte(register.Key))
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.Equal(oldValue, register.Value) {
+			continue
+		}
+
+		changes = append(changes, Change{
+			Owner:    register.Owner,
+			Key:      register.Key,
+			OldValue: oldValue,
+			NewValue: register.Value,
+		})
+	}
+
+	return changes, nil
+}
+
+// Reset discards the overlay,
+// so the ledger is in the state of the snapshot again
+func (l *Ledger) Reset() {
+	l.writes = map[registerKey][]byte{}
+	l.nextSlabIndices = map[common.Address]atree.SlabIndex{}
+}
@@ -0,0 +1,177 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package overlay
+
+import (
+	"testing"
+
+	"github.com/onflow/atree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+)
+
+type testSnapshot map[string][]byte
+
+var _ Snapshot = testSnapshot{}
+
+func (s testSnapshot) GetValue(_, key []byte) ([]byte, error) {
+	return s[string(key)], nil
+}
+
+func (s testSnapshot) NextSlabIndex(_ common.Address) (atree.SlabIndex, error) {
+	return atree.SlabIndex{0, 0, 0, 0, 0, 0, 0, 3}, nil
+}
+
+func TestLedger(t *testing.T) {
+
+	t.Parallel()
+
+	owner := common.MustBytesToAddress([]byte{0x1})
+
+	newBase := func() testSnapshot {
+		return testSnapshot{
+			"a": {1},
+			"b": {2},
+			"c": {3},
+		}
+	}
+
+	getValue := func(t *testing.T, snapshot Snapshot, key string) []byte {
+		value, err := snapshot.GetValue(owner[:], []byte(key))
+		require.NoError(t, err)
+		return value
+	}
+
+	setValue := func(t *testing.T, ledger *Ledger, key string, value []byte) {
+		err := ledger.SetValue(owner[:], []byte(key), value)
+		require.NoError(t, err)
+	}
+
+	t.Run("read and write", func(t *testing.T) {
+
+		t.Parallel()
+
+		base := newBase()
+		ledger := NewLedger(base)
+
+		setValue(t, ledger, "a", []byte{4})
+		setValue(t, ledger, "b", nil)
+		setValue(t, ledger, "d", []byte{5})
+
+		assert.Equal(t, []byte{4}, getValue(t, ledger, "a"))
+		assert.Empty(t, getValue(t, ledger, "b"))
+		assert.Equal(t, []byte{3}, getValue(t, ledger, "c"))
+		assert.Equal(t, []byte{5}, getValue(t, ledger, "d"))
+
+		exists, err := ledger.ValueExists(owner[:], []byte("b"))
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		assert.Equal(t, newBase(), base)
+
+		ledger.Reset()
+
+		assert.Empty(t, ledger.Writes())
+		assert.Equal(t, []byte{2}, getValue(t, ledger, "b"))
+	})
+
+	t.Run("diff", func(t *testing.T) {
+
+		t.Parallel()
+
+		ledger := NewLedger(newBase())
+
+		setValue(t, ledger, "d", []byte{5})
+		setValue(t, ledger, "b", nil)
+		setValue(t, ledger, "a", []byte{4})
+		// Unchanged
+		setValue(t, ledger, "c", []byte{3})
+
+		assert.Equal(t,
+			[]Register{
+				{Owner: owner, Key: "a", Value: []byte{4}},
+				{Owner: owner, Key: "b", Value: nil},
+				{Owner: owner, Key: "c", Value: []byte{3}},
+				{Owner: owner, Key: "d", Value: []byte{5}},
+			},
+			ledger.Writes(),
+		)
+
+		diff, err := ledger.Diff()
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]Change{
+				{Owner: owner, Key: "a", OldValue: []byte{1}, NewValue: []byte{4}},
+				{Owner: owner, Key: "b", OldValue: []byte{2}, NewValue: nil},
+				{Owner: owner, Key: "d", OldValue: nil, NewValue: []byte{5}},
+			},
+			diff,
+		)
+	})
+
+	t.Run("fork", func(t *testing.T) {
+
+		t.Parallel()
+
+		ledger := NewLedger(newBase())
+		setValue(t, ledger, "a", []byte{4})
+
+		fork := ledger.Fork()
+		setValue(t, fork, "a", []byte{6})
+		setValue(t, fork, "b", []byte{7})
+
+		assert.Equal(t, []byte{4}, getValue(t, ledger, "a"))
+		assert.Equal(t, []byte{2}, getValue(t, ledger, "b"))
+
+		diff, err := fork.Diff()
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]Change{
+				{Owner: owner, Key: "a", OldValue: []byte{4}, NewValue: []byte{6}},
+				{Owner: owner, Key: "b", OldValue: []byte{2}, NewValue: []byte{7}},
+			},
+			diff,
+		)
+	})
+
+	t.Run("slab indices", func(t *testing.T) {
+
+		t.Parallel()
+
+		ledger := NewLedger(newBase())
+
+		slabIndex, err := ledger.AllocateSlabIndex(owner[:])
+		require.NoError(t, err)
+		assert.Equal(t, atree.SlabIndex{0, 0, 0, 0, 0, 0, 0, 3}, slabIndex)
+
+		fork := ledger.Fork()
+
+		slabIndex, err = fork.AllocateSlabIndex(owner[:])
+		require.NoError(t, err)
+		assert.Equal(t, atree.SlabIndex{0, 0, 0, 0, 0, 0, 0, 4}, slabIndex)
+
+		slabIndex, err = ledger.AllocateSlabIndex(owner[:])
+		require.NoError(t, err)
+		assert.Equal(t, atree.SlabIndex{0, 0, 0, 0, 0, 0, 0, 4}, slabIndex)
+	})
+}
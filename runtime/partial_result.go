@@ -0,0 +1,173 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	goerrors "errors"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/cadence/sema"
+)
+
+// ExecutionPhase is the phase of an execution in which an error occurred
+type ExecutionPhase string
+
+const (
+	ExecutionPhaseUnknown ExecutionPhase = ""
+	// ExecutionPhasePreExecution is the phase before the program is executed,
+	// i.e. parsing, checking, and the validation of the arguments and authorizers
+	ExecutionPhasePreExecution ExecutionPhase = "pre-execution"
+	// ExecutionPhaseExecution is the phase in which the program is executed,
+	// including the commit of the storage
+	ExecutionPhaseExecution ExecutionPhase = "execution"
+)
+
+// PartialResult contains the artifacts that were produced by an execution before it failed
+type PartialResult struct {
+	// Logs are the messages logged before the failure.
+	// Only recorded if Config.PartialResultsEnabled is set
+	Logs []string
+	// Events are the events emitted before the failure.
+	// Only recorded if Config.PartialResultsEnabled is set.
+	// NOTE: the events were already emitted through the runtime interface
+	Events []cadence.Event
+	// Diagnostics are the individual parsing and checking errors, if any
+	Diagnostics []error
+}
+
+// executionPhaseOf returns the phase of the given error,
+// which occurred in the given phase.
+//
+// Arguments are only validated when the program is executed,
+// but invalid arguments are considered a pre-execution failure.
+func executionPhaseOf(err error, phase ExecutionPhase) ExecutionPhase {
+	var parameterCountErr InvalidEntryPointParameterCountError
+	var argumentErr *InvalidEntryPointArgumentError
+	var notImportableErr *ArgumentNotImportableError
+
+	if goerrors.As(err, &parameterCountErr) ||
+		goerrors.As(err, &argumentErr) ||
+		goerrors.As(err, &notImportableErr) {
+
+		return ExecutionPhasePreExecution
+	}
+
+	return phase
+}
+
+// diagnosticsOf returns the individual parsing and checking errors of the given error
+func diagnosticsOf(err error) []error {
+	var parserErr parser.Error
+	if goerrors.As(err, &parserErr) {
+		return parserErr.Errors
+	}
+
+	var checkerErr *sema.CheckerError
+	if goerrors.As(err, &checkerErr) {
+		return checkerErr.Errors
+	}
+
+	return nil
+}
+
+// partialResultRecorder is a runtime interface which records the logs and events
+// produced by an execution, so they can be attached to the error if the execution fails
+type partialResultRecorder struct {
+	Interface
+	logs   []string
+	events []cadence.Event
+}
+
+var _ Interface = &partialResultRecorder{}
+var _ Metrics = &partialResultRecorder{}
+var _ EventSizer = &partialResultRecorder{}
+
+func newPartialResultRecorder(runtimeInterface Interface) *partialResultRecorder {
+	return &partialResultRecorder{
+		Interface: runtimeInterface,
+	}
+}
+
+func (r *partialResultRecorder) ProgramLog(message string) error {
+	err := r.Interface.ProgramLog(message)
+	if err == nil {
+		r.logs = append(r.logs, message)
+	}
+	return err
+}
+
+func (r *partialResultRecorder) EmitEvent(event cadence.Event) error {
+	err := r.Interface.EmitEvent(event)
+	if err == nil {
+		r.events = append(r.events, event)
+	}
+	return err
+}
+
+func (r *partialResultRecorder) unwrap() Interface {
+	return r.Interface
+}
+
+func (r *partialResultRecorder) EventSize(event cadence.Event) (uint64, error) {
+	return eventSize(r.Interface, event)
+}
+
+func (r *partialResultRecorder) ProgramParsed(location Location, duration time.Duration) {
+	if metrics, ok := r.Interface.(Metrics); ok {
+		metrics.ProgramParsed(location, duration)
+	}
+}
+
+func (r *partialResultRecorder) ProgramChecked(location Location, duration time.Duration) {
+	if metrics, ok := r.Interface.(Metrics); ok {
+		metrics.ProgramChecked(location, duration)
+	}
+}
+
+func (r *partialResultRecorder) ProgramInterpreted(location Location, duration time.Duration) {
+	if metrics, ok := r.Interface.(Metrics); ok {
+		metrics.ProgramInterpreted(location, duration)
+	}
+}
+
+// annotateError sets the phase and the partial result of the given error, if it is a runtime error.
+// The phase is only set if it is not set yet, e.g. when the error is a pre-execution error
+// that is returned again when executing.
+func annotateError(err error, phase ExecutionPhase, recorder *partialResultRecorder) error {
+	runtimeErr, ok := err.(Error)
+	if !ok {
+		return err
+	}
+
+	if runtimeErr.Phase == ExecutionPhaseUnknown {
+		runtimeErr.Phase = executionPhaseOf(runtimeErr.Err, phase)
+	}
+
+	runtimeErr.PartialResult = PartialResult{
+		Diagnostics: diagnosticsOf(runtimeErr.Err),
+	}
+	if recorder != nil {
+		runtimeErr.PartialResult.Logs = recorder.logs
+		runtimeErr.PartialResult.Events = recorder.events
+	}
+
+	return runtimeErr
+}
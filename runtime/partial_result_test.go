@@ -0,0 +1,222 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/encoding/json"
+	. "github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeErrorPartialResult(t *testing.T) {
+
+	t.Parallel()
+
+	newRuntimeInterface := func() *TestRuntimeInterface {
+		return &TestRuntimeInterface{
+			Storage: NewTestLedger(nil, nil),
+			OnGetSigningAccounts: func() ([]Address, error) {
+				return nil, nil
+			},
+			OnProgramLog: func(_ string) {},
+			OnEmitEvent: func(_ cadence.Event) error {
+				return nil
+			},
+			OnDecodeArgument: func(b []byte, t cadence.Type) (cadence.Value, error) {
+				return json.Decode(nil, b)
+			},
+		}
+	}
+
+	requireRuntimeError := func(t *testing.T, err error) Error {
+		RequireError(t, err)
+
+		var runtimeErr Error
+		require.ErrorAs(t, err, &runtimeErr)
+		return runtimeErr
+	}
+
+	executeFailingTransaction := func(t *testing.T, partialResultsEnabled bool) Error {
+
+		config := DefaultTestInterpreterConfig
+		config.PartialResultsEnabled = partialResultsEnabled
+
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		address := common.MustBytesToAddress([]byte{0x1})
+		accountCodes := map[Location][]byte{}
+
+		runtimeInterface := newRuntimeInterface()
+		runtimeInterface.OnGetSigningAccounts = func() ([]Address, error) {
+			return []Address{address}, nil
+		}
+		runtimeInterface.OnResolveLocation = NewSingleIdentifierLocationResolver(t)
+		runtimeInterface.OnUpdateAccountContractCode = func(location common.AddressLocation, code []byte) error {
+			accountCodes[location] = code
+			return nil
+		}
+		runtimeInterface.OnGetAccountContractCode = func(location common.AddressLocation) ([]byte, error) {
+			return accountCodes[location], nil
+		}
+
+		nextTransactionLocation := NewTransactionLocationGenerator()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: DeploymentTransaction(
+					"Test",
+					[]byte(`
+                      access(all) contract Test {
+
+                          access(all) event Tested()
+
+                          access(all) fun test() {
+                              emit Tested()
+                          }
+                      }
+                    `),
+				),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		err = runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(`
+                  import Test from 0x1
+
+                  transaction {
+
+                      prepare(signer: &Account) {}
+
+                      execute {
+                          log("before")
+                          Test.test()
+                          panic("failure")
+                      }
+                  }
+                `),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		return requireRuntimeError(t, err)
+	}
+
+	t.Run("execution failure", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeErr := executeFailingTransaction(t, true)
+
+		assert.Equal(t, ExecutionPhaseExecution, runtimeErr.Phase)
+		assert.Equal(t, []string{`"before"`}, runtimeErr.PartialResult.Logs)
+		require.Len(t, runtimeErr.PartialResult.Events, 1)
+		assert.Equal(t,
+			"A.0000000000000001.Test.Tested",
+			runtimeErr.PartialResult.Events[0].EventType.ID(),
+		)
+		assert.Empty(t, runtimeErr.PartialResult.Diagnostics)
+	})
+
+	t.Run("execution failure, partial results disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtimeErr := executeFailingTransaction(t, false)
+
+		assert.Equal(t, ExecutionPhaseExecution, runtimeErr.Phase)
+		assert.Empty(t, runtimeErr.PartialResult.Logs)
+		assert.Empty(t, runtimeErr.PartialResult.Events)
+	})
+
+	t.Run("checking failure", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := NewTestInterpreterRuntime()
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(`
+                  access(all) fun main(): Int {
+                      let x: Int = "one"
+                      return y
+                  }
+                `),
+			},
+			Context{
+				Interface: newRuntimeInterface(),
+				Location:  common.ScriptLocation{},
+			},
+		)
+		runtimeErr := requireRuntimeError(t, err)
+
+		assert.Equal(t, ExecutionPhasePreExecution, runtimeErr.Phase)
+		assert.Empty(t, runtimeErr.PartialResult.Logs)
+
+		diagnostics := runtimeErr.PartialResult.Diagnostics
+		require.Len(t, diagnostics, 2)
+		assert.IsType(t, &sema.TypeMismatchError{}, diagnostics[0])
+		assert.IsType(t, &sema.NotDeclaredError{}, diagnostics[1])
+	})
+
+	t.Run("argument failure", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := NewTestInterpreterRuntime()
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(`
+                  access(all) fun main(x: Int): Int {
+                      return x
+                  }
+                `),
+				Arguments: encodeArgs([]cadence.Value{
+					cadence.String("one"),
+				}),
+			},
+			Context{
+				Interface: newRuntimeInterface(),
+				Location:  common.ScriptLocation{},
+			},
+		)
+		runtimeErr := requireRuntimeError(t, err)
+
+		assert.Equal(t, ExecutionPhasePreExecution, runtimeErr.Phase)
+		assert.Empty(t, runtimeErr.PartialResult.Diagnostics)
+	})
+}
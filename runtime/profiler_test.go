@@ -0,0 +1,100 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeProfiler(t *testing.T) {
+
+	t.Parallel()
+
+	profiler := interpreter.NewProfiler()
+
+	config := DefaultTestInterpreterConfig
+	config.Profiler = profiler
+	runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	runtimeInterface := &TestRuntimeInterface{
+		Storage: NewTestLedger(nil, nil),
+		OnGetSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+	}
+
+	location := NewTransactionLocationGenerator()()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(`
+              transaction {
+                  prepare(signer: &Account) {
+                      var i = 0
+                      while i < 3 {
+                          i = i + 1
+                      }
+                  }
+
+                  execute {
+                      let x = 1
+                  }
+              }
+            `),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  location,
+		},
+	)
+	require.NoError(t, err)
+
+	samples := profiler.Samples()
+
+	var functions []string
+	computation := map[string]uint64{}
+	for _, sample := range samples {
+		require.Len(t, sample.Stack, 1)
+		frame := sample.Stack[0]
+		assert.Equal(t, location, frame.Location)
+
+		functions = append(functions, frame.Function)
+		computation[frame.Function] = sample.Computation
+	}
+
+	assert.Equal(t,
+		[]string{
+			"transaction.prepare",
+			"transaction.execute",
+		},
+		functions,
+	)
+
+	assert.Greater(t, computation["transaction.prepare"], computation["transaction.execute"])
+}
@@ -0,0 +1,189 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/parser"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeProgramTransformer(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	contract := []byte(`
+      access(all) contract Foo {
+
+          access(all) fun answer(): Int {
+              return 42
+          }
+      }
+    `)
+
+	script := []byte(`
+      import Foo from 0x1
+
+      access(all) fun main(): Int {
+          return Foo.answer()
+      }
+    `)
+
+	// instrument injects a log statement into each function of each composite
+	instrument := func(program *ast.Program, location Location) (*ast.Program, error) {
+		for _, composite := range program.CompositeDeclarations() {
+			for _, function := range composite.Members.Functions() {
+				name := composite.Identifier.Identifier + "." + function.Identifier.Identifier
+
+				statements, errs := parser.ParseStatements(
+					nil,
+					[]byte(`log("`+name+`")`),
+					parser.Config{},
+				)
+				if len(errs) > 0 {
+					return nil, errs[0]
+				}
+
+				block := function.FunctionBlock.Block
+				block.Statements = append(statements, block.Statements...)
+			}
+		}
+		return program, nil
+	}
+
+	t.Run("instrumentation", func(t *testing.T) {
+
+		t.Parallel()
+
+		var transformedLocations []Location
+
+		config := DefaultTestInterpreterConfig
+		config.TestingEnabled = true
+		config.ProgramTransformer = func(program *ast.Program, location Location) (*ast.Program, error) {
+			transformedLocations = append(transformedLocations, location)
+			return instrument(program, location)
+		}
+
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		accountCodes := map[Location][]byte{}
+		var logs []string
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage: NewTestLedger(nil, nil),
+			OnGetSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			OnResolveLocation: NewSingleIdentifierLocationResolver(t),
+			OnUpdateAccountContractCode: func(location common.AddressLocation, code []byte) error {
+				accountCodes[location] = code
+				return nil
+			},
+			OnGetAccountContractCode: func(location common.AddressLocation) (code []byte, err error) {
+				return accountCodes[location], nil
+			},
+			OnEmitEvent: func(_ cadence.Event) error {
+				return nil
+			},
+			OnProgramLog: func(message string) {
+				logs = append(logs, message)
+			},
+		}
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: DeploymentTransaction("Foo", contract),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  NewTransactionLocationGenerator()(),
+			},
+		)
+		require.NoError(t, err)
+
+		// Deployed contracts are not transformed
+		assert.Empty(t, transformedLocations)
+
+		result, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt(42), result)
+		assert.Equal(t, []string{`"Foo.answer"`}, logs)
+
+		// Only the imported program is transformed
+		assert.Equal(
+			t,
+			[]Location{
+				common.AddressLocation{
+					Address: address,
+					Name:    "Foo",
+				},
+			},
+			transformedLocations,
+		)
+	})
+
+	t.Run("testing not enabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		config := DefaultTestInterpreterConfig
+		config.ProgramTransformer = instrument
+
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage: NewTestLedger(nil, nil),
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(`access(all) fun main() {}`),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		RequireError(t, err)
+
+		var unexpectedErr errors.UnexpectedError
+		require.ErrorAs(t, err, &unexpectedErr)
+	})
+}
@@ -0,0 +1,285 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeReadOnlyScripts(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	const contract = `
+      access(all) contract Test {
+
+          access(all) event Ping()
+
+          access(all) var counter: Int
+
+          init() {
+              self.counter = 0
+          }
+
+          access(all) fun increment() {
+              self.counter = self.counter + 1
+          }
+
+          access(all) fun ping() {
+              emit Ping()
+          }
+      }
+    `
+
+	const setupTx = `
+      transaction {
+          prepare(signer: auth(Storage) &Account) {
+              signer.storage.save([1, 2, 3], to: /storage/values)
+          }
+      }
+    `
+
+	execute := func(t *testing.T, readOnly bool, script string) (cadence.Value, error) {
+
+		config := DefaultTestInterpreterConfig
+		config.ReadOnlyScriptsEnabled = readOnly
+
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		accountCodes := map[Location][]byte{}
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage:           NewTestLedger(nil, nil),
+			OnResolveLocation: NewSingleIdentifierLocationResolver(t),
+			OnGetSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			OnUpdateAccountContractCode: func(location common.AddressLocation, code []byte) error {
+				accountCodes[location] = code
+				return nil
+			},
+			OnGetAccountContractCode: func(location common.AddressLocation) (code []byte, err error) {
+				return accountCodes[location], nil
+			},
+			OnRemoveAccountContractCode: func(location common.AddressLocation) error {
+				delete(accountCodes, location)
+				return nil
+			},
+			OnEmitEvent: func(_ cadence.Event) error {
+				return nil
+			},
+			OnCreateAccount: func(_ Address) (Address, error) {
+				return common.MustBytesToAddress([]byte{0x2}), nil
+			},
+		}
+
+		nextTransactionLocation := NewTransactionLocationGenerator()
+
+		for _, tx := range [][]byte{
+			DeploymentTransaction("Test", []byte(contract)),
+			[]byte(setupTx),
+		} {
+			// Transactions are not affected by the read-only mode of scripts
+			err := runtime.ExecuteTransaction(
+				Script{
+					Source: tx,
+				},
+				Context{
+					Interface: runtimeInterface,
+					Location:  nextTransactionLocation(),
+				},
+			)
+			require.NoError(t, err)
+		}
+
+		return runtime.ExecuteScript(
+			Script{
+				Source: []byte(script),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+	}
+
+	requireReadOnlyViolationError := func(
+		t *testing.T,
+		err error,
+		operation interpreter.ReadOnlyOperation,
+	) {
+		RequireError(t, err)
+
+		var readOnlyErr interpreter.ReadOnlyViolationError
+		require.ErrorAs(t, err, &readOnlyErr)
+		assert.Equal(t, operation, readOnlyErr.Operation)
+	}
+
+	t.Run("reads", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, err := execute(t, true, `
+          import Test from 0x1
+
+          access(all) fun main(): Int {
+              let account = getAuthAccount<auth(Storage) &Account>(0x1)
+              let values = account.storage.borrow<&[Int]>(from: /storage/values)!
+
+              // Mutations of values which are not stored are allowed
+              let copy = *values
+              copy.append(4)
+
+              return copy.length + values.length + Test.counter
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt(7), result)
+	})
+
+	const saveScript = `
+      access(all) fun main() {
+          let account = getAuthAccount<auth(Storage) &Account>(0x1)
+          account.storage.save(1, to: /storage/one)
+      }
+    `
+
+	t.Run("save, disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(t, false, saveScript)
+		require.NoError(t, err)
+	})
+
+	t.Run("save", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(t, true, saveScript)
+		requireReadOnlyViolationError(t, err, interpreter.ReadOnlyOperationStorageWrite)
+	})
+
+	t.Run("load", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(t, true, `
+          access(all) fun main() {
+              let account = getAuthAccount<auth(Storage) &Account>(0x1)
+              account.storage.load<[Int]>(from: /storage/values)
+          }
+        `)
+		requireReadOnlyViolationError(t, err, interpreter.ReadOnlyOperationStorageWrite)
+	})
+
+	t.Run("mutation through reference", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(t, true, `
+          access(all) fun main() {
+              let account = getAuthAccount<auth(Storage) &Account>(0x1)
+              let values = account.storage.borrow<auth(Mutate) &[Int]>(from: /storage/values)!
+              values.append(4)
+          }
+        `)
+		requireReadOnlyViolationError(t, err, interpreter.ReadOnlyOperationStorageWrite)
+	})
+
+	t.Run("contract field", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(t, true, `
+          import Test from 0x1
+
+          access(all) fun main() {
+              Test.increment()
+          }
+        `)
+		requireReadOnlyViolationError(t, err, interpreter.ReadOnlyOperationStorageWrite)
+	})
+
+	t.Run("event", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(t, true, `
+          import Test from 0x1
+
+          access(all) fun main() {
+              Test.ping()
+          }
+        `)
+		requireReadOnlyViolationError(t, err, interpreter.ReadOnlyOperationEventEmission)
+	})
+
+	t.Run("account creation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(t, true, `
+          access(all) fun main() {
+              let payer = getAuthAccount<auth(BorrowValue) &Account>(0x1)
+              Account(payer: payer)
+          }
+        `)
+		requireReadOnlyViolationError(t, err, interpreter.ReadOnlyOperationAccountMutation)
+	})
+
+	t.Run("key revocation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(t, true, `
+          access(all) fun main() {
+              let account = getAuthAccount<auth(RevokeKey) &Account>(0x1)
+              account.keys.revoke(keyIndex: 0)
+          }
+        `)
+		requireReadOnlyViolationError(t, err, interpreter.ReadOnlyOperationAccountMutation)
+	})
+
+	t.Run("contract removal", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(t, true, `
+          access(all) fun main() {
+              let account = getAuthAccount<auth(RemoveContract) &Account>(0x1)
+              account.contracts.remove(name: "Test")
+          }
+        `)
+		requireReadOnlyViolationError(t, err, interpreter.ReadOnlyOperationAccountMutation)
+	})
+}
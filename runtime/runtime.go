@@ -144,6 +144,13 @@ type Runtime interface {
 	// This function returns an error if the program contains any syntax or semantic errors.
 	ParseAndCheckProgram(source []byte, context Context) (*interpreter.Program, error)
 
+	// ContractDeclaredTypes returns all types declared by the contract deployed at the given location,
+	// including nested types, e.g. composites, interfaces, events, and entitlements.
+	//
+	// Returns nil if no contract is deployed at the given location.
+	// This function returns an error if the contract cannot be parsed and checked.
+	ContractDeclaredTypes(location common.AddressLocation, context Context) ([]DeclaredType, error)
+
 	// ReadStored reads the value stored at the given path
 	//
 	ReadStored(address common.Address, path cadence.Path, context Context) (cadence.Value, error)
@@ -550,6 +557,58 @@ func (r *interpreterRuntime) ParseAndCheckProgram(
 	return program, nil
 }
 
+func (r *interpreterRuntime) ContractDeclaredTypes(
+	location common.AddressLocation,
+	context Context,
+) (
+	declaredTypes []DeclaredType,
+	err error,
+) {
+	codesAndPrograms := NewCodesAndPrograms()
+
+	defer r.Recover(
+		func(internalErr Error) {
+			err = internalErr
+		},
+		location,
+		codesAndPrograms,
+	)
+
+	var code []byte
+	errors.WrapPanic(func() {
+		code, err = context.Interface.GetAccountContractCode(location)
+	})
+	if err != nil {
+		return nil, newError(interpreter.WrappedExternalError(err), location, codesAndPrograms)
+	}
+
+	if len(code) == 0 {
+		return nil, nil
+	}
+
+	environment := context.Environment
+	if environment == nil {
+		environment = NewBaseInterpreterEnvironment(r.defaultConfig)
+	}
+	environment.Configure(
+		context.Interface,
+		codesAndPrograms,
+		nil,
+		context.CoverageReport,
+	)
+
+	program, err := environment.ParseAndCheckProgram(
+		code,
+		location,
+		true,
+	)
+	if err != nil {
+		return nil, newError(err, location, codesAndPrograms)
+	}
+
+	return program.Elaboration.DeclaredTypes(), nil
+}
+
 type InterpretFunc func(inter *interpreter.Interpreter) (interpreter.Value, error)
 
 func (r *interpreterRuntime) Storage(context Context) (*Storage, *interpreter.Interpreter, error) {
@@ -565,6 +624,7 @@ func (r *interpreterRuntime) Storage(context Context) (*Storage, *interpreter.In
 		runtimeInterface,
 		StorageConfig{
 			StorageFormatV2Enabled: r.defaultConfig.StorageFormatV2Enabled,
+			WriteLimits:            r.defaultConfig.StorageWriteLimits,
 		},
 	)
 
@@ -34,6 +34,7 @@ type interpreterScriptExecutorPreparation struct {
 	program                *interpreter.Program
 	storage                *Storage
 	interpret              InterpretFunc
+	partialResult          *partialResultRecorder
 	preprocessOnce         sync.Once
 }
 
@@ -66,7 +67,11 @@ func newInterpreterScriptExecutor(
 
 func (executor *interpreterScriptExecutor) Preprocess() error {
 	executor.preprocessOnce.Do(func() {
-		executor.preprocessErr = executor.preprocess()
+		executor.preprocessErr = annotateError(
+			executor.preprocess(),
+			ExecutionPhasePreExecution,
+			executor.partialResult,
+		)
 	})
 
 	return executor.preprocessErr
@@ -74,7 +79,13 @@ func (executor *interpreterScriptExecutor) Preprocess() error {
 
 func (executor *interpreterScriptExecutor) Execute() error {
 	executor.executeOnce.Do(func() {
-		executor.result, executor.executeErr = executor.execute()
+		var err error
+		executor.result, err = executor.execute()
+		executor.executeErr = annotateError(
+			err,
+			ExecutionPhaseExecution,
+			executor.partialResult,
+		)
 	})
 
 	return executor.executeErr
@@ -106,12 +117,55 @@ func (executor *interpreterScriptExecutor) preprocess() (err error) {
 	)
 
 	runtimeInterface := context.Interface
+	if interpreterRuntime.defaultConfig.PartialResultsEnabled {
+		partialResult := newPartialResultRecorder(runtimeInterface)
+		executor.partialResult = partialResult
+		runtimeInterface = partialResult
+	}
+	if uuidGeneratorProvider := interpreterRuntime.defaultConfig.UUIDGeneratorProvider; uuidGeneratorProvider != nil {
+		runtimeInterface = newUUIDGeneratorInterface(
+			uuidGeneratorProvider(location),
+			runtimeInterface,
+		)
+	}
+	if context.ExecutionTrace != nil {
+		runtimeInterface = newExecutionTraceRecorder(
+			context.ExecutionTrace,
+			ExecutionTraceKindScript,
+			location,
+			script,
+			runtimeInterface,
+		)
+	}
+	if context.Suspension != nil {
+		runtimeInterface, err = newExecutionSuspender(
+			context.Suspension,
+			ExecutionTraceKindScript,
+			location,
+			script,
+			runtimeInterface,
+		)
+		if err != nil {
+			return newError(err, location, codesAndPrograms)
+		}
+	}
+	if context.Progress != nil {
+		runtimeInterface = newProgressReporter(context.Progress, runtimeInterface)
+	}
+
+	// Decoded slabs can only be shared safely if the script is read-only
+	var sharedSlabCache *SharedSlabCache
+	if interpreterRuntime.defaultConfig.ReadOnlyScriptsEnabled {
+		sharedSlabCache = context.SharedSlabCache
+	}
 
 	storage := NewStorage(
 		runtimeInterface,
 		runtimeInterface,
 		StorageConfig{
 			StorageFormatV2Enabled: interpreterRuntime.defaultConfig.StorageFormatV2Enabled,
+			WriteLimits:            interpreterRuntime.defaultConfig.StorageWriteLimits,
+			SharedSlabCache:        sharedSlabCache,
 		},
 	)
 	executor.storage = storage
@@ -0,0 +1,153 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"sync"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/common"
+)
+
+// SharedSlabCache is a cache of the decoded slabs of an immutable execution state snapshot,
+// e.g. the state at a certain block, which is shared by concurrent executions of scripts
+// against the snapshot, see Context.SharedSlabCache.
+//
+// A slab is decoded by the first execution which reads it, and is then reused by all other executions,
+// so executions do not need to decode the slabs they read again.
+//
+// Sharing decoded slabs is only safe if they are never modified.
+// Therefore the cache is only used by read-only executions, see Config.ReadOnlyScriptsEnabled,
+// and stored values which have mutable state are copied when they are read,
+// see interpreter.SharedSlabStorage.
+//
+// All executions which share a cache must read the same snapshot.
+// The memory used by decoding a slab is recorded when the slab is decoded,
+// and is metered again for every other execution which reads the slab from the cache,
+// so the metered memory of an execution does not depend on the executions it shares the cache with.
+//
+// Atree only modifies slabs when values are mutated, e.g. in OrderedMap.Set and Array.Set,
+// which read-only executions reject. TestRuntimeSharedSlabCache ensures that the cached slabs
+// are still encoded to the stored data after they were read.
+type SharedSlabCache struct {
+	lock  sync.RWMutex
+	slabs map[atree.SlabID]sharedSlab
+}
+
+// sharedSlab is a cached slab and the memory used by decoding it
+type sharedSlab struct {
+	slab         atree.Slab
+	memoryUsages []common.MemoryUsage
+}
+
+// NewSharedSlabCache returns a new, empty cache.
+func NewSharedSlabCache() *SharedSlabCache {
+	return &SharedSlabCache{
+		slabs: map[atree.SlabID]sharedSlab{},
+	}
+}
+
+// Count returns the number of cached slabs.
+func (c *SharedSlabCache) Count() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return len(c.slabs)
+}
+
+// ForEach calls the given function for each cached slab, in no particular order,
+// e.g. to inspect or validate the cache. The slabs must not be modified.
+func (c *SharedSlabCache) ForEach(f func(id atree.SlabID, slab atree.Slab)) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	for id, cached := range c.slabs { //nolint:maprange
+		f(id, cached.slab)
+	}
+}
+
+func (c *SharedSlabCache) get(id atree.SlabID) (sharedSlab, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	cached, ok := c.slabs[id]
+	return cached, ok
+}
+
+// add adds the given slab to the cache, unless another execution already added it concurrently.
+// It returns the cached slab.
+func (c *SharedSlabCache) add(id atree.SlabID, slab sharedSlab) sharedSlab {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if existing, ok := c.slabs[id]; ok {
+		return existing
+	}
+
+	c.slabs[id] = slab
+	return slab
+}
+
+// slabDecodingGauge is a memory gauge which records the memory used by decoding a slab,
+// so it can be metered again when the slab is read from the shared cache
+type slabDecodingGauge struct {
+	gauge     common.MemoryGauge
+	usages    []common.MemoryUsage
+	recording bool
+}
+
+var _ common.MemoryGauge = &slabDecodingGauge{}
+
+func newSlabDecodingGauge(gauge common.MemoryGauge) *slabDecodingGauge {
+	return &slabDecodingGauge{
+		gauge: gauge,
+	}
+}
+
+func (g *slabDecodingGauge) MeterMemory(usage common.MemoryUsage) error {
+	if g.recording {
+		g.record(usage)
+	}
+	return g.gauge.MeterMemory(usage)
+}
+
+// record records the given usage, summed up by kind,
+// so the memory of the cache does not grow with the number of values in a slab
+func (g *slabDecodingGauge) record(usage common.MemoryUsage) {
+	for i := range g.usages {
+		if g.usages[i].Kind == usage.Kind {
+			g.usages[i].Amount += usage.Amount
+			return
+		}
+	}
+	g.usages = append(g.usages, usage)
+}
+
+func (g *slabDecodingGauge) startRecording() {
+	g.recording = true
+	g.usages = nil
+}
+
+func (g *slabDecodingGauge) stopRecording() []common.MemoryUsage {
+	usages := g.usages
+	g.recording = false
+	g.usages = nil
+	return usages
+}
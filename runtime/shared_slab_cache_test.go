@@ -0,0 +1,286 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeSharedSlabCache(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	const contract = `
+      access(all) contract Test {
+
+          access(all) struct S {
+              access(all) let name: String
+              access(all) let values: {String: [Int]}
+
+              init(name: String, values: {String: [Int]}) {
+                  self.name = name
+                  self.values = values
+              }
+          }
+      }
+    `
+
+	const setupTx = `
+      import Test from 0x1
+
+      transaction {
+          prepare(signer: auth(Storage, StorageCapabilities) &Account) {
+              signer.storage.save(
+                  Test.S(
+                      name: "café",
+                      values: {"a": [1, 2, 3], "b": [4]}
+                  ),
+                  to: /storage/s
+              )
+              let cap = signer.capabilities.storage.issue<&Test.S>(/storage/s)
+              signer.capabilities.storage.getController(byCapabilityID: cap.id)!.setTag("tag")
+          }
+      }
+    `
+
+	const script = `
+      import Test from 0x1
+
+      access(all) fun main(): Int {
+          let account = getAuthAccount<auth(Storage, StorageCapabilities) &Account>(0x1)
+          let s = account.storage.borrow<&Test.S>(from: /storage/s)!
+          let controller = account.capabilities.storage.getController(byCapabilityID: 1)!
+          return s.name.length
+              + s.values["a"]!.length
+              + s.values["b"]!.length
+              + controller.tag.length
+              + controller.capability.borrow<&Test.S>()!.name.length
+      }
+    `
+
+	const expectedResult = 4 + 3 + 1 + 3 + 4
+
+	// Set up the snapshot
+
+	ledger := NewTestLedger(nil, nil)
+	accountCodes := map[Location][]byte{}
+
+	newRuntimeInterface := func() *TestRuntimeInterface {
+		return &TestRuntimeInterface{
+			Storage: NewTestLedgerWithData(
+				nil,
+				nil,
+				ledger.StoredValues,
+				ledger.StorageIndices,
+			),
+			OnResolveLocation: NewSingleIdentifierLocationResolver(t),
+			OnGetSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			OnUpdateAccountContractCode: func(location common.AddressLocation, code []byte) error {
+				accountCodes[location] = code
+				return nil
+			},
+			OnGetAccountContractCode: func(location common.AddressLocation) (code []byte, err error) {
+				return accountCodes[location], nil
+			},
+			OnEmitEvent: func(_ cadence.Event) error {
+				return nil
+			},
+			OnGenerateAccountID: func(_ common.Address) (uint64, error) {
+				return 1, nil
+			},
+		}
+	}
+
+	setupRuntime := NewTestInterpreterRuntime()
+	setupInterface := newRuntimeInterface()
+	nextTransactionLocation := NewTransactionLocationGenerator()
+
+	for _, tx := range [][]byte{
+		DeploymentTransaction("Test", []byte(contract)),
+		[]byte(setupTx),
+	} {
+		err := setupRuntime.ExecuteTransaction(
+			Script{
+				Source: tx,
+			},
+			Context{
+				Interface: setupInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	executeScriptWithInterface := func(
+		readOnly bool,
+		cache *SharedSlabCache,
+		script string,
+		runtimeInterface *TestRuntimeInterface,
+	) (cadence.Value, error) {
+		config := DefaultTestInterpreterConfig
+		config.ReadOnlyScriptsEnabled = readOnly
+
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		return runtime.ExecuteScript(
+			Script{
+				Source: []byte(script),
+			},
+			Context{
+				Interface:       runtimeInterface,
+				Location:        common.ScriptLocation{},
+				SharedSlabCache: cache,
+			},
+		)
+	}
+
+	executeScript := func(readOnly bool, cache *SharedSlabCache, script string) (cadence.Value, error) {
+		return executeScriptWithInterface(readOnly, cache, script, newRuntimeInterface())
+	}
+
+	t.Run("concurrent scripts", func(t *testing.T) {
+
+		t.Parallel()
+
+		cache := NewSharedSlabCache()
+
+		const executions = 10
+
+		results := make([]cadence.Value, executions)
+		errs := make([]error, executions)
+
+		var wg sync.WaitGroup
+		for i := 0; i < executions; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], errs[i] = executeScript(true, cache, script)
+			}(i)
+		}
+		wg.Wait()
+
+		for i := 0; i < executions; i++ {
+			require.NoError(t, errs[i])
+			assert.Equal(t, cadence.NewInt(expectedResult), results[i])
+		}
+
+		assert.Greater(t, cache.Count(), 0)
+
+		// Reading the slabs did not modify them:
+		// they are still encoded to the data they were decoded from
+
+		cache.ForEach(func(id atree.SlabID, slab atree.Slab) {
+			encoded, err := atree.EncodeSlab(slab, interpreter.CBOREncMode)
+			require.NoError(t, err)
+
+			address := id.Address()
+			key := atree.SlabIndexToLedgerKey(id.Index())
+			stored, err := ledger.GetValue(address[:], key)
+			require.NoError(t, err)
+
+			assert.Equal(t, stored, encoded, id.String())
+		})
+	})
+
+	t.Run("memory metering", func(t *testing.T) {
+
+		t.Parallel()
+
+		meteredMemory := func(cache *SharedSlabCache) map[common.MemoryKind]uint64 {
+			memory := map[common.MemoryKind]uint64{}
+
+			runtimeInterface := newRuntimeInterface()
+			runtimeInterface.OnMeterMemory = func(usage common.MemoryUsage) error {
+				memory[usage.Kind] += usage.Amount
+				return nil
+			}
+
+			result, err := executeScriptWithInterface(true, cache, script, runtimeInterface)
+			require.NoError(t, err)
+			assert.Equal(t, cadence.NewInt(expectedResult), result)
+
+			return memory
+		}
+
+		withoutCache := meteredMemory(nil)
+
+		// The first execution decodes the slabs,
+		// the second execution reads them from the cache.
+		// Both are metered the same as an execution without a cache
+
+		cache := NewSharedSlabCache()
+
+		assert.Equal(t, withoutCache, meteredMemory(cache))
+		assert.Equal(t, withoutCache, meteredMemory(cache))
+	})
+
+	t.Run("mutation", func(t *testing.T) {
+
+		t.Parallel()
+
+		cache := NewSharedSlabCache()
+
+		_, err := executeScript(true, cache, `
+          import Test from 0x1
+
+          access(all) fun main() {
+              let account = getAuthAccount<auth(Storage) &Account>(0x1)
+              account.storage.load<Test.S>(from: /storage/s)
+          }
+        `)
+		RequireError(t, err)
+
+		var readOnlyErr interpreter.ReadOnlyViolationError
+		require.ErrorAs(t, err, &readOnlyErr)
+
+		result, err := executeScript(true, cache, script)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(expectedResult), result)
+	})
+
+	t.Run("not read-only", func(t *testing.T) {
+
+		t.Parallel()
+
+		cache := NewSharedSlabCache()
+
+		result, err := executeScript(false, cache, script)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(expectedResult), result)
+
+		assert.Equal(t, 0, cache.Count())
+	})
+}
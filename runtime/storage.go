@@ -38,6 +38,11 @@ const (
 
 type StorageConfig struct {
 	StorageFormatV2Enabled bool
+	// WriteLimits bounds the writes of an execution to the ledger
+	WriteLimits StorageWriteLimits
+	// SharedSlabCache, if set, is the cache of decoded slabs which is shared with other executions.
+	// The execution must be read-only, see SharedSlabCache
+	SharedSlabCache *SharedSlabCache
 }
 
 type StorageFormat uint8
@@ -72,10 +77,18 @@ type Storage struct {
 	AccountStorageV1      *AccountStorageV1
 	AccountStorageV2      *AccountStorageV2
 	scheduledV2Migrations []common.Address
+
+	// sharedSlabs are the slabs which were read from the shared slab cache by this execution,
+	// see StorageConfig.SharedSlabCache
+	sharedSlabs map[atree.SlabID]atree.Slab
+	// slabDecodingGauge records the memory used by decoding the slabs which are added to the shared slab cache.
+	// Nil if the storage does not share slabs, or memory is not metered
+	slabDecodingGauge *slabDecodingGauge
 }
 
 var _ atree.SlabStorage = &Storage{}
 var _ interpreter.Storage = &Storage{}
+var _ interpreter.SharedSlabStorage = &Storage{}
 
 func NewPersistentSlabStorage(
 	ledger atree.Ledger,
@@ -117,31 +130,135 @@ func NewStorage(
 	memoryGauge common.MemoryGauge,
 	config StorageConfig,
 ) *Storage {
-	persistentSlabStorage := NewPersistentSlabStorage(ledger, memoryGauge)
+	if config.WriteLimits.enabled() {
+		ledger = newWriteLimitingLedger(ledger, config.WriteLimits)
+	}
+
+	// The memory used by decoding shared slabs is metered again
+	// when other executions read them from the shared slab cache
+	var decodingGauge common.MemoryGauge = memoryGauge
+	var slabDecodingGauge *slabDecodingGauge
+	if config.SharedSlabCache != nil && memoryGauge != nil {
+		slabDecodingGauge = newSlabDecodingGauge(memoryGauge)
+		decodingGauge = slabDecodingGauge
+	}
+
+	persistentSlabStorage := NewPersistentSlabStorage(ledger, decodingGauge)
+
+	storage := &Storage{
+		Ledger:                ledger,
+		PersistentSlabStorage: persistentSlabStorage,
+		memoryGauge:           memoryGauge,
+		Config:                config,
+		slabDecodingGauge:     slabDecodingGauge,
+	}
+
+	// Account storage maps must retrieve slabs through the storage,
+	// so that the slabs are shared, if enabled
+	var slabStorage atree.SlabStorage = persistentSlabStorage
+	if storage.SharesSlabs() {
+		slabStorage = storage
+	}
 
-	accountStorageV1 := NewAccountStorageV1(
+	storage.AccountStorageV1 = NewAccountStorageV1(
 		ledger,
-		persistentSlabStorage,
+		slabStorage,
 		memoryGauge,
 	)
 
-	var accountStorageV2 *AccountStorageV2
 	if config.StorageFormatV2Enabled {
-		accountStorageV2 = NewAccountStorageV2(
+		storage.AccountStorageV2 = NewAccountStorageV2(
 			ledger,
-			persistentSlabStorage,
+			slabStorage,
 			memoryGauge,
 		)
 	}
 
-	return &Storage{
-		Ledger:                ledger,
-		PersistentSlabStorage: persistentSlabStorage,
-		memoryGauge:           memoryGauge,
-		Config:                config,
-		AccountStorageV1:      accountStorageV1,
-		AccountStorageV2:      accountStorageV2,
+	return storage
+}
+
+// SharesSlabs returns true if the storage shares the decoded slabs of accounts with other executions,
+// see StorageConfig.SharedSlabCache
+func (s *Storage) SharesSlabs() bool {
+	return s.Config.SharedSlabCache != nil
+}
+
+// Retrieve returns the slab with the given ID.
+//
+// If the storage shares slabs, the slabs of accounts are retrieved from the shared cache,
+// and slabs which are not cached yet are decoded and added to it.
+// The memory used by decoding a slab is metered the first time the execution retrieves it,
+// whether it is decoded or read from the cache.
+func (s *Storage) Retrieve(id atree.SlabID) (atree.Slab, bool, error) {
+	cache := s.Config.SharedSlabCache
+	if cache == nil || id.HasTempAddress() {
+		return s.PersistentSlabStorage.Retrieve(id)
 	}
+
+	if slab, ok := s.sharedSlabs[id]; ok {
+		return slab, true, nil
+	}
+
+	cached, ok := cache.get(id)
+	if ok {
+		for _, usage := range cached.memoryUsages {
+			common.UseMemory(s.memoryGauge, usage)
+		}
+	} else {
+		var slab atree.Slab
+		var err error
+		if s.slabDecodingGauge != nil {
+			s.slabDecodingGauge.startRecording()
+			slab, ok, err = s.PersistentSlabStorage.RetrieveIgnoringDeltas(id, false)
+			cached.memoryUsages = s.slabDecodingGauge.stopRecording()
+		} else {
+			slab, ok, err = s.PersistentSlabStorage.RetrieveIgnoringDeltas(id, false)
+		}
+		if err != nil || !ok {
+			return slab, ok, err
+		}
+
+		cached.slab = slab
+
+		// If another execution added the slab concurrently,
+		// the memory used by decoding it was already metered for this execution
+		cached = cache.add(id, cached)
+	}
+
+	if s.sharedSlabs == nil {
+		s.sharedSlabs = map[atree.SlabID]atree.Slab{}
+	}
+	s.sharedSlabs[id] = cached.slab
+
+	return cached.slab, true, nil
+}
+
+// RetrieveIfLoaded returns the slab with the given ID, if it was already retrieved by the execution.
+func (s *Storage) RetrieveIfLoaded(id atree.SlabID) atree.Slab {
+	cache := s.Config.SharedSlabCache
+	if cache == nil || id.HasTempAddress() {
+		return s.PersistentSlabStorage.RetrieveIfLoaded(id)
+	}
+
+	return s.sharedSlabs[id]
+}
+
+// Store stores the given slab.
+// If the storage shares slabs, the slabs of accounts must not be modified.
+func (s *Storage) Store(id atree.SlabID, slab atree.Slab) error {
+	if s.SharesSlabs() && !id.HasTempAddress() {
+		return errors.NewUnexpectedError("cannot store shared slab %s", id)
+	}
+	return s.PersistentSlabStorage.Store(id, slab)
+}
+
+// Remove removes the slab with the given ID.
+// If the storage shares slabs, the slabs of accounts must not be modified.
+func (s *Storage) Remove(id atree.SlabID) error {
+	if s.SharesSlabs() && !id.HasTempAddress() {
+		return errors.NewUnexpectedError("cannot remove shared slab %s", id)
+	}
+	return s.PersistentSlabStorage.Remove(id)
 }
 
 const storageIndexLength = 8
@@ -606,6 +723,12 @@ func (s *Storage) migrateV1AccountsToV2(inter *interpreter.Interpreter) error {
 
 func (s *Storage) CheckHealth() error {
 
+	// Shared slabs are not loaded into the slab storage,
+	// and they are never modified, so there is nothing to check
+	if s.SharesSlabs() {
+		return nil
+	}
+
 	// Check slab storage health
 	rootSlabIDs, err := atree.CheckStorageHealth(s, -1)
 	if err != nil {
@@ -0,0 +1,311 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/interpreter"
+)
+
+// AccountStorageArchiveVersion is the current version of the account storage archive format
+const AccountStorageArchiveVersion = 1
+
+// AccountStorageArchive is a portable copy of the complete Cadence storage of an account.
+//
+// It contains the raw registers of the account's storage:
+// the registers which store the slab indices of the account's storage maps
+// (the account storage map for accounts in storage format v2,
+// or the domain storage maps for accounts in storage format v1),
+// and the registers of all slabs reachable from them.
+//
+// Registers are copied verbatim, so the slab structure
+// and the values, including resource UUIDs, are preserved.
+type AccountStorageArchive struct {
+	Version   uint16
+	Address   string
+	Registers []AccountStorageArchiveRegister
+}
+
+// AccountStorageArchiveRegister is a register of an account storage archive
+type AccountStorageArchiveRegister struct {
+	Key   []byte
+	Value []byte
+}
+
+// AccountStorageImportLedger is a ledger into which account storage archives can be imported,
+// see ImportAccountStorage
+type AccountStorageImportLedger interface {
+	atree.Ledger
+	// ReserveSlabIndices ensures that all slab indices allocated for the given account
+	// after the call are greater than the given slab index
+	ReserveSlabIndices(owner []byte, slabIndex atree.SlabIndex) error
+}
+
+// accountStorageRootKeys returns the keys of the registers which store the slab indices of the storage maps.
+// The keys of both storage formats are returned, as an account might be in the middle of a migration
+func accountStorageRootKeys() [][]byte {
+	rootKeys := make([][]byte, 0, 1+len(common.AllStorageDomains))
+	rootKeys = append(rootKeys, []byte(AccountStorageKey))
+	for _, domain := range common.AllStorageDomains {
+		rootKeys = append(rootKeys, []byte(domain.Identifier()))
+	}
+	return rootKeys
+}
+
+// ExportAccountStorage exports the complete Cadence storage of the given account to an archive.
+//
+// The ledger must reflect the committed state of the account,
+// i.e. any pending changes of a storage must be committed first.
+func ExportAccountStorage(
+	ledger atree.Ledger,
+	address common.Address,
+) (
+	*AccountStorageArchive,
+	error,
+) {
+	archive := &AccountStorageArchive{
+		Version: AccountStorageArchiveVersion,
+		Address: address.HexWithPrefix(),
+	}
+
+	addRegister := func(key []byte) ([]byte, error) {
+		var value []byte
+		var err error
+		errors.WrapPanic(func() {
+			value, err = ledger.GetValue(address[:], key)
+		})
+		if err != nil {
+			return nil, interpreter.WrappedExternalError(err)
+		}
+
+		if len(value) > 0 {
+			archive.Registers = append(
+				archive.Registers,
+				AccountStorageArchiveRegister{
+					Key:   key,
+					Value: value,
+				},
+			)
+		}
+
+		return value, nil
+	}
+
+	// Collect the root slabs from the registers which store the slab indices of the storage maps
+
+	var slabIDs []atree.SlabID
+
+	for _, key := range accountStorageRootKeys() {
+		slabIndex, exists, err := readSlabIndexFromRegister(ledger, address, key)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+
+		_, err = addRegister(key)
+		if err != nil {
+			return nil, err
+		}
+
+		slabIDs = append(
+			slabIDs,
+			atree.NewSlabID(atree.Address(address), slabIndex),
+		)
+	}
+
+	// Traverse all slabs reachable from the root slabs
+
+	slabStorage := NewPersistentSlabStorage(ledger, nil)
+
+	seen := map[atree.SlabID]struct{}{}
+
+	for len(slabIDs) > 0 {
+		slabID := slabIDs[len(slabIDs)-1]
+		slabIDs = slabIDs[:len(slabIDs)-1]
+
+		if _, ok := seen[slabID]; ok {
+			continue
+		}
+		seen[slabID] = struct{}{}
+
+		if slabID.Address() != atree.Address(address) {
+			return nil, errors.NewUnexpectedError(
+				"slab %s is not owned by account %s",
+				slabID,
+				address,
+			)
+		}
+
+		slabIndex := slabID.Index()
+		_, err := addRegister(atree.SlabIndexToLedgerKey(slabIndex))
+		if err != nil {
+			return nil, err
+		}
+
+		var slab atree.Slab
+		var found bool
+		errors.WrapPanic(func() {
+			slab, found, err = slabStorage.Retrieve(slabID)
+		})
+		if err != nil {
+			return nil, interpreter.WrappedExternalError(err)
+		}
+		if !found {
+			return nil, errors.NewUnexpectedError("missing slab %s", slabID)
+		}
+
+		// Inlined slabs are child storables themselves,
+		// so traverse their child storables, too
+		childStorables := slab.ChildStorables()
+		for len(childStorables) > 0 {
+			var next []atree.Storable
+
+			for _, storable := range childStorables {
+				if slabIDStorable, ok := storable.(atree.SlabIDStorable); ok {
+					slabIDs = append(slabIDs, atree.SlabID(slabIDStorable))
+				}
+				next = append(next, storable.ChildStorables()...)
+			}
+
+			childStorables = next
+		}
+	}
+
+	sort.Slice(archive.Registers, func(i, j int) bool {
+		return bytes.Compare(
+			archive.Registers[i].Key,
+			archive.Registers[j].Key,
+		) < 0
+	})
+
+	return archive, nil
+}
+
+// ImportAccountStorage imports the given archive into the storage of the archived account.
+//
+// The account must not have any Cadence storage yet,
+// i.e. none of the registers which store the slab indices of the storage maps may exist,
+// and none of the archived registers may exist.
+// After the registers are written, the slab indices up to the greatest imported slab index are reserved,
+// so that new slabs do not overwrite imported ones.
+func ImportAccountStorage(ledger AccountStorageImportLedger, archive *AccountStorageArchive) error {
+	if archive.Version != AccountStorageArchiveVersion {
+		return errors.NewDefaultUserError(
+			"unsupported account storage archive version: expected %d, got %d",
+			AccountStorageArchiveVersion,
+			archive.Version,
+		)
+	}
+
+	address, err := common.HexToAddress(archive.Address)
+	if err != nil {
+		return errors.NewDefaultUserError(
+			"invalid account storage archive address: %s",
+			archive.Address,
+		)
+	}
+
+	// Ensure the account has no storage yet, before writing any register
+
+	checkRegisterNotExists := func(key []byte) error {
+		var exists bool
+		errors.WrapPanic(func() {
+			exists, err = ledger.ValueExists(address[:], key)
+		})
+		if err != nil {
+			return interpreter.WrappedExternalError(err)
+		}
+		if exists {
+			return errors.NewDefaultUserError(
+				"cannot import account storage: register %x of account %s already exists",
+				key,
+				address,
+			)
+		}
+		return nil
+	}
+
+	for _, key := range accountStorageRootKeys() {
+		err = checkRegisterNotExists(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	var maxSlabIndex atree.SlabIndex
+
+	for _, register := range archive.Registers {
+		err = checkRegisterNotExists(register.Key)
+		if err != nil {
+			return err
+		}
+
+		if atree.LedgerKeyIsSlabKey(string(register.Key)) {
+			var slabIndex atree.SlabIndex
+			copy(slabIndex[:], register.Key[1:])
+			if bytes.Compare(slabIndex[:], maxSlabIndex[:]) > 0 {
+				maxSlabIndex = slabIndex
+			}
+		}
+	}
+
+	for _, register := range archive.Registers {
+		errors.WrapPanic(func() {
+			err = ledger.SetValue(address[:], register.Key, register.Value)
+		})
+		if err != nil {
+			return interpreter.WrappedExternalError(err)
+		}
+	}
+
+	errors.WrapPanic(func() {
+		err = ledger.ReserveSlabIndices(address[:], maxSlabIndex)
+	})
+	if err != nil {
+		return interpreter.WrappedExternalError(err)
+	}
+
+	return nil
+}
+
+// Encode writes the archive in JSON format to the given writer.
+func (a *AccountStorageArchive) Encode(w io.Writer) error {
+	return json.NewEncoder(w).Encode(a)
+}
+
+// DecodeAccountStorageArchive reads an archive in JSON format from the given reader.
+func DecodeAccountStorageArchive(r io.Reader) (*AccountStorageArchive, error) {
+	var archive AccountStorageArchive
+	err := json.NewDecoder(r).Decode(&archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode account storage archive: %w", err)
+	}
+	return &archive, nil
+}
@@ -0,0 +1,197 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/onflow/atree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeAccountStorageArchive(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	tx := []byte(`
+      transaction {
+          prepare(signer: auth(Storage) &Account) {
+              let values: [String] = []
+              var i = 0
+              while i < 1000 {
+                  values.append("value ".concat(i.toString()))
+                  i = i + 1
+              }
+              signer.storage.save(values, to: /storage/values)
+              signer.storage.save({"answer": 42}, to: /storage/dictionary)
+          }
+       }
+    `)
+
+	script := []byte(`
+      access(all) fun main(): [AnyStruct] {
+          let account = getAuthAccount<auth(Storage) &Account>(0x1)
+          let values = account.storage.borrow<&[String]>(from: /storage/values)!
+          let dictionary = account.storage.borrow<&{String: Int}>(from: /storage/dictionary)!
+          return [values.length, values[999], dictionary["answer"]!]
+      }
+    `)
+
+	for _, storageFormatV2Enabled := range []bool{false, true} {
+
+		name := "v1"
+		if storageFormatV2Enabled {
+			name = "v2"
+		}
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			config := DefaultTestInterpreterConfig
+			config.StorageFormatV2Enabled = storageFormatV2Enabled
+			runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+			newRuntimeInterface := func(ledger TestLedger) *TestRuntimeInterface {
+				return &TestRuntimeInterface{
+					Storage: ledger,
+					OnGetSigningAccounts: func() ([]Address, error) {
+						return []Address{address}, nil
+					},
+				}
+			}
+
+			sourceLedger := NewTestLedger(nil, nil)
+
+			err := runtime.ExecuteTransaction(
+				Script{
+					Source: tx,
+				},
+				Context{
+					Interface: newRuntimeInterface(sourceLedger),
+					Location:  NewTransactionLocationGenerator()(),
+				},
+			)
+			require.NoError(t, err)
+
+			archive, err := ExportAccountStorage(sourceLedger, address)
+			require.NoError(t, err)
+
+			// All registers of the account are Cadence storage registers,
+			// so the archive must contain all of them
+
+			var registerCount int
+			for key, value := range sourceLedger.StoredValues { //nolint:maprange
+				if len(value) > 0 && strings.HasPrefix(key, string(address[:])) {
+					registerCount++
+				}
+			}
+			assert.Len(t, archive.Registers, registerCount)
+
+			// Round-trip the archive through its encoding
+
+			var buffer bytes.Buffer
+			err = archive.Encode(&buffer)
+			require.NoError(t, err)
+
+			decodedArchive, err := DecodeAccountStorageArchive(&buffer)
+			require.NoError(t, err)
+			require.Equal(t, archive, decodedArchive)
+
+			// Import into another ledger
+
+			targetLedger := NewTestLedger(nil, nil)
+
+			err = ImportAccountStorage(targetLedger, decodedArchive)
+			require.NoError(t, err)
+
+			for _, register := range archive.Registers {
+				value, err := targetLedger.GetValue(address[:], register.Key)
+				require.NoError(t, err)
+				assert.Equal(t, register.Value, value)
+			}
+
+			// New slabs must not overwrite imported ones
+
+			var maxSlabIndex uint64
+			for _, register := range archive.Registers {
+				if !atree.LedgerKeyIsSlabKey(string(register.Key)) {
+					continue
+				}
+				slabIndex := binary.BigEndian.Uint64(register.Key[1:])
+				if slabIndex > maxSlabIndex {
+					maxSlabIndex = slabIndex
+				}
+			}
+			require.NotZero(t, maxSlabIndex)
+			assert.Equal(t, maxSlabIndex, targetLedger.StorageIndices[string(address[:])])
+
+			result, err := runtime.ExecuteScript(
+				Script{
+					Source: script,
+				},
+				Context{
+					Interface: newRuntimeInterface(targetLedger),
+					Location:  common.ScriptLocation{},
+				},
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t,
+				cadence.NewArray([]cadence.Value{
+					cadence.NewInt(1000),
+					cadence.String("value 999"),
+					cadence.NewInt(42),
+				}).WithType(cadence.NewVariableSizedArrayType(cadence.AnyStructType)),
+				result,
+			)
+
+			// Importing again must fail, as the account already has storage
+
+			err = ImportAccountStorage(targetLedger, decodedArchive)
+			var userError errors.UserError
+			require.ErrorAs(t, err, &userError)
+
+			// Importing into an account which has storage in a domain that is not archived must fail,
+			// and must not write any register
+
+			otherLedger := NewTestLedger(nil, nil)
+
+			inboxKey := []byte(common.StorageDomainInbox.Identifier())
+			err = otherLedger.SetValue(address[:], inboxKey, []byte{0, 0, 0, 0, 0, 0, 0, 1})
+			require.NoError(t, err)
+
+			err = ImportAccountStorage(otherLedger, decodedArchive)
+			require.ErrorAs(t, err, &userError)
+			assert.Len(t, otherLedger.StoredValues, 1)
+		})
+	}
+}
@@ -0,0 +1,384 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/interpreter"
+)
+
+// AccountOwnershipReport reports which contracts own the values stored in an account,
+// and which capabilities point into and out of the account,
+// e.g. to explain the storage used by an account, or to audit it.
+//
+// A value is owned by the contract which declares the type of the value,
+// if the value is a composite value (e.g. a resource or struct),
+// or otherwise by the owner of the composite value which contains the value.
+// Values which are not contained in a composite value, e.g. an array stored at a path,
+// are owned by no contract, i.e. the built-in types.
+//
+// The bytes of a value are the bytes of the slabs of the value.
+// Values which are inlined into the slab of their parent container
+// are accounted to the owner of the parent container.
+type AccountOwnershipReport struct {
+	Address common.Address
+	// Owners are the owners of the values of the account, sorted by location.
+	// The values owned by no contract are reported first, with a nil location
+	Owners []ValueOwnership
+	// StorageMapBytes are the bytes of the slabs of the storage maps of the account,
+	// which are not owned by any value
+	StorageMapBytes uint64
+	// IncomingCapabilities are the capabilities issued by the account,
+	// i.e. the capabilities through which other accounts may access the account, sorted by ID
+	IncomingCapabilities []IncomingCapability
+	// OutgoingCapabilities are the capabilities stored in the account,
+	// which target other accounts
+	OutgoingCapabilities []OutgoingCapability
+}
+
+// ValueOwnership are the values of an account owned by a contract
+type ValueOwnership struct {
+	// Location is the location of the contract which declares the types of the values,
+	// or nil for the values owned by no contract
+	Location common.Location
+	// Values is the number of values owned, including nested values
+	Values uint64
+	// Bytes is the number of bytes of the slabs of the values owned
+	Bytes uint64
+}
+
+// IncomingCapability is a capability issued by an account
+type IncomingCapability struct {
+	ID         uint64
+	BorrowType interpreter.StaticType
+	// TargetPath is the storage path targeted by the capability,
+	// or nil if the capability targets the account itself
+	TargetPath *interpreter.PathValue
+}
+
+// OutgoingCapability is a capability stored in an account, which targets another account
+type OutgoingCapability struct {
+	// Domain and Key are the storage map entry in which the capability is stored
+	Domain common.StorageDomain
+	Key    string
+	// Address is the address of the targeted account
+	Address    common.Address
+	BorrowType interpreter.StaticType
+}
+
+// NewAccountOwnershipReport walks the storage of the given account,
+// and returns a report of the ownership of its values and of its capabilities.
+//
+// The ledger must reflect the committed state of the account,
+// i.e. any pending changes of a storage must be committed first.
+func NewAccountOwnershipReport(
+	ledger atree.Ledger,
+	address common.Address,
+	storageConfig StorageConfig,
+) (
+	report *AccountOwnershipReport,
+	err error,
+) {
+	// Loading values panics on errors, e.g. missing slabs
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = errors.NewUnexpectedError("%v", r)
+			}
+			report = nil
+		}
+	}()
+
+	storage := NewStorage(ledger, nil, storageConfig)
+
+	inter, err := interpreter.NewInterpreter(
+		nil,
+		nil,
+		&interpreter.Config{
+			Storage: storage,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	report = &AccountOwnershipReport{
+		Address: address,
+	}
+
+	ownerships := map[common.Location]*ValueOwnership{}
+
+	ownership := func(location common.Location) *ValueOwnership {
+		result, ok := ownerships[location]
+		if !ok {
+			result = &ValueOwnership{
+				Location: location,
+			}
+			ownerships[location] = result
+		}
+		return result
+	}
+
+	// The owners of the values which are stored in their own slabs, by root slab
+	slabOwners := map[atree.SlabID]common.Location{}
+
+	locationRange := interpreter.EmptyLocationRange
+
+	for _, domain := range common.AllStorageDomains {
+		storageMap := storage.GetDomainStorageMap(inter, address, domain, false)
+		if storageMap == nil {
+			continue
+		}
+
+		iterator := storageMap.Iterator(nil)
+		for {
+			key, value := iterator.Next()
+			if key == nil {
+				break
+			}
+
+			addIncomingCapability(report, value)
+
+			var walk func(value interpreter.Value, owner common.Location)
+			walk = func(value interpreter.Value, owner common.Location) {
+				switch value := value.(type) {
+				case *interpreter.CompositeValue:
+					owner = value.Location
+
+				case interpreter.CapabilityValue:
+					capabilityAddress := common.Address(value.Address())
+					if capabilityAddress != address {
+						report.OutgoingCapabilities = append(
+							report.OutgoingCapabilities,
+							OutgoingCapability{
+								Domain:     domain,
+								Key:        fmt.Sprint(key),
+								Address:    capabilityAddress,
+								BorrowType: capabilityBorrowType(value),
+							},
+						)
+					}
+				}
+
+				ownership(owner).Values++
+
+				if container, ok := value.(interface {
+					Inlined() bool
+					SlabID() atree.SlabID
+				}); ok && !container.Inlined() {
+					slabOwners[container.SlabID()] = owner
+				}
+
+				value.Walk(
+					inter,
+					func(child interpreter.Value) {
+						walk(child, owner)
+					},
+					locationRange,
+				)
+			}
+
+			walk(value, nil)
+		}
+	}
+
+	err = accountSlabOwnership(
+		ledger,
+		storage,
+		address,
+		slabOwners,
+		func(slab atree.Slab, owner common.Location, owned bool) {
+			size := uint64(slab.ByteSize())
+			if owned {
+				ownership(owner).Bytes += size
+			} else {
+				report.StorageMapBytes += size
+			}
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Owners = make([]ValueOwnership, 0, len(ownerships))
+	for _, ownership := range ownerships { //nolint:maprange
+		report.Owners = append(report.Owners, *ownership)
+	}
+
+	sort.Slice(report.Owners, func(i, j int) bool {
+		a := report.Owners[i].Location
+		b := report.Owners[j].Location
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return a.ID() < b.ID()
+	})
+
+	sort.Slice(report.IncomingCapabilities, func(i, j int) bool {
+		return report.IncomingCapabilities[i].ID < report.IncomingCapabilities[j].ID
+	})
+
+	return report, nil
+}
+
+// addIncomingCapability adds the capability of the given value to the report,
+// if the value is a capability controller
+func addIncomingCapability(report *AccountOwnershipReport, value interpreter.Value) {
+	switch value := value.(type) {
+	case *interpreter.StorageCapabilityControllerValue:
+		targetPath := value.TargetPath
+		report.IncomingCapabilities = append(
+			report.IncomingCapabilities,
+			IncomingCapability{
+				ID:         uint64(value.CapabilityID),
+				BorrowType: value.BorrowType,
+				TargetPath: &targetPath,
+			},
+		)
+
+	case *interpreter.AccountCapabilityControllerValue:
+		report.IncomingCapabilities = append(
+			report.IncomingCapabilities,
+			IncomingCapability{
+				ID:         uint64(value.CapabilityID),
+				BorrowType: value.BorrowType,
+			},
+		)
+	}
+}
+
+func capabilityBorrowType(capability interpreter.CapabilityValue) interpreter.StaticType {
+	switch capability := capability.(type) {
+	case *interpreter.IDCapabilityValue:
+		return capability.BorrowType
+	case *interpreter.PathCapabilityValue: //nolint:staticcheck
+		return capability.BorrowType
+	default:
+		return nil
+	}
+}
+
+// accountSlabOwnership traverses all slabs of the storage of the given account,
+// starting at the root slabs of its storage maps, and calls f for each slab,
+// with the owner of the value the slab belongs to.
+//
+// A slab belongs to the closest value stored in its own slab which contains it, if any,
+// i.e. the closest slab in the given slab owners which is the slab itself or one of its ancestors.
+// Slabs which belong to no value, i.e. the slabs of the storage maps, are reported as not owned.
+func accountSlabOwnership(
+	ledger atree.Ledger,
+	slabStorage atree.SlabStorage,
+	address common.Address,
+	slabOwners map[atree.SlabID]common.Location,
+	f func(slab atree.Slab, owner common.Location, owned bool),
+) error {
+
+	type slabOwner struct {
+		slabID atree.SlabID
+		owner  common.Location
+		owned  bool
+	}
+
+	var stack []slabOwner
+
+	rootKeys := make([][]byte, 0, 1+len(common.AllStorageDomains))
+	rootKeys = append(rootKeys, []byte(AccountStorageKey))
+	for _, domain := range common.AllStorageDomains {
+		rootKeys = append(rootKeys, []byte(domain.Identifier()))
+	}
+
+	for _, key := range rootKeys {
+		slabIndex, exists, err := readSlabIndexFromRegister(ledger, address, key)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+
+		stack = append(
+			stack,
+			slabOwner{
+				slabID: atree.NewSlabID(atree.Address(address), slabIndex),
+			},
+		)
+	}
+
+	seen := map[atree.SlabID]struct{}{}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		slabID := current.slabID
+
+		if _, ok := seen[slabID]; ok {
+			continue
+		}
+		seen[slabID] = struct{}{}
+
+		if owner, ok := slabOwners[slabID]; ok {
+			current.owner = owner
+			current.owned = true
+		}
+
+		slab, found, err := slabStorage.Retrieve(slabID)
+		if err != nil {
+			return interpreter.WrappedExternalError(err)
+		}
+		if !found {
+			return errors.NewUnexpectedError("missing slab %s", slabID)
+		}
+
+		f(slab, current.owner, current.owned)
+
+		// Inlined slabs are child storables themselves,
+		// so traverse their child storables, too
+		childStorables := slab.ChildStorables()
+		for len(childStorables) > 0 {
+			var next []atree.Storable
+
+			for _, storable := range childStorables {
+				if slabIDStorable, ok := storable.(atree.SlabIDStorable); ok {
+					stack = append(
+						stack,
+						slabOwner{
+							slabID: atree.SlabID(slabIDStorable),
+							owner:  current.owner,
+							owned:  current.owned,
+						},
+					)
+				}
+				next = append(next, storable.ChildStorables()...)
+			}
+
+			childStorables = next
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeAccountOwnershipReport(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+	otherAddress := common.MustBytesToAddress([]byte{0x2})
+
+	const contract = `
+      access(all) contract Test {
+
+          access(all) resource R {
+              access(all) let values: [String]
+
+              init(values: [String]) {
+                  self.values = values
+              }
+          }
+
+          access(all) fun createR(values: [String]): @R {
+              return <- create R(values: values)
+          }
+      }
+    `
+
+	const setupTx = `
+      import Test from 0x1
+
+      transaction {
+          prepare(signer: auth(Storage, Capabilities) &Account) {
+              let values: [String] = []
+              var i = 0
+              while i < 1000 {
+                  values.append("value ".concat(i.toString()))
+                  i = i + 1
+              }
+              signer.storage.save(<- Test.createR(values: values), to: /storage/r)
+
+              signer.storage.save([1, 2, 3], to: /storage/numbers)
+
+              signer.capabilities.storage.issue<&Test.R>(/storage/r)
+              signer.capabilities.account.issue<&Account>()
+
+              let otherCapability = getAccount(0x2).capabilities.get<&Int>(/public/number)
+              signer.storage.save(otherCapability, to: /storage/otherCapability)
+          }
+      }
+    `
+
+	for _, storageFormatV2Enabled := range []bool{false, true} {
+
+		name := "v1"
+		if storageFormatV2Enabled {
+			name = "v2"
+		}
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			config := DefaultTestInterpreterConfig
+			config.StorageFormatV2Enabled = storageFormatV2Enabled
+			runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+			ledger := NewTestLedger(nil, nil)
+
+			accountCodes := map[Location][]byte{}
+
+			runtimeInterface := &TestRuntimeInterface{
+				Storage:           ledger,
+				OnResolveLocation: NewSingleIdentifierLocationResolver(t),
+				OnGetSigningAccounts: func() ([]Address, error) {
+					return []Address{address}, nil
+				},
+				OnUpdateAccountContractCode: func(location common.AddressLocation, code []byte) error {
+					accountCodes[location] = code
+					return nil
+				},
+				OnGetAccountContractCode: func(location common.AddressLocation) (code []byte, err error) {
+					return accountCodes[location], nil
+				},
+				OnEmitEvent: func(_ cadence.Event) error {
+					return nil
+				},
+			}
+
+			nextTransactionLocation := NewTransactionLocationGenerator()
+
+			for _, tx := range [][]byte{
+				DeploymentTransaction("Test", []byte(contract)),
+				[]byte(setupTx),
+			} {
+				err := runtime.ExecuteTransaction(
+					Script{
+						Source: tx,
+					},
+					Context{
+						Interface: runtimeInterface,
+						Location:  nextTransactionLocation(),
+					},
+				)
+				require.NoError(t, err)
+			}
+
+			report, err := NewAccountOwnershipReport(
+				ledger,
+				address,
+				StorageConfig{
+					StorageFormatV2Enabled: storageFormatV2Enabled,
+				},
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, address, report.Address)
+
+			// Values of built-in types, and values of the Test contract
+
+			require.Len(t, report.Owners, 2)
+
+			builtinOwnership := report.Owners[0]
+			assert.Nil(t, builtinOwnership.Location)
+
+			testOwnership := report.Owners[1]
+			assert.Equal(t,
+				common.AddressLocation{
+					Address: address,
+					Name:    "Test",
+				},
+				testOwnership.Location,
+			)
+
+			// The contract, the resource, its UUID, its array, and the array's strings
+			assert.Equal(t, uint64(1+1+1+1+1000), testOwnership.Values)
+
+			// The strings of the resource's array do not fit into a single slab
+			assert.Greater(t, testOwnership.Bytes, uint64(10_000))
+			assert.Less(t, builtinOwnership.Bytes, testOwnership.Bytes)
+			assert.Positive(t, report.StorageMapBytes)
+
+			// All slabs are accounted for
+
+			var registerBytes uint64
+			for _, register := range mustExportAccountStorage(t, ledger, address).Registers {
+				registerBytes += uint64(len(register.Value))
+			}
+
+			totalBytes := report.StorageMapBytes
+			for _, ownership := range report.Owners {
+				totalBytes += ownership.Bytes
+			}
+			assert.InDelta(t, registerBytes, totalBytes, float64(registerBytes)/10)
+
+			// Capabilities
+
+			require.Len(t, report.IncomingCapabilities, 2)
+
+			storageCapability := report.IncomingCapabilities[0]
+			assert.Equal(t, uint64(1), storageCapability.ID)
+			require.NotNil(t, storageCapability.TargetPath)
+			assert.Equal(t,
+				interpreter.NewUnmeteredPathValue(common.PathDomainStorage, "r"),
+				*storageCapability.TargetPath,
+			)
+
+			accountCapability := report.IncomingCapabilities[1]
+			assert.Equal(t, uint64(2), accountCapability.ID)
+			assert.Nil(t, accountCapability.TargetPath)
+			assert.Equal(t,
+				interpreter.NewReferenceStaticType(
+					nil,
+					interpreter.UnauthorizedAccess,
+					interpreter.PrimitiveStaticTypeAccount,
+				),
+				accountCapability.BorrowType,
+			)
+
+			require.Len(t, report.OutgoingCapabilities, 1)
+
+			outgoingCapability := report.OutgoingCapabilities[0]
+			assert.Equal(t, otherAddress, outgoingCapability.Address)
+			assert.Equal(t, common.StorageDomainPathStorage, outgoingCapability.Domain)
+			assert.Equal(t, "otherCapability", outgoingCapability.Key)
+		})
+	}
+}
+
+func mustExportAccountStorage(t *testing.T, ledger TestLedger, address common.Address) *AccountStorageArchive {
+	archive, err := ExportAccountStorage(ledger, address)
+	require.NoError(t, err)
+	return archive
+}
@@ -0,0 +1,148 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+)
+
+// StorageWriteLimits bounds the writes of an execution to the ledger.
+// A limit of zero means unlimited.
+//
+// A register is counted once, no matter how often it is written,
+// e.g. when storage is committed temporarily to determine the storage used by an account.
+// The written size of a register is the size of the last value written to it.
+type StorageWriteLimits struct {
+	// WriteCountLimit is the maximum number of registers written
+	WriteCountLimit uint64
+	// WriteSizeLimit is the maximum number of bytes written
+	WriteSizeLimit uint64
+	// AccountWriteCountLimit is the maximum number of registers written per account
+	AccountWriteCountLimit uint64
+	// AccountWriteSizeLimit is the maximum number of bytes written per account
+	AccountWriteSizeLimit uint64
+}
+
+func (l StorageWriteLimits) enabled() bool {
+	return l.WriteCountLimit > 0 ||
+		l.WriteSizeLimit > 0 ||
+		l.AccountWriteCountLimit > 0 ||
+		l.AccountWriteSizeLimit > 0
+}
+
+type storageWriteUsage struct {
+	count uint64
+	size  uint64
+}
+
+// writeLimitingLedger is a ledger which enforces StorageWriteLimits
+type writeLimitingLedger struct {
+	atree.Ledger
+	limits StorageWriteLimits
+	// writtenSizes is the size of the last value written to each register.
+	// Key is owner and key of the register
+	writtenSizes map[string]uint64
+	total        storageWriteUsage
+	accounts     map[common.Address]*storageWriteUsage
+}
+
+var _ atree.Ledger = &writeLimitingLedger{}
+
+func newWriteLimitingLedger(ledger atree.Ledger, limits StorageWriteLimits) *writeLimitingLedger {
+	return &writeLimitingLedger{
+		Ledger:       ledger,
+		limits:       limits,
+		writtenSizes: map[string]uint64{},
+		accounts:     map[common.Address]*storageWriteUsage{},
+	}
+}
+
+func (l *writeLimitingLedger) SetValue(owner, key, value []byte) error {
+	address, err := common.BytesToAddress(owner)
+	if err != nil {
+		return errors.NewUnexpectedErrorFromCause(err)
+	}
+
+	registerID := string(owner) + string(key)
+	size := uint64(len(value))
+
+	previousSize, written := l.writtenSizes[registerID]
+
+	var newCount uint64
+	if !written {
+		newCount = 1
+	}
+
+	account := l.accounts[address]
+	if account == nil {
+		account = &storageWriteUsage{}
+		l.accounts[address] = account
+	}
+
+	total := storageWriteUsage{
+		count: l.total.count + newCount,
+		size:  l.total.size - previousSize + size,
+	}
+	accountTotal := storageWriteUsage{
+		count: account.count + newCount,
+		size:  account.size - previousSize + size,
+	}
+
+	if l.limits.WriteCountLimit > 0 && total.count > l.limits.WriteCountLimit {
+		return StorageWriteCountLimitExceededError{
+			Limit: l.limits.WriteCountLimit,
+		}
+	}
+
+	if l.limits.WriteSizeLimit > 0 && total.size > l.limits.WriteSizeLimit {
+		return StorageWriteSizeLimitExceededError{
+			Limit: l.limits.WriteSizeLimit,
+			Size:  total.size,
+		}
+	}
+
+	if l.limits.AccountWriteCountLimit > 0 && accountTotal.count > l.limits.AccountWriteCountLimit {
+		return AccountStorageWriteCountLimitExceededError{
+			Address: address,
+			Limit:   l.limits.AccountWriteCountLimit,
+		}
+	}
+
+	if l.limits.AccountWriteSizeLimit > 0 && accountTotal.size > l.limits.AccountWriteSizeLimit {
+		return AccountStorageWriteSizeLimitExceededError{
+			Address: address,
+			Limit:   l.limits.AccountWriteSizeLimit,
+			Size:    accountTotal.size,
+		}
+	}
+
+	err = l.Ledger.SetValue(owner, key, value)
+	if err != nil {
+		return err
+	}
+
+	l.writtenSizes[registerID] = size
+	l.total = total
+	*account = accountTotal
+
+	return nil
+}
@@ -0,0 +1,216 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeStorageWriteLimits(t *testing.T) {
+
+	t.Parallel()
+
+	address1 := common.MustBytesToAddress([]byte{0x1})
+	address2 := common.MustBytesToAddress([]byte{0x2})
+
+	// newTransaction returns a transaction which saves the given number of large strings
+	// into the storage of each signer, so each string is stored in a separate register
+	newTransaction := func(signerCount int, count int) []byte {
+		var parameters []string
+		for i := 0; i < signerCount; i++ {
+			parameters = append(parameters, fmt.Sprintf("signer%d: auth(Storage) &Account", i))
+		}
+
+		var statements []string
+		for i := 0; i < signerCount; i++ {
+			statements = append(
+				statements,
+				fmt.Sprintf(
+					`
+                      var i%[1]d = 0
+                      while i%[1]d < %[2]d {
+                          signer%[1]d.storage.save(value, to: StoragePath(identifier: "value".concat(i%[1]d.toString()))!)
+                          i%[1]d = i%[1]d + 1
+                      }
+                    `,
+					i,
+					count,
+				),
+			)
+		}
+
+		return []byte(fmt.Sprintf(
+			`
+              transaction {
+                  prepare(%s) {
+                      let value = "%s"
+                      %s
+                  }
+              }
+            `,
+			strings.Join(parameters, ", "),
+			strings.Repeat("a", 1000),
+			strings.Join(statements, "\n"),
+		))
+	}
+
+	execute := func(limits StorageWriteLimits, signers []Address, transaction []byte) (int, error) {
+
+		config := DefaultTestInterpreterConfig
+		config.StorageWriteLimits = limits
+
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		var writes int
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage: NewTestLedger(
+				nil,
+				func(_, _, _ []byte) {
+					writes++
+				},
+			),
+			OnGetSigningAccounts: func() ([]Address, error) {
+				return signers, nil
+			},
+		}
+
+		nextTransactionLocation := NewTransactionLocationGenerator()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: transaction,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+
+		return writes, err
+	}
+
+	t.Run("unlimited", func(t *testing.T) {
+
+		t.Parallel()
+
+		writes, err := execute(
+			StorageWriteLimits{},
+			[]Address{address1, address2},
+			newTransaction(2, 10),
+		)
+		require.NoError(t, err)
+		assert.Greater(t, writes, 20)
+	})
+
+	t.Run("count", func(t *testing.T) {
+
+		t.Parallel()
+
+		limits := StorageWriteLimits{
+			WriteCountLimit: 10,
+		}
+
+		_, err := execute(limits, []Address{address1}, newTransaction(1, 1))
+		require.NoError(t, err)
+
+		writes, err := execute(limits, []Address{address1}, newTransaction(1, 10))
+		RequireError(t, err)
+
+		var writeCountLimitExceededErr StorageWriteCountLimitExceededError
+		require.ErrorAs(t, err, &writeCountLimitExceededErr)
+		assert.Equal(t, uint64(10), writeCountLimitExceededErr.Limit)
+
+		// The write exceeding the limit was not performed
+		assert.Equal(t, 10, writes)
+	})
+
+	t.Run("size", func(t *testing.T) {
+
+		t.Parallel()
+
+		limits := StorageWriteLimits{
+			WriteSizeLimit: 5000,
+		}
+
+		_, err := execute(limits, []Address{address1}, newTransaction(1, 1))
+		require.NoError(t, err)
+
+		_, err = execute(limits, []Address{address1}, newTransaction(1, 10))
+		RequireError(t, err)
+
+		var writeSizeLimitExceededErr StorageWriteSizeLimitExceededError
+		require.ErrorAs(t, err, &writeSizeLimitExceededErr)
+		assert.Equal(t, uint64(5000), writeSizeLimitExceededErr.Limit)
+		assert.Greater(t, writeSizeLimitExceededErr.Size, uint64(5000))
+	})
+
+	t.Run("account count", func(t *testing.T) {
+
+		t.Parallel()
+
+		limits := StorageWriteLimits{
+			AccountWriteCountLimit: 10,
+		}
+
+		// The limit applies to each account separately
+
+		_, err := execute(limits, []Address{address1, address2}, newTransaction(2, 5))
+		require.NoError(t, err)
+
+		_, err = execute(limits, []Address{address1, address2}, newTransaction(2, 10))
+		RequireError(t, err)
+
+		var accountWriteCountLimitExceededErr AccountStorageWriteCountLimitExceededError
+		require.ErrorAs(t, err, &accountWriteCountLimitExceededErr)
+		assert.Equal(t, uint64(10), accountWriteCountLimitExceededErr.Limit)
+		assert.Equal(t, address1, accountWriteCountLimitExceededErr.Address)
+	})
+
+	t.Run("account size", func(t *testing.T) {
+
+		t.Parallel()
+
+		limits := StorageWriteLimits{
+			AccountWriteSizeLimit: 7000,
+		}
+
+		_, err := execute(limits, []Address{address1, address2}, newTransaction(2, 5))
+		require.NoError(t, err)
+
+		_, err = execute(limits, []Address{address1, address2}, newTransaction(2, 10))
+		RequireError(t, err)
+
+		var accountWriteSizeLimitExceededErr AccountStorageWriteSizeLimitExceededError
+		require.ErrorAs(t, err, &accountWriteSizeLimitExceededErr)
+		assert.Equal(t, uint64(7000), accountWriteSizeLimitExceededErr.Limit)
+		assert.Equal(t, address1, accountWriteSizeLimitExceededErr.Address)
+		assert.Greater(t, accountWriteSizeLimitExceededErr.Size, uint64(7000))
+	})
+}
@@ -0,0 +1,263 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeStoredValueSizeLimit(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	const limit = 500
+
+	smallString := fmt.Sprintf("%q", strings.Repeat("a", 100))
+	largeString := fmt.Sprintf("%q", strings.Repeat("a", 1000))
+
+	execute := func(limit uint64, prepare string) error {
+
+		config := DefaultTestInterpreterConfig
+		config.StoredValueSizeLimit = limit
+
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		accountCodes := map[Location][]byte{}
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage:           NewTestLedger(nil, nil),
+			OnResolveLocation: NewSingleIdentifierLocationResolver(t),
+			OnGetSigningAccounts: func() ([]Address, error) {
+				return []Address{address}, nil
+			},
+			OnUpdateAccountContractCode: func(location common.AddressLocation, code []byte) error {
+				accountCodes[location] = code
+				return nil
+			},
+			OnGetAccountContractCode: func(location common.AddressLocation) (code []byte, err error) {
+				return accountCodes[location], nil
+			},
+			OnEmitEvent: func(_ cadence.Event) error {
+				return nil
+			},
+		}
+
+		nextTransactionLocation := NewTransactionLocationGenerator()
+
+		deployTx := DeploymentTransaction("Test", []byte(`
+          access(all) contract Test {
+
+              access(all) struct S {
+                  access(all) var value: String
+
+                  init(_ value: String) {
+                      self.value = value
+                  }
+
+                  access(all) fun setValue(_ value: String) {
+                      self.value = value
+                  }
+              }
+          }
+        `))
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: deployTx,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+
+		return runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(fmt.Sprintf(
+					`
+                      import Test from 0x1
+
+                      transaction {
+                          prepare(signer: auth(Storage) &Account) {
+                              %s
+                          }
+                      }
+                    `,
+					prepare,
+				)),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+	}
+
+	requireStoredValueSizeLimitExceededError := func(t *testing.T, err error) {
+		RequireError(t, err)
+
+		var limitErr interpreter.StoredValueSizeLimitExceededError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, uint64(limit), limitErr.Limit)
+		assert.Greater(t, limitErr.Size, uint64(limit))
+	}
+
+	t.Run("unlimited", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := execute(
+			0,
+			fmt.Sprintf(`signer.storage.save(%s, to: /storage/value)`, largeString),
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("save, small", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := execute(
+			limit,
+			fmt.Sprintf(
+				`signer.storage.save([%[1]s, %[1]s, %[1]s, %[1]s, %[1]s, %[1]s], to: /storage/value)`,
+				smallString,
+			),
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("save, large", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := execute(
+			limit,
+			fmt.Sprintf(`signer.storage.save(%s, to: /storage/value)`, largeString),
+		)
+		requireStoredValueSizeLimitExceededError(t, err)
+	})
+
+	t.Run("save, nested", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := execute(
+			limit,
+			fmt.Sprintf(
+				`signer.storage.save({"a": [Test.S(%s), Test.S(%s)]}, to: /storage/value)`,
+				smallString,
+				largeString,
+			),
+		)
+		requireStoredValueSizeLimitExceededError(t, err)
+	})
+
+	t.Run("array append", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := execute(
+			limit,
+			fmt.Sprintf(
+				`
+                  signer.storage.save([%s], to: /storage/value)
+                  signer.storage.borrow<auth(Mutate) &[String]>(from: /storage/value)!
+                      .append(%s)
+                `,
+				smallString,
+				largeString,
+			),
+		)
+		requireStoredValueSizeLimitExceededError(t, err)
+	})
+
+	t.Run("dictionary insert", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := execute(
+			limit,
+			fmt.Sprintf(
+				`
+                  signer.storage.save({"a": %s}, to: /storage/value)
+                  signer.storage.borrow<auth(Mutate) &{String: String}>(from: /storage/value)!
+                      .insert(key: "b", %s)
+                `,
+				smallString,
+				largeString,
+			),
+		)
+		requireStoredValueSizeLimitExceededError(t, err)
+	})
+
+	t.Run("composite field", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := execute(
+			limit,
+			fmt.Sprintf(
+				`
+                  signer.storage.save(Test.S(%s), to: /storage/value)
+                  signer.storage.borrow<&Test.S>(from: /storage/value)!
+                      .setValue(%s)
+                `,
+				smallString,
+				largeString,
+			),
+		)
+		requireStoredValueSizeLimitExceededError(t, err)
+	})
+
+	t.Run("not stored", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := execute(
+			limit,
+			fmt.Sprintf(
+				`
+                  let values = [%s]
+                  values.append(%s)
+                  let s = Test.S(%[2]s)
+                  s.setValue(%[2]s)
+                `,
+				smallString,
+				largeString,
+			),
+		)
+		require.NoError(t, err)
+	})
+}
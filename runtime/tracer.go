@@ -0,0 +1,115 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+)
+
+// Tracer starts spans for the execution phases of the runtime:
+// parsing, checking, interpretation, contract function invocations, and storage commits.
+//
+// Spans are started and ended in a strictly nested order, on the same goroutine.
+// This allows implementations to map the spans to OpenTelemetry spans,
+// e.g. by maintaining a stack of span contexts.
+type Tracer interface {
+	// StartSpan starts a new span with the given name and attributes.
+	// The span is a child of the span which was most recently started and has not ended yet, if any.
+	StartSpan(name string, attrs []attribute.KeyValue) TracerSpan
+}
+
+// TracerSpan is a span started by a Tracer
+type TracerSpan interface {
+	// SetAttributes sets the given attributes on the span
+	SetAttributes(attrs ...attribute.KeyValue)
+	// End ends the span
+	End()
+}
+
+const (
+	TracerSpanParse                  = "cadence.parse"
+	TracerSpanCheck                  = "cadence.check"
+	TracerSpanInterpret              = "cadence.interpret"
+	TracerSpanInvokeContractFunction = "cadence.invokeContractFunction"
+	TracerSpanCommitStorage          = "cadence.commitStorage"
+)
+
+const (
+	TracerAttributeLocation        = attribute.Key("cadence.location")
+	TracerAttributeFunction        = attribute.Key("cadence.function")
+	TracerAttributeComputationUsed = attribute.Key("cadence.computationUsed")
+)
+
+// traceSpan calls the given function in a span with the given name, if a tracer is given.
+//
+// The span has the location and the given attributes,
+// and when the function returns, the computation used by the function is added.
+func traceSpan(
+	tracer Tracer,
+	runtimeInterface Interface,
+	name string,
+	location common.Location,
+	f func(),
+	attrs ...attribute.KeyValue,
+) {
+	if tracer == nil {
+		f()
+		return
+	}
+
+	if location != nil {
+		attrs = append(attrs, TracerAttributeLocation.String(location.String()))
+	}
+
+	computationUsedBefore, computationUsedOK := computationUsed(runtimeInterface)
+
+	span := tracer.StartSpan(name, attrs)
+	defer func() {
+		if computationUsedOK {
+			computationUsedAfter, ok := computationUsed(runtimeInterface)
+			if ok && computationUsedAfter >= computationUsedBefore {
+				span.SetAttributes(
+					TracerAttributeComputationUsed.Int64(
+						int64(computationUsedAfter - computationUsedBefore),
+					),
+				)
+			}
+		}
+		span.End()
+	}()
+
+	f()
+}
+
+// computationUsed returns the total computation used so far,
+// and whether it could be determined
+func computationUsed(runtimeInterface Interface) (used uint64, ok bool) {
+	if runtimeInterface == nil {
+		return 0, false
+	}
+
+	var err error
+	errors.WrapPanic(func() {
+		used, err = runtimeInterface.ComputationUsed()
+	})
+	return used, err == nil
+}
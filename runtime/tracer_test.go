@@ -0,0 +1,214 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+type testTracerSpan struct {
+	tracer     *testTracer
+	name       string
+	depth      int
+	attributes map[attribute.Key]attribute.Value
+	ended      bool
+}
+
+var _ TracerSpan = &testTracerSpan{}
+
+func (s *testTracerSpan) SetAttributes(attrs ...attribute.KeyValue) {
+	for _, attr := range attrs {
+		s.attributes[attr.Key] = attr.Value
+	}
+}
+
+func (s *testTracerSpan) End() {
+	s.ended = true
+	s.tracer.depth--
+}
+
+type testTracer struct {
+	spans []*testTracerSpan
+	depth int
+}
+
+var _ Tracer = &testTracer{}
+
+func (t *testTracer) StartSpan(name string, attrs []attribute.KeyValue) TracerSpan {
+	span := &testTracerSpan{
+		tracer:     t,
+		name:       name,
+		depth:      t.depth,
+		attributes: map[attribute.Key]attribute.Value{},
+	}
+	span.SetAttributes(attrs...)
+	t.spans = append(t.spans, span)
+	t.depth++
+	return span
+}
+
+func TestRuntimeTracer(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	contract := []byte(`
+      access(all) contract Test {
+
+          access(all) fun answer(): Int {
+              return 42
+          }
+      }
+    `)
+
+	tracer := &testTracer{}
+
+	config := DefaultTestInterpreterConfig
+	config.Tracer = tracer
+	runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+	accountCodes := map[Location][]byte{}
+	var computationUsed uint64
+
+	runtimeInterface := &TestRuntimeInterface{
+		Storage:           NewTestLedger(nil, nil),
+		OnResolveLocation: NewSingleIdentifierLocationResolver(t),
+		OnGetSigningAccounts: func() ([]Address, error) {
+			return []Address{address}, nil
+		},
+		OnUpdateAccountContractCode: func(location common.AddressLocation, code []byte) error {
+			accountCodes[location] = code
+			return nil
+		},
+		OnGetAccountContractCode: func(location common.AddressLocation) (code []byte, err error) {
+			return accountCodes[location], nil
+		},
+		OnEmitEvent: func(event cadence.Event) error {
+			return nil
+		},
+		OnMeterComputation: func(_ common.ComputationKind, intensity uint) error {
+			computationUsed += uint64(intensity)
+			return nil
+		},
+		OnComputationUsed: func() (uint64, error) {
+			return computationUsed, nil
+		},
+	}
+
+	nextTransactionLocation := NewTransactionLocationGenerator()
+
+	deploymentLocation := nextTransactionLocation()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: DeploymentTransaction("Test", contract),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  deploymentLocation,
+		},
+	)
+	require.NoError(t, err)
+
+	type span struct {
+		name     string
+		depth    int
+		location string
+	}
+
+	spans := func() (result []span) {
+		for _, s := range tracer.spans {
+			assert.True(t, s.ended)
+			result = append(result, span{
+				name:     s.name,
+				depth:    s.depth,
+				location: s.attributes[TracerAttributeLocation].AsString(),
+			})
+		}
+		return
+	}
+
+	contractLocation := common.AddressLocation{
+		Address: address,
+		Name:    "Test",
+	}
+
+	assert.Equal(t,
+		[]span{
+			{name: TracerSpanParse, depth: 0, location: deploymentLocation.String()},
+			{name: TracerSpanCheck, depth: 0, location: deploymentLocation.String()},
+			{name: TracerSpanInterpret, depth: 0, location: deploymentLocation.String()},
+			{name: TracerSpanParse, depth: 1, location: contractLocation.String()},
+			{name: TracerSpanCheck, depth: 1, location: contractLocation.String()},
+			{name: TracerSpanInterpret, depth: 1, location: contractLocation.String()},
+			{name: TracerSpanCommitStorage, depth: 0, location: deploymentLocation.String()},
+		},
+		spans(),
+	)
+
+	interpretSpan := tracer.spans[2]
+	require.Contains(t, interpretSpan.attributes, TracerAttributeComputationUsed)
+	assert.Positive(t, interpretSpan.attributes[TracerAttributeComputationUsed].AsInt64())
+
+	// Invoke a contract function
+
+	tracer.spans = nil
+
+	invocationLocation := nextTransactionLocation()
+
+	result, err := runtime.InvokeContractFunction(
+		contractLocation,
+		"answer",
+		nil,
+		nil,
+		Context{
+			Interface: runtimeInterface,
+			Location:  invocationLocation,
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewInt(42), result)
+
+	require.Equal(t,
+		[]span{
+			{name: TracerSpanInterpret, depth: 0, location: invocationLocation.String()},
+			{name: TracerSpanParse, depth: 0, location: contractLocation.String()},
+			{name: TracerSpanCheck, depth: 0, location: contractLocation.String()},
+			{name: TracerSpanInvokeContractFunction, depth: 0, location: contractLocation.String()},
+			{name: TracerSpanCommitStorage, depth: 0, location: contractLocation.String()},
+		},
+		spans(),
+	)
+
+	invocationSpan := tracer.spans[3]
+	assert.Equal(t,
+		"answer",
+		invocationSpan.attributes[TracerAttributeFunction].AsString(),
+	)
+}
@@ -34,6 +34,7 @@ type interpreterTransactionExecutorPreparation struct {
 	transactionType  *sema.TransactionType
 	storage          *Storage
 	program          *interpreter.Program
+	partialResult    *partialResultRecorder
 	preprocessOnce   sync.Once
 }
 
@@ -68,7 +69,11 @@ func newInterpreterTransactionExecutor(
 // transactions / scripts.
 func (executor *interpreterTransactionExecutor) Preprocess() error {
 	executor.preprocessOnce.Do(func() {
-		executor.preprocessErr = executor.preprocess()
+		executor.preprocessErr = annotateError(
+			executor.preprocess(),
+			ExecutionPhasePreExecution,
+			executor.partialResult,
+		)
 	})
 
 	return executor.preprocessErr
@@ -76,7 +81,11 @@ func (executor *interpreterTransactionExecutor) Preprocess() error {
 
 func (executor *interpreterTransactionExecutor) Execute() error {
 	executor.executeOnce.Do(func() {
-		executor.executeErr = executor.execute()
+		executor.executeErr = annotateError(
+			executor.execute(),
+			ExecutionPhaseExecution,
+			executor.partialResult,
+		)
 	})
 
 	return executor.executeErr
@@ -105,12 +114,48 @@ func (executor *interpreterTransactionExecutor) preprocess() (err error) {
 	)
 
 	runtimeInterface := context.Interface
+	if interpreterRuntime.defaultConfig.PartialResultsEnabled {
+		partialResult := newPartialResultRecorder(runtimeInterface)
+		executor.partialResult = partialResult
+		runtimeInterface = partialResult
+	}
+	if uuidGeneratorProvider := interpreterRuntime.defaultConfig.UUIDGeneratorProvider; uuidGeneratorProvider != nil {
+		runtimeInterface = newUUIDGeneratorInterface(
+			uuidGeneratorProvider(location),
+			runtimeInterface,
+		)
+	}
+	if context.ExecutionTrace != nil {
+		runtimeInterface = newExecutionTraceRecorder(
+			context.ExecutionTrace,
+			ExecutionTraceKindTransaction,
+			location,
+			script,
+			runtimeInterface,
+		)
+	}
+	if context.Suspension != nil {
+		runtimeInterface, err = newExecutionSuspender(
+			context.Suspension,
+			ExecutionTraceKindTransaction,
+			location,
+			script,
+			runtimeInterface,
+		)
+		if err != nil {
+			return newError(err, location, codesAndPrograms)
+		}
+	}
+	if context.Progress != nil {
+		runtimeInterface = newProgressReporter(context.Progress, runtimeInterface)
+	}
 
 	storage := NewStorage(
 		runtimeInterface,
 		runtimeInterface,
 		StorageConfig{
 			StorageFormatV2Enabled: interpreterRuntime.defaultConfig.StorageFormatV2Enabled,
+			WriteLimits:            interpreterRuntime.defaultConfig.StorageWriteLimits,
 		},
 	)
 	executor.storage = storage
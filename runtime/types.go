@@ -28,6 +28,7 @@ import (
 type ResolvedLocation = sema.ResolvedLocation
 type Identifier = ast.Identifier
 type Location = common.Location
+type DeclaredType = sema.DeclaredType
 
 type SignatureAlgorithm = sema.SignatureAlgorithm
 
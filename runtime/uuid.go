@@ -0,0 +1,221 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/errors"
+)
+
+// UUIDGenerator generates the UUIDs of resources.
+// It must not generate the same UUID more than once
+type UUIDGenerator interface {
+	GenerateUUID() (uint64, error)
+}
+
+// UUIDGeneratorProvider returns the UUID generator for the execution
+// of the transaction or script with the given location, see Config.UUIDGeneratorProvider
+type UUIDGeneratorProvider func(location Location) UUIDGenerator
+
+// DeterministicUUIDGenerator is a UUID generator which derives UUIDs from a seed and a counter,
+// e.g. from the hash of a transaction and the number of UUIDs generated so far.
+// Generators with the same seed generate the same sequence of UUIDs,
+// so executions are reproducible, e.g. for test fixtures, or for replays in other environments
+type DeterministicUUIDGenerator struct {
+	seed    []byte
+	counter uint64
+}
+
+var _ UUIDGenerator = &DeterministicUUIDGenerator{}
+
+func NewDeterministicUUIDGenerator(seed []byte) *DeterministicUUIDGenerator {
+	return &DeterministicUUIDGenerator{
+		seed: seed,
+	}
+}
+
+func (g *DeterministicUUIDGenerator) GenerateUUID() (uint64, error) {
+	hasher := sha256.New()
+	hasher.Write(g.seed)
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], g.counter)
+	hasher.Write(counter[:])
+
+	g.counter++
+
+	hash := hasher.Sum(nil)
+	return binary.BigEndian.Uint64(hash[:8]), nil
+}
+
+// NewDeterministicUUIDGeneratorProvider returns a UUID generator provider
+// which provides deterministic UUID generators (see DeterministicUUIDGenerator),
+// seeded with the ID of the location of the transaction or script,
+// e.g. the hash of the transaction
+func NewDeterministicUUIDGeneratorProvider() UUIDGeneratorProvider {
+	return func(location Location) UUIDGenerator {
+		return NewDeterministicUUIDGenerator([]byte(location.ID()))
+	}
+}
+
+// ValidateUUIDGeneratorProvider validates that the generators provided by the given provider
+// for the given location generate the given number of unique UUIDs,
+// and that they are deterministic, i.e. that two generators generate the same sequence of UUIDs
+func ValidateUUIDGeneratorProvider(provider UUIDGeneratorProvider, location Location, count int) error {
+	generator := provider(location)
+	otherGenerator := provider(location)
+
+	seen := make(map[uint64]struct{}, count)
+
+	for index := 0; index < count; index++ {
+		uuid, err := generator.GenerateUUID()
+		if err != nil {
+			return err
+		}
+
+		otherUUID, err := otherGenerator.GenerateUUID()
+		if err != nil {
+			return err
+		}
+
+		if uuid != otherUUID {
+			return NondeterministicUUIDError{
+				Index:     index,
+				UUID:      uuid,
+				OtherUUID: otherUUID,
+			}
+		}
+
+		if _, ok := seen[uuid]; ok {
+			return DuplicateUUIDError{
+				UUID: uuid,
+			}
+		}
+		seen[uuid] = struct{}{}
+	}
+
+	return nil
+}
+
+// uuidGeneratorInterface is a runtime interface which generates UUIDs using a UUID generator,
+// instead of the runtime interface, see Config.UUIDGeneratorProvider.
+// It validates that generated UUIDs are unique
+type uuidGeneratorInterface struct {
+	Interface
+	generator UUIDGenerator
+	generated map[uint64]struct{}
+}
+
+var _ Interface = &uuidGeneratorInterface{}
+var _ Metrics = &uuidGeneratorInterface{}
+var _ EventSizer = &uuidGeneratorInterface{}
+
+func newUUIDGeneratorInterface(generator UUIDGenerator, runtimeInterface Interface) *uuidGeneratorInterface {
+	return &uuidGeneratorInterface{
+		Interface: runtimeInterface,
+		generator: generator,
+		generated: map[uint64]struct{}{},
+	}
+}
+
+func (i *uuidGeneratorInterface) GenerateUUID() (uint64, error) {
+	uuid, err := i.generator.GenerateUUID()
+	if err != nil {
+		return 0, err
+	}
+
+	if _, ok := i.generated[uuid]; ok {
+		return 0, DuplicateUUIDError{
+			UUID: uuid,
+		}
+	}
+	i.generated[uuid] = struct{}{}
+
+	return uuid, nil
+}
+
+func (i *uuidGeneratorInterface) unwrap() Interface {
+	return i.Interface
+}
+
+func (i *uuidGeneratorInterface) ProgramParsed(location Location, duration time.Duration) {
+	if metrics, ok := i.Interface.(Metrics); ok {
+		metrics.ProgramParsed(location, duration)
+	}
+}
+
+func (i *uuidGeneratorInterface) ProgramChecked(location Location, duration time.Duration) {
+	if metrics, ok := i.Interface.(Metrics); ok {
+		metrics.ProgramChecked(location, duration)
+	}
+}
+
+func (i *uuidGeneratorInterface) ProgramInterpreted(location Location, duration time.Duration) {
+	if metrics, ok := i.Interface.(Metrics); ok {
+		metrics.ProgramInterpreted(location, duration)
+	}
+}
+
+func (i *uuidGeneratorInterface) EventSize(event cadence.Event) (uint64, error) {
+	return eventSize(i.Interface, event)
+}
+
+// DuplicateUUIDError is reported when a UUID generator generated a UUID more than once
+type DuplicateUUIDError struct {
+	UUID uint64
+}
+
+var _ errors.InternalError = DuplicateUUIDError{}
+
+func (DuplicateUUIDError) IsInternalError() {}
+
+func (e DuplicateUUIDError) Error() string {
+	return fmt.Sprintf(
+		"%s UUID generator generated duplicate UUID %d",
+		errors.InternalErrorMessagePrefix,
+		e.UUID,
+	)
+}
+
+// NondeterministicUUIDError is reported when two UUID generators for the same execution
+// generated different UUIDs, see ValidateUUIDGeneratorProvider
+type NondeterministicUUIDError struct {
+	Index     int
+	UUID      uint64
+	OtherUUID uint64
+}
+
+var _ errors.InternalError = NondeterministicUUIDError{}
+
+func (NondeterministicUUIDError) IsInternalError() {}
+
+func (e NondeterministicUUIDError) Error() string {
+	return fmt.Sprintf(
+		"%s UUID generator is not deterministic: UUID %d is %d and %d",
+		errors.InternalErrorMessagePrefix,
+		e.Index,
+		e.UUID,
+		e.OtherUUID,
+	)
+}
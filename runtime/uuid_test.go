@@ -0,0 +1,236 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeUUIDGeneratorProvider(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      access(all) resource R {}
+
+      access(all) fun main(): [UInt64] {
+          let r1 <- create R()
+          let r2 <- create R()
+          let uuids = [r1.uuid, r2.uuid]
+          destroy r1
+          destroy r2
+          return uuids
+      }
+    `)
+
+	execute := func(provider UUIDGeneratorProvider, location common.Location) (cadence.Value, error) {
+
+		config := DefaultTestInterpreterConfig
+		config.UUIDGeneratorProvider = provider
+
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage: NewTestLedger(nil, nil),
+			OnGenerateUUID: func() (uint64, error) {
+				return 0, nil
+			},
+		}
+
+		return runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  location,
+			},
+		)
+	}
+
+	t.Run("deterministic", func(t *testing.T) {
+
+		t.Parallel()
+
+		provider := NewDeterministicUUIDGeneratorProvider()
+
+		location := common.ScriptLocation{0x1}
+		otherLocation := common.ScriptLocation{0x2}
+
+		result, err := execute(provider, location)
+		require.NoError(t, err)
+
+		uuids := result.(cadence.Array).Values
+		require.Len(t, uuids, 2)
+		assert.NotEqual(t, uuids[0], uuids[1])
+
+		// The same location results in the same UUIDs
+
+		sameResult, err := execute(provider, location)
+		require.NoError(t, err)
+		assert.Equal(t, result, sameResult)
+
+		// A different location results in different UUIDs
+
+		otherResult, err := execute(provider, otherLocation)
+		require.NoError(t, err)
+		assert.NotEqual(t, result, otherResult)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := execute(
+			func(_ common.Location) UUIDGenerator {
+				return constantUUIDGenerator(42)
+			},
+			common.ScriptLocation{},
+		)
+		RequireError(t, err)
+
+		var duplicateErr DuplicateUUIDError
+		require.ErrorAs(t, err, &duplicateErr)
+		assert.Equal(t, uint64(42), duplicateErr.UUID)
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+
+		t.Parallel()
+
+		config := DefaultTestInterpreterConfig
+		config.UUIDGeneratorProvider = NewDeterministicUUIDGeneratorProvider()
+
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		var parsed, checked, interpreted int
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage: NewTestLedger(nil, nil),
+			OnProgramParsed: func(_ common.Location, _ time.Duration) {
+				parsed++
+			},
+			OnProgramChecked: func(_ common.Location, _ time.Duration) {
+				checked++
+			},
+			OnProgramInterpreted: func(_ common.Location, _ time.Duration) {
+				interpreted++
+			},
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		require.NoError(t, err)
+
+		// The metrics are reported to the wrapped runtime interface
+
+		assert.Equal(t, 1, parsed)
+		assert.Equal(t, 1, checked)
+		assert.Equal(t, 1, interpreted)
+	})
+}
+
+type constantUUIDGenerator uint64
+
+func (g constantUUIDGenerator) GenerateUUID() (uint64, error) {
+	return uint64(g), nil
+}
+
+type counterUUIDGenerator struct {
+	counter *uint64
+}
+
+func (g counterUUIDGenerator) GenerateUUID() (uint64, error) {
+	*g.counter++
+	return *g.counter, nil
+}
+
+func TestRuntimeValidateUUIDGeneratorProvider(t *testing.T) {
+
+	t.Parallel()
+
+	location := common.TransactionLocation{0x1}
+
+	t.Run("deterministic", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := ValidateUUIDGeneratorProvider(
+			NewDeterministicUUIDGeneratorProvider(),
+			location,
+			1000,
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := ValidateUUIDGeneratorProvider(
+			func(_ common.Location) UUIDGenerator {
+				return constantUUIDGenerator(1)
+			},
+			location,
+			2,
+		)
+		require.ErrorAs(t, err, &DuplicateUUIDError{})
+	})
+
+	t.Run("nondeterministic", func(t *testing.T) {
+
+		t.Parallel()
+
+		// The generators share a counter,
+		// so they generate different UUIDs
+
+		var counter uint64
+
+		err := ValidateUUIDGeneratorProvider(
+			func(_ common.Location) UUIDGenerator {
+				return counterUUIDGenerator{
+					counter: &counter,
+				}
+			},
+			location,
+			2,
+		)
+
+		var nondeterministicErr NondeterministicUUIDError
+		require.ErrorAs(t, err, &nondeterministicErr)
+		assert.Equal(t, 0, nondeterministicErr.Index)
+	})
+}
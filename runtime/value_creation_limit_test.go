@@ -0,0 +1,177 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	. "github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func TestRuntimeValueCreationLimits(t *testing.T) {
+
+	t.Parallel()
+
+	const limit = 10
+
+	execute := func(config Config, setup string, body string, iterations int) error {
+
+		runtime := NewTestInterpreterRuntimeWithConfig(config)
+
+		runtimeInterface := &TestRuntimeInterface{
+			Storage: NewTestLedger(nil, nil),
+		}
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: []byte(fmt.Sprintf(
+					`
+                      access(all) struct S {}
+
+                      access(all) fun main() {
+                          %s
+                          var i = 0
+                          while i < %d {
+                              %s
+                              i = i + 1
+                          }
+                      }
+                    `,
+					setup,
+					iterations,
+					body,
+				)),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.ScriptLocation{},
+			},
+		)
+		return err
+	}
+
+	requireValueCreationLimitExceededError := func(
+		t *testing.T,
+		err error,
+		kind interpreter.CreatedValueKind,
+	) {
+		RequireError(t, err)
+
+		var limitErr interpreter.ValueCreationLimitExceededError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, kind, limitErr.Kind)
+		assert.Equal(t, uint64(limit), limitErr.Limit)
+	}
+
+	type testCase struct {
+		kind      interpreter.CreatedValueKind
+		configure func(config *Config, limit uint64)
+		create    string
+	}
+
+	for _, test := range []testCase{
+		{
+			kind: interpreter.CreatedValueKindComposite,
+			configure: func(config *Config, limit uint64) {
+				config.CompositeValueCreationLimit = limit
+			},
+			create: `S()`,
+		},
+		{
+			kind: interpreter.CreatedValueKindArray,
+			configure: func(config *Config, limit uint64) {
+				config.ArrayValueCreationLimit = limit
+			},
+			create: `[1]`,
+		},
+		{
+			kind: interpreter.CreatedValueKindDictionary,
+			configure: func(config *Config, limit uint64) {
+				config.DictionaryValueCreationLimit = limit
+			},
+			create: `{1: 2}`,
+		},
+	} {
+		test := test
+
+		t.Run(test.kind.String(), func(t *testing.T) {
+
+			t.Parallel()
+
+			t.Run("unlimited", func(t *testing.T) {
+
+				t.Parallel()
+
+				config := DefaultTestInterpreterConfig
+				test.configure(&config, 0)
+
+				err := execute(config, "", test.create, 2*limit)
+				require.NoError(t, err)
+			})
+
+			t.Run("within limit", func(t *testing.T) {
+
+				t.Parallel()
+
+				config := DefaultTestInterpreterConfig
+				test.configure(&config, limit)
+
+				err := execute(config, "", test.create, limit)
+				require.NoError(t, err)
+			})
+
+			t.Run("creation exceeds limit", func(t *testing.T) {
+
+				t.Parallel()
+
+				config := DefaultTestInterpreterConfig
+				test.configure(&config, limit)
+
+				err := execute(config, "", test.create, limit+1)
+				requireValueCreationLimitExceededError(t, err, test.kind)
+			})
+
+			t.Run("copy exceeds limit", func(t *testing.T) {
+
+				t.Parallel()
+
+				config := DefaultTestInterpreterConfig
+				test.configure(&config, limit)
+
+				// Copies of the value count as created values
+
+				err := execute(
+					config,
+					fmt.Sprintf("let value = %s", test.create),
+					"let copy = value",
+					limit,
+				)
+				requireValueCreationLimitExceededError(t, err, test.kind)
+			})
+		})
+	}
+}
@@ -190,6 +190,34 @@ If there is an object stored, the type of the object is returned without modifyi
 The path must be a storage path, i.e., only the domain ` + "`storage`" + ` is allowed.
 `
 
+const Account_StorageTypeSizeFunctionName = "size"
+
+var Account_StorageTypeSizeFunctionType = &FunctionType{
+	Purity: FunctionPurityView,
+	Parameters: []Parameter{
+		{
+			Label:          "at",
+			Identifier:     "path",
+			TypeAnnotation: NewTypeAnnotation(StoragePathType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: UInt64Type,
+		},
+	),
+}
+
+const Account_StorageTypeSizeFunctionDocString = `
+Returns the approximate size, in bytes, of the object stored under the given path,
+or nil if no object is stored under the given path.
+
+The size is determined from the storage metadata of the object, without loading the whole object.
+For large objects, which are split into multiple parts, only the size of the top-level part is returned.
+
+The path must be a storage path, i.e., only the domain ` + "`storage`" + ` is allowed.
+`
+
 const Account_StorageTypeLoadFunctionName = "load"
 
 var Account_StorageTypeLoadFunctionTypeParameterT = &TypeParameter{
@@ -503,6 +531,13 @@ func init() {
 			Account_StorageTypeTypeFunctionType,
 			Account_StorageTypeTypeFunctionDocString,
 		),
+		NewUnmeteredFunctionMember(
+			Account_StorageType,
+			PrimitiveAccess(ast.AccessAll),
+			Account_StorageTypeSizeFunctionName,
+			Account_StorageTypeSizeFunctionType,
+			Account_StorageTypeSizeFunctionDocString,
+		),
 		NewUnmeteredFunctionMember(
 			Account_StorageType,
 			newEntitlementAccess(
@@ -707,6 +742,30 @@ Returns the deployed contract for the contract/contract interface with the given
 Returns nil if no contract/contract interface with the given name exists in the account.
 `
 
+const Account_ContractsTypeMetadataFunctionName = "metadata"
+
+var Account_ContractsTypeMetadataFunctionType = &FunctionType{
+	Purity: FunctionPurityView,
+	Parameters: []Parameter{
+		{
+			Identifier:     "name",
+			TypeAnnotation: NewTypeAnnotation(StringType),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&OptionalType{
+			Type: ContractMetadataType,
+		},
+	),
+}
+
+const Account_ContractsTypeMetadataFunctionDocString = `
+Returns the metadata of the contract/contract interface with the given name in the account, if any,
+e.g. the hash and size of its code, and the height of the block in which it was last updated.
+
+Returns nil if no contract/contract interface with the given name exists in the account.
+`
+
 const Account_ContractsTypeRemoveFunctionName = "remove"
 
 var Account_ContractsTypeRemoveFunctionType = &FunctionType{
@@ -828,6 +887,13 @@ func init() {
 			Account_ContractsTypeGetFunctionType,
 			Account_ContractsTypeGetFunctionDocString,
 		),
+		NewUnmeteredFunctionMember(
+			Account_ContractsType,
+			PrimitiveAccess(ast.AccessAll),
+			Account_ContractsTypeMetadataFunctionName,
+			Account_ContractsTypeMetadataFunctionType,
+			Account_ContractsTypeMetadataFunctionDocString,
+		),
 		NewUnmeteredFunctionMember(
 			Account_ContractsType,
 			newEntitlementAccess(
@@ -1357,6 +1423,67 @@ Returns the capability if one was published at the path.
 Returns nil if no capability was published at the path.
 `
 
+const Account_CapabilitiesTypePublishAllFunctionName = "publishAll"
+
+var Account_CapabilitiesTypePublishAllFunctionType = &FunctionType{
+	Parameters: []Parameter{
+		{
+			Label:      ArgumentLabelNotRequired,
+			Identifier: "capabilities",
+			TypeAnnotation: NewTypeAnnotation(&VariableSizedType{
+				Type: &CapabilityType{},
+			}),
+		},
+		{
+			Identifier: "at",
+			TypeAnnotation: NewTypeAnnotation(&VariableSizedType{
+				Type: PublicPathType,
+			}),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		VoidType,
+	),
+}
+
+const Account_CapabilitiesTypePublishAllFunctionDocString = `
+Publish the given capabilities at the given public paths.
+Each capability is published at the path with the same index.
+
+The capabilities are only published if all of them can be published.
+Otherwise, the program aborts with an error that reports all capabilities that cannot be published.
+
+The paths must be public paths, i.e., only the domain ` + "`public`" + ` is allowed.
+`
+
+const Account_CapabilitiesTypeUnpublishAllFunctionName = "unpublishAll"
+
+var Account_CapabilitiesTypeUnpublishAllFunctionType = &FunctionType{
+	Parameters: []Parameter{
+		{
+			Label:      ArgumentLabelNotRequired,
+			Identifier: "paths",
+			TypeAnnotation: NewTypeAnnotation(&VariableSizedType{
+				Type: PublicPathType,
+			}),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&VariableSizedType{
+			Type: &OptionalType{
+				Type: &CapabilityType{},
+			},
+		},
+	),
+}
+
+const Account_CapabilitiesTypeUnpublishAllFunctionDocString = `
+Unpublish the capabilities published at the given paths.
+
+Returns, for each path, the capability if one was published at the path,
+or nil if no capability was published at the path.
+`
+
 const Account_CapabilitiesTypeName = "Capabilities"
 
 var Account_CapabilitiesType = func() *CompositeType {
@@ -1435,6 +1562,26 @@ func init() {
 			Account_CapabilitiesTypeUnpublishFunctionType,
 			Account_CapabilitiesTypeUnpublishFunctionDocString,
 		),
+		NewUnmeteredFunctionMember(
+			Account_CapabilitiesType,
+			newEntitlementAccess(
+				[]Type{CapabilitiesType, PublishCapabilityType},
+				Disjunction,
+			),
+			Account_CapabilitiesTypePublishAllFunctionName,
+			Account_CapabilitiesTypePublishAllFunctionType,
+			Account_CapabilitiesTypePublishAllFunctionDocString,
+		),
+		NewUnmeteredFunctionMember(
+			Account_CapabilitiesType,
+			newEntitlementAccess(
+				[]Type{CapabilitiesType, UnpublishCapabilityType},
+				Disjunction,
+			),
+			Account_CapabilitiesTypeUnpublishAllFunctionName,
+			Account_CapabilitiesTypeUnpublishAllFunctionType,
+			Account_CapabilitiesTypeUnpublishAllFunctionDocString,
+		),
 	}
 
 	Account_CapabilitiesType.Members = MembersAsMap(members)
@@ -1499,6 +1646,45 @@ const Account_StorageCapabilitiesTypeIssueWithTypeFunctionDocString = `
 Issue/create a new storage capability.
 `
 
+const Account_StorageCapabilitiesTypeIssueAllFunctionName = "issueAll"
+
+var Account_StorageCapabilitiesTypeIssueAllFunctionTypeParameterT = &TypeParameter{
+	Name: "T",
+	TypeBound: &ReferenceType{
+		Type:          AnyType,
+		Authorization: UnauthorizedAccess,
+	},
+}
+
+var Account_StorageCapabilitiesTypeIssueAllFunctionType = &FunctionType{
+	TypeParameters: []*TypeParameter{
+		Account_StorageCapabilitiesTypeIssueAllFunctionTypeParameterT,
+	},
+	Parameters: []Parameter{
+		{
+			Label:      ArgumentLabelNotRequired,
+			Identifier: "paths",
+			TypeAnnotation: NewTypeAnnotation(&VariableSizedType{
+				Type: StoragePathType,
+			}),
+		},
+	},
+	ReturnTypeAnnotation: NewTypeAnnotation(
+		&VariableSizedType{
+			Type: MustInstantiate(
+				&CapabilityType{},
+				&GenericType{
+					TypeParameter: Account_StorageCapabilitiesTypeIssueAllFunctionTypeParameterT,
+				},
+			),
+		},
+	),
+}
+
+const Account_StorageCapabilitiesTypeIssueAllFunctionDocString = `
+Issue/create new storage capabilities, one for each of the given paths.
+`
+
 const Account_StorageCapabilitiesTypeGetControllerFunctionName = "getController"
 
 var Account_StorageCapabilitiesTypeGetControllerFunctionType = &FunctionType{
@@ -1628,6 +1814,16 @@ func init() {
 			Account_StorageCapabilitiesTypeIssueWithTypeFunctionType,
 			Account_StorageCapabilitiesTypeIssueWithTypeFunctionDocString,
 		),
+		NewUnmeteredFunctionMember(
+			Account_StorageCapabilitiesType,
+			newEntitlementAccess(
+				[]Type{CapabilitiesType, StorageCapabilitiesType, IssueStorageCapabilityControllerType},
+				Disjunction,
+			),
+			Account_StorageCapabilitiesTypeIssueAllFunctionName,
+			Account_StorageCapabilitiesTypeIssueAllFunctionType,
+			Account_StorageCapabilitiesTypeIssueAllFunctionDocString,
+		),
 		NewUnmeteredFunctionMember(
 			Account_StorageCapabilitiesType,
 			newEntitlementAccess(
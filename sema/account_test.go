@@ -1007,6 +1007,23 @@ func TestCheckAccountContractsGet(t *testing.T) {
 
 }
 
+func TestCheckAccountContractsMetadata(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      fun test(contracts: &Account.Contracts): [AnyStruct] {
+          let metadata: ContractMetadata = contracts.metadata(name: "foo")!
+          let codeHash: [UInt8; 32] = metadata.codeHash
+          let size: UInt64 = metadata.size
+          let updateHeight: UInt64? = metadata.updateHeight
+          return [metadata.name, codeHash, size, updateHeight]
+      }
+    `)
+
+	require.NoError(t, err)
+}
+
 func TestCheckAccountContractsBorrow(t *testing.T) {
 
 	t.Parallel()
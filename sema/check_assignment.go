@@ -191,7 +191,11 @@ func isWriteableInViewContext(t Type) bool {
 }
 
 func (checker *Checker) enforceViewAssignment(assignment ast.Statement, target ast.Expression) {
-	if !checker.CurrentPurityScope().EnforcePurity {
+	// Impure assignments outside of view contexts only need to be observed
+	// if missing view annotations are reported
+	if !checker.CurrentPurityScope().EnforcePurity &&
+		!checker.Config.ViewAnnotationWarningsEnabled {
+
 		return
 	}
 
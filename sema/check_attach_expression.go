@@ -52,7 +52,7 @@ func (checker *Checker) VisitAttachExpression(expression *ast.AttachExpression)
 
 	declaredBaseType := attachmentCompositeType.baseType
 
-	if !IsSubType(baseType, declaredBaseType) {
+	if !checker.isSubType(baseType, declaredBaseType) {
 		checker.report(
 			&TypeMismatchError{
 				ExpectedType: declaredBaseType,
@@ -162,6 +162,8 @@ func (checker *Checker) visitAttachmentDeclaration(declaration *ast.AttachmentDe
 // and that the members and nested declarations for the composite type were declared
 // through `declareCompositeMembersAndValue`.
 func (checker *Checker) visitCompositeLikeDeclaration(declaration ast.CompositeLikeDeclaration) {
+	defer checker.lintDeclaration(declaration)
+
 	compositeType := checker.Elaboration.CompositeDeclarationType(declaration)
 	if compositeType == nil {
 		panic(errors.NewUnreachableError())
@@ -172,6 +174,9 @@ func (checker *Checker) visitCompositeLikeDeclaration(declaration ast.CompositeL
 		checker.containerTypes[compositeType] = false
 	}()
 
+	checker.enterIncrementalCheckingContainer(declaration)
+	defer checker.leaveIncrementalCheckingContainer()
+
 	checker.checkDeclarationAccessModifier(
 		checker.accessFromAstAccess(declaration.DeclarationAccess()),
 		declaration.DeclarationKind(),
@@ -183,6 +188,10 @@ func (checker *Checker) visitCompositeLikeDeclaration(declaration ast.CompositeL
 
 	members := declaration.DeclarationMembers()
 
+	// NOTE: private functions may be used in nested declarations,
+	// so only check for unused ones after the whole composite was checked
+	defer checker.checkUnusedPrivateFunctions(compositeType, members.Functions())
+
 	// NOTE: functions are checked separately
 	declarationKind := declaration.Kind()
 	checker.checkFieldsAccessModifier(members.Fields(), compositeType.Members, &declarationKind)
@@ -1592,7 +1601,7 @@ func (checker *Checker) memberSatisfied(
 			if compositeMemberFunctionType.ReturnTypeAnnotation.Type != nil &&
 				interfaceMemberFunctionType.ReturnTypeAnnotation.Type != nil {
 
-				if !IsSubType(
+				if !checker.isSubType(
 					compositeMemberFunctionType.ReturnTypeAnnotation.Type,
 					interfaceMemberFunctionType.ReturnTypeAnnotation.Type,
 				) {
@@ -2348,6 +2357,8 @@ func (checker *Checker) checkSpecialFunction(
 		true,
 		initializationInfo,
 		checkResourceLoss,
+		containerKind == ContainerKindComposite &&
+			containerType.GetCompositeKind() != common.CompositeKindEvent,
 	)
 
 	if containerKind == ContainerKindComposite {
@@ -2376,6 +2387,12 @@ func (checker *Checker) checkCompositeFunctions(
 	selfDocString string,
 ) {
 	for _, function := range functions {
+		// NOTE: member functions of attachments cannot be re-checked
+		var checkedFunction *checkedFunction
+		if selfType.Kind != common.CompositeKindAttachment {
+			checkedFunction = checker.beginFunctionDiagnostics(function)
+		}
+
 		// NOTE: new activation, as function declarations
 		// shouldn't be visible in other function declarations,
 		// and `self` is only visible inside function
@@ -2403,9 +2420,10 @@ func (checker *Checker) checkCompositeFunctions(
 			checker.visitFunctionDeclaration(
 				function,
 				functionDeclarationOptions{
-					mustExit:          true,
-					declareFunction:   false,
-					checkResourceLoss: true,
+					mustExit:              true,
+					declareFunction:       false,
+					checkResourceLoss:     true,
+					checkUnusedParameters: !isInterfaceRequirement(selfType, function.Identifier.Identifier),
 				},
 				&selfType.Kind,
 			)
@@ -2418,6 +2436,8 @@ func (checker *Checker) checkCompositeFunctions(
 				},
 			)
 		}
+
+		checker.endFunctionDiagnostics(checkedFunction)
 	}
 }
 
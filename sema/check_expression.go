@@ -251,6 +251,26 @@ func (checker *Checker) VisitNilExpression(_ *ast.NilExpression) Type {
 	return NilType
 }
 
+// checkIntegerLiteralSize checks that the value of the integer literal
+// does not exceed the size limit, see Config.IntegerLiteralBitLengthLimit
+func (checker *Checker) checkIntegerLiteralSize(expression *ast.IntegerExpression) {
+	limit := checker.Config.IntegerLiteralBitLengthLimit
+	if limit <= 0 {
+		return
+	}
+
+	bitLength := expression.Value.BitLen()
+	if bitLength <= limit {
+		return
+	}
+
+	checker.report(&IntegerLiteralSizeLimitExceededError{
+		BitLength: bitLength,
+		Limit:     limit,
+		Range:     ast.NewRangeFromPositioned(checker.memoryGauge, expression),
+	})
+}
+
 func (checker *Checker) VisitIntegerExpression(expression *ast.IntegerExpression) Type {
 	expectedType := UnwrapOptionalType(checker.expectedType)
 
@@ -270,6 +290,7 @@ func (checker *Checker) VisitIntegerExpression(expression *ast.IntegerExpression
 	}
 
 	if !isAddress {
+		checker.checkIntegerLiteralSize(expression)
 		CheckIntegerLiteral(checker.memoryGauge, expression, actualType, checker.report)
 	}
 
@@ -0,0 +1,131 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+)
+
+// Scope declares values and types in which an expression is checked, see Checker.CheckExpressionInScope,
+// e.g. the local variables of a function at a breakpoint of a debugger,
+// or the values declared in a REPL session.
+//
+// The variables of the activations and of their parents are declared,
+// in addition to the global values and types of the checked program.
+// Variables of inner activations shadow variables of outer activations.
+// The activations should not have the base activations (e.g. BaseValueActivation) as parents
+type Scope struct {
+	Values *VariableActivation
+	Types  *VariableActivation
+}
+
+// CheckExpressionInScope checks the given expression in the given scope, which may be nil,
+// and returns the type of the expression.
+// If the expected type is not nil, the type of the expression must be a subtype of it.
+//
+// The program of the checker is checked first, if it has not been checked yet.
+// The errors of the expression are returned as a CheckerError,
+// and are not added to the errors of the checked program.
+// The results of checking the expression are recorded in the elaboration
+func (checker *Checker) CheckExpressionInScope(
+	expression ast.Expression,
+	scope *Scope,
+	expectedType Type,
+) (
+	ty Type,
+	err error,
+) {
+	if !checker.IsChecked() {
+		err = checker.Check()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	programErrors := checker.errors
+	programWarnings := checker.warnings
+	checker.errors = nil
+	checker.warnings = nil
+
+	checker.Elaboration.setIsChecking(true)
+	checker.resources = NewResources()
+
+	getEndPosition := func(memoryGauge common.MemoryGauge) ast.Position {
+		return expression.EndPosition(memoryGauge)
+	}
+
+	checker.valueActivations.Enter()
+	checker.typeActivations.Enter()
+
+	defer func() {
+		checker.typeActivations.Leave(getEndPosition)
+		checker.valueActivations.Leave(getEndPosition)
+
+		checker.resources.Reclaim()
+		checker.resources = nil
+		checker.Elaboration.setIsChecking(false)
+
+		if checkerErr := checker.CheckerError(); checkerErr != nil {
+			err = checkerErr
+		}
+
+		checker.errors = programErrors
+		checker.warnings = programWarnings
+	}()
+
+	if scope != nil {
+		declareScopeActivation(checker.valueActivations, scope.Values)
+		declareScopeActivation(checker.typeActivations, scope.Types)
+	}
+
+	if checker.Config.ErrorShortCircuitingEnabled {
+		defer func() {
+			switch recovered := recover().(type) {
+			case stopChecking, nil:
+				// checking stopped, the reported error is returned
+			default:
+				panic(recovered)
+			}
+		}()
+	}
+
+	ty = checker.VisitExpression(expression, nil, expectedType)
+
+	return ty, nil
+}
+
+// declareScopeActivation declares the variables of the given activation and of its parents
+// in the current activation, outermost first, so variables of inner activations shadow outer ones
+func declareScopeActivation(activations *VariableActivations, activation *VariableActivation) {
+	var chain []*VariableActivation
+	for current := activation; current != nil; current = current.Parent {
+		chain = append(chain, current)
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		entries := chain[i].entries
+		if entries == nil {
+			continue
+		}
+		for pair := entries.Oldest(); pair != nil; pair = pair.Next() {
+			activations.Set(pair.Key, pair.Value)
+		}
+	}
+}
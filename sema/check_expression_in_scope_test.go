@@ -0,0 +1,111 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/cadence/sema"
+	"github.com/onflow/cadence/stdlib"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func TestCheckExpressionInScope(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      let x = 1
+
+      struct S {
+          fun name(): String {
+              return "S"
+          }
+      }
+
+      let s = S()
+    `)
+	require.NoError(t, err)
+
+	newScope := func() *sema.Scope {
+		outer := sema.NewVariableActivation(nil)
+		outer.DeclareValue(stdlib.StandardLibraryValue{
+			Name: "y",
+			Type: sema.StringType,
+			Kind: common.DeclarationKindConstant,
+		})
+
+		inner := sema.NewVariableActivation(outer)
+		inner.DeclareValue(stdlib.StandardLibraryValue{
+			Name: "y",
+			Type: sema.IntType,
+			Kind: common.DeclarationKindConstant,
+		})
+
+		return &sema.Scope{
+			Values: inner,
+		}
+	}
+
+	check := func(code string, scope *sema.Scope, expectedType sema.Type) (sema.Type, error) {
+		expression, errs := parser.ParseExpression(nil, []byte(code), parser.Config{})
+		require.Empty(t, errs)
+
+		return checker.CheckExpressionInScope(expression, scope, expectedType)
+	}
+
+	t.Run("globals and scope", func(t *testing.T) {
+		ty, err := check(`x + y`, newScope(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, sema.IntType, ty)
+	})
+
+	t.Run("members", func(t *testing.T) {
+		ty, err := check(`s.name()`, nil, sema.StringType)
+		require.NoError(t, err)
+		assert.Equal(t, sema.StringType, ty)
+	})
+
+	t.Run("expected type mismatch", func(t *testing.T) {
+		_, err := check(`x + y`, newScope(), sema.StringType)
+
+		errs := RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("not declared outside of scope", func(t *testing.T) {
+		_, err := check(`x + y`, nil, nil)
+
+		errs := RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+
+		// The scope is left, and the errors of the expression
+		// are not added to the errors of the program
+
+		_, err = check(`y`, nil, nil)
+		RequireCheckerErrors(t, err, 1)
+
+		assert.Nil(t, checker.CheckerError())
+	})
+}
@@ -35,9 +35,10 @@ func (checker *Checker) VisitFunctionDeclaration(declaration *ast.FunctionDeclar
 	checker.visitFunctionDeclaration(
 		declaration,
 		functionDeclarationOptions{
-			mustExit:          true,
-			declareFunction:   true,
-			checkResourceLoss: true,
+			mustExit:              true,
+			declareFunction:       true,
+			checkResourceLoss:     true,
+			checkUnusedParameters: true,
 		},
 		nil,
 	)
@@ -61,6 +62,9 @@ type functionDeclarationOptions struct {
 	// checkResourceLoss if the function should be checked for resource loss.
 	// For example, function declarations in interfaces should not be checked.
 	checkResourceLoss bool
+	// checkUnusedParameters specifies if unused parameters should be reported.
+	// For example, the parameters of functions required by an interface must be kept
+	checkUnusedParameters bool
 }
 
 func (checker *Checker) visitFunctionDeclaration(
@@ -68,6 +72,7 @@ func (checker *Checker) visitFunctionDeclaration(
 	options functionDeclarationOptions,
 	containerKind *common.CompositeKind,
 ) {
+	defer checker.lintDeclaration(declaration)
 
 	checker.checkStaticModifier(
 		declaration.IsStatic(),
@@ -126,7 +131,9 @@ func (checker *Checker) visitFunctionDeclaration(
 
 	checker.Elaboration.SetFunctionDeclarationFunctionType(declaration, functionType)
 
-	checker.checkFunction(
+	errorCount := len(checker.errors)
+
+	observedImpureOperation := checker.checkFunction(
 		declaration.ParameterList,
 		declaration.ReturnTypeAnnotation,
 		access,
@@ -135,7 +142,29 @@ func (checker *Checker) visitFunctionDeclaration(
 		options.mustExit,
 		nil,
 		options.checkResourceLoss,
+		options.checkUnusedParameters,
 	)
+
+	// Only report a missing view annotation if the function was checked successfully,
+	// as e.g. invocations of undeclared functions are not observed as impure operations
+
+	if checker.Config.ViewAnnotationWarningsEnabled &&
+		functionBlock != nil &&
+		declaration.Purity != ast.FunctionPurityView &&
+		!observedImpureOperation &&
+		len(checker.errors) == errorCount {
+
+		checker.reportWarning(
+			&MissingViewAnnotationWarning{
+				FunctionDeclaration: declaration,
+				Range: ast.NewRange(
+					checker.memoryGauge,
+					declaration.Identifier.StartPosition(),
+					declaration.Identifier.EndPosition(checker.memoryGauge),
+				),
+			},
+		)
+	}
 }
 
 func (checker *Checker) declareFunctionDeclaration(
@@ -144,7 +173,7 @@ func (checker *Checker) declareFunctionDeclaration(
 ) {
 	argumentLabels := declaration.ParameterList.EffectiveArgumentLabels()
 
-	_, err := checker.valueActivations.declare(variableDeclaration{
+	variable, err := checker.valueActivations.declare(variableDeclaration{
 		identifier:               declaration.Identifier.Identifier,
 		ty:                       functionType,
 		docString:                declaration.DocString,
@@ -157,8 +186,17 @@ func (checker *Checker) declareFunctionDeclaration(
 	})
 	checker.report(err)
 
+	if checker.functionActivations.IsLocal() {
+		checker.trackVariableUse(variable)
+	}
+
 	if checker.PositionInfo != nil {
-		checker.recordFunctionDeclarationOrigin(declaration, functionType)
+		origin := checker.recordFunctionDeclarationOrigin(declaration, functionType)
+		if variable != nil {
+			// Associate the origin with the variable,
+			// so the references to the function are recorded for it
+			checker.PositionInfo.VariableOrigins[variable] = origin
+		}
 	}
 }
 
@@ -171,7 +209,8 @@ func (checker *Checker) checkFunction(
 	mustExit bool,
 	initializationInfo *InitializationInfo,
 	checkResourceLoss bool,
-) {
+	checkUnusedParameters bool,
+) (observedImpureOperation bool) {
 	// check argument labels
 	checker.checkArgumentLabels(parameterList)
 
@@ -205,7 +244,11 @@ func (checker *Checker) checkFunction(
 				checker.leaveValueScope(endPosGetter, checkResourceLoss)
 			}()
 
-			checker.declareParameters(parameterList, functionType.Parameters)
+			checker.declareParameters(
+				parameterList,
+				functionType.Parameters,
+				checkUnusedParameters && functionBlock != nil,
+			)
 
 			functionActivation.InitializationInfo = initializationInfo
 
@@ -219,7 +262,7 @@ func (checker *Checker) checkFunction(
 					}
 					defer func() { checker.entitlementMappingInScope = oldMappedAccess }()
 
-					checker.InNewPurityScope(functionType.Purity == FunctionPurityView, func() {
+					purityScope := checker.InNewPurityScope(functionType.Purity == FunctionPurityView, func() {
 						checker.visitFunctionBlock(
 							functionBlock,
 							functionType.ReturnTypeAnnotation.Type,
@@ -227,6 +270,7 @@ func (checker *Checker) checkFunction(
 							checkResourceLoss,
 						)
 					})
+					observedImpureOperation = purityScope.ObservedImpureOperation
 				}()
 
 				if mustExit {
@@ -249,6 +293,8 @@ func (checker *Checker) checkFunction(
 			checker.PositionInfo.recordParameterRange(startPos, endPos, parameter)
 		}
 	}
+
+	return
 }
 
 // checkFunctionExits checks that the given function block exits
@@ -319,6 +365,7 @@ func (checker *Checker) checkArgumentLabels(parameterList *ast.ParameterList) {
 func (checker *Checker) declareParameters(
 	parameterList *ast.ParameterList,
 	parameters []Parameter,
+	checkUnused bool,
 ) {
 	depth := checker.valueActivations.Depth()
 
@@ -352,6 +399,9 @@ func (checker *Checker) declareParameters(
 			Pos:             &identifier.Pos,
 		}
 		checker.valueActivations.Set(identifier.Identifier, variable)
+		if checkUnused {
+			checker.trackVariableUse(variable)
+		}
 		if checker.PositionInfo != nil {
 			checker.recordVariableDeclarationOccurrence(identifier.Identifier, variable)
 		}
@@ -559,6 +609,7 @@ func (checker *Checker) VisitFunctionExpression(expression *ast.FunctionExpressi
 		true,
 		nil,
 		true,
+		true,
 	)
 
 	// function expressions are not allowed in conditions
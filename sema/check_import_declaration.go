@@ -46,6 +46,8 @@ func (checker *Checker) VisitImportDeclaration(declaration *ast.ImportDeclaratio
 }
 
 func (checker *Checker) declareImportDeclaration(declaration *ast.ImportDeclaration) {
+	defer checker.lintDeclaration(declaration)
+
 	locationRange := ast.NewRange(
 		checker.memoryGauge,
 		declaration.LocationPos,
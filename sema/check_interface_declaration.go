@@ -32,6 +32,7 @@ import (
 // and that the members and nested declarations for the interface type were declared
 // through `declareInterfaceMembers`.
 func (checker *Checker) VisitInterfaceDeclaration(declaration *ast.InterfaceDeclaration) (_ struct{}) {
+	defer checker.lintDeclaration(declaration)
 
 	wasInInterface := checker.inInterface
 	checker.inInterface = true
@@ -503,6 +504,7 @@ func (checker *Checker) declareEntitlementType(declaration *ast.EntitlementDecla
 }
 
 func (checker *Checker) VisitEntitlementDeclaration(declaration *ast.EntitlementDeclaration) (_ struct{}) {
+	defer checker.lintDeclaration(declaration)
 
 	entitlementType := checker.Elaboration.EntitlementDeclarationType(declaration)
 	// all entitlement declarations were previously declared in `declareEntitlementType`
@@ -583,6 +585,7 @@ func (checker *Checker) declareEntitlementMappingType(declaration *ast.Entitleme
 }
 
 func (checker *Checker) VisitEntitlementMappingDeclaration(declaration *ast.EntitlementMappingDeclaration) (_ struct{}) {
+	defer checker.lintDeclaration(declaration)
 
 	entitlementMapType := checker.Elaboration.EntitlementMapDeclarationType(declaration)
 	if entitlementMapType == nil {
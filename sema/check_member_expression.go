@@ -139,12 +139,15 @@ func (checker *Checker) visitMember(expression *ast.MemberExpression, isAssignme
 ) {
 	memberInfo, ok := checker.Elaboration.MemberExpressionMemberAccessInfo(expression)
 	if ok {
+		checker.reportCacheHit(CheckerCacheMemberAccess)
 		return memberInfo.AccessedType, memberInfo.ResultingType, memberInfo.Member, memberInfo.IsOptional
 	}
 
 	returnReference := false
 
 	defer func() {
+		checker.reportMemberResolved()
+
 		checker.Elaboration.SetMemberExpressionMemberAccessInfo(
 			expression,
 			MemberAccessInfo{
@@ -209,7 +212,7 @@ func (checker *Checker) visitMember(expression *ast.MemberExpression, isAssignme
 	// However, for some types (e.g. reference types) this depends on what type is referenced
 
 	getMemberForType := func(expressionType Type) {
-		resolver, ok := expressionType.GetMembers()[identifier]
+		resolver, ok := checker.memberResolvers(expressionType)[identifier]
 		if !ok {
 			return
 		}
@@ -262,6 +265,8 @@ func (checker *Checker) visitMember(expression *ast.MemberExpression, isAssignme
 		isOptional = false
 	}
 
+	checker.checkDeprecatedMember(accessedType, expression.Identifier)
+
 	if member == nil {
 		if !accessedType.IsInvalidType() {
 
@@ -290,6 +295,8 @@ func (checker *Checker) visitMember(expression *ast.MemberExpression, isAssignme
 		return
 	}
 
+	checker.recordMemberUse(member)
+
 	if checker.PositionInfo != nil {
 		checker.PositionInfo.recordMemberOccurrence(
 			accessedType,
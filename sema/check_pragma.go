@@ -24,11 +24,16 @@ import "github.com/onflow/cadence/ast"
 // It is valid if the root expression is an identifier or invocation.
 // Invocations must
 func (checker *Checker) VisitPragmaDeclaration(declaration *ast.PragmaDeclaration) (_ struct{}) {
+	defer checker.lintDeclaration(declaration)
 
 	switch expression := declaration.Expression.(type) {
 	case *ast.IdentifierExpression:
 		// valid, NO-OP
 
+		if expression.Identifier.Identifier == allowAccountLinkingPragmaName {
+			checker.checkDeprecatedSyntax(DeprecatedSyntaxAllowAccountLinkingPragma, declaration)
+		}
+
 	case *ast.InvocationExpression:
 		checker.checkPragmaInvocationExpression(expression)
 
@@ -40,8 +45,11 @@ func (checker *Checker) VisitPragmaDeclaration(declaration *ast.PragmaDeclaratio
 				expression,
 			),
 		})
+		return
 	}
 
+	checker.checkFeaturePragma(declaration)
+
 	return
 }
 
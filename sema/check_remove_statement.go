@@ -50,7 +50,7 @@ func (checker *Checker) VisitRemoveStatement(statement *ast.RemoveStatement) (_
 	switch baseType := base.(type) {
 	case *CompositeType:
 		if !baseType.Kind.SupportsAttachments() ||
-			!IsSubType(baseType, attachmentType.baseType) {
+			!checker.isSubType(baseType, attachmentType.baseType) {
 			checker.report(
 				&InvalidAttachmentRemoveError{
 					Attachment: nominalType,
@@ -60,7 +60,7 @@ func (checker *Checker) VisitRemoveStatement(statement *ast.RemoveStatement) (_
 			)
 		}
 	case *IntersectionType:
-		if !IsSubType(baseType, attachmentType.baseType) {
+		if !checker.isSubType(baseType, attachmentType.baseType) {
 			checker.report(
 				&InvalidAttachmentRemoveError{
 					Attachment: nominalType,
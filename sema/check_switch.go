@@ -50,6 +50,8 @@ func (checker *Checker) VisitSwitchStatement(statement *ast.SwitchStatement) (_
 		)
 	})
 
+	checker.checkSwitchExhaustiveness(statement, testType)
+
 	return
 }
 
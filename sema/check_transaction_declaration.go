@@ -26,6 +26,8 @@ import (
 )
 
 func (checker *Checker) VisitTransactionDeclaration(declaration *ast.TransactionDeclaration) (_ struct{}) {
+	defer checker.lintDeclaration(declaration)
+
 	transactionType := checker.Elaboration.TransactionDeclarationType(declaration)
 	if transactionType == nil {
 		panic(errors.NewUnreachableError())
@@ -88,7 +90,7 @@ func (checker *Checker) VisitTransactionDeclaration(declaration *ast.Transaction
 func (checker *Checker) checkTransactionParameters(declaration *ast.TransactionDeclaration, parameters []Parameter) {
 	checker.checkArgumentLabels(declaration.ParameterList)
 	checker.checkParameters(declaration.ParameterList, parameters)
-	checker.declareParameters(declaration.ParameterList, parameters)
+	checker.declareParameters(declaration.ParameterList, parameters, false)
 
 	// Check parameter types
 
@@ -193,6 +195,8 @@ func (checker *Checker) visitTransactionPrepareFunction(
 		true,
 		initializationInfo,
 		true,
+		// NOTE: the parameters are required for the signers, even if unused
+		false,
 	)
 
 	checker.checkTransactionPrepareFunctionParameters(
@@ -210,7 +214,7 @@ func (checker *Checker) checkTransactionPrepareFunctionParameters(
 		parameterType := parameters[i].TypeAnnotation.Type
 
 		if !parameterType.IsInvalidType() &&
-			!IsSubType(parameterType, AccountReferenceType) {
+			!checker.isSubType(parameterType, AccountReferenceType) {
 
 			checker.report(
 				&InvalidTransactionPrepareParameterTypeError{
@@ -243,6 +247,7 @@ func (checker *Checker) visitTransactionExecuteFunction(
 		true,
 		nil,
 		true,
+		false,
 	)
 }
 
@@ -25,7 +25,13 @@ import (
 
 func (checker *Checker) VisitVariableDeclaration(declaration *ast.VariableDeclaration) (_ struct{}) {
 	declarationType := checker.visitVariableDeclarationValues(declaration, false)
-	checker.declareVariableDeclaration(declaration, declarationType)
+	variable := checker.declareVariableDeclaration(declaration, declarationType)
+
+	if checker.functionActivations.IsLocal() {
+		checker.trackVariableUse(variable)
+	}
+
+	checker.lintDeclaration(declaration)
 
 	return
 }
@@ -217,7 +223,7 @@ func (checker *Checker) visitVariableDeclarationValues(declaration *ast.Variable
 	return declarationType
 }
 
-func (checker *Checker) declareVariableDeclaration(declaration *ast.VariableDeclaration, declarationType Type) {
+func (checker *Checker) declareVariableDeclaration(declaration *ast.VariableDeclaration, declarationType Type) *Variable {
 	// Finally, declare the variable in the current value activation
 
 	identifier := declaration.Identifier.Identifier
@@ -241,6 +247,8 @@ func (checker *Checker) declareVariableDeclaration(declaration *ast.VariableDecl
 	}
 
 	checker.recordReference(variable, declaration.Value)
+
+	return variable
 }
 
 func (checker *Checker) recordVariableDeclarationRange(
@@ -88,6 +88,8 @@ type PurityCheckScope struct {
 	// whether encountering an impure operation should cause an error
 	EnforcePurity   bool
 	ActivationDepth int
+	// whether an impure operation was encountered
+	ObservedImpureOperation bool
 }
 
 type ContractValueHandlerFunc func(
@@ -115,6 +117,11 @@ type Checker struct {
 	// initialized lazily. use beforeExtractor()
 	_beforeExtractor                   *BeforeExtractor
 	errors                             []error
+	warnings                           []error
+	unusedVariables                    map[*Variable]struct{}
+	usedMembers                        map[usedMember]struct{}
+	memberResolverCache                map[memberResolverCacheKey]map[string]MemberResolver
+	incrementalChecking                *incrementalChecking
 	functionActivations                *FunctionActivations
 	purityCheckScopes                  []PurityCheckScope
 	entitlementMappingInScope          *EntitlementMapType
@@ -212,6 +219,11 @@ func NewChecker(
 		checker.PositionInfo = NewPositionInfo()
 	}
 
+	// Initialize incremental checking state, if enabled
+	if checker.Config.IncrementalCheckingEnabled {
+		checker.incrementalChecking = newIncrementalChecking()
+	}
+
 	return checker, nil
 }
 
@@ -259,6 +271,8 @@ func (checker *Checker) EnforcePurity(operation ast.Element, purity FunctionPuri
 }
 
 func (checker *Checker) ObserveImpureOperation(operation ast.Element) {
+	checker.purityCheckScopes[len(checker.purityCheckScopes)-1].ObservedImpureOperation = true
+
 	scope := checker.CurrentPurityScope()
 	if scope.EnforcePurity {
 		checker.report(
@@ -269,10 +283,10 @@ func (checker *Checker) ObserveImpureOperation(operation ast.Element) {
 	}
 }
 
-func (checker *Checker) InNewPurityScope(enforce bool, f func()) {
+func (checker *Checker) InNewPurityScope(enforce bool, f func()) PurityCheckScope {
 	checker.PushNewPurityScope(enforce, checker.ValueActivationDepth())
 	f()
-	checker.PopPurityScope()
+	return checker.PopPurityScope()
 }
 
 type stopChecking struct{}
@@ -451,14 +465,23 @@ func (checker *Checker) CheckProgram(program *ast.Program) {
 
 	checker.checkTopLevelDeclarationsValidity(declarations)
 
-	for _, declaration := range declarations {
+	if checker.incrementalChecking != nil {
+		checker.incrementalChecking.declarationErrorCount = len(checker.errors)
+	}
+
+	if checker.concurrentCheckingEnabled() {
+		checker.checkTopLevelDeclarationsConcurrently(declarations)
+		return
+	}
+
+	for index, declaration := range declarations {
 
 		// Skip import declarations, they are already handled above
 		if _, isImport := declaration.(*ast.ImportDeclaration); isImport {
 			continue
 		}
 
-		ast.AcceptDeclaration[struct{}](declaration, checker)
+		checker.checkTopLevelDeclaration(index, declaration)
 		checker.declareGlobalDeclaration(declaration)
 	}
 }
@@ -592,7 +615,7 @@ func (checker *Checker) checkTypeCompatibility(expression ast.Expression, valueT
 
 				literalCount := int64(len(typedExpression.Values))
 
-				if IsSubType(valueElementType, targetElementType) {
+				if checker.isSubType(valueElementType, targetElementType) {
 
 					expectedSize := constantSizedTargetType.Size
 
@@ -621,7 +644,7 @@ func (checker *Checker) checkTypeCompatibility(expression ast.Expression, valueT
 		}
 	}
 
-	return IsSubType(valueType, targetType)
+	return checker.isSubType(valueType, targetType)
 }
 
 // CheckIntegerLiteral checks that the value of the integer literal
@@ -845,6 +868,8 @@ func (checker *Checker) findAndCheckValueVariable(identifierExpression *ast.Iden
 		return nil
 	}
 
+	checker.recordVariableUse(variable)
+
 	if checker.PositionInfo != nil && recordOccurrence && identifier.Identifier != "" {
 		checker.recordVariableReferenceOccurrence(
 			identifier.StartPosition(),
@@ -1086,7 +1111,7 @@ func (checker *Checker) convertDictionaryType(t *ast.DictionaryType) Type {
 	keyType := checker.ConvertType(t.KeyType)
 	valueType := checker.ConvertType(t.ValueType)
 
-	if !IsSubType(keyType, HashableStructType) {
+	if !checker.isSubType(keyType, HashableStructType) {
 		checker.report(
 			&InvalidDictionaryKeyTypeError{
 				Type:  keyType,
@@ -1474,6 +1499,8 @@ func (checker *Checker) leaveValueScope(getEndPosition EndPositionGetter, checkR
 		checker.checkResourceLoss(checker.valueActivations.Depth())
 	}
 
+	checker.checkUnusedVariables()
+
 	checker.valueActivations.Leave(getEndPosition)
 }
 
@@ -2558,7 +2585,7 @@ func (checker *Checker) VisitExpressionWithReferenceCheck(expr ast.Expression, p
 
 	if expectedType == nil &&
 		!visibleType.IsInvalidType() &&
-		!IsSubType(visibleType, targetType) {
+		!checker.isSubType(visibleType, targetType) {
 
 		checker.report(
 			&TypeMismatchError{
@@ -2613,6 +2640,8 @@ func (checker *Checker) visitExpressionWithForceType(
 
 	checker.checkErrorsForInvalidExpressionTypes(actualType, expectedType)
 
+	checker.lintExpression(expr, actualType)
+
 	if checker.Config.ExtendedElaborationEnabled {
 		checker.Elaboration.SetExpressionTypes(
 			expr,
@@ -2627,7 +2656,7 @@ func (checker *Checker) visitExpressionWithForceType(
 		expectedType != nil &&
 		!expectedType.IsInvalidType() &&
 		actualType != InvalidType &&
-		!IsSubType(actualType, expectedType) {
+		!checker.isSubType(actualType, expectedType) {
 
 		checker.report(
 			&TypeMismatchError{
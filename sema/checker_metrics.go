@@ -0,0 +1,104 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"time"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+)
+
+// CheckerMetrics is used to report metrics of the checker,
+// e.g. to diagnose programs which are expensive to check.
+//
+// When concurrent checking is enabled (see Config.CheckConcurrency),
+// the functions may be called concurrently
+type CheckerMetrics interface {
+	// DeclarationChecked is called after a top-level declaration of the program with the given location
+	// has been checked, with the duration of the check, including the check of all nested declarations
+	DeclarationChecked(location common.Location, kind common.DeclarationKind, duration time.Duration)
+	// MemberResolved is called when the checker resolved the member accessed in a member expression
+	MemberResolved(location common.Location)
+	// SubtypeChecked is called when the checker determines if a type is a subtype of another type.
+	// Subtype checks performed as part of another subtype check are not reported
+	SubtypeChecked(location common.Location)
+	// CacheHit is called when the checker reuses a previously determined result
+	CacheHit(location common.Location, cache CheckerCache)
+}
+
+// CheckerCache is a cache of the checker, see CheckerMetrics.CacheHit
+type CheckerCache uint8
+
+const (
+	CheckerCacheUnknown CheckerCache = iota
+	// CheckerCacheMemberAccess is the cache of the members accessed in member expressions
+	CheckerCacheMemberAccess
+)
+
+func (c CheckerCache) String() string {
+	switch c {
+	case CheckerCacheUnknown:
+		return "unknown"
+	case CheckerCacheMemberAccess:
+		return "member access"
+	}
+
+	return ""
+}
+
+// checkTopLevelDeclarationWithMetrics checks the given top-level declaration,
+// and reports the duration of the check, if metrics are enabled
+func (checker *Checker) checkTopLevelDeclarationWithMetrics(declaration ast.Declaration) {
+	metrics := checker.Config.Metrics
+	if metrics == nil {
+		ast.AcceptDeclaration[struct{}](declaration, checker)
+		return
+	}
+
+	start := time.Now()
+	ast.AcceptDeclaration[struct{}](declaration, checker)
+	metrics.DeclarationChecked(
+		checker.Location,
+		declaration.DeclarationKind(),
+		time.Since(start),
+	)
+}
+
+// isSubType determines if the given subtype is a subtype of the given supertype (see IsSubType),
+// and reports the check, if metrics are enabled
+func (checker *Checker) isSubType(subType Type, superType Type) bool {
+	if metrics := checker.Config.Metrics; metrics != nil {
+		metrics.SubtypeChecked(checker.Location)
+	}
+
+	return IsSubType(subType, superType)
+}
+
+func (checker *Checker) reportMemberResolved() {
+	if metrics := checker.Config.Metrics; metrics != nil {
+		metrics.MemberResolved(checker.Location)
+	}
+}
+
+func (checker *Checker) reportCacheHit(cache CheckerCache) {
+	if metrics := checker.Config.Metrics; metrics != nil {
+		metrics.CacheHit(checker.Location, cache)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+type testCheckerMetrics struct {
+	mu                   sync.Mutex
+	declarations         []common.DeclarationKind
+	memberResolutions    int
+	subtypeChecks        int
+	memberAccessCacheHit int
+}
+
+var _ sema.CheckerMetrics = &testCheckerMetrics{}
+
+func (m *testCheckerMetrics) DeclarationChecked(_ common.Location, kind common.DeclarationKind, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if duration < 0 {
+		panic("negative duration")
+	}
+	m.declarations = append(m.declarations, kind)
+}
+
+func (m *testCheckerMetrics) MemberResolved(_ common.Location) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.memberResolutions++
+}
+
+func (m *testCheckerMetrics) SubtypeChecked(_ common.Location) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.subtypeChecks++
+}
+
+func (m *testCheckerMetrics) CacheHit(_ common.Location, cache sema.CheckerCache) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cache == sema.CheckerCacheMemberAccess {
+		m.memberAccessCacheHit++
+	}
+}
+
+func TestCheckCheckerMetrics(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      struct S {
+          var x: Int
+
+          init() {
+              self.x = 1
+          }
+
+          fun get(): Int {
+              return self.x
+          }
+      }
+
+      fun test(): Int {
+          let s = S()
+          s.x = s.x + 1
+          return s.x + s.get()
+      }
+
+      let y: Int = 2
+    `
+
+	test := func(t *testing.T, concurrency int) {
+		metrics := &testCheckerMetrics{}
+
+		_, err := ParseAndCheckWithOptions(t,
+			code,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					Metrics:          metrics,
+					CheckConcurrency: concurrency,
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t,
+			[]common.DeclarationKind{
+				common.DeclarationKindStructure,
+				common.DeclarationKindFunction,
+				common.DeclarationKindConstant,
+			},
+			metrics.declarations,
+		)
+
+		// self.x (initializer), self.x (function), s.x (assignment target and value),
+		// s.x and s.get (return)
+		assert.Equal(t, 6, metrics.memberResolutions)
+		assert.Positive(t, metrics.subtypeChecks)
+		// the invoked member expression s.get is resolved when checking the invocation
+		assert.Equal(t, 1, metrics.memberAccessCacheHit)
+	}
+
+	t.Run("sequential", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, 0)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		t.Parallel()
+
+		test(t, 4)
+	})
+}
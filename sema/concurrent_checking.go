@@ -0,0 +1,203 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"sync"
+
+	"github.com/onflow/cadence/ast"
+)
+
+// Concurrent checking
+//
+// Once all types and values of a program have been declared,
+// top-level declarations which only read the global scope can be checked independently of each other.
+// Each worker checks declarations using a fork of the checker,
+// which has its own scopes, resource tracking, and elaboration.
+//
+// Consecutive concurrently checkable declarations are checked as a batch.
+// Other declarations, e.g. variable declarations, declare values in the global scope,
+// so they are checked sequentially, in between batches.
+// The diagnostics and elaborations of a batch are joined in declaration order,
+// so the result is the same as if the declarations were checked sequentially.
+
+func (checker *Checker) concurrentCheckingEnabled() bool {
+	return checker.Config.CheckConcurrency > 1 &&
+		checker.memoryGauge == nil &&
+		checker.PositionInfo == nil &&
+		checker.incrementalChecking == nil &&
+		!checker.Config.ErrorShortCircuitingEnabled &&
+		len(checker.Config.LintRules) == 0
+}
+
+func isConcurrentlyCheckable(declaration ast.Declaration) bool {
+	switch declaration.(type) {
+	case *ast.FunctionDeclaration,
+		*ast.CompositeDeclaration,
+		*ast.InterfaceDeclaration,
+		*ast.AttachmentDeclaration:
+
+		return true
+	}
+
+	return false
+}
+
+func (checker *Checker) checkTopLevelDeclarationsConcurrently(declarations []ast.Declaration) {
+
+	var batch []ast.Declaration
+
+	for index, declaration := range declarations {
+
+		// Skip import declarations, they are already handled in CheckProgram
+		if _, isImport := declaration.(*ast.ImportDeclaration); isImport {
+			continue
+		}
+
+		if isConcurrentlyCheckable(declaration) {
+			batch = append(batch, declaration)
+			continue
+		}
+
+		checker.checkDeclarationBatch(batch)
+		batch = batch[:0]
+
+		checker.checkTopLevelDeclaration(index, declaration)
+		checker.declareGlobalDeclaration(declaration)
+	}
+
+	checker.checkDeclarationBatch(batch)
+}
+
+type batchDeclarationResult struct {
+	errors   []error
+	warnings []error
+}
+
+func (checker *Checker) checkDeclarationBatch(batch []ast.Declaration) {
+
+	if len(batch) < 2 {
+		for _, declaration := range batch {
+			checker.checkTopLevelDeclarationWithMetrics(declaration)
+			checker.declareGlobalDeclaration(declaration)
+		}
+		return
+	}
+
+	workerCount := min(checker.Config.CheckConcurrency, len(batch))
+
+	indices := make(chan int, len(batch))
+	for index := range batch {
+		indices <- index
+	}
+	close(indices)
+
+	results := make([]batchDeclarationResult, len(batch))
+	workers := make([]*Checker, workerCount)
+
+	var wg sync.WaitGroup
+	var panicOnce sync.Once
+	var recovered any
+
+	errorCount := len(checker.errors)
+
+	for i := range workers {
+		worker := checker.fork()
+		workers[i] = worker
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			defer func() {
+				if r := recover(); r != nil {
+					panicOnce.Do(func() {
+						recovered = r
+					})
+				}
+			}()
+
+			for index := range indices {
+				// Workers start with the errors reported so far, e.g. while declaring the program,
+				// as some checks depend on whether errors have already been reported
+				worker.errors = checker.errors[:errorCount:errorCount]
+				worker.warnings = nil
+
+				worker.checkTopLevelDeclarationWithMetrics(batch[index])
+
+				results[index] = batchDeclarationResult{
+					errors:   worker.errors[errorCount:],
+					warnings: worker.warnings,
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// Propagate internal errors of workers
+	if recovered != nil {
+		panic(recovered)
+	}
+
+	for _, worker := range workers {
+		checker.Elaboration.join(worker.Elaboration)
+		worker.resources.Reclaim()
+	}
+
+	for index, declaration := range batch {
+		result := results[index]
+		checker.errors = append(checker.errors, result.errors...)
+		checker.warnings = append(checker.warnings, result.warnings...)
+
+		checker.declareGlobalDeclaration(declaration)
+	}
+}
+
+// fork returns a checker which checks declarations in the current global scope
+// independently of this checker, see checkDeclarationBatch
+func (checker *Checker) fork() *Checker {
+
+	functionActivations := &FunctionActivations{
+		activations: make([]*FunctionActivation, 0, 2),
+	}
+	functionActivations.EnterFunction(
+		baseFunctionType,
+		0,
+	)
+
+	return &Checker{
+		Program:     checker.Program,
+		Location:    checker.Location,
+		Config:      checker.Config,
+		Elaboration: checker.Elaboration.fork(),
+		resources:   checker.resources.Clone(),
+		// The global scope is shared, but not modified by workers:
+		// Workers declare values and types in nested scopes
+		valueActivations: &VariableActivations{
+			activations: []*VariableActivation{checker.valueActivations.Current()},
+		},
+		typeActivations: &VariableActivations{
+			activations: []*VariableActivation{checker.typeActivations.Current()},
+		},
+		functionActivations: functionActivations,
+		containerTypes:      map[Type]bool{},
+		purityCheckScopes:   []PurityCheckScope{{}},
+	}
+}
@@ -0,0 +1,251 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func concurrentCheckingTestProgram(declarationCount int) string {
+	var builder strings.Builder
+
+	for i := 0; i < declarationCount; i++ {
+		switch i % 4 {
+		case 0:
+			_, _ = fmt.Fprintf(&builder,
+				`
+                  fun f%[1]d(_ s: S%[2]d): Int {
+                      var sum = 0
+                      for x in [1, 2, 3] {
+                          sum = sum + x + s.x
+                      }
+                      return sum
+                  }
+                `,
+				i,
+				i+1,
+			)
+
+		case 1:
+			_, _ = fmt.Fprintf(&builder,
+				`
+                  struct S%[1]d {
+                      let x: Int
+                      init() { self.x = f%[2]d(S%[1]d()) }
+                      fun test(): String { return self.x.toString() }
+                  }
+                `,
+				i,
+				i-1,
+			)
+
+		case 2:
+			_, _ = fmt.Fprintf(&builder,
+				`
+                  resource interface I%[1]d {
+                      fun test(): Int
+                  }
+                `,
+				i,
+			)
+
+		case 3:
+			// Invalid: undeclared variable and type mismatch
+			_, _ = fmt.Fprintf(&builder,
+				`
+                  fun g%[1]d(): String {
+                      let y: Int = "%[1]d"
+                      return z%[1]d
+                  }
+                `,
+				i,
+			)
+		}
+	}
+
+	return builder.String()
+}
+
+func TestCheckConcurrently(t *testing.T) {
+
+	t.Parallel()
+
+	// errorDescriptions returns descriptions of the given errors,
+	// so the errors of different checkers can be compared
+	errorDescriptions := func(errs []error) []string {
+		descriptions := make([]string, 0, len(errs))
+		for _, err := range errs {
+			descriptions = append(
+				descriptions,
+				fmt.Sprintf(
+					"%T %s %s",
+					err,
+					err.(ast.HasPosition).StartPosition(),
+					err.Error(),
+				),
+			)
+		}
+		return descriptions
+	}
+
+	check := func(t *testing.T, program *ast.Program, concurrency int) (*sema.Checker, []error) {
+		checker, err := sema.NewChecker(
+			program,
+			TestLocation,
+			nil,
+			&sema.Config{
+				AccessCheckMode:  sema.AccessCheckModeNotSpecifiedUnrestricted,
+				CheckConcurrency: concurrency,
+			},
+		)
+		require.NoError(t, err)
+
+		err = checker.Check()
+		if err == nil {
+			return checker, nil
+		}
+		return checker, RequireCheckerErrors(t, err, len(checker.CheckerError().Errors))
+	}
+
+	parse := func(t *testing.T, code string) *ast.Program {
+		program, err := parser.ParseProgram(nil, []byte(code), parser.Config{})
+		require.NoError(t, err)
+		return program
+	}
+
+	t.Run("same result as sequential checking", func(t *testing.T) {
+		t.Parallel()
+
+		program := parse(t, concurrentCheckingTestProgram(100))
+
+		sequentialChecker, sequentialErrors := check(t, program, 0)
+		concurrentChecker, concurrentErrors := check(t, program, 8)
+
+		require.Len(t, sequentialErrors, 50)
+		assert.Equal(t,
+			errorDescriptions(sequentialErrors),
+			errorDescriptions(concurrentErrors),
+		)
+
+		for _, declaration := range concurrentChecker.Program.FunctionDeclarations() {
+			for _, statement := range declaration.FunctionBlock.Block.Statements {
+				returnStatement, ok := statement.(*ast.ReturnStatement)
+				if !ok {
+					continue
+				}
+
+				sequentialTypes := sequentialChecker.Elaboration.ReturnStatementTypes(returnStatement)
+				concurrentTypes := concurrentChecker.Elaboration.ReturnStatementTypes(returnStatement)
+				require.NotNil(t, concurrentTypes.ValueType)
+				assert.Equal(t,
+					sequentialTypes.ValueType.ID(),
+					concurrentTypes.ValueType.ID(),
+				)
+			}
+		}
+
+		_, ok := concurrentChecker.Elaboration.GetGlobalValue("f0")
+		assert.True(t, ok)
+		_, ok = concurrentChecker.Elaboration.GetGlobalType("S1")
+		assert.True(t, ok)
+	})
+
+	t.Run("global variables are declared in order", func(t *testing.T) {
+		t.Parallel()
+
+		program := parse(t, `
+          fun a(): Int { return x }
+          fun b(): Int { return x }
+
+          let x = 1
+
+          fun c(): Int { return x }
+          fun d(): Int { return y }
+
+          let y = 2
+        `)
+
+		_, sequentialErrors := check(t, program, 0)
+		_, concurrentErrors := check(t, program, 4)
+
+		require.Len(t, sequentialErrors, 3)
+		assert.Equal(t,
+			errorDescriptions(sequentialErrors),
+			errorDescriptions(concurrentErrors),
+		)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		_, errs := check(t,
+			parse(t, `
+              fun a(): Int { return b() }
+              fun b(): Int { return 1 }
+              struct S {
+                  fun c(): Int { return a() }
+              }
+            `),
+			4,
+		)
+		require.Empty(t, errs)
+	})
+}
+
+func BenchmarkCheckConcurrently(b *testing.B) {
+
+	code := concurrentCheckingTestProgram(1000)
+
+	program, err := parser.ParseProgram(nil, []byte(code), parser.Config{})
+	require.NoError(b, err)
+
+	for _, concurrency := range []int{1, 2, 4, 8} {
+
+		b.Run(fmt.Sprintf("concurrency %d", concurrency), func(b *testing.B) {
+
+			config := &sema.Config{
+				AccessCheckMode:  sema.AccessCheckModeNotSpecifiedUnrestricted,
+				CheckConcurrency: concurrency,
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				checker, err := sema.NewChecker(program, TestLocation, nil, config)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				_ = checker.Check()
+			}
+		})
+	}
+}
@@ -49,8 +49,53 @@ type Config struct {
 	// PositionInfoEnabled determines if position information is generated.
 	// Position info includes origins, occurrences, member accesses, ranges, and function invocations
 	PositionInfoEnabled bool
+	// IncrementalCheckingEnabled determines if the state required for re-checking
+	// individual declarations of a checked program is recorded, see Checker.Recheck
+	IncrementalCheckingEnabled bool
 	// AllowNativeDeclarations determines if declarations may be native
 	AllowNativeDeclarations bool
 	// AllowStaticDeclarations determines if declarations may be static
 	AllowStaticDeclarations bool
+	// DeprecationRules are the rules for deprecated syntax and APIs.
+	// The checker reports warnings for uses of them, see Checker.Warnings.
+	// When nil (the default), no deprecation warnings are reported
+	DeprecationRules *DeprecationRuleSet
+	// ViewAnnotationWarningsEnabled determines if the checker reports warnings for functions
+	// which have no side effects, but are not annotated with `view`, see MissingViewAnnotationWarning
+	ViewAnnotationWarningsEnabled bool
+	// SwitchExhaustivenessWarningsEnabled determines if the checker reports warnings for switch statements
+	// over enum and optional types which do not cover all cases, see NonExhaustiveSwitchWarning
+	SwitchExhaustivenessWarningsEnabled bool
+	// UnusedDeclarationWarningsEnabled determines if the checker reports warnings for local variables,
+	// function parameters, and private member functions which are never used, see UnusedDeclarationWarning
+	// and Checker.DeadCode
+	UnusedDeclarationWarningsEnabled bool
+	// DiagnosticLevels overrides the severity of warnings by their code, see ErrorCodeOf.
+	// Warnings can be promoted to errors, or suppressed.
+	// Only warnings are affected, errors are always reported as errors
+	DiagnosticLevels map[ErrorCode]Severity
+	// DiagnosticLevelsHandler, if set, is used to override the severity of warnings
+	// for the program with a given location. The returned levels have precedence over DiagnosticLevels
+	DiagnosticLevelsHandler DiagnosticLevelsHandlerFunc
+	// WarningsAsErrors determines if all warnings are reported as errors,
+	// unless a different severity is configured for the warning's code in the diagnostic levels
+	WarningsAsErrors bool
+	// FeaturePragmas are the pragmas which enable features for a program, see FeaturePragma.
+	// Uses of feature pragmas are validated, and recorded in the elaboration, see Elaboration.FeaturePragmas
+	FeaturePragmas *FeaturePragmaSet
+	// LintRules are additional rules which are checked in the same pass as type checking, see LintRule
+	LintRules []*LintRule
+	// Metrics, if set, is used to report metrics of the checker, see CheckerMetrics
+	Metrics CheckerMetrics
+	// IntegerLiteralBitLengthLimit is the maximum length in bits of the value of integer literals,
+	// see IntegerLiteralSizeLimitExceededError.
+	// When 0 (the default), the size of integer literals is only limited by the range of their type
+	IntegerLiteralBitLengthLimit int
+	// CheckConcurrency is the maximum number of top-level declarations of a program which are checked concurrently,
+	// once all types and values of the program have been declared.
+	// When 0 or 1 (the default), all declarations are checked sequentially.
+	// When enabled, MemberAccountAccessHandler and Metrics must be safe for concurrent use.
+	// Declarations are always checked sequentially when a memory gauge is used,
+	// or when error short-circuiting, position info, incremental checking, or lint rules are enabled
+	CheckConcurrency int
 }
@@ -0,0 +1,117 @@
+// Code generated from contract_metadata.cdc. DO NOT EDIT.
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+)
+
+const ContractMetadataTypeNameFieldName = "name"
+
+var ContractMetadataTypeNameFieldType = StringType
+
+const ContractMetadataTypeNameFieldDocString = `
+The name of the contract.
+`
+
+const ContractMetadataTypeCodeHashFieldName = "codeHash"
+
+var ContractMetadataTypeCodeHashFieldType = &ConstantSizedType{
+	Type: UInt8Type,
+	Size: 32,
+}
+
+const ContractMetadataTypeCodeHashFieldDocString = `
+The SHA3-256 hash of the code of the contract.
+`
+
+const ContractMetadataTypeSizeFieldName = "size"
+
+var ContractMetadataTypeSizeFieldType = UInt64Type
+
+const ContractMetadataTypeSizeFieldDocString = `
+The size of the code of the contract, in bytes.
+`
+
+const ContractMetadataTypeUpdateHeightFieldName = "updateHeight"
+
+var ContractMetadataTypeUpdateHeightFieldType = &OptionalType{
+	Type: UInt64Type,
+}
+
+const ContractMetadataTypeUpdateHeightFieldDocString = `
+The height of the block in which the contract was last added or updated.
+
+Nil if the height is not provided by the environment.
+`
+
+const ContractMetadataTypeName = "ContractMetadata"
+
+var ContractMetadataType = func() *CompositeType {
+	var t = &CompositeType{
+		Identifier:         ContractMetadataTypeName,
+		Kind:               common.CompositeKindStructure,
+		ImportableBuiltin:  false,
+		HasComputedMembers: true,
+	}
+
+	return t
+}()
+
+func init() {
+	var members = []*Member{
+		NewUnmeteredFieldMember(
+			ContractMetadataType,
+			PrimitiveAccess(ast.AccessAll),
+			ast.VariableKindConstant,
+			ContractMetadataTypeNameFieldName,
+			ContractMetadataTypeNameFieldType,
+			ContractMetadataTypeNameFieldDocString,
+		),
+		NewUnmeteredFieldMember(
+			ContractMetadataType,
+			PrimitiveAccess(ast.AccessAll),
+			ast.VariableKindConstant,
+			ContractMetadataTypeCodeHashFieldName,
+			ContractMetadataTypeCodeHashFieldType,
+			ContractMetadataTypeCodeHashFieldDocString,
+		),
+		NewUnmeteredFieldMember(
+			ContractMetadataType,
+			PrimitiveAccess(ast.AccessAll),
+			ast.VariableKindConstant,
+			ContractMetadataTypeSizeFieldName,
+			ContractMetadataTypeSizeFieldType,
+			ContractMetadataTypeSizeFieldDocString,
+		),
+		NewUnmeteredFieldMember(
+			ContractMetadataType,
+			PrimitiveAccess(ast.AccessAll),
+			ast.VariableKindConstant,
+			ContractMetadataTypeUpdateHeightFieldName,
+			ContractMetadataTypeUpdateHeightFieldType,
+			ContractMetadataTypeUpdateHeightFieldDocString,
+		),
+	}
+
+	ContractMetadataType.Members = MembersAsMap(members)
+	ContractMetadataType.Fields = MembersFieldNames(members)
+}
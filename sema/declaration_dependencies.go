@@ -0,0 +1,169 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"sort"
+
+	"github.com/onflow/cadence/ast"
+)
+
+// DeclarationDependencies is a dependency graph between the declarations of a program.
+//
+// The nodes of the graph are the top-level declarations of the program,
+// and the member functions and nested declarations of composite declarations.
+// A composite declaration node does not cover its member functions and nested composite declarations,
+// so that a change of a member function does not affect the containing composite declaration.
+//
+// A node depends on a top-level declaration if it refers to the declaration by name.
+// References are over-approximated: shadowing is not taken into account.
+type DeclarationDependencies struct {
+	topLevelDeclarations map[string]ast.Declaration
+	indices              map[ast.Declaration]int
+	nodes                []ast.Declaration
+	dependencies         map[ast.Declaration]map[ast.Declaration]struct{}
+	dependents           map[ast.Declaration]map[ast.Declaration]struct{}
+}
+
+// NewDeclarationDependencies returns the dependency graph for the given program
+func NewDeclarationDependencies(program *ast.Program) *DeclarationDependencies {
+	dependencies := &DeclarationDependencies{
+		topLevelDeclarations: map[string]ast.Declaration{},
+		indices:              map[ast.Declaration]int{},
+		dependencies:         map[ast.Declaration]map[ast.Declaration]struct{}{},
+		dependents:           map[ast.Declaration]map[ast.Declaration]struct{}{},
+	}
+
+	declarations := program.Declarations()
+
+	for _, declaration := range declarations {
+		identifier := declaration.DeclarationIdentifier()
+		if identifier == nil {
+			continue
+		}
+		dependencies.topLevelDeclarations[identifier.Identifier] = declaration
+	}
+
+	for _, declaration := range declarations {
+		dependencies.addNode(declaration)
+	}
+
+	for _, node := range dependencies.nodes {
+		dependencies.Update(node)
+	}
+
+	return dependencies
+}
+
+func (d *DeclarationDependencies) addNode(declaration ast.Declaration) {
+	d.indices[declaration] = len(d.nodes)
+	d.nodes = append(d.nodes, declaration)
+
+	compositeDeclaration, ok := declaration.(*ast.CompositeDeclaration)
+	if !ok {
+		return
+	}
+
+	members := compositeDeclaration.Members
+
+	for _, function := range members.Functions() {
+		d.addNode(function)
+	}
+
+	for _, nestedComposite := range members.Composites() {
+		d.addNode(nestedComposite)
+	}
+}
+
+// Update recomputes the dependencies of the given declaration,
+// e.g. after the body of a function declaration was replaced
+func (d *DeclarationDependencies) Update(declaration ast.Declaration) {
+	if _, ok := d.indices[declaration]; !ok {
+		return
+	}
+
+	for dependency := range d.dependencies[declaration] {
+		delete(d.dependents[dependency], declaration)
+	}
+
+	dependencies := map[ast.Declaration]struct{}{}
+	d.dependencies[declaration] = dependencies
+
+	ast.Inspect(declaration, func(element ast.Element) bool {
+		switch element := element.(type) {
+		case nil:
+			return false
+
+		case *ast.IdentifierExpression:
+			dependency, ok := d.topLevelDeclarations[element.Identifier.Identifier]
+			if ok && dependency != declaration {
+				dependencies[dependency] = struct{}{}
+			}
+
+		case ast.Declaration:
+			// Nested nodes have their own dependencies
+			if element != declaration && d.isNode(element) {
+				return false
+			}
+		}
+
+		return true
+	})
+
+	for dependency := range dependencies {
+		dependents, ok := d.dependents[dependency]
+		if !ok {
+			dependents = map[ast.Declaration]struct{}{}
+			d.dependents[dependency] = dependents
+		}
+		dependents[declaration] = struct{}{}
+	}
+}
+
+func (d *DeclarationDependencies) isNode(declaration ast.Declaration) bool {
+	_, ok := d.indices[declaration]
+	return ok
+}
+
+// Dependencies returns the top-level declarations the given declaration depends on, in program order
+func (d *DeclarationDependencies) Dependencies(declaration ast.Declaration) []ast.Declaration {
+	return d.sorted(d.dependencies[declaration])
+}
+
+// Dependents returns the declarations which depend on the given top-level declaration, in program order
+func (d *DeclarationDependencies) Dependents(declaration ast.Declaration) []ast.Declaration {
+	return d.sorted(d.dependents[declaration])
+}
+
+func (d *DeclarationDependencies) sorted(declarations map[ast.Declaration]struct{}) []ast.Declaration {
+	if len(declarations) == 0 {
+		return nil
+	}
+
+	result := make([]ast.Declaration, 0, len(declarations))
+	for declaration := range declarations {
+		result = append(result, declaration)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return d.indices[result[i]] < d.indices[result[j]]
+	})
+
+	return result
+}
@@ -0,0 +1,99 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/cadence/sema"
+)
+
+func TestDeclarationDependencies(t *testing.T) {
+
+	t.Parallel()
+
+	program, err := parser.ParseProgram(
+		nil,
+		[]byte(`
+          fun f(): Int {
+              return 1
+          }
+
+          access(all) contract C {
+
+              access(all) let x: Int
+
+              init() {
+                  self.x = f()
+              }
+
+              access(all) fun g(): Int {
+                  let f = 2
+                  return f
+              }
+
+              access(all) fun h(): Int {
+                  return self.g()
+              }
+          }
+
+          fun i(): Int {
+              fun j(): Int {
+                  return f()
+              }
+              return j() + C.h()
+          }
+        `),
+		parser.Config{},
+	)
+	require.NoError(t, err)
+
+	dependencies := sema.NewDeclarationDependencies(program)
+
+	f := program.FunctionDeclarations()[0]
+	i := program.FunctionDeclarations()[1]
+	c := program.CompositeDeclarations()[0]
+	g := c.Members.FunctionsByIdentifier()["g"]
+	h := c.Members.FunctionsByIdentifier()["h"]
+
+	// NOTE: references are over-approximated, the local variable f in g shadows the function f
+
+	assert.Equal(t,
+		[]ast.Declaration{c, g, i},
+		dependencies.Dependents(f),
+	)
+
+	assert.Equal(t,
+		[]ast.Declaration{i},
+		dependencies.Dependents(c),
+	)
+
+	assert.Empty(t, dependencies.Dependents(i))
+	assert.Empty(t, dependencies.Dependencies(h))
+
+	assert.Equal(t,
+		[]ast.Declaration{f, c},
+		dependencies.Dependencies(i),
+	)
+}
@@ -0,0 +1,72 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/common"
+)
+
+// DeclaredType is a type declared in a program,
+// e.g. a composite, interface, event, entitlement, or entitlement mapping
+type DeclaredType struct {
+	Type Type
+	Kind common.DeclarationKind
+}
+
+func (t DeclaredType) ID() TypeID {
+	return t.Type.ID()
+}
+
+// DeclaredTypes returns all types declared in the checked program, including nested types.
+// Each type is followed by its nested types, in the order in which they were declared by the checker.
+func (e *Elaboration) DeclaredTypes() (declaredTypes []DeclaredType) {
+	e.ForEachGlobalType(func(_ string, variable *Variable) {
+		VisitThisAndNested(variable.Type, func(ty Type) {
+			kind, ok := declaredTypeKind(ty)
+			if !ok {
+				return
+			}
+
+			declaredTypes = append(
+				declaredTypes,
+				DeclaredType{
+					Type: ty,
+					Kind: kind,
+				},
+			)
+		})
+	})
+
+	return
+}
+
+func declaredTypeKind(ty Type) (common.DeclarationKind, bool) {
+	switch ty := ty.(type) {
+	case *CompositeType:
+		return ty.Kind.DeclarationKind(false), true
+	case *InterfaceType:
+		return ty.CompositeKind.DeclarationKind(true), true
+	case *EntitlementType:
+		return common.DeclarationKindEntitlement, true
+	case *EntitlementMapType:
+		return common.DeclarationKindEntitlementMapping, true
+	default:
+		return common.DeclarationKindUnknown, false
+	}
+}
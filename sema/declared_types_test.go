@@ -0,0 +1,93 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func TestCheckDeclaredTypes(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      access(all) contract C {
+
+          access(all) event E(id: UInt64)
+
+          access(all) resource R {
+
+              access(all) event ResourceDestroyed()
+          }
+
+          access(all) resource interface RI {}
+
+          access(all) struct S {}
+
+          access(all) enum Kind: UInt8 {}
+
+          access(all) entitlement X
+
+          access(all) entitlement mapping M {}
+
+          access(all) attachment A for S {}
+
+          access(all) fun f() {}
+      }
+    `)
+	require.NoError(t, err)
+
+	type declaredType struct {
+		ID   string
+		Kind common.DeclarationKind
+	}
+
+	var declaredTypes []declaredType
+	for _, ty := range checker.Elaboration.DeclaredTypes() {
+		declaredTypes = append(
+			declaredTypes,
+			declaredType{
+				ID:   string(ty.ID()),
+				Kind: ty.Kind,
+			},
+		)
+	}
+
+	assert.Equal(t,
+		[]declaredType{
+			{ID: "S.test.C", Kind: common.DeclarationKindContract},
+			{ID: "S.test.C.X", Kind: common.DeclarationKindEntitlement},
+			{ID: "S.test.C.M", Kind: common.DeclarationKindEntitlementMapping},
+			{ID: "S.test.C.RI", Kind: common.DeclarationKindResourceInterface},
+			{ID: "S.test.C.E", Kind: common.DeclarationKindEvent},
+			{ID: "S.test.C.R", Kind: common.DeclarationKindResource},
+			{ID: "S.test.C.R.ResourceDestroyed", Kind: common.DeclarationKindEvent},
+			{ID: "S.test.C.S", Kind: common.DeclarationKindStructure},
+			{ID: "S.test.C.Kind", Kind: common.DeclarationKindEnum},
+			{ID: "S.test.C.A", Kind: common.DeclarationKindAttachment},
+		},
+		declaredTypes,
+	)
+}
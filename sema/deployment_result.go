@@ -18,4 +18,4 @@
 
 package sema
 
-//go:generate go run ./gen deployment_result.cdc deployment_result.gen.go
+//go:generate go run ./gen -values ../interpreter/value_deployment_result.gen.go deployment_result.cdc deployment_result.gen.go
@@ -0,0 +1,339 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence/ast"
+)
+
+// DeprecatedSyntax is syntax which is scheduled for removal
+type DeprecatedSyntax uint8
+
+const (
+	DeprecatedSyntaxUnknown DeprecatedSyntax = iota
+	// DeprecatedSyntaxAllowAccountLinkingPragma is the pragma `#allowAccountLinking`,
+	// which has no effect anymore
+	DeprecatedSyntaxAllowAccountLinkingPragma
+)
+
+const allowAccountLinkingPragmaName = "allowAccountLinking"
+
+// DeprecationRule describes syntax or an API which is scheduled for removal.
+//
+// A rule either matches syntax, if Syntax is set,
+// or the access of a member of a type, if TypeID and Member are set.
+type DeprecationRule struct {
+	// ID is the unique identifier of the rule
+	ID string
+	// Since is the version of Cadence in which the syntax or API got deprecated, e.g. v1.0.0
+	Since string
+	// RemovedIn is the version of Cadence in which the syntax or API is scheduled to be removed.
+	// Empty if the version is not known yet
+	RemovedIn string
+	// Description is a human-readable description of the deprecated syntax or API
+	Description string
+	// Replacement is a human-readable description of the replacement, if any
+	Replacement string
+
+	Syntax DeprecatedSyntax
+	TypeID TypeID
+	Member string
+}
+
+func (r *DeprecationRule) Message() string {
+	var builder strings.Builder
+	builder.WriteString(r.Description)
+	builder.WriteString(" is deprecated")
+	if r.Since != "" {
+		builder.WriteString(" since ")
+		builder.WriteString(r.Since)
+	}
+	if r.RemovedIn != "" {
+		builder.WriteString(" and will be removed in ")
+		builder.WriteString(r.RemovedIn)
+	}
+	if r.Replacement != "" {
+		builder.WriteString("; ")
+		builder.WriteString(r.Replacement)
+	}
+	return builder.String()
+}
+
+// DefaultDeprecationRules are the deprecation rules of the language,
+// ordered by the version in which the syntax or API got deprecated.
+//
+// NOTE: append new rules, do not modify or remove existing rules
+var DefaultDeprecationRules = []*DeprecationRule{
+	{
+		ID:          "allow-account-linking-pragma",
+		Since:       "v1.0.0",
+		Description: "the pragma `#allowAccountLinking`",
+		Replacement: "account capabilities can be issued using `account.capabilities.account.issue` without it",
+		Syntax:      DeprecatedSyntaxAllowAccountLinkingPragma,
+	},
+}
+
+// DeprecationRulesForVersion returns the given rules which apply to the given version of Cadence,
+// i.e. the rules for syntax and APIs which got deprecated in or before the given version.
+func DeprecationRulesForVersion(rules []*DeprecationRule, version string) []*DeprecationRule {
+	var result []*DeprecationRule
+	for _, rule := range rules {
+		if compareVersions(rule.Since, version) <= 0 {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+// compareVersions compares the given semantic versions, e.g. v1.0.0 or v1.0.0-preview.1,
+// and returns -1, 0, or +1, depending on whether a < b, a == b, or a > b.
+// Build metadata is ignored. An invalid version is considered less than a valid one
+func compareVersions(a, b string) int {
+	aParts, aPreRelease, aOK := parseVersion(a)
+	bParts, bPreRelease, bOK := parseVersion(b)
+
+	switch {
+	case !aOK && !bOK:
+		return 0
+	case !aOK:
+		return -1
+	case !bOK:
+		return 1
+	}
+
+	for i := range aParts {
+		if aParts[i] != bParts[i] {
+			if aParts[i] < bParts[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// A pre-release version has a lower precedence than the release version
+
+	switch {
+	case aPreRelease == bPreRelease:
+		return 0
+	case aPreRelease == "":
+		return 1
+	case bPreRelease == "":
+		return -1
+	}
+
+	return comparePreReleases(aPreRelease, bPreRelease)
+}
+
+// comparePreReleases compares the given pre-release versions identifier by identifier.
+// Numeric identifiers are compared numerically, and have a lower precedence than alphanumeric identifiers
+func comparePreReleases(a, b string) int {
+	aIdentifiers := strings.Split(a, ".")
+	bIdentifiers := strings.Split(b, ".")
+
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		aIdentifier := aIdentifiers[i]
+		bIdentifier := bIdentifiers[i]
+
+		aNumber, aErr := strconv.ParseUint(aIdentifier, 10, 64)
+		bNumber, bErr := strconv.ParseUint(bIdentifier, 10, 64)
+
+		var result int
+		switch {
+		case aErr == nil && bErr == nil:
+			switch {
+			case aNumber < bNumber:
+				result = -1
+			case aNumber > bNumber:
+				result = 1
+			}
+		case aErr == nil:
+			result = -1
+		case bErr == nil:
+			result = 1
+		default:
+			result = strings.Compare(aIdentifier, bIdentifier)
+		}
+
+		if result != 0 {
+			return result
+		}
+	}
+
+	switch {
+	case len(aIdentifiers) < len(bIdentifiers):
+		return -1
+	case len(aIdentifiers) > len(bIdentifiers):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func parseVersion(version string) (parts [3]uint64, preRelease string, ok bool) {
+	version, ok = strings.CutPrefix(version, "v")
+	if !ok {
+		return
+	}
+
+	version, _, _ = strings.Cut(version, "+")
+	version, preRelease, _ = strings.Cut(version, "-")
+
+	components := strings.Split(version, ".")
+	if len(components) != len(parts) {
+		return parts, "", false
+	}
+
+	for i, component := range components {
+		var err error
+		parts[i], err = strconv.ParseUint(component, 10, 64)
+		if err != nil {
+			return parts, "", false
+		}
+	}
+
+	return parts, preRelease, true
+}
+
+// DeprecationRuleSet is an index of deprecation rules
+type DeprecationRuleSet struct {
+	syntax  map[DeprecatedSyntax]*DeprecationRule
+	members map[TypeID]map[string]*DeprecationRule
+}
+
+func NewDeprecationRuleSet(rules []*DeprecationRule) *DeprecationRuleSet {
+	ruleSet := &DeprecationRuleSet{
+		syntax:  map[DeprecatedSyntax]*DeprecationRule{},
+		members: map[TypeID]map[string]*DeprecationRule{},
+	}
+
+	for _, rule := range rules {
+		if rule.Syntax != DeprecatedSyntaxUnknown {
+			ruleSet.syntax[rule.Syntax] = rule
+		}
+
+		if rule.TypeID != "" && rule.Member != "" {
+			members, ok := ruleSet.members[rule.TypeID]
+			if !ok {
+				members = map[string]*DeprecationRule{}
+				ruleSet.members[rule.TypeID] = members
+			}
+			members[rule.Member] = rule
+		}
+	}
+
+	return ruleSet
+}
+
+func (s *DeprecationRuleSet) syntaxRule(syntax DeprecatedSyntax) *DeprecationRule {
+	if s == nil {
+		return nil
+	}
+	return s.syntax[syntax]
+}
+
+func (s *DeprecationRuleSet) memberRule(typeID TypeID, member string) *DeprecationRule {
+	if s == nil {
+		return nil
+	}
+	return s.members[typeID][member]
+}
+
+// DeprecationWarning is reported for the use of deprecated syntax or a deprecated API.
+// It is not an error, the program is still valid
+type DeprecationWarning struct {
+	Rule *DeprecationRule
+	ast.Range
+}
+
+var _ error = &DeprecationWarning{}
+
+func (w *DeprecationWarning) Error() string {
+	return w.Rule.Message()
+}
+
+func (checker *Checker) Warnings() []error {
+	return checker.warnings
+}
+
+func (checker *Checker) reportWarning(warning error) {
+	switch checker.warningSeverity(warning) {
+	case SeverityOff:
+		return
+	case SeverityError:
+		checker.report(warning)
+		return
+	}
+
+	checker.warnings = append(checker.warnings, warning)
+}
+
+func (checker *Checker) checkDeprecatedSyntax(syntax DeprecatedSyntax, hasPosition ast.HasPosition) {
+	rule := checker.Config.DeprecationRules.syntaxRule(syntax)
+	if rule == nil {
+		return
+	}
+
+	checker.reportWarning(
+		&DeprecationWarning{
+			Rule:  rule,
+			Range: ast.NewRangeFromPositioned(checker.memoryGauge, hasPosition),
+		},
+	)
+}
+
+func (checker *Checker) checkDeprecatedMember(accessedType Type, member ast.Identifier) {
+	ruleSet := checker.Config.DeprecationRules
+	if ruleSet == nil {
+		return
+	}
+
+	// Members are accessed through optionals and references,
+	// so find the rules for the underlying type
+
+	for {
+		switch ty := accessedType.(type) {
+		case *OptionalType:
+			accessedType = ty.Type
+			continue
+		case *ReferenceType:
+			accessedType = ty.Type
+			continue
+		}
+		break
+	}
+
+	rule := ruleSet.memberRule(accessedType.ID(), member.Identifier)
+	if rule == nil {
+		return
+	}
+
+	checker.reportWarning(
+		&DeprecationWarning{
+			Rule: rule,
+			Range: ast.NewRange(
+				checker.memoryGauge,
+				member.StartPosition(),
+				member.EndPosition(checker.memoryGauge),
+			),
+		},
+	)
+}
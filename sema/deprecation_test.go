@@ -0,0 +1,165 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func TestCheckDeprecationWarnings(t *testing.T) {
+
+	t.Parallel()
+
+	newConfig := func() *sema.Config {
+		return &sema.Config{
+			DeprecationRules: sema.NewDeprecationRuleSet(sema.DefaultDeprecationRules),
+		}
+	}
+
+	t.Run("allowAccountLinking pragma", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheckWithOptions(t,
+			`
+              #allowAccountLinking
+            `,
+			ParseAndCheckOptions{
+				Config: newConfig(),
+			},
+		)
+
+		// The pragma is deprecated, but still accepted
+		require.NoError(t, err)
+
+		warnings := checker.Warnings()
+		require.Len(t, warnings, 1)
+
+		var warning *sema.DeprecationWarning
+		require.ErrorAs(t, warnings[0], &warning)
+		assert.Equal(t, "allow-account-linking-pragma", warning.Rule.ID)
+		assert.Equal(t, 2, warning.StartPos.Line)
+		assert.Equal(t,
+			"the pragma `#allowAccountLinking` is deprecated since v1.0.0; "+
+				"account capabilities can be issued using `account.capabilities.account.issue` without it",
+			warning.Error(),
+		)
+	})
+
+	t.Run("other pragma", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheckWithOptions(t,
+			`
+              #someOtherPragma
+            `,
+			ParseAndCheckOptions{
+				Config: newConfig(),
+			},
+		)
+		require.NoError(t, err)
+		assert.Empty(t, checker.Warnings())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t,
+			`
+              #allowAccountLinking
+            `,
+		)
+		require.NoError(t, err)
+		assert.Empty(t, checker.Warnings())
+	})
+
+	t.Run("member", func(t *testing.T) {
+		t.Parallel()
+
+		rule := &sema.DeprecationRule{
+			ID:          "account-storage-save",
+			Since:       "v1.0.0",
+			Description: "the function `save`",
+			TypeID:      sema.Account_StorageType.ID(),
+			Member:      "save",
+		}
+
+		checker, err := ParseAndCheckWithOptions(t,
+			`
+              fun test(account: auth(Storage) &Account) {
+                  account.storage.save(1, to: /storage/one)
+                  account.storage.load<Int>(from: /storage/one)
+              }
+            `,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					DeprecationRules: sema.NewDeprecationRuleSet([]*sema.DeprecationRule{rule}),
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		// The member is accessed through a reference
+
+		warnings := checker.Warnings()
+		require.Len(t, warnings, 1)
+
+		var warning *sema.DeprecationWarning
+		require.ErrorAs(t, warnings[0], &warning)
+		assert.Same(t, rule, warning.Rule)
+		assert.Equal(t, 3, warning.StartPos.Line)
+	})
+}
+
+func TestDeprecationRulesForVersion(t *testing.T) {
+
+	t.Parallel()
+
+	rules := []*sema.DeprecationRule{
+		{ID: "a", Since: "v1.0.0"},
+		{ID: "b", Since: "v1.2.0"},
+	}
+
+	assert.Empty(t, sema.DeprecationRulesForVersion(rules, "v0.42.0"))
+	assert.Equal(t, rules[:1], sema.DeprecationRulesForVersion(rules, "v1.1.0"))
+	assert.Equal(t, rules, sema.DeprecationRulesForVersion(rules, "v1.2.0"))
+	assert.Equal(t, rules, sema.DeprecationRulesForVersion(rules, "v1.10.0"))
+
+	// Pre-release versions precede the release version
+
+	assert.Empty(t, sema.DeprecationRulesForVersion(rules, "v1.0.0-preview.1"))
+	assert.Equal(t, rules[:1], sema.DeprecationRulesForVersion(rules, "v1.2.0-preview.10"))
+
+	preReleaseRules := []*sema.DeprecationRule{
+		{ID: "c", Since: "v1.0.0-preview.9"},
+		{ID: "d", Since: "v1.0.0-preview.10"},
+	}
+	assert.Equal(t, preReleaseRules[:1], sema.DeprecationRulesForVersion(preReleaseRules, "v1.0.0-preview.9"))
+	assert.Equal(t, preReleaseRules, sema.DeprecationRulesForVersion(preReleaseRules, "v1.0.0-preview.10"))
+	assert.Equal(t, preReleaseRules, sema.DeprecationRulesForVersion(preReleaseRules, "v1.0.0"))
+
+	// Invalid versions precede all valid versions
+
+	assert.Empty(t, sema.DeprecationRulesForVersion(rules, "1.2.0"))
+}
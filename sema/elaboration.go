@@ -19,6 +19,7 @@
 package sema
 
 import (
+	"maps"
 	"sync"
 
 	"github.com/onflow/cadence/ast"
@@ -170,6 +171,7 @@ type Elaboration struct {
 	staticCastTypes                     map[*ast.CastingExpression]CastTypes
 	expressionTypes                     map[ast.Expression]ExpressionTypes
 	TransactionTypes                    []*TransactionType
+	featurePragmas                      []FeaturePragmaUse
 	semanticAccesses                    map[ast.Access]Access
 	isChecking                          bool
 	// IsRecovered is true if the program was recovered (see runtime.Interface.RecoverProgram)
@@ -196,6 +198,132 @@ func (e *Elaboration) setIsChecking(isChecking bool) {
 	e.isChecking = isChecking
 }
 
+// fork returns a copy of the elaboration, which can be written to independently,
+// e.g. when checking declarations concurrently.
+// Fields which are only written while declaring the program's types and values,
+// or while checking declarations sequentially, e.g. transactions and pragmas, are shared.
+// The results can be joined back into this elaboration using join.
+//
+// NOTE: handle new fields in fork and join, see TestElaborationForkJoin
+func (e *Elaboration) fork() *Elaboration {
+	return &Elaboration{
+		lock: new(sync.RWMutex),
+
+		interfaceTypesAndDeclarationsBiMap:      e.interfaceTypesAndDeclarationsBiMap,
+		entitlementTypesAndDeclarationsBiMap:    e.entitlementTypesAndDeclarationsBiMap,
+		entitlementMapTypesAndDeclarationsBiMap: e.entitlementMapTypesAndDeclarationsBiMap,
+		globalValues:                            e.globalValues,
+		globalTypes:                             e.globalTypes,
+		TransactionTypes:                        e.TransactionTypes,
+		featurePragmas:                          e.featurePragmas,
+		isChecking:                              e.isChecking,
+		IsRecovered:                             e.IsRecovered,
+
+		fixedPointExpressionTypes:           maps.Clone(e.fixedPointExpressionTypes),
+		swapStatementTypes:                  maps.Clone(e.swapStatementTypes),
+		forStatementTypes:                   maps.Clone(e.forStatementTypes),
+		assignmentStatementTypes:            maps.Clone(e.assignmentStatementTypes),
+		compositeDeclarationTypes:           maps.Clone(e.compositeDeclarationTypes),
+		compositeTypeDeclarations:           maps.Clone(e.compositeTypeDeclarations),
+		transactionDeclarationTypes:         maps.Clone(e.transactionDeclarationTypes),
+		constructorFunctionTypes:            maps.Clone(e.constructorFunctionTypes),
+		functionExpressionFunctionTypes:     maps.Clone(e.functionExpressionFunctionTypes),
+		invocationExpressionTypes:           maps.Clone(e.invocationExpressionTypes),
+		castingExpressionTypes:              maps.Clone(e.castingExpressionTypes),
+		binaryExpressionTypes:               maps.Clone(e.binaryExpressionTypes),
+		memberExpressionMemberAccessInfos:   maps.Clone(e.memberExpressionMemberAccessInfos),
+		memberExpressionExpectedTypes:       maps.Clone(e.memberExpressionExpectedTypes),
+		arrayExpressionTypes:                maps.Clone(e.arrayExpressionTypes),
+		dictionaryExpressionTypes:           maps.Clone(e.dictionaryExpressionTypes),
+		integerExpressionTypes:              maps.Clone(e.integerExpressionTypes),
+		stringExpressionTypes:               maps.Clone(e.stringExpressionTypes),
+		returnStatementTypes:                maps.Clone(e.returnStatementTypes),
+		functionDeclarationFunctionTypes:    maps.Clone(e.functionDeclarationFunctionTypes),
+		variableDeclarationTypes:            maps.Clone(e.variableDeclarationTypes),
+		nestedResourceMoveExpressions:       maps.Clone(e.nestedResourceMoveExpressions),
+		compositeNestedDeclarations:         maps.Clone(e.compositeNestedDeclarations),
+		interfaceNestedDeclarations:         maps.Clone(e.interfaceNestedDeclarations),
+		defaultDestroyDeclarations:          maps.Clone(e.defaultDestroyDeclarations),
+		postConditionsRewrites:              maps.Clone(e.postConditionsRewrites),
+		emitStatementEventTypes:             maps.Clone(e.emitStatementEventTypes),
+		compositeTypes:                      maps.Clone(e.compositeTypes),
+		interfaceTypes:                      maps.Clone(e.interfaceTypes),
+		entitlementTypes:                    maps.Clone(e.entitlementTypes),
+		entitlementMapTypes:                 maps.Clone(e.entitlementMapTypes),
+		identifierInInvocationTypes:         maps.Clone(e.identifierInInvocationTypes),
+		importDeclarationsResolvedLocations: maps.Clone(e.importDeclarationsResolvedLocations),
+		numberConversionArgumentTypes:       maps.Clone(e.numberConversionArgumentTypes),
+		runtimeCastTypes:                    maps.Clone(e.runtimeCastTypes),
+		referenceExpressionBorrowTypes:      maps.Clone(e.referenceExpressionBorrowTypes),
+		indexExpressionTypes:                maps.Clone(e.indexExpressionTypes),
+		attachmentAccessTypes:               maps.Clone(e.attachmentAccessTypes),
+		attachmentRemoveTypes:               maps.Clone(e.attachmentRemoveTypes),
+		attachTypes:                         maps.Clone(e.attachTypes),
+		forceExpressionTypes:                maps.Clone(e.forceExpressionTypes),
+		staticCastTypes:                     maps.Clone(e.staticCastTypes),
+		expressionTypes:                     maps.Clone(e.expressionTypes),
+		semanticAccesses:                    maps.Clone(e.semanticAccesses),
+	}
+}
+
+// join adds the results of the given forked elaboration (see fork) to this elaboration
+func (e *Elaboration) join(other *Elaboration) {
+	joinMap(&e.fixedPointExpressionTypes, other.fixedPointExpressionTypes)
+	joinMap(&e.swapStatementTypes, other.swapStatementTypes)
+	joinMap(&e.forStatementTypes, other.forStatementTypes)
+	joinMap(&e.assignmentStatementTypes, other.assignmentStatementTypes)
+	joinMap(&e.compositeDeclarationTypes, other.compositeDeclarationTypes)
+	joinMap(&e.compositeTypeDeclarations, other.compositeTypeDeclarations)
+	joinMap(&e.transactionDeclarationTypes, other.transactionDeclarationTypes)
+	joinMap(&e.constructorFunctionTypes, other.constructorFunctionTypes)
+	joinMap(&e.functionExpressionFunctionTypes, other.functionExpressionFunctionTypes)
+	joinMap(&e.invocationExpressionTypes, other.invocationExpressionTypes)
+	joinMap(&e.castingExpressionTypes, other.castingExpressionTypes)
+	joinMap(&e.binaryExpressionTypes, other.binaryExpressionTypes)
+	joinMap(&e.memberExpressionMemberAccessInfos, other.memberExpressionMemberAccessInfos)
+	joinMap(&e.memberExpressionExpectedTypes, other.memberExpressionExpectedTypes)
+	joinMap(&e.arrayExpressionTypes, other.arrayExpressionTypes)
+	joinMap(&e.dictionaryExpressionTypes, other.dictionaryExpressionTypes)
+	joinMap(&e.integerExpressionTypes, other.integerExpressionTypes)
+	joinMap(&e.stringExpressionTypes, other.stringExpressionTypes)
+	joinMap(&e.returnStatementTypes, other.returnStatementTypes)
+	joinMap(&e.functionDeclarationFunctionTypes, other.functionDeclarationFunctionTypes)
+	joinMap(&e.variableDeclarationTypes, other.variableDeclarationTypes)
+	joinMap(&e.nestedResourceMoveExpressions, other.nestedResourceMoveExpressions)
+	joinMap(&e.compositeNestedDeclarations, other.compositeNestedDeclarations)
+	joinMap(&e.interfaceNestedDeclarations, other.interfaceNestedDeclarations)
+	joinMap(&e.defaultDestroyDeclarations, other.defaultDestroyDeclarations)
+	joinMap(&e.postConditionsRewrites, other.postConditionsRewrites)
+	joinMap(&e.emitStatementEventTypes, other.emitStatementEventTypes)
+	joinMap(&e.compositeTypes, other.compositeTypes)
+	joinMap(&e.interfaceTypes, other.interfaceTypes)
+	joinMap(&e.entitlementTypes, other.entitlementTypes)
+	joinMap(&e.entitlementMapTypes, other.entitlementMapTypes)
+	joinMap(&e.identifierInInvocationTypes, other.identifierInInvocationTypes)
+	joinMap(&e.importDeclarationsResolvedLocations, other.importDeclarationsResolvedLocations)
+	joinMap(&e.numberConversionArgumentTypes, other.numberConversionArgumentTypes)
+	joinMap(&e.runtimeCastTypes, other.runtimeCastTypes)
+	joinMap(&e.referenceExpressionBorrowTypes, other.referenceExpressionBorrowTypes)
+	joinMap(&e.indexExpressionTypes, other.indexExpressionTypes)
+	joinMap(&e.attachmentAccessTypes, other.attachmentAccessTypes)
+	joinMap(&e.attachmentRemoveTypes, other.attachmentRemoveTypes)
+	joinMap(&e.attachTypes, other.attachTypes)
+	joinMap(&e.forceExpressionTypes, other.forceExpressionTypes)
+	joinMap(&e.staticCastTypes, other.staticCastTypes)
+	joinMap(&e.expressionTypes, other.expressionTypes)
+	joinMap(&e.semanticAccesses, other.semanticAccesses)
+}
+
+func joinMap[K comparable, V any](target *map[K]V, source map[K]V) {
+	if len(source) == 0 {
+		return
+	}
+	if *target == nil {
+		*target = make(map[K]V, len(source))
+	}
+	maps.Copy(*target, source)
+}
+
 // FunctionEntryPointType returns the type of the entry point function declaration, if any.
 //
 // Returns an error if no valid entry point function declaration exists.
@@ -797,6 +925,14 @@ func (e *Elaboration) SetCompositeType(typeID TypeID, ty *CompositeType) {
 	e.compositeTypes[typeID] = ty
 }
 
+// ForEachCompositeType calls the given function for each composite type
+// declared in the program, in no particular order
+func (e *Elaboration) ForEachCompositeType(f func(ty *CompositeType)) {
+	for _, ty := range e.compositeTypes { //nolint:maprange
+		f(ty)
+	}
+}
+
 func (e *Elaboration) EntitlementType(typeID common.TypeID) *EntitlementType {
 	if e.entitlementTypes == nil {
 		return nil
@@ -839,6 +975,14 @@ func (e *Elaboration) SetInterfaceType(typeID TypeID, ty *InterfaceType) {
 	e.interfaceTypes[typeID] = ty
 }
 
+// ForEachInterfaceType calls the given function for each interface type
+// declared in the program, in no particular order
+func (e *Elaboration) ForEachInterfaceType(f func(ty *InterfaceType)) {
+	for _, ty := range e.interfaceTypes { //nolint:maprange
+		f(ty)
+	}
+}
+
 func (e *Elaboration) IdentifierInInvocationType(expression *ast.IdentifierExpression) Type {
 	if e.identifierInInvocationTypes == nil {
 		return nil
@@ -1074,3 +1218,23 @@ func (e *Elaboration) ForStatementType(statement *ast.ForStatement) (types ForSt
 	}
 	return e.forStatementTypes[statement]
 }
+
+// FeaturePragmas returns the uses of feature pragmas in the program, in declaration order,
+// see Config.FeaturePragmas
+func (e *Elaboration) FeaturePragmas() []FeaturePragmaUse {
+	return e.featurePragmas
+}
+
+// FeaturePragma returns the first use of the feature pragma with the given name in the program, if any
+func (e *Elaboration) FeaturePragma(name string) (use FeaturePragmaUse, ok bool) {
+	for _, use := range e.featurePragmas {
+		if use.Pragma.Name == name {
+			return use, true
+		}
+	}
+	return
+}
+
+func (e *Elaboration) addFeaturePragma(use FeaturePragmaUse) {
+	e.featurePragmas = append(e.featurePragmas, use)
+}
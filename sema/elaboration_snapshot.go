@@ -0,0 +1,333 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/errors"
+)
+
+// ElaborationSnapshotVersion is the version of the encoding of elaboration snapshots.
+// It must be incremented when the encoding changes, so outdated snapshots are invalidated
+const ElaborationSnapshotVersion = 1
+
+// ElaborationSnapshot is a serializable snapshot of the results of checking a program,
+// i.e. of parts of its elaboration:
+// the types of expressions, the members accessed in member expressions, and casts.
+//
+// Snapshots allow tools, e.g. analyzers, the language server, or the compiler,
+// to cache checking results between runs, and skip re-checking programs which did not change.
+//
+// Types are represented by their IDs and qualified strings, not by their full definitions,
+// and AST nodes are identified by their element type and range.
+// To find the results for a node of a re-parsed program, use the lookup functions,
+// e.g. ElaborationSnapshot.ExpressionTypes.
+//
+// The types of expressions are only recorded if the program was checked with extended elaboration,
+// see Config.ExtendedElaborationEnabled.
+//
+// A snapshot is only valid for the exact sources it was created for, see DecodeElaborationSnapshot
+type ElaborationSnapshot struct {
+	Version uint
+	// SourceHash is the hash of the sources of the program, see ElaborationSourceHash
+	SourceHash     string
+	Expressions    []ExpressionTypesSnapshot
+	MemberAccesses []MemberAccessSnapshot
+	Casts          []CastSnapshot
+
+	expressionTypesIndex map[ElaborationSnapshotNode]int
+	memberAccessesIndex  map[ElaborationSnapshotNode]int
+	castsIndex           map[ElaborationSnapshotNode]int
+}
+
+// ElaborationSnapshotNode identifies an AST node in an elaboration snapshot
+type ElaborationSnapshotNode struct {
+	ElementType string
+	StartOffset int
+	EndOffset   int
+}
+
+func newElaborationSnapshotNode(element ast.Element) ElaborationSnapshotNode {
+	return ElaborationSnapshotNode{
+		ElementType: element.ElementType().String(),
+		StartOffset: element.StartPosition().Offset,
+		EndOffset:   element.EndPosition(nil).Offset,
+	}
+}
+
+func compareElaborationSnapshotNodes(a, b ElaborationSnapshotNode) int {
+	return cmp.Or(
+		cmp.Compare(a.StartOffset, b.StartOffset),
+		cmp.Compare(a.EndOffset, b.EndOffset),
+		cmp.Compare(a.ElementType, b.ElementType),
+	)
+}
+
+// TypeSnapshot is a type in an elaboration snapshot
+type TypeSnapshot struct {
+	ID              TypeID
+	QualifiedString string
+}
+
+func newTypeSnapshot(ty Type) *TypeSnapshot {
+	if ty == nil {
+		return nil
+	}
+	return &TypeSnapshot{
+		ID:              ty.ID(),
+		QualifiedString: ty.QualifiedString(),
+	}
+}
+
+// ExpressionTypesSnapshot are the types of an expression, see ExpressionTypes
+type ExpressionTypesSnapshot struct {
+	Node         ElaborationSnapshotNode
+	ActualType   *TypeSnapshot
+	ExpectedType *TypeSnapshot `json:",omitempty"`
+}
+
+// MemberAccessSnapshot is the member accessed in a member expression, see MemberAccessInfo
+type MemberAccessSnapshot struct {
+	Node            ElaborationSnapshotNode
+	AccessedType    *TypeSnapshot
+	ResultingType   *TypeSnapshot
+	Member          *MemberSnapshot `json:",omitempty"`
+	IsOptional      bool
+	ReturnReference bool
+}
+
+// MemberSnapshot is a member in an elaboration snapshot
+type MemberSnapshot struct {
+	Identifier      string
+	DeclarationKind string
+	Access          string
+	ContainerType   *TypeSnapshot `json:",omitempty"`
+	Type            *TypeSnapshot
+}
+
+func newMemberSnapshot(member *Member) *MemberSnapshot {
+	if member == nil {
+		return nil
+	}
+
+	var access string
+	if member.Access != nil {
+		access = member.Access.QualifiedKeyword()
+	}
+
+	return &MemberSnapshot{
+		Identifier:      member.Identifier.Identifier,
+		DeclarationKind: member.DeclarationKind.Name(),
+		Access:          access,
+		ContainerType:   newTypeSnapshot(member.ContainerType),
+		Type:            newTypeSnapshot(member.TypeAnnotation.Type),
+	}
+}
+
+// CastSnapshot is a cast in a casting expression, see CastingExpressionTypes
+type CastSnapshot struct {
+	Node ElaborationSnapshotNode
+	// Operation is the symbol of the cast operation, i.e. `as`, `as?`, or `as!`
+	Operation       string
+	StaticValueType *TypeSnapshot
+	TargetType      *TypeSnapshot
+}
+
+// ElaborationSourceHash returns the hash of the given sources of a program,
+// i.e. the code of the program, followed by the code of all programs it imports, if any.
+//
+// Including the imported programs invalidates a snapshot when an imported program changes
+func ElaborationSourceHash(sources ...[]byte) string {
+	hash := sha256.New()
+
+	var length [8]byte
+	for _, source := range sources {
+		binary.BigEndian.PutUint64(length[:], uint64(len(source)))
+		_, _ = hash.Write(length[:])
+		_, _ = hash.Write(source)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Snapshot returns a snapshot of the elaboration,
+// for the program with the given sources, see ElaborationSourceHash
+func (e *Elaboration) Snapshot(sources ...[]byte) *ElaborationSnapshot {
+	snapshot := &ElaborationSnapshot{
+		Version:    ElaborationSnapshotVersion,
+		SourceHash: ElaborationSourceHash(sources...),
+	}
+
+	for expression, types := range e.expressionTypes { //nolint:maprange
+		snapshot.Expressions = append(
+			snapshot.Expressions,
+			ExpressionTypesSnapshot{
+				Node:         newElaborationSnapshotNode(expression),
+				ActualType:   newTypeSnapshot(types.ActualType),
+				ExpectedType: newTypeSnapshot(types.ExpectedType),
+			},
+		)
+	}
+
+	for expression, info := range e.memberExpressionMemberAccessInfos { //nolint:maprange
+		snapshot.MemberAccesses = append(
+			snapshot.MemberAccesses,
+			MemberAccessSnapshot{
+				Node:            newElaborationSnapshotNode(expression),
+				AccessedType:    newTypeSnapshot(info.AccessedType),
+				ResultingType:   newTypeSnapshot(info.ResultingType),
+				Member:          newMemberSnapshot(info.Member),
+				IsOptional:      info.IsOptional,
+				ReturnReference: info.ReturnReference,
+			},
+		)
+	}
+
+	for expression, types := range e.castingExpressionTypes { //nolint:maprange
+		snapshot.Casts = append(
+			snapshot.Casts,
+			CastSnapshot{
+				Node:            newElaborationSnapshotNode(expression),
+				Operation:       expression.Operation.Symbol(),
+				StaticValueType: newTypeSnapshot(types.StaticValueType),
+				TargetType:      newTypeSnapshot(types.TargetType),
+			},
+		)
+	}
+
+	// Sort the entries, so the encoding is deterministic
+
+	slices.SortFunc(snapshot.Expressions, func(a, b ExpressionTypesSnapshot) int {
+		return compareElaborationSnapshotNodes(a.Node, b.Node)
+	})
+	slices.SortFunc(snapshot.MemberAccesses, func(a, b MemberAccessSnapshot) int {
+		return compareElaborationSnapshotNodes(a.Node, b.Node)
+	})
+	slices.SortFunc(snapshot.Casts, func(a, b CastSnapshot) int {
+		return compareElaborationSnapshotNodes(a.Node, b.Node)
+	})
+
+	snapshot.buildIndices()
+
+	return snapshot
+}
+
+func (s *ElaborationSnapshot) buildIndices() {
+	s.expressionTypesIndex = make(map[ElaborationSnapshotNode]int, len(s.Expressions))
+	for i, entry := range s.Expressions {
+		s.expressionTypesIndex[entry.Node] = i
+	}
+
+	s.memberAccessesIndex = make(map[ElaborationSnapshotNode]int, len(s.MemberAccesses))
+	for i, entry := range s.MemberAccesses {
+		s.memberAccessesIndex[entry.Node] = i
+	}
+
+	s.castsIndex = make(map[ElaborationSnapshotNode]int, len(s.Casts))
+	for i, entry := range s.Casts {
+		s.castsIndex[entry.Node] = i
+	}
+}
+
+// ExpressionTypes returns the types of the given expression, if any
+func (s *ElaborationSnapshot) ExpressionTypes(expression ast.Expression) (ExpressionTypesSnapshot, bool) {
+	index, ok := s.expressionTypesIndex[newElaborationSnapshotNode(expression)]
+	if !ok {
+		return ExpressionTypesSnapshot{}, false
+	}
+	return s.Expressions[index], true
+}
+
+// MemberAccess returns the member accessed in the given member expression, if any
+func (s *ElaborationSnapshot) MemberAccess(expression *ast.MemberExpression) (MemberAccessSnapshot, bool) {
+	index, ok := s.memberAccessesIndex[newElaborationSnapshotNode(expression)]
+	if !ok {
+		return MemberAccessSnapshot{}, false
+	}
+	return s.MemberAccesses[index], true
+}
+
+// Cast returns the cast of the given casting expression, if any
+func (s *ElaborationSnapshot) Cast(expression *ast.CastingExpression) (CastSnapshot, bool) {
+	index, ok := s.castsIndex[newElaborationSnapshotNode(expression)]
+	if !ok {
+		return CastSnapshot{}, false
+	}
+	return s.Casts[index], true
+}
+
+// Encode encodes the snapshot
+func (s *ElaborationSnapshot) Encode() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// InvalidElaborationSnapshotError is returned when a snapshot cannot be used,
+// because it was encoded with a different version,
+// or because it was created for different sources.
+// The program must be re-checked
+type InvalidElaborationSnapshotError struct {
+	Message string
+}
+
+var _ errors.UserError = InvalidElaborationSnapshotError{}
+
+func (InvalidElaborationSnapshotError) IsUserError() {}
+
+func (e InvalidElaborationSnapshotError) Error() string {
+	return fmt.Sprintf("invalid elaboration snapshot: %s", e.Message)
+}
+
+// DecodeElaborationSnapshot decodes a snapshot encoded with ElaborationSnapshot.Encode,
+// and validates that it was created for the given sources of the program, see ElaborationSourceHash.
+// An InvalidElaborationSnapshotError is returned if the snapshot is outdated
+func DecodeElaborationSnapshot(data []byte, sources ...[]byte) (*ElaborationSnapshot, error) {
+	var snapshot ElaborationSnapshot
+	err := json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot.Version != ElaborationSnapshotVersion {
+		return nil, InvalidElaborationSnapshotError{
+			Message: fmt.Sprintf(
+				"expected version %d, got %d",
+				ElaborationSnapshotVersion,
+				snapshot.Version,
+			),
+		}
+	}
+
+	if snapshot.SourceHash != ElaborationSourceHash(sources...) {
+		return nil, InvalidElaborationSnapshotError{
+			Message: "sources changed",
+		}
+	}
+
+	snapshot.buildIndices()
+
+	return &snapshot, nil
+}
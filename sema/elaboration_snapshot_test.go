@@ -0,0 +1,175 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func TestElaborationSnapshot(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      struct S {
+          access(all) let x: Int
+
+          init() {
+              self.x = 1
+          }
+      }
+
+      fun test(): Int? {
+          let s: AnyStruct = S()
+          let y = (s as! S).x
+          return y as? Int
+      }
+    `
+
+	checker, err := ParseAndCheck(t, code)
+	require.NoError(t, err)
+
+	data, err := checker.Elaboration.Snapshot([]byte(code)).Encode()
+	require.NoError(t, err)
+
+	snapshot, err := sema.DecodeElaborationSnapshot(data, []byte(code))
+	require.NoError(t, err)
+
+	// Look up the results for the nodes of a re-parsed program
+
+	program, err := parser.ParseProgram(nil, []byte(code), parser.Config{})
+	require.NoError(t, err)
+
+	var memberExpressions []*ast.MemberExpression
+	var castingExpressions []*ast.CastingExpression
+
+	ast.Inspect(program, func(element ast.Element) bool {
+		switch element := element.(type) {
+		case *ast.MemberExpression:
+			memberExpressions = append(memberExpressions, element)
+		case *ast.CastingExpression:
+			castingExpressions = append(castingExpressions, element)
+		}
+		return true
+	})
+
+	require.Len(t, memberExpressions, 2)
+	require.Len(t, castingExpressions, 2)
+
+	// (s as! S).x
+
+	memberAccess, ok := snapshot.MemberAccess(memberExpressions[1])
+	require.True(t, ok)
+	assert.Equal(t, sema.TypeID("S.test.S"), memberAccess.AccessedType.ID)
+	assert.Equal(t, "Int", memberAccess.ResultingType.QualifiedString)
+	require.NotNil(t, memberAccess.Member)
+	assert.Equal(t, "x", memberAccess.Member.Identifier)
+	assert.Equal(t, "field", memberAccess.Member.DeclarationKind)
+	assert.Equal(t, "access(all)", memberAccess.Member.Access)
+
+	expressionTypes, ok := snapshot.ExpressionTypes(memberExpressions[1])
+	require.True(t, ok)
+	assert.Equal(t, "Int", expressionTypes.ActualType.QualifiedString)
+
+	// s as! S
+
+	cast, ok := snapshot.Cast(castingExpressions[0])
+	require.True(t, ok)
+	assert.Equal(t, "as!", cast.Operation)
+	assert.Equal(t, "AnyStruct", cast.StaticValueType.QualifiedString)
+	assert.Equal(t, "S", cast.TargetType.QualifiedString)
+
+	// y as? Int
+
+	cast, ok = snapshot.Cast(castingExpressions[1])
+	require.True(t, ok)
+	assert.Equal(t, "as?", cast.Operation)
+	assert.Equal(t, "Int", cast.StaticValueType.QualifiedString)
+
+	// Encoding is deterministic
+
+	data2, err := checker.Elaboration.Snapshot([]byte(code)).Encode()
+	require.NoError(t, err)
+	assert.Equal(t, data, data2)
+}
+
+func TestElaborationSnapshotInvalidation(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `let x = 1`
+	const importedCode = `let y = 2`
+
+	checker, err := ParseAndCheck(t, code)
+	require.NoError(t, err)
+
+	data, err := checker.Elaboration.
+		Snapshot([]byte(code), []byte(importedCode)).
+		Encode()
+	require.NoError(t, err)
+
+	t.Run("same sources", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sema.DecodeElaborationSnapshot(data, []byte(code), []byte(importedCode))
+		require.NoError(t, err)
+	})
+
+	t.Run("changed source", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sema.DecodeElaborationSnapshot(data, []byte(`let x = 2`), []byte(importedCode))
+		require.ErrorAs(t, err, &sema.InvalidElaborationSnapshotError{})
+	})
+
+	t.Run("changed import", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sema.DecodeElaborationSnapshot(data, []byte(code), []byte(`let y = 3`))
+		require.ErrorAs(t, err, &sema.InvalidElaborationSnapshotError{})
+	})
+
+	t.Run("concatenated sources", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sema.DecodeElaborationSnapshot(data, []byte(code+importedCode))
+		require.ErrorAs(t, err, &sema.InvalidElaborationSnapshotError{})
+	})
+
+	t.Run("different version", func(t *testing.T) {
+		t.Parallel()
+
+		snapshot := checker.Elaboration.Snapshot([]byte(code))
+		snapshot.Version = sema.ElaborationSnapshotVersion + 1
+
+		data, err := snapshot.Encode()
+		require.NoError(t, err)
+
+		_, err = sema.DecodeElaborationSnapshot(data, []byte(code))
+		require.ErrorAs(t, err, &sema.InvalidElaborationSnapshotError{})
+	})
+}
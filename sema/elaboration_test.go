@@ -0,0 +1,140 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElaborationForkJoin(t *testing.T) {
+
+	t.Parallel()
+
+	// sharedFields are the fields of the elaboration which are shared by forks,
+	// as they are only written while declaring the program, or while checking declarations sequentially.
+	// All other fields must be copied by fork and joined by join
+	sharedFields := map[string]struct{}{
+		"lock":                                    {},
+		"interfaceTypesAndDeclarationsBiMap":      {},
+		"entitlementTypesAndDeclarationsBiMap":    {},
+		"entitlementMapTypesAndDeclarationsBiMap": {},
+		"globalValues":                            {},
+		"globalTypes":                             {},
+		"TransactionTypes":                        {},
+		"featurePragmas":                          {},
+		"isChecking":                              {},
+		"IsRecovered":                             {},
+	}
+
+	elaborationType := reflect.TypeOf(Elaboration{})
+
+	// setFields sets all fields of the given elaboration to a non-zero value,
+	// except for the lock
+	setFields := func(elaboration *Elaboration) {
+		value := reflect.ValueOf(elaboration).Elem()
+
+		for i := 0; i < elaborationType.NumField(); i++ {
+			field := elaborationType.Field(i)
+			if field.Name == "lock" {
+				continue
+			}
+
+			fieldValue := reflect.NewAt(
+				field.Type,
+				value.Field(i).Addr().UnsafePointer(),
+			).Elem()
+
+			switch field.Type.Kind() {
+			case reflect.Map:
+				newMap := reflect.MakeMap(field.Type)
+				newMap.SetMapIndex(
+					reflect.Zero(field.Type.Key()),
+					reflect.Zero(field.Type.Elem()),
+				)
+				fieldValue.Set(newMap)
+
+			case reflect.Slice:
+				fieldValue.Set(reflect.MakeSlice(field.Type, 1, 1))
+
+			case reflect.Pointer:
+				fieldValue.Set(reflect.New(field.Type.Elem()))
+
+			case reflect.Bool:
+				fieldValue.SetBool(true)
+
+			default:
+				require.Failf(t, "unsupported field type", "field %s has type %s", field.Name, field.Type)
+			}
+		}
+	}
+
+	isZero := func(elaboration *Elaboration, fieldName string) bool {
+		return reflect.ValueOf(elaboration).Elem().FieldByName(fieldName).IsZero()
+	}
+
+	t.Run("fork", func(t *testing.T) {
+
+		t.Parallel()
+
+		elaboration := NewElaboration(nil)
+		setFields(elaboration)
+
+		forked := elaboration.fork()
+
+		for i := 0; i < elaborationType.NumField(); i++ {
+			fieldName := elaborationType.Field(i).Name
+
+			assert.False(t,
+				isZero(forked, fieldName),
+				"field %s is not handled by fork",
+				fieldName,
+			)
+		}
+	})
+
+	t.Run("join", func(t *testing.T) {
+
+		t.Parallel()
+
+		elaboration := NewElaboration(nil)
+
+		forked := elaboration.fork()
+		setFields(forked)
+
+		elaboration.join(forked)
+
+		for i := 0; i < elaborationType.NumField(); i++ {
+			fieldName := elaborationType.Field(i).Name
+
+			if _, ok := sharedFields[fieldName]; ok {
+				continue
+			}
+
+			assert.False(t,
+				isZero(elaboration, fieldName),
+				"field %s is not handled by join",
+				fieldName,
+			)
+		}
+	})
+}
@@ -0,0 +1,436 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/errors"
+)
+
+const EntitlementMinimizationDiagnosticCode = "over-broad-authorization"
+
+// EntitlementMinimizationLintRule is a lint rule which reports parameters of functions
+// (including the prepare function of transactions) that have an authorized reference type,
+// where the function only requires a subset of the granted entitlements.
+//
+// The required entitlements are determined from the member accesses on the parameter,
+// including accesses through entitlement mappings, e.g. `account.storage.save(...)`.
+// Parameters which are used in any other way, e.g. passed to another function,
+// are not reported, as their required entitlements are unknown.
+var EntitlementMinimizationLintRule = &LintRule{
+	Name:             "entitlement-minimization",
+	VisitDeclaration: checkEntitlementMinimization,
+}
+
+func checkEntitlementMinimization(context LintContext, declaration ast.Declaration) {
+	elaboration := context.Checker.Elaboration
+
+	switch declaration := declaration.(type) {
+	case *ast.FunctionDeclaration:
+		functionType := elaboration.FunctionDeclarationFunctionType(declaration)
+		if functionType == nil {
+			return
+		}
+
+		checkFunctionEntitlementMinimization(context, declaration, functionType)
+
+	case *ast.TransactionDeclaration:
+		if declaration.Prepare == nil {
+			return
+		}
+
+		transactionType := elaboration.TransactionDeclarationType(declaration)
+		if transactionType == nil {
+			return
+		}
+
+		checkFunctionEntitlementMinimization(
+			context,
+			declaration.Prepare.FunctionDeclaration,
+			transactionType.PrepareFunctionType(),
+		)
+	}
+}
+
+func checkFunctionEntitlementMinimization(
+	context LintContext,
+	declaration *ast.FunctionDeclaration,
+	functionType *FunctionType,
+) {
+	functionBlock := declaration.FunctionBlock
+	if functionBlock == nil || declaration.ParameterList == nil {
+		return
+	}
+
+	parameters := declaration.ParameterList.Parameters
+	if len(parameters) != len(functionType.Parameters) {
+		return
+	}
+
+	for i, parameter := range parameters {
+
+		referenceType, ok := functionType.Parameters[i].TypeAnnotation.Type.(*ReferenceType)
+		if !ok {
+			continue
+		}
+
+		granted, ok := referenceType.Authorization.(EntitlementSetAccess)
+		if !ok ||
+			granted.SetKind != Conjunction ||
+			granted.Entitlements.Len() == 0 {
+
+			continue
+		}
+
+		astReferenceType, ok := parameter.TypeAnnotation.Type.(*ast.ReferenceType)
+		if !ok {
+			continue
+		}
+
+		astEntitlements, ok := astReferenceType.Authorization.(*ast.ConjunctiveEntitlementSet)
+		if !ok || len(astEntitlements.Elements) != granted.Entitlements.Len() {
+			continue
+		}
+
+		clauses, ok := requiredEntitlements(
+			context.Checker.Elaboration,
+			parameter.Identifier.Identifier,
+			functionBlock,
+		)
+		if !ok {
+			continue
+		}
+
+		minimal, ok := minimalEntitlements(granted.Entitlements, clauses)
+		if !ok || len(minimal) == granted.Entitlements.Len() {
+			continue
+		}
+
+		// Keep the entitlements as they were written in the program
+
+		var names []string
+		index := 0
+		granted.Entitlements.Foreach(func(entitlement *EntitlementType, _ struct{}) {
+			if _, ok := minimal[entitlement]; ok {
+				names = append(names, astEntitlements.Elements[index].String())
+			}
+			index++
+		})
+
+		var message string
+		var replacement string
+		if len(names) == 0 {
+			message = fmt.Sprintf(
+				"parameter `%s` does not require any entitlements",
+				parameter.Identifier.Identifier,
+			)
+			replacement = "&"
+		} else {
+			quotedNames := make([]string, 0, len(names))
+			for _, name := range names {
+				quotedNames = append(quotedNames, fmt.Sprintf("`%s`", name))
+			}
+			message = fmt.Sprintf(
+				"parameter `%s` only requires the entitlements %s",
+				parameter.Identifier.Identifier,
+				strings.Join(quotedNames, ", "),
+			)
+			replacement = fmt.Sprintf("auth(%s) &", strings.Join(names, ", "))
+		}
+
+		context.Report(&LintDiagnostic{
+			Code:    EntitlementMinimizationDiagnosticCode,
+			Message: message,
+			SuggestedFixes: []errors.SuggestedFix[ast.TextEdit]{
+				{
+					Message: "narrow authorization",
+					TextEdits: []ast.TextEdit{
+						{
+							// Replace the authorization and the reference symbol
+							Replacement: replacement,
+							Range: ast.NewUnmeteredRange(
+								astReferenceType.StartPos,
+								astReferenceType.Type.StartPosition().Shifted(nil, -1),
+							),
+						},
+					},
+				},
+			},
+			Range: ast.NewUnmeteredRangeFromPositioned(astReferenceType),
+		})
+	}
+}
+
+// minimalEntitlements returns a minimal subset of the granted entitlements,
+// which satisfies the given clauses, i.e. which contains at least one entitlement of each clause.
+// It returns false if a clause cannot be satisfied.
+func minimalEntitlements(
+	granted *EntitlementOrderedSet,
+	clauses [][]*EntitlementType,
+) (map[*EntitlementType]struct{}, bool) {
+
+	// Count the clauses each entitlement occurs in,
+	// to prefer entitlements which satisfy the most clauses
+
+	occurrences := map[*EntitlementType]int{}
+	for _, clause := range clauses {
+		for _, entitlement := range clause {
+			occurrences[entitlement]++
+		}
+	}
+
+	chosen := map[*EntitlementType]struct{}{}
+
+	for _, clause := range clauses {
+
+		satisfied := false
+		for _, entitlement := range clause {
+			if _, ok := chosen[entitlement]; ok {
+				satisfied = true
+				break
+			}
+		}
+		if satisfied {
+			continue
+		}
+
+		var best *EntitlementType
+		granted.Foreach(func(entitlement *EntitlementType, _ struct{}) {
+			for _, candidate := range clause {
+				if candidate != entitlement {
+					continue
+				}
+				if best == nil || occurrences[entitlement] > occurrences[best] {
+					best = entitlement
+				}
+			}
+		})
+
+		if best == nil {
+			return nil, false
+		}
+
+		chosen[best] = struct{}{}
+	}
+
+	return chosen, true
+}
+
+// requiredEntitlements returns the entitlements which are required for the given parameter in the given function block,
+// as clauses in conjunctive normal form, i.e. at least one entitlement of each clause is required.
+// It returns false if the required entitlements cannot be determined.
+func requiredEntitlements(
+	elaboration *Elaboration,
+	parameterName string,
+	functionBlock *ast.FunctionBlock,
+) ([][]*EntitlementType, bool) {
+
+	walker := &entitlementRequirementWalker{
+		elaboration:   elaboration,
+		parameterName: parameterName,
+	}
+
+	ast.Walk(walker, functionBlock)
+
+	if walker.unknown {
+		return nil, false
+	}
+
+	return walker.clauses, true
+}
+
+type entitlementRequirementWalker struct {
+	elaboration   *Elaboration
+	parameterName string
+	parents       []ast.Element
+	clauses       [][]*EntitlementType
+	unknown       bool
+}
+
+var _ ast.Walker = &entitlementRequirementWalker{}
+
+func (w *entitlementRequirementWalker) Walk(element ast.Element) ast.Walker {
+	if w.unknown {
+		return nil
+	}
+
+	if element == nil {
+		w.parents = w.parents[:len(w.parents)-1]
+		return nil
+	}
+
+	switch element := element.(type) {
+	case *ast.IdentifierExpression:
+		if element.Identifier.Identifier == w.parameterName {
+			w.useParameter(element)
+		}
+
+	case *ast.VariableDeclaration:
+		w.declare(element.Identifier)
+
+	case *ast.ForStatement:
+		w.declare(element.Identifier)
+		if element.Index != nil {
+			w.declare(*element.Index)
+		}
+
+	case *ast.FunctionDeclaration:
+		w.declareParameters(element.ParameterList)
+
+	case *ast.FunctionExpression:
+		w.declareParameters(element.ParameterList)
+	}
+
+	w.parents = append(w.parents, element)
+
+	return w
+}
+
+// declare handles the declaration of a variable in the function.
+// Declarations which shadow the parameter are not supported
+func (w *entitlementRequirementWalker) declare(identifier ast.Identifier) {
+	if identifier.Identifier == w.parameterName {
+		w.unknown = true
+	}
+}
+
+func (w *entitlementRequirementWalker) declareParameters(parameterList *ast.ParameterList) {
+	if parameterList == nil {
+		return
+	}
+	for _, parameter := range parameterList.Parameters {
+		w.declare(parameter.Identifier)
+	}
+}
+
+// useParameter determines the entitlements required by the use of the parameter.
+//
+// The parameter must be the base of a chain of member accesses, e.g. `account.storage.save`.
+// The entitlements required for each member access are propagated to the parameter
+// through the entitlement mappings of the accessed members.
+func (w *entitlementRequirementWalker) useParameter(identifierExpression *ast.IdentifierExpression) {
+
+	// The member accesses, from the innermost to the outermost
+	var chain []*ast.MemberExpression
+
+	var current ast.Expression = identifierExpression
+	for i := len(w.parents) - 1; i >= 0; i-- {
+		memberExpression, ok := w.parents[i].(*ast.MemberExpression)
+		if !ok || memberExpression.Expression != current {
+			break
+		}
+		chain = append(chain, memberExpression)
+		current = memberExpression
+	}
+
+	// The parameter itself is used, e.g. passed to a function
+	if len(chain) == 0 {
+		w.unknown = true
+		return
+	}
+
+	// The clauses, in terms of the entitlements of the current value in the chain
+	var clauses [][]*EntitlementType
+
+	// Propagate the required entitlements from the outermost to the innermost member access
+	for i := len(chain) - 1; i >= 0; i-- {
+		memberExpression := chain[i]
+
+		memberInfo, ok := w.elaboration.MemberExpressionMemberAccessInfo(memberExpression)
+		if !ok || memberInfo.Member == nil {
+			w.unknown = true
+			return
+		}
+
+		member := memberInfo.Member
+
+		switch access := member.Access.(type) {
+		case *EntitlementMapAccess:
+			// The result of the member access is used, e.g. passed to a function,
+			// and its entitlements depend on the entitlements of the parameter
+			if i == len(chain)-1 {
+				w.unknown = true
+				return
+			}
+
+			clauses, ok = preimageClauses(access.Type, clauses)
+			if !ok {
+				w.unknown = true
+				return
+			}
+
+		case EntitlementSetAccess:
+			// The entitlements of the result do not depend on the accessed value
+			clauses = nil
+
+			switch access.SetKind {
+			case Conjunction:
+				access.Entitlements.Foreach(func(entitlement *EntitlementType, _ struct{}) {
+					clauses = append(clauses, []*EntitlementType{entitlement})
+				})
+
+			case Disjunction:
+				var clause []*EntitlementType
+				access.Entitlements.Foreach(func(entitlement *EntitlementType, _ struct{}) {
+					clause = append(clause, entitlement)
+				})
+				clauses = append(clauses, clause)
+			}
+
+		default:
+			// The entitlements of the result do not depend on the accessed value
+			clauses = nil
+		}
+	}
+
+	w.clauses = append(w.clauses, clauses...)
+}
+
+// preimageClauses maps the given clauses on the output entitlements of the given entitlement mapping
+// to clauses on the input entitlements.
+// It returns false if an output entitlement is not produced by any input entitlement
+func preimageClauses(mapType *EntitlementMapType, clauses [][]*EntitlementType) ([][]*EntitlementType, bool) {
+	result := make([][]*EntitlementType, 0, len(clauses))
+
+	for _, clause := range clauses {
+		var inputs []*EntitlementType
+
+		for _, output := range clause {
+			if mapType.IncludesIdentity {
+				inputs = append(inputs, output)
+			}
+			for _, relation := range mapType.Relations {
+				if relation.Output == output {
+					inputs = append(inputs, relation.Input)
+				}
+			}
+		}
+
+		if len(inputs) == 0 {
+			return nil, false
+		}
+
+		result = append(result, inputs)
+	}
+
+	return result, true
+}
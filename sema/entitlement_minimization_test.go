@@ -0,0 +1,223 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func TestCheckEntitlementMinimization(t *testing.T) {
+
+	t.Parallel()
+
+	check := func(t *testing.T, code string) []*sema.LintDiagnostic {
+		checker, err := ParseAndCheckWithOptions(t,
+			code,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					LintRules: []*sema.LintRule{
+						sema.EntitlementMinimizationLintRule,
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		var diagnostics []*sema.LintDiagnostic
+		for _, warning := range checker.Warnings() {
+			var diagnostic *sema.LintDiagnostic
+			require.ErrorAs(t, warning, &diagnostic)
+			assert.Equal(t, sema.EntitlementMinimizationDiagnosticCode, diagnostic.Code)
+			diagnostics = append(diagnostics, diagnostic)
+		}
+		return diagnostics
+	}
+
+	applyFix := func(t *testing.T, code string, diagnostic *sema.LintDiagnostic) string {
+		fixes := diagnostic.SuggestFixes(code)
+		require.Len(t, fixes, 1)
+		require.Len(t, fixes[0].TextEdits, 1)
+
+		edit := fixes[0].TextEdits[0]
+		return code[:edit.StartPos.Offset] + edit.Replacement + code[edit.EndPos.Offset+1:]
+	}
+
+	const entitlements = `
+      entitlement E
+      entitlement F
+
+      struct S {
+          access(E) fun e() {}
+          access(F) fun f() {}
+          access(E | F) fun eOrF() {}
+          access(all) fun g() {}
+      }
+
+      fun other(_ s: auth(E, F) &S) {
+          s.e()
+          s.f()
+      }
+    `
+
+	t.Run("transaction, mapped access", func(t *testing.T) {
+		t.Parallel()
+
+		const code = `
+          transaction {
+              prepare(signer: auth(Storage, Contracts) &Account) {
+                  signer.storage.save(1, to: /storage/one)
+              }
+          }
+        `
+
+		diagnostics := check(t, code)
+		require.Len(t, diagnostics, 1)
+
+		diagnostic := diagnostics[0]
+		assert.Equal(t, "parameter `signer` only requires the entitlements `Storage`", diagnostic.Message)
+		assert.Equal(t,
+			ast.Range{
+				StartPos: ast.Position{Offset: 55, Line: 3, Column: 30},
+				EndPos:   ast.Position{Offset: 87, Line: 3, Column: 62},
+			},
+			diagnostic.Range,
+		)
+
+		fixedCode := applyFix(t, code, diagnostic)
+		assert.Contains(t, fixedCode, "prepare(signer: auth(Storage) &Account) {")
+
+		assert.Empty(t, check(t, fixedCode))
+	})
+
+	t.Run("no entitlements required", func(t *testing.T) {
+		t.Parallel()
+
+		const code = `
+          fun test(account: auth(Storage) &Account): Address {
+              return account.address
+          }
+        `
+
+		diagnostics := check(t, code)
+		require.Len(t, diagnostics, 1)
+
+		diagnostic := diagnostics[0]
+		assert.Equal(t, "parameter `account` does not require any entitlements", diagnostic.Message)
+
+		fixedCode := applyFix(t, code, diagnostic)
+		assert.Contains(t, fixedCode, "fun test(account: &Account): Address {")
+
+		assert.Empty(t, check(t, fixedCode))
+	})
+
+	t.Run("conjunction", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := check(t, entitlements+`
+          fun test(s: auth(E, F) &S) {
+              s.e()
+              s.g()
+          }
+        `)
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "parameter `s` only requires the entitlements `E`", diagnostics[0].Message)
+	})
+
+	t.Run("disjunction", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := check(t, entitlements+`
+          fun test(s: auth(E, F) &S) {
+              s.eOrF()
+          }
+
+          // E satisfies both member accesses
+          fun test2(s: auth(F, E) &S) {
+              s.eOrF()
+              s.e()
+          }
+        `)
+		require.Len(t, diagnostics, 2)
+		assert.Equal(t, "parameter `s` only requires the entitlements `E`", diagnostics[0].Message)
+		assert.Equal(t, "parameter `s` only requires the entitlements `E`", diagnostics[1].Message)
+	})
+
+	t.Run("minimal", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := check(t, entitlements+`
+          fun test(s: auth(E) &S) {
+              s.e()
+          }
+
+          transaction {
+              prepare(signer: auth(Storage) &Account) {
+                  signer.storage.save(1, to: /storage/one)
+              }
+          }
+        `)
+		assert.Empty(t, diagnostics)
+	})
+
+	t.Run("parameter used", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := check(t, entitlements+`
+          fun test(s: auth(E, F) &S) {
+              s.e()
+              other(s)
+          }
+        `)
+		assert.Empty(t, diagnostics)
+	})
+
+	t.Run("mapped result used", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := check(t, `
+          fun test(account: auth(Storage, Contracts) &Account) {
+              let storage = account.storage
+              storage.save(1, to: /storage/one)
+          }
+        `)
+		assert.Empty(t, diagnostics)
+	})
+
+	t.Run("shadowed", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := check(t, entitlements+`
+          fun test(s: auth(E, F) &S, s2: auth(E, F) &S) {
+              s.e()
+              if true {
+                  let s = s2
+                  s.f()
+              }
+          }
+        `)
+		assert.Empty(t, diagnostics)
+	})
+}
@@ -0,0 +1,251 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"reflect"
+)
+
+// ErrorCode is the stable identifier of a kind of checker error or warning,
+// e.g. for filtering diagnostics, or in machine-readable output.
+type ErrorCode string
+
+const (
+	ErrorCodeUnknown ErrorCode = ""
+	// ErrorCodeDeprecation is the code of DeprecationWarning
+	ErrorCodeDeprecation ErrorCode = "deprecation"
+	// ErrorCodeMissingViewAnnotation is the code of MissingViewAnnotationWarning
+	ErrorCodeMissingViewAnnotation ErrorCode = "missing-view-annotation"
+	// ErrorCodeNonExhaustiveSwitch is the code of NonExhaustiveSwitchWarning
+	ErrorCodeNonExhaustiveSwitch ErrorCode = "non-exhaustive-switch"
+	// ErrorCodeUnusedDeclaration is the code of UnusedDeclarationWarning
+	ErrorCodeUnusedDeclaration ErrorCode = "unused-declaration"
+)
+
+// errorCodes are the codes of the checker errors.
+//
+// NOTE: codes must be stable. Add codes for new errors,
+// but do not modify the codes of existing errors, even if an error type is renamed
+var errorCodes = map[reflect.Type]ErrorCode{
+	reflect.TypeOf(InvalidPragmaError{}):                                         "invalid-pragma",
+	reflect.TypeOf(RedeclarationError{}):                                         "redeclaration",
+	reflect.TypeOf(NotDeclaredError{}):                                           "not-declared",
+	reflect.TypeOf(AssignmentToConstantError{}):                                  "assignment-to-constant",
+	reflect.TypeOf(TypeMismatchError{}):                                          "type-mismatch",
+	reflect.TypeOf(TypeMismatchWithDescriptionError{}):                           "type-mismatch-with-description",
+	reflect.TypeOf(NotIndexableTypeError{}):                                      "not-indexable-type",
+	reflect.TypeOf(NotIndexingAssignableTypeError{}):                             "not-indexing-assignable-type",
+	reflect.TypeOf(NotEquatableTypeError{}):                                      "not-equatable-type",
+	reflect.TypeOf(NotCallableError{}):                                           "not-callable",
+	reflect.TypeOf(InsufficientArgumentsError{}):                                 "insufficient-arguments",
+	reflect.TypeOf(ExcessiveArgumentsError{}):                                    "excessive-arguments",
+	reflect.TypeOf(MissingArgumentLabelError{}):                                  "missing-argument-label",
+	reflect.TypeOf(IncorrectArgumentLabelError{}):                                "incorrect-argument-label",
+	reflect.TypeOf(InvalidUnaryOperandError{}):                                   "invalid-unary-operand",
+	reflect.TypeOf(InvalidBinaryOperandError{}):                                  "invalid-binary-operand",
+	reflect.TypeOf(InvalidBinaryOperandsError{}):                                 "invalid-binary-operands",
+	reflect.TypeOf(InvalidNilCoalescingRightResourceOperandError{}):              "invalid-nil-coalescing-right-resource-operand",
+	reflect.TypeOf(InvalidConditionalResourceOperandError{}):                     "invalid-conditional-resource-operand",
+	reflect.TypeOf(ControlStatementError{}):                                      "control-statement",
+	reflect.TypeOf(InvalidAccessModifierError{}):                                 "invalid-access-modifier",
+	reflect.TypeOf(MissingAccessModifierError{}):                                 "missing-access-modifier",
+	reflect.TypeOf(InvalidStaticModifierError{}):                                 "invalid-static-modifier",
+	reflect.TypeOf(InvalidNativeModifierError{}):                                 "invalid-native-modifier",
+	reflect.TypeOf(NativeFunctionWithImplementationError{}):                      "native-function-with-implementation",
+	reflect.TypeOf(InvalidNameError{}):                                           "invalid-name",
+	reflect.TypeOf(UnknownSpecialFunctionError{}):                                "unknown-special-function",
+	reflect.TypeOf(InvalidVariableKindError{}):                                   "invalid-variable-kind",
+	reflect.TypeOf(InvalidDeclarationError{}):                                    "invalid-declaration",
+	reflect.TypeOf(MissingInitializerError{}):                                    "missing-initializer",
+	reflect.TypeOf(NotDeclaredMemberError{}):                                     "not-declared-member",
+	reflect.TypeOf(AssignmentToConstantMemberError{}):                            "assignment-to-constant-member",
+	reflect.TypeOf(FieldReinitializationError{}):                                 "field-reinitialization",
+	reflect.TypeOf(FieldUninitializedError{}):                                    "field-uninitialized",
+	reflect.TypeOf(FieldTypeNotStorableError{}):                                  "field-type-not-storable",
+	reflect.TypeOf(FunctionExpressionInConditionError{}):                         "function-expression-in-condition",
+	reflect.TypeOf(InvalidEmitConditionError{}):                                  "invalid-emit-condition",
+	reflect.TypeOf(MissingReturnValueError{}):                                    "missing-return-value",
+	reflect.TypeOf(InvalidImplementationError{}):                                 "invalid-implementation",
+	reflect.TypeOf(InvalidConformanceError{}):                                    "invalid-conformance",
+	reflect.TypeOf(InvalidEnumRawTypeError{}):                                    "invalid-enum-raw-type",
+	reflect.TypeOf(MissingEnumRawTypeError{}):                                    "missing-enum-raw-type",
+	reflect.TypeOf(InvalidEnumConformancesError{}):                               "invalid-enum-conformances",
+	reflect.TypeOf(InvalidAttachmentConformancesError{}):                         "invalid-attachment-conformances",
+	reflect.TypeOf(ConformanceError{}):                                           "conformance",
+	reflect.TypeOf(DuplicateConformanceError{}):                                  "duplicate-conformance",
+	reflect.TypeOf(CyclicConformanceError{}):                                     "cyclic-conformance",
+	reflect.TypeOf(MultipleInterfaceDefaultImplementationsError{}):               "multiple-interface-default-implementations",
+	reflect.TypeOf(SpecialFunctionDefaultImplementationError{}):                  "special-function-default-implementation",
+	reflect.TypeOf(InterfaceMemberConflictError{}):                               "interface-member-conflict",
+	reflect.TypeOf(MissingConformanceError{}):                                    "missing-conformance",
+	reflect.TypeOf(UnresolvedImportError{}):                                      "unresolved-import",
+	reflect.TypeOf(NotExportedError{}):                                           "not-exported",
+	reflect.TypeOf(ImportedProgramError{}):                                       "imported-program",
+	reflect.TypeOf(AlwaysFailingNonResourceCastingTypeError{}):                   "always-failing-non-resource-casting-type",
+	reflect.TypeOf(AlwaysFailingResourceCastingTypeError{}):                      "always-failing-resource-casting-type",
+	reflect.TypeOf(UnsupportedOverloadingError{}):                                "unsupported-overloading",
+	reflect.TypeOf(CompositeKindMismatchError{}):                                 "composite-kind-mismatch",
+	reflect.TypeOf(InvalidIntegerLiteralRangeError{}):                            "invalid-integer-literal-range",
+	reflect.TypeOf(IntegerLiteralSizeLimitExceededError{}):                       "integer-literal-size-limit-exceeded",
+	reflect.TypeOf(InvalidAddressLiteralError{}):                                 "invalid-address-literal",
+	reflect.TypeOf(InvalidFixedPointLiteralRangeError{}):                         "invalid-fixed-point-literal-range",
+	reflect.TypeOf(InvalidFixedPointLiteralScaleError{}):                         "invalid-fixed-point-literal-scale",
+	reflect.TypeOf(MissingReturnStatementError{}):                                "missing-return-statement",
+	reflect.TypeOf(UnsupportedOptionalChainingAssignmentError{}):                 "unsupported-optional-chaining-assignment",
+	reflect.TypeOf(MissingResourceAnnotationError{}):                             "missing-resource-annotation",
+	reflect.TypeOf(InvalidNestedResourceMoveError{}):                             "invalid-nested-resource-move",
+	reflect.TypeOf(InvalidInterfaceConditionResourceInvalidationError{}):         "invalid-interface-condition-resource-invalidation",
+	reflect.TypeOf(InvalidResourceAnnotationError{}):                             "invalid-resource-annotation",
+	reflect.TypeOf(InvalidInterfaceTypeError{}):                                  "invalid-interface-type",
+	reflect.TypeOf(InvalidInterfaceDeclarationError{}):                           "invalid-interface-declaration",
+	reflect.TypeOf(IncorrectTransferOperationError{}):                            "incorrect-transfer-operation",
+	reflect.TypeOf(InvalidConstructionError{}):                                   "invalid-construction",
+	reflect.TypeOf(InvalidDestructionError{}):                                    "invalid-destruction",
+	reflect.TypeOf(ResourceLossError{}):                                          "resource-loss",
+	reflect.TypeOf(ResourceUseAfterInvalidationError{}):                          "resource-use-after-invalidation",
+	reflect.TypeOf(MissingCreateError{}):                                         "missing-create",
+	reflect.TypeOf(MissingMoveOperationError{}):                                  "missing-move-operation",
+	reflect.TypeOf(InvalidMoveOperationError{}):                                  "invalid-move-operation",
+	reflect.TypeOf(ResourceCapturingError{}):                                     "resource-capturing",
+	reflect.TypeOf(InvalidResourceFieldError{}):                                  "invalid-resource-field",
+	reflect.TypeOf(InvalidSwapExpressionError{}):                                 "invalid-swap-expression",
+	reflect.TypeOf(InvalidEventParameterTypeError{}):                             "invalid-event-parameter-type",
+	reflect.TypeOf(InvalidEventUsageError{}):                                     "invalid-event-usage",
+	reflect.TypeOf(EmitNonEventError{}):                                          "emit-non-event",
+	reflect.TypeOf(EmitDefaultDestroyEventError{}):                               "emit-default-destroy-event",
+	reflect.TypeOf(EmitImportedEventError{}):                                     "emit-imported-event",
+	reflect.TypeOf(InvalidResourceAssignmentError{}):                             "invalid-resource-assignment",
+	reflect.TypeOf(ResourceFieldNotInvalidatedError{}):                           "resource-field-not-invalidated",
+	reflect.TypeOf(UninitializedFieldAccessError{}):                              "uninitialized-field-access",
+	reflect.TypeOf(UnreachableStatementError{}):                                  "unreachable-statement",
+	reflect.TypeOf(UninitializedUseError{}):                                      "uninitialized-use",
+	reflect.TypeOf(InvalidResourceArrayMemberError{}):                            "invalid-resource-array-member",
+	reflect.TypeOf(InvalidResourceDictionaryMemberError{}):                       "invalid-resource-dictionary-member",
+	reflect.TypeOf(InvalidResourceOptionalMemberError{}):                         "invalid-resource-optional-member",
+	reflect.TypeOf(NonReferenceTypeReferenceError{}):                             "non-reference-type-reference",
+	reflect.TypeOf(ReferenceToAnOptionalError{}):                                 "reference-to-an-optional",
+	reflect.TypeOf(InvalidResourceCreationError{}):                               "invalid-resource-creation",
+	reflect.TypeOf(NonResourceTypeError{}):                                       "non-resource-type",
+	reflect.TypeOf(InvalidAssignmentTargetError{}):                               "invalid-assignment-target",
+	reflect.TypeOf(ResourceMethodBindingError{}):                                 "resource-method-binding",
+	reflect.TypeOf(InvalidDictionaryKeyTypeError{}):                              "invalid-dictionary-key-type",
+	reflect.TypeOf(MissingFunctionBodyError{}):                                   "missing-function-body",
+	reflect.TypeOf(InvalidOptionalChainingError{}):                               "invalid-optional-chaining",
+	reflect.TypeOf(InvalidAccessError{}):                                         "invalid-access",
+	reflect.TypeOf(InvalidAssignmentAccessError{}):                               "invalid-assignment-access",
+	reflect.TypeOf(UnauthorizedReferenceAssignmentError{}):                       "unauthorized-reference-assignment",
+	reflect.TypeOf(InvalidCharacterLiteralError{}):                               "invalid-character-literal",
+	reflect.TypeOf(InvalidFailableResourceDowncastOutsideOptionalBindingError{}): "invalid-failable-resource-downcast-outside-optional-binding",
+	reflect.TypeOf(ReadOnlyTargetAssignmentError{}):                              "read-only-target-assignment",
+	reflect.TypeOf(InvalidTransactionBlockError{}):                               "invalid-transaction-block",
+	reflect.TypeOf(TransactionMissingPrepareError{}):                             "transaction-missing-prepare",
+	reflect.TypeOf(InvalidResourceTransactionParameterError{}):                   "invalid-resource-transaction-parameter",
+	reflect.TypeOf(InvalidNonImportableTransactionParameterTypeError{}):          "invalid-non-importable-transaction-parameter-type",
+	reflect.TypeOf(InvalidTransactionFieldAccessModifierError{}):                 "invalid-transaction-field-access-modifier",
+	reflect.TypeOf(InvalidTransactionPrepareParameterTypeError{}):                "invalid-transaction-prepare-parameter-type",
+	reflect.TypeOf(InvalidNestedDeclarationError{}):                              "invalid-nested-declaration",
+	reflect.TypeOf(InvalidNestedTypeError{}):                                     "invalid-nested-type",
+	reflect.TypeOf(InvalidEnumCaseError{}):                                       "invalid-enum-case",
+	reflect.TypeOf(InvalidNonEnumCaseError{}):                                    "invalid-non-enum-case",
+	reflect.TypeOf(DeclarationKindMismatchError{}):                               "declaration-kind-mismatch",
+	reflect.TypeOf(InvalidTopLevelDeclarationError{}):                            "invalid-top-level-declaration",
+	reflect.TypeOf(InvalidSelfInvalidationError{}):                               "invalid-self-invalidation",
+	reflect.TypeOf(InvalidMoveError{}):                                           "invalid-move",
+	reflect.TypeOf(ConstantSizedArrayLiteralSizeError{}):                         "constant-sized-array-literal-size",
+	reflect.TypeOf(InvalidIntersectedTypeError{}):                                "invalid-intersected-type",
+	reflect.TypeOf(IntersectionCompositeKindMismatchError{}):                     "intersection-composite-kind-mismatch",
+	reflect.TypeOf(InvalidIntersectionTypeDuplicateError{}):                      "invalid-intersection-type-duplicate",
+	reflect.TypeOf(IntersectionMemberClashError{}):                               "intersection-member-clash",
+	reflect.TypeOf(AmbiguousIntersectionTypeError{}):                             "ambiguous-intersection-type",
+	reflect.TypeOf(InvalidPathDomainError{}):                                     "invalid-path-domain",
+	reflect.TypeOf(InvalidPathIdentifierError{}):                                 "invalid-path-identifier",
+	reflect.TypeOf(InvalidTypeArgumentCountError{}):                              "invalid-type-argument-count",
+	reflect.TypeOf(MissingTypeArgumentError{}):                                   "missing-type-argument",
+	reflect.TypeOf(InvalidTypeArgumentError{}):                                   "invalid-type-argument",
+	reflect.TypeOf(TypeParameterTypeInferenceError{}):                            "type-parameter-type-inference",
+	reflect.TypeOf(InvalidConstantSizedTypeBaseError{}):                          "invalid-constant-sized-type-base",
+	reflect.TypeOf(InvalidConstantSizedTypeSizeError{}):                          "invalid-constant-sized-type-size",
+	reflect.TypeOf(UnsupportedResourceForLoopError{}):                            "unsupported-resource-for-loop",
+	reflect.TypeOf(TypeParameterTypeMismatchError{}):                             "type-parameter-type-mismatch",
+	reflect.TypeOf(UnparameterizedTypeInstantiationError{}):                      "unparameterized-type-instantiation",
+	reflect.TypeOf(TypeAnnotationRequiredError{}):                                "type-annotation-required",
+	reflect.TypeOf(CyclicImportsError{}):                                         "cyclic-imports",
+	reflect.TypeOf(SwitchDefaultPositionError{}):                                 "switch-default-position",
+	reflect.TypeOf(MissingSwitchCaseStatementsError{}):                           "missing-switch-case-statements",
+	reflect.TypeOf(MissingEntryPointError{}):                                     "missing-entry-point",
+	reflect.TypeOf(InvalidEntryPointTypeError{}):                                 "invalid-entry-point-type",
+	reflect.TypeOf(PurityError{}):                                                "purity",
+	reflect.TypeOf(InvalidatedResourceReferenceError{}):                          "invalidated-resource-reference",
+	reflect.TypeOf(InvalidEntitlementAccessError{}):                              "invalid-entitlement-access",
+	reflect.TypeOf(InvalidEntitlementMappingTypeError{}):                         "invalid-entitlement-mapping-type",
+	reflect.TypeOf(InvalidNonEntitlementTypeInMapError{}):                        "invalid-non-entitlement-type-in-map",
+	reflect.TypeOf(InvalidMappedEntitlementMemberError{}):                        "invalid-mapped-entitlement-member",
+	reflect.TypeOf(InvalidAttachmentMappedEntitlementMemberError{}):              "invalid-attachment-mapped-entitlement-member",
+	reflect.TypeOf(InvalidNonEntitlementAccessError{}):                           "invalid-non-entitlement-access",
+	reflect.TypeOf(MappingAccessMissingKeywordError{}):                           "mapping-access-missing-keyword",
+	reflect.TypeOf(DirectEntitlementAnnotationError{}):                           "direct-entitlement-annotation",
+	reflect.TypeOf(UnrepresentableEntitlementMapOutputError{}):                   "unrepresentable-entitlement-map-output",
+	reflect.TypeOf(InvalidMappedAuthorizationOutsideOfFieldError{}):              "invalid-mapped-authorization-outside-of-field",
+	reflect.TypeOf(InvalidEntitlementMappingInclusionError{}):                    "invalid-entitlement-mapping-inclusion",
+	reflect.TypeOf(DuplicateEntitlementMappingInclusionError{}):                  "duplicate-entitlement-mapping-inclusion",
+	reflect.TypeOf(CyclicEntitlementMappingError{}):                              "cyclic-entitlement-mapping",
+	reflect.TypeOf(InvalidBaseTypeError{}):                                       "invalid-base-type",
+	reflect.TypeOf(InvalidAttachmentAnnotationError{}):                           "invalid-attachment-annotation",
+	reflect.TypeOf(InvalidAttachmentUsageError{}):                                "invalid-attachment-usage",
+	reflect.TypeOf(AttachNonAttachmentError{}):                                   "attach-non-attachment",
+	reflect.TypeOf(AttachToInvalidTypeError{}):                                   "attach-to-invalid-type",
+	reflect.TypeOf(InvalidAttachmentRemoveError{}):                               "invalid-attachment-remove",
+	reflect.TypeOf(InvalidTypeIndexingError{}):                                   "invalid-type-indexing",
+	reflect.TypeOf(InvalidAttachmentEntitlementError{}):                          "invalid-attachment-entitlement",
+	reflect.TypeOf(DefaultDestroyEventInNonResourceError{}):                      "default-destroy-event-in-non-resource",
+	reflect.TypeOf(DefaultDestroyInvalidArgumentError{}):                         "default-destroy-invalid-argument",
+	reflect.TypeOf(DefaultDestroyInvalidParameterError{}):                        "default-destroy-invalid-parameter",
+	reflect.TypeOf(InvalidTypeParameterizedNonNativeFunctionError{}):             "invalid-type-parameterized-non-native-function",
+	reflect.TypeOf(NestedReferenceError{}):                                       "nested-reference",
+	reflect.TypeOf(ResultVariableConflictError{}):                                "result-variable-conflict",
+	reflect.TypeOf(InvocationTypeInferenceError{}):                               "invocation-type-inference",
+	reflect.TypeOf(UnconvertableTypeError{}):                                     "unconvertable-type",
+	reflect.TypeOf(DeprecationWarning{}):                                         ErrorCodeDeprecation,
+	reflect.TypeOf(MissingViewAnnotationWarning{}):                               ErrorCodeMissingViewAnnotation,
+	reflect.TypeOf(NonExhaustiveSwitchWarning{}):                                 ErrorCodeNonExhaustiveSwitch,
+	reflect.TypeOf(UnusedDeclarationWarning{}):                                   ErrorCodeUnusedDeclaration,
+}
+
+// ErrorCodeOf returns the code of the given checker error or warning,
+// or ErrorCodeUnknown if the error is not a checker error or warning.
+//
+// The code of a lint diagnostic is its code, if any, or otherwise the name of the rule which reported it
+func ErrorCodeOf(err error) ErrorCode {
+	if diagnostic, ok := err.(*LintDiagnostic); ok {
+		if diagnostic.Code != "" {
+			return ErrorCode(diagnostic.Code)
+		}
+		return ErrorCode(diagnostic.Rule)
+	}
+
+	ty := reflect.TypeOf(err)
+	if ty == nil {
+		return ErrorCodeUnknown
+	}
+	if ty.Kind() == reflect.Pointer {
+		ty = ty.Elem()
+	}
+
+	return errorCodes[ty]
+}
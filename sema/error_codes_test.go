@@ -0,0 +1,106 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCodes(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("all errors have codes", func(t *testing.T) {
+		t.Parallel()
+
+		file, err := parser.ParseFile(token.NewFileSet(), "errors.go", nil, 0)
+		require.NoError(t, err)
+
+		typesWithCodes := map[string]struct{}{}
+		for ty := range errorCodes { //nolint:maprange
+			typesWithCodes[ty.Name()] = struct{}{}
+		}
+
+		for _, declaration := range file.Decls {
+			genDecl, ok := declaration.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if _, ok := typeSpec.Type.(*ast.StructType); !ok {
+					continue
+				}
+
+				name := typeSpec.Name.Name
+				if !strings.HasSuffix(name, "Error") || name == "CheckerError" {
+					continue
+				}
+
+				assert.Contains(t, typesWithCodes, name)
+			}
+		}
+	})
+
+	t.Run("codes are unique", func(t *testing.T) {
+		t.Parallel()
+
+		seen := map[ErrorCode]struct{}{}
+		for _, code := range errorCodes { //nolint:maprange
+			require.NotEqual(t, ErrorCodeUnknown, code)
+			require.NotContains(t, seen, code)
+			seen[code] = struct{}{}
+		}
+	})
+
+	t.Run("pointer and value", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, ErrorCode("redeclaration"), ErrorCodeOf(&RedeclarationError{}))
+		assert.Equal(t, ErrorCode("cyclic-conformance"), ErrorCodeOf(CyclicConformanceError{}))
+	})
+
+	t.Run("lint diagnostic", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t,
+			ErrorCode("code"),
+			ErrorCodeOf(&LintDiagnostic{Rule: "rule", Code: "code"}),
+		)
+		assert.Equal(t,
+			ErrorCode("rule"),
+			ErrorCodeOf(&LintDiagnostic{Rule: "rule"}),
+		)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, ErrorCodeUnknown, ErrorCodeOf(nil))
+		assert.Equal(t, ErrorCodeUnknown, ErrorCodeOf(&CheckerError{}))
+	})
+}
@@ -697,6 +697,13 @@ type InvalidNilCoalescingRightResourceOperandError struct {
 	ast.Range
 }
 
+var _ SemanticError = &InvalidNilCoalescingRightResourceOperandError{}
+var _ errors.UserError = &InvalidNilCoalescingRightResourceOperandError{}
+
+func (*InvalidNilCoalescingRightResourceOperandError) isSemanticError() {}
+
+func (*InvalidNilCoalescingRightResourceOperandError) IsUserError() {}
+
 func (e *InvalidNilCoalescingRightResourceOperandError) Error() string {
 	return "nil-coalescing with right-hand resource is not supported at the moment"
 }
@@ -707,6 +714,13 @@ type InvalidConditionalResourceOperandError struct {
 	ast.Range
 }
 
+var _ SemanticError = &InvalidConditionalResourceOperandError{}
+var _ errors.UserError = &InvalidConditionalResourceOperandError{}
+
+func (*InvalidConditionalResourceOperandError) isSemanticError() {}
+
+func (*InvalidConditionalResourceOperandError) IsUserError() {}
+
 func (e *InvalidConditionalResourceOperandError) Error() string {
 	return "conditional with resource is not supported at the moment"
 }
@@ -1941,6 +1955,34 @@ func (e *InvalidIntegerLiteralRangeError) SecondaryError() string {
 	)
 }
 
+// IntegerLiteralSizeLimitExceededError
+
+type IntegerLiteralSizeLimitExceededError struct {
+	BitLength int
+	Limit     int
+	ast.Range
+}
+
+var _ SemanticError = &IntegerLiteralSizeLimitExceededError{}
+var _ errors.UserError = &IntegerLiteralSizeLimitExceededError{}
+var _ errors.SecondaryError = &IntegerLiteralSizeLimitExceededError{}
+
+func (*IntegerLiteralSizeLimitExceededError) IsUserError() {}
+
+func (*IntegerLiteralSizeLimitExceededError) isSemanticError() {}
+
+func (e *IntegerLiteralSizeLimitExceededError) Error() string {
+	return "integer literal too large"
+}
+
+func (e *IntegerLiteralSizeLimitExceededError) SecondaryError() string {
+	return fmt.Sprintf(
+		"literal has %d bits, limit is %d bits",
+		e.BitLength,
+		e.Limit,
+	)
+}
+
 // InvalidAddressLiteralError
 
 type InvalidAddressLiteralError struct {
@@ -3777,6 +3819,13 @@ func (*InvalidPathDomainError) isSemanticError() {}
 
 func (*InvalidPathDomainError) IsUserError() {}
 
+var _ SemanticError = &InvalidPathIdentifierError{}
+var _ errors.UserError = &InvalidPathIdentifierError{}
+
+func (*InvalidPathIdentifierError) isSemanticError() {}
+
+func (*InvalidPathIdentifierError) IsUserError() {}
+
 func (e *InvalidPathIdentifierError) Error() string {
 	return fmt.Sprintf("invalid path identifier %s", e.ActualIdentifier)
 }
@@ -4061,6 +4110,9 @@ func (e *TypeAnnotationRequiredError) EndPosition(common.MemoryGauge) ast.Positi
 
 type CyclicImportsError struct {
 	Location common.Location
+	// Cycle is the chain of imports which forms the cycle, if known,
+	// starting and ending with Location, e.g. A -> B -> C -> A
+	Cycle []common.Location
 	ast.Range
 }
 
@@ -4072,7 +4124,23 @@ func (*CyclicImportsError) isSemanticError() {}
 func (*CyclicImportsError) IsUserError() {}
 
 func (e *CyclicImportsError) Error() string {
-	return fmt.Sprintf("cyclic import of `%s`", e.Location)
+	if len(e.Cycle) == 0 {
+		return fmt.Sprintf("cyclic import of `%s`", e.Location)
+	}
+
+	var sb strings.Builder
+	for i, location := range e.Cycle {
+		if i > 0 {
+			sb.WriteString(" -> ")
+		}
+		sb.WriteString(location.String())
+	}
+
+	return fmt.Sprintf(
+		"cyclic import of `%s`: %s",
+		e.Location,
+		sb.String(),
+	)
 }
 
 // SwitchDefaultPositionError
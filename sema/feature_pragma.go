@@ -0,0 +1,226 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/errors"
+)
+
+// FeaturePragmaArgumentKind is the kind of an argument of a feature pragma
+type FeaturePragmaArgumentKind uint8
+
+const (
+	// FeaturePragmaArgumentKindString is the kind of string literal arguments.
+	// The value of the argument is a string
+	FeaturePragmaArgumentKindString FeaturePragmaArgumentKind = iota
+	// FeaturePragmaArgumentKindInteger is the kind of integer literal arguments.
+	// The value of the argument is a *big.Int
+	FeaturePragmaArgumentKindInteger
+	// FeaturePragmaArgumentKindBool is the kind of boolean literal arguments.
+	// The value of the argument is a bool
+	FeaturePragmaArgumentKindBool
+)
+
+func (k FeaturePragmaArgumentKind) Name() string {
+	switch k {
+	case FeaturePragmaArgumentKindString:
+		return "string"
+	case FeaturePragmaArgumentKindInteger:
+		return "integer"
+	case FeaturePragmaArgumentKindBool:
+		return "boolean"
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+// FeaturePragma defines a pragma which enables a feature for a program,
+// e.g. `#allowAccountLinking`, or `#limit("storage", 100)`.
+//
+// Feature pragmas are declared at the top-level of a program.
+// The uses of feature pragmas are recorded in the elaboration, see Elaboration.FeaturePragmas
+type FeaturePragma struct {
+	// Name is the name of the pragma, e.g. "allowAccountLinking"
+	Name string
+	// Arguments are the kinds of the arguments of the pragma.
+	// If the pragma has no arguments, it may be used as an identifier, e.g. `#allowAccountLinking`,
+	// or as an invocation without arguments, e.g. `#allowAccountLinking()`
+	Arguments []FeaturePragmaArgumentKind
+	// Validate, if set, is called with the use of the pragma
+	// after its arguments have been checked against the argument kinds.
+	// A returned error is reported as an invalid pragma error
+	Validate func(use FeaturePragmaUse) error
+}
+
+// FeaturePragmaUse is a use of a feature pragma in a program
+type FeaturePragmaUse struct {
+	Pragma      *FeaturePragma
+	Declaration *ast.PragmaDeclaration
+	// Arguments are the values of the arguments,
+	// see FeaturePragmaArgumentKind for the types of the values
+	Arguments []any
+}
+
+// FeaturePragmaSet is an index of feature pragmas
+type FeaturePragmaSet struct {
+	pragmas map[string]*FeaturePragma
+}
+
+func NewFeaturePragmaSet(pragmas []*FeaturePragma) *FeaturePragmaSet {
+	set := &FeaturePragmaSet{
+		pragmas: make(map[string]*FeaturePragma, len(pragmas)),
+	}
+
+	for _, pragma := range pragmas {
+		set.pragmas[pragma.Name] = pragma
+	}
+
+	return set
+}
+
+// Get returns the feature pragma with the given name, or nil if there is none
+func (s *FeaturePragmaSet) Get(name string) *FeaturePragma {
+	if s == nil {
+		return nil
+	}
+	return s.pragmas[name]
+}
+
+// checkFeaturePragma checks the given pragma declaration against the feature pragma with its name, if any.
+// Pragmas which are not feature pragmas are not checked further
+func (checker *Checker) checkFeaturePragma(declaration *ast.PragmaDeclaration) {
+	if checker.Config.FeaturePragmas == nil {
+		return
+	}
+
+	var identifier ast.Identifier
+	var arguments ast.Arguments
+
+	switch expression := declaration.Expression.(type) {
+	case *ast.IdentifierExpression:
+		identifier = expression.Identifier
+
+	case *ast.InvocationExpression:
+		invokedIdentifier, ok := expression.InvokedExpression.(*ast.IdentifierExpression)
+		if !ok {
+			return
+		}
+		identifier = invokedIdentifier.Identifier
+		arguments = expression.Arguments
+
+	default:
+		return
+	}
+
+	pragma := checker.Config.FeaturePragmas.Get(identifier.Identifier)
+	if pragma == nil {
+		return
+	}
+
+	if len(arguments) != len(pragma.Arguments) {
+		checker.report(&InvalidPragmaError{
+			Message: fmt.Sprintf(
+				"incorrect number of arguments for pragma `%s`: expected %d, got %d",
+				pragma.Name,
+				len(pragma.Arguments),
+				len(arguments),
+			),
+			Range: ast.NewRangeFromPositioned(checker.memoryGauge, declaration),
+		})
+		return
+	}
+
+	values := make([]any, 0, len(arguments))
+
+	for i, argument := range arguments {
+		kind := pragma.Arguments[i]
+
+		value, ok := featurePragmaArgumentValue(argument.Expression, kind)
+		if !ok {
+			checker.report(&InvalidPragmaError{
+				Message: fmt.Sprintf(
+					"argument %d of pragma `%s` must be a %s literal",
+					i+1,
+					pragma.Name,
+					kind.Name(),
+				),
+				Range: ast.NewRangeFromPositioned(checker.memoryGauge, argument.Expression),
+			})
+			return
+		}
+
+		values = append(values, value)
+	}
+
+	use := FeaturePragmaUse{
+		Pragma:      pragma,
+		Declaration: declaration,
+		Arguments:   values,
+	}
+
+	if pragma.Validate != nil {
+		err := pragma.Validate(use)
+		if err != nil {
+			checker.report(&InvalidPragmaError{
+				Message: err.Error(),
+				Range:   ast.NewRangeFromPositioned(checker.memoryGauge, declaration),
+			})
+			return
+		}
+	}
+
+	checker.Elaboration.addFeaturePragma(use)
+}
+
+func featurePragmaArgumentValue(expression ast.Expression, kind FeaturePragmaArgumentKind) (any, bool) {
+	switch kind {
+	case FeaturePragmaArgumentKindString:
+		if expression, ok := expression.(*ast.StringExpression); ok {
+			return expression.Value, true
+		}
+
+	case FeaturePragmaArgumentKindInteger:
+		switch expression := expression.(type) {
+		case *ast.IntegerExpression:
+			return expression.Value, true
+
+		case *ast.UnaryExpression:
+			if expression.Operation != ast.OperationMinus {
+				break
+			}
+			if integerExpression, ok := expression.Expression.(*ast.IntegerExpression); ok {
+				return new(big.Int).Neg(integerExpression.Value), true
+			}
+		}
+
+	case FeaturePragmaArgumentKindBool:
+		if expression, ok := expression.(*ast.BoolExpression); ok {
+			return expression.Value, true
+		}
+
+	default:
+		panic(errors.NewUnreachableError())
+	}
+
+	return nil, false
+}
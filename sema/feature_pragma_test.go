@@ -0,0 +1,191 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func TestCheckFeaturePragma(t *testing.T) {
+
+	t.Parallel()
+
+	allowAccountLinkingPragma := &sema.FeaturePragma{
+		Name: "allowAccountLinking",
+	}
+
+	limitPragma := &sema.FeaturePragma{
+		Name: "limit",
+		Arguments: []sema.FeaturePragmaArgumentKind{
+			sema.FeaturePragmaArgumentKindString,
+			sema.FeaturePragmaArgumentKindInteger,
+			sema.FeaturePragmaArgumentKindBool,
+		},
+		Validate: func(use sema.FeaturePragmaUse) error {
+			if use.Arguments[0] == "invalid" {
+				return errors.New("invalid limit")
+			}
+			return nil
+		},
+	}
+
+	featurePragmas := sema.NewFeaturePragmaSet([]*sema.FeaturePragma{
+		allowAccountLinkingPragma,
+		limitPragma,
+	})
+
+	parseAndCheck := func(t *testing.T, code string) (*sema.Checker, error) {
+		return ParseAndCheckWithOptions(t,
+			code,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					FeaturePragmas: featurePragmas,
+				},
+			},
+		)
+	}
+
+	t.Run("identifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, `
+          #allowAccountLinking
+        `)
+		require.NoError(t, err)
+
+		uses := checker.Elaboration.FeaturePragmas()
+		require.Len(t, uses, 1)
+		assert.Same(t, allowAccountLinkingPragma, uses[0].Pragma)
+		assert.Empty(t, uses[0].Arguments)
+
+		use, ok := checker.Elaboration.FeaturePragma("allowAccountLinking")
+		require.True(t, ok)
+		assert.Same(t, allowAccountLinkingPragma, use.Pragma)
+
+		_, ok = checker.Elaboration.FeaturePragma("limit")
+		assert.False(t, ok)
+	})
+
+	t.Run("invocation without arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, `
+          #allowAccountLinking()
+        `)
+		require.NoError(t, err)
+
+		_, ok := checker.Elaboration.FeaturePragma("allowAccountLinking")
+		assert.True(t, ok)
+	})
+
+	t.Run("invocation with arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, `
+          #limit("storage", -100, true)
+        `)
+		require.NoError(t, err)
+
+		use, ok := checker.Elaboration.FeaturePragma("limit")
+		require.True(t, ok)
+		assert.Equal(t,
+			[]any{"storage", big.NewInt(-100), true},
+			use.Arguments,
+		)
+	})
+
+	t.Run("incorrect number of arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, `
+          #limit("storage", 100)
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.InvalidPragmaError{}, errs[0])
+
+		assert.Empty(t, checker.Elaboration.FeaturePragmas())
+	})
+
+	t.Run("argument of incorrect kind", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, `
+          #limit("storage", "100", true)
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.InvalidPragmaError{}, errs[0])
+
+		assert.Empty(t, checker.Elaboration.FeaturePragmas())
+	})
+
+	t.Run("validation failure", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, `
+          #limit("invalid", 100, true)
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+		require.IsType(t, &sema.InvalidPragmaError{}, errs[0])
+		assert.Equal(t, "invalid limit", errs[0].(*sema.InvalidPragmaError).Message)
+
+		assert.Empty(t, checker.Elaboration.FeaturePragmas())
+	})
+
+	t.Run("unknown pragma", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, `
+          #version("1.0")
+        `)
+		require.NoError(t, err)
+
+		assert.Empty(t, checker.Elaboration.FeaturePragmas())
+	})
+
+	t.Run("no feature pragmas", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          #allowAccountLinking
+          #limit("storage")
+        `)
+		require.NoError(t, err)
+
+		assert.Empty(t, checker.Elaboration.FeaturePragmas())
+	})
+}
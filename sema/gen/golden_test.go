@@ -21,9 +21,12 @@ package main
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/interpreter"
 	"github.com/onflow/cadence/sema"
 	_ "github.com/onflow/cadence/sema/gen/testdata/comparable"
 	_ "github.com/onflow/cadence/sema/gen/testdata/composite_type_pragma"
@@ -38,10 +41,12 @@ import (
 	_ "github.com/onflow/cadence/sema/gen/testdata/functions"
 	_ "github.com/onflow/cadence/sema/gen/testdata/importable"
 	_ "github.com/onflow/cadence/sema/gen/testdata/member_accessible"
+	"github.com/onflow/cadence/sema/gen/testdata/native_contract"
 	_ "github.com/onflow/cadence/sema/gen/testdata/nested"
 	_ "github.com/onflow/cadence/sema/gen/testdata/simple_resource"
 	_ "github.com/onflow/cadence/sema/gen/testdata/simple_struct"
 	_ "github.com/onflow/cadence/sema/gen/testdata/storable"
+	"github.com/onflow/cadence/sema/gen/testdata/values"
 	"github.com/onflow/cadence/stdlib"
 	. "github.com/onflow/cadence/test_utils/sema_utils"
 )
@@ -97,3 +102,102 @@ func TestContract(t *testing.T) {
 	)
 	require.NoError(t, err)
 }
+
+func TestValueConstructor(t *testing.T) {
+
+	t.Parallel()
+
+	name := interpreter.NewUnmeteredStringValue("test")
+	count := interpreter.NewUnmeteredUInt64Value(42)
+
+	value := values.NewTestValue(
+		nil,
+		name,
+		count,
+		nil,
+		interpreter.NilOptionalValue,
+		interpreter.Nil,
+	)
+
+	require.IsType(t, &interpreter.SimpleCompositeValue{}, value)
+	compositeValue := value.(*interpreter.SimpleCompositeValue)
+
+	assert.Equal(t, values.TestType.ID(), compositeValue.TypeID)
+	assert.Equal(t,
+		[]string{
+			values.TestTypeNameFieldName,
+			values.TestTypeCountFieldName,
+			values.TestTypeFlagsFieldName,
+			values.TestTypeParentFieldName,
+			values.TestTypeTypeFieldName,
+		},
+		compositeValue.FieldNames,
+	)
+	assert.Equal(t, name, compositeValue.Fields[values.TestTypeNameFieldName])
+	assert.Equal(t, count, compositeValue.Fields[values.TestTypeCountFieldName])
+}
+
+func TestNativeContractValue(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("all implemented", func(t *testing.T) {
+		t.Parallel()
+
+		identity := func(invocation interpreter.Invocation) interpreter.Value {
+			return invocation.Arguments[0]
+		}
+		add := func(invocation interpreter.Invocation) interpreter.Value {
+			return interpreter.Nil
+		}
+
+		value := native_contract.NewTestContractValue(
+			nil,
+			native_contract.TestContractFunctions{
+				Identity: identity,
+				Add:      add,
+			},
+		)
+
+		assert.Equal(t, native_contract.TestType.ID(), value.TypeID)
+		require.Len(t, value.Fields, 2)
+
+		identityValue := value.Fields[native_contract.TestTypeIdentityFunctionName]
+		require.IsType(t, &interpreter.HostFunctionValue{}, identityValue)
+		assert.Equal(t,
+			native_contract.TestTypeIdentityFunctionType,
+			identityValue.(*interpreter.HostFunctionValue).Type,
+		)
+
+		addValue := value.Fields[native_contract.TestTypeAddFunctionName]
+		require.IsType(t, &interpreter.HostFunctionValue{}, addValue)
+		assert.Equal(t,
+			native_contract.TestTypeAddFunctionType,
+			addValue.(*interpreter.HostFunctionValue).Type,
+		)
+	})
+
+	t.Run("missing implementation", func(t *testing.T) {
+		t.Parallel()
+
+		defer func() {
+			err, ok := recover().(error)
+			require.True(t, ok)
+
+			var unexpectedErr errors.UnexpectedError
+			require.ErrorAs(t, err, &unexpectedErr)
+			assert.ErrorContains(t, err, "missing implementation of native function Test.add")
+		}()
+
+		native_contract.NewTestContractValue(
+			nil,
+			native_contract.TestContractFunctions{
+				Identity: func(invocation interpreter.Invocation) interpreter.Value {
+					return invocation.Arguments[0]
+				},
+			},
+		)
+
+		t.Fatal("expected panic")
+	})
+}
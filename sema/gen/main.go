@@ -43,8 +43,13 @@ import (
 
 const semaPath = "github.com/onflow/cadence/sema"
 const astPath = "github.com/onflow/cadence/ast"
+const commonPath = "github.com/onflow/cadence/common"
+const errorsPath = "github.com/onflow/cadence/errors"
+const interpreterPath = "github.com/onflow/cadence/interpreter"
 
 var packagePathFlag = flag.String("p", semaPath, "package path")
+var valuesPathFlag = flag.String("values", "", "path to output Go file for value constructors")
+var valuesPackagePathFlag = flag.String("vp", interpreterPath, "package path of value constructors")
 
 const headerTemplate = `// Code generated from {{ . }}. DO NOT EDIT.
 /*
@@ -82,6 +87,30 @@ func initialUpper(s string) string {
 	return string(unicode.ToUpper(rune(s[0]))) + s[1:]
 }
 
+// unexportedName returns the given exported name as an unexported name,
+// by lowering the leading upper-case letters, e.g. `RLP` becomes `rlp`,
+// and `ContractMetadata` becomes `contractMetadata`
+func unexportedName(s string) string {
+	runes := []rune(s)
+
+	upperCount := 0
+	for upperCount < len(runes) && unicode.IsUpper(runes[upperCount]) {
+		upperCount++
+	}
+
+	// Keep the last upper-case letter, if it starts the next word,
+	// e.g. `HTTPServer` becomes `httpServer`
+	if upperCount > 1 && upperCount < len(runes) {
+		upperCount--
+	}
+
+	for i := 0; i < upperCount; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+
+	return string(runes)
+}
+
 // turn a non-empty docstring into a formatted raw go string literal, with surrounding backticks.
 // inline backticks for code literals are turned into separate strings that are
 func renderDocString(s string) dst.Expr {
@@ -172,7 +201,10 @@ type typeDecl struct {
 type generator struct {
 	typeStack     []*typeDecl
 	decls         []dst.Decl
+	valueDecls    []dst.Decl
 	leadingPragma map[string]struct{}
+	// packagePath is the path of the package of the generated types
+	packagePath string
 }
 
 var _ ast.DeclarationVisitor[struct{}] = &generator{}
@@ -181,6 +213,10 @@ func (g *generator) addDecls(decls ...dst.Decl) {
 	g.decls = append(g.decls, decls...)
 }
 
+func (g *generator) addValueDecls(decls ...dst.Decl) {
+	g.valueDecls = append(g.valueDecls, decls...)
+}
+
 func (*generator) VisitVariableDeclaration(_ *ast.VariableDeclaration) struct{} {
 	panic("variable declarations are not supported")
 }
@@ -627,6 +663,10 @@ func (g *generator) VisitCompositeOrInterfaceDeclaration(decl ast.ConformingDecl
 		),
 	)
 
+	if !generateSimpleType && !isInterfaceType {
+		g.addValueConstructorDecls(typeDec)
+	}
+
 	memberDeclarations := typeDec.memberDeclarations
 
 	if len(memberDeclarations) > 0 {
@@ -766,6 +806,551 @@ func (g *generator) VisitCompositeOrInterfaceDeclaration(decl ast.ConformingDecl
 	return
 }
 
+// addValueConstructorDecls generates the interpreter-side value constructor for the given composite type,
+// if the type is a top-level structure which only declares fields.
+// Values of other types, e.g. with functions, require a host implementation, and are not supported.
+func (g *generator) addValueConstructorDecls(ty *typeDecl) {
+
+	if len(g.typeStack) != 1 {
+		return
+	}
+
+	switch ty.compositeKind {
+	case common.CompositeKindStructure:
+		break
+
+	case common.CompositeKindContract:
+		g.addContractValueConstructorDecls(ty)
+		return
+
+	default:
+		return
+	}
+
+	var fieldDeclarations []*ast.FieldDeclaration
+	for _, memberDeclaration := range ty.memberDeclarations {
+		fieldDeclaration, ok := memberDeclaration.(*ast.FieldDeclaration)
+		if !ok {
+			return
+		}
+		fieldDeclarations = append(fieldDeclarations, fieldDeclaration)
+	}
+
+	// Generates:
+	//
+	//   var fooTypeID = sema.FooType.ID()
+	//   var fooStaticType = ConvertSemaToStaticType(nil, sema.FooType) // unmetered
+	//   var fooFieldNames = []string{
+	//       sema.FooTypeBarFieldName,
+	//   }
+	//
+	//   func NewFooValue(gauge common.MemoryGauge, bar UInt64Value) Value {
+	//       return NewSimpleCompositeValue(
+	//           gauge,
+	//           fooTypeID,
+	//           fooStaticType,
+	//           fooFieldNames,
+	//           map[string]Value{
+	//               sema.FooTypeBarFieldName: bar,
+	//           },
+	//           nil,
+	//           nil,
+	//           nil,
+	//       )
+	//   }
+
+	fullTypeName := ty.fullTypeName
+	typeVarPrefix := unexportedName(fullTypeName)
+
+	typeIDVarName := typeVarPrefix + "TypeID"
+	staticTypeVarName := typeVarPrefix + "StaticType"
+	fieldNamesVarName := typeVarPrefix + "FieldNames"
+
+	fieldNameVar := func(fieldName string) dst.Expr {
+		return &dst.Ident{
+			Name: fieldNameVarName(fullTypeName, fieldName),
+			Path: g.packagePath,
+		}
+	}
+
+	typeIDDecl, staticTypeDecl := g.valueTypeDecls(fullTypeName, typeIDVarName, staticTypeVarName)
+
+	fieldNameExprs := make([]dst.Expr, 0, len(fieldDeclarations))
+	parameters := []*dst.Field{
+		goField("gauge", &dst.Ident{
+			Name: "MemoryGauge",
+			Path: commonPath,
+		}),
+	}
+	fieldValues := make([]dst.Expr, 0, len(fieldDeclarations))
+
+	for _, fieldDeclaration := range fieldDeclarations {
+		fieldName := fieldDeclaration.Identifier.Identifier
+
+		fieldNameExpr := fieldNameVar(fieldName)
+		fieldNameExpr.Decorations().Before = dst.NewLine
+		fieldNameExpr.Decorations().After = dst.NewLine
+		fieldNameExprs = append(fieldNameExprs, fieldNameExpr)
+
+		parameterName := fieldName
+		if token.IsKeyword(parameterName) {
+			parameterName += "_"
+		}
+
+		parameters = append(
+			parameters,
+			goField(
+				parameterName,
+				valueGoType(fieldDeclaration.TypeAnnotation.Type),
+			),
+		)
+
+		fieldValue := &dst.KeyValueExpr{
+			Key:   fieldNameVar(fieldName),
+			Value: dst.NewIdent(parameterName),
+		}
+		fieldValue.Decorations().Before = dst.NewLine
+		fieldValue.Decorations().After = dst.NewLine
+		fieldValues = append(fieldValues, fieldValue)
+	}
+
+	fieldNamesDecl := goVarDecl(
+		fieldNamesVarName,
+		&dst.CompositeLit{
+			Type: &dst.ArrayType{
+				Elt: dst.NewIdent("string"),
+			},
+			Elts: fieldNameExprs,
+		},
+	)
+
+	arguments := []dst.Expr{
+		dst.NewIdent("gauge"),
+		dst.NewIdent(typeIDVarName),
+		dst.NewIdent(staticTypeVarName),
+		dst.NewIdent(fieldNamesVarName),
+		&dst.CompositeLit{
+			Type: &dst.MapType{
+				Key:   dst.NewIdent("string"),
+				Value: interpreterIdent("Value"),
+			},
+			Elts: fieldValues,
+		},
+		dst.NewIdent("nil"),
+		dst.NewIdent("nil"),
+		dst.NewIdent("nil"),
+	}
+
+	for _, argument := range arguments {
+		argument.Decorations().Before = dst.NewLine
+		argument.Decorations().After = dst.NewLine
+	}
+
+	functionName := fmt.Sprintf("New%sValue", fullTypeName)
+
+	functionDecl := &dst.FuncDecl{
+		Name: dst.NewIdent(functionName),
+		Type: &dst.FuncType{
+			Params: &dst.FieldList{
+				List: parameters,
+			},
+			Results: &dst.FieldList{
+				List: []*dst.Field{
+					{
+						Type: interpreterIdent("Value"),
+					},
+				},
+			},
+		},
+		Body: &dst.BlockStmt{
+			List: []dst.Stmt{
+				&dst.ReturnStmt{
+					Results: []dst.Expr{
+						&dst.CallExpr{
+							Fun:  interpreterIdent("NewSimpleCompositeValue"),
+							Args: arguments,
+						},
+					},
+				},
+			},
+		},
+	}
+	functionDecl.Decorations().Start.Append(
+		fmt.Sprintf("// %s returns a new value of the built-in type %s", functionName, ty.typeName),
+	)
+
+	g.addValueDecls(
+		typeIDDecl,
+		staticTypeDecl,
+		fieldNamesDecl,
+		functionDecl,
+	)
+}
+
+// addContractValueConstructorDecls generates the interpreter-side value constructor for the given contract type,
+// if the contract only declares native functions.
+// The implementations of the native functions are provided by the host, through a table of host functions
+func (g *generator) addContractValueConstructorDecls(ty *typeDecl) {
+
+	var functionDeclarations []*ast.FunctionDeclaration
+	for _, memberDeclaration := range ty.memberDeclarations {
+		functionDeclaration, ok := memberDeclaration.(*ast.FunctionDeclaration)
+		if !ok || !functionDeclaration.IsNative() {
+			return
+		}
+		functionDeclarations = append(functionDeclarations, functionDeclaration)
+	}
+
+	if len(functionDeclarations) == 0 {
+		return
+	}
+
+	// Generates:
+	//
+	//   type FooContractFunctions struct {
+	//       Bar interpreter.HostFunction
+	//   }
+	//
+	//   var fooTypeID = FooType.ID()
+	//   var FooTypeStaticType = interpreter.ConvertSemaToStaticType(nil, FooType) // unmetered
+	//
+	//   func NewFooContractValue(gauge common.MemoryGauge, functions FooContractFunctions) *interpreter.SimpleCompositeValue {
+	//       if functions.Bar == nil {
+	//           panic(errors.NewUnexpectedError("missing implementation of native function Foo.bar"))
+	//       }
+	//
+	//       return interpreter.NewSimpleCompositeValue(
+	//           gauge,
+	//           fooTypeID,
+	//           FooTypeStaticType,
+	//           nil,
+	//           map[string]interpreter.Value{
+	//               FooTypeBarFunctionName: interpreter.NewStaticHostFunctionValue(
+	//                   gauge,
+	//                   FooTypeBarFunctionType,
+	//                   functions.Bar,
+	//               ),
+	//           },
+	//           nil,
+	//           nil,
+	//           nil,
+	//       )
+	//   }
+
+	fullTypeName := ty.fullTypeName
+	typeVarPrefix := unexportedName(fullTypeName)
+
+	typeIDVarName := typeVarPrefix + "TypeID"
+	// The static type is exported, so embedders can refer to the type of the contract value
+	staticTypeVarName := typeVarName(fullTypeName) + "StaticType"
+	functionsTypeName := fmt.Sprintf("%sContractFunctions", fullTypeName)
+	functionName := fmt.Sprintf("New%sContractValue", fullTypeName)
+
+	const functionsParameterName = "functions"
+
+	typeIDDecl, staticTypeDecl := g.valueTypeDecls(fullTypeName, typeIDVarName, staticTypeVarName)
+	staticTypeDecl.Decorations().After = dst.EmptyLine
+
+	functionsFields := make([]*dst.Field, 0, len(functionDeclarations))
+	checkStmts := make([]dst.Stmt, 0, len(functionDeclarations))
+	fieldValues := make([]dst.Expr, 0, len(functionDeclarations))
+
+	for _, functionDeclaration := range functionDeclarations {
+		memberName := functionDeclaration.Identifier.Identifier
+		functionsFieldName := initialUpper(memberName)
+
+		functionsField := goField(functionsFieldName, interpreterIdent("HostFunction"))
+		functionsField.Decorations().Before = dst.NewLine
+		functionsField.Decorations().After = dst.NewLine
+		functionsFields = append(functionsFields, functionsField)
+
+		implementation := func() dst.Expr {
+			return &dst.SelectorExpr{
+				X:   dst.NewIdent(functionsParameterName),
+				Sel: dst.NewIdent(functionsFieldName),
+			}
+		}
+
+		checkStmt := &dst.IfStmt{
+			Cond: &dst.BinaryExpr{
+				X:  implementation(),
+				Op: token.EQL,
+				Y:  dst.NewIdent("nil"),
+			},
+			Body: &dst.BlockStmt{
+				List: []dst.Stmt{
+					&dst.ExprStmt{
+						X: &dst.CallExpr{
+							Fun: dst.NewIdent("panic"),
+							Args: []dst.Expr{
+								&dst.CallExpr{
+									Fun: &dst.Ident{
+										Name: "NewUnexpectedError",
+										Path: errorsPath,
+									},
+									Args: []dst.Expr{
+										goStringLit(fmt.Sprintf(
+											"missing implementation of native function %s.%s",
+											ty.typeName,
+											memberName,
+										)),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		checkStmt.Decorations().After = dst.EmptyLine
+		checkStmts = append(checkStmts, checkStmt)
+
+		hostFunctionArguments := []dst.Expr{
+			dst.NewIdent("gauge"),
+			&dst.Ident{
+				Name: functionTypeVarName(fullTypeName, memberName),
+				Path: g.packagePath,
+			},
+			implementation(),
+		}
+		for _, argument := range hostFunctionArguments {
+			argument.Decorations().Before = dst.NewLine
+			argument.Decorations().After = dst.NewLine
+		}
+
+		fieldValue := &dst.KeyValueExpr{
+			Key: &dst.Ident{
+				Name: functionNameVarName(fullTypeName, memberName),
+				Path: g.packagePath,
+			},
+			Value: &dst.CallExpr{
+				Fun:  interpreterIdent("NewStaticHostFunctionValue"),
+				Args: hostFunctionArguments,
+			},
+		}
+		fieldValue.Decorations().Before = dst.NewLine
+		fieldValue.Decorations().After = dst.NewLine
+		fieldValues = append(fieldValues, fieldValue)
+	}
+
+	functionsTypeDecl := &dst.GenDecl{
+		Tok: token.TYPE,
+		Specs: []dst.Spec{
+			&dst.TypeSpec{
+				Name: dst.NewIdent(functionsTypeName),
+				Type: &dst.StructType{
+					Fields: &dst.FieldList{
+						List: functionsFields,
+					},
+				},
+			},
+		},
+	}
+	functionsTypeDecl.Decorations().Start.Append(
+		fmt.Sprintf(
+			"// %s are the implementations of the native functions of the built-in contract %s",
+			functionsTypeName,
+			ty.typeName,
+		),
+	)
+	functionsTypeDecl.Decorations().After = dst.EmptyLine
+
+	arguments := []dst.Expr{
+		dst.NewIdent("gauge"),
+		dst.NewIdent(typeIDVarName),
+		dst.NewIdent(staticTypeVarName),
+		dst.NewIdent("nil"),
+		&dst.CompositeLit{
+			Type: &dst.MapType{
+				Key:   dst.NewIdent("string"),
+				Value: interpreterIdent("Value"),
+			},
+			Elts: fieldValues,
+		},
+		dst.NewIdent("nil"),
+		dst.NewIdent("nil"),
+		dst.NewIdent("nil"),
+	}
+
+	for _, argument := range arguments {
+		argument.Decorations().Before = dst.NewLine
+		argument.Decorations().After = dst.NewLine
+	}
+
+	bodyStmts := append(
+		checkStmts,
+		&dst.ReturnStmt{
+			Results: []dst.Expr{
+				&dst.CallExpr{
+					Fun:  interpreterIdent("NewSimpleCompositeValue"),
+					Args: arguments,
+				},
+			},
+		},
+	)
+
+	functionDecl := &dst.FuncDecl{
+		Name: dst.NewIdent(functionName),
+		Type: &dst.FuncType{
+			Params: &dst.FieldList{
+				List: []*dst.Field{
+					goField("gauge", &dst.Ident{
+						Name: "MemoryGauge",
+						Path: commonPath,
+					}),
+					goField(functionsParameterName, dst.NewIdent(functionsTypeName)),
+				},
+			},
+			Results: &dst.FieldList{
+				List: []*dst.Field{
+					{
+						Type: &dst.StarExpr{
+							X: interpreterIdent("SimpleCompositeValue"),
+						},
+					},
+				},
+			},
+		},
+		Body: &dst.BlockStmt{
+			List: bodyStmts,
+		},
+	}
+	functionDecl.Decorations().Start.Append(
+		fmt.Sprintf(
+			"// %s returns a new value of the built-in contract %s,",
+			functionName,
+			ty.typeName,
+		),
+		"// with the given implementations of its native functions",
+	)
+
+	g.addValueDecls(
+		functionsTypeDecl,
+		typeIDDecl,
+		staticTypeDecl,
+		functionDecl,
+	)
+}
+
+// valueTypeDecls generates the declarations of the type ID and the static type of the given type
+func (g *generator) valueTypeDecls(
+	fullTypeName string,
+	typeIDVarName string,
+	staticTypeVarName string,
+) (
+	typeIDDecl *dst.GenDecl,
+	staticTypeDecl *dst.GenDecl,
+) {
+	typeIDDecl = goVarDecl(
+		typeIDVarName,
+		&dst.CallExpr{
+			Fun: &dst.SelectorExpr{
+				X:   g.qualifiedTypeVarIdent(fullTypeName),
+				Sel: dst.NewIdent("ID"),
+			},
+		},
+	)
+	typeIDDecl.Decorations().After = dst.NewLine
+
+	staticTypeDecl = goVarDecl(
+		staticTypeVarName,
+		&dst.CallExpr{
+			Fun: interpreterIdent("ConvertSemaToStaticType"),
+			Args: []dst.Expr{
+				dst.NewIdent("nil"),
+				g.qualifiedTypeVarIdent(fullTypeName),
+			},
+		},
+	)
+	staticTypeDecl.Decorations().End.Append("// unmetered")
+	staticTypeDecl.Decorations().After = dst.NewLine
+
+	return
+}
+
+// qualifiedTypeVarIdent returns the identifier of the variable of the given generated type,
+// qualified with the package of the generated types
+func (g *generator) qualifiedTypeVarIdent(fullTypeName string) *dst.Ident {
+	return &dst.Ident{
+		Name: typeVarName(fullTypeName),
+		Path: g.packagePath,
+	}
+}
+
+func interpreterIdent(name string) *dst.Ident {
+	return &dst.Ident{
+		Name: name,
+		Path: interpreterPath,
+	}
+}
+
+// valueGoTypeNames are the names of the interpreter value types
+// for the Cadence types which have a dedicated value type
+var valueGoTypeNames = map[string]string{
+	"Address":   "AddressValue",
+	"Bool":      "BoolValue",
+	"Character": "CharacterValue",
+	"Path":      "PathValue",
+	"String":    "*StringValue",
+	"Int":       "IntValue",
+	"Int8":      "Int8Value",
+	"Int16":     "Int16Value",
+	"Int32":     "Int32Value",
+	"Int64":     "Int64Value",
+	"Int128":    "Int128Value",
+	"Int256":    "Int256Value",
+	"UInt":      "UIntValue",
+	"UInt8":     "UInt8Value",
+	"UInt16":    "UInt16Value",
+	"UInt32":    "UInt32Value",
+	"UInt64":    "UInt64Value",
+	"UInt128":   "UInt128Value",
+	"UInt256":   "UInt256Value",
+	"Word8":     "Word8Value",
+	"Word16":    "Word16Value",
+	"Word32":    "Word32Value",
+	"Word64":    "Word64Value",
+	"Word128":   "Word128Value",
+	"Word256":   "Word256Value",
+	"Fix64":     "Fix64Value",
+	"UFix64":    "UFix64Value",
+}
+
+// valueGoType returns the interpreter value type for values of the given Cadence type.
+// Values of types without a dedicated value type, e.g. composites, are typed as Value
+func valueGoType(t ast.Type) dst.Expr {
+	switch t := t.(type) {
+	case *ast.NominalType:
+		if len(t.NestedIdentifiers) == 0 {
+			if name, ok := valueGoTypeNames[t.Identifier.Identifier]; ok {
+				if pointee, ok := strings.CutPrefix(name, "*"); ok {
+					return &dst.StarExpr{
+						X: interpreterIdent(pointee),
+					}
+				}
+				return interpreterIdent(name)
+			}
+		}
+
+	case *ast.OptionalType:
+		return interpreterIdent("OptionalValue")
+
+	case *ast.VariableSizedType,
+		*ast.ConstantSizedType:
+
+		return &dst.StarExpr{
+			X: interpreterIdent("ArrayValue"),
+		}
+
+	case *ast.DictionaryType:
+		return &dst.StarExpr{
+			X: interpreterIdent("DictionaryValue"),
+		}
+	}
+
+	return interpreterIdent("Value")
+}
+
 func (g *generator) VisitCompositeDeclaration(decl *ast.CompositeDeclaration) (_ struct{}) {
 	return g.VisitCompositeOrInterfaceDeclaration(decl)
 }
@@ -1540,7 +2125,7 @@ func goBoolLit(b bool) dst.Expr {
 
 func compositeKindExpr(compositeKind common.CompositeKind) *dst.Ident {
 	return &dst.Ident{
-		Path: "github.com/onflow/cadence/common",
+		Path: commonPath,
 		Name: compositeKind.String(),
 	}
 }
@@ -2296,10 +2881,18 @@ func parseCadenceFile(path string) *ast.Program {
 	return program
 }
 
-func gen(inPath string, outFile *os.File, packagePath string) {
+func gen(
+	inPath string,
+	outFile *os.File,
+	packagePath string,
+	valuesOutFile *os.File,
+	valuesPackagePath string,
+) {
 	program := parseCadenceFile(inPath)
 
-	var gen generator
+	gen := generator{
+		packagePath: packagePath,
+	}
 
 	for _, declaration := range program.Declarations() {
 		generateDeclaration(&gen, declaration)
@@ -2308,6 +2901,14 @@ func gen(inPath string, outFile *os.File, packagePath string) {
 	gen.generateTypeInit(program)
 
 	writeGoFile(inPath, outFile, gen.decls, packagePath)
+
+	if valuesOutFile != nil {
+		if len(gen.valueDecls) == 0 {
+			panic("no value constructors to generate: only top-level structures with fields are supported")
+		}
+
+		writeGoFile(inPath, valuesOutFile, gen.valueDecls, valuesPackagePath)
+	}
 }
 
 func generateDeclaration(gen *generator, declaration ast.Declaration) {
@@ -2368,5 +2969,14 @@ func main() {
 	}
 	defer outFile.Close()
 
-	gen(inPath, outFile, *packagePathFlag)
+	var valuesOutFile *os.File
+	if *valuesPathFlag != "" {
+		valuesOutFile, err = os.Create(*valuesPathFlag)
+		if err != nil {
+			panic(err)
+		}
+		defer valuesOutFile.Close()
+	}
+
+	gen(inPath, outFile, *packagePathFlag, valuesOutFile, *valuesPackagePathFlag)
 }
@@ -34,6 +34,7 @@ const testDataDirectory = "testdata"
 // Each file turns into a test case.
 // Each input file is expected to have a "golden output" file,
 // with the same path, except the `.cdc` extension is replaced by `.golden.go`.
+// If a file with the extension `.values.golden.go` exists, value constructors are generated as well.
 func TestFiles(t *testing.T) {
 
 	t.Parallel()
@@ -50,20 +51,42 @@ func TestFiles(t *testing.T) {
 			defer outFile.Close()
 
 			inputPath := filepath.Join(dirPath, "test.cdc")
+			packagePath := "github.com/onflow/cadence/sema/gen/" + dirPath
 
-			gen(inputPath, outFile, "github.com/onflow/cadence/sema/gen/"+dirPath)
+			// If a golden output file for value constructors exists,
+			// also generate the value constructors, into the same package
 
-			goldenPath := filepath.Join(dirPath, "test.golden.go")
-			want, err := os.ReadFile(goldenPath)
-			require.NoError(t, err)
+			valuesGoldenPath := filepath.Join(dirPath, "test.values.golden.go")
+			_, err = os.Stat(valuesGoldenPath)
+			generateValues := err == nil
 
-			_, err = outFile.Seek(0, io.SeekStart)
-			require.NoError(t, err)
+			var valuesOutFile *os.File
+			if generateValues {
+				valuesOutFile, err = os.CreateTemp(t.TempDir(), "gen.values.*.go")
+				require.NoError(t, err)
+				defer valuesOutFile.Close()
+			}
 
-			got, err := io.ReadAll(outFile)
-			require.NoError(t, err)
+			gen(inputPath, outFile, packagePath, valuesOutFile, packagePath)
+
+			requireGolden := func(goldenPath string, outFile *os.File) {
+				want, err := os.ReadFile(goldenPath)
+				require.NoError(t, err)
+
+				_, err = outFile.Seek(0, io.SeekStart)
+				require.NoError(t, err)
+
+				got, err := io.ReadAll(outFile)
+				require.NoError(t, err)
+
+				require.Equal(t, string(want), string(got))
+			}
+
+			requireGolden(filepath.Join(dirPath, "test.golden.go"), outFile)
 
-			require.Equal(t, string(want), string(got))
+			if generateValues {
+				requireGolden(valuesGoldenPath, valuesOutFile)
+			}
 		})
 	}
 
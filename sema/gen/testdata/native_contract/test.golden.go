@@ -0,0 +1,105 @@
+// Code generated from testdata/native_contract/test.cdc. DO NOT EDIT.
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package native_contract
+
+import (
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/sema"
+)
+
+const TestTypeIdentityFunctionName = "identity"
+
+var TestTypeIdentityFunctionType = &sema.FunctionType{
+	Purity: sema.FunctionPurityView,
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "value",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.IntType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.IntType,
+	),
+}
+
+const TestTypeIdentityFunctionDocString = `
+Returns the given value.
+`
+
+const TestTypeAddFunctionName = "add"
+
+var TestTypeAddFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "a",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.IntType),
+		},
+		{
+			Label:          sema.ArgumentLabelNotRequired,
+			Identifier:     "b",
+			TypeAnnotation: sema.NewTypeAnnotation(sema.IntType),
+		},
+	},
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		sema.IntType,
+	),
+}
+
+const TestTypeAddFunctionDocString = `
+Returns the sum of the given values.
+`
+
+const TestTypeName = "Test"
+
+var TestType = func() *sema.CompositeType {
+	var t = &sema.CompositeType{
+		Identifier:         TestTypeName,
+		Kind:               common.CompositeKindContract,
+		ImportableBuiltin:  false,
+		HasComputedMembers: true,
+	}
+
+	return t
+}()
+
+func init() {
+	var members = []*sema.Member{
+		sema.NewUnmeteredFunctionMember(
+			TestType,
+			sema.PrimitiveAccess(ast.AccessAll),
+			TestTypeIdentityFunctionName,
+			TestTypeIdentityFunctionType,
+			TestTypeIdentityFunctionDocString,
+		),
+		sema.NewUnmeteredFunctionMember(
+			TestType,
+			sema.PrimitiveAccess(ast.AccessAll),
+			TestTypeAddFunctionName,
+			TestTypeAddFunctionType,
+			TestTypeAddFunctionDocString,
+		),
+	}
+
+	TestType.Members = sema.MembersAsMap(members)
+	TestType.Fields = sema.MembersFieldNames(members)
+}
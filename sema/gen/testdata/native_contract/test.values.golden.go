@@ -0,0 +1,69 @@
+// Code generated from testdata/native_contract/test.cdc. DO NOT EDIT.
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package native_contract
+
+import (
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/interpreter"
+)
+
+// TestContractFunctions are the implementations of the native functions of the built-in contract Test
+type TestContractFunctions struct {
+	Identity interpreter.HostFunction
+	Add      interpreter.HostFunction
+}
+
+var testTypeID = TestType.ID()
+var TestTypeStaticType = interpreter.ConvertSemaToStaticType(nil, TestType) // unmetered
+
+// NewTestContractValue returns a new value of the built-in contract Test,
+// with the given implementations of its native functions
+func NewTestContractValue(gauge common.MemoryGauge, functions TestContractFunctions) *interpreter.SimpleCompositeValue {
+	if functions.Identity == nil {
+		panic(errors.NewUnexpectedError("missing implementation of native function Test.identity"))
+	}
+
+	if functions.Add == nil {
+		panic(errors.NewUnexpectedError("missing implementation of native function Test.add"))
+	}
+
+	return interpreter.NewSimpleCompositeValue(
+		gauge,
+		testTypeID,
+		TestTypeStaticType,
+		nil,
+		map[string]interpreter.Value{
+			TestTypeIdentityFunctionName: interpreter.NewStaticHostFunctionValue(
+				gauge,
+				TestTypeIdentityFunctionType,
+				functions.Identity,
+			),
+			TestTypeAddFunctionName: interpreter.NewStaticHostFunctionValue(
+				gauge,
+				TestTypeAddFunctionType,
+				functions.Add,
+			),
+		},
+		nil,
+		nil,
+		nil,
+	)
+}
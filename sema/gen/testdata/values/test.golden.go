@@ -0,0 +1,131 @@
+// Code generated from testdata/values/test.cdc. DO NOT EDIT.
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package values
+
+import (
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/sema"
+)
+
+const TestTypeNameFieldName = "name"
+
+var TestTypeNameFieldType = sema.StringType
+
+const TestTypeNameFieldDocString = `
+The name.
+`
+
+const TestTypeCountFieldName = "count"
+
+var TestTypeCountFieldType = sema.UInt64Type
+
+const TestTypeCountFieldDocString = `
+The count.
+`
+
+const TestTypeFlagsFieldName = "flags"
+
+var TestTypeFlagsFieldType = &sema.VariableSizedType{
+	Type: sema.BoolType,
+}
+
+const TestTypeFlagsFieldDocString = `
+The flags.
+`
+
+const TestTypeParentFieldName = "parent"
+
+var TestTypeParentFieldType = &sema.OptionalType{
+	Type: TestType,
+}
+
+const TestTypeParentFieldDocString = `
+The parent.
+`
+
+const TestTypeTypeFieldName = "type"
+
+var TestTypeTypeFieldType = sema.MetaType
+
+const TestTypeTypeFieldDocString = `
+The type.
+`
+
+const TestTypeName = "Test"
+
+var TestType = func() *sema.CompositeType {
+	var t = &sema.CompositeType{
+		Identifier:         TestTypeName,
+		Kind:               common.CompositeKindStructure,
+		ImportableBuiltin:  false,
+		HasComputedMembers: true,
+	}
+
+	return t
+}()
+
+func init() {
+	var members = []*sema.Member{
+		sema.NewUnmeteredFieldMember(
+			TestType,
+			sema.PrimitiveAccess(ast.AccessAll),
+			ast.VariableKindConstant,
+			TestTypeNameFieldName,
+			TestTypeNameFieldType,
+			TestTypeNameFieldDocString,
+		),
+		sema.NewUnmeteredFieldMember(
+			TestType,
+			sema.PrimitiveAccess(ast.AccessAll),
+			ast.VariableKindConstant,
+			TestTypeCountFieldName,
+			TestTypeCountFieldType,
+			TestTypeCountFieldDocString,
+		),
+		sema.NewUnmeteredFieldMember(
+			TestType,
+			sema.PrimitiveAccess(ast.AccessAll),
+			ast.VariableKindConstant,
+			TestTypeFlagsFieldName,
+			TestTypeFlagsFieldType,
+			TestTypeFlagsFieldDocString,
+		),
+		sema.NewUnmeteredFieldMember(
+			TestType,
+			sema.PrimitiveAccess(ast.AccessAll),
+			ast.VariableKindConstant,
+			TestTypeParentFieldName,
+			TestTypeParentFieldType,
+			TestTypeParentFieldDocString,
+		),
+		sema.NewUnmeteredFieldMember(
+			TestType,
+			sema.PrimitiveAccess(ast.AccessAll),
+			ast.VariableKindConstant,
+			TestTypeTypeFieldName,
+			TestTypeTypeFieldType,
+			TestTypeTypeFieldDocString,
+		),
+	}
+
+	TestType.Members = sema.MembersAsMap(members)
+	TestType.Fields = sema.MembersFieldNames(members)
+}
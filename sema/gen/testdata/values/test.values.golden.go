@@ -0,0 +1,55 @@
+// Code generated from testdata/values/test.cdc. DO NOT EDIT.
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package values
+
+import (
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+)
+
+var testTypeID = TestType.ID()
+var testStaticType = interpreter.ConvertSemaToStaticType(nil, TestType) // unmetered
+var testFieldNames = []string{
+	TestTypeNameFieldName,
+	TestTypeCountFieldName,
+	TestTypeFlagsFieldName,
+	TestTypeParentFieldName,
+	TestTypeTypeFieldName,
+}
+
+// NewTestValue returns a new value of the built-in type Test
+func NewTestValue(gauge common.MemoryGauge, name *interpreter.StringValue, count interpreter.UInt64Value, flags *interpreter.ArrayValue, parent interpreter.OptionalValue, type_ interpreter.Value) interpreter.Value {
+	return interpreter.NewSimpleCompositeValue(
+		gauge,
+		testTypeID,
+		testStaticType,
+		testFieldNames,
+		map[string]interpreter.Value{
+			TestTypeNameFieldName:   name,
+			TestTypeCountFieldName:  count,
+			TestTypeFlagsFieldName:  flags,
+			TestTypeParentFieldName: parent,
+			TestTypeTypeFieldName:   type_,
+		},
+		nil,
+		nil,
+		nil,
+	)
+}
@@ -0,0 +1,473 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"golang.org/x/exp/slices"
+
+	"github.com/onflow/cadence/ast"
+)
+
+// incrementalChecking is the state required for re-checking declarations
+// of an already checked program, see Checker.Recheck
+type incrementalChecking struct {
+	dependencies *DeclarationDependencies
+	functions    map[*ast.FunctionDeclaration]*checkedFunction
+	// containers are the composite declarations currently being checked
+	containers []ast.CompositeLikeDeclaration
+	// topLevelIndex is the index of the top-level declaration currently being checked
+	topLevelIndex int
+	// lastVariableDeclarationIndex is the index of the last top-level variable declaration.
+	// Global variables are only visible in declarations following them
+	lastVariableDeclarationIndex int
+	// declarationErrorCount is the number of errors reported
+	// before the top-level declarations were checked, e.g. for function signatures
+	declarationErrorCount int
+	rechecking            bool
+}
+
+func newIncrementalChecking() *incrementalChecking {
+	return &incrementalChecking{
+		functions:                    map[*ast.FunctionDeclaration]*checkedFunction{},
+		lastVariableDeclarationIndex: -1,
+	}
+}
+
+// checkedFunction is a checked global function or composite member function
+type checkedFunction struct {
+	identifier     string
+	access         ast.Access
+	argumentLabels []string
+	containers     []ast.CompositeLikeDeclaration
+	topLevelIndex  int
+	// index is the position of the function in the checking order
+	index       int
+	diagnostics diagnosticsRange
+}
+
+// diagnosticsRange is the range of errors and warnings reported for a declaration
+type diagnosticsRange struct {
+	errorsStart   int
+	errorsEnd     int
+	warningsStart int
+	warningsEnd   int
+}
+
+func (checker *Checker) checkTopLevelDeclaration(index int, declaration ast.Declaration) {
+	state := checker.incrementalChecking
+	if state == nil {
+		checker.checkTopLevelDeclarationWithMetrics(declaration)
+		return
+	}
+
+	state.topLevelIndex = index
+
+	switch declaration := declaration.(type) {
+	case *ast.FunctionDeclaration:
+		function := checker.beginFunctionDiagnostics(declaration)
+		checker.checkTopLevelDeclarationWithMetrics(declaration)
+		checker.endFunctionDiagnostics(function)
+		return
+
+	case *ast.VariableDeclaration:
+		state.lastVariableDeclarationIndex = index
+	}
+
+	checker.checkTopLevelDeclarationWithMetrics(declaration)
+}
+
+// beginFunctionDiagnostics starts recording the errors and warnings reported for the given function declaration,
+// so it can be re-checked later.
+// It returns nil if incremental checking is not enabled, or if the function is currently re-checked
+func (checker *Checker) beginFunctionDiagnostics(declaration *ast.FunctionDeclaration) *checkedFunction {
+	state := checker.incrementalChecking
+	if state == nil || state.rechecking {
+		return nil
+	}
+
+	function := &checkedFunction{
+		identifier:     declaration.Identifier.Identifier,
+		access:         declaration.Access,
+		argumentLabels: declaration.ParameterList.EffectiveArgumentLabels(),
+		containers:     append([]ast.CompositeLikeDeclaration(nil), state.containers...),
+		topLevelIndex:  state.topLevelIndex,
+		index:          len(state.functions),
+		diagnostics: diagnosticsRange{
+			errorsStart:   len(checker.errors),
+			warningsStart: len(checker.warnings),
+		},
+	}
+
+	state.functions[declaration] = function
+
+	return function
+}
+
+func (checker *Checker) endFunctionDiagnostics(function *checkedFunction) {
+	if function == nil {
+		return
+	}
+
+	function.diagnostics.errorsEnd = len(checker.errors)
+	function.diagnostics.warningsEnd = len(checker.warnings)
+}
+
+func (checker *Checker) enterIncrementalCheckingContainer(declaration ast.CompositeLikeDeclaration) {
+	state := checker.incrementalChecking
+	if state == nil || state.rechecking {
+		return
+	}
+	state.containers = append(state.containers, declaration)
+}
+
+func (checker *Checker) leaveIncrementalCheckingContainer() {
+	state := checker.incrementalChecking
+	if state == nil || state.rechecking {
+		return
+	}
+	state.containers = state.containers[:len(state.containers)-1]
+}
+
+// DeclarationDependencies returns the dependency graph of the declarations of the checked program.
+// It returns nil if incremental checking is not enabled
+func (checker *Checker) DeclarationDependencies() *DeclarationDependencies {
+	state := checker.incrementalChecking
+	if state == nil {
+		return nil
+	}
+	if state.dependencies == nil {
+		state.dependencies = NewDeclarationDependencies(checker.Program)
+	}
+	return state.dependencies
+}
+
+// Recheck re-checks the given function declarations of the checked program,
+// after they were modified in place, e.g. after their function blocks were replaced.
+//
+// When incremental checking is enabled and only the function blocks of global functions
+// or composite member functions changed, only the given declarations are re-checked.
+// When the signature of a global function changed, the declarations depending on it
+// are re-checked as well, see DeclarationDependencies.
+// In all other cases, e.g. when unused declaration warnings or lint rules are enabled,
+// the whole program is re-checked.
+func (checker *Checker) Recheck(declarations ...*ast.FunctionDeclaration) error {
+	if !checker.IsChecked() {
+		return checker.Check()
+	}
+
+	if !checker.recheckIncrementally(declarations) {
+		err := checker.recheckProgram()
+		if err != nil {
+			return err
+		}
+	}
+
+	err := checker.CheckerError()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (checker *Checker) recheckProgram() error {
+	newChecker, err := NewChecker(
+		checker.Program,
+		checker.Location,
+		checker.memoryGauge,
+		checker.Config,
+	)
+	if err != nil {
+		return err
+	}
+
+	*checker = *newChecker
+
+	// NOTE: errors are returned by Recheck
+	_ = checker.Check()
+
+	return nil
+}
+
+// recheckIncrementally re-checks the given function declarations and their affected declarations.
+// It returns false if the declarations cannot be re-checked incrementally,
+// in which case the whole program must be re-checked
+func (checker *Checker) recheckIncrementally(declarations []*ast.FunctionDeclaration) bool {
+	state := checker.incrementalChecking
+	// Unused declaration warnings and lint rules may be reported for a whole composite or program,
+	// outside of the diagnostics of any function, so they cannot be updated incrementally
+
+	if state == nil ||
+		checker.PositionInfo != nil ||
+		checker.Config.ErrorShortCircuitingEnabled ||
+		checker.Config.UnusedDeclarationWarningsEnabled ||
+		len(checker.Config.LintRules) > 0 {
+
+		return false
+	}
+
+	dependencies := checker.DeclarationDependencies()
+
+	var affected []*ast.FunctionDeclaration
+	isAffected := map[*ast.FunctionDeclaration]struct{}{}
+
+	addAffected := func(declaration *ast.FunctionDeclaration) bool {
+		if _, ok := isAffected[declaration]; ok {
+			return true
+		}
+
+		function, ok := state.functions[declaration]
+		if !ok ||
+			function.identifier != declaration.Identifier.Identifier ||
+			function.access != declaration.Access ||
+			function.topLevelIndex < state.lastVariableDeclarationIndex {
+
+			return false
+		}
+
+		isAffected[declaration] = struct{}{}
+		affected = append(affected, declaration)
+		return true
+	}
+
+	for _, declaration := range declarations {
+		if !addAffected(declaration) {
+			return false
+		}
+		dependencies.Update(declaration)
+	}
+
+	// Determine the functions with changed signatures.
+	// Only the signatures of global functions may change,
+	// as the types of member functions are also used in e.g. conformance checks
+
+	type changedFunctionType struct {
+		declaration  *ast.FunctionDeclaration
+		functionType *FunctionType
+	}
+
+	var changedFunctionTypes []changedFunctionType
+
+	for _, declaration := range declarations {
+		function := state.functions[declaration]
+
+		functionType, ok := checker.recheckFunctionType(declaration, function)
+		if !ok {
+			return false
+		}
+
+		if functionType == nil {
+			continue
+		}
+
+		if len(function.containers) > 0 || state.declarationErrorCount > 0 {
+			return false
+		}
+
+		changedFunctionTypes = append(
+			changedFunctionTypes,
+			changedFunctionType{
+				declaration:  declaration,
+				functionType: functionType,
+			},
+		)
+
+		for _, dependent := range dependencies.Dependents(declaration) {
+			dependentFunction, ok := dependent.(*ast.FunctionDeclaration)
+			if !ok || !addAffected(dependentFunction) {
+				return false
+			}
+		}
+	}
+
+	checker.Elaboration.setIsChecking(true)
+	state.rechecking = true
+	checker.resources = NewResources()
+
+	for _, changed := range changedFunctionTypes {
+		declaration := changed.declaration
+		checker.redeclareGlobalFunction(declaration, changed.functionType)
+		state.functions[declaration].argumentLabels = declaration.ParameterList.EffectiveArgumentLabels()
+	}
+
+	for _, declaration := range affected {
+		checker.recheckFunction(declaration, state.functions[declaration])
+	}
+
+	checker.resources.Reclaim()
+	checker.resources = nil
+	state.rechecking = false
+	checker.Elaboration.setIsChecking(false)
+
+	return true
+}
+
+// recheckFunctionType determines the type of the given function declaration.
+// It returns the type if it changed, or nil if it did not change.
+// It returns false if the type is invalid
+func (checker *Checker) recheckFunctionType(
+	declaration *ast.FunctionDeclaration,
+	function *checkedFunction,
+) (
+	functionType *FunctionType,
+	ok bool,
+) {
+	errorCount := len(checker.errors)
+	warningCount := len(checker.warnings)
+
+	access := UnauthorizedAccess
+	if len(function.containers) > 0 {
+		access = checker.accessFromAstAccess(declaration.Access)
+	}
+
+	checker.inContainers(function.containers, func() {
+		functionType = checker.functionType(
+			declaration.IsNative(),
+			declaration.Purity,
+			access,
+			declaration.TypeParameterList,
+			declaration.ParameterList,
+			declaration.ReturnTypeAnnotation,
+		)
+	})
+
+	ok = len(checker.errors) == errorCount
+
+	// The diagnostics for the signature were already reported when the program was checked
+	checker.errors = checker.errors[:errorCount]
+	checker.warnings = checker.warnings[:warningCount]
+
+	if !ok {
+		return nil, false
+	}
+
+	if functionType.Equal(checker.Elaboration.FunctionDeclarationFunctionType(declaration)) &&
+		slices.Equal(declaration.ParameterList.EffectiveArgumentLabels(), function.argumentLabels) {
+
+		return nil, true
+	}
+
+	return functionType, true
+}
+
+func (checker *Checker) redeclareGlobalFunction(declaration *ast.FunctionDeclaration, functionType *FunctionType) {
+	checker.Elaboration.SetFunctionDeclarationFunctionType(declaration, functionType)
+
+	variable := checker.valueActivations.Find(declaration.Identifier.Identifier)
+	if variable == nil {
+		return
+	}
+	variable.Type = functionType
+	variable.ArgumentLabels = declaration.ParameterList.EffectiveArgumentLabels()
+	variable.DocString = declaration.DocString
+}
+
+func (checker *Checker) recheckFunction(declaration *ast.FunctionDeclaration, function *checkedFunction) {
+	errorCount := len(checker.errors)
+	warningCount := len(checker.warnings)
+
+	checker.inContainers(function.containers, func() {
+		containerCount := len(function.containers)
+		if containerCount == 0 {
+			ast.AcceptDeclaration[struct{}](declaration, checker)
+			return
+		}
+
+		container := function.containers[containerCount-1]
+		compositeType := checker.Elaboration.CompositeDeclarationType(container)
+
+		checker.checkCompositeFunctions(
+			[]*ast.FunctionDeclaration{declaration},
+			compositeType,
+			container.DeclarationDocString(),
+		)
+	})
+
+	errors := append([]error(nil), checker.errors[errorCount:]...)
+	warnings := append([]error(nil), checker.warnings[warningCount:]...)
+	checker.errors = checker.errors[:errorCount]
+	checker.warnings = checker.warnings[:warningCount]
+
+	checker.replaceFunctionDiagnostics(function, errors, warnings)
+}
+
+// inContainers calls the given function in the scopes of the given composite declarations
+func (checker *Checker) inContainers(containers []ast.CompositeLikeDeclaration, f func()) {
+	if len(containers) == 0 {
+		f()
+		return
+	}
+
+	container := containers[0]
+	compositeType := checker.Elaboration.CompositeDeclarationType(container)
+
+	checker.containerTypes[compositeType] = true
+	defer func() {
+		checker.containerTypes[compositeType] = false
+	}()
+
+	checker.typeActivations.Enter()
+	defer checker.typeActivations.Leave(container.EndPosition)
+
+	checker.enterValueScope()
+	defer checker.leaveValueScope(container.EndPosition, false)
+
+	// The diagnostics for the nested types were already reported when the program was checked
+	errorCount := len(checker.errors)
+	warningCount := len(checker.warnings)
+
+	checker.declareCompositeLikeNestedTypes(container, true)
+
+	checker.errors = checker.errors[:errorCount]
+	checker.warnings = checker.warnings[:warningCount]
+
+	checker.inContainers(containers[1:], f)
+}
+
+// replaceFunctionDiagnostics replaces the errors and warnings previously reported for the given function,
+// and updates the diagnostics ranges of the functions following it
+func (checker *Checker) replaceFunctionDiagnostics(function *checkedFunction, errors []error, warnings []error) {
+	oldRange := function.diagnostics
+
+	checker.errors = replaceRange(checker.errors, oldRange.errorsStart, oldRange.errorsEnd, errors)
+	checker.warnings = replaceRange(checker.warnings, oldRange.warningsStart, oldRange.warningsEnd, warnings)
+
+	errorsDelta := len(errors) - (oldRange.errorsEnd - oldRange.errorsStart)
+	warningsDelta := len(warnings) - (oldRange.warningsEnd - oldRange.warningsStart)
+
+	function.diagnostics.errorsEnd += errorsDelta
+	function.diagnostics.warningsEnd += warningsDelta
+
+	for _, other := range checker.incrementalChecking.functions { //nolint:maprange
+		if other.index <= function.index {
+			continue
+		}
+		other.diagnostics.errorsStart += errorsDelta
+		other.diagnostics.errorsEnd += errorsDelta
+		other.diagnostics.warningsStart += warningsDelta
+		other.diagnostics.warningsEnd += warningsDelta
+	}
+}
+
+func replaceRange(diagnostics []error, start, end int, replacement []error) []error {
+	result := make([]error, 0, len(diagnostics)-(end-start)+len(replacement))
+	result = append(result, diagnostics[:start]...)
+	result = append(result, replacement...)
+	result = append(result, diagnostics[end:]...)
+	return result
+}
@@ -0,0 +1,325 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func parseAndCheckIncrementally(t *testing.T, code string) (*sema.Checker, error) {
+	return ParseAndCheckWithOptions(t,
+		code,
+		ParseAndCheckOptions{
+			Config: &sema.Config{
+				IncrementalCheckingEnabled: true,
+			},
+		},
+	)
+}
+
+// replaceFunctionDeclaration replaces the given function declaration in place
+// with the first function declaration of the given code
+func replaceFunctionDeclaration(t *testing.T, declaration *ast.FunctionDeclaration, code string) {
+	program, err := parser.ParseProgram(nil, []byte(code), parser.Config{})
+	require.NoError(t, err)
+
+	functionDeclarations := program.FunctionDeclarations()
+	require.Len(t, functionDeclarations, 1)
+
+	*declaration = *functionDeclarations[0]
+}
+
+func globalFunctionDeclaration(t *testing.T, checker *sema.Checker, name string) *ast.FunctionDeclaration {
+	for _, declaration := range checker.Program.FunctionDeclarations() {
+		if declaration.Identifier.Identifier == name {
+			return declaration
+		}
+	}
+	require.FailNow(t, "missing function declaration", name)
+	return nil
+}
+
+func TestCheckRecheckGlobalFunctionBody(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := parseAndCheckIncrementally(t, `
+      fun f(): Int {
+          return "one"
+      }
+
+      fun g(): Int {
+          let x: Int = y
+          return f()
+      }
+    `)
+
+	errs := RequireCheckerErrors(t, err, 2)
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	assert.IsType(t, &sema.NotDeclaredError{}, errs[1])
+
+	elaboration := checker.Elaboration
+	f := globalFunctionDeclaration(t, checker, "f")
+
+	replaceFunctionDeclaration(t, f, `
+      fun f(): Int {
+          let a: Int = "a"
+          return true
+      }
+    `)
+
+	err = checker.Recheck(f)
+
+	errs = RequireCheckerErrors(t, err, 3)
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[1])
+	assert.IsType(t, &sema.NotDeclaredError{}, errs[2])
+
+	replaceFunctionDeclaration(t, f, `
+      fun f(): Int {
+          return 1
+      }
+    `)
+
+	err = checker.Recheck(f)
+
+	errs = RequireCheckerErrors(t, err, 1)
+	assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+
+	// Only the function was re-checked
+	assert.Same(t, elaboration, checker.Elaboration)
+}
+
+func TestCheckRecheckCompositeFunctionBody(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := parseAndCheckIncrementally(t, `
+      access(all) contract C {
+
+          access(all) resource R {}
+
+          access(all) fun make(): @R {
+              return <- create R()
+          }
+
+          access(all) fun f(): Int {
+              return 1
+          }
+      }
+    `)
+	require.NoError(t, err)
+
+	elaboration := checker.Elaboration
+
+	f := checker.Program.CompositeDeclarations()[0].Members.FunctionsByIdentifier()["f"]
+
+	replaceFunctionDeclaration(t, f, `
+      access(all) fun f(): Int {
+          let r: @R <- self.make()
+          return 2
+      }
+    `)
+
+	err = checker.Recheck(f)
+
+	errs := RequireCheckerErrors(t, err, 1)
+	assert.IsType(t, &sema.ResourceLossError{}, errs[0])
+
+	replaceFunctionDeclaration(t, f, `
+      access(all) fun f(): Int {
+          let r: @R <- self.make()
+          destroy r
+          return 2
+      }
+    `)
+
+	err = checker.Recheck(f)
+	require.NoError(t, err)
+
+	// Only the function was re-checked
+	assert.Same(t, elaboration, checker.Elaboration)
+}
+
+func TestCheckRecheckGlobalFunctionSignature(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := parseAndCheckIncrementally(t, `
+      fun f(): Int {
+          return 1
+      }
+
+      fun g(): Int {
+          return f()
+      }
+
+      fun h(): Int {
+          return 2
+      }
+    `)
+	require.NoError(t, err)
+
+	elaboration := checker.Elaboration
+	f := globalFunctionDeclaration(t, checker, "f")
+
+	replaceFunctionDeclaration(t, f, `
+      fun f(): String {
+          return "1"
+      }
+    `)
+
+	err = checker.Recheck(f)
+
+	errs := RequireCheckerErrors(t, err, 1)
+	assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+
+	// Only the function and its dependents were re-checked
+	assert.Same(t, elaboration, checker.Elaboration)
+}
+
+func TestCheckRecheckFallback(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("member function signature", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheckIncrementally(t, `
+          access(all) contract C {
+
+              access(all) fun f(): Int {
+                  return 1
+              }
+
+              access(all) fun g(): Int {
+                  return self.f()
+              }
+          }
+        `)
+		require.NoError(t, err)
+
+		elaboration := checker.Elaboration
+
+		f := checker.Program.CompositeDeclarations()[0].Members.FunctionsByIdentifier()["f"]
+
+		replaceFunctionDeclaration(t, f, `
+          access(all) fun f(): String {
+              return "1"
+          }
+        `)
+
+		err = checker.Recheck(f)
+
+		errs := RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+
+		// The whole program was re-checked
+		assert.NotSame(t, elaboration, checker.Elaboration)
+	})
+
+	t.Run("later global variable", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheckIncrementally(t, `
+          fun f(): Int {
+              return 1
+          }
+
+          let x = 1
+        `)
+		require.NoError(t, err)
+
+		elaboration := checker.Elaboration
+		f := globalFunctionDeclaration(t, checker, "f")
+
+		replaceFunctionDeclaration(t, f, `
+          fun f(): Int {
+              return x
+          }
+        `)
+
+		err = checker.Recheck(f)
+
+		errs := RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+
+		// The whole program was re-checked
+		assert.NotSame(t, elaboration, checker.Elaboration)
+	})
+
+	t.Run("unused declaration warnings", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheckWithOptions(t,
+			`
+              access(all) struct S {
+
+                  access(self) fun helper(): Int {
+                      return 1
+                  }
+
+                  access(all) fun f(): Int {
+                      return 2
+                  }
+              }
+            `,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					IncrementalCheckingEnabled:       true,
+					UnusedDeclarationWarningsEnabled: true,
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		warnings := checker.Warnings()
+		require.Len(t, warnings, 1)
+		assert.IsType(t, &sema.UnusedDeclarationWarning{}, warnings[0])
+
+		elaboration := checker.Elaboration
+
+		f := checker.Program.CompositeDeclarations()[0].Members.FunctionsByIdentifier()["f"]
+
+		replaceFunctionDeclaration(t, f, `
+          access(all) fun f(): Int {
+              return self.helper()
+          }
+        `)
+
+		err = checker.Recheck(f)
+		require.NoError(t, err)
+
+		assert.Empty(t, checker.Warnings())
+
+		// The whole program was re-checked
+		assert.NotSame(t, elaboration, checker.Elaboration)
+	})
+}
@@ -585,3 +585,64 @@ func TestCheckIntegerMinMax(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckIntegerLiteralSizeLimit(t *testing.T) {
+
+	t.Parallel()
+
+	check := func(t *testing.T, limit int, code string) error {
+		_, err := ParseAndCheckWithOptions(t,
+			code,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					IntegerLiteralBitLengthLimit: limit,
+				},
+			},
+		)
+		return err
+	}
+
+	// 2^64, 65 bits
+	const code = `let x = 18446744073709551616`
+
+	t.Run("unlimited", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := check(t, 0, code)
+		require.NoError(t, err)
+	})
+
+	t.Run("within limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := check(t, 65, code)
+		require.NoError(t, err)
+	})
+
+	t.Run("exceeds limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := check(t, 64, code)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		var sizeErr *sema.IntegerLiteralSizeLimitExceededError
+		require.ErrorAs(t, errs[0], &sizeErr)
+		assert.Equal(t, 65, sizeErr.BitLength)
+		assert.Equal(t, 64, sizeErr.Limit)
+	})
+
+	t.Run("negative, exceeds limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		err := check(t, 64, `let x = -18446744073709551616`)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.IntegerLiteralSizeLimitExceededError{}, errs[0])
+	})
+}
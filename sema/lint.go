@@ -0,0 +1,148 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/errors"
+)
+
+// LintRule is an additional rule, which is checked in the same pass as the program is type checked.
+//
+// Rules are notified about checked declarations and expressions,
+// and may report diagnostics, see LintContext.Report
+type LintRule struct {
+	// Name is the name of the rule, e.g. "redundant-cast"
+	Name string
+	// VisitDeclaration, if set, is called for each declaration after it was checked,
+	// including nested declarations, composite functions, and local declarations.
+	// Fields, enum cases, and special functions (e.g. initializers)
+	// are not visited separately, but as part of their containing declaration
+	VisitDeclaration func(context LintContext, declaration ast.Declaration)
+	// VisitExpression, if set, is called for each expression after it was checked,
+	// with the actual type of the expression
+	VisitExpression func(context LintContext, expression ast.Expression, ty Type)
+}
+
+// LintContext is the context in which a lint rule is checked
+type LintContext struct {
+	Checker *Checker
+	Rule    *LintRule
+}
+
+// Report reports the given diagnostic of the rule.
+//
+// Diagnostics with error severity are reported as checker errors, i.e. they cause checking to fail.
+// All other diagnostics are reported as warnings, see Checker.Warnings
+func (c LintContext) Report(diagnostic *LintDiagnostic) {
+	diagnostic.Rule = c.Rule.Name
+
+	if diagnostic.Severity == LintSeverityError {
+		c.Checker.report(diagnostic)
+	} else {
+		c.Checker.reportWarning(diagnostic)
+	}
+}
+
+// LintSeverity is the severity of a lint diagnostic
+type LintSeverity uint8
+
+const (
+	LintSeverityWarning LintSeverity = iota
+	LintSeverityError
+	LintSeverityInfo
+	LintSeverityHint
+)
+
+func (s LintSeverity) String() string {
+	switch s {
+	case LintSeverityWarning:
+		return "warning"
+	case LintSeverityError:
+		return "error"
+	case LintSeverityInfo:
+		return "info"
+	case LintSeverityHint:
+		return "hint"
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+// LintDiagnostic is a diagnostic reported by a lint rule
+type LintDiagnostic struct {
+	// Rule is the name of the rule which reported the diagnostic.
+	// It is set when the diagnostic is reported
+	Rule     string
+	Severity LintSeverity
+	// Code is the diagnostic code, e.g. "unnecessary-cast"
+	Code           string
+	Message        string
+	SuggestedFixes []errors.SuggestedFix[ast.TextEdit]
+	ast.Range
+}
+
+var _ SemanticError = &LintDiagnostic{}
+var _ errors.UserError = &LintDiagnostic{}
+var _ errors.HasSuggestedFixes[ast.TextEdit] = &LintDiagnostic{}
+
+func (*LintDiagnostic) isSemanticError() {}
+
+func (*LintDiagnostic) IsUserError() {}
+
+func (d *LintDiagnostic) Error() string {
+	return d.Message
+}
+
+func (d *LintDiagnostic) SuggestFixes(_ string) []errors.SuggestedFix[ast.TextEdit] {
+	return d.SuggestedFixes
+}
+
+func (checker *Checker) lintDeclaration(declaration ast.Declaration) {
+	for _, rule := range checker.Config.LintRules {
+		if rule.VisitDeclaration == nil {
+			continue
+		}
+
+		rule.VisitDeclaration(
+			LintContext{
+				Checker: checker,
+				Rule:    rule,
+			},
+			declaration,
+		)
+	}
+}
+
+func (checker *Checker) lintExpression(expression ast.Expression, ty Type) {
+	for _, rule := range checker.Config.LintRules {
+		if rule.VisitExpression == nil {
+			continue
+		}
+
+		rule.VisitExpression(
+			LintContext{
+				Checker: checker,
+				Rule:    rule,
+			},
+			expression,
+			ty,
+		)
+	}
+}
@@ -0,0 +1,213 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"fmt"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func TestCheckLintRules(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("declarations", func(t *testing.T) {
+		t.Parallel()
+
+		var visited []string
+
+		rule := &sema.LintRule{
+			Name: "record",
+			VisitDeclaration: func(_ sema.LintContext, declaration ast.Declaration) {
+				identifier := declaration.DeclarationIdentifier()
+				name := ""
+				if identifier != nil {
+					name = identifier.Identifier
+				}
+				visited = append(
+					visited,
+					fmt.Sprintf("%s %s", declaration.DeclarationKind().Name(), name),
+				)
+			},
+		}
+
+		_, err := ParseAndCheckWithOptions(t,
+			`
+              let x = 1
+
+              struct S {
+                  let y: Int
+
+                  init() {
+                      self.y = 2
+                  }
+
+                  fun f() {
+                      let z = 3
+                  }
+              }
+
+              fun g() {
+                  fun h() {}
+              }
+            `,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					LintRules: []*sema.LintRule{rule},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			[]string{
+				"constant x",
+				"constant z",
+				"function f",
+				"structure S",
+				"function h",
+				"function g",
+			},
+			visited,
+		)
+	})
+
+	t.Run("expressions, warning", func(t *testing.T) {
+		t.Parallel()
+
+		// Reports force unwraps of optional integers
+		rule := &sema.LintRule{
+			Name: "no-force-unwrap",
+			VisitExpression: func(context sema.LintContext, expression ast.Expression, ty sema.Type) {
+				forceExpression, ok := expression.(*ast.ForceExpression)
+				if !ok || ty != sema.IntType {
+					return
+				}
+
+				context.Report(&sema.LintDiagnostic{
+					Code:    "force-unwrap",
+					Message: "force unwrap of integer",
+					SuggestedFixes: []errors.SuggestedFix[ast.TextEdit]{
+						{
+							Message: "replace with nil-coalescing",
+							TextEdits: []ast.TextEdit{
+								{
+									Insertion: " ?? 0",
+									Range: ast.NewUnmeteredRange(
+										forceExpression.EndPos,
+										forceExpression.EndPos,
+									),
+								},
+							},
+						},
+					},
+					Range: ast.NewUnmeteredRangeFromPositioned(forceExpression),
+				})
+			},
+		}
+
+		checker, err := ParseAndCheckWithOptions(t,
+			`
+              let x: Int? = 1
+              let y = x!
+              let z: String? = "z"
+              let w = z!
+            `,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					LintRules: []*sema.LintRule{rule},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		warnings := checker.Warnings()
+		require.Len(t, warnings, 1)
+
+		var diagnostic *sema.LintDiagnostic
+		require.ErrorAs(t, warnings[0], &diagnostic)
+
+		assert.Equal(t, "no-force-unwrap", diagnostic.Rule)
+		assert.Equal(t, "force-unwrap", diagnostic.Code)
+		assert.Equal(t, sema.LintSeverityWarning, diagnostic.Severity)
+		assert.Equal(t, "force unwrap of integer", diagnostic.Error())
+		assert.Equal(t, 3, diagnostic.StartPos.Line)
+		assert.Len(t, diagnostic.SuggestFixes(""), 1)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		// Requires function names to start with a lowercase letter
+		rule := &sema.LintRule{
+			Name: "function-names",
+			VisitDeclaration: func(context sema.LintContext, declaration ast.Declaration) {
+				functionDeclaration, ok := declaration.(*ast.FunctionDeclaration)
+				if !ok {
+					return
+				}
+
+				identifier := functionDeclaration.Identifier
+				if unicode.IsLower([]rune(identifier.Identifier)[0]) {
+					return
+				}
+
+				context.Report(&sema.LintDiagnostic{
+					Severity: sema.LintSeverityError,
+					Message:  "function names must start with a lowercase letter",
+					Range: ast.NewUnmeteredRange(
+						identifier.StartPosition(),
+						identifier.EndPosition(nil),
+					),
+				})
+			},
+		}
+
+		checker, err := ParseAndCheckWithOptions(t,
+			`
+              fun valid() {}
+
+              fun Invalid() {}
+            `,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					LintRules: []*sema.LintRule{rule},
+				},
+			},
+		)
+
+		errs := RequireCheckerErrors(t, err, 1)
+
+		var diagnostic *sema.LintDiagnostic
+		require.ErrorAs(t, errs[0], &diagnostic)
+		assert.Equal(t, "function-names", diagnostic.Rule)
+		assert.Equal(t, 4, diagnostic.StartPos.Line)
+
+		assert.Empty(t, checker.Warnings())
+	})
+}
@@ -0,0 +1,120 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+// Member resolver cache
+//
+// Types cache their member resolvers per type instance (see GetMembers).
+// However, the checker creates a new instance for each occurrence of a container type,
+// e.g. for each array type annotation, or for the result of each `keys` access of a dictionary,
+// so the member resolvers of identical container types are built over and over again.
+//
+// The member resolvers of the container types below only depend on their component types,
+// so the checker caches them by the identities of the component types.
+// The cache is per checker, so it is dropped with the checker,
+// and component types of different programs never collide.
+
+type memberResolverCacheKind uint8
+
+const (
+	memberResolverCacheKindUnknown memberResolverCacheKind = iota
+	memberResolverCacheKindVariableSized
+	memberResolverCacheKindConstantSized
+	memberResolverCacheKindDictionary
+	memberResolverCacheKindOptional
+)
+
+type memberResolverCacheKey struct {
+	first  Type
+	second Type
+	size   int64
+	kind   memberResolverCacheKind
+}
+
+func memberResolverCacheKeyOf(ty Type) (memberResolverCacheKey, bool) {
+	switch ty := ty.(type) {
+	case *VariableSizedType:
+		if ty.Type == nil {
+			break
+		}
+		return memberResolverCacheKey{
+			kind:  memberResolverCacheKindVariableSized,
+			first: ty.Type,
+		}, true
+
+	case *ConstantSizedType:
+		if ty.Type == nil {
+			break
+		}
+		return memberResolverCacheKey{
+			kind:  memberResolverCacheKindConstantSized,
+			first: ty.Type,
+			size:  ty.Size,
+		}, true
+
+	case *DictionaryType:
+		if ty.KeyType == nil || ty.ValueType == nil {
+			break
+		}
+		return memberResolverCacheKey{
+			kind:   memberResolverCacheKindDictionary,
+			first:  ty.KeyType,
+			second: ty.ValueType,
+		}, true
+
+	case *OptionalType:
+		if ty.Type == nil {
+			break
+		}
+		return memberResolverCacheKey{
+			kind:  memberResolverCacheKindOptional,
+			first: ty.Type,
+		}, true
+	}
+
+	return memberResolverCacheKey{}, false
+}
+
+// memberResolvers returns the member resolvers of the given type,
+// reusing the member resolvers of an identical container type, if any.
+func (checker *Checker) memberResolvers(ty Type) map[string]MemberResolver {
+
+	// References have the members of the referenced type
+	if referenceType, ok := ty.(*ReferenceType); ok {
+		return checker.memberResolvers(referenceType.Type)
+	}
+
+	key, ok := memberResolverCacheKeyOf(ty)
+	if !ok {
+		return ty.GetMembers()
+	}
+
+	if resolvers, ok := checker.memberResolverCache[key]; ok {
+		return resolvers
+	}
+
+	resolvers := ty.GetMembers()
+
+	if checker.memberResolverCache == nil {
+		checker.memberResolverCache = map[memberResolverCacheKey]map[string]MemberResolver{}
+	}
+	checker.memberResolverCache[key] = resolvers
+
+	return resolvers
+}
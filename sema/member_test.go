@@ -20,13 +20,16 @@ package sema_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/parser"
 	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/common_utils"
 	. "github.com/onflow/cadence/test_utils/sema_utils"
 )
 
@@ -1011,3 +1014,80 @@ func TestCheckContractFieldAccessInSameContract(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+func memberResolutionBenchmarkProgram(compositeCount int) string {
+	var builder strings.Builder
+
+	builder.WriteString("access(all) contract C {\n")
+
+	for i := 0; i < compositeCount; i++ {
+		_, _ = fmt.Fprintf(&builder,
+			`
+              access(all) struct interface I%[1]d {
+                  access(all) let value: Int
+              }
+
+              access(all) struct Inner%[1]d: I%[1]d {
+                  access(all) let value: Int
+                  init() { self.value = %[1]d }
+              }
+
+              access(all) struct S%[1]d {
+                  access(all) var values: [Int]
+                  access(all) var names: {String: Int}
+                  access(all) var next: Inner%[1]d?
+
+                  init() {
+                      self.values = []
+                      self.names = {}
+                      self.next = nil
+                  }
+
+                  access(all) fun test(other: &S%[1]d): Int {
+                      self.values.append(other.values.length)
+                      self.values.insert(at: 0, self.names.keys.length)
+                      self.names.insert(key: self.values.length.toString(), 1)
+                      let inner: &{I%[1]d}? = self.next != nil ? &self.next! as &{I%[1]d} : nil
+                      return self.values.length
+                          + self.names.values.length
+                          + (self.next?.value ?? 0)
+                          + (inner?.value ?? 0)
+                          + other.values.firstIndex(of: 1)!
+                  }
+              }
+            `,
+			i,
+		)
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+func BenchmarkCheckMemberResolution(b *testing.B) {
+
+	code := memberResolutionBenchmarkProgram(100)
+
+	program, err := parser.ParseProgram(nil, []byte(code), parser.Config{})
+	require.NoError(b, err)
+
+	config := &sema.Config{
+		AccessCheckMode: sema.AccessCheckModeStrict,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		checker, err := sema.NewChecker(program, TestLocation, nil, config)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		err = checker.Check()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -89,6 +89,87 @@ const metaTypeContractNameFieldDocString = `
 The contract name of the type, if it was declared in a contract
 `
 
+const MetaTypeOptionalTypeFunctionName = "optionalType"
+
+var MetaTypeOptionalTypeFunctionType = NewSimpleFunctionType(
+	FunctionPurityView,
+	nil,
+	MetaTypeAnnotation,
+)
+
+const metaTypeOptionalTypeFunctionDocString = `
+Returns the optional type with this type as its inner type
+`
+
+const MetaTypeVariableSizedArrayTypeFunctionName = "variableSizedArrayType"
+
+var MetaTypeVariableSizedArrayTypeFunctionType = NewSimpleFunctionType(
+	FunctionPurityView,
+	nil,
+	MetaTypeAnnotation,
+)
+
+const metaTypeVariableSizedArrayTypeFunctionDocString = `
+Returns the variable-sized array type with this type as its element type
+`
+
+const MetaTypeConstantSizedArrayTypeFunctionName = "constantSizedArrayType"
+
+var MetaTypeConstantSizedArrayTypeFunctionType = NewSimpleFunctionType(
+	FunctionPurityView,
+	[]Parameter{
+		{
+			Identifier:     "size",
+			TypeAnnotation: IntTypeAnnotation,
+		},
+	},
+	MetaTypeAnnotation,
+)
+
+const metaTypeConstantSizedArrayTypeFunctionDocString = `
+Returns the constant-sized array type with this type as its element type and the given size
+`
+
+const MetaTypeDictionaryTypeFunctionName = "dictionaryType"
+
+var MetaTypeDictionaryTypeFunctionType = NewSimpleFunctionType(
+	FunctionPurityView,
+	[]Parameter{
+		{
+			Identifier:     "value",
+			TypeAnnotation: MetaTypeAnnotation,
+		},
+	},
+	OptionalMetaTypeAnnotation,
+)
+
+const metaTypeDictionaryTypeFunctionDocString = `
+Returns the dictionary type with this type as its key type and the given value type,
+or nil if this type is not a valid dictionary key type
+`
+
+const MetaTypeReferenceTypeFunctionName = "referenceType"
+
+var MetaTypeReferenceTypeFunctionType = NewSimpleFunctionType(
+	FunctionPurityView,
+	[]Parameter{
+		{
+			Identifier: "entitlements",
+			TypeAnnotation: NewTypeAnnotation(
+				&VariableSizedType{
+					Type: StringType,
+				},
+			),
+		},
+	},
+	OptionalMetaTypeAnnotation,
+)
+
+const metaTypeReferenceTypeFunctionDocString = `
+Returns the reference type with this type as its referenced type and the given entitlements,
+or nil if any of the entitlements is invalid
+`
+
 func init() {
 	MetaType.Members = func(t *SimpleType) map[string]MemberResolver {
 		return MembersAsResolvers([]*Member{
@@ -122,6 +203,36 @@ func init() {
 				MetaTypeContractNameFieldType,
 				metaTypeContractNameFieldDocString,
 			),
+			NewUnmeteredPublicFunctionMember(
+				t,
+				MetaTypeOptionalTypeFunctionName,
+				MetaTypeOptionalTypeFunctionType,
+				metaTypeOptionalTypeFunctionDocString,
+			),
+			NewUnmeteredPublicFunctionMember(
+				t,
+				MetaTypeVariableSizedArrayTypeFunctionName,
+				MetaTypeVariableSizedArrayTypeFunctionType,
+				metaTypeVariableSizedArrayTypeFunctionDocString,
+			),
+			NewUnmeteredPublicFunctionMember(
+				t,
+				MetaTypeConstantSizedArrayTypeFunctionName,
+				MetaTypeConstantSizedArrayTypeFunctionType,
+				metaTypeConstantSizedArrayTypeFunctionDocString,
+			),
+			NewUnmeteredPublicFunctionMember(
+				t,
+				MetaTypeDictionaryTypeFunctionName,
+				MetaTypeDictionaryTypeFunctionType,
+				metaTypeDictionaryTypeFunctionDocString,
+			),
+			NewUnmeteredPublicFunctionMember(
+				t,
+				MetaTypeReferenceTypeFunctionName,
+				MetaTypeReferenceTypeFunctionType,
+				metaTypeReferenceTypeFunctionDocString,
+			),
 		})
 	}
 }
@@ -0,0 +1,178 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/ast"
+)
+
+const ResourceDestructionDocumentationDiagnosticCode = "undocumented-resource-destruction"
+
+// ResourceDestructionDocumentationTag is the doc comment tag
+// which documents the resources destroyed by a function, and the side effects of the destruction
+const ResourceDestructionDocumentationTag = "destroys"
+
+// ResourceDestructionDocumentationLintRule is a lint rule which reports `destroy` expressions
+// in functions (including initializers and the prepare and execute blocks of transactions)
+// whose doc comment does not have a non-empty `@destroys` tag, e.g.:
+//
+//	/// Burns the given tokens.
+//	///
+//	/// @destroys The vault, which emits the `Burned` event and decreases the total supply
+//	fun burn(_ vault: @Vault) {
+//	    destroy vault
+//	}
+//
+// For transactions, the doc comment of the transaction is used.
+// Nested functions are checked separately, using their own doc comment.
+//
+// The rule is intended for audited codebases, and is not enabled by default.
+var ResourceDestructionDocumentationLintRule = &LintRule{
+	Name:             "resource-destruction-documentation",
+	VisitDeclaration: checkResourceDestructionDocumentation,
+}
+
+func checkResourceDestructionDocumentation(context LintContext, declaration ast.Declaration) {
+	switch declaration := declaration.(type) {
+	case *ast.FunctionDeclaration:
+		checkFunctionResourceDestructionDocumentation(
+			context,
+			declaration.DeclarationDocComment(),
+			declaration.FunctionBlock,
+			functionResourceDestructionSubject(declaration),
+		)
+
+	case *ast.CompositeDeclaration,
+		*ast.AttachmentDeclaration,
+		*ast.InterfaceDeclaration:
+
+		members := declaration.DeclarationMembers()
+		if members == nil {
+			return
+		}
+
+		// Special functions, e.g. initializers, are not visited separately
+
+		for _, specialFunction := range members.SpecialFunctions() {
+			functionDeclaration := specialFunction.FunctionDeclaration
+			checkFunctionResourceDestructionDocumentation(
+				context,
+				functionDeclaration.DeclarationDocComment(),
+				functionDeclaration.FunctionBlock,
+				functionResourceDestructionSubject(functionDeclaration),
+			)
+		}
+
+	case *ast.TransactionDeclaration:
+		// NOTE: TransactionDeclaration.DeclarationDocComment does not provide the doc comment
+		docComment := ast.ParseDocComment(declaration.DocString)
+
+		for _, specialFunction := range []*ast.SpecialFunctionDeclaration{
+			declaration.Prepare,
+			declaration.Execute,
+		} {
+			if specialFunction == nil {
+				continue
+			}
+			checkFunctionResourceDestructionDocumentation(
+				context,
+				docComment,
+				specialFunction.FunctionDeclaration.FunctionBlock,
+				"transaction",
+			)
+		}
+	}
+}
+
+func functionResourceDestructionSubject(declaration *ast.FunctionDeclaration) string {
+	return fmt.Sprintf("function `%s`", declaration.Identifier.Identifier)
+}
+
+func checkFunctionResourceDestructionDocumentation(
+	context LintContext,
+	docComment ast.DocComment,
+	functionBlock *ast.FunctionBlock,
+	subject string,
+) {
+	if functionBlock == nil {
+		return
+	}
+
+	if documentsResourceDestruction(docComment) {
+		return
+	}
+
+	walker := &destroyExpressionWalker{}
+	ast.Walk(walker, functionBlock)
+
+	if len(walker.destroyExpressions) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf(
+		"resource destruction in %s is not documented: "+
+			"add a `@%s` tag to its doc comment, which explains the side effects",
+		subject,
+		ResourceDestructionDocumentationTag,
+	)
+
+	for _, destroyExpression := range walker.destroyExpressions {
+		context.Report(&LintDiagnostic{
+			Code:    ResourceDestructionDocumentationDiagnosticCode,
+			Message: message,
+			Range:   ast.NewUnmeteredRangeFromPositioned(destroyExpression),
+		})
+	}
+}
+
+// documentsResourceDestruction returns true if the given doc comment
+// has a non-empty `@destroys` tag
+func documentsResourceDestruction(docComment ast.DocComment) bool {
+	for _, tag := range docComment.Tags {
+		if tag.Name == ResourceDestructionDocumentationTag &&
+			strings.TrimSpace(tag.Text) != "" {
+
+			return true
+		}
+	}
+	return false
+}
+
+// destroyExpressionWalker collects the destroy expressions of a function block.
+// Nested function declarations are not walked, as they are checked separately
+type destroyExpressionWalker struct {
+	destroyExpressions []*ast.DestroyExpression
+}
+
+var _ ast.Walker = &destroyExpressionWalker{}
+
+func (w *destroyExpressionWalker) Walk(element ast.Element) ast.Walker {
+	switch element := element.(type) {
+	case *ast.FunctionDeclaration:
+		return nil
+
+	case *ast.DestroyExpression:
+		w.destroyExpressions = append(w.destroyExpressions, element)
+	}
+
+	return w
+}
@@ -0,0 +1,240 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func TestCheckResourceDestructionDocumentation(t *testing.T) {
+
+	t.Parallel()
+
+	check := func(t *testing.T, code string) []*sema.LintDiagnostic {
+		checker, err := ParseAndCheckWithOptions(t,
+			code,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					LintRules: []*sema.LintRule{
+						sema.ResourceDestructionDocumentationLintRule,
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		var diagnostics []*sema.LintDiagnostic
+		for _, warning := range checker.Warnings() {
+			var diagnostic *sema.LintDiagnostic
+			require.ErrorAs(t, warning, &diagnostic)
+			assert.Equal(t, sema.ResourceDestructionDocumentationDiagnosticCode, diagnostic.Code)
+			diagnostics = append(diagnostics, diagnostic)
+		}
+		return diagnostics
+	}
+
+	t.Run("function, undocumented", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := check(t, `
+          resource R {}
+
+          /// Burns the given resources.
+          fun burn(_ r1: @R, _ r2: @R) {
+              destroy r1
+              destroy r2
+          }
+        `)
+		require.Len(t, diagnostics, 2)
+
+		assert.Equal(t,
+			"resource destruction in function `burn` is not documented: "+
+				"add a `@destroys` tag to its doc comment, which explains the side effects",
+			diagnostics[0].Message,
+		)
+		assert.Equal(t, 6, diagnostics[0].StartPos.Line)
+		assert.Equal(t, 7, diagnostics[1].StartPos.Line)
+	})
+
+	t.Run("function, documented", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := check(t, `
+          resource R {}
+
+          /// Burns the given resource.
+          ///
+          /// @destroys The resource, which emits its default destroy event
+          fun burn(_ r: @R) {
+              destroy r
+          }
+        `)
+		require.Empty(t, diagnostics)
+	})
+
+	t.Run("function, empty tag", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := check(t, `
+          resource R {}
+
+          /// @destroys
+          fun burn(_ r: @R) {
+              destroy r
+          }
+        `)
+		require.Len(t, diagnostics, 1)
+	})
+
+	t.Run("function, no destruction", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := check(t, `
+          resource R {}
+
+          fun move(_ r: @R): @R {
+              return <-r
+          }
+        `)
+		require.Empty(t, diagnostics)
+	})
+
+	t.Run("composite function and initializer", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := check(t, `
+          resource R {}
+
+          resource Collection {
+
+              /// @destroys The given resource, which is not used
+              init(_ r: @R) {
+                  destroy r
+              }
+
+              fun burn(_ r: @R) {
+                  destroy r
+              }
+          }
+        `)
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, 12, diagnostics[0].StartPos.Line)
+	})
+
+	t.Run("nested function", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := check(t, `
+          resource R {}
+
+          /// @destroys The given resource
+          fun outer(_ r: @R) {
+              fun inner(_ r: @R) {
+                  destroy r
+              }
+              destroy r
+          }
+        `)
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t,
+			"resource destruction in function `inner` is not documented: "+
+				"add a `@destroys` tag to its doc comment, which explains the side effects",
+			diagnostics[0].Message,
+		)
+	})
+
+	t.Run("function expression", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := check(t, `
+          resource R {}
+
+          fun outer() {
+              let burn = fun (_ r: @R) {
+                  destroy r
+              }
+          }
+        `)
+		require.Len(t, diagnostics, 1)
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := check(t, `
+          resource R {}
+
+          transaction {
+              prepare() {
+                  destroy <-create R()
+              }
+
+              execute {
+                  destroy <-create R()
+              }
+          }
+        `)
+		require.Len(t, diagnostics, 2)
+		assert.Equal(t,
+			"resource destruction in transaction is not documented: "+
+				"add a `@destroys` tag to its doc comment, which explains the side effects",
+			diagnostics[0].Message,
+		)
+	})
+
+	t.Run("transaction, documented", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostics := check(t, `
+          resource R {}
+
+          /// @destroys Temporary resources
+          transaction {
+              prepare() {
+                  destroy <-create R()
+              }
+
+              execute {
+                  destroy <-create R()
+              }
+          }
+        `)
+		require.Empty(t, diagnostics)
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          resource R {}
+
+          fun burn(_ r: @R) {
+              destroy r
+          }
+        `)
+		require.NoError(t, err)
+		require.Empty(t, checker.Warnings())
+	})
+}
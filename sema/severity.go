@@ -0,0 +1,106 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+)
+
+// Severity is the severity with which a warning is reported, see Config.DiagnosticLevels
+type Severity uint8
+
+const (
+	// SeverityWarning reports the warning as a warning, see Checker.Warnings
+	SeverityWarning Severity = iota
+	// SeverityError reports the warning as an error, i.e. checking fails
+	SeverityError
+	// SeverityOff suppresses the warning
+	SeverityOff
+)
+
+var Severities = []Severity{
+	SeverityWarning,
+	SeverityError,
+	SeverityOff,
+}
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityOff:
+		return "off"
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+// ParseSeverity returns the severity with the given name, e.g. "error"
+func ParseSeverity(name string) (Severity, error) {
+	for _, severity := range Severities {
+		if severity.String() == name {
+			return severity, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown severity: %s", name)
+}
+
+// DiagnosticLevelsHandlerFunc is a function that returns the severities of warnings
+// for the program with the given location
+type DiagnosticLevelsHandlerFunc func(location common.Location) map[ErrorCode]Severity
+
+// warningSeverity returns the severity with which the given warning is reported.
+//
+// The severity for the warning's code in the diagnostic levels of the program's location has precedence,
+// then the severity in the global diagnostic levels, then the warnings-as-errors switch
+func (checker *Checker) warningSeverity(warning error) Severity {
+	config := checker.Config
+
+	if config.DiagnosticLevelsHandler == nil &&
+		config.DiagnosticLevels == nil &&
+		!config.WarningsAsErrors {
+
+		return SeverityWarning
+	}
+
+	code := ErrorCodeOf(warning)
+
+	if config.DiagnosticLevelsHandler != nil {
+		levels := config.DiagnosticLevelsHandler(checker.Location)
+		if severity, ok := levels[code]; ok {
+			return severity
+		}
+	}
+
+	if severity, ok := config.DiagnosticLevels[code]; ok {
+		return severity
+	}
+
+	if config.WarningsAsErrors {
+		return SeverityError
+	}
+
+	return SeverityWarning
+}
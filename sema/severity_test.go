@@ -0,0 +1,153 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func TestCheckDiagnosticLevels(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      fun test() {
+          let x = 1
+      }
+    `
+
+	parseAndCheck := func(t *testing.T, config *sema.Config) (*sema.Checker, error) {
+		config.UnusedDeclarationWarningsEnabled = true
+		return ParseAndCheckWithOptions(t,
+			code,
+			ParseAndCheckOptions{
+				Config: config,
+			},
+		)
+	}
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, &sema.Config{})
+		require.NoError(t, err)
+
+		warnings := checker.Warnings()
+		require.Len(t, warnings, 1)
+		assert.IsType(t, &sema.UnusedDeclarationWarning{}, warnings[0])
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, &sema.Config{
+			DiagnosticLevels: map[sema.ErrorCode]sema.Severity{
+				sema.ErrorCodeUnusedDeclaration: sema.SeverityError,
+			},
+		})
+
+		errs := RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.UnusedDeclarationWarning{}, errs[0])
+
+		assert.Empty(t, checker.Warnings())
+	})
+
+	t.Run("off", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, &sema.Config{
+			DiagnosticLevels: map[sema.ErrorCode]sema.Severity{
+				sema.ErrorCodeUnusedDeclaration: sema.SeverityOff,
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Empty(t, checker.Warnings())
+	})
+
+	t.Run("warnings as errors", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, &sema.Config{
+			WarningsAsErrors: true,
+		})
+
+		errs := RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.UnusedDeclarationWarning{}, errs[0])
+
+		assert.Empty(t, checker.Warnings())
+	})
+
+	t.Run("warnings as errors, level overrides", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, &sema.Config{
+			WarningsAsErrors: true,
+			DiagnosticLevels: map[sema.ErrorCode]sema.Severity{
+				sema.ErrorCodeUnusedDeclaration: sema.SeverityWarning,
+			},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, checker.Warnings(), 1)
+	})
+
+	t.Run("location levels override", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, &sema.Config{
+			DiagnosticLevels: map[sema.ErrorCode]sema.Severity{
+				sema.ErrorCodeUnusedDeclaration: sema.SeverityError,
+			},
+			DiagnosticLevelsHandler: func(location common.Location) map[sema.ErrorCode]sema.Severity {
+				if location != TestLocation {
+					return nil
+				}
+				return map[sema.ErrorCode]sema.Severity{
+					sema.ErrorCodeUnusedDeclaration: sema.SeverityOff,
+				}
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Empty(t, checker.Warnings())
+	})
+}
+
+func TestParseSeverity(t *testing.T) {
+
+	t.Parallel()
+
+	for _, severity := range sema.Severities {
+		parsed, err := sema.ParseSeverity(severity.String())
+		require.NoError(t, err)
+		assert.Equal(t, severity, parsed)
+	}
+
+	_, err := sema.ParseSeverity("fatal")
+	require.Error(t, err)
+}
@@ -0,0 +1,157 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SubtypeExplanation is a trace of the subtyping rules applied
+// when determining if a type is a subtype of another type.
+//
+// Each premise is the explanation of a subtype check
+// that the applied rule depends on.
+type SubtypeExplanation struct {
+	SubType   Type
+	SuperType Type
+	IsSubType bool
+	Rule      string
+	Premises  []*SubtypeExplanation
+}
+
+// ExplainIsSubType determines if the given subtype is a subtype of the given supertype,
+// like IsSubType, and returns an explanation of why it is or is not.
+func ExplainIsSubType(subType Type, superType Type) *SubtypeExplanation {
+	explanation := &SubtypeExplanation{
+		SubType:   subType,
+		SuperType: superType,
+	}
+	isSubType(subType, superType, explanation)
+	return explanation
+}
+
+// The following functions are nil-safe,
+// so the subtype check only records its rules when an explanation is requested.
+
+func (e *SubtypeExplanation) rule(rule string) {
+	if e == nil {
+		return
+	}
+	e.Rule = rule
+}
+
+func (e *SubtypeExplanation) setResult(result bool) {
+	if e == nil {
+		return
+	}
+	e.IsSubType = result
+}
+
+// checkPremise determines if the given subtype is a subtype of the given supertype,
+// and records the explanation of it as a premise.
+func (e *SubtypeExplanation) checkPremise(subType Type, superType Type) bool {
+	if e == nil {
+		return isSubType(subType, superType, nil)
+	}
+
+	premise := &SubtypeExplanation{
+		SubType:   subType,
+		SuperType: superType,
+	}
+	e.Premises = append(e.Premises, premise)
+
+	return isSubType(subType, superType, premise)
+}
+
+// checkInterfaces determines if the required interfaces are a subset of the available interfaces,
+// and records the given rule, including the missing interfaces, if any.
+func (e *SubtypeExplanation) checkInterfaces(required *InterfaceSet, available *InterfaceSet, rule string) bool {
+	if e == nil {
+		return required.IsSubsetOf(available)
+	}
+
+	var missing []string
+	required.ForEach(func(interfaceType *InterfaceType) {
+		if !available.Contains(interfaceType) {
+			missing = append(
+				missing,
+				fmt.Sprintf("`%s`", interfaceType.QualifiedString()),
+			)
+		}
+	})
+
+	if len(missing) > 0 {
+		rule = fmt.Sprintf(
+			"%s, but %s %s missing",
+			rule,
+			strings.Join(missing, ", "),
+			pluralize(len(missing), "is", "are"),
+		)
+	}
+	e.rule(rule)
+
+	return len(missing) == 0
+}
+
+func pluralize(count int, singular string, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
+
+// String returns the explanation as an indented tree,
+// where each line explains one subtype check, and its premises follow indented.
+func (e *SubtypeExplanation) String() string {
+	var builder strings.Builder
+	e.write(&builder, 0)
+	return builder.String()
+}
+
+func (e *SubtypeExplanation) write(builder *strings.Builder, depth int) {
+	for i := 0; i < depth; i++ {
+		builder.WriteString("  ")
+	}
+
+	builder.WriteString(qualifiedTypeString(e.SubType))
+	if e.IsSubType {
+		builder.WriteString(" is a subtype of ")
+	} else {
+		builder.WriteString(" is not a subtype of ")
+	}
+	builder.WriteString(qualifiedTypeString(e.SuperType))
+
+	if e.Rule != "" {
+		builder.WriteString(": ")
+		builder.WriteString(e.Rule)
+	}
+	builder.WriteByte('\n')
+
+	for _, premise := range e.Premises {
+		premise.write(builder, depth+1)
+	}
+}
+
+func qualifiedTypeString(ty Type) string {
+	if ty == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("`%s`", ty.QualifiedString())
+}
@@ -0,0 +1,204 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+)
+
+func TestExplainIsSubType(t *testing.T) {
+
+	t.Parallel()
+
+	testLocation := common.StringLocation("test")
+
+	x := &EntitlementType{
+		Location:   testLocation,
+		Identifier: "X",
+	}
+
+	y := &EntitlementType{
+		Location:   testLocation,
+		Identifier: "Y",
+	}
+
+	interfaceI := &InterfaceType{
+		Location:      testLocation,
+		Identifier:    "I",
+		CompositeKind: common.CompositeKindResource,
+	}
+
+	interfaceJ := &InterfaceType{
+		Location:      testLocation,
+		Identifier:    "J",
+		CompositeKind: common.CompositeKindResource,
+	}
+
+	resourceR := &CompositeType{
+		Location:                      testLocation,
+		Identifier:                    "R",
+		Kind:                          common.CompositeKindResource,
+		ExplicitInterfaceConformances: []*InterfaceType{interfaceI},
+	}
+
+	t.Run("equal", func(t *testing.T) {
+
+		t.Parallel()
+
+		explanation := ExplainIsSubType(IntType, IntType)
+
+		assert.Equal(t,
+			"`Int` is a subtype of `Int`: types are subtypes of themselves\n",
+			explanation.String(),
+		)
+	})
+
+	t.Run("number hierarchy", func(t *testing.T) {
+
+		t.Parallel()
+
+		explanation := ExplainIsSubType(Int8Type, NumberType)
+
+		require.True(t, explanation.IsSubType)
+		assert.Equal(t,
+			"`Int8` is a subtype of `Number`: a type is a subtype of `Number` if it is a subtype of `Integer` or `FixedPoint`\n"+
+				"  `Int8` is a subtype of `Integer`: a type is a subtype of `Integer` if it is a subtype of `SignedInteger` or `FixedSizeUnsignedInteger`\n"+
+				"    `Int8` is a subtype of `SignedInteger`: only the signed integer types are subtypes of `SignedInteger`\n",
+			explanation.String(),
+		)
+	})
+
+	t.Run("optional", func(t *testing.T) {
+
+		t.Parallel()
+
+		explanation := ExplainIsSubType(
+			&OptionalType{Type: StringType},
+			&OptionalType{Type: IntType},
+		)
+
+		require.False(t, explanation.IsSubType)
+		assert.Equal(t,
+			"`String?` is not a subtype of `Int?`: optionals are covariant: `T?` is a subtype of `U?` if `T` is a subtype of `U`\n"+
+				"  `String` is not a subtype of `Int`: no subtyping rule applies\n",
+			explanation.String(),
+		)
+	})
+
+	t.Run("nested reference, authorization mismatch", func(t *testing.T) {
+
+		t.Parallel()
+
+		explanation := ExplainIsSubType(
+			&OptionalType{
+				Type: &ReferenceType{
+					Authorization: NewEntitlementSetAccess([]*EntitlementType{x}, Conjunction),
+					Type:          IntType,
+				},
+			},
+			&OptionalType{
+				Type: &ReferenceType{
+					Authorization: NewEntitlementSetAccess([]*EntitlementType{x, y}, Conjunction),
+					Type:          IntType,
+				},
+			},
+		)
+
+		require.False(t, explanation.IsSubType)
+		require.Len(t, explanation.Premises, 1)
+
+		premise := explanation.Premises[0]
+		assert.False(t, premise.IsSubType)
+		assert.Equal(t,
+			"the authorization of the subtype reference must permit the access of the supertype reference, "+
+				"but `X, Y` is not permitted by `X`",
+			premise.Rule,
+		)
+		assert.Empty(t, premise.Premises)
+	})
+
+	t.Run("intersection, missing conformance", func(t *testing.T) {
+
+		t.Parallel()
+
+		explanation := ExplainIsSubType(
+			resourceR,
+			&IntersectionType{
+				Types: []*InterfaceType{interfaceI, interfaceJ},
+			},
+		)
+
+		require.False(t, explanation.IsSubType)
+		assert.Equal(t,
+			"`R` is not a subtype of `{I, J}`: "+
+				"a type `T` is a subtype of an intersection type `{Us}` if `T` conforms to `Us`, "+
+				"but `J` is missing\n",
+			explanation.String(),
+		)
+	})
+
+	t.Run("consistent with IsSubType", func(t *testing.T) {
+
+		t.Parallel()
+
+		types := []Type{
+			NeverType,
+			AnyType,
+			AnyStructType,
+			AnyResourceType,
+			IntType,
+			Int8Type,
+			UInt8Type,
+			UFix64Type,
+			NumberType,
+			SignedNumberType,
+			StringType,
+			StoragePathType,
+			PathType,
+			&OptionalType{Type: IntType},
+			&OptionalType{Type: AnyStructType},
+			&VariableSizedType{Type: IntType},
+			&ConstantSizedType{Type: IntType, Size: 2},
+			&DictionaryType{KeyType: StringType, ValueType: IntType},
+			&ReferenceType{Authorization: UnauthorizedAccess, Type: IntType},
+			resourceR,
+			interfaceI,
+			&IntersectionType{Types: []*InterfaceType{interfaceI}},
+		}
+
+		for _, subType := range types {
+			for _, superType := range types {
+				explanation := ExplainIsSubType(subType, superType)
+				assert.Equal(t,
+					IsSubType(subType, superType),
+					explanation.IsSubType,
+					"%s <: %s",
+					subType,
+					superType,
+				)
+				assert.NotEmpty(t, explanation.Rule)
+			}
+		}
+	})
+}
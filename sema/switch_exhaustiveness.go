@@ -0,0 +1,222 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+)
+
+// NonExhaustiveSwitchWarning is reported for a switch statement without a default case,
+// which does not cover all cases of the tested enum or optional type.
+// Values which are not covered by any case are silently ignored
+type NonExhaustiveSwitchWarning struct {
+	Type Type
+	// MissingCases are the cases which are not covered,
+	// e.g. `E.b` for enum types, and `nil` for optional types
+	MissingCases []string
+	ast.Range
+}
+
+var _ error = &NonExhaustiveSwitchWarning{}
+var _ errors.SecondaryError = &NonExhaustiveSwitchWarning{}
+
+func (w *NonExhaustiveSwitchWarning) Error() string {
+	noun := "case"
+	if len(w.MissingCases) > 1 {
+		noun = "cases"
+	}
+
+	return fmt.Sprintf(
+		"switch over `%s` is not exhaustive: missing %s %s",
+		w.Type.QualifiedString(),
+		noun,
+		formatMissingCases(w.MissingCases),
+	)
+}
+
+func (*NonExhaustiveSwitchWarning) SecondaryError() string {
+	return "add the missing cases, or a default case"
+}
+
+func formatMissingCases(cases []string) string {
+	var builder strings.Builder
+	for i, name := range cases {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteByte('`')
+		builder.WriteString(name)
+		builder.WriteByte('`')
+	}
+	return builder.String()
+}
+
+// checkSwitchExhaustiveness reports a warning if the given switch statement
+// over an enum or optional type has no default case and does not cover all cases
+func (checker *Checker) checkSwitchExhaustiveness(statement *ast.SwitchStatement, testType Type) {
+
+	if !checker.Config.SwitchExhaustivenessWarningsEnabled ||
+		testType.IsInvalidType() {
+
+		return
+	}
+
+	// A default case covers all remaining cases
+
+	for _, switchCase := range statement.Cases {
+		if switchCase.Expression == nil {
+			return
+		}
+	}
+
+	requiresNilCase := false
+	caseType := testType
+	if optionalType, ok := testType.(*OptionalType); ok {
+		requiresNilCase = true
+		caseType = optionalType.Type
+	}
+
+	enumType, ok := caseType.(*CompositeType)
+	if !ok || enumType.Kind != common.CompositeKindEnum {
+		enumType = nil
+	}
+
+	if !requiresNilCase && enumType == nil {
+		return
+	}
+
+	hasNilCase := false
+	coveredEnumCases := map[string]struct{}{}
+
+	// enumConstructorType is the constructor function of the enum,
+	// which has the enum cases as members
+	var enumConstructorType *FunctionType
+
+	for _, switchCase := range statement.Cases {
+		switch expression := switchCase.Expression.(type) {
+		case *ast.NilExpression:
+			hasNilCase = true
+
+		case *ast.MemberExpression:
+			if enumType == nil {
+				continue
+			}
+
+			constructorType := checker.enumCaseConstructorType(expression, enumType)
+			if constructorType == nil {
+				continue
+			}
+
+			enumConstructorType = constructorType
+			coveredEnumCases[expression.Identifier.Identifier] = struct{}{}
+		}
+	}
+
+	var missingCases []string
+
+	if enumType != nil {
+		for _, caseName := range checker.enumCaseNames(enumType, enumConstructorType) {
+			if _, ok := coveredEnumCases[caseName]; ok {
+				continue
+			}
+
+			missingCases = append(
+				missingCases,
+				fmt.Sprintf("%s.%s", enumType.QualifiedString(), caseName),
+			)
+		}
+	}
+
+	if requiresNilCase && !hasNilCase {
+		missingCases = append(missingCases, "nil")
+	}
+
+	if len(missingCases) == 0 {
+		return
+	}
+
+	checker.reportWarning(
+		&NonExhaustiveSwitchWarning{
+			Type:         testType,
+			MissingCases: missingCases,
+			Range: ast.NewRange(
+				checker.memoryGauge,
+				statement.StartPos,
+				statement.Expression.EndPosition(checker.memoryGauge),
+			),
+		},
+	)
+}
+
+// enumCaseConstructorType returns the constructor function type of the given enum type,
+// if the given member expression is an access of one of its cases, e.g. `E.a`.
+// It returns nil otherwise
+func (checker *Checker) enumCaseConstructorType(
+	expression *ast.MemberExpression,
+	enumType *CompositeType,
+) *FunctionType {
+
+	memberInfo, ok := checker.Elaboration.MemberExpressionMemberAccessInfo(expression)
+	if !ok || memberInfo.Member == nil {
+		return nil
+	}
+
+	constructorType, ok := memberInfo.Member.ContainerType.(*FunctionType)
+	if !ok || !constructorType.IsConstructor {
+		return nil
+	}
+
+	if !memberInfo.Member.TypeAnnotation.Type.Equal(enumType) {
+		return nil
+	}
+
+	return constructorType
+}
+
+// enumCaseNames returns the names of the cases of the given enum type, in declaration order.
+// The cases of enums declared in the checked program are determined from the declaration,
+// the cases of imported enums are determined from the given constructor type, if any
+func (checker *Checker) enumCaseNames(enumType *CompositeType, constructorType *FunctionType) []string {
+
+	if declaration, ok := checker.Elaboration.CompositeTypeDeclaration(enumType); ok {
+		enumCases := declaration.DeclarationMembers().EnumCases()
+		names := make([]string, 0, len(enumCases))
+		for _, enumCase := range enumCases {
+			names = append(names, enumCase.Identifier.Identifier)
+		}
+		return names
+	}
+
+	if constructorType == nil || constructorType.Members == nil {
+		return nil
+	}
+
+	var names []string
+	constructorType.Members.Foreach(func(name string, member *Member) {
+		if member.TypeAnnotation.Type.Equal(enumType) {
+			names = append(names, name)
+		}
+	})
+	return names
+}
@@ -0,0 +1,279 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func TestCheckSwitchExhaustivenessWarnings(t *testing.T) {
+
+	t.Parallel()
+
+	parseAndCheck := func(t *testing.T, code string) *sema.Checker {
+		checker, err := ParseAndCheckWithOptions(t,
+			code,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					SwitchExhaustivenessWarningsEnabled: true,
+				},
+			},
+		)
+		require.NoError(t, err)
+		return checker
+	}
+
+	requireWarning := func(t *testing.T, checker *sema.Checker) *sema.NonExhaustiveSwitchWarning {
+		warnings := checker.Warnings()
+		require.Len(t, warnings, 1)
+
+		var warning *sema.NonExhaustiveSwitchWarning
+		require.ErrorAs(t, warnings[0], &warning)
+		return warning
+	}
+
+	t.Run("enum, missing cases", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          enum E: UInt8 {
+              case a
+              case b
+              case c
+          }
+
+          fun test(e: E): Int {
+              switch e {
+                  case E.b:
+                      return 2
+              }
+              return 0
+          }
+        `)
+
+		warning := requireWarning(t, checker)
+		assert.Equal(t, []string{"E.a", "E.c"}, warning.MissingCases)
+		assert.Equal(t,
+			"switch over `E` is not exhaustive: missing cases `E.a`, `E.c`",
+			warning.Error(),
+		)
+		assert.Equal(t,
+			ast.Range{
+				StartPos: ast.Position{Offset: 149, Line: 9, Column: 14},
+				EndPos:   ast.Position{Offset: 156, Line: 9, Column: 21},
+			},
+			warning.Range,
+		)
+	})
+
+	t.Run("enum, all cases", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          enum E: UInt8 {
+              case a
+              case b
+          }
+
+          fun test(e: E): Int {
+              switch e {
+                  case E.a:
+                      return 1
+                  case E.b:
+                      return 2
+              }
+              return 0
+          }
+        `)
+
+		assert.Empty(t, checker.Warnings())
+	})
+
+	t.Run("enum, default case", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          enum E: UInt8 {
+              case a
+              case b
+          }
+
+          fun test(e: E): Int {
+              switch e {
+                  case E.a:
+                      return 1
+                  default:
+                      return 2
+              }
+          }
+        `)
+
+		assert.Empty(t, checker.Warnings())
+	})
+
+	t.Run("optional enum, missing nil", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          enum E: UInt8 {
+              case a
+          }
+
+          fun test(e: E?): Int {
+              switch e {
+                  case E.a:
+                      return 1
+              }
+              return 0
+          }
+        `)
+
+		warning := requireWarning(t, checker)
+		assert.Equal(t, []string{"nil"}, warning.MissingCases)
+		assert.Equal(t,
+			"switch over `E?` is not exhaustive: missing case `nil`",
+			warning.Error(),
+		)
+	})
+
+	t.Run("optional enum, all cases", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          enum E: UInt8 {
+              case a
+          }
+
+          fun test(e: E?): Int {
+              switch e {
+                  case E.a:
+                      return 1
+                  case nil:
+                      return 0
+              }
+              return 2
+          }
+        `)
+
+		assert.Empty(t, checker.Warnings())
+	})
+
+	t.Run("optional, missing nil", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          fun test(x: Int?): Int {
+              switch x {
+                  case 1:
+                      return 1
+              }
+              return 0
+          }
+        `)
+
+		warning := requireWarning(t, checker)
+		assert.Equal(t, []string{"nil"}, warning.MissingCases)
+	})
+
+	t.Run("non-optional, non-enum", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          fun test(x: Int): Int {
+              switch x {
+                  case 1:
+                      return 1
+              }
+              return 0
+          }
+        `)
+
+		assert.Empty(t, checker.Warnings())
+	})
+
+	t.Run("imported enum", func(t *testing.T) {
+		t.Parallel()
+
+		importedChecker, err := ParseAndCheckWithOptions(t,
+			`
+              access(all) enum E: UInt8 {
+                  access(all) case a
+                  access(all) case b
+                  access(all) case c
+              }
+            `,
+			ParseAndCheckOptions{
+				Location: ImportedLocation,
+			},
+		)
+		require.NoError(t, err)
+
+		checker, err := ParseAndCheckWithOptions(t,
+			`
+              import E from "imported"
+
+              fun test(e: E) {
+                  switch e {
+                      case E.a:
+                          return
+                  }
+              }
+            `,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					SwitchExhaustivenessWarningsEnabled: true,
+					ImportHandler: func(_ *sema.Checker, _ common.Location, _ ast.Range) (sema.Import, error) {
+						return sema.ElaborationImport{
+							Elaboration: importedChecker.Elaboration,
+						}, nil
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+
+		warning := requireWarning(t, checker)
+		assert.Equal(t, []string{"E.b", "E.c"}, warning.MissingCases)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun test(x: Int?) {
+              switch x {
+                  case 1:
+                      return
+              }
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.Empty(t, checker.Warnings())
+	})
+}
@@ -0,0 +1,306 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+)
+
+// Symbol is a declaration, e.g. of a variable, a parameter, a member, or a type,
+// together with all references to it
+type Symbol struct {
+	// Type is the type of the declared value, or the declared type
+	Type Type
+	// Declaration is the range of the identifier of the declaration,
+	// or nil if the symbol is declared outside of the program, e.g. if it is imported or built-in
+	Declaration *ast.Range
+	Name        string
+	// Container is the qualified name of the innermost declaration which contains the declaration,
+	// e.g. `C.R.foo` for a local variable of function `foo` of resource `R` in contract `C`.
+	// It is empty for global declarations, and declarations outside of the program
+	Container string
+	DocString string
+	// References are the ranges of all occurrences of the symbol, excluding the declaration
+	References []ast.Range
+	Kind       common.DeclarationKind
+}
+
+type jsonSymbol struct {
+	Name        string                 `json:"name"`
+	Kind        common.DeclarationKind `json:"kind"`
+	Type        string                 `json:"type,omitempty"`
+	TypeID      TypeID                 `json:"typeID,omitempty"`
+	Container   string                 `json:"container,omitempty"`
+	DocString   string                 `json:"docString,omitempty"`
+	Declaration *ast.Range             `json:"declaration,omitempty"`
+	References  []ast.Range            `json:"references"`
+}
+
+func (s *Symbol) MarshalJSON() ([]byte, error) {
+	symbol := jsonSymbol{
+		Name:        s.Name,
+		Kind:        s.Kind,
+		Container:   s.Container,
+		DocString:   s.DocString,
+		Declaration: s.Declaration,
+		References:  s.References,
+	}
+
+	if s.Type != nil {
+		symbol.Type = s.Type.QualifiedString()
+		symbol.TypeID = s.Type.ID()
+	}
+
+	if symbol.References == nil {
+		symbol.References = []ast.Range{}
+	}
+
+	return json.Marshal(symbol)
+}
+
+// SymbolTable is the table of all symbols of a checked program
+type SymbolTable struct {
+	Location common.Location `json:"location"`
+	// Symbols are ordered by the position of their declaration.
+	// Symbols declared outside of the program come last, ordered by name
+	Symbols []*Symbol `json:"symbols"`
+}
+
+// Symbols returns the symbol table of the checked program.
+// The symbol table is built from the position information, so it is nil
+// if Config.PositionInfoEnabled is not set.
+//
+// Unlike the occurrences of the position information,
+// which are indexed by position, e.g. for looking up the declaration at a position,
+// the symbol table lists each declaration once, together with all references to it,
+// e.g. for finding all references, renaming, or indexing
+func (checker *Checker) Symbols() *SymbolTable {
+	positionInfo := checker.PositionInfo
+	if positionInfo == nil {
+		return nil
+	}
+
+	scopes := collectSymbolScopes(checker.Program)
+
+	// NOTE: the type and the constructor function of a composite
+	// have the same declaration, so merge symbols by declaration
+
+	type symbolKey struct {
+		name   string
+		offset int
+	}
+
+	symbolsByDeclaration := map[symbolKey]*Symbol{}
+	var symbols []*Symbol
+
+	addSymbol := func(name string, origin *Origin) {
+		if origin == nil {
+			return
+		}
+
+		var declaration *ast.Range
+		if origin.StartPos != nil && origin.StartPos.Line > 0 {
+			endPos := *origin.StartPos
+			if origin.EndPos != nil {
+				endPos = *origin.EndPos
+			}
+			declaration = &ast.Range{
+				StartPos: *origin.StartPos,
+				EndPos:   endPos,
+			}
+		}
+
+		var references []ast.Range
+		for _, occurrence := range origin.Occurrences {
+			if declaration != nil && occurrence.StartPos == declaration.StartPos {
+				continue
+			}
+			references = append(references, occurrence)
+		}
+
+		if declaration != nil {
+			key := symbolKey{
+				name:   name,
+				offset: declaration.StartPos.Offset,
+			}
+			if existing, ok := symbolsByDeclaration[key]; ok {
+				existing.References = append(existing.References, references...)
+				// Prefer the declared type over the type of its constructor function
+				if _, ok := origin.Type.(*FunctionType); !ok {
+					existing.Type = origin.Type
+				}
+				if existing.DocString == "" {
+					existing.DocString = origin.DocString
+				}
+				return
+			}
+		}
+
+		symbol := &Symbol{
+			Type:        origin.Type,
+			Declaration: declaration,
+			Name:        name,
+			DocString:   origin.DocString,
+			References:  references,
+			Kind:        origin.DeclarationKind,
+		}
+		if declaration != nil {
+			symbol.Container = scopes.container(declaration.StartPos)
+			symbolsByDeclaration[symbolKey{
+				name:   name,
+				offset: declaration.StartPos.Offset,
+			}] = symbol
+		}
+
+		symbols = append(symbols, symbol)
+	}
+
+	for variable, origin := range positionInfo.VariableOrigins {
+		// Skip implicitly declared variables, like `self` and `result`
+		if variable.Pos == nil && variable.ActivationDepth > 0 {
+			continue
+		}
+		addSymbol(variable.Identifier, origin)
+	}
+
+	for _, origins := range positionInfo.MemberOrigins {
+		for name, origin := range origins {
+			addSymbol(name, origin)
+		}
+	}
+
+	for _, symbol := range symbols {
+		slices.SortFunc(symbol.References, func(a, b ast.Range) int {
+			return a.StartPos.Compare(b.StartPos)
+		})
+		symbol.References = slices.CompactFunc(symbol.References, func(a, b ast.Range) bool {
+			return a.StartPos == b.StartPos
+		})
+	}
+
+	slices.SortFunc(symbols, func(a, b *Symbol) int {
+		switch {
+		case a.Declaration == nil && b.Declaration == nil:
+			if result := strings.Compare(a.Name, b.Name); result != 0 {
+				return result
+			}
+			return int(a.Kind) - int(b.Kind)
+		case a.Declaration == nil:
+			return 1
+		case b.Declaration == nil:
+			return -1
+		}
+		return a.Declaration.StartPos.Compare(b.Declaration.StartPos)
+	})
+
+	return &SymbolTable{
+		Location: checker.Location,
+		Symbols:  symbols,
+	}
+}
+
+// symbolScope is a declaration which contains other declarations,
+// e.g. a composite or a function
+type symbolScope struct {
+	qualifiedName string
+	identifierPos ast.Position
+	startPos      ast.Position
+	endPos        ast.Position
+}
+
+// symbolScopes are the scopes of a program, in depth-first order
+type symbolScopes []symbolScope
+
+// container returns the qualified name of the innermost scope
+// which contains the declaration at the given position
+func (scopes symbolScopes) container(pos ast.Position) string {
+	// NOTE: scopes are in depth-first order,
+	// so the last scope which contains the position is the innermost one
+	for i := len(scopes) - 1; i >= 0; i-- {
+		scope := scopes[i]
+		if scope.identifierPos == pos {
+			continue
+		}
+		if scope.startPos.Offset <= pos.Offset && pos.Offset <= scope.endPos.Offset {
+			return scope.qualifiedName
+		}
+	}
+	return ""
+}
+
+func collectSymbolScopes(program *ast.Program) symbolScopes {
+	var scopes symbolScopes
+	if program != nil {
+		ast.Walk(symbolScopeCollector{scopes: &scopes}, program)
+	}
+	return scopes
+}
+
+type symbolScopeCollector struct {
+	scopes        *symbolScopes
+	qualifiedName string
+}
+
+var _ ast.Walker = symbolScopeCollector{}
+
+func (c symbolScopeCollector) Walk(element ast.Element) ast.Walker {
+	var name string
+	var identifierPos ast.Position
+
+	switch declaration := element.(type) {
+	case *ast.FunctionDeclaration,
+		*ast.SpecialFunctionDeclaration,
+		*ast.CompositeDeclaration,
+		*ast.InterfaceDeclaration,
+		*ast.AttachmentDeclaration:
+
+		identifier := declaration.(ast.Declaration).DeclarationIdentifier()
+		name = identifier.Identifier
+		identifierPos = identifier.Pos
+
+	case *ast.TransactionDeclaration:
+		name = common.DeclarationKindTransaction.Keywords()
+		identifierPos = declaration.StartPos
+
+	default:
+		return c
+	}
+
+	qualifiedName := name
+	if c.qualifiedName != "" {
+		qualifiedName = c.qualifiedName + "." + name
+	}
+
+	*c.scopes = append(*c.scopes, symbolScope{
+		qualifiedName: qualifiedName,
+		identifierPos: identifierPos,
+		startPos:      element.StartPosition(),
+		endPos:        element.EndPosition(nil),
+	})
+
+	return symbolScopeCollector{
+		scopes:        c.scopes,
+		qualifiedName: qualifiedName,
+	}
+}
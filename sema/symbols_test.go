@@ -0,0 +1,188 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func TestCheckSymbols(t *testing.T) {
+
+	t.Parallel()
+
+	parseAndCheck := func(t *testing.T, code string) *sema.Checker {
+		checker, err := ParseAndCheckWithOptions(t,
+			code,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					PositionInfoEnabled: true,
+				},
+			},
+		)
+		require.NoError(t, err)
+		return checker
+	}
+
+	describe := func(symbol *sema.Symbol) string {
+		declaration := "-"
+		if symbol.Declaration != nil {
+			declaration = fmt.Sprint(symbol.Declaration.StartPos.Offset)
+		}
+
+		var references []int
+		for _, reference := range symbol.References {
+			references = append(references, reference.StartPos.Offset)
+		}
+
+		return fmt.Sprintf(
+			"%s %s: %s in %q at %s, referenced at %v",
+			symbol.Kind.Name(),
+			symbol.Name,
+			symbol.Type.QualifiedString(),
+			symbol.Container,
+			declaration,
+			references,
+		)
+	}
+
+	t.Run("declarations and references", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          access(all) contract C {
+              access(all) let x: Int
+              init(a: Int) { self.x = a }
+              access(all) resource R {
+                  access(all) fun foo(b: Int): Int {
+                      let c = b + C.x
+                      return c
+                  }
+              }
+              access(all) fun make(): @R { return <- create R() }
+          }
+          fun test() { let r <- C.make(); destroy r; test() }
+        `)
+
+		symbolTable := checker.Symbols()
+		require.NotNil(t, symbolTable)
+
+		assert.Equal(t, checker.Location, symbolTable.Location)
+
+		var descriptions []string
+		for _, symbol := range symbolTable.Symbols {
+			descriptions = append(descriptions, describe(symbol))
+		}
+
+		assert.Equal(t,
+			[]string{
+				`contract C: C in "" at 32, referenced at [241 422]`,
+				`field x: Int in "C" at 66, referenced at [107 243]`,
+				`parameter a: Int in "C.init" at 92, referenced at [111]`,
+				`resource R: C.R in "C" at 150, referenced at [351 372]`,
+				`function foo: fun(b: Int): Int in "C.R" at 188, referenced at []`,
+				`parameter b: Int in "C.R.foo" at 192, referenced at [237]`,
+				`constant c: Int in "C.R.foo" at 233, referenced at [274]`,
+				`function make: fun(): @C.R in "C" at 342, referenced at [424]`,
+				`function test: fun(): Void in "" at 404, referenced at [443]`,
+				`constant r: C.R in "test" at 417, referenced at [440]`,
+				`type Int: Int in "" at -, referenced at [69 95 195 201]`,
+			},
+			descriptions,
+		)
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          transaction(a: Int) {
+              prepare(signer: &Account) {
+                  let b = a
+              }
+          }
+        `)
+
+		symbolTable := checker.Symbols()
+		require.NotNil(t, symbolTable)
+
+		containers := map[string]string{}
+		for _, symbol := range symbolTable.Symbols {
+			containers[symbol.Name] = symbol.Container
+		}
+
+		assert.Equal(t, "transaction", containers["a"])
+		assert.Equal(t, "transaction.prepare", containers["signer"])
+		assert.Equal(t, "transaction.prepare", containers["b"])
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		symbol := &sema.Symbol{
+			Type: sema.IntType,
+			Declaration: &ast.Range{
+				StartPos: ast.Position{Offset: 4, Line: 1, Column: 4},
+				EndPos:   ast.Position{Offset: 4, Line: 1, Column: 4},
+			},
+			Name:      "x",
+			Container: "test",
+			Kind:      common.DeclarationKindConstant,
+		}
+
+		actual, err := json.Marshal(symbol)
+		require.NoError(t, err)
+
+		assert.JSONEq(t,
+			`
+              {
+                "name": "x",
+                "kind": "DeclarationKindConstant",
+                "type": "Int",
+                "typeID": "Int",
+                "container": "test",
+                "declaration": {
+                  "StartPos": {"Offset": 4, "Line": 1, "Column": 4},
+                  "EndPos": {"Offset": 4, "Line": 1, "Column": 4}
+                },
+                "references": []
+              }
+            `,
+			string(actual),
+		)
+	})
+
+	t.Run("position info disabled", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `let x = 1`)
+		require.NoError(t, err)
+
+		assert.Nil(t, checker.Symbols())
+	})
+}
@@ -4196,6 +4196,7 @@ func init() {
 			StorageCapabilityControllerType,
 			AccountCapabilityControllerType,
 			DeploymentResultType,
+			ContractMetadataType,
 			HashableStructType,
 			&InclusiveRangeType{},
 			StructStringerType,
@@ -4928,6 +4929,17 @@ func (t *CompositeType) clearCachedIdentifiers() {
 }
 
 func (t *CompositeType) initializeIdentifiers() {
+	// Fast path: the identifiers are usually already cached,
+	// and the type ID is requested very often, e.g. for type equality checks,
+	// so avoid taking the exclusive lock
+	t.cachedIdentifiersLock.RLock()
+	cached := t.cachedIdentifiers != nil
+	t.cachedIdentifiersLock.RUnlock()
+
+	if cached {
+		return
+	}
+
 	t.cachedIdentifiersLock.Lock()
 	defer t.cachedIdentifiersLock.Unlock()
 
@@ -5783,6 +5795,17 @@ func (t *InterfaceType) ID() TypeID {
 }
 
 func (t *InterfaceType) initializeIdentifiers() {
+	// Fast path: the identifiers are usually already cached,
+	// and the type ID is requested very often, e.g. for type equality checks,
+	// so avoid taking the exclusive lock
+	t.cachedIdentifiersLock.RLock()
+	cached := t.cachedIdentifiers != nil
+	t.cachedIdentifiersLock.RUnlock()
+
+	if cached {
+		return
+	}
+
 	t.cachedIdentifiersLock.Lock()
 	defer t.cachedIdentifiersLock.Unlock()
 
@@ -7447,16 +7470,26 @@ func IsPrimitiveOrContainerOfPrimitive(referencedType Type) bool {
 //     usage is, using IsSubType() method with a constant/pre-defined superType.
 //     e.g: IsSubType(<<someType>>, FixedPointType)
 func IsSubType(subType Type, superType Type) bool {
+	return isSubType(subType, superType, nil)
+}
+
+// isSubType is IsSubType, which records the applied subtyping rules in the given explanation, if any
+func isSubType(subType Type, superType Type, explanation *SubtypeExplanation) bool {
 
 	if subType == nil {
+		explanation.rule("there is no subtype")
 		return false
 	}
 
 	if subType.Equal(superType) {
+		explanation.rule("types are subtypes of themselves")
+		explanation.setResult(true)
 		return true
 	}
 
-	return checkSubTypeWithoutEquality(subType, superType)
+	result := checkSubTypeWithoutEquality(subType, superType, explanation)
+	explanation.setResult(result)
+	return result
 }
 
 // IsSameTypeKind determines if the given subtype belongs to the
@@ -7484,7 +7517,7 @@ func IsProperSubType(subType Type, superType Type) bool {
 		return false
 	}
 
-	return checkSubTypeWithoutEquality(subType, superType)
+	return checkSubTypeWithoutEquality(subType, superType, nil)
 }
 
 // checkSubTypeWithoutEquality determines if the given subtype
@@ -7492,76 +7525,99 @@ func IsProperSubType(subType Type, superType Type) bool {
 // the equality of the two types, so does NOT return a specific
 // value when the two types are equal or are not.
 //
+// The applied subtyping rules are recorded in the given explanation, if any.
+// Keep the rules in sync with their descriptions.
+//
 // Consider using IsSubType or IsProperSubType
-func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
+func checkSubTypeWithoutEquality(subType Type, superType Type, explanation *SubtypeExplanation) bool {
 
 	if subType == NeverType {
+		explanation.rule("`Never` is a subtype of all types")
 		return true
 	}
 
 	switch superType {
 	case AnyType:
+		explanation.rule("all types are subtypes of `Any`")
 		return true
 
 	case AnyStructType:
 		if subType.IsResourceType() {
+			explanation.rule("resource types are not subtypes of `AnyStruct`")
 			return false
 		}
+		explanation.rule("all non-resource types, except `Any`, are subtypes of `AnyStruct`")
 		return subType != AnyType
 
 	case AnyResourceType:
+		explanation.rule("only resource types are subtypes of `AnyResource`")
 		return subType.IsResourceType()
 
 	case AnyResourceAttachmentType:
+		explanation.rule("only resource attachment types are subtypes of `AnyResourceAttachment`")
 		return subType.IsResourceType() && isAttachmentType(subType)
 
 	case AnyStructAttachmentType:
+		explanation.rule("only struct attachment types are subtypes of `AnyStructAttachment`")
 		return !subType.IsResourceType() && isAttachmentType(subType)
 
 	case HashableStructType:
+		explanation.rule("only hashable struct types are subtypes of `HashableStruct`")
 		return IsHashableStructType(subType)
 
 	case PathType:
-		return IsSubType(subType, StoragePathType) ||
-			IsSubType(subType, CapabilityPathType)
+		explanation.rule("a type is a subtype of `Path` if it is a subtype of `StoragePath` or `CapabilityPath`")
+		return explanation.checkPremise(subType, StoragePathType) ||
+			explanation.checkPremise(subType, CapabilityPathType)
 
 	case StorableType:
+		explanation.rule("only storable types are subtypes of `Storable`")
 		storableResults := map[*Member]bool{}
 		return subType.IsStorable(storableResults)
 
 	case CapabilityPathType:
-		return IsSubType(subType, PrivatePathType) ||
-			IsSubType(subType, PublicPathType)
+		explanation.rule("a type is a subtype of `CapabilityPath` if it is a subtype of `PrivatePath` or `PublicPath`")
+		return explanation.checkPremise(subType, PrivatePathType) ||
+			explanation.checkPremise(subType, PublicPathType)
 
 	case NumberType:
 		switch subType {
 		case NumberType, SignedNumberType:
+			explanation.rule("`SignedNumber` is a subtype of `Number`")
 			return true
 		}
 
-		return IsSubType(subType, IntegerType) ||
-			IsSubType(subType, FixedPointType)
+		explanation.rule("a type is a subtype of `Number` if it is a subtype of `Integer` or `FixedPoint`")
+		return explanation.checkPremise(subType, IntegerType) ||
+			explanation.checkPremise(subType, FixedPointType)
 
 	case SignedNumberType:
 		if subType == SignedNumberType {
+			explanation.rule("types are subtypes of themselves")
 			return true
 		}
 
-		return IsSubType(subType, SignedIntegerType) ||
-			IsSubType(subType, SignedFixedPointType)
+		explanation.rule("a type is a subtype of `SignedNumber` if it is a subtype of `SignedInteger` or `SignedFixedPoint`")
+		return explanation.checkPremise(subType, SignedIntegerType) ||
+			explanation.checkPremise(subType, SignedFixedPointType)
 
 	case IntegerType:
 		switch subType {
 		case IntegerType, SignedIntegerType, FixedSizeUnsignedIntegerType,
 			UIntType:
 
+			explanation.rule("`SignedInteger`, `FixedSizeUnsignedInteger`, and `UInt` are subtypes of `Integer`")
 			return true
 
 		default:
-			return IsSubType(subType, SignedIntegerType) || IsSubType(subType, FixedSizeUnsignedIntegerType)
+			explanation.rule("a type is a subtype of `Integer` if it is a subtype of `SignedInteger` or `FixedSizeUnsignedInteger`")
+			return explanation.checkPremise(subType, SignedIntegerType) ||
+				explanation.checkPremise(subType, FixedSizeUnsignedIntegerType)
 		}
 
 	case SignedIntegerType:
+		explanation.rule("only the signed integer types are subtypes of `SignedInteger`")
+
 		switch subType {
 		case SignedIntegerType,
 			IntType,
@@ -7574,6 +7630,8 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 		}
 
 	case FixedSizeUnsignedIntegerType:
+		explanation.rule("only the fixed-size unsigned integer types are subtypes of `FixedSizeUnsignedInteger`")
+
 		switch subType {
 		case UInt8Type, UInt16Type, UInt32Type, UInt64Type, UInt128Type, UInt256Type,
 			Word8Type, Word16Type, Word32Type, Word64Type, Word128Type, Word256Type:
@@ -7589,13 +7647,17 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 		case FixedPointType, SignedFixedPointType,
 			UFix64Type:
 
+			explanation.rule("`SignedFixedPoint` and `UFix64` are subtypes of `FixedPoint`")
 			return true
 
 		default:
-			return IsSubType(subType, SignedFixedPointType)
+			explanation.rule("a type is a subtype of `FixedPoint` if it is a subtype of `SignedFixedPoint`")
+			return explanation.checkPremise(subType, SignedFixedPointType)
 		}
 
 	case SignedFixedPointType:
+		explanation.rule("only the signed fixed-point types are subtypes of `SignedFixedPoint`")
+
 		switch subType {
 		case SignedFixedPointType, Fix64Type:
 			return true
@@ -7610,27 +7672,33 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 		optionalSubType, ok := subType.(*OptionalType)
 		if !ok {
 			// T <: U? if T <: U
-			return IsSubType(subType, typedSuperType.Type)
+			explanation.rule("a non-optional type `T` is a subtype of an optional type `U?` if `T` is a subtype of `U`")
+			return explanation.checkPremise(subType, typedSuperType.Type)
 		}
 		// Optionals are covariant: T? <: U? if T <: U
-		return IsSubType(optionalSubType.Type, typedSuperType.Type)
+		explanation.rule("optionals are covariant: `T?` is a subtype of `U?` if `T` is a subtype of `U`")
+		return explanation.checkPremise(optionalSubType.Type, typedSuperType.Type)
 
 	case *DictionaryType:
 		typedSubType, ok := subType.(*DictionaryType)
 		if !ok {
+			explanation.rule("only dictionary types are subtypes of dictionary types")
 			return false
 		}
 
-		return IsSubType(typedSubType.KeyType, typedSuperType.KeyType) &&
-			IsSubType(typedSubType.ValueType, typedSuperType.ValueType)
+		explanation.rule("dictionaries are covariant: `{K1: V1}` is a subtype of `{K2: V2}` if `K1` is a subtype of `K2` and `V1` is a subtype of `V2`")
+		return explanation.checkPremise(typedSubType.KeyType, typedSuperType.KeyType) &&
+			explanation.checkPremise(typedSubType.ValueType, typedSuperType.ValueType)
 
 	case *VariableSizedType:
 		typedSubType, ok := subType.(*VariableSizedType)
 		if !ok {
+			explanation.rule("only variable-sized array types are subtypes of variable-sized array types")
 			return false
 		}
 
-		return IsSubType(
+		explanation.rule("arrays are covariant: `[T]` is a subtype of `[U]` if `T` is a subtype of `U`")
+		return explanation.checkPremise(
 			typedSubType.ElementType(false),
 			typedSuperType.ElementType(false),
 		)
@@ -7638,14 +7706,17 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 	case *ConstantSizedType:
 		typedSubType, ok := subType.(*ConstantSizedType)
 		if !ok {
+			explanation.rule("only constant-sized array types are subtypes of constant-sized array types")
 			return false
 		}
 
 		if typedSubType.Size != typedSuperType.Size {
+			explanation.rule("constant-sized array types of different sizes are not subtypes of each other")
 			return false
 		}
 
-		return IsSubType(
+		explanation.rule("arrays are covariant: `[T; N]` is a subtype of `[U; N]` if `T` is a subtype of `U`")
+		return explanation.checkPremise(
 			typedSubType.ElementType(false),
 			typedSuperType.ElementType(false),
 		)
@@ -7653,37 +7724,52 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 	case *ReferenceType:
 		typedSubType, ok := subType.(*ReferenceType)
 		if !ok {
+			explanation.rule("only reference types are subtypes of reference types")
 			return false
 		}
 
 		// the authorization of the subtype reference must be usable in all situations where the supertype reference is usable
 		if !typedSuperType.Authorization.PermitsAccess(typedSubType.Authorization) {
+			if explanation != nil {
+				explanation.rule(fmt.Sprintf(
+					"the authorization of the subtype reference must permit the access of the supertype reference, "+
+						"but `%s` is not permitted by `%s`",
+					typedSuperType.Authorization.QualifiedString(),
+					typedSubType.Authorization.QualifiedString(),
+				))
+			}
 			return false
 		}
 
 		// references are covariant in their referenced type
-		return IsSubType(typedSubType.Type, typedSuperType.Type)
+		explanation.rule("references are covariant: `&T` is a subtype of `&U` if `T` is a subtype of `U`, and the authorization of `&T` permits the access of `&U`")
+		return explanation.checkPremise(typedSubType.Type, typedSuperType.Type)
 
 	case *FunctionType:
 		typedSubType, ok := subType.(*FunctionType)
 		if !ok {
+			explanation.rule("only function types are subtypes of function types")
 			return false
 		}
 
 		// view functions are subtypes of impure functions
 		if typedSubType.Purity != typedSuperType.Purity && typedSubType.Purity != FunctionPurityView {
+			explanation.rule("impure functions are not subtypes of view functions")
 			return false
 		}
 
 		if len(typedSubType.Parameters) != len(typedSuperType.Parameters) {
+			explanation.rule("functions with different numbers of parameters are not subtypes of each other")
 			return false
 		}
 
+		explanation.rule("functions are contravariant in their parameter types, and covariant in their return type")
+
 		// Functions are contravariant in their parameter types
 
 		for i, subParameter := range typedSubType.Parameters {
 			superParameter := typedSuperType.Parameters[i]
-			if !IsSubType(
+			if !explanation.checkPremise(
 				superParameter.TypeAnnotation.Type,
 				subParameter.TypeAnnotation.Type,
 			) {
@@ -7695,16 +7781,18 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 
 		if typedSubType.ReturnTypeAnnotation.Type != nil {
 			if typedSuperType.ReturnTypeAnnotation.Type == nil {
+				explanation.rule("functions with a return type are not subtypes of functions without a return type")
 				return false
 			}
 
-			if !IsSubType(
+			if !explanation.checkPremise(
 				typedSubType.ReturnTypeAnnotation.Type,
 				typedSuperType.ReturnTypeAnnotation.Type,
 			) {
 				return false
 			}
 		} else if typedSuperType.ReturnTypeAnnotation.Type != nil {
+			explanation.rule("functions without a return type are not subtypes of functions with a return type")
 			return false
 		}
 
@@ -7715,6 +7803,7 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 		// Constructors?
 
 		if typedSubType.IsConstructor != typedSuperType.IsConstructor {
+			explanation.rule("constructor functions and non-constructor functions are not subtypes of each other")
 			return false
 		}
 
@@ -7753,6 +7842,7 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 				// - `AnyStruct{Us}`: never.
 				// - `Any{Us}`: not statically;
 
+				explanation.rule("`AnyResource` is not statically a subtype of an intersection type")
 				return false
 
 			case AnyStructType:
@@ -7761,6 +7851,7 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 				// - `AnyResource{Us}`: never;
 				// - `Any{Us}`: not statically.
 
+				explanation.rule("`AnyStruct` is not statically a subtype of an intersection type")
 				return false
 
 			case AnyType:
@@ -7769,6 +7860,7 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 				// - `AnyStruct{Us}`: never;
 				// - `AnyResource{Us}`: never;
 
+				explanation.rule("`Any` is not statically a subtype of an intersection type")
 				return false
 			}
 
@@ -7784,8 +7876,11 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 					// An intersection type `{Us}` is a subtype of an intersection type `{Vs}` / `{Vs}` / `{Vs}`:
 					// when `Vs` is a subset of `Us`.
 
-					return typedSuperType.EffectiveIntersectionSet().
-						IsSubsetOf(typedSubType.EffectiveIntersectionSet())
+					return explanation.checkInterfaces(
+						typedSuperType.EffectiveIntersectionSet(),
+						typedSubType.EffectiveIntersectionSet(),
+						"an intersection type `{Us}` is a subtype of an intersection type `{Vs}` if `Vs` is a subset of `Us`",
+					)
 
 				case AnyResourceType, AnyStructType, AnyType:
 					// When `T == AnyResource || T == AnyStruct || T == Any`:
@@ -7793,14 +7888,19 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 					// is a subtype of the intersection supertype,
 					// and `Vs` is a subset of `Us`.
 
+					explanation.rule("an intersection type `T{Us}` is a subtype of an intersection type `V{Vs}` if `T` is a subtype of `V`, and `Vs` is a subset of `Us`")
+
 					if intersectionSuperType != nil &&
-						!IsSubType(intersectionSubtype, intersectionSuperType) {
+						!explanation.checkPremise(intersectionSubtype, intersectionSuperType) {
 
 						return false
 					}
 
-					return typedSuperType.EffectiveIntersectionSet().
-						IsSubsetOf(typedSubType.EffectiveIntersectionSet())
+					return explanation.checkInterfaces(
+						typedSuperType.EffectiveIntersectionSet(),
+						typedSubType.EffectiveIntersectionSet(),
+						"an intersection type `T{Us}` is a subtype of an intersection type `V{Vs}` if `T` is a subtype of `V`, and `Vs` is a subset of `Us`",
+					)
 				}
 
 				if intersectionSubtype, ok := intersectionSubtype.(*CompositeType); ok {
@@ -7810,14 +7910,19 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 					// and `T` conforms to `Vs`.
 					// `Us` and `Vs` do *not* have to be subsets.
 
+					explanation.rule("an intersection type `T{Us}` is a subtype of an intersection type `V{Vs}` if `T` is a subtype of `V`, and `T` conforms to `Vs`")
+
 					if intersectionSuperType != nil &&
-						!IsSubType(intersectionSubtype, intersectionSuperType) {
+						!explanation.checkPremise(intersectionSubtype, intersectionSuperType) {
 
 						return false
 					}
 
-					return typedSuperType.EffectiveIntersectionSet().
-						IsSubsetOf(intersectionSubtype.EffectiveInterfaceConformanceSet())
+					return explanation.checkInterfaces(
+						typedSuperType.EffectiveIntersectionSet(),
+						intersectionSubtype.EffectiveInterfaceConformanceSet(),
+						"an intersection type `T{Us}` is a subtype of an intersection type `V{Vs}` if `T` is a subtype of `V`, and `T` conforms to `Vs`",
+					)
 				}
 
 			case ConformingType:
@@ -7826,14 +7931,19 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 				// if `T` is a subtype of the intersection supertype,
 				// and `T` conforms to `Us`.
 
+				explanation.rule("a type `T` is a subtype of an intersection type `{Us}` if `T` conforms to `Us`")
+
 				if intersectionSuperType != nil &&
-					!IsSubType(typedSubType, intersectionSuperType) {
+					!explanation.checkPremise(typedSubType, intersectionSuperType) {
 
 					return false
 				}
 
-				return typedSuperType.EffectiveIntersectionSet().
-					IsSubsetOf(typedSubType.EffectiveInterfaceConformanceSet())
+				return explanation.checkInterfaces(
+					typedSuperType.EffectiveIntersectionSet(),
+					typedSubType.EffectiveInterfaceConformanceSet(),
+					"a type `T` is a subtype of an intersection type `{Us}` if `T` conforms to `Us`",
+				)
 			}
 
 		default:
@@ -7850,6 +7960,7 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 				case nil, AnyResourceType, AnyStructType, AnyType:
 					// When `T == AnyResource || T == AnyStruct || T == Any`:
 					// not statically.
+					explanation.rule("an intersection type without a concrete type is not statically a subtype of an intersection type with a concrete type")
 					return false
 				}
 
@@ -7859,6 +7970,7 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 					// `Us` and `Ws` do *not* have to be subsets:
 					// The owner may freely restrict and unrestrict.
 
+					explanation.rule("an intersection type `T{Us}` is a subtype of an intersection type `V{Ws}` if `T` is `V`")
 					return intersectionSubType == intersectionSuperType
 				}
 
@@ -7868,7 +7980,8 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 				//
 				// The owner may freely restrict.
 
-				return IsSubType(typedSubType, intersectionSuperType)
+				explanation.rule("a composite type `T` is a subtype of an intersection type `U{Vs}` if `T` is a subtype of `U`")
+				return explanation.checkPremise(typedSubType, intersectionSuperType)
 			}
 
 			switch subType {
@@ -7877,6 +7990,7 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 				// is a subtype of an intersection type `AnyResource{Vs}` / `AnyStruct{Vs}` / `Any{Vs}`:
 				// not statically.
 
+				explanation.rule("`AnyResource`, `AnyStruct`, and `Any` are not statically subtypes of an intersection type")
 				return false
 			}
 		}
@@ -7901,6 +8015,7 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 			switch legacyType {
 			case nil, AnyResourceType, AnyStructType, AnyType:
 				// When `T == AnyResource || T == AnyStruct || T == Any`: not statically.
+				explanation.rule("an intersection type `{Us}` is never a subtype of a composite type")
 				return false
 			}
 
@@ -7909,11 +8024,13 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 				//
 				// The owner may freely unrestrict.
 
+				explanation.rule("an intersection type `T{Us}` is a subtype of a composite type `V` if `T` is `V`")
 				return intersectionSubType == typedSuperType
 			}
 
 		case *CompositeType:
 			// Non-equal composite types are never subtypes of each other
+			explanation.rule("different composite types are never subtypes of each other")
 			return false
 		}
 
@@ -7926,9 +8043,11 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 			// if `T` conforms to `V`, and `V` and `T` are of the same kind
 
 			if typedSubType.Kind != typedSuperType.CompositeKind {
+				explanation.rule("a composite type is not a subtype of an interface type of a different kind")
 				return false
 			}
 
+			explanation.rule("a composite type `T` is a subtype of an interface type `V` if `T` conforms to `V`")
 			return typedSubType.EffectiveInterfaceConformanceSet().
 				Contains(typedSuperType)
 
@@ -7944,9 +8063,11 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 		//   let i : {I} = ... // some operation constructing `i`
 		//   let a = i[A] // must here check that `i`'s type is a subtype of `A`'s base type, or that {I} <: I
 		case *IntersectionType:
+			explanation.rule("an intersection type `{Us}` is a subtype of an interface type `V` if `V` is one of `Us`, or one of their conformances")
 			return typedSubType.EffectiveIntersectionSet().Contains(typedSuperType)
 
 		case *InterfaceType:
+			explanation.rule("an interface type `U` is a subtype of an interface type `V` if `U` conforms to `V`")
 			return typedSubType.EffectiveInterfaceConformanceSet().
 				Contains(typedSuperType)
 		}
@@ -7960,7 +8081,9 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 			if typedSubType, ok := subType.(ParameterizedType); ok {
 				if subTypeBaseType := typedSubType.BaseType(); subTypeBaseType != nil {
 
-					if !IsSubType(subTypeBaseType, superTypeBaseType) {
+					explanation.rule("a parameterized type `T<Us>` is a subtype of a parameterized type `V<Ws>` if `T` is a subtype of `V`, and each `U` is a subtype of the corresponding `W`")
+
+					if !explanation.checkPremise(subTypeBaseType, superTypeBaseType) {
 						return false
 					}
 
@@ -7968,12 +8091,13 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 					superTypeTypeArguments := typedSuperType.TypeArguments()
 
 					if len(subTypeTypeArguments) != len(superTypeTypeArguments) {
+						explanation.rule("parameterized types with different numbers of type arguments are not subtypes of each other")
 						return false
 					}
 
 					for i, superTypeTypeArgument := range superTypeTypeArguments {
 						subTypeTypeArgument := subTypeTypeArguments[i]
-						if !IsSubType(subTypeTypeArgument, superTypeTypeArgument) {
+						if !explanation.checkPremise(subTypeTypeArgument, superTypeTypeArgument) {
 							return false
 						}
 					}
@@ -7991,10 +8115,12 @@ func checkSubTypeWithoutEquality(subType Type, superType Type) bool {
 
 	if typedSubType, ok := subType.(ParameterizedType); ok {
 		if baseType := typedSubType.BaseType(); baseType != nil {
-			return IsSubType(baseType, superType)
+			explanation.rule("a parameterized type `T<Us>` is a subtype of a type `V` if `T` is a subtype of `V`")
+			return explanation.checkPremise(baseType, superType)
 		}
 	}
 
+	explanation.rule("no subtyping rule applies")
 	return false
 }
 
@@ -9559,6 +9685,7 @@ func init() {
 		SignatureAlgorithmType,
 		AccountType,
 		DeploymentResultType,
+		ContractMetadataType,
 	}
 
 	extractNativeTypes(compositeTypes)
@@ -0,0 +1,153 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"slices"
+	"strings"
+)
+
+// TypeHierarchy allows querying the conformance relationships
+// of the composite and interface types declared in a set of checked programs,
+// e.g. to find all implementations of an interface
+type TypeHierarchy struct {
+	// conformingTypes maps the type ID of an interface type
+	// to all types which directly or indirectly conform to it
+	conformingTypes map[TypeID][]ConformingType
+}
+
+// NewTypeHierarchy returns the type hierarchy for the composite and interface types
+// declared in the programs with the given elaborations.
+// Types declared in multiple elaborations, e.g. through imports, are only considered once
+func NewTypeHierarchy(elaborations ...*Elaboration) *TypeHierarchy {
+	hierarchy := &TypeHierarchy{
+		conformingTypes: map[TypeID][]ConformingType{},
+	}
+
+	seen := map[TypeID]struct{}{}
+
+	add := func(ty ConformingType) {
+		typeID := ty.ID()
+		if _, ok := seen[typeID]; ok {
+			return
+		}
+		seen[typeID] = struct{}{}
+
+		ty.EffectiveInterfaceConformanceSet().ForEach(func(interfaceType *InterfaceType) {
+			interfaceTypeID := interfaceType.ID()
+			hierarchy.conformingTypes[interfaceTypeID] = append(
+				hierarchy.conformingTypes[interfaceTypeID],
+				ty,
+			)
+		})
+	}
+
+	for _, elaboration := range elaborations {
+		elaboration.ForEachCompositeType(func(ty *CompositeType) {
+			add(ty)
+		})
+		elaboration.ForEachInterfaceType(func(ty *InterfaceType) {
+			add(ty)
+		})
+	}
+
+	// Sort the conforming types, so results are deterministic
+
+	for _, types := range hierarchy.conformingTypes { //nolint:maprange
+		slices.SortFunc(types, compareTypeIDs)
+	}
+
+	return hierarchy
+}
+
+func compareTypeIDs[T Type](a, b T) int {
+	return strings.Compare(string(a.ID()), string(b.ID()))
+}
+
+// ConformingTypes returns all composite and interface types
+// which directly or indirectly conform to the given interface type,
+// sorted by type ID
+func (h *TypeHierarchy) ConformingTypes(interfaceType *InterfaceType) []ConformingType {
+	return slices.Clone(h.conformingTypes[interfaceType.ID()])
+}
+
+// ConformingCompositeTypes returns all composite types
+// which directly or indirectly conform to the given interface type,
+// i.e. the implementations of the interface, sorted by type ID
+func (h *TypeHierarchy) ConformingCompositeTypes(interfaceType *InterfaceType) []*CompositeType {
+	var result []*CompositeType
+	for _, ty := range h.conformingTypes[interfaceType.ID()] {
+		if compositeType, ok := ty.(*CompositeType); ok {
+			result = append(result, compositeType)
+		}
+	}
+	return result
+}
+
+// SuperInterfaces returns all interface types
+// the given composite or interface type directly or indirectly conforms to,
+// sorted by type ID
+func (h *TypeHierarchy) SuperInterfaces(ty ConformingType) []*InterfaceType {
+	conformances := ty.EffectiveInterfaceConformanceSet()
+
+	result := make([]*InterfaceType, 0, conformances.Len())
+	conformances.ForEach(func(interfaceType *InterfaceType) {
+		result = append(result, interfaceType)
+	})
+
+	slices.SortFunc(result, compareTypeIDs)
+
+	return result
+}
+
+// EntitlementSurface returns all entitlements which may be used
+// to access the members of the given composite or interface type,
+// including the entitlements supported through its super-interfaces,
+// and for attachments, through its base type.
+//
+// Entitlements are sorted by type ID.
+// Disjunctions of entitlements are flattened into their entitlements
+func (h *TypeHierarchy) EntitlementSurface(ty EntitlementSupportingType) []*EntitlementType {
+	supportedEntitlements := ty.SupportedEntitlements()
+
+	seen := map[*EntitlementType]struct{}{}
+	var result []*EntitlementType
+
+	add := func(entitlementType *EntitlementType, _ struct{}) {
+		if _, ok := seen[entitlementType]; ok {
+			return
+		}
+		seen[entitlementType] = struct{}{}
+		result = append(result, entitlementType)
+	}
+
+	if supportedEntitlements.Entitlements != nil {
+		supportedEntitlements.Entitlements.Foreach(add)
+	}
+
+	if supportedEntitlements.Disjunctions != nil {
+		supportedEntitlements.Disjunctions.Foreach(func(_ string, disjunction *EntitlementOrderedSet) {
+			disjunction.Foreach(add)
+		})
+	}
+
+	slices.SortFunc(result, compareTypeIDs)
+
+	return result
+}
@@ -0,0 +1,197 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func TestTypeHierarchy(t *testing.T) {
+
+	t.Parallel()
+
+	importedChecker, err := ParseAndCheckWithOptions(t,
+		`
+          access(all) entitlement E
+          access(all) entitlement F
+          access(all) entitlement G
+
+          access(all) resource interface I {
+              access(E) fun foo()
+          }
+
+          access(all) resource interface J: I {
+              access(F | G) fun bar()
+          }
+
+          access(all) resource R: J {
+              access(E) fun foo() {}
+              access(F | G) fun bar() {}
+          }
+        `,
+		ParseAndCheckOptions{
+			Location: ImportedLocation,
+		},
+	)
+	require.NoError(t, err)
+
+	checker, err := ParseAndCheckWithOptions(t,
+		`
+          import E, F, G, I, J from "imported"
+
+          access(all) contract C {
+
+              access(all) resource interface K: J {}
+
+              access(all) resource S: K {
+                  access(E) fun foo() {}
+                  access(F | G) fun bar() {}
+              }
+
+              access(all) resource T: I {
+                  access(E) fun foo() {}
+              }
+          }
+        `,
+		ParseAndCheckOptions{
+			Config: &sema.Config{
+				ImportHandler: func(_ *sema.Checker, _ common.Location, _ ast.Range) (sema.Import, error) {
+					return sema.ElaborationImport{
+						Elaboration: importedChecker.Elaboration,
+					}, nil
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	hierarchy := sema.NewTypeHierarchy(
+		importedChecker.Elaboration,
+		checker.Elaboration,
+	)
+
+	typeIDs := func(types []sema.ConformingType) (result []sema.TypeID) {
+		for _, ty := range types {
+			result = append(result, ty.ID())
+		}
+		return
+	}
+
+	interfaceI := importedChecker.Elaboration.InterfaceType("S.imported.I")
+	require.NotNil(t, interfaceI)
+
+	interfaceJ := importedChecker.Elaboration.InterfaceType("S.imported.J")
+	require.NotNil(t, interfaceJ)
+
+	interfaceK := checker.Elaboration.InterfaceType("S.test.C.K")
+	require.NotNil(t, interfaceK)
+
+	compositeS := checker.Elaboration.CompositeType("S.test.C.S")
+	require.NotNil(t, compositeS)
+
+	t.Run("conforming types", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t,
+			[]sema.TypeID{
+				"S.imported.J",
+				"S.imported.R",
+				"S.test.C.K",
+				"S.test.C.S",
+				"S.test.C.T",
+			},
+			typeIDs(hierarchy.ConformingTypes(interfaceI)),
+		)
+
+		assert.Equal(t,
+			[]sema.TypeID{
+				"S.test.C.S",
+			},
+			typeIDs(hierarchy.ConformingTypes(interfaceK)),
+		)
+	})
+
+	t.Run("conforming composite types", func(t *testing.T) {
+		t.Parallel()
+
+		var compositeTypeIDs []sema.TypeID
+		for _, ty := range hierarchy.ConformingCompositeTypes(interfaceJ) {
+			compositeTypeIDs = append(compositeTypeIDs, ty.ID())
+		}
+
+		assert.Equal(t,
+			[]sema.TypeID{
+				"S.imported.R",
+				"S.test.C.S",
+			},
+			compositeTypeIDs,
+		)
+	})
+
+	t.Run("super-interfaces", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t,
+			[]*sema.InterfaceType{
+				interfaceI,
+				interfaceJ,
+				interfaceK,
+			},
+			hierarchy.SuperInterfaces(compositeS),
+		)
+
+		assert.Empty(t, hierarchy.SuperInterfaces(interfaceI))
+	})
+
+	t.Run("entitlement surface", func(t *testing.T) {
+		t.Parallel()
+
+		entitlementIDs := func(entitlementTypes []*sema.EntitlementType) (result []sema.TypeID) {
+			for _, entitlementType := range entitlementTypes {
+				result = append(result, entitlementType.ID())
+			}
+			return
+		}
+
+		assert.Equal(t,
+			[]sema.TypeID{
+				"S.imported.E",
+				"S.imported.F",
+				"S.imported.G",
+			},
+			entitlementIDs(hierarchy.EntitlementSurface(compositeS)),
+		)
+
+		assert.Equal(t,
+			[]sema.TypeID{
+				"S.imported.E",
+			},
+			entitlementIDs(hierarchy.EntitlementSurface(interfaceI)),
+		)
+	})
+}
@@ -0,0 +1,201 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+)
+
+// UnusedDeclarationWarning is reported for a local variable or function,
+// a function parameter, or a private member function, which is never used
+type UnusedDeclarationWarning struct {
+	Name string
+	Kind common.DeclarationKind
+	ast.Range
+}
+
+var _ error = &UnusedDeclarationWarning{}
+var _ errors.SecondaryError = &UnusedDeclarationWarning{}
+
+func (w *UnusedDeclarationWarning) Error() string {
+	return fmt.Sprintf(
+		"%s `%s` is never used",
+		w.Kind.Name(),
+		w.Name,
+	)
+}
+
+func (w *UnusedDeclarationWarning) SecondaryError() string {
+	return fmt.Sprintf("consider removing the %s", w.Kind.Name())
+}
+
+// usedMember identifies a member of a type by the type and the member's name
+type usedMember struct {
+	containerType Type
+	identifier    string
+}
+
+// trackVariableUse starts tracking the uses of the given local variable or parameter.
+// If it is not used by the time its scope is left, a warning is reported
+func (checker *Checker) trackVariableUse(variable *Variable) {
+	if !checker.Config.UnusedDeclarationWarningsEnabled || variable == nil {
+		return
+	}
+
+	if checker.unusedVariables == nil {
+		checker.unusedVariables = map[*Variable]struct{}{}
+	}
+	checker.unusedVariables[variable] = struct{}{}
+}
+
+func (checker *Checker) recordVariableUse(variable *Variable) {
+	if checker.unusedVariables == nil {
+		return
+	}
+
+	delete(checker.unusedVariables, variable)
+}
+
+func (checker *Checker) recordMemberUse(member *Member) {
+	if !checker.Config.UnusedDeclarationWarningsEnabled {
+		return
+	}
+
+	if checker.usedMembers == nil {
+		checker.usedMembers = map[usedMember]struct{}{}
+	}
+	checker.usedMembers[usedMember{
+		containerType: member.ContainerType,
+		identifier:    member.Identifier.Identifier,
+	}] = struct{}{}
+}
+
+// checkUnusedVariables reports a warning for each tracked variable
+// which is declared in the current scope and was not used
+func (checker *Checker) checkUnusedVariables() {
+	if len(checker.unusedVariables) == 0 {
+		return
+	}
+
+	depth := checker.valueActivations.Depth()
+
+	checker.valueActivations.ForEachVariableDeclaredInAndBelow(depth, func(name string, variable *Variable) {
+		if _, ok := checker.unusedVariables[variable]; !ok {
+			return
+		}
+		delete(checker.unusedVariables, variable)
+
+		checker.reportWarning(
+			&UnusedDeclarationWarning{
+				Name: name,
+				Kind: variable.DeclarationKind,
+				Range: ast.NewRange(
+					checker.memoryGauge,
+					*variable.Pos,
+					variable.Pos.Shifted(checker.memoryGauge, len(name)-1),
+				),
+			},
+		)
+	})
+}
+
+// checkUnusedPrivateFunctions reports a warning for each private (`access(self)`) function
+// of the given composite which is never used within the composite.
+//
+// NOTE: must be called after the composite, including its nested declarations, was checked
+func (checker *Checker) checkUnusedPrivateFunctions(
+	compositeType *CompositeType,
+	functions []*ast.FunctionDeclaration,
+) {
+	if !checker.Config.UnusedDeclarationWarningsEnabled {
+		return
+	}
+
+	for _, function := range functions {
+		if function.Access != ast.AccessSelf {
+			continue
+		}
+
+		identifier := function.Identifier
+		_, used := checker.usedMembers[usedMember{
+			containerType: compositeType,
+			identifier:    identifier.Identifier,
+		}]
+		if used {
+			continue
+		}
+
+		checker.reportWarning(
+			&UnusedDeclarationWarning{
+				Name: identifier.Identifier,
+				Kind: common.DeclarationKindFunction,
+				Range: ast.NewRange(
+					checker.memoryGauge,
+					identifier.StartPosition(),
+					identifier.EndPosition(checker.memoryGauge),
+				),
+			},
+		)
+	}
+}
+
+// isInterfaceRequirement returns true if the member with the given name
+// is required by an interface the composite conforms to.
+// The parameters of such a function must be kept, even if they are unused
+func isInterfaceRequirement(compositeType *CompositeType, name string) bool {
+	for _, conformance := range compositeType.EffectiveInterfaceConformances() {
+		if _, ok := conformance.InterfaceType.Members.Get(name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// DeadCode returns the ranges of code which has no effect,
+// i.e. unreachable statements, and declarations which are never used,
+// ordered by their start position.
+// For example, editors may gray out these ranges.
+//
+// Unused declarations are only reported if Config.UnusedDeclarationWarningsEnabled is set
+func (checker *Checker) DeadCode() []ast.Range {
+	var ranges []ast.Range
+
+	for _, err := range checker.errors {
+		if unreachableStatementErr, ok := err.(*UnreachableStatementError); ok {
+			ranges = append(ranges, unreachableStatementErr.Range)
+		}
+	}
+
+	for _, warning := range checker.warnings {
+		if unusedDeclarationWarning, ok := warning.(*UnusedDeclarationWarning); ok {
+			ranges = append(ranges, unusedDeclarationWarning.Range)
+		}
+	}
+
+	slices.SortStableFunc(ranges, func(a, b ast.Range) int {
+		return a.StartPos.Compare(b.StartPos)
+	})
+
+	return ranges
+}
@@ -0,0 +1,286 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func TestCheckUnusedDeclarationWarnings(t *testing.T) {
+
+	t.Parallel()
+
+	config := &sema.Config{
+		UnusedDeclarationWarningsEnabled: true,
+	}
+
+	parseAndCheck := func(t *testing.T, code string) *sema.Checker {
+		checker, err := ParseAndCheckWithOptions(t,
+			code,
+			ParseAndCheckOptions{
+				Config: config,
+			},
+		)
+		require.NoError(t, err)
+		return checker
+	}
+
+	unusedDeclarations := func(checker *sema.Checker) []string {
+		var descriptions []string
+		for _, warning := range checker.Warnings() {
+			var unusedDeclarationWarning *sema.UnusedDeclarationWarning
+			if assert.ErrorAs(t, warning, &unusedDeclarationWarning) {
+				descriptions = append(descriptions, unusedDeclarationWarning.Error())
+			}
+		}
+		return descriptions
+	}
+
+	t.Run("local variables", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          fun test(): Int {
+              let a = 1
+              var b = 2
+              let c = 3
+              var d = 4
+              d = 5
+              if true {
+                  let e = 6
+              }
+              return c
+          }
+        `)
+
+		assert.Equal(t,
+			[]string{
+				"constant `e` is never used",
+				"constant `a` is never used",
+				"variable `b` is never used",
+			},
+			unusedDeclarations(checker),
+		)
+
+		warning := checker.Warnings()[1].(*sema.UnusedDeclarationWarning)
+		assert.Equal(t,
+			ast.Range{
+				StartPos: ast.Position{Offset: 47, Line: 3, Column: 18},
+				EndPos:   ast.Position{Offset: 47, Line: 3, Column: 18},
+			},
+			warning.Range,
+		)
+	})
+
+	t.Run("global variables", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          let a = 1
+          var b = 2
+        `)
+
+		assert.Empty(t, checker.Warnings())
+	})
+
+	t.Run("local functions", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          fun test() {
+              fun a() {}
+              fun b() {}
+              b()
+          }
+        `)
+
+		assert.Equal(t,
+			[]string{"function `a` is never used"},
+			unusedDeclarations(checker),
+		)
+	})
+
+	t.Run("parameters", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          fun test(a: Int, b: Int): Int {
+              let f = fun (c: Int, d: Int): Int {
+                  return d
+              }
+              return f(c: 1, d: b)
+          }
+        `)
+
+		assert.Equal(t,
+			[]string{
+				"parameter `c` is never used",
+				"parameter `a` is never used",
+			},
+			unusedDeclarations(checker),
+		)
+	})
+
+	t.Run("parameters of functions without body", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          struct interface SI {
+              fun test(a: Int)
+          }
+
+          event E(c: Int)
+        `)
+
+		assert.Empty(t, checker.Warnings())
+	})
+
+	t.Run("parameters of interface requirements", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          struct interface SI {
+              fun test(a: Int)
+          }
+
+          struct S: SI {
+              init(b: Int) {}
+              fun test(a: Int) {}
+              fun test2(c: Int) {}
+          }
+        `)
+
+		assert.Equal(t,
+			[]string{
+				"parameter `b` is never used",
+				"parameter `c` is never used",
+			},
+			unusedDeclarations(checker),
+		)
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          transaction(a: Int) {
+              prepare(signer: &Account) {}
+          }
+        `)
+
+		assert.Empty(t, checker.Warnings())
+	})
+
+	t.Run("private functions", func(t *testing.T) {
+		t.Parallel()
+
+		checker := parseAndCheck(t, `
+          contract C {
+
+              access(self) fun a() {}
+
+              access(self) fun b() {}
+
+              access(self) fun c() {}
+
+              access(all) fun d() {}
+
+              access(contract) fun e() {}
+
+              access(all) fun test() {
+                  self.b()
+              }
+
+              resource R {
+                  access(all) fun test() {
+                      C.c()
+                  }
+              }
+          }
+        `)
+
+		assert.Equal(t,
+			[]string{"function `a` is never used"},
+			unusedDeclarations(checker),
+		)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          contract C {
+              access(self) fun a(b: Int) {
+                  let c = 1
+              }
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.Empty(t, checker.Warnings())
+	})
+}
+
+func TestCheckDeadCode(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheckWithOptions(t,
+		`
+          fun test(a: Int): Int {
+              let b = 1
+              return 2
+              let c = 3
+          }
+        `,
+		ParseAndCheckOptions{
+			Config: &sema.Config{
+				UnusedDeclarationWarningsEnabled: true,
+			},
+		},
+	)
+
+	errs := RequireCheckerErrors(t, err, 1)
+	require.IsType(t, &sema.UnreachableStatementError{}, errs[0])
+
+	var deadCode []string
+	for _, r := range checker.DeadCode() {
+		deadCode = append(deadCode, fmt.Sprintf("%d-%d", r.StartPos.Offset, r.EndPos.Offset))
+	}
+
+	assert.Equal(t,
+		[]string{
+			// parameter `a`
+			"20-20",
+			// constant `b`
+			"53-53",
+			// unreachable `let c = 3`
+			"96-104",
+		},
+		deadCode,
+	)
+}
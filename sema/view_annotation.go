@@ -0,0 +1,89 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/errors"
+)
+
+// MissingViewAnnotationWarning is reported for a function which has no side effects,
+// but is not annotated with `view`.
+// Annotating the function allows it to be called from view contexts, e.g. in scripts and conditions
+type MissingViewAnnotationWarning struct {
+	FunctionDeclaration *ast.FunctionDeclaration
+	ast.Range
+}
+
+var _ error = &MissingViewAnnotationWarning{}
+var _ errors.HasSuggestedFixes[ast.TextEdit] = &MissingViewAnnotationWarning{}
+
+func (w *MissingViewAnnotationWarning) Error() string {
+	return fmt.Sprintf(
+		"function `%s` has no side effects and can be annotated with `view`",
+		w.FunctionDeclaration.Identifier.Identifier,
+	)
+}
+
+func (w *MissingViewAnnotationWarning) SuggestFixes(code string) []errors.SuggestedFix[ast.TextEdit] {
+	// Insert the annotation before the `fun` keyword,
+	// which precedes the identifier, after all other modifiers
+
+	identifierOffset := w.FunctionDeclaration.Identifier.Pos.Offset
+	if identifierOffset > len(code) {
+		return nil
+	}
+
+	keywordOffset := strings.LastIndex(code[:identifierOffset], "fun")
+	if keywordOffset < 0 {
+		return nil
+	}
+
+	keywordPos := positionAtOffset(code, keywordOffset)
+
+	return []errors.SuggestedFix[ast.TextEdit]{
+		{
+			Message: "annotate function with `view`",
+			TextEdits: []ast.TextEdit{
+				{
+					Insertion: "view ",
+					Range: ast.NewUnmeteredRange(
+						keywordPos,
+						keywordPos,
+					),
+				},
+			},
+		},
+	}
+}
+
+// positionAtOffset returns the position of the given byte offset in the given code
+func positionAtOffset(code string, offset int) ast.Position {
+	preceding := code[:offset]
+	lineOffset := strings.LastIndexByte(preceding, '\n') + 1
+	return ast.Position{
+		Offset: offset,
+		Line:   strings.Count(preceding, "\n") + 1,
+		Column: utf8.RuneCountInString(preceding[lineOffset:]),
+	}
+}
@@ -0,0 +1,250 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func TestCheckMissingViewAnnotationWarnings(t *testing.T) {
+
+	t.Parallel()
+
+	parseAndCheck := func(t *testing.T, code string) (*sema.Checker, error) {
+		return ParseAndCheckWithOptions(t,
+			code,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					ViewAnnotationWarningsEnabled: true,
+				},
+			},
+		)
+	}
+
+	requireWarnings := func(t *testing.T, checker *sema.Checker, names ...string) []*sema.MissingViewAnnotationWarning {
+		warnings := checker.Warnings()
+		require.Len(t, warnings, len(names))
+
+		result := make([]*sema.MissingViewAnnotationWarning, 0, len(warnings))
+		for i, warning := range warnings {
+			var missingViewAnnotationWarning *sema.MissingViewAnnotationWarning
+			require.ErrorAs(t, warning, &missingViewAnnotationWarning)
+			assert.Equal(t, names[i], missingViewAnnotationWarning.FunctionDeclaration.Identifier.Identifier)
+			result = append(result, missingViewAnnotationWarning)
+		}
+		return result
+	}
+
+	applyFix := func(t *testing.T, code string, warning *sema.MissingViewAnnotationWarning) string {
+		fixes := warning.SuggestFixes(code)
+		require.Len(t, fixes, 1)
+		require.Len(t, fixes[0].TextEdits, 1)
+
+		edit := fixes[0].TextEdits[0]
+		offset := edit.StartPos.Offset
+		return code[:offset] + edit.Insertion + code[offset:]
+	}
+
+	t.Run("global function", func(t *testing.T) {
+		t.Parallel()
+
+		const code = `
+          fun add(_ a: Int, _ b: Int): Int {
+              var result = a
+              result = result + b
+              return result
+          }
+        `
+
+		checker, err := parseAndCheck(t, code)
+		require.NoError(t, err)
+
+		warnings := requireWarnings(t, checker, "add")
+		assert.Equal(t, 2, warnings[0].StartPos.Line)
+		assert.Equal(t,
+			"function `add` has no side effects and can be annotated with `view`",
+			warnings[0].Error(),
+		)
+
+		fixedCode := applyFix(t, code, warnings[0])
+		assert.Equal(t,
+			`
+          view fun add(_ a: Int, _ b: Int): Int {
+              var result = a
+              result = result + b
+              return result
+          }
+        `,
+			fixedCode,
+		)
+
+		checker, err = parseAndCheck(t, fixedCode)
+		require.NoError(t, err)
+		assert.Empty(t, checker.Warnings())
+	})
+
+	t.Run("composite function", func(t *testing.T) {
+		t.Parallel()
+
+		const code = `
+          access(all) struct S {
+              access(all) var x: Int
+
+              init() {
+                  self.x = 0
+              }
+
+              access(all) fun getX(): Int {
+                  return self.x
+              }
+
+              access(all) fun setX(_ x: Int) {
+                  self.x = x
+              }
+          }
+        `
+
+		checker, err := parseAndCheck(t, code)
+		require.NoError(t, err)
+
+		warnings := requireWarnings(t, checker, "getX")
+
+		fixedCode := applyFix(t, code, warnings[0])
+		assert.Contains(t, fixedCode, "access(all) view fun getX(): Int {")
+
+		checker, err = parseAndCheck(t, fixedCode)
+		require.NoError(t, err)
+		assert.Empty(t, checker.Warnings())
+	})
+
+	t.Run("side effects", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, `
+          var x = 0
+
+          resource R {}
+
+          fun write() {
+              x = 1
+          }
+
+          fun destroyR(_ r: @R) {
+              destroy r
+          }
+
+          fun callImpure() {
+              write()
+          }
+        `)
+		require.NoError(t, err)
+
+		requireWarnings(t, checker)
+	})
+
+	t.Run("calls", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, `
+          view fun pure(): Int {
+              return 1
+          }
+
+          fun unannotated(): Int {
+              return 2
+          }
+
+          fun callsPure(): Int {
+              return pure()
+          }
+
+          // Calling a function which is not annotated is impure,
+          // until the annotation is added to the called function
+          fun callsUnannotated(): Int {
+              return unannotated()
+          }
+        `)
+		require.NoError(t, err)
+
+		requireWarnings(t, checker, "unannotated", "callsPure")
+	})
+
+	t.Run("nested function", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, `
+          var x = 0
+
+          // The nested impure function is declared, but not called
+          fun test() {
+              fun write() {
+                  x = 1
+              }
+          }
+        `)
+		require.NoError(t, err)
+
+		requireWarnings(t, checker, "test")
+	})
+
+	t.Run("invalid function", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, `
+          fun test() {
+              undeclared()
+          }
+        `)
+		errs := RequireCheckerErrors(t, err, 1)
+		require.IsType(t, &sema.NotDeclaredError{}, errs[0])
+
+		requireWarnings(t, checker)
+	})
+
+	t.Run("interface requirement", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, `
+          struct interface I {
+              fun requirement(): Int
+          }
+        `)
+		require.NoError(t, err)
+
+		requireWarnings(t, checker)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun test(): Int {
+              return 1
+          }
+        `)
+		require.NoError(t, err)
+		assert.Empty(t, checker.Warnings())
+	})
+}
@@ -27,11 +27,9 @@ import (
 
 	"github.com/onflow/atree"
 
-	"github.com/onflow/cadence/ast"
 	"github.com/onflow/cadence/common"
 	"github.com/onflow/cadence/errors"
 	"github.com/onflow/cadence/interpreter"
-	"github.com/onflow/cadence/old_parser"
 	"github.com/onflow/cadence/parser"
 	"github.com/onflow/cadence/sema"
 )
@@ -125,6 +123,8 @@ func NewAccountConstructor(creator AccountCreator) StandardLibraryValue {
 			inter := invocation.Interpreter
 			locationRange := invocation.LocationRange
 
+			inter.CheckReadOnly(interpreter.ReadOnlyOperationAccountMutation, locationRange)
+
 			inter.ExpectType(
 				payer,
 				sema.AccountReferenceType,
@@ -334,6 +334,7 @@ type AccountContractAdditionAndNamesHandler interface {
 
 type AccountContractsHandler interface {
 	AccountContractProvider
+	AccountContractUpdateHeightProvider
 	AccountContractAdditionAndNamesHandler
 	AccountContractRemovalHandler
 }
@@ -372,6 +373,12 @@ func newAccountContractsValue(
 			handler,
 			addressValue,
 		),
+		newAccountContractsMetadataFunction(
+			inter,
+			sema.Account_ContractsTypeMetadataFunctionType,
+			handler,
+			addressValue,
+		),
 		newAccountContractsBorrowFunction(
 			inter,
 			sema.Account_ContractsTypeBorrowFunctionType,
@@ -618,6 +625,8 @@ func newAccountKeysAddFunction(
 				inter := invocation.Interpreter
 				locationRange := invocation.LocationRange
 
+				inter.CheckReadOnly(interpreter.ReadOnlyOperationAccountMutation, locationRange)
+
 				publicKey, err := NewPublicKeyFromValue(inter, locationRange, publicKeyValue)
 				if err != nil {
 					panic(err)
@@ -894,6 +903,9 @@ func newAccountKeysRevokeFunction(
 					panic(errors.NewUnreachableError())
 				}
 				locationRange := invocation.LocationRange
+
+				invocation.Interpreter.CheckReadOnly(interpreter.ReadOnlyOperationAccountMutation, locationRange)
+
 				index := indexValue.ToUint32(locationRange)
 
 				var err error
@@ -992,11 +1004,12 @@ func newAccountInboxPublishFunction(
 
 				storageMapKey := interpreter.StringStorageMapKey(nameValue.Str)
 
-				inter.WriteStored(
+				inter.WriteStoredWithLocationRange(
 					provider,
 					common.StorageDomainInbox,
 					storageMapKey,
 					publishedValue,
+					locationRange,
 				)
 
 				return interpreter.Void
@@ -1061,11 +1074,12 @@ func newAccountInboxUnpublishFunction(
 					false, // publishedValue is an element in storage map because it is returned by ReadStored.
 				)
 
-				inter.WriteStored(
+				inter.WriteStoredWithLocationRange(
 					provider,
 					common.StorageDomainInbox,
 					storageMapKey,
 					nil,
+					locationRange,
 				)
 
 				handler.EmitEvent(
@@ -1151,11 +1165,12 @@ func newAccountInboxClaimFunction(
 					false, // publishedValue is an element in storage map because it is returned by ReadStored.
 				)
 
-				inter.WriteStored(
+				inter.WriteStoredWithLocationRange(
 					providerAddress,
 					common.StorageDomainInbox,
 					storageMapKey,
 					nil,
+					locationRange,
 				)
 
 				handler.EmitEvent(
@@ -1306,6 +1321,97 @@ func newAccountContractsGetFunction(
 	}
 }
 
+type AccountContractUpdateHeightProvider interface {
+	// GetAccountContractUpdateHeight returns the height of the block
+	// in which an account contract was last added or updated, if known.
+	GetAccountContractUpdateHeight(location common.AddressLocation) (height uint64, known bool, err error)
+}
+
+type AccountContractMetadataProvider interface {
+	AccountContractProvider
+	AccountContractUpdateHeightProvider
+}
+
+func newAccountContractsMetadataFunction(
+	inter *interpreter.Interpreter,
+	functionType *sema.FunctionType,
+	provider AccountContractMetadataProvider,
+	addressValue interpreter.AddressValue,
+) interpreter.BoundFunctionGenerator {
+	return func(accountContracts interpreter.MemberAccessibleValue) interpreter.BoundFunctionValue {
+
+		// Converted addresses can be cached and don't have to be recomputed on each function invocation
+		address := addressValue.ToAddress()
+
+		return interpreter.NewBoundHostFunctionValue(
+			inter,
+			accountContracts,
+			functionType,
+			func(_ interpreter.MemberAccessibleValue, invocation interpreter.Invocation) interpreter.Value {
+				nameValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				name := nameValue.Str
+				location := common.NewAddressLocation(invocation.Interpreter, address, name)
+
+				var code []byte
+				var err error
+				errors.WrapPanic(func() {
+					code, err = provider.GetAccountContractCode(location)
+				})
+				if err != nil {
+					panic(interpreter.WrappedExternalError(err))
+				}
+
+				if len(code) == 0 {
+					return interpreter.Nil
+				}
+
+				var height uint64
+				var known bool
+				errors.WrapPanic(func() {
+					height, known, err = provider.GetAccountContractUpdateHeight(location)
+				})
+				if err != nil {
+					panic(interpreter.WrappedExternalError(err))
+				}
+
+				inter := invocation.Interpreter
+
+				var updateHeight interpreter.OptionalValue = interpreter.NilOptionalValue
+				if known {
+					updateHeight = interpreter.NewSomeValueNonCopying(
+						inter,
+						interpreter.NewUInt64Value(
+							inter,
+							func() uint64 {
+								return height
+							},
+						),
+					)
+				}
+
+				return interpreter.NewSomeValueNonCopying(
+					inter,
+					interpreter.NewContractMetadataValue(
+						inter,
+						nameValue,
+						CodeToHashValue(inter, code),
+						interpreter.NewUInt64Value(
+							inter,
+							func() uint64 {
+								return uint64(len(code))
+							},
+						),
+						updateHeight,
+					),
+				)
+			},
+		)
+	}
+}
+
 func newAccountContractsBorrowFunction(
 	inter *interpreter.Interpreter,
 	functionType *sema.FunctionType,
@@ -1439,6 +1545,8 @@ type AccountContractAdditionHandler interface {
 
 	// IsContractBeingAdded checks whether a contract is being added in the current execution.
 	IsContractBeingAdded(location common.AddressLocation) bool
+
+	ContractMetadataEventsHandler
 }
 
 // newAccountContractsChangeFunction called when e.g.
@@ -1498,6 +1606,8 @@ func changeAccountContracts(
 
 	locationRange := invocation.LocationRange
 
+	invocation.Interpreter.CheckReadOnly(interpreter.ReadOnlyOperationAccountMutation, locationRange)
+
 	const requiredArgumentCount = 2
 
 	nameValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
@@ -1566,171 +1676,25 @@ func changeAccountContracts(
 		}
 	}
 
-	// Check the code
-	handleContractUpdateError := func(err error, code []byte) {
-		if err == nil {
-			return
-		}
-
-		// Update the code for the error pretty printing.
-		// The code may be the new code, or the old code if this is an update.
-		// NOTE: only do this when an error occurs
-
-		handler.TemporarilyRecordCode(location, code)
-
-		panic(&InvalidContractDeploymentError{
-			Err:           err,
-			LocationRange: locationRange,
-		})
-	}
-
-	// NOTE: do NOT use the program obtained from the host environment, as the current program.
-	// Always re-parse and re-check the new program.
-
-	// NOTE: *DO NOT* store the program – the new or updated program
-	// should not be effective during the execution
-
-	const getAndSetProgram = false
-
-	program, err := handler.ParseAndCheckProgram(
-		newCode,
-		location,
-		getAndSetProgram,
-	)
-	handleContractUpdateError(err, newCode)
-
-	// The code may declare exactly one contract or one contract interface.
-
-	var contractTypes []*sema.CompositeType
-	var contractInterfaceTypes []*sema.InterfaceType
-
-	program.Elaboration.ForEachGlobalType(func(_ string, variable *sema.Variable) {
-		switch ty := variable.Type.(type) {
-		case *sema.CompositeType:
-			if ty.Kind == common.CompositeKindContract {
-				contractTypes = append(contractTypes, ty)
-			}
-
-		case *sema.InterfaceType:
-			if ty.CompositeKind == common.CompositeKindContract {
-				contractInterfaceTypes = append(contractInterfaceTypes, ty)
-			}
-		}
-	})
-
-	var deployedType sema.Type
-	var contractType *sema.CompositeType
-	var contractInterfaceType *sema.InterfaceType
-	var declaredName string
-	var declarationKind common.DeclarationKind
-
-	switch {
-	case len(contractTypes) == 1 && len(contractInterfaceTypes) == 0:
-		contractType = contractTypes[0]
-		declaredName = contractType.Identifier
-		deployedType = contractType
-		declarationKind = common.DeclarationKindContract
-	case len(contractInterfaceTypes) == 1 && len(contractTypes) == 0:
-		contractInterfaceType = contractInterfaceTypes[0]
-		declaredName = contractInterfaceType.Identifier
-		deployedType = contractInterfaceType
-		declarationKind = common.DeclarationKindContractInterface
-	}
-
-	if deployedType == nil {
-		// Update the code for the error pretty printing
-		// NOTE: only do this when an error occurs
-
-		handler.TemporarilyRecordCode(location, newCode)
-
-		panic(errors.NewDefaultUserError(
-			"invalid %s: the code must declare exactly one contract or contract interface",
-			declarationKind.Name(),
-		))
-	}
-
-	// The declared contract or contract interface must have the name
-	// passed to the constructor as the first argument
-
-	if declaredName != contractName {
-		// Update the code for the error pretty printing
-		// NOTE: only do this when an error occurs
-
-		handler.TemporarilyRecordCode(location, newCode)
-
-		panic(errors.NewDefaultUserError(
-			"invalid %s: the name argument must match the name of the declaration: got %q, expected %q",
-			declarationKind.Name(),
-			contractName,
-			declaredName,
-		))
-	}
-
-	// Validate the contract update
+	// Validate the deployment
 
 	inter := invocation.Interpreter
 
+	deployment := &ContractDeployment{
+		Interpreter: inter,
+		Location:    location,
+		Name:        contractName,
+		Code:        newCode,
+		IsUpdate:    isUpdate,
+	}
 	if isUpdate {
-		oldCode, err := handler.GetAccountContractCode(location)
-		handleContractUpdateError(err, newCode)
-
-		memoryGauge := invocation.Interpreter.SharedState.Config.MemoryGauge
-		legacyUpgradeEnabled := invocation.Interpreter.SharedState.Config.LegacyContractUpgradeEnabled
-
-		var oldProgram *ast.Program
-
-		// It is not always possible to determine whether the old code is pre-1.0 or not,
-		// only based on the parser errors. Therefore, always rely on the flag only.
-		// If the legacy contract upgrades are enabled, then use the old parser.
-		if legacyUpgradeEnabled {
-			oldProgram, err = old_parser.ParseProgram(
-				memoryGauge,
-				oldCode,
-				old_parser.Config{},
-			)
-		} else {
-			oldProgram, err = parser.ParseProgram(
-				memoryGauge,
-				oldCode,
-				parser.Config{
-					IgnoreLeadingIdentifierEnabled: true,
-				},
-			)
-		}
+		deployment.OldCode = existingCode
+	}
 
-		if err != nil && !ignoreUpdatedProgramParserError(err) {
-			// NOTE: Errors are usually in the new program / new code,
-			// but here we failed for the old program / old code.
-			err = &OldProgramError{
-				Err:      err,
-				Location: location,
-			}
-			handleContractUpdateError(err, oldCode)
-		}
+	validateContractDeployment(handler, deployment, locationRange)
 
-		var validator UpdateValidator
-		if legacyUpgradeEnabled {
-			validator = NewCadenceV042ToV1ContractUpdateValidator(
-				location,
-				contractName,
-				handler,
-				oldProgram,
-				program,
-				inter.AllElaborations(),
-			)
-		} else {
-			validator = NewContractUpdateValidator(
-				location,
-				contractName,
-				handler,
-				oldProgram,
-				program.Program,
-			)
-		}
-
-		err = validator.Validate()
-		handleContractUpdateError(err, newCode)
-	}
+	program := deployment.Program
+	contractType := deployment.ContractType
 
 	err = updateAccountContractCode(
 		handler,
@@ -1774,6 +1738,18 @@ func changeAccountContracts(
 		},
 	)
 
+	if handler.ContractMetadataEventsEnabled() {
+		emitContractDeploymentMetadataEvent(
+			inter,
+			locationRange,
+			handler,
+			deployment,
+			addressValue,
+			codeHashValue,
+			nameValue,
+		)
+	}
+
 	return interpreter.NewDeployedContractValue(
 		inter,
 		addressValue,
@@ -2081,6 +2057,7 @@ type AccountContractRemovalHandler interface {
 	AccountContractProvider
 	RemoveAccountContractCode(location common.AddressLocation) error
 	RecordContractRemoval(location common.AddressLocation)
+	ContractMetadataEventsHandler
 }
 
 func newAccountContractsRemoveFunction(
@@ -2100,6 +2077,9 @@ func newAccountContractsRemoveFunction(
 			func(_ interpreter.MemberAccessibleValue, invocation interpreter.Invocation) interpreter.Value {
 
 				inter := invocation.Interpreter
+
+				inter.CheckReadOnly(interpreter.ReadOnlyOperationAccountMutation, invocation.LocationRange)
+
 				nameValue, ok := invocation.Arguments[0].(*interpreter.StringValue)
 				if !ok {
 					panic(errors.NewUnreachableError())
@@ -2164,6 +2144,24 @@ func newAccountContractsRemoveFunction(
 						},
 					)
 
+					if handler.ContractMetadataEventsEnabled() {
+						// The existing program may be incomplete, if it is not parsable
+						removedTypes := newContractTypeInfo(location, existingProgram)
+
+						emitContractMetadataEvent(
+							inter,
+							locationRange,
+							handler,
+							AccountContractRemovedMetadataEventType,
+							addressValue,
+							codeHashValue,
+							nameValue,
+							removedTypes.declaredTypes,
+							nil,
+							removedTypes.eventTypes,
+						)
+					}
+
 					return interpreter.NewSomeValueNonCopying(
 						inter,
 						interpreter.NewDeployedContractValue(
@@ -2308,6 +2306,7 @@ func newAccountStorageCapabilitiesValue(
 		newAccountStorageCapabilitiesForEachControllerFunction(inter, addressValue, handler),
 		newAccountStorageCapabilitiesIssueFunction(inter, issueHandler, addressValue),
 		newAccountStorageCapabilitiesIssueWithTypeFunction(inter, issueHandler, addressValue),
+		newAccountStorageCapabilitiesIssueAllFunction(inter, issueHandler, addressValue),
 	)
 }
 
@@ -2344,6 +2343,8 @@ func newAccountCapabilitiesValue(
 		newAccountCapabilitiesExistsFunction(inter, addressValue),
 		newAccountCapabilitiesPublishFunction(inter, addressValue, handler),
 		newAccountCapabilitiesUnpublishFunction(inter, addressValue, handler),
+		newAccountCapabilitiesPublishAllFunction(inter, addressValue, handler),
+		newAccountCapabilitiesUnpublishAllFunction(inter, addressValue, handler),
 		func() interpreter.Value {
 			return newAccountStorageCapabilitiesValue(
 				inter,
@@ -2695,6 +2696,78 @@ func newAccountStorageCapabilitiesIssueWithTypeFunction(
 	}
 }
 
+func newAccountStorageCapabilitiesIssueAllFunction(
+	inter *interpreter.Interpreter,
+	handler CapabilityControllerIssueHandler,
+	addressValue interpreter.AddressValue,
+) interpreter.BoundFunctionGenerator {
+	return func(storageCapabilities interpreter.MemberAccessibleValue) interpreter.BoundFunctionValue {
+		address := addressValue.ToAddress()
+		return interpreter.NewBoundHostFunctionValue(
+			inter,
+			storageCapabilities,
+			sema.Account_StorageCapabilitiesTypeIssueAllFunctionType,
+			func(_ interpreter.MemberAccessibleValue, invocation interpreter.Invocation) interpreter.Value {
+
+				inter := invocation.Interpreter
+				locationRange := invocation.LocationRange
+
+				// Get paths argument
+
+				targetPathsValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				// Get borrow-type type-argument
+
+				typeParameterPair := invocation.TypeParameterTypes.Oldest()
+				ty := typeParameterPair.Value
+
+				borrowType, ok := ty.(*sema.ReferenceType)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				borrowStaticType := interpreter.ConvertSemaReferenceTypeToStaticReferenceType(inter, borrowType)
+
+				// Issue capability controllers and return capabilities
+
+				count := targetPathsValue.Count()
+				capabilities := make([]interpreter.Value, 0, count)
+
+				for index := 0; index < count; index++ {
+					targetPathValue, ok := targetPathsValue.Get(inter, locationRange, index).(interpreter.PathValue)
+					if !ok || targetPathValue.Domain != common.PathDomainStorage {
+						panic(errors.NewUnreachableError())
+					}
+
+					capability := checkAndIssueStorageCapabilityControllerWithType(
+						inter,
+						locationRange,
+						handler,
+						address,
+						targetPathValue,
+						borrowType,
+					)
+					capabilities = append(capabilities, capability)
+				}
+
+				return interpreter.NewArrayValue(
+					inter,
+					locationRange,
+					interpreter.NewVariableSizedStaticType(
+						inter,
+						interpreter.NewCapabilityStaticType(inter, borrowStaticType),
+					),
+					common.ZeroAddress,
+					capabilities...,
+				)
+			},
+		)
+	}
+}
+
 func checkAndIssueStorageCapabilityControllerWithType(
 	inter *interpreter.Interpreter,
 	locationRange interpreter.LocationRange,
@@ -2778,7 +2851,7 @@ func IssueStorageCapabilityController(
 		targetPathValue,
 	)
 
-	storeCapabilityController(inter, address, capabilityIDValue, controller)
+	storeCapabilityController(inter, locationRange, address, capabilityIDValue, controller)
 	recordStorageCapabilityController(inter, locationRange, address, targetPathValue, capabilityIDValue)
 
 	addressValue := interpreter.AddressValue(address)
@@ -2956,7 +3029,7 @@ func IssueAccountCapabilityController(
 		capabilityIDValue,
 	)
 
-	storeCapabilityController(inter, address, capabilityIDValue, controller)
+	storeCapabilityController(inter, locationRange, address, capabilityIDValue, controller)
 	recordAccountCapabilityController(
 		inter,
 		locationRange,
@@ -2984,17 +3057,19 @@ func IssueAccountCapabilityController(
 // storeCapabilityController stores a capability controller in the account's capability ID to controller storage map
 func storeCapabilityController(
 	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
 	address common.Address,
 	capabilityIDValue interpreter.UInt64Value,
 	controller interpreter.CapabilityControllerValue,
 ) {
 	storageMapKey := interpreter.Uint64StorageMapKey(capabilityIDValue)
 
-	existed := inter.WriteStored(
+	existed := inter.WriteStoredWithLocationRange(
 		address,
 		common.StorageDomainCapabilityController,
 		storageMapKey,
 		controller,
+		locationRange,
 	)
 	if existed {
 		panic(errors.NewUnreachableError())
@@ -3004,16 +3079,18 @@ func storeCapabilityController(
 // removeCapabilityController removes a capability controller from the account's capability ID to controller storage map
 func removeCapabilityController(
 	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
 	address common.Address,
 	capabilityIDValue interpreter.UInt64Value,
 ) {
 	storageMapKey := interpreter.Uint64StorageMapKey(capabilityIDValue)
 
-	existed := inter.WriteStored(
+	existed := inter.WriteStoredWithLocationRange(
 		address,
 		common.StorageDomainCapabilityController,
 		storageMapKey,
 		nil,
+		locationRange,
 	)
 	if !existed {
 		panic(errors.NewUnreachableError())
@@ -3021,6 +3098,7 @@ func removeCapabilityController(
 
 	setCapabilityControllerTag(
 		inter,
+		locationRange,
 		address,
 		uint64(capabilityIDValue),
 		nil,
@@ -3196,6 +3274,7 @@ func newStorageCapabilityControllerDeleteFunction(
 		)
 		removeCapabilityController(
 			inter,
+			locationRange,
 			address,
 			capabilityID,
 		)
@@ -3491,7 +3570,6 @@ func newAccountCapabilitiesPublishFunction(
 ) interpreter.BoundFunctionGenerator {
 
 	return func(accountCapabilities interpreter.MemberAccessibleValue) interpreter.BoundFunctionValue {
-		accountAddress := accountAddressValue.ToAddress()
 		return interpreter.NewBoundHostFunctionValue(
 			inter,
 			accountCapabilities,
@@ -3507,15 +3585,6 @@ func newAccountCapabilitiesPublishFunction(
 					panic(errors.NewUnreachableError())
 				}
 
-				capabilityAddressValue := capabilityValue.Address()
-				if capabilityAddressValue != accountAddressValue {
-					panic(interpreter.CapabilityAddressPublishingError{
-						LocationRange:     locationRange,
-						CapabilityAddress: capabilityAddressValue,
-						AccountAddress:    accountAddressValue,
-					})
-				}
-
 				// Get path argument
 
 				pathValue, ok := invocation.Arguments[1].(interpreter.PathValue)
@@ -3523,94 +3592,139 @@ func newAccountCapabilitiesPublishFunction(
 					panic(errors.NewUnreachableError())
 				}
 
-				domain := pathValue.Domain.StorageDomain()
-				identifier := pathValue.Identifier
+				err := checkCapabilityPublication(
+					inter,
+					locationRange,
+					accountAddressValue,
+					capabilityValue,
+					pathValue,
+				)
+				if err != nil {
+					panic(err)
+				}
+
+				publishCapability(
+					inter,
+					locationRange,
+					handler,
+					accountAddressValue,
+					capabilityValue,
+					pathValue,
+					true, // capabilityValue is standalone because it is from invocation.Arguments[0].
+				)
+
+				return interpreter.Void
+			},
+		)
+	}
+}
 
-				capabilityType, ok := capabilityValue.StaticType(inter).(*interpreter.CapabilityStaticType)
+func newAccountCapabilitiesPublishAllFunction(
+	inter *interpreter.Interpreter,
+	accountAddressValue interpreter.AddressValue,
+	handler CapabilityControllerHandler,
+) interpreter.BoundFunctionGenerator {
+
+	return func(accountCapabilities interpreter.MemberAccessibleValue) interpreter.BoundFunctionValue {
+		return interpreter.NewBoundHostFunctionValue(
+			inter,
+			accountCapabilities,
+			sema.Account_CapabilitiesTypePublishAllFunctionType,
+			func(_ interpreter.MemberAccessibleValue, invocation interpreter.Invocation) interpreter.Value {
+				inter := invocation.Interpreter
+				locationRange := invocation.LocationRange
+
+				// Get capabilities argument
+
+				capabilitiesValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
 				if !ok {
 					panic(errors.NewUnreachableError())
 				}
 
-				borrowType := capabilityType.BorrowType
+				// Get paths argument
 
-				// It is possible to have legacy capabilities without borrow type.
-				// So perform the validation only if the borrow type is present.
-				if borrowType != nil {
-					capabilityBorrowType, ok := borrowType.(*interpreter.ReferenceStaticType)
+				pathsValue, ok := invocation.Arguments[1].(*interpreter.ArrayValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				count := capabilitiesValue.Count()
+				if pathsValue.Count() != count {
+					panic(errors.NewDefaultUserError(
+						"cannot publish capabilities: got %d capabilities, but %d paths",
+						count,
+						pathsValue.Count(),
+					))
+				}
+
+				capabilityValues := make([]interpreter.CapabilityValue, 0, count)
+				pathValues := make([]interpreter.PathValue, 0, count)
+
+				for index := 0; index < count; index++ {
+					capabilityValue, ok := capabilitiesValue.Get(inter, locationRange, index).(interpreter.CapabilityValue)
 					if !ok {
 						panic(errors.NewUnreachableError())
 					}
 
-					publishHandler := inter.SharedState.Config.ValidateAccountCapabilitiesPublishHandler
-					if publishHandler != nil {
-						valid, err := publishHandler(
+					pathValue, ok := pathsValue.Get(inter, locationRange, index).(interpreter.PathValue)
+					if !ok || pathValue.Domain != common.PathDomainPublic {
+						panic(errors.NewUnreachableError())
+					}
+
+					capabilityValues = append(capabilityValues, capabilityValue)
+					pathValues = append(pathValues, pathValue)
+				}
+
+				// Check all publications before publishing any capability,
+				// so either all or none of the capabilities are published
+
+				var publicationErrors []error
+				seenIdentifiers := make(map[string]struct{}, count)
+
+				for index, pathValue := range pathValues {
+					var err error
+
+					if _, ok := seenIdentifiers[pathValue.Identifier]; ok {
+						err = interpreter.OverwriteError{
+							Address:       accountAddressValue,
+							Path:          pathValue,
+							LocationRange: locationRange,
+						}
+					} else {
+						seenIdentifiers[pathValue.Identifier] = struct{}{}
+
+						err = checkCapabilityPublication(
 							inter,
 							locationRange,
-							capabilityAddressValue,
+							accountAddressValue,
+							capabilityValues[index],
 							pathValue,
-							capabilityBorrowType,
 						)
-						if err != nil {
-							panic(err)
-						}
-						if !valid {
-							panic(interpreter.EntitledCapabilityPublishingError{
-								LocationRange: locationRange,
-								BorrowType:    capabilityBorrowType,
-								Path:          pathValue,
-							})
-						}
 					}
-				}
 
-				// Prevent an overwrite
-
-				storageMapKey := interpreter.StringStorageMapKey(identifier)
+					if err != nil {
+						publicationErrors = append(publicationErrors, err)
+					}
+				}
 
-				if inter.StoredValueExists(
-					accountAddress,
-					domain,
-					storageMapKey,
-				) {
-					panic(interpreter.OverwriteError{
-						Address:       accountAddressValue,
-						Path:          pathValue,
+				if len(publicationErrors) > 0 {
+					panic(interpreter.CapabilityBatchPublishingError{
+						Errors:        publicationErrors,
 						LocationRange: locationRange,
 					})
 				}
 
-				capabilityValue, ok = capabilityValue.Transfer(
-					inter,
-					locationRange,
-					atree.Address(accountAddress),
-					true,
-					nil,
-					nil,
-					true, // capabilityValue is standalone because it is from invocation.Arguments[0].
-				).(interpreter.CapabilityValue)
-				if !ok {
-					panic(errors.NewUnreachableError())
-				}
-
-				// Write new value
-
-				inter.WriteStored(
-					accountAddress,
-					domain,
-					storageMapKey,
-					capabilityValue,
-				)
-
-				handler.EmitEvent(
-					inter,
-					locationRange,
-					CapabilityPublishedEventType,
-					[]interpreter.Value{
+				for index, pathValue := range pathValues {
+					publishCapability(
+						inter,
+						locationRange,
+						handler,
 						accountAddressValue,
+						capabilityValues[index],
 						pathValue,
-						capabilityValue,
-					},
-				)
+						false, // capabilityValue is an element of the capabilities array.
+					)
+				}
 
 				return interpreter.Void
 			},
@@ -3618,6 +3732,128 @@ func newAccountCapabilitiesPublishFunction(
 	}
 }
 
+// checkCapabilityPublication checks if the given capability can be published at the given path,
+// and returns an error if it cannot
+func checkCapabilityPublication(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	accountAddressValue interpreter.AddressValue,
+	capabilityValue interpreter.CapabilityValue,
+	pathValue interpreter.PathValue,
+) error {
+	capabilityAddressValue := capabilityValue.Address()
+	if capabilityAddressValue != accountAddressValue {
+		return interpreter.CapabilityAddressPublishingError{
+			LocationRange:     locationRange,
+			CapabilityAddress: capabilityAddressValue,
+			AccountAddress:    accountAddressValue,
+		}
+	}
+
+	capabilityType, ok := capabilityValue.StaticType(inter).(*interpreter.CapabilityStaticType)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	borrowType := capabilityType.BorrowType
+
+	// It is possible to have legacy capabilities without borrow type.
+	// So perform the validation only if the borrow type is present.
+	if borrowType != nil {
+		capabilityBorrowType, ok := borrowType.(*interpreter.ReferenceStaticType)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		publishHandler := inter.SharedState.Config.ValidateAccountCapabilitiesPublishHandler
+		if publishHandler != nil {
+			valid, err := publishHandler(
+				inter,
+				locationRange,
+				capabilityAddressValue,
+				pathValue,
+				capabilityBorrowType,
+			)
+			if err != nil {
+				panic(err)
+			}
+			if !valid {
+				return interpreter.EntitledCapabilityPublishingError{
+					LocationRange: locationRange,
+					BorrowType:    capabilityBorrowType,
+					Path:          pathValue,
+				}
+			}
+		}
+	}
+
+	// Prevent an overwrite
+
+	if inter.StoredValueExists(
+		accountAddressValue.ToAddress(),
+		pathValue.Domain.StorageDomain(),
+		interpreter.StringStorageMapKey(pathValue.Identifier),
+	) {
+		return interpreter.OverwriteError{
+			Address:       accountAddressValue,
+			Path:          pathValue,
+			LocationRange: locationRange,
+		}
+	}
+
+	return nil
+}
+
+// publishCapability publishes the given capability at the given path.
+// The publication must have been checked using checkCapabilityPublication
+func publishCapability(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	handler CapabilityControllerHandler,
+	accountAddressValue interpreter.AddressValue,
+	capabilityValue interpreter.CapabilityValue,
+	pathValue interpreter.PathValue,
+	standalone bool,
+) {
+	accountAddress := accountAddressValue.ToAddress()
+
+	// Standalone capabilities, i.e. arguments, are moved,
+	// whereas elements of containers are copied
+	capabilityValue, ok := capabilityValue.Transfer(
+		inter,
+		locationRange,
+		atree.Address(accountAddress),
+		standalone,
+		nil,
+		nil,
+		standalone,
+	).(interpreter.CapabilityValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	// Write new value
+
+	inter.WriteStoredWithLocationRange(
+		accountAddress,
+		pathValue.Domain.StorageDomain(),
+		interpreter.StringStorageMapKey(pathValue.Identifier),
+		capabilityValue,
+		locationRange,
+	)
+
+	handler.EmitEvent(
+		inter,
+		locationRange,
+		CapabilityPublishedEventType,
+		[]interpreter.Value{
+			accountAddressValue,
+			pathValue,
+			capabilityValue,
+		},
+	)
+}
+
 func newAccountCapabilitiesUnpublishFunction(
 	inter *interpreter.Interpreter,
 	addressValue interpreter.AddressValue,
@@ -3625,7 +3861,6 @@ func newAccountCapabilitiesUnpublishFunction(
 ) interpreter.BoundFunctionGenerator {
 
 	return func(accountCapabilities interpreter.MemberAccessibleValue) interpreter.BoundFunctionValue {
-		address := addressValue.ToAddress()
 		return interpreter.NewBoundHostFunctionValue(
 			inter,
 			accountCapabilities,
@@ -3642,72 +3877,155 @@ func newAccountCapabilitiesUnpublishFunction(
 					panic(errors.NewUnreachableError())
 				}
 
-				domain := pathValue.Domain.StorageDomain()
-				identifier := pathValue.Identifier
+				return unpublishCapability(
+					inter,
+					locationRange,
+					handler,
+					addressValue,
+					pathValue,
+				)
+			},
+		)
+	}
+}
 
-				// Read/remove capability
+var optionalCapabilityArrayStaticType = interpreter.NewVariableSizedStaticType(
+	nil,
+	interpreter.NewOptionalStaticType(
+		nil,
+		interpreter.NewCapabilityStaticType(nil, nil),
+	),
+)
 
-				storageMapKey := interpreter.StringStorageMapKey(identifier)
+func newAccountCapabilitiesUnpublishAllFunction(
+	inter *interpreter.Interpreter,
+	addressValue interpreter.AddressValue,
+	handler CapabilityControllerHandler,
+) interpreter.BoundFunctionGenerator {
 
-				readValue := inter.ReadStored(address, domain, storageMapKey)
-				if readValue == nil {
-					return interpreter.Nil
+	return func(accountCapabilities interpreter.MemberAccessibleValue) interpreter.BoundFunctionValue {
+		return interpreter.NewBoundHostFunctionValue(
+			inter,
+			accountCapabilities,
+			sema.Account_CapabilitiesTypeUnpublishAllFunctionType,
+			func(_ interpreter.MemberAccessibleValue, invocation interpreter.Invocation) interpreter.Value {
+
+				inter := invocation.Interpreter
+				locationRange := invocation.LocationRange
+
+				// Get paths argument
+
+				pathsValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
 				}
 
-				var capabilityValue interpreter.CapabilityValue
-				switch readValue := readValue.(type) {
-				case interpreter.CapabilityValue:
-					capabilityValue = readValue
+				count := pathsValue.Count()
+				results := make([]interpreter.Value, 0, count)
 
-				case interpreter.PathLinkValue: //nolint:staticcheck
-					// If the stored value is a path link,
-					// it failed to be migrated during the Cadence 1.0 migration.
-					// Use an invalid capability value instead
+				for index := 0; index < count; index++ {
+					pathValue, ok := pathsValue.Get(inter, locationRange, index).(interpreter.PathValue)
+					if !ok || pathValue.Domain != common.PathDomainPublic {
+						panic(errors.NewUnreachableError())
+					}
 
-					capabilityValue = interpreter.NewInvalidCapabilityValue(
+					result := unpublishCapability(
 						inter,
+						locationRange,
+						handler,
 						addressValue,
-						readValue.Type,
+						pathValue,
 					)
-
-				default:
-					panic(errors.NewUnreachableError())
+					results = append(results, result)
 				}
 
-				capabilityValue, ok = capabilityValue.Transfer(
+				return interpreter.NewArrayValue(
 					inter,
 					locationRange,
-					atree.Address{},
-					true,
-					nil,
-					nil,
-					false, // capabilityValue is an element of storage map.
-				).(interpreter.CapabilityValue)
-				if !ok {
-					panic(errors.NewUnreachableError())
-				}
-
-				inter.WriteStored(
-					address,
-					domain,
-					storageMapKey,
-					nil,
+					optionalCapabilityArrayStaticType,
+					common.ZeroAddress,
+					results...,
 				)
+			},
+		)
+	}
+}
 
-				handler.EmitEvent(
-					inter,
-					locationRange,
-					CapabilityUnpublishedEventType,
-					[]interpreter.Value{
-						addressValue,
-						pathValue,
-					},
-				)
+// unpublishCapability unpublishes the capability published at the given path.
+// Returns the optional capability, or nil if no capability was published at the path
+func unpublishCapability(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	handler CapabilityControllerHandler,
+	addressValue interpreter.AddressValue,
+	pathValue interpreter.PathValue,
+) interpreter.Value {
 
-				return interpreter.NewSomeValueNonCopying(inter, capabilityValue)
-			},
+	address := addressValue.ToAddress()
+	domain := pathValue.Domain.StorageDomain()
+	identifier := pathValue.Identifier
+
+	// Read/remove capability
+
+	storageMapKey := interpreter.StringStorageMapKey(identifier)
+
+	readValue := inter.ReadStored(address, domain, storageMapKey)
+	if readValue == nil {
+		return interpreter.Nil
+	}
+
+	var capabilityValue interpreter.CapabilityValue
+	switch readValue := readValue.(type) {
+	case interpreter.CapabilityValue:
+		capabilityValue = readValue
+
+	case interpreter.PathLinkValue: //nolint:staticcheck
+		// If the stored value is a path link,
+		// it failed to be migrated during the Cadence 1.0 migration.
+		// Use an invalid capability value instead
+
+		capabilityValue = interpreter.NewInvalidCapabilityValue(
+			inter,
+			addressValue,
+			readValue.Type,
 		)
+
+	default:
+		panic(errors.NewUnreachableError())
 	}
+
+	capabilityValue, ok := capabilityValue.Transfer(
+		inter,
+		locationRange,
+		atree.Address{},
+		true,
+		nil,
+		nil,
+		false, // capabilityValue is an element of storage map.
+	).(interpreter.CapabilityValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	inter.WriteStoredWithLocationRange(
+		address,
+		domain,
+		storageMapKey,
+		nil,
+		locationRange,
+	)
+
+	handler.EmitEvent(
+		inter,
+		locationRange,
+		CapabilityUnpublishedEventType,
+		[]interpreter.Value{
+			addressValue,
+			pathValue,
+		},
+	)
+
+	return interpreter.NewSomeValueNonCopying(inter, capabilityValue)
 }
 
 func canBorrow(
@@ -4401,6 +4719,7 @@ func newAccountCapabilityControllerDeleteFunction(
 		)
 		removeCapabilityController(
 			inter,
+			locationRange,
 			address,
 			capabilityID,
 		)
@@ -4477,6 +4796,7 @@ func newCapabilityControllerGetTagFunction(
 
 func setCapabilityControllerTag(
 	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
 	address common.Address,
 	capabilityID uint64,
 	tagValue *interpreter.StringValue,
@@ -4485,13 +4805,16 @@ func setCapabilityControllerTag(
 	var value interpreter.Value
 	if tagValue != nil {
 		value = tagValue
+
+		inter.CheckStoredValueSize(address, value, locationRange)
 	}
 
-	inter.WriteStored(
+	inter.WriteStoredWithLocationRange(
 		address,
 		common.StorageDomainCapabilityControllerTag,
 		interpreter.Uint64StorageMapKey(capabilityID),
 		value,
+		locationRange,
 	)
 }
 
@@ -4502,6 +4825,7 @@ func newCapabilityControllerSetTagFunction(
 	return func(inter *interpreter.Interpreter, tagValue *interpreter.StringValue) {
 		setCapabilityControllerTag(
 			inter,
+			interpreter.EmptyLocationRange,
 			address,
 			uint64(capabilityIDValue),
 			tagValue,
@@ -18,7 +18,7 @@
 
 package stdlib
 
-//go:generate go run ../sema/gen -p stdlib bls.cdc bls.gen.go
+//go:generate go run ../sema/gen -p stdlib -values bls_contract.gen.go -vp stdlib bls.cdc bls.gen.go
 
 import (
 	"github.com/onflow/cadence/common"
@@ -35,75 +35,70 @@ type BLSPublicKeyAggregator interface {
 }
 
 func newBLSAggregatePublicKeysFunction(
-	gauge common.MemoryGauge,
 	aggregator BLSPublicKeyAggregator,
-) *interpreter.HostFunctionValue {
+) interpreter.HostFunction {
 	// TODO: Should create a bound-host function here, but interpreter is not available at this point.
 	// However, this is not a problem for now, since underlying contract doesn't get moved.
-	return interpreter.NewStaticHostFunctionValue(
-		gauge,
-		BLSTypeAggregatePublicKeysFunctionType,
-		func(invocation interpreter.Invocation) interpreter.Value {
-			publicKeysValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
-			if !ok {
-				panic(errors.NewUnreachableError())
-			}
-
-			inter := invocation.Interpreter
-			locationRange := invocation.LocationRange
-
-			inter.ExpectType(
-				publicKeysValue,
-				sema.PublicKeyArrayType,
-				locationRange,
-			)
-
-			publicKeys := make([]*PublicKey, 0, publicKeysValue.Count())
-			publicKeysValue.Iterate(
-				inter,
-				func(element interpreter.Value) (resume bool) {
-					publicKeyValue, ok := element.(*interpreter.CompositeValue)
-					if !ok {
-						panic(errors.NewUnreachableError())
-					}
-
-					publicKey, err := NewPublicKeyFromValue(inter, locationRange, publicKeyValue)
-					if err != nil {
-						panic(err)
-					}
-
-					publicKeys = append(publicKeys, publicKey)
-
-					// Continue iteration
-					return true
-				},
-				false,
-				locationRange,
-			)
-
-			var err error
-			var aggregatedPublicKey *PublicKey
-			errors.WrapPanic(func() {
-				aggregatedPublicKey, err = aggregator.BLSAggregatePublicKeys(publicKeys)
-			})
-
-			// If the crypto layer produces an error, we have invalid input, return nil
-			if err != nil {
-				return interpreter.NilOptionalValue
-			}
-
-			aggregatedPublicKeyValue := NewPublicKeyValue(
-				inter,
-				locationRange,
-				aggregatedPublicKey,
-			)
-
-			return interpreter.NewSomeValueNonCopying(
-				inter,
-				aggregatedPublicKeyValue,
-			)
-		},
-	)
+	return func(invocation interpreter.Invocation) interpreter.Value {
+		publicKeysValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		inter := invocation.Interpreter
+		locationRange := invocation.LocationRange
+
+		inter.ExpectType(
+			publicKeysValue,
+			sema.PublicKeyArrayType,
+			locationRange,
+		)
+
+		publicKeys := make([]*PublicKey, 0, publicKeysValue.Count())
+		publicKeysValue.Iterate(
+			inter,
+			func(element interpreter.Value) (resume bool) {
+				publicKeyValue, ok := element.(*interpreter.CompositeValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				publicKey, err := NewPublicKeyFromValue(inter, locationRange, publicKeyValue)
+				if err != nil {
+					panic(err)
+				}
+
+				publicKeys = append(publicKeys, publicKey)
+
+				// Continue iteration
+				return true
+			},
+			false,
+			locationRange,
+		)
+
+		var err error
+		var aggregatedPublicKey *PublicKey
+		errors.WrapPanic(func() {
+			aggregatedPublicKey, err = aggregator.BLSAggregatePublicKeys(publicKeys)
+		})
+
+		// If the crypto layer produces an error, we have invalid input, return nil
+		if err != nil {
+			return interpreter.NilOptionalValue
+		}
+
+		aggregatedPublicKeyValue := NewPublicKeyValue(
+			inter,
+			locationRange,
+			aggregatedPublicKey,
+		)
+
+		return interpreter.NewSomeValueNonCopying(
+			inter,
+			aggregatedPublicKeyValue,
+		)
+	}
 }
 
 type BLSSignatureAggregator interface {
@@ -112,71 +107,66 @@ type BLSSignatureAggregator interface {
 }
 
 func newBLSAggregateSignaturesFunction(
-	gauge common.MemoryGauge,
 	aggregator BLSSignatureAggregator,
-) *interpreter.HostFunctionValue {
+) interpreter.HostFunction {
 	// TODO: Should create a bound-host function here, but interpreter is not available at this point.
 	// However, this is not a problem for now, since underlying contract doesn't get moved.
-	return interpreter.NewStaticHostFunctionValue(
-		gauge,
-		BLSTypeAggregateSignaturesFunctionType,
-		func(invocation interpreter.Invocation) interpreter.Value {
-			signaturesValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
-			if !ok {
-				panic(errors.NewUnreachableError())
-			}
-
-			inter := invocation.Interpreter
-			locationRange := invocation.LocationRange
-
-			inter.ExpectType(
-				signaturesValue,
-				sema.ByteArrayArrayType,
-				locationRange,
-			)
-
-			bytesArray := make([][]byte, 0, signaturesValue.Count())
-			signaturesValue.Iterate(
-				inter,
-				func(element interpreter.Value) (resume bool) {
-					signature, ok := element.(*interpreter.ArrayValue)
-					if !ok {
-						panic(errors.NewUnreachableError())
-					}
-
-					bytes, err := interpreter.ByteArrayValueToByteSlice(inter, signature, invocation.LocationRange)
-					if err != nil {
-						panic(err)
-					}
-
-					bytesArray = append(bytesArray, bytes)
-
-					// Continue iteration
-					return true
-				},
-				false,
-				locationRange,
-			)
-
-			var err error
-			var aggregatedSignature []byte
-			errors.WrapPanic(func() {
-				aggregatedSignature, err = aggregator.BLSAggregateSignatures(bytesArray)
-			})
-
-			// If the crypto layer produces an error, we have invalid input, return nil
-			if err != nil {
-				return interpreter.NilOptionalValue
-			}
-
-			aggregatedSignatureValue := interpreter.ByteSliceToByteArrayValue(inter, aggregatedSignature)
-
-			return interpreter.NewSomeValueNonCopying(
-				inter,
-				aggregatedSignatureValue,
-			)
-		},
-	)
+	return func(invocation interpreter.Invocation) interpreter.Value {
+		signaturesValue, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+		if !ok {
+			panic(errors.NewUnreachableError())
+		}
+
+		inter := invocation.Interpreter
+		locationRange := invocation.LocationRange
+
+		inter.ExpectType(
+			signaturesValue,
+			sema.ByteArrayArrayType,
+			locationRange,
+		)
+
+		bytesArray := make([][]byte, 0, signaturesValue.Count())
+		signaturesValue.Iterate(
+			inter,
+			func(element interpreter.Value) (resume bool) {
+				signature, ok := element.(*interpreter.ArrayValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+
+				bytes, err := interpreter.ByteArrayValueToByteSlice(inter, signature, invocation.LocationRange)
+				if err != nil {
+					panic(err)
+				}
+
+				bytesArray = append(bytesArray, bytes)
+
+				// Continue iteration
+				return true
+			},
+			false,
+			locationRange,
+		)
+
+		var err error
+		var aggregatedSignature []byte
+		errors.WrapPanic(func() {
+			aggregatedSignature, err = aggregator.BLSAggregateSignatures(bytesArray)
+		})
+
+		// If the crypto layer produces an error, we have invalid input, return nil
+		if err != nil {
+			return interpreter.NilOptionalValue
+		}
+
+		aggregatedSignatureValue := interpreter.ByteSliceToByteArrayValue(inter, aggregatedSignature)
+
+		return interpreter.NewSomeValueNonCopying(
+			inter,
+			aggregatedSignatureValue,
+		)
+	}
 }
 
 type BLSContractHandler interface {
@@ -187,26 +177,16 @@ type BLSContractHandler interface {
 	BLSSignatureAggregator
 }
 
-var BLSTypeStaticType = interpreter.ConvertSemaToStaticType(nil, BLSType)
-
 func NewBLSContract(
 	gauge common.MemoryGauge,
 	handler BLSContractHandler,
 ) StandardLibraryValue {
-	blsContractFields := map[string]interpreter.Value{
-		BLSTypeAggregatePublicKeysFunctionName: newBLSAggregatePublicKeysFunction(gauge, handler),
-		BLSTypeAggregateSignaturesFunctionName: newBLSAggregateSignaturesFunction(gauge, handler),
-	}
-
-	blsContractValue := interpreter.NewSimpleCompositeValue(
+	blsContractValue := NewBLSContractValue(
 		gauge,
-		BLSType.ID(),
-		BLSTypeStaticType,
-		nil,
-		blsContractFields,
-		nil,
-		nil,
-		nil,
+		BLSContractFunctions{
+			AggregateSignatures: newBLSAggregateSignaturesFunction(handler),
+			AggregatePublicKeys: newBLSAggregatePublicKeysFunction(handler),
+		},
 	)
 
 	return StandardLibraryValue{
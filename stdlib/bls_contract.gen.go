@@ -0,0 +1,69 @@
+// Code generated from bls.cdc. DO NOT EDIT.
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/interpreter"
+)
+
+// BLSContractFunctions are the implementations of the native functions of the built-in contract BLS
+type BLSContractFunctions struct {
+	AggregateSignatures interpreter.HostFunction
+	AggregatePublicKeys interpreter.HostFunction
+}
+
+var blsTypeID = BLSType.ID()
+var BLSTypeStaticType = interpreter.ConvertSemaToStaticType(nil, BLSType) // unmetered
+
+// NewBLSContractValue returns a new value of the built-in contract BLS,
+// with the given implementations of its native functions
+func NewBLSContractValue(gauge common.MemoryGauge, functions BLSContractFunctions) *interpreter.SimpleCompositeValue {
+	if functions.AggregateSignatures == nil {
+		panic(errors.NewUnexpectedError("missing implementation of native function BLS.aggregateSignatures"))
+	}
+
+	if functions.AggregatePublicKeys == nil {
+		panic(errors.NewUnexpectedError("missing implementation of native function BLS.aggregatePublicKeys"))
+	}
+
+	return interpreter.NewSimpleCompositeValue(
+		gauge,
+		blsTypeID,
+		BLSTypeStaticType,
+		nil,
+		map[string]interpreter.Value{
+			BLSTypeAggregateSignaturesFunctionName: interpreter.NewStaticHostFunctionValue(
+				gauge,
+				BLSTypeAggregateSignaturesFunctionType,
+				functions.AggregateSignatures,
+			),
+			BLSTypeAggregatePublicKeysFunctionName: interpreter.NewStaticHostFunctionValue(
+				gauge,
+				BLSTypeAggregatePublicKeysFunctionType,
+				functions.AggregatePublicKeys,
+			),
+		},
+		nil,
+		nil,
+		nil,
+	)
+}
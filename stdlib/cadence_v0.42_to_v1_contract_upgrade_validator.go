@@ -137,6 +137,15 @@ func (validator *CadenceV042ToV1ContractUpdateValidator) Validate() error {
 	return nil
 }
 
+// Report validates the contract update, like Validate,
+// and returns a report of the changes of the update,
+// including why they are invalid, if they are.
+func (validator *CadenceV042ToV1ContractUpdateValidator) Report() *ContractUpdateReport {
+	validator.underlyingUpdateValidator.resetErrors()
+	_ = validator.Validate()
+	return newContractUpdateReport(validator.underlyingUpdateValidator)
+}
+
 func (validator *CadenceV042ToV1ContractUpdateValidator) report(err error) {
 	validator.underlyingUpdateValidator.report(err)
 }
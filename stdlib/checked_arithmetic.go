@@ -0,0 +1,266 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/sema"
+)
+
+const checkedAddFunctionDocString = `
+Returns the sum of the given numbers, or nil if the result overflows or underflows
+`
+
+const checkedSubtractFunctionDocString = `
+Returns the difference of the given numbers, or nil if the result overflows or underflows
+`
+
+const checkedMultiplyFunctionDocString = `
+Returns the product of the given numbers, or nil if the result overflows or underflows
+`
+
+const checkedDivideFunctionDocString = `
+Returns the quotient of the given numbers, or nil if the divisor is zero, or if the result overflows or underflows
+`
+
+const checkedModuloFunctionDocString = `
+Returns the remainder of the division of the given numbers, or nil if the divisor is zero
+`
+
+func newCheckedArithmeticFunctionType(functionName string) *sema.FunctionType {
+	typeParameter := &sema.TypeParameter{
+		Name:      "T",
+		TypeBound: sema.NumberType,
+	}
+
+	typeAnnotation := sema.NewTypeAnnotation(
+		&sema.GenericType{
+			TypeParameter: typeParameter,
+		},
+	)
+
+	return &sema.FunctionType{
+		Purity: sema.FunctionPurityView,
+		TypeParameters: []*sema.TypeParameter{
+			typeParameter,
+		},
+		Parameters: []sema.Parameter{
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "a",
+				TypeAnnotation: typeAnnotation,
+			},
+			{
+				Label:          sema.ArgumentLabelNotRequired,
+				Identifier:     "b",
+				TypeAnnotation: typeAnnotation,
+			},
+		},
+		TypeArgumentsCheck: func(
+			memoryGauge common.MemoryGauge,
+			typeArguments *sema.TypeParameterTypeOrderedMap,
+			_ []*ast.TypeAnnotation,
+			invocationRange ast.HasPosition,
+			report func(err error),
+		) {
+			typeArg, ok := typeArguments.Get(typeParameter)
+			if !ok || typeArg == nil {
+				// Invalid, already reported by checker
+				return
+			}
+
+			// The operands must have the same concrete number type
+			var isSuperType bool
+			switch typeArg := typeArg.(type) {
+			case *sema.NumericType:
+				isSuperType = typeArg.IsSuperType()
+			case *sema.FixedPointNumericType:
+				isSuperType = typeArg.IsSuperType()
+			default:
+				isSuperType = true
+			}
+
+			if isSuperType {
+				report(&sema.InvalidTypeArgumentError{
+					TypeArgumentName: typeParameter.Name,
+					Range:            ast.NewRangeFromPositioned(memoryGauge, invocationRange),
+					Details: fmt.Sprintf(
+						"Type argument for `%s` cannot be `%s`",
+						functionName,
+						typeArg,
+					),
+				})
+			}
+		},
+		ReturnTypeAnnotation: sema.NewTypeAnnotation(
+			&sema.OptionalType{
+				Type: typeAnnotation.Type,
+			},
+		),
+	}
+}
+
+func newCheckedArithmeticFunction(
+	name string,
+	docString string,
+	operation func(
+		left interpreter.NumberValue,
+		inter *interpreter.Interpreter,
+		right interpreter.NumberValue,
+		locationRange interpreter.LocationRange,
+	) interpreter.NumberValue,
+) StandardLibraryValue {
+	return NewStandardLibraryStaticFunction(
+		name,
+		newCheckedArithmeticFunctionType(name),
+		docString,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+
+			left, ok := invocation.Arguments[0].(interpreter.NumberValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			right, ok := invocation.Arguments[1].(interpreter.NumberValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			result, ok := checkedArithmetic(func() interpreter.NumberValue {
+				return operation(left, inter, right, invocation.LocationRange)
+			})
+			if !ok {
+				return interpreter.Nil
+			}
+
+			return interpreter.NewSomeValueNonCopying(inter, result)
+		},
+	)
+}
+
+// checkedArithmetic performs the given operation,
+// and returns false instead of aborting if the operation overflows, underflows, or divides by zero
+func checkedArithmetic(operation func() interpreter.NumberValue) (result interpreter.NumberValue, ok bool) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		switch recovered.(type) {
+		case interpreter.OverflowError,
+			interpreter.UnderflowError,
+			interpreter.DivisionByZeroError:
+
+			result = nil
+			ok = false
+
+		default:
+			panic(recovered)
+		}
+	}()
+
+	return operation(), true
+}
+
+var CheckedAddFunction = newCheckedArithmeticFunction(
+	"checkedAdd",
+	checkedAddFunctionDocString,
+	interpreter.NumberValue.Plus,
+)
+
+var CheckedSubtractFunction = newCheckedArithmeticFunction(
+	"checkedSubtract",
+	checkedSubtractFunctionDocString,
+	interpreter.NumberValue.Minus,
+)
+
+var CheckedMultiplyFunction = newCheckedArithmeticFunction(
+	"checkedMultiply",
+	checkedMultiplyFunctionDocString,
+	interpreter.NumberValue.Mul,
+)
+
+var CheckedDivideFunction = newCheckedArithmeticFunction(
+	"checkedDivide",
+	checkedDivideFunctionDocString,
+	checkedDivision(interpreter.NumberValue.Div),
+)
+
+var CheckedModuloFunction = newCheckedArithmeticFunction(
+	"checkedModulo",
+	checkedModuloFunctionDocString,
+	checkedDivision(interpreter.NumberValue.Mod),
+)
+
+// CheckedArithmeticFunctions are the checked arithmetic functions.
+// They are not part of the default standard library, as existing programs may declare functions with the same names
+var CheckedArithmeticFunctions = []StandardLibraryValue{
+	CheckedAddFunction,
+	CheckedSubtractFunction,
+	CheckedMultiplyFunction,
+	CheckedDivideFunction,
+	CheckedModuloFunction,
+}
+
+// checkedDivision wraps the given division operation
+// and reports a division by zero for fixed-point divisors,
+// which, unlike integer divisors, are not checked by the interpreter
+func checkedDivision(
+	operation func(
+		left interpreter.NumberValue,
+		inter *interpreter.Interpreter,
+		right interpreter.NumberValue,
+		locationRange interpreter.LocationRange,
+	) interpreter.NumberValue,
+) func(
+	left interpreter.NumberValue,
+	inter *interpreter.Interpreter,
+	right interpreter.NumberValue,
+	locationRange interpreter.LocationRange,
+) interpreter.NumberValue {
+	return func(
+		left interpreter.NumberValue,
+		inter *interpreter.Interpreter,
+		right interpreter.NumberValue,
+		locationRange interpreter.LocationRange,
+	) interpreter.NumberValue {
+		var isZero bool
+		switch right := right.(type) {
+		case interpreter.Fix64Value:
+			isZero = right == 0
+		case interpreter.UFix64Value:
+			isZero = right == 0
+		}
+
+		if isZero {
+			panic(interpreter.DivisionByZeroError{
+				LocationRange: locationRange,
+			})
+		}
+
+		return operation(left, inter, right, locationRange)
+	}
+}
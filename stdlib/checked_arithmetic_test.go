@@ -0,0 +1,143 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/sema"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+)
+
+func TestCheckCheckedArithmetic(t *testing.T) {
+
+	t.Parallel()
+
+	baseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
+	for _, function := range CheckedArithmeticFunctions {
+		baseValueActivation.DeclareValue(function)
+	}
+
+	parseAndCheck := func(t *testing.T, code string) (*sema.Checker, error) {
+		return ParseAndCheckWithOptions(t,
+			code,
+			ParseAndCheckOptions{
+				Config: &sema.Config{
+					BaseValueActivationHandler: func(_ common.Location) *sema.VariableActivation {
+						return baseValueActivation
+					},
+				},
+			},
+		)
+	}
+
+	t.Run("concrete type", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheck(t, `
+          let a: UInt8? = checkedAdd(1 as UInt8, 2)
+          let b: Fix64? = checkedDivide(1.0 as Fix64, 2.0)
+        `)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			&sema.OptionalType{Type: sema.UInt8Type},
+			RequireGlobalValue(t, checker.Elaboration, "a"),
+		)
+	})
+
+	t.Run("abstract type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := parseAndCheck(t, `
+          let x: Integer = 1
+          let a = checkedAdd(x, x)
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.InvalidTypeArgumentError{}, errs[0])
+	})
+
+	t.Run("mismatched types", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := parseAndCheck(t, `
+          let a = checkedAdd(1 as UInt8, 2 as Int8)
+        `)
+
+		errs := RequireCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
+
+func TestInterpretCheckedArithmetic(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, expression string, expected interpreter.Value) {
+		inter := newInterpreter(t,
+			`
+              access(all) fun test(): AnyStruct {
+                  return `+expression+`
+              }
+            `,
+			CheckedArithmeticFunctions...,
+		)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	}
+
+	for expression, expected := range map[string]interpreter.Value{
+		"checkedAdd(1 as UInt8, 2)": interpreter.NewUnmeteredSomeValueNonCopying(
+			interpreter.NewUnmeteredUInt8Value(3),
+		),
+		"checkedAdd(UInt8.max, 1)":          interpreter.Nil,
+		"checkedSubtract(0 as UInt, 1)":     interpreter.Nil,
+		"checkedSubtract(Int8.min, 1)":      interpreter.Nil,
+		"checkedMultiply(Int64.max, 2)":     interpreter.Nil,
+		"checkedMultiply(UFix64.max, 2.0)":  interpreter.Nil,
+		"checkedDivide(1 as Int, 0)":        interpreter.Nil,
+		"checkedDivide(Int8.min, -1)":       interpreter.Nil,
+		"checkedDivide(1.0 as Fix64, 0.0)":  interpreter.Nil,
+		"checkedModulo(5 as Word8, 0)":      interpreter.Nil,
+		"checkedAdd(Word8.max, 1)":          interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredWord8Value(0)),
+		"checkedModulo(7 as UInt16, 4)":     interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredUInt16Value(3)),
+		"checkedSubtract(10 as Int16, 12)":  interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredInt16Value(-2)),
+		"checkedMultiply(UInt128.max, 1)":   interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredUInt128ValueFromBigInt(sema.UInt128TypeMaxIntBig)),
+		"checkedMultiply(UInt128.max, 2)":   interpreter.Nil,
+		"checkedAdd(-1.5 as Fix64, 0.5)":    interpreter.NewUnmeteredSomeValueNonCopying(interpreter.NewUnmeteredFix64ValueWithInteger(-1, interpreter.EmptyLocationRange)),
+		"checkedDivide(Fix64.max, 0.5)":     interpreter.Nil,
+		"checkedModulo(1.0 as UFix64, 0.0)": interpreter.Nil,
+	} {
+		t.Run(expression, func(t *testing.T) {
+			test(t, expression, expected)
+		})
+	}
+}
@@ -0,0 +1,484 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	goerrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/old_parser"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/cadence/sema"
+)
+
+// ContractDeployment is a contract addition or update which is being validated.
+//
+// The validators of the pipeline are run in order, and earlier validators record their results,
+// e.g. the checked program, so later validators can use them.
+// A result is nil if the validator which produces it rejected the deployment.
+type ContractDeployment struct {
+	Interpreter *interpreter.Interpreter
+	Location    common.AddressLocation
+	Name        string
+	Code        []byte
+	// OldCode is the code of the existing contract, if this is an update
+	OldCode  []byte
+	IsUpdate bool
+
+	// Program is the parsed and checked new program
+	Program *interpreter.Program
+	// ContractType is the contract declared by the new program, if any
+	ContractType *sema.CompositeType
+	// ContractInterfaceType is the contract interface declared by the new program, if any
+	ContractInterfaceType *sema.InterfaceType
+	// OldProgram is the parsed program of the existing contract, if this is an update
+	OldProgram *ast.Program
+}
+
+// DeclaresContract returns true if the new program declares a valid contract or contract interface.
+func (d *ContractDeployment) DeclaresContract() bool {
+	return d.ContractType != nil || d.ContractInterfaceType != nil
+}
+
+// ContractDeploymentValidator validates contract additions and updates.
+//
+// Embedders may provide additional validators to enforce network policies.
+// Validators which depend on the results of earlier validators
+// should skip the validation if the results are not available.
+type ContractDeploymentValidator interface {
+	// Name returns the name of the validator, which is reported in errors
+	Name() string
+	// Validate validates the deployment, and returns an error if the deployment is rejected
+	Validate(deployment *ContractDeployment) error
+}
+
+type contractDeploymentValidatorFunc struct {
+	name     string
+	validate func(deployment *ContractDeployment) error
+}
+
+var _ ContractDeploymentValidator = contractDeploymentValidatorFunc{}
+
+// NewContractDeploymentValidator returns a contract deployment validator
+// with the given name, which validates deployments using the given function.
+func NewContractDeploymentValidator(
+	name string,
+	validate func(deployment *ContractDeployment) error,
+) ContractDeploymentValidator {
+	return contractDeploymentValidatorFunc{
+		name:     name,
+		validate: validate,
+	}
+}
+
+func (v contractDeploymentValidatorFunc) Name() string {
+	return v.name
+}
+
+func (v contractDeploymentValidatorFunc) Validate(deployment *ContractDeployment) error {
+	return v.validate(deployment)
+}
+
+// ContractDeploymentValidatorProvider may be implemented by an AccountContractAdditionHandler
+// to provide additional contract deployment validators
+type ContractDeploymentValidatorProvider interface {
+	// ContractDeploymentValidators returns the additional validators for contract deployments,
+	// which are run after the built-in validators
+	ContractDeploymentValidators() []ContractDeploymentValidator
+}
+
+// validateContractDeployment runs the built-in validators and the validators provided by the handler, if any,
+// and panics if any validator rejects the deployment.
+//
+// The built-in validators stop at the first error, as later ones depend on the results of earlier ones.
+// If only a built-in validator rejects the deployment, its error is reported as is.
+// If an additional validator rejects the deployment, all errors are combined
+// in a ContractDeploymentValidationError.
+func validateContractDeployment(
+	handler AccountContractAdditionAndNamesHandler,
+	deployment *ContractDeployment,
+	locationRange interpreter.LocationRange,
+) {
+	builtInValidators := []ContractDeploymentValidator{
+		contractProgramValidator{handler: handler},
+		contractDeclarationValidator{},
+		contractUpdateCompatibilityValidator{handler: handler},
+	}
+
+	var builtInValidator ContractDeploymentValidator
+	var builtInErr error
+
+	for _, validator := range builtInValidators {
+		err := validator.Validate(deployment)
+		if err != nil {
+			builtInValidator = validator
+			builtInErr = err
+			break
+		}
+	}
+
+	var errs []error
+
+	if provider, ok := handler.(ContractDeploymentValidatorProvider); ok {
+		for _, validator := range provider.ContractDeploymentValidators() {
+			err := validator.Validate(deployment)
+			if err != nil {
+				errs = append(errs, &ContractDeploymentValidatorError{
+					Validator: validator.Name(),
+					Err:       err,
+				})
+			}
+		}
+	}
+
+	if builtInErr == nil && len(errs) == 0 {
+		return
+	}
+
+	// Update the code for the error pretty printing.
+	// The code is the new code, or the old code if the old program is invalid.
+	// NOTE: only do this when an error occurs
+
+	code := deployment.Code
+	var oldProgramErr *OldProgramError
+	if goerrors.As(builtInErr, &oldProgramErr) {
+		code = deployment.OldCode
+	}
+
+	handler.TemporarilyRecordCode(deployment.Location, code)
+
+	if len(errs) == 0 {
+		// Invalid declarations are user errors on their own,
+		// all other errors are reported as an invalid deployment
+		if _, ok := builtInValidator.(contractDeclarationValidator); ok {
+			panic(builtInErr)
+		}
+
+		panic(&InvalidContractDeploymentError{
+			Err:           builtInErr,
+			LocationRange: locationRange,
+		})
+	}
+
+	if builtInErr != nil {
+		errs = append(
+			[]error{
+				&ContractDeploymentValidatorError{
+					Validator: builtInValidator.Name(),
+					Err:       builtInErr,
+				},
+			},
+			errs...,
+		)
+	}
+
+	panic(&InvalidContractDeploymentError{
+		Err: &ContractDeploymentValidationError{
+			Location: deployment.Location,
+			Errors:   errs,
+		},
+		LocationRange: locationRange,
+	})
+}
+
+// contractProgramValidator parses and checks the new code
+type contractProgramValidator struct {
+	handler AccountContractAdditionHandler
+}
+
+var _ ContractDeploymentValidator = contractProgramValidator{}
+
+func (contractProgramValidator) Name() string {
+	return "program"
+}
+
+func (v contractProgramValidator) Validate(deployment *ContractDeployment) error {
+
+	// NOTE: do NOT use the program obtained from the host environment, as the current program.
+	// Always re-parse and re-check the new program.
+
+	// NOTE: *DO NOT* store the program – the new or updated program
+	// should not be effective during the execution
+
+	const getAndSetProgram = false
+
+	program, err := v.handler.ParseAndCheckProgram(
+		deployment.Code,
+		deployment.Location,
+		getAndSetProgram,
+	)
+	if err != nil {
+		return err
+	}
+
+	deployment.Program = program
+
+	return nil
+}
+
+// contractDeclarationValidator ensures the new program declares exactly one contract
+// or one contract interface, with the name of the deployment
+type contractDeclarationValidator struct{}
+
+var _ ContractDeploymentValidator = contractDeclarationValidator{}
+
+func (contractDeclarationValidator) Name() string {
+	return "declaration"
+}
+
+func (contractDeclarationValidator) Validate(deployment *ContractDeployment) error {
+	program := deployment.Program
+	if program == nil {
+		return nil
+	}
+
+	// The code may declare exactly one contract or one contract interface.
+
+	var contractTypes []*sema.CompositeType
+	var contractInterfaceTypes []*sema.InterfaceType
+
+	program.Elaboration.ForEachGlobalType(func(_ string, variable *sema.Variable) {
+		switch ty := variable.Type.(type) {
+		case *sema.CompositeType:
+			if ty.Kind == common.CompositeKindContract {
+				contractTypes = append(contractTypes, ty)
+			}
+
+		case *sema.InterfaceType:
+			if ty.CompositeKind == common.CompositeKindContract {
+				contractInterfaceTypes = append(contractInterfaceTypes, ty)
+			}
+		}
+	})
+
+	var contractType *sema.CompositeType
+	var contractInterfaceType *sema.InterfaceType
+	var declaredName string
+	var declarationKind common.DeclarationKind
+
+	switch {
+	case len(contractTypes) == 1 && len(contractInterfaceTypes) == 0:
+		contractType = contractTypes[0]
+		declaredName = contractType.Identifier
+		declarationKind = common.DeclarationKindContract
+	case len(contractInterfaceTypes) == 1 && len(contractTypes) == 0:
+		contractInterfaceType = contractInterfaceTypes[0]
+		declaredName = contractInterfaceType.Identifier
+		declarationKind = common.DeclarationKindContractInterface
+	default:
+		return errors.NewDefaultUserError(
+			"invalid %s: the code must declare exactly one contract or contract interface",
+			declarationKind.Name(),
+		)
+	}
+
+	// The declared contract or contract interface must have the name
+	// passed to the constructor as the first argument
+
+	if declaredName != deployment.Name {
+		return errors.NewDefaultUserError(
+			"invalid %s: the name argument must match the name of the declaration: got %q, expected %q",
+			declarationKind.Name(),
+			deployment.Name,
+			declaredName,
+		)
+	}
+
+	deployment.ContractType = contractType
+	deployment.ContractInterfaceType = contractInterfaceType
+
+	return nil
+}
+
+// contractUpdateCompatibilityValidator ensures an updated program is compatible with the existing program
+type contractUpdateCompatibilityValidator struct {
+	handler AccountContractNamesProvider
+}
+
+var _ ContractDeploymentValidator = contractUpdateCompatibilityValidator{}
+
+func (contractUpdateCompatibilityValidator) Name() string {
+	return "update compatibility"
+}
+
+func (v contractUpdateCompatibilityValidator) Validate(deployment *ContractDeployment) error {
+	if !deployment.IsUpdate || !deployment.DeclaresContract() {
+		return nil
+	}
+
+	inter := deployment.Interpreter
+	config := inter.SharedState.Config
+
+	memoryGauge := config.MemoryGauge
+	legacyUpgradeEnabled := config.LegacyContractUpgradeEnabled
+
+	var oldProgram *ast.Program
+	var err error
+
+	// It is not always possible to determine whether the old code is pre-1.0 or not,
+	// only based on the parser errors. Therefore, always rely on the flag only.
+	// If the legacy contract upgrades are enabled, then use the old parser.
+	if legacyUpgradeEnabled {
+		oldProgram, err = old_parser.ParseProgram(
+			memoryGauge,
+			deployment.OldCode,
+			old_parser.Config{},
+		)
+	} else {
+		oldProgram, err = parser.ParseProgram(
+			memoryGauge,
+			deployment.OldCode,
+			parser.Config{
+				IgnoreLeadingIdentifierEnabled: true,
+			},
+		)
+	}
+
+	if err != nil && !ignoreUpdatedProgramParserError(err) {
+		// NOTE: Errors are usually in the new program / new code,
+		// but here we failed for the old program / old code.
+		return &OldProgramError{
+			Err:      err,
+			Location: deployment.Location,
+		}
+	}
+
+	deployment.OldProgram = oldProgram
+
+	var validator UpdateValidator
+	if legacyUpgradeEnabled {
+		validator = NewCadenceV042ToV1ContractUpdateValidator(
+			deployment.Location,
+			deployment.Name,
+			v.handler,
+			oldProgram,
+			deployment.Program,
+			inter.AllElaborations(),
+		)
+	} else {
+		validator = NewContractUpdateValidator(
+			deployment.Location,
+			deployment.Name,
+			v.handler,
+			oldProgram,
+			deployment.Program.Program,
+		)
+	}
+
+	return validator.Validate()
+}
+
+// ContractCodeSizeValidator rejects contract deployments with code larger than the limit
+type ContractCodeSizeValidator struct {
+	Limit uint64
+}
+
+var _ ContractDeploymentValidator = ContractCodeSizeValidator{}
+
+func (ContractCodeSizeValidator) Name() string {
+	return "code size"
+}
+
+func (v ContractCodeSizeValidator) Validate(deployment *ContractDeployment) error {
+	size := uint64(len(deployment.Code))
+	if size > v.Limit {
+		return &ContractCodeSizeLimitExceededError{
+			Size:  size,
+			Limit: v.Limit,
+		}
+	}
+	return nil
+}
+
+// ContractCodeSizeLimitExceededError
+type ContractCodeSizeLimitExceededError struct {
+	Size  uint64
+	Limit uint64
+}
+
+var _ errors.UserError = &ContractCodeSizeLimitExceededError{}
+
+func (*ContractCodeSizeLimitExceededError) IsUserError() {}
+
+func (e *ContractCodeSizeLimitExceededError) Error() string {
+	return fmt.Sprintf(
+		"contract code size limit exceeded: %d bytes, limit is %d bytes",
+		e.Size,
+		e.Limit,
+	)
+}
+
+// ContractDeploymentValidatorError is reported when a validator rejects a contract deployment
+type ContractDeploymentValidatorError struct {
+	Validator string
+	Err       error
+}
+
+var _ errors.UserError = &ContractDeploymentValidatorError{}
+var _ errors.ParentError = &ContractDeploymentValidatorError{}
+
+func (*ContractDeploymentValidatorError) IsUserError() {}
+
+func (e *ContractDeploymentValidatorError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ContractDeploymentValidatorError) ChildErrors() []error {
+	return []error{e.Err}
+}
+
+func (e *ContractDeploymentValidatorError) Unwrap() error {
+	return e.Err
+}
+
+// ContractDeploymentValidationError combines the errors of all validators
+// which rejected a contract deployment
+type ContractDeploymentValidationError struct {
+	Location common.AddressLocation
+	Errors   []error
+}
+
+var _ errors.UserError = &ContractDeploymentValidationError{}
+var _ errors.ParentError = &ContractDeploymentValidationError{}
+
+func (*ContractDeploymentValidationError) IsUserError() {}
+
+func (e *ContractDeploymentValidationError) Error() string {
+	var sb strings.Builder
+	for i, err := range e.Errors {
+		if i > 0 {
+			sb.WriteString("; ")
+		}
+		sb.WriteString(err.Error())
+	}
+	return sb.String()
+}
+
+func (e *ContractDeploymentValidationError) ChildErrors() []error {
+	return e.Errors
+}
+
+func (e *ContractDeploymentValidationError) Unwrap() []error {
+	return e.Errors
+}
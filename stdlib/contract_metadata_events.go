@@ -0,0 +1,215 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/sema"
+)
+
+// ContractMetadataEventsHandler configures the emission of contract metadata events
+type ContractMetadataEventsHandler interface {
+	// ContractMetadataEventsEnabled returns true if metadata events should be emitted
+	// in addition to the standard events, when contracts are added, updated, or removed
+	ContractMetadataEventsEnabled() bool
+}
+
+// contractTypeInfo describes the types declared by a contract or contract interface
+type contractTypeInfo struct {
+	// declaredTypes are the IDs of all declared types, including nested types
+	declaredTypes []common.TypeID
+	// eventTypes are the IDs of the declared event types
+	eventTypes []common.TypeID
+}
+
+// newContractTypeInfo returns the types declared by the given program.
+// The types are determined from the declarations, so the program does not have to be checked.
+// If the program is nil, or does not declare a contract or contract interface, no types are returned.
+func newContractTypeInfo(location common.Location, program *ast.Program) (info contractTypeInfo) {
+	if program == nil {
+		return
+	}
+
+	rootDeclaration, err := getRootDeclarationOfProgram(program)
+	if err != nil {
+		return
+	}
+
+	var addDeclaration func(declaration ast.Declaration, prefix string)
+	addDeclaration = func(declaration ast.Declaration, prefix string) {
+		qualifiedIdentifier := declaration.DeclarationIdentifier().Identifier
+		if prefix != "" {
+			qualifiedIdentifier = prefix + "." + qualifiedIdentifier
+		}
+
+		typeID := location.TypeID(nil, qualifiedIdentifier)
+		info.declaredTypes = append(info.declaredTypes, typeID)
+
+		if compositeDeclaration, ok := declaration.(*ast.CompositeDeclaration); ok &&
+			compositeDeclaration.CompositeKind == common.CompositeKindEvent {
+
+			info.eventTypes = append(info.eventTypes, typeID)
+		}
+
+		members := declaration.DeclarationMembers()
+		if members == nil {
+			return
+		}
+
+		for _, nestedDeclaration := range members.Declarations() {
+			switch nestedDeclaration.(type) {
+			case *ast.CompositeDeclaration,
+				*ast.InterfaceDeclaration,
+				*ast.AttachmentDeclaration,
+				*ast.EntitlementDeclaration,
+				*ast.EntitlementMappingDeclaration:
+
+				addDeclaration(nestedDeclaration, qualifiedIdentifier)
+			}
+		}
+	}
+
+	addDeclaration(rootDeclaration, "")
+
+	return
+}
+
+// typeIDsDifference returns the type IDs in a which are not in b, in the order of a
+func typeIDsDifference(a, b []common.TypeID) []common.TypeID {
+	excluded := make(map[common.TypeID]struct{}, len(b))
+	for _, typeID := range b {
+		excluded[typeID] = struct{}{}
+	}
+
+	var result []common.TypeID
+	for _, typeID := range a {
+		if _, ok := excluded[typeID]; !ok {
+			result = append(result, typeID)
+		}
+	}
+	return result
+}
+
+func newTypeIDsValue(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	typeIDs []common.TypeID,
+) *interpreter.ArrayValue {
+	values := make([]interpreter.Value, len(typeIDs))
+	for i, typeID := range typeIDs {
+		memoryUsage := common.NewStringMemoryUsage(len(typeID))
+		values[i] = interpreter.NewStringValue(
+			inter,
+			memoryUsage,
+			func() string {
+				return string(typeID)
+			},
+		)
+	}
+
+	arrayType := interpreter.NewVariableSizedStaticType(
+		inter,
+		interpreter.NewPrimitiveStaticType(
+			inter,
+			interpreter.PrimitiveStaticTypeString,
+		),
+	)
+
+	return interpreter.NewArrayValue(
+		inter,
+		locationRange,
+		arrayType,
+		common.ZeroAddress,
+		values...,
+	)
+}
+
+func emitContractMetadataEvent(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	emitter EventEmitter,
+	eventType *sema.CompositeType,
+	addressValue interpreter.AddressValue,
+	codeHashValue *interpreter.ArrayValue,
+	nameValue *interpreter.StringValue,
+	declaredTypes []common.TypeID,
+	addedEventTypes []common.TypeID,
+	removedEventTypes []common.TypeID,
+) {
+	emitter.EmitEvent(
+		inter,
+		locationRange,
+		eventType,
+		[]interpreter.Value{
+			addressValue,
+			codeHashValue,
+			nameValue,
+			newTypeIDsValue(inter, locationRange, declaredTypes),
+			newTypeIDsValue(inter, locationRange, addedEventTypes),
+			newTypeIDsValue(inter, locationRange, removedEventTypes),
+		},
+	)
+}
+
+// emitContractDeploymentMetadataEvent emits the metadata event for the addition or update of a contract.
+// For an update, the event types are compared to the event types of the old program, if it is available.
+func emitContractDeploymentMetadataEvent(
+	inter *interpreter.Interpreter,
+	locationRange interpreter.LocationRange,
+	emitter EventEmitter,
+	deployment *ContractDeployment,
+	addressValue interpreter.AddressValue,
+	codeHashValue *interpreter.ArrayValue,
+	nameValue *interpreter.StringValue,
+) {
+	newTypes := newContractTypeInfo(deployment.Location, deployment.Program.Program)
+
+	if !deployment.IsUpdate {
+		emitContractMetadataEvent(
+			inter,
+			locationRange,
+			emitter,
+			AccountContractAddedMetadataEventType,
+			addressValue,
+			codeHashValue,
+			nameValue,
+			newTypes.declaredTypes,
+			newTypes.eventTypes,
+			nil,
+		)
+		return
+	}
+
+	oldTypes := newContractTypeInfo(deployment.Location, deployment.OldProgram)
+
+	emitContractMetadataEvent(
+		inter,
+		locationRange,
+		emitter,
+		AccountContractUpdatedMetadataEventType,
+		addressValue,
+		codeHashValue,
+		nameValue,
+		newTypes.declaredTypes,
+		typeIDsDifference(newTypes.eventTypes, oldTypes.eventTypes),
+		typeIDsDifference(oldTypes.eventTypes, newTypes.eventTypes),
+	)
+}
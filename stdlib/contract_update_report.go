@@ -0,0 +1,578 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+)
+
+// ContractUpdateChange is the kind of change of a declaration or a field in a contract update
+type ContractUpdateChange uint8
+
+const (
+	ContractUpdateChangeUnchanged ContractUpdateChange = iota
+	ContractUpdateChangeAdded
+	ContractUpdateChangeRemoved
+	ContractUpdateChangeChanged
+)
+
+func (c ContractUpdateChange) String() string {
+	switch c {
+	case ContractUpdateChangeUnchanged:
+		return "unchanged"
+	case ContractUpdateChangeAdded:
+		return "added"
+	case ContractUpdateChangeRemoved:
+		return "removed"
+	case ContractUpdateChangeChanged:
+		return "changed"
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+func (c ContractUpdateChange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// StorageLayoutImpact is the impact of the update of a declaration
+// on the values of the declared type which are already stored
+type StorageLayoutImpact uint8
+
+const (
+	// StorageLayoutImpactNone indicates that stored values are not affected
+	StorageLayoutImpactNone StorageLayoutImpact = iota
+	// StorageLayoutImpactUnusedData indicates that stored values keep data
+	// which is no longer used, e.g. the values of removed fields
+	StorageLayoutImpactUnusedData
+	// StorageLayoutImpactIncompatible indicates that stored values
+	// no longer match the declaration, e.g. because a field was added
+	StorageLayoutImpactIncompatible
+)
+
+func (i StorageLayoutImpact) String() string {
+	switch i {
+	case StorageLayoutImpactNone:
+		return "none"
+	case StorageLayoutImpactUnusedData:
+		return "unusedData"
+	case StorageLayoutImpactIncompatible:
+		return "incompatible"
+	}
+
+	panic(errors.NewUnreachableError())
+}
+
+func (i StorageLayoutImpact) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// ContractUpdateReport is a report of the changes of a contract update,
+// and of the reasons why the update is invalid, if it is.
+//
+// The changes are determined syntactically, e.g. a field type is changed
+// if it is written differently. Whether a change is valid is determined by the validator,
+// which reports issues for the declarations and fields of invalid changes
+type ContractUpdateReport struct {
+	Location     common.Location `json:"location"`
+	ContractName string          `json:"contractName"`
+	Valid        bool            `json:"valid"`
+	// Declarations are the contract and its nested type declarations,
+	// with the nested declarations of a declaration following it
+	Declarations []*DeclarationUpdateReport `json:"declarations"`
+	// Issues are the issues which are not specific to a declaration,
+	// e.g. if the old or new program has no contract
+	Issues []*ContractUpdateIssue `json:"issues,omitempty"`
+}
+
+// DeclarationUpdateReport is a report of the changes of a type declaration in a contract update
+type DeclarationUpdateReport struct {
+	// Name is the qualified name of the declaration, e.g. `C.R` for resource `R` in contract `C`
+	Name                string                 `json:"name"`
+	OldKind             common.DeclarationKind `json:"oldKind,omitempty"`
+	NewKind             common.DeclarationKind `json:"newKind,omitempty"`
+	Change              ContractUpdateChange   `json:"change"`
+	StorageLayoutImpact StorageLayoutImpact    `json:"storageLayoutImpact"`
+	// Fields are the added, removed, and changed fields
+	Fields              []*FieldUpdateReport   `json:"fields,omitempty"`
+	AddedConformances   []string               `json:"addedConformances,omitempty"`
+	RemovedConformances []string               `json:"removedConformances,omitempty"`
+	AddedEnumCases      []string               `json:"addedEnumCases,omitempty"`
+	RemovedEnumCases    []string               `json:"removedEnumCases,omitempty"`
+	Issues              []*ContractUpdateIssue `json:"issues,omitempty"`
+}
+
+// FieldUpdateReport is a report of the change of a field in a contract update.
+// The access of a field reflects entitlement changes, e.g. `access(all)` to `access(E)`
+type FieldUpdateReport struct {
+	Name      string                 `json:"name"`
+	Change    ContractUpdateChange   `json:"change"`
+	OldType   string                 `json:"oldType,omitempty"`
+	NewType   string                 `json:"newType,omitempty"`
+	OldAccess string                 `json:"oldAccess,omitempty"`
+	NewAccess string                 `json:"newAccess,omitempty"`
+	Issues    []*ContractUpdateIssue `json:"issues,omitempty"`
+}
+
+// ContractUpdateIssue is an error reported by the validator, i.e. why an update is invalid
+type ContractUpdateIssue struct {
+	// Kind is the name of the error type, e.g. `FieldMismatchError`
+	Kind             string     `json:"kind"`
+	Message          string     `json:"message"`
+	SecondaryMessage string     `json:"secondaryMessage,omitempty"`
+	Range            *ast.Range `json:"range,omitempty"`
+	Err              error      `json:"-"`
+}
+
+func newContractUpdateIssue(err error) *ContractUpdateIssue {
+	errType := reflect.TypeOf(err)
+	if errType.Kind() == reflect.Pointer {
+		errType = errType.Elem()
+	}
+
+	issue := &ContractUpdateIssue{
+		Kind:    errType.Name(),
+		Message: err.Error(),
+		Err:     err,
+	}
+
+	if secondaryError, ok := err.(errors.SecondaryError); ok {
+		issue.SecondaryMessage = secondaryError.SecondaryError()
+	}
+
+	if positioned, ok := err.(ast.HasPosition); ok {
+		issueRange := ast.NewUnmeteredRangeFromPositioned(positioned)
+		issue.Range = &issueRange
+	}
+
+	return issue
+}
+
+func (r *DeclarationUpdateReport) fieldReport(name string) *FieldUpdateReport {
+	for _, fieldReport := range r.Fields {
+		if fieldReport.Name == name {
+			return fieldReport
+		}
+	}
+
+	// The field is written the same in the old and new program,
+	// but the validator still considers it changed,
+	// e.g. because an imported type changed
+
+	fieldReport := &FieldUpdateReport{
+		Name:   name,
+		Change: ContractUpdateChangeUnchanged,
+	}
+	r.Fields = append(r.Fields, fieldReport)
+	return fieldReport
+}
+
+func (r *DeclarationUpdateReport) storageLayoutImpact() StorageLayoutImpact {
+	// Only values of composite types are stored.
+	// Values of added types cannot be stored yet
+
+	if r.Change == ContractUpdateChangeAdded ||
+		!isStoredDeclarationKind(r.OldKind) {
+
+		return StorageLayoutImpactNone
+	}
+
+	for _, issue := range r.Issues {
+		switch issue.Err.(type) {
+		case *InvalidDeclarationKindChangeError,
+			*MissingDeclarationError,
+			*MissingEnumCasesError,
+			*EnumCaseMismatchError:
+
+			return StorageLayoutImpactIncompatible
+		}
+	}
+
+	unusedData := r.Change == ContractUpdateChangeRemoved
+
+	for _, fieldReport := range r.Fields {
+		if len(fieldReport.Issues) > 0 {
+			return StorageLayoutImpactIncompatible
+		}
+		if fieldReport.Change == ContractUpdateChangeRemoved {
+			unusedData = true
+		}
+	}
+
+	if unusedData {
+		return StorageLayoutImpactUnusedData
+	}
+
+	return StorageLayoutImpactNone
+}
+
+func isStoredDeclarationKind(kind common.DeclarationKind) bool {
+	switch kind {
+	case common.DeclarationKindContract,
+		common.DeclarationKindStructure,
+		common.DeclarationKindResource,
+		common.DeclarationKindEnum,
+		common.DeclarationKindAttachment:
+		return true
+	}
+	return false
+}
+
+type contractUpdateReporter struct {
+	report *ContractUpdateReport
+	// declarationReports are the reports of the declarations of the new program
+	declarationReports      map[ast.Declaration]*DeclarationUpdateReport
+	namedDeclarationReports map[string]*DeclarationUpdateReport
+}
+
+func newContractUpdateReport(validator *ContractUpdateValidator) *ContractUpdateReport {
+	reporter := &contractUpdateReporter{
+		report: &ContractUpdateReport{
+			Location:     validator.location,
+			ContractName: validator.contractName,
+			Valid:        !validator.hasErrors(),
+			Declarations: []*DeclarationUpdateReport{},
+		},
+		declarationReports:      map[ast.Declaration]*DeclarationUpdateReport{},
+		namedDeclarationReports: map[string]*DeclarationUpdateReport{},
+	}
+
+	oldRootDecl, oldErr := getRootDeclarationOfProgram(validator.oldProgram)
+	newRootDecl, newErr := getRootDeclarationOfProgram(validator.newProgram)
+	if oldErr == nil && newErr == nil {
+		reporter.reportDeclaration("", oldRootDecl, newRootDecl)
+	}
+
+	for i, err := range validator.errors {
+		reporter.reportIssue(err, validator.errorDeclarations[i])
+	}
+
+	for _, declarationReport := range reporter.report.Declarations {
+		declarationReport.StorageLayoutImpact = declarationReport.storageLayoutImpact()
+	}
+
+	return reporter.report
+}
+
+func (r *contractUpdateReporter) reportDeclaration(
+	containerName string,
+	oldDeclaration ast.Declaration,
+	newDeclaration ast.Declaration,
+) {
+	var identifier string
+	if newDeclaration != nil {
+		identifier = newDeclaration.DeclarationIdentifier().Identifier
+	} else {
+		identifier = oldDeclaration.DeclarationIdentifier().Identifier
+	}
+
+	name := identifier
+	if containerName != "" {
+		name = containerName + "." + identifier
+	}
+
+	declarationReport := &DeclarationUpdateReport{
+		Name: name,
+	}
+	r.report.Declarations = append(r.report.Declarations, declarationReport)
+	r.namedDeclarationReports[name] = declarationReport
+
+	switch {
+	case oldDeclaration == nil:
+		declarationReport.Change = ContractUpdateChangeAdded
+		declarationReport.NewKind = newDeclaration.DeclarationKind()
+		r.declarationReports[newDeclaration] = declarationReport
+
+	case newDeclaration == nil:
+		declarationReport.Change = ContractUpdateChangeRemoved
+		declarationReport.OldKind = oldDeclaration.DeclarationKind()
+
+	default:
+		declarationReport.OldKind = oldDeclaration.DeclarationKind()
+		declarationReport.NewKind = newDeclaration.DeclarationKind()
+		r.declarationReports[newDeclaration] = declarationReport
+
+		declarationReport.Fields = fieldUpdateReports(oldDeclaration, newDeclaration)
+
+		declarationReport.AddedConformances, declarationReport.RemovedConformances =
+			diffNames(conformanceNames(oldDeclaration), conformanceNames(newDeclaration))
+
+		declarationReport.AddedEnumCases, declarationReport.RemovedEnumCases =
+			diffNames(enumCaseNames(oldDeclaration), enumCaseNames(newDeclaration))
+
+		if declarationReport.OldKind != declarationReport.NewKind ||
+			oldDeclaration.DeclarationIdentifier().Identifier != identifier ||
+			len(declarationReport.Fields) > 0 ||
+			len(declarationReport.AddedConformances) > 0 ||
+			len(declarationReport.RemovedConformances) > 0 ||
+			len(declarationReport.AddedEnumCases) > 0 ||
+			len(declarationReport.RemovedEnumCases) > 0 {
+
+			declarationReport.Change = ContractUpdateChangeChanged
+		}
+	}
+
+	// Report the nested declarations.
+	// The nested declarations of the new declaration come first, in declaration order,
+	// followed by the removed nested declarations of the old declaration, ordered by name
+
+	oldNestedDeclarations := map[string]ast.Declaration{}
+	if oldDeclaration != nil {
+		for _, oldNestedDeclaration := range nestedTypeDeclarations(oldDeclaration) {
+			oldNestedDeclarations[oldNestedDeclaration.DeclarationIdentifier().Identifier] = oldNestedDeclaration
+		}
+	}
+
+	if newDeclaration != nil {
+		for _, newNestedDeclaration := range nestedTypeDeclarations(newDeclaration) {
+			nestedIdentifier := newNestedDeclaration.DeclarationIdentifier().Identifier
+			oldNestedDeclaration := oldNestedDeclarations[nestedIdentifier]
+			delete(oldNestedDeclarations, nestedIdentifier)
+
+			r.reportDeclaration(name, oldNestedDeclaration, newNestedDeclaration)
+		}
+	}
+
+	removedDeclarations := make([]ast.Declaration, 0, len(oldNestedDeclarations))
+	for _, oldNestedDeclaration := range oldNestedDeclarations { //nolint:maprange
+		removedDeclarations = append(removedDeclarations, oldNestedDeclaration)
+	}
+
+	sort.Slice(removedDeclarations, func(i, j int) bool {
+		return removedDeclarations[i].DeclarationIdentifier().Identifier <
+			removedDeclarations[j].DeclarationIdentifier().Identifier
+	})
+
+	for _, removedDeclaration := range removedDeclarations {
+		r.reportDeclaration(name, removedDeclaration, nil)
+	}
+}
+
+// reportIssue adds the given error, reported while checking the given declaration of the new program,
+// to the report of the declaration or field it is about
+func (r *contractUpdateReporter) reportIssue(err error, declaration ast.Declaration) {
+	issue := newContractUpdateIssue(err)
+
+	var declarationReport *DeclarationUpdateReport
+	if declaration != nil {
+		declarationReport = r.declarationReports[declaration]
+	}
+
+	switch err := err.(type) {
+	case *FieldMismatchError:
+		if declarationReport != nil {
+			fieldReport := declarationReport.fieldReport(err.FieldName)
+			fieldReport.Issues = append(fieldReport.Issues, issue)
+			return
+		}
+
+	case *ExtraneousFieldError:
+		if declarationReport != nil {
+			fieldReport := declarationReport.fieldReport(err.FieldName)
+			fieldReport.Issues = append(fieldReport.Issues, issue)
+			return
+		}
+
+	case *MissingDeclarationError:
+		// Reported while checking the containing declaration
+		if declarationReport != nil {
+			nestedReport := r.namedDeclarationReports[declarationReport.Name+"."+err.Name]
+			if nestedReport != nil {
+				nestedReport.Issues = append(nestedReport.Issues, issue)
+				return
+			}
+		}
+
+	case *InvalidDeclarationKindChangeError:
+		// Reported before checking the declaration, i.e. while checking the containing declaration,
+		// or no declaration, if it is the contract
+		if declarationReport != nil {
+			declarationReport = r.namedDeclarationReports[declarationReport.Name+"."+err.Name]
+		} else if len(r.report.Declarations) > 0 {
+			declarationReport = r.report.Declarations[0]
+		}
+	}
+
+	if declarationReport != nil {
+		declarationReport.Issues = append(declarationReport.Issues, issue)
+		return
+	}
+
+	r.report.Issues = append(r.report.Issues, issue)
+}
+
+func nestedTypeDeclarations(declaration ast.Declaration) []ast.Declaration {
+	members := declaration.DeclarationMembers()
+	if members == nil {
+		return nil
+	}
+
+	var declarations []ast.Declaration
+
+	for _, nestedDeclaration := range members.Composites() {
+		declarations = append(declarations, nestedDeclaration)
+	}
+	for _, nestedDeclaration := range members.Attachments() {
+		declarations = append(declarations, nestedDeclaration)
+	}
+	for _, nestedDeclaration := range members.Interfaces() {
+		declarations = append(declarations, nestedDeclaration)
+	}
+	for _, nestedDeclaration := range members.Entitlements() {
+		declarations = append(declarations, nestedDeclaration)
+	}
+	for _, nestedDeclaration := range members.EntitlementMaps() {
+		declarations = append(declarations, nestedDeclaration)
+	}
+
+	return declarations
+}
+
+func fieldUpdateReports(oldDeclaration, newDeclaration ast.Declaration) []*FieldUpdateReport {
+	oldMembers := oldDeclaration.DeclarationMembers()
+	newMembers := newDeclaration.DeclarationMembers()
+
+	// Entitlement declarations have no members
+	if oldMembers == nil || newMembers == nil {
+		return nil
+	}
+
+	oldFields := oldMembers.FieldsByIdentifier()
+	newFields := newMembers.FieldsByIdentifier()
+
+	var fieldReports []*FieldUpdateReport
+
+	for _, newField := range newMembers.Fields() {
+		name := newField.Identifier.Identifier
+		newType := newField.TypeAnnotation.String()
+		newAccess := newField.Access.Keyword()
+
+		oldField := oldFields[name]
+		if oldField == nil {
+			fieldReports = append(
+				fieldReports,
+				&FieldUpdateReport{
+					Name:      name,
+					Change:    ContractUpdateChangeAdded,
+					NewType:   newType,
+					NewAccess: newAccess,
+				},
+			)
+			continue
+		}
+
+		oldType := oldField.TypeAnnotation.String()
+		oldAccess := oldField.Access.Keyword()
+
+		if oldType == newType && oldAccess == newAccess {
+			continue
+		}
+
+		fieldReports = append(
+			fieldReports,
+			&FieldUpdateReport{
+				Name:      name,
+				Change:    ContractUpdateChangeChanged,
+				OldType:   oldType,
+				NewType:   newType,
+				OldAccess: oldAccess,
+				NewAccess: newAccess,
+			},
+		)
+	}
+
+	for _, oldField := range oldMembers.Fields() {
+		name := oldField.Identifier.Identifier
+		if newFields[name] != nil {
+			continue
+		}
+
+		fieldReports = append(
+			fieldReports,
+			&FieldUpdateReport{
+				Name:      name,
+				Change:    ContractUpdateChangeRemoved,
+				OldType:   oldField.TypeAnnotation.String(),
+				OldAccess: oldField.Access.Keyword(),
+			},
+		)
+	}
+
+	return fieldReports
+}
+
+func conformanceNames(declaration ast.Declaration) []string {
+	conformingDeclaration, ok := declaration.(ast.ConformingDeclaration)
+	if !ok {
+		return nil
+	}
+
+	conformances := conformingDeclaration.ConformanceList()
+	names := make([]string, 0, len(conformances))
+	for _, conformance := range conformances {
+		names = append(names, conformance.String())
+	}
+	return names
+}
+
+func enumCaseNames(declaration ast.Declaration) []string {
+	members := declaration.DeclarationMembers()
+	if members == nil {
+		return nil
+	}
+
+	enumCases := members.EnumCases()
+	names := make([]string, 0, len(enumCases))
+	for _, enumCase := range enumCases {
+		names = append(names, enumCase.Identifier.Identifier)
+	}
+	return names
+}
+
+// diffNames returns the names which are only in the new names, and the names which are only in the old names,
+// in the order in which they occur
+func diffNames(oldNames, newNames []string) (added, removed []string) {
+	oldNameSet := make(map[string]struct{}, len(oldNames))
+	for _, name := range oldNames {
+		oldNameSet[name] = struct{}{}
+	}
+
+	newNameSet := make(map[string]struct{}, len(newNames))
+	for _, name := range newNames {
+		newNameSet[name] = struct{}{}
+
+		if _, ok := oldNameSet[name]; !ok {
+			added = append(added, name)
+		}
+	}
+
+	for _, name := range oldNames {
+		if _, ok := newNameSet[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+
+	return
+}
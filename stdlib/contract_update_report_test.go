@@ -0,0 +1,366 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/parser"
+	"github.com/onflow/cadence/stdlib"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+)
+
+func testContractUpdateReport(t *testing.T, oldCode string, newCode string) *stdlib.ContractUpdateReport {
+	oldProgram, err := parser.ParseProgram(nil, []byte(oldCode), parser.Config{})
+	require.NoError(t, err)
+
+	newProgram, err := parser.ParseProgram(nil, []byte(newCode), parser.Config{})
+	require.NoError(t, err)
+
+	validator := stdlib.NewContractUpdateValidator(
+		TestLocation,
+		"Test",
+		&TestRuntimeInterface{},
+		oldProgram,
+		newProgram,
+	)
+
+	return validator.Report()
+}
+
+func TestContractUpdateReport(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            access(all) contract Test {
+                access(all) var a: Int
+                access(all) var b: String
+
+                init() {
+                    self.a = 1
+                    self.b = ""
+                }
+            }
+        `
+
+		const newCode = `
+            access(all) contract Test {
+                access(all) var a: Int
+
+                access(all) struct S {}
+
+                init() {
+                    self.a = 1
+                }
+            }
+        `
+
+		report := testContractUpdateReport(t, oldCode, newCode)
+
+		assert.Equal(t,
+			&stdlib.ContractUpdateReport{
+				Location:     TestLocation,
+				ContractName: "Test",
+				Valid:        true,
+				Declarations: []*stdlib.DeclarationUpdateReport{
+					{
+						Name:                "Test",
+						OldKind:             common.DeclarationKindContract,
+						NewKind:             common.DeclarationKindContract,
+						Change:              stdlib.ContractUpdateChangeChanged,
+						StorageLayoutImpact: stdlib.StorageLayoutImpactUnusedData,
+						Fields: []*stdlib.FieldUpdateReport{
+							{
+								Name:      "b",
+								Change:    stdlib.ContractUpdateChangeRemoved,
+								OldType:   "String",
+								OldAccess: "access(all)",
+							},
+						},
+					},
+					{
+						Name:                "Test.S",
+						NewKind:             common.DeclarationKindStructure,
+						Change:              stdlib.ContractUpdateChangeAdded,
+						StorageLayoutImpact: stdlib.StorageLayoutImpactNone,
+					},
+				},
+			},
+			report,
+		)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            access(all) contract Test {
+
+                access(all) entitlement E
+
+                access(all) resource interface I {}
+
+                access(all) resource R: I {
+                    access(all) var a: Int
+
+                    init() {
+                        self.a = 1
+                    }
+                }
+
+                access(all) struct S {}
+
+                access(all) enum Color: UInt8 {
+                    access(all) case red
+                    access(all) case green
+                }
+            }
+        `
+
+		const newCode = `
+            access(all) contract Test {
+
+                access(all) resource R {
+                    access(E) var a: String
+                    access(all) var b: Int
+
+                    init() {
+                        self.a = ""
+                        self.b = 1
+                    }
+                }
+
+                access(all) resource interface I {}
+
+                access(all) enum Color: UInt8 {
+                    access(all) case red
+                }
+            }
+        `
+
+		report := testContractUpdateReport(t, oldCode, newCode)
+
+		require.False(t, report.Valid)
+		require.Empty(t, report.Issues)
+
+		names := make([]string, 0, len(report.Declarations))
+		for _, declarationReport := range report.Declarations {
+			names = append(names, declarationReport.Name)
+		}
+		require.Equal(t,
+			[]string{"Test", "Test.R", "Test.Color", "Test.I", "Test.E", "Test.S"},
+			names,
+		)
+
+		contractReport := report.Declarations[0]
+		assert.Equal(t, stdlib.ContractUpdateChangeUnchanged, contractReport.Change)
+		assert.Equal(t, stdlib.StorageLayoutImpactNone, contractReport.StorageLayoutImpact)
+		assert.Empty(t, contractReport.Issues)
+
+		// Resource: field type and access changed, field added, conformance removed
+
+		resourceReport := report.Declarations[1]
+		assert.Equal(t, stdlib.ContractUpdateChangeChanged, resourceReport.Change)
+		assert.Equal(t, stdlib.StorageLayoutImpactIncompatible, resourceReport.StorageLayoutImpact)
+		assert.Equal(t, []string{"I"}, resourceReport.RemovedConformances)
+		assert.Empty(t, resourceReport.AddedConformances)
+
+		require.Len(t, resourceReport.Issues, 1)
+		assert.Equal(t, "ConformanceMismatchError", resourceReport.Issues[0].Kind)
+		assert.IsType(t, &stdlib.ConformanceMismatchError{}, resourceReport.Issues[0].Err)
+
+		require.Len(t, resourceReport.Fields, 2)
+
+		fieldA := resourceReport.Fields[0]
+		assert.Equal(t, "a", fieldA.Name)
+		assert.Equal(t, stdlib.ContractUpdateChangeChanged, fieldA.Change)
+		assert.Equal(t, "Int", fieldA.OldType)
+		assert.Equal(t, "String", fieldA.NewType)
+		assert.Equal(t, "access(all)", fieldA.OldAccess)
+		assert.Equal(t, "access(E)", fieldA.NewAccess)
+		require.Len(t, fieldA.Issues, 1)
+		assert.Equal(t, "FieldMismatchError", fieldA.Issues[0].Kind)
+		assert.NotEmpty(t, fieldA.Issues[0].SecondaryMessage)
+		assert.NotNil(t, fieldA.Issues[0].Range)
+
+		fieldB := resourceReport.Fields[1]
+		assert.Equal(t, "b", fieldB.Name)
+		assert.Equal(t, stdlib.ContractUpdateChangeAdded, fieldB.Change)
+		require.Len(t, fieldB.Issues, 1)
+		assert.Equal(t, "ExtraneousFieldError", fieldB.Issues[0].Kind)
+
+		// Enum: case removed
+
+		enumReport := report.Declarations[2]
+		assert.Equal(t, stdlib.ContractUpdateChangeChanged, enumReport.Change)
+		assert.Equal(t, stdlib.StorageLayoutImpactIncompatible, enumReport.StorageLayoutImpact)
+		assert.Equal(t, []string{"green"}, enumReport.RemovedEnumCases)
+		require.Len(t, enumReport.Issues, 1)
+		assert.Equal(t, "MissingEnumCasesError", enumReport.Issues[0].Kind)
+
+		// Interface: unchanged
+
+		interfaceReport := report.Declarations[3]
+		assert.Equal(t, stdlib.ContractUpdateChangeUnchanged, interfaceReport.Change)
+		assert.Empty(t, interfaceReport.Issues)
+
+		// Entitlement: removed, but entitlements are not stored
+
+		entitlementReport := report.Declarations[4]
+		assert.Equal(t, stdlib.ContractUpdateChangeRemoved, entitlementReport.Change)
+		assert.Equal(t, stdlib.StorageLayoutImpactNone, entitlementReport.StorageLayoutImpact)
+		assert.Empty(t, entitlementReport.Issues)
+
+		// Struct: removed
+
+		structReport := report.Declarations[5]
+		assert.Equal(t, stdlib.ContractUpdateChangeRemoved, structReport.Change)
+		assert.Equal(t, stdlib.StorageLayoutImpactIncompatible, structReport.StorageLayoutImpact)
+		require.Len(t, structReport.Issues, 1)
+		assert.Equal(t, "MissingDeclarationError", structReport.Issues[0].Kind)
+	})
+
+	t.Run("declaration kind change", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            access(all) contract Test {}
+        `
+
+		const newCode = `
+            access(all) contract interface Test {}
+        `
+
+		report := testContractUpdateReport(t, oldCode, newCode)
+
+		require.False(t, report.Valid)
+		require.Len(t, report.Declarations, 1)
+
+		contractReport := report.Declarations[0]
+		assert.Equal(t, stdlib.ContractUpdateChangeChanged, contractReport.Change)
+		assert.Equal(t, stdlib.StorageLayoutImpactIncompatible, contractReport.StorageLayoutImpact)
+		require.Len(t, contractReport.Issues, 1)
+		assert.Equal(t, "InvalidDeclarationKindChangeError", contractReport.Issues[0].Kind)
+	})
+
+	t.Run("missing contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            access(all) contract Test {}
+        `
+
+		const newCode = `
+            access(all) struct S {}
+        `
+
+		report := testContractUpdateReport(t, oldCode, newCode)
+
+		require.False(t, report.Valid)
+		assert.Empty(t, report.Declarations)
+		require.Len(t, report.Issues, 1)
+		assert.Equal(t, "ContractNotFoundError", report.Issues[0].Kind)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            access(all) contract Test {
+                access(all) let a: Int
+
+                init() {
+                    self.a = 1
+                }
+            }
+        `
+
+		const newCode = `
+            access(all) contract Test {
+                access(all) let a: String
+
+                init() {
+                    self.a = ""
+                }
+            }
+        `
+
+		report := testContractUpdateReport(t, oldCode, newCode)
+
+		actual, err := json.Marshal(report)
+		require.NoError(t, err)
+
+		assert.JSONEq(t,
+			// language=json
+			`
+              {
+                "location": {"Type": "StringLocation", "String": "test"},
+                "contractName": "Test",
+                "valid": false,
+                "declarations": [
+                  {
+                    "name": "Test",
+                    "oldKind": "DeclarationKindContract",
+                    "newKind": "DeclarationKindContract",
+                    "change": "changed",
+                    "storageLayoutImpact": "incompatible",
+                    "fields": [
+                      {
+                        "name": "a",
+                        "change": "changed",
+                        "oldType": "Int",
+                        "newType": "String",
+                        "oldAccess": "access(all)",
+                        "newAccess": "access(all)",
+                        "issues": [
+                          {
+                            "kind": "FieldMismatchError",
+                            "message": "mismatching field `+"`a`"+` in `+"`Test`"+`",
+                            "secondaryMessage": "incompatible type annotations. expected `+"`Int`"+`, found `+"`String`"+`",
+                            "range": {
+                              "StartPos": {"Offset": 76, "Line": 3, "Column": 35},
+                              "EndPos": {"Offset": 81, "Line": 3, "Column": 40}
+                            }
+                          }
+                        ]
+                      }
+                    ]
+                  }
+                ]
+              }
+            `,
+			string(actual),
+		)
+	})
+}
@@ -34,6 +34,7 @@ type UpdateValidator interface {
 	ast.TypeEqualityChecker
 
 	Validate() error
+	Report() *ContractUpdateReport
 	report(error)
 	Location() common.Location
 
@@ -71,6 +72,9 @@ type ContractUpdateValidator struct {
 	importLocations              map[ast.Identifier]common.Location
 	accountContractNamesProvider AccountContractNamesProvider
 	errors                       []error
+	// errorDeclarations are the declarations that were being checked
+	// when the errors were reported, in the same order as errors
+	errorDeclarations []ast.Declaration
 }
 
 // ContractUpdateValidator should implement ast.TypeEqualityChecker
@@ -149,6 +153,15 @@ func (validator *ContractUpdateValidator) Validate() error {
 	return nil
 }
 
+// Report validates the contract update, like Validate,
+// and returns a report of the changes of the update,
+// including why they are invalid, if they are.
+func (validator *ContractUpdateValidator) Report() *ContractUpdateReport {
+	validator.resetErrors()
+	_ = validator.Validate()
+	return newContractUpdateReport(validator)
+}
+
 func collectImports(validator UpdateValidator, program *ast.Program) map[string]common.Location {
 	importLocations := map[string]common.Location{}
 
@@ -240,6 +253,11 @@ func (validator *ContractUpdateValidator) hasErrors() bool {
 	return len(validator.errors) > 0
 }
 
+func (validator *ContractUpdateValidator) resetErrors() {
+	validator.errors = nil
+	validator.errorDeclarations = nil
+}
+
 func collectRemovedTypePragmas(
 	validator UpdateValidator,
 	pragmas []*ast.PragmaDeclaration,
@@ -710,6 +728,7 @@ func (validator *ContractUpdateValidator) report(err error) {
 		return
 	}
 	validator.errors = append(validator.errors, err)
+	validator.errorDeclarations = append(validator.errorDeclarations, validator.currentDecl)
 }
 
 func (validator *ContractUpdateValidator) getContractUpdateError() error {
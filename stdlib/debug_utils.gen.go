@@ -0,0 +1,72 @@
+// Code generated from debug_utils.cdc. DO NOT EDIT.
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/sema"
+)
+
+const DebugUtilsTypeCallStackFunctionName = "callStack"
+
+var DebugUtilsTypeCallStackFunctionType = &sema.FunctionType{
+	Purity: sema.FunctionPurityView,
+	ReturnTypeAnnotation: sema.NewTypeAnnotation(
+		&sema.VariableSizedType{
+			Type: sema.StringType,
+		},
+	),
+}
+
+const DebugUtilsTypeCallStackFunctionDocString = `
+Returns the current call stack,
+from the outermost to the innermost invocation.
+Each element describes an invocation of a function,
+i.e. the name of the invoked function and the location of the invocation.
+`
+
+const DebugUtilsTypeName = "DebugUtils"
+
+var DebugUtilsType = func() *sema.CompositeType {
+	var t = &sema.CompositeType{
+		Identifier:         DebugUtilsTypeName,
+		Kind:               common.CompositeKindContract,
+		ImportableBuiltin:  false,
+		HasComputedMembers: true,
+	}
+
+	return t
+}()
+
+func init() {
+	var members = []*sema.Member{
+		sema.NewUnmeteredFunctionMember(
+			DebugUtilsType,
+			sema.PrimitiveAccess(ast.AccessAll),
+			DebugUtilsTypeCallStackFunctionName,
+			DebugUtilsTypeCallStackFunctionType,
+			DebugUtilsTypeCallStackFunctionDocString,
+		),
+	}
+
+	DebugUtilsType.Members = sema.MembersAsMap(members)
+	DebugUtilsType.Fields = sema.MembersFieldNames(members)
+}
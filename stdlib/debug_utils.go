@@ -0,0 +1,75 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+//go:generate go run ../sema/gen -p stdlib -values debug_utils_contract.gen.go -vp stdlib debug_utils.cdc debug_utils.gen.go
+
+import (
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+)
+
+func debugUtilsCallStack(invocation interpreter.Invocation) interpreter.Value {
+	inter := invocation.Interpreter
+
+	frames := inter.CaptureCallStack()
+
+	values := make([]interpreter.Value, 0, len(frames))
+	for _, frame := range frames {
+		description := frame.String()
+		values = append(
+			values,
+			interpreter.NewStringValue(
+				inter,
+				common.NewStringMemoryUsage(len(description)),
+				func() string {
+					return description
+				},
+			),
+		)
+	}
+
+	return interpreter.NewArrayValue(
+		inter,
+		invocation.LocationRange,
+		interpreter.NewVariableSizedStaticType(
+			inter,
+			interpreter.PrimitiveStaticTypeString,
+		),
+		common.ZeroAddress,
+		values...,
+	)
+}
+
+var debugUtilsContractValue = NewDebugUtilsContractValue(
+	nil,
+	DebugUtilsContractFunctions{
+		CallStack: debugUtilsCallStack,
+	},
+)
+
+// DebugUtilsContract provides debugging utilities, e.g. to inspect the call stack.
+// It is not part of the default standard library,
+// and should only be declared in test environments
+var DebugUtilsContract = StandardLibraryValue{
+	Name:  DebugUtilsTypeName,
+	Type:  DebugUtilsType,
+	Value: debugUtilsContractValue,
+	Kind:  common.DeclarationKindContract,
+}
@@ -0,0 +1,59 @@
+// Code generated from debug_utils.cdc. DO NOT EDIT.
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/interpreter"
+)
+
+// DebugUtilsContractFunctions are the implementations of the native functions of the built-in contract DebugUtils
+type DebugUtilsContractFunctions struct {
+	CallStack interpreter.HostFunction
+}
+
+var debugUtilsTypeID = DebugUtilsType.ID()
+var DebugUtilsTypeStaticType = interpreter.ConvertSemaToStaticType(nil, DebugUtilsType) // unmetered
+
+// NewDebugUtilsContractValue returns a new value of the built-in contract DebugUtils,
+// with the given implementations of its native functions
+func NewDebugUtilsContractValue(gauge common.MemoryGauge, functions DebugUtilsContractFunctions) *interpreter.SimpleCompositeValue {
+	if functions.CallStack == nil {
+		panic(errors.NewUnexpectedError("missing implementation of native function DebugUtils.callStack"))
+	}
+
+	return interpreter.NewSimpleCompositeValue(
+		gauge,
+		debugUtilsTypeID,
+		DebugUtilsTypeStaticType,
+		nil,
+		map[string]interpreter.Value{
+			DebugUtilsTypeCallStackFunctionName: interpreter.NewStaticHostFunctionValue(
+				gauge,
+				DebugUtilsTypeCallStackFunctionType,
+				functions.CallStack,
+			),
+		},
+		nil,
+		nil,
+		nil,
+	)
+}
@@ -0,0 +1,68 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	. "github.com/onflow/cadence/test_utils/interpreter_utils"
+)
+
+func TestDebugUtilsCallStack(t *testing.T) {
+
+	t.Parallel()
+
+	inter := newInterpreter(t,
+		`
+          access(all) fun foo(): [String] {
+              return DebugUtils.callStack()
+          }
+
+          access(all) fun bar(): [String] {
+              return foo()
+          }
+
+          access(all) fun test(): [String] {
+              return bar()
+          }
+        `,
+		DebugUtilsContract,
+	)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	AssertValuesEqual(
+		t,
+		inter,
+		interpreter.NewArrayValue(
+			inter,
+			interpreter.EmptyLocationRange,
+			interpreter.NewVariableSizedStaticType(nil, interpreter.PrimitiveStaticTypeString),
+			common.ZeroAddress,
+			interpreter.NewUnmeteredStringValue("bar (test:11:21)"),
+			interpreter.NewUnmeteredStringValue("foo (test:7:21)"),
+		),
+		result,
+	)
+}
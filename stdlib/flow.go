@@ -265,6 +265,55 @@ var AccountContractRemovedEventType = newFlowEventType(
 	AccountEventContractParameter,
 )
 
+var TypeIDsType = sema.NewVariableSizedType(nil, sema.StringType)
+
+var TypeIDsTypeAnnotation = sema.NewTypeAnnotation(TypeIDsType)
+
+var AccountEventDeclaredTypesParameter = sema.Parameter{
+	Identifier:     "declaredTypes",
+	TypeAnnotation: TypeIDsTypeAnnotation,
+}
+
+var AccountEventAddedEventTypesParameter = sema.Parameter{
+	Identifier:     "addedEventTypes",
+	TypeAnnotation: TypeIDsTypeAnnotation,
+}
+
+var AccountEventRemovedEventTypesParameter = sema.Parameter{
+	Identifier:     "removedEventTypes",
+	TypeAnnotation: TypeIDsTypeAnnotation,
+}
+
+var AccountContractAddedMetadataEventType = newFlowEventType(
+	"AccountContractAddedMetadata",
+	AccountEventAddressParameter,
+	AccountEventCodeHashParameter,
+	AccountEventContractParameter,
+	AccountEventDeclaredTypesParameter,
+	AccountEventAddedEventTypesParameter,
+	AccountEventRemovedEventTypesParameter,
+)
+
+var AccountContractUpdatedMetadataEventType = newFlowEventType(
+	"AccountContractUpdatedMetadata",
+	AccountEventAddressParameter,
+	AccountEventCodeHashParameter,
+	AccountEventContractParameter,
+	AccountEventDeclaredTypesParameter,
+	AccountEventAddedEventTypesParameter,
+	AccountEventRemovedEventTypesParameter,
+)
+
+var AccountContractRemovedMetadataEventType = newFlowEventType(
+	"AccountContractRemovedMetadata",
+	AccountEventAddressParameter,
+	AccountEventCodeHashParameter,
+	AccountEventContractParameter,
+	AccountEventDeclaredTypesParameter,
+	AccountEventAddedEventTypesParameter,
+	AccountEventRemovedEventTypesParameter,
+)
+
 var AccountEventProviderParameter = sema.Parameter{
 	Identifier:     "provider",
 	TypeAnnotation: sema.AddressTypeAnnotation,
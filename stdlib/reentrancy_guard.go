@@ -0,0 +1,150 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/sema"
+)
+
+const withReentrancyGuardFunctionDocString = `
+Calls the given function while holding a lock, and terminates the program if the lock is already held,
+i.e. if the function is reentered while it is still being called.
+
+The lock is scoped to the program (e.g. the contract) which calls this function.
+If a storage path is given, the lock is additionally scoped to the path.
+
+The lock is released when the function returns.
+`
+
+const withReentrancyGuardFunctionName = "withReentrancyGuard"
+
+var withReentrancyGuardFunctionType = &sema.FunctionType{
+	Parameters: []sema.Parameter{
+		{
+			Label:      sema.ArgumentLabelNotRequired,
+			Identifier: "function",
+			TypeAnnotation: sema.NewTypeAnnotation(
+				&sema.FunctionType{
+					ReturnTypeAnnotation: sema.VoidTypeAnnotation,
+				},
+			),
+		},
+		{
+			Identifier:     "path",
+			TypeAnnotation: sema.StoragePathTypeAnnotation,
+		},
+	},
+	ReturnTypeAnnotation: sema.VoidTypeAnnotation,
+	// `path` parameter is optional
+	Arity: &sema.Arity{Min: 1, Max: 2},
+}
+
+// reentrancyGuardKey identifies a lock of the reentrancy guard
+type reentrancyGuardKey struct {
+	location common.Location
+	path     interpreter.PathValue
+}
+
+// NewWithReentrancyGuardFunction returns the reentrancy guard function.
+// It is not part of the default standard library, as existing programs may declare a function with the same name.
+// The held locks are tracked by the returned function,
+// so it should not be shared by concurrent executions.
+func NewWithReentrancyGuardFunction() StandardLibraryValue {
+
+	locks := map[reentrancyGuardKey]struct{}{}
+
+	return NewStandardLibraryStaticFunction(
+		withReentrancyGuardFunctionName,
+		withReentrancyGuardFunctionType,
+		withReentrancyGuardFunctionDocString,
+		func(invocation interpreter.Invocation) interpreter.Value {
+			inter := invocation.Interpreter
+			locationRange := invocation.LocationRange
+
+			functionValue, ok := invocation.Arguments[0].(interpreter.FunctionValue)
+			if !ok {
+				panic(errors.NewUnreachableError())
+			}
+
+			key := reentrancyGuardKey{
+				location: locationRange.Location,
+			}
+
+			if len(invocation.Arguments) > 1 {
+				pathValue, ok := invocation.Arguments[1].(interpreter.PathValue)
+				if !ok {
+					panic(errors.NewUnreachableError())
+				}
+				key.path = pathValue
+			}
+
+			if _, ok := locks[key]; ok {
+				panic(ReentrancyError{
+					Path:          key.path,
+					LocationRange: locationRange,
+				})
+			}
+
+			locks[key] = struct{}{}
+
+			// Release the lock even if the function fails,
+			// so the lock does not outlive the call
+			defer delete(locks, key)
+
+			_, err := inter.InvokeFunctionValue(
+				functionValue,
+				nil,
+				nil,
+				nil,
+				sema.VoidType,
+				locationRange,
+			)
+			if err != nil {
+				// interpreter panicked while invoking the inner function value
+				panic(err)
+			}
+
+			return interpreter.Void
+		},
+	)
+}
+
+// ReentrancyError is reported when a function guarded by a reentrancy guard is reentered
+
+type ReentrancyError struct {
+	interpreter.LocationRange
+	Path interpreter.PathValue
+}
+
+var _ errors.UserError = ReentrancyError{}
+
+func (ReentrancyError) IsUserError() {}
+
+func (e ReentrancyError) Error() string {
+	const message = "reentrancy guard: lock is already held"
+	if e.Path == (interpreter.PathValue{}) {
+		return message
+	}
+	return fmt.Sprintf("%s for path `%s`", message, e.Path)
+}
@@ -0,0 +1,153 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/interpreter"
+	. "github.com/onflow/cadence/test_utils/common_utils"
+)
+
+func TestInterpretWithReentrancyGuard(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("not reentered", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newInterpreter(t,
+			`
+              access(all) fun test(): Int {
+                  var count = 0
+                  withReentrancyGuard(fun () {
+                      count = count + 1
+                  })
+                  withReentrancyGuard(fun () {
+                      count = count + 1
+                  })
+                  return count
+              }
+            `,
+			NewWithReentrancyGuardFunction(),
+		)
+
+		result, err := inter.Invoke("test")
+		require.NoError(t, err)
+
+		assert.Equal(t, interpreter.NewUnmeteredIntValueFromInt64(2), result)
+	})
+
+	t.Run("reentered", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newInterpreter(t,
+			`
+              access(all) fun test() {
+                  withReentrancyGuard(fun () {
+                      withReentrancyGuard(fun () {})
+                  })
+              }
+            `,
+			NewWithReentrancyGuardFunction(),
+		)
+
+		_, err := inter.Invoke("test")
+		RequireError(t, err)
+
+		var reentrancyErr ReentrancyError
+		require.ErrorAs(t, err, &reentrancyErr)
+		assert.Equal(t, interpreter.PathValue{}, reentrancyErr.Path)
+	})
+
+	t.Run("reentered, same path", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newInterpreter(t,
+			`
+              access(all) fun test() {
+                  withReentrancyGuard(fun () {
+                      withReentrancyGuard(fun () {}, path: /storage/foo)
+                  }, path: /storage/foo)
+              }
+            `,
+			NewWithReentrancyGuardFunction(),
+		)
+
+		_, err := inter.Invoke("test")
+		RequireError(t, err)
+
+		var reentrancyErr ReentrancyError
+		require.ErrorAs(t, err, &reentrancyErr)
+		assert.Equal(t, "/storage/foo", reentrancyErr.Path.String())
+	})
+
+	t.Run("different paths", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newInterpreter(t,
+			`
+              access(all) fun test() {
+                  withReentrancyGuard(fun () {
+                      withReentrancyGuard(fun () {
+                          withReentrancyGuard(fun () {})
+                      }, path: /storage/bar)
+                  }, path: /storage/foo)
+              }
+            `,
+			NewWithReentrancyGuardFunction(),
+		)
+
+		_, err := inter.Invoke("test")
+		require.NoError(t, err)
+	})
+
+	t.Run("released after failure", func(t *testing.T) {
+
+		t.Parallel()
+
+		inter := newInterpreter(t,
+			`
+              access(all) fun test(fail: Bool) {
+                  withReentrancyGuard(fun () {
+                      assert(!fail)
+                  })
+              }
+            `,
+			NewWithReentrancyGuardFunction(),
+			AssertFunction,
+		)
+
+		_, err := inter.Invoke("test", interpreter.TrueValue)
+		RequireError(t, err)
+
+		var assertionErr AssertionError
+		require.ErrorAs(t, err, &assertionErr)
+
+		_, err = inter.Invoke("test", interpreter.FalseValue)
+		require.NoError(t, err)
+	})
+}
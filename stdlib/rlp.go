@@ -18,7 +18,7 @@
 
 package stdlib
 
-//go:generate go run ../sema/gen -p stdlib rlp.cdc rlp.gen.go
+//go:generate go run ../sema/gen -p stdlib -values rlp_contract.gen.go -vp stdlib rlp.cdc rlp.gen.go
 
 import (
 	"fmt"
@@ -44,42 +44,39 @@ func (e RLPDecodeStringError) Error() string {
 
 const rlpErrMsgInputContainsExtraBytes = "input data is expected to be RLP-encoded of a single string or a single list but it seems it contains extra trailing bytes."
 
-// rlpDecodeStringFunction is a static function
-var rlpDecodeStringFunction = interpreter.NewUnmeteredStaticHostFunctionValue(
-	RLPTypeDecodeStringFunctionType,
-	func(invocation interpreter.Invocation) interpreter.Value {
-		input, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
-		if !ok {
-			panic(errors.NewUnreachableError())
-		}
-
-		invocation.Interpreter.ReportComputation(common.ComputationKindSTDLIBRLPDecodeString, uint(input.Count()))
-
-		locationRange := invocation.LocationRange
-
-		convertedInput, err := interpreter.ByteArrayValueToByteSlice(invocation.Interpreter, input, locationRange)
-		if err != nil {
-			panic(RLPDecodeStringError{
-				Msg:           err.Error(),
-				LocationRange: locationRange,
-			})
-		}
-		output, bytesRead, err := rlp.DecodeString(convertedInput, 0)
-		if err != nil {
-			panic(RLPDecodeStringError{
-				Msg:           err.Error(),
-				LocationRange: locationRange,
-			})
-		}
-		if bytesRead != len(convertedInput) {
-			panic(RLPDecodeStringError{
-				Msg:           rlpErrMsgInputContainsExtraBytes,
-				LocationRange: locationRange,
-			})
-		}
-		return interpreter.ByteSliceToByteArrayValue(invocation.Interpreter, output)
-	},
-)
+// rlpDecodeString is the implementation of the native function RLP.decodeString
+func rlpDecodeString(invocation interpreter.Invocation) interpreter.Value {
+	input, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	invocation.Interpreter.ReportComputation(common.ComputationKindSTDLIBRLPDecodeString, uint(input.Count()))
+
+	locationRange := invocation.LocationRange
+
+	convertedInput, err := interpreter.ByteArrayValueToByteSlice(invocation.Interpreter, input, locationRange)
+	if err != nil {
+		panic(RLPDecodeStringError{
+			Msg:           err.Error(),
+			LocationRange: locationRange,
+		})
+	}
+	output, bytesRead, err := rlp.DecodeString(convertedInput, 0)
+	if err != nil {
+		panic(RLPDecodeStringError{
+			Msg:           err.Error(),
+			LocationRange: locationRange,
+		})
+	}
+	if bytesRead != len(convertedInput) {
+		panic(RLPDecodeStringError{
+			Msg:           rlpErrMsgInputContainsExtraBytes,
+			LocationRange: locationRange,
+		})
+	}
+	return interpreter.ByteSliceToByteArrayValue(invocation.Interpreter, output)
+}
 
 type RLPDecodeListError struct {
 	interpreter.LocationRange
@@ -94,77 +91,64 @@ func (e RLPDecodeListError) Error() string {
 	return fmt.Sprintf("failed to RLP-decode list: %s", e.Msg)
 }
 
-// rlpDecodeListFunction is a static function
-var rlpDecodeListFunction = interpreter.NewUnmeteredStaticHostFunctionValue(
-	RLPTypeDecodeListFunctionType,
-	func(invocation interpreter.Invocation) interpreter.Value {
-		input, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
-		if !ok {
-			panic(errors.NewUnreachableError())
-		}
-
-		invocation.Interpreter.ReportComputation(common.ComputationKindSTDLIBRLPDecodeList, uint(input.Count()))
-
-		locationRange := invocation.LocationRange
-
-		convertedInput, err := interpreter.ByteArrayValueToByteSlice(invocation.Interpreter, input, locationRange)
-		if err != nil {
-			panic(RLPDecodeListError{
-				Msg:           err.Error(),
-				LocationRange: locationRange,
-			})
-		}
-
-		output, bytesRead, err := rlp.DecodeList(convertedInput, 0)
-
-		if err != nil {
-			panic(RLPDecodeListError{
-				Msg:           err.Error(),
-				LocationRange: locationRange,
-			})
-		}
-
-		if bytesRead != len(convertedInput) {
-			panic(RLPDecodeListError{
-				Msg:           rlpErrMsgInputContainsExtraBytes,
-				LocationRange: locationRange,
-			})
-		}
-
-		values := make([]interpreter.Value, len(output))
-		for i, b := range output {
-			values[i] = interpreter.ByteSliceToByteArrayValue(invocation.Interpreter, b)
-		}
-
-		return interpreter.NewArrayValue(
+// rlpDecodeList is the implementation of the native function RLP.decodeList
+func rlpDecodeList(invocation interpreter.Invocation) interpreter.Value {
+	input, ok := invocation.Arguments[0].(*interpreter.ArrayValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	invocation.Interpreter.ReportComputation(common.ComputationKindSTDLIBRLPDecodeList, uint(input.Count()))
+
+	locationRange := invocation.LocationRange
+
+	convertedInput, err := interpreter.ByteArrayValueToByteSlice(invocation.Interpreter, input, locationRange)
+	if err != nil {
+		panic(RLPDecodeListError{
+			Msg:           err.Error(),
+			LocationRange: locationRange,
+		})
+	}
+
+	output, bytesRead, err := rlp.DecodeList(convertedInput, 0)
+
+	if err != nil {
+		panic(RLPDecodeListError{
+			Msg:           err.Error(),
+			LocationRange: locationRange,
+		})
+	}
+
+	if bytesRead != len(convertedInput) {
+		panic(RLPDecodeListError{
+			Msg:           rlpErrMsgInputContainsExtraBytes,
+			LocationRange: locationRange,
+		})
+	}
+
+	values := make([]interpreter.Value, len(output))
+	for i, b := range output {
+		values[i] = interpreter.ByteSliceToByteArrayValue(invocation.Interpreter, b)
+	}
+
+	return interpreter.NewArrayValue(
+		invocation.Interpreter,
+		locationRange,
+		interpreter.NewVariableSizedStaticType(
 			invocation.Interpreter,
-			locationRange,
-			interpreter.NewVariableSizedStaticType(
-				invocation.Interpreter,
-				interpreter.ByteArrayStaticType,
-			),
-			common.ZeroAddress,
-			values...,
-		)
-	},
-)
-
-var rlpContractFields = map[string]interpreter.Value{
-	RLPTypeDecodeListFunctionName:   rlpDecodeListFunction,
-	RLPTypeDecodeStringFunctionName: rlpDecodeStringFunction,
+			interpreter.ByteArrayStaticType,
+		),
+		common.ZeroAddress,
+		values...,
+	)
 }
 
-var RLPTypeStaticType = interpreter.ConvertSemaToStaticType(nil, RLPType)
-
-var rlpContractValue = interpreter.NewSimpleCompositeValue(
-	nil,
-	RLPType.ID(),
-	RLPTypeStaticType,
-	nil,
-	rlpContractFields,
-	nil,
-	nil,
+var rlpContractValue = NewRLPContractValue(
 	nil,
+	RLPContractFunctions{
+		DecodeString: rlpDecodeString,
+		DecodeList:   rlpDecodeList,
+	},
 )
 
 var RLPContract = StandardLibraryValue{
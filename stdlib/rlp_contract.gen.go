@@ -0,0 +1,69 @@
+// Code generated from rlp.cdc. DO NOT EDIT.
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdlib
+
+import (
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/interpreter"
+)
+
+// RLPContractFunctions are the implementations of the native functions of the built-in contract RLP
+type RLPContractFunctions struct {
+	DecodeString interpreter.HostFunction
+	DecodeList   interpreter.HostFunction
+}
+
+var rlpTypeID = RLPType.ID()
+var RLPTypeStaticType = interpreter.ConvertSemaToStaticType(nil, RLPType) // unmetered
+
+// NewRLPContractValue returns a new value of the built-in contract RLP,
+// with the given implementations of its native functions
+func NewRLPContractValue(gauge common.MemoryGauge, functions RLPContractFunctions) *interpreter.SimpleCompositeValue {
+	if functions.DecodeString == nil {
+		panic(errors.NewUnexpectedError("missing implementation of native function RLP.decodeString"))
+	}
+
+	if functions.DecodeList == nil {
+		panic(errors.NewUnexpectedError("missing implementation of native function RLP.decodeList"))
+	}
+
+	return interpreter.NewSimpleCompositeValue(
+		gauge,
+		rlpTypeID,
+		RLPTypeStaticType,
+		nil,
+		map[string]interpreter.Value{
+			RLPTypeDecodeStringFunctionName: interpreter.NewStaticHostFunctionValue(
+				gauge,
+				RLPTypeDecodeStringFunctionType,
+				functions.DecodeString,
+			),
+			RLPTypeDecodeListFunctionName: interpreter.NewStaticHostFunctionValue(
+				gauge,
+				RLPTypeDecodeListFunctionType,
+				functions.DecodeList,
+			),
+		},
+		nil,
+		nil,
+		nil,
+	)
+}
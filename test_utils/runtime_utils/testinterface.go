@@ -57,16 +57,18 @@ type TestRuntimeInterface struct {
 		hashAlgo runtime.HashAlgorithm,
 		weight int,
 	) (*stdlib.AccountKey, error)
-	OnGetAccountKey             func(address runtime.Address, index uint32) (*stdlib.AccountKey, error)
-	OnRemoveAccountKey          func(address runtime.Address, index uint32) (*stdlib.AccountKey, error)
-	OnAccountKeysCount          func(address runtime.Address) (uint32, error)
-	OnUpdateAccountContractCode func(location common.AddressLocation, code []byte) error
-	OnGetAccountContractCode    func(location common.AddressLocation) (code []byte, err error)
-	OnRemoveAccountContractCode func(location common.AddressLocation) (err error)
-	OnGetSigningAccounts        func() ([]runtime.Address, error)
-	OnProgramLog                func(string)
-	OnEmitEvent                 func(cadence.Event) error
-	OnResourceOwnerChanged      func(
+	OnGetAccountKey                  func(address runtime.Address, index uint32) (*stdlib.AccountKey, error)
+	OnRemoveAccountKey               func(address runtime.Address, index uint32) (*stdlib.AccountKey, error)
+	OnAccountKeysCount               func(address runtime.Address) (uint32, error)
+	OnUpdateAccountContractCode      func(location common.AddressLocation, code []byte) error
+	OnGetAccountContractCode         func(location common.AddressLocation) (code []byte, err error)
+	OnGetAccountContractUpdateHeight func(location common.AddressLocation) (height uint64, known bool, err error)
+	OnRemoveAccountContractCode      func(location common.AddressLocation) (err error)
+	OnGetSigningAccounts             func() ([]runtime.Address, error)
+	OnProgramLog                     func(string)
+	OnEmitEvent                      func(cadence.Event) error
+	OnEventSize                      func(cadence.Event) (uint64, error)
+	OnResourceOwnerChanged           func(
 		interpreter *interpreter.Interpreter,
 		resource *interpreter.CompositeValue,
 		oldAddress common.Address,
@@ -314,6 +316,19 @@ func (i *TestRuntimeInterface) GetAccountContractCode(location common.AddressLoc
 	return i.OnGetAccountContractCode(location)
 }
 
+func (i *TestRuntimeInterface) GetAccountContractUpdateHeight(
+	location common.AddressLocation,
+) (
+	height uint64,
+	known bool,
+	err error,
+) {
+	if i.OnGetAccountContractUpdateHeight == nil {
+		panic("must specify TestRuntimeInterface.OnGetAccountContractUpdateHeight")
+	}
+	return i.OnGetAccountContractUpdateHeight(location)
+}
+
 func (i *TestRuntimeInterface) RemoveAccountContractCode(location common.AddressLocation) (err error) {
 	if i.OnRemoveAccountContractCode == nil {
 		panic("must specify TestRuntimeInterface.OnRemoveAccountContractCode")
@@ -343,6 +358,13 @@ func (i *TestRuntimeInterface) EmitEvent(event cadence.Event) error {
 	return i.OnEmitEvent(event)
 }
 
+func (i *TestRuntimeInterface) EventSize(event cadence.Event) (uint64, error) {
+	if i.OnEventSize == nil {
+		panic("must specify TestRuntimeInterface.OnEventSize")
+	}
+	return i.OnEventSize(event)
+}
+
 func (i *TestRuntimeInterface) ResourceOwnerChanged(
 	interpreter *interpreter.Interpreter,
 	resource *interpreter.CompositeValue,
@@ -30,12 +30,13 @@ import (
 )
 
 type TestLedger struct {
-	StoredValues        map[string][]byte
-	StorageIndices      map[string]uint64
-	OnValueExists       func(owner, key []byte) (exists bool, err error)
-	OnGetValue          func(owner, key []byte) (value []byte, err error)
-	OnSetValue          func(owner, key, value []byte) (err error)
-	OnAllocateSlabIndex func(owner []byte) (atree.SlabIndex, error)
+	StoredValues         map[string][]byte
+	StorageIndices       map[string]uint64
+	OnValueExists        func(owner, key []byte) (exists bool, err error)
+	OnGetValue           func(owner, key []byte) (value []byte, err error)
+	OnSetValue           func(owner, key, value []byte) (err error)
+	OnAllocateSlabIndex  func(owner []byte) (atree.SlabIndex, error)
+	OnReserveSlabIndices func(owner []byte, slabIndex atree.SlabIndex) error
 }
 
 var _ atree.Ledger = TestLedger{}
@@ -56,6 +57,10 @@ func (s TestLedger) AllocateSlabIndex(owner []byte) (atree.SlabIndex, error) {
 	return s.OnAllocateSlabIndex(owner)
 }
 
+func (s TestLedger) ReserveSlabIndices(owner []byte, slabIndex atree.SlabIndex) error {
+	return s.OnReserveSlabIndices(owner, slabIndex)
+}
+
 const testLedgerKeySeparator = "|"
 
 func (s TestLedger) ForEach(f func(owner, key, value []byte) error) error {
@@ -150,6 +155,13 @@ func NewTestLedger(
 			binary.BigEndian.PutUint64(result[:], index)
 			return
 		},
+		OnReserveSlabIndices: func(owner []byte, slabIndex atree.SlabIndex) error {
+			index := binary.BigEndian.Uint64(slabIndex[:])
+			if index > storageIndices[string(owner)] {
+				storageIndices[string(owner)] = index
+			}
+			return nil
+		},
 	}
 }
 
@@ -191,5 +203,12 @@ func NewTestLedgerWithData(
 			binary.BigEndian.PutUint64(result[:], index)
 			return
 		},
+		OnReserveSlabIndices: func(owner []byte, slabIndex atree.SlabIndex) error {
+			index := binary.BigEndian.Uint64(slabIndex[:])
+			if index > storageIndices[string(owner)] {
+				storageIndices[string(owner)] = index
+			}
+			return nil
+		},
 	}
 }
@@ -52,6 +52,19 @@ var checkConcurrently = flag.Int(
 	"check programs N times, concurrently. useful for detecting non-determinism, and data races with the -race flag",
 )
 
+var checkDeclarationsConcurrently = flag.Int(
+	"cadence.checkDeclarationsConcurrently",
+	0,
+	"check the top-level declarations of programs with N workers, see sema.Config.CheckConcurrency. "+
+		"useful for detecting non-determinism, and data races with the -race flag",
+)
+
+var warningsAsErrors = flag.Bool(
+	"cadence.warningsAsErrors",
+	false,
+	"report checker warnings as errors, see sema.Config.WarningsAsErrors",
+)
+
 func ParseAndCheckWithOptions(
 	t testing.TB,
 	code string,
@@ -96,6 +109,14 @@ func ParseAndCheckWithOptionsAndMemoryMetering(
 		}
 		config.ExtendedElaborationEnabled = true
 
+		if *checkDeclarationsConcurrently > 1 {
+			config.CheckConcurrency = *checkDeclarationsConcurrently
+		}
+
+		if *warningsAsErrors {
+			config.WarningsAsErrors = true
+		}
+
 		checker, err := sema.NewChecker(
 			program,
 			options.Location,
@@ -27,6 +27,15 @@ func Load(config *Config, locations ...common.Location) (*Programs, error) {
 		Programs:                  make(map[common.Location]*Program, len(locations)),
 		CryptoContractElaboration: config.CryptoContractElaboration,
 	}
+
+	if config.ParseConcurrency > 1 {
+		programs.Parse(config, locations...)
+	}
+
+	if config.CheckConcurrency > 1 {
+		programs.LoadConcurrently(config, locations...)
+	}
+
 	for _, location := range locations {
 		err := programs.Load(config, location)
 		if err != nil {
@@ -21,8 +21,11 @@ package analysis_test
 import (
 	"fmt"
 	"sort"
+	"sync"
+	"sync/atomic"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence/ast"
@@ -60,7 +63,7 @@ func TestNeedSyntaxAndImport(t *testing.T) {
 	config := &analysis.Config{
 		Mode: analysis.NeedTypes,
 		ResolveAddressContractNames: func(address common.Address) ([]string, error) {
-			require.Equal(t, contractAddress, address)
+			assert.Equal(t, contractAddress, address)
 			return []string{contractLocation.Name}, nil
 		},
 		ResolveCode: func(
@@ -567,5 +570,434 @@ func TestCyclicImports(t *testing.T) {
 	require.ErrorAs(t, importedProgramErr.Err, &nestedCheckerErr)
 
 	errs = RequireCheckerErrors(t, nestedCheckerErr, 1)
-	require.IsType(t, &sema.CyclicImportsError{}, errs[0])
+
+	var cyclicImportsErr *sema.CyclicImportsError
+	require.ErrorAs(t, errs[0], &cyclicImportsErr)
+
+	assert.Equal(t,
+		[]common.Location{
+			fooContractLocation,
+			barContractLocation,
+			fooContractLocation,
+		},
+		cyclicImportsErr.Cycle,
+	)
+}
+
+func TestCyclicImportsAcrossLocations(t *testing.T) {
+
+	t.Parallel()
+
+	aLocation := common.StringLocation("A")
+	const aCode = `
+        import B from 0x1
+        access(all) fun a() {}
+	`
+
+	contractAddress := common.MustBytesToAddress([]byte{0x1})
+	bLocation := common.AddressLocation{
+		Address: contractAddress,
+		Name:    "B",
+	}
+	const bCode = `
+        import "C"
+        access(all) contract B {}
+	`
+
+	cLocation := common.StringLocation("C")
+	const cCode = `
+        import "A"
+        access(all) fun c() {}
+	`
+
+	codes := map[common.Location][]byte{
+		aLocation: []byte(aCode),
+		bLocation: []byte(bCode),
+		cLocation: []byte(cCode),
+	}
+
+	var importedProgramErrors []error
+
+	config := &analysis.Config{
+		Mode: analysis.NeedTypes,
+		ResolveCode: func(
+			location common.Location,
+			importingLocation common.Location,
+			importRange ast.Range,
+		) ([]byte, error) {
+			code, ok := codes[location]
+			if !ok {
+				require.FailNowf(t,
+					"import of unknown location",
+					"location: %s",
+					location,
+				)
+			}
+			return code, nil
+		},
+		HandleCheckerError: func(err analysis.ParsingCheckingError, _ *sema.Checker) error {
+			importedProgramErrors = append(importedProgramErrors, err)
+			return nil
+		},
+	}
+
+	programs, err := analysis.Load(config, aLocation)
+	require.NoError(t, err)
+
+	// The innermost program reports the full cycle
+
+	require.NotEmpty(t, importedProgramErrors)
+
+	var checkerError *sema.CheckerError
+	require.ErrorAs(t, importedProgramErrors[0], &checkerError)
+
+	errs := RequireCheckerErrors(t, checkerError, 1)
+
+	var cyclicImportsErr *sema.CyclicImportsError
+	require.ErrorAs(t, errs[0], &cyclicImportsErr)
+
+	assert.Equal(t,
+		[]common.Location{
+			aLocation,
+			bLocation,
+			cLocation,
+			aLocation,
+		},
+		cyclicImportsErr.Cycle,
+	)
+	assert.Equal(t,
+		"cyclic import of `A`: A -> 0000000000000001.B -> C -> A",
+		cyclicImportsErr.Error(),
+	)
+
+	assert.Equal(t,
+		map[common.Location][]common.Location{
+			aLocation: {bLocation},
+			bLocation: {cLocation},
+			cLocation: {aLocation},
+		},
+		programs.ImportGraph(),
+	)
+}
+
+func TestParseConcurrency(t *testing.T) {
+
+	t.Parallel()
+
+	contractAddress := common.MustBytesToAddress([]byte{0x1})
+
+	const count = 100
+
+	codes := map[common.Location][]byte{}
+	var contractNames []string
+	var locations []common.Location
+
+	for i := 0; i < count; i++ {
+		name := fmt.Sprintf("Contract%d", i)
+
+		location := common.AddressLocation{
+			Address: contractAddress,
+			Name:    name,
+		}
+
+		var code string
+		switch i % 3 {
+		case 0:
+			code = fmt.Sprintf(`access(all) contract %s {}`, name)
+		case 1:
+			// Import the previous contract
+			code = fmt.Sprintf(
+				`
+                  import Contract%d from 0x1
+
+                  access(all) contract %s {}
+                `,
+				i-1,
+				name,
+			)
+		case 2:
+			// Parser error
+			code = fmt.Sprintf(`access(all) contract %s { ??? }`, name)
+		}
+
+		codes[location] = []byte(code)
+		contractNames = append(contractNames, name)
+		locations = append(locations, location)
+	}
+
+	config := analysis.NewSimpleConfig(
+		analysis.NeedTypes,
+		codes,
+		map[common.Address][]string{
+			contractAddress: contractNames,
+		},
+		nil,
+	)
+	config.ParseConcurrency = 8
+
+	var parserErrorCount int
+	config.HandleParserError = func(err analysis.ParsingCheckingError, _ *ast.Program) error {
+		parserErrorCount++
+		return nil
+	}
+
+	programs, err := analysis.Load(config, locations...)
+	require.NoError(t, err)
+
+	require.Equal(t, count/3, parserErrorCount)
+
+	for i, location := range locations {
+		program := programs.Get(location)
+		require.NotNil(t, program)
+		require.Equal(t, codes[location], program.Code)
+
+		if i%3 == 2 {
+			var parserError parser.Error
+			require.ErrorAs(t, program.LoadError, &parserError)
+		} else {
+			require.NoError(t, program.LoadError)
+			require.NotNil(t, program.Checker)
+		}
+	}
+}
+
+func TestCheckConcurrency(t *testing.T) {
+
+	t.Parallel()
+
+	contractAddress := common.MustBytesToAddress([]byte{0x1})
+
+	const count = 100
+
+	codes := map[common.Location][]byte{}
+	var contractNames []string
+	var locations []common.Location
+
+	for i := 0; i < count; i++ {
+		name := fmt.Sprintf("Contract%d", i)
+
+		location := common.AddressLocation{
+			Address: contractAddress,
+			Name:    name,
+		}
+
+		var code string
+		switch {
+		case i < 2:
+			code = fmt.Sprintf(`access(all) contract %s {}`, name)
+		case i%4 == 3:
+			// Type error, not imported by any other contract
+			code = fmt.Sprintf(
+				`
+                  import Contract%d from 0x1
+
+                  access(all) contract %s {
+                      access(all) let x: Int
+                      init() { self.x = "" }
+                  }
+                `,
+				i-1,
+				name,
+			)
+		default:
+			// Import the two previous valid contracts (diamond)
+			var previous []int
+			for j := i - 1; len(previous) < 2; j-- {
+				if j%4 != 3 {
+					previous = append(previous, j)
+				}
+			}
+			code = fmt.Sprintf(
+				`
+                  import Contract%d from 0x1
+                  import Contract%d from 0x1
+
+                  access(all) contract %s {}
+                `,
+				previous[0],
+				previous[1],
+				name,
+			)
+		}
+
+		codes[location] = []byte(code)
+		contractNames = append(contractNames, name)
+		locations = append(locations, location)
+	}
+
+	config := analysis.NewSimpleConfig(
+		analysis.NeedTypes,
+		codes,
+		map[common.Address][]string{
+			contractAddress: contractNames,
+		},
+		nil,
+	)
+	config.ParseConcurrency = 8
+	config.CheckConcurrency = 8
+
+	var checkerErrorsMutex sync.Mutex
+	checkerErrorLocations := map[common.Location]struct{}{}
+	config.HandleCheckerError = func(err analysis.ParsingCheckingError, _ *sema.Checker) error {
+		checkerErrorsMutex.Lock()
+		defer checkerErrorsMutex.Unlock()
+
+		checkerErrorLocations[err.ImportLocation()] = struct{}{}
+		return nil
+	}
+
+	programs, err := analysis.Load(config, locations...)
+	require.NoError(t, err)
+
+	for i, location := range locations {
+		program := programs.Get(location)
+		require.NotNil(t, program)
+		require.Equal(t, codes[location], program.Code)
+		require.NotNil(t, program.Checker)
+
+		if i >= 2 && i%4 == 3 {
+			var checkerError *sema.CheckerError
+			require.ErrorAs(t, program.LoadError, &checkerError)
+			require.Contains(t, checkerErrorLocations, location)
+		} else {
+			require.NoError(t, program.LoadError)
+		}
+	}
+}
+
+func TestSimpleConfigConcurrentAddressContractsResolution(t *testing.T) {
+
+	t.Parallel()
+
+	contractAddress := common.MustBytesToAddress([]byte{0x1})
+	otherLocation := common.StringLocation("other")
+
+	const name = "Foo"
+	code := []byte(`access(all) contract Foo {}`)
+
+	var resolveCount int32
+	resolveStarted := make(chan struct{})
+	resolveReleased := make(chan struct{})
+
+	config := analysis.NewSimpleConfig(
+		analysis.NeedTypes,
+		map[common.Location][]byte{
+			otherLocation: []byte(`access(all) contract Other {}`),
+		},
+		map[common.Address][]string{},
+		func(address common.Address) (map[string][]byte, error) {
+			assert.Equal(t, contractAddress, address)
+
+			if atomic.AddInt32(&resolveCount, 1) == 1 {
+				close(resolveStarted)
+			}
+			<-resolveReleased
+
+			return map[string][]byte{
+				name: code,
+			}, nil
+		},
+	)
+
+	location := common.AddressLocation{
+		Address: contractAddress,
+		Name:    name,
+	}
+
+	const count = 8
+
+	var wg sync.WaitGroup
+	wg.Add(count)
+
+	results := make([][]byte, count)
+	errs := make([]error, count)
+
+	for i := 0; i < count; i++ {
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = config.ResolveCode(location, nil, ast.EmptyRange)
+		}(i)
+	}
+
+	<-resolveStarted
+
+	// Other locations can be resolved while the contracts of the address are resolved
+
+	otherCode, err := config.ResolveCode(otherLocation, nil, ast.EmptyRange)
+	require.NoError(t, err)
+	require.NotEmpty(t, otherCode)
+
+	close(resolveReleased)
+	wg.Wait()
+
+	for i := 0; i < count; i++ {
+		require.NoError(t, errs[i])
+		require.Equal(t, code, results[i])
+	}
+
+	names, err := config.ResolveAddressContractNames(contractAddress)
+	require.NoError(t, err)
+	require.Equal(t, []string{name}, names)
+
+	// The contracts of the address were resolved once
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&resolveCount))
+}
+
+func TestProfile(t *testing.T) {
+
+	t.Parallel()
+
+	location := common.StringLocation("test")
+	const code = `
+      access(all) resource R {}
+
+      access(all) fun burn(_ r: @R) {
+          destroy r
+      }
+    `
+
+	load := func(t *testing.T, profile *analysis.Profile) *analysis.Program {
+		config := analysis.NewSimpleConfig(
+			analysis.NeedTypes,
+			map[common.Location][]byte{
+				location: []byte(code),
+			},
+			nil,
+			nil,
+		)
+		config.Profile = profile
+
+		programs, err := analysis.Load(config, location)
+		require.NoError(t, err)
+
+		program := programs.Get(location)
+		require.NotNil(t, program)
+		require.NotNil(t, program.Checker)
+
+		return program
+	}
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		program := load(t, analysis.DefaultProfile)
+		require.Empty(t, program.Checker.Warnings())
+	})
+
+	t.Run("audit", func(t *testing.T) {
+		t.Parallel()
+
+		program := load(t, analysis.Profiles["audit"])
+
+		warnings := program.Checker.Warnings()
+		require.Len(t, warnings, 1)
+
+		var diagnostic *sema.LintDiagnostic
+		require.ErrorAs(t, warnings[0], &diagnostic)
+		assert.Equal(t,
+			sema.ResourceDestructionDocumentationDiagnosticCode,
+			diagnostic.Code,
+		)
+	})
 }
@@ -0,0 +1,265 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"sync"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/stdlib"
+)
+
+// importGraphNode is a location in the import graph of the programs to be loaded
+type importGraphNode struct {
+	location   common.Location
+	dependents []*importGraphNode
+	// pendingImports is the number of imported locations which are not loaded yet
+	pendingImports int
+	// unresolved is true if the imports of the location could not be determined,
+	// e.g. because the code could not be resolved or parsed
+	unresolved bool
+}
+
+// LoadConcurrently loads the given locations and all their imports,
+// using at most config.CheckConcurrency workers.
+//
+// The import graph of the locations is resolved first.
+// A program is checked as soon as all its imports are loaded,
+// so independent programs are checked concurrently.
+//
+// Locations which cannot be loaded concurrently,
+// e.g. because they have cyclic imports or an import failed to load, are skipped.
+// Locations which fail to load are not recorded, like in Load.
+// Load must be called for each location afterwards, to load the remaining locations and report errors.
+// Load returns immediately for locations which are already loaded.
+func (programs *Programs) LoadConcurrently(config *Config, locations ...common.Location) {
+	workers := config.CheckConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	nodes := programs.importGraph(config, locations)
+
+	type result struct {
+		node *importGraphNode
+		err  error
+	}
+
+	jobs := make(chan *importGraphNode)
+	results := make(chan result)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for node := range jobs {
+				err := programs.load(
+					config,
+					node.location,
+					nil,
+					ast.Range{},
+					newImportChain(node.location),
+				)
+				results <- result{
+					node: node,
+					err:  err,
+				}
+			}
+		}()
+	}
+
+	var ready []*importGraphNode
+	for _, node := range nodes {
+		if node.pendingImports == 0 && !node.unresolved {
+			ready = append(ready, node)
+		}
+	}
+
+	running := 0
+
+	for len(ready) > 0 || running > 0 {
+		var next *importGraphNode
+		var nextJobs chan<- *importGraphNode
+		if len(ready) > 0 {
+			next = ready[0]
+			nextJobs = jobs
+		}
+
+		select {
+		case nextJobs <- next:
+			ready = ready[1:]
+			running++
+
+		case result := <-results:
+			running--
+
+			// Dependents of a location which failed to load are loaded by Load
+			if result.err != nil {
+				continue
+			}
+
+			for _, dependent := range result.node.dependents {
+				dependent.pendingImports--
+				if dependent.pendingImports == 0 && !dependent.unresolved {
+					ready = append(ready, dependent)
+				}
+			}
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+}
+
+// importGraph resolves and parses the given locations and all their imports,
+// and returns the import graph of the locations which are not loaded yet, in discovery order
+func (programs *Programs) importGraph(config *Config, locations []common.Location) []*importGraphNode {
+
+	var nodes []*importGraphNode
+	nodesByLocation := map[common.Location]*importGraphNode{}
+	importsByLocation := map[common.Location][]common.Location{}
+
+	pending := locations
+
+	for len(pending) > 0 {
+
+		var unparsed []common.Location
+		for _, location := range pending {
+			if !programs.isParsed(location) {
+				unparsed = append(unparsed, location)
+			}
+		}
+		programs.Parse(config, unparsed...)
+
+		var next []common.Location
+
+		for _, location := range pending {
+			if _, ok := nodesByLocation[location]; ok || programs.get(location) != nil {
+				continue
+			}
+
+			node := &importGraphNode{
+				location: location,
+			}
+			nodes = append(nodes, node)
+			nodesByLocation[location] = node
+
+			imports, ok := programs.resolveImports(config, location)
+			if !ok {
+				node.unresolved = true
+				continue
+			}
+
+			importsByLocation[location] = imports
+			next = append(next, imports...)
+		}
+
+		pending = next
+	}
+
+	for _, node := range nodes {
+		for _, importedLocation := range importsByLocation[node.location] {
+			importedNode, ok := nodesByLocation[importedLocation]
+			if !ok {
+				// Already loaded
+				continue
+			}
+
+			importedNode.dependents = append(importedNode.dependents, node)
+			node.pendingImports++
+		}
+	}
+
+	return nodes
+}
+
+// resolveImports returns the locations imported by the parsed program of the given location.
+// It returns false if the imports cannot be determined
+func (programs *Programs) resolveImports(config *Config, location common.Location) ([]common.Location, bool) {
+	programs.mutex.Lock()
+	result, ok := programs.parsed[location]
+	programs.mutex.Unlock()
+
+	if !ok || result.resolveErr != nil || result.program == nil {
+		return nil, false
+	}
+
+	var imports []common.Location
+
+	for _, declaration := range result.program.ImportDeclarations() {
+		importedLocation := declaration.Location
+
+		addressLocation, isAddress := importedLocation.(common.AddressLocation)
+		if !isAddress {
+			if importedLocation == stdlib.CryptoContractLocation {
+				if programs.cryptoContractElaboration() != nil {
+					continue
+				}
+				if programs.CryptoContractLocation == nil {
+					return nil, false
+				}
+				importedLocation = programs.CryptoContractLocation()
+			}
+
+			imports = append(imports, importedLocation)
+			continue
+		}
+
+		// See sema.AddressLocationHandlerFunc
+
+		names := make([]string, 0, len(declaration.Identifiers))
+		for _, identifier := range declaration.Identifiers {
+			names = append(names, identifier.Identifier)
+		}
+
+		if len(names) == 0 {
+			if config.ResolveAddressContractNames == nil {
+				continue
+			}
+
+			var err error
+			names, err = config.ResolveAddressContractNames(addressLocation.Address)
+			if err != nil {
+				return nil, false
+			}
+		}
+
+		for _, name := range names {
+			imports = append(
+				imports,
+				common.AddressLocation{
+					Address: addressLocation.Address,
+					Name:    name,
+				},
+			)
+		}
+	}
+
+	return imports, true
+}
+
+func (programs *Programs) isParsed(location common.Location) bool {
+	programs.mutex.Lock()
+	defer programs.mutex.Unlock()
+
+	_, ok := programs.parsed[location]
+	return ok
+}
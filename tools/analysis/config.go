@@ -21,6 +21,9 @@ package analysis
 import (
 	"fmt"
 	"sort"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
 
 	"github.com/onflow/cadence/ast"
 	"github.com/onflow/cadence/common"
@@ -47,8 +50,24 @@ type Config struct {
 	HandleCheckerError func(err ParsingCheckingError, checker *sema.Checker) error
 	// CryptoContractElaboration is the elaboration of the Crypto contract
 	CryptoContractElaboration *sema.Elaboration
+	// ParseConcurrency is the maximum number of programs that are parsed concurrently.
+	// If greater than 1, ResolveCode must be safe for concurrent use.
+	ParseConcurrency int
+	// CheckConcurrency is the maximum number of programs that are checked concurrently,
+	// see Programs.LoadConcurrently.
+	// If greater than 1, ResolveCode, ResolveAddressContractNames, HandleParserError,
+	// and HandleCheckerError must be safe for concurrent use.
+	CheckConcurrency int
+	// Profile specifies the lint rules which are checked when programs are loaded.
+	// If nil, no lint rules are checked, see DefaultProfile
+	Profile *Profile
 }
 
+// NewSimpleConfig returns a configuration which resolves code and contract names
+// from the given maps, and loads the contracts of an address on demand using resolveAddressContracts.
+// The returned configuration is safe for concurrent use.
+// The contracts of an address are loaded at most once at a time,
+// concurrent resolutions for the same address wait for the same load.
 func NewSimpleConfig(
 	mode LoadMode,
 	codes map[common.Location][]byte,
@@ -56,35 +75,75 @@ func NewSimpleConfig(
 	resolveAddressContracts func(common.Address) (contracts map[string][]byte, err error),
 ) *Config {
 
-	loadAddressContracts := func(address common.Address) error {
+	// Guards codes and contractNames,
+	// which are populated on demand by loadAddressContracts.
+	// Not held while resolving the contracts of an address
+	var mutex sync.RWMutex
+
+	// Deduplicates concurrent loads of the contracts of an address
+	var addressContractsLoads singleflight.Group
+
+	lookupCode := func(location common.Location) ([]byte, bool) {
+		mutex.RLock()
+		defer mutex.RUnlock()
+
+		code, ok := codes[location]
+		return code, ok
+	}
+
+	lookupContractNames := func(address common.Address) ([]string, bool) {
+		mutex.RLock()
+		defer mutex.RUnlock()
+
+		names, ok := contractNames[address]
+		return names, ok
+	}
+
+	// loadAddressContracts loads the contracts of the given address,
+	// unless loaded returns true, i.e. a load finished since the caller's lookup
+	loadAddressContracts := func(address common.Address, loaded func() bool) error {
 		if resolveAddressContracts == nil {
 			return nil
 		}
-		contracts, err := resolveAddressContracts(address)
-		if err != nil {
-			return err
-		}
 
-		names := make([]string, 0, len(contracts))
+		_, err, _ := addressContractsLoads.Do(
+			address.Hex(),
+			func() (any, error) {
+				if loaded() {
+					return nil, nil
+				}
 
-		for name := range contracts { //nolint:maprange
-			names = append(names, name)
-		}
+				contracts, err := resolveAddressContracts(address)
+				if err != nil {
+					return nil, err
+				}
 
-		sort.Strings(names)
+				names := make([]string, 0, len(contracts))
 
-		for _, name := range names {
-			code := contracts[name]
-			location := common.AddressLocation{
-				Address: address,
-				Name:    name,
-			}
-			codes[location] = code
-		}
+				for name := range contracts { //nolint:maprange
+					names = append(names, name)
+				}
+
+				sort.Strings(names)
+
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				for _, name := range names {
+					code := contracts[name]
+					location := common.AddressLocation{
+						Address: address,
+						Name:    name,
+					}
+					codes[location] = code
+				}
 
-		contractNames[address] = names
+				contractNames[address] = names
 
-		return nil
+				return nil, nil
+			},
+		)
+		return err
 	}
 
 	config := &Config{
@@ -97,10 +156,16 @@ func NewSimpleConfig(
 		) {
 			repeat := true
 			for {
-				names, ok := contractNames[address]
+				names, ok := lookupContractNames(address)
 				if !ok {
 					if repeat {
-						err := loadAddressContracts(address)
+						err := loadAddressContracts(
+							address,
+							func() bool {
+								_, ok := lookupContractNames(address)
+								return ok
+							},
+						)
 						if err != nil {
 							return nil, err
 						}
@@ -126,11 +191,17 @@ func NewSimpleConfig(
 		) {
 			repeat := true
 			for {
-				code, ok := codes[location]
+				code, ok := lookupCode(location)
 				if !ok {
 					if repeat {
 						if addressLocation, ok := location.(common.AddressLocation); ok {
-							err := loadAddressContracts(addressLocation.Address)
+							err := loadAddressContracts(
+								addressLocation.Address,
+								func() bool {
+									_, ok := lookupCode(location)
+									return ok
+								},
+							)
 							if err != nil {
 								return nil, err
 							}
@@ -0,0 +1,113 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"sync"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/parser"
+)
+
+// parseResult is the result of resolving and parsing the code of a location.
+// If resolving the code failed, the code and program are nil, and resolveErr is set.
+// If parsing failed, program might still be set, and parseErr is set.
+type parseResult struct {
+	code       []byte
+	program    *ast.Program
+	resolveErr error
+	parseErr   error
+}
+
+func parse(config *Config, location common.Location) parseResult {
+	code, err := config.ResolveCode(location, nil, ast.Range{})
+	if err != nil {
+		return parseResult{
+			resolveErr: err,
+		}
+	}
+
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+
+	return parseResult{
+		code:     code,
+		program:  program,
+		parseErr: err,
+	}
+}
+
+// parseAll resolves and parses the given locations,
+// using at most the given number of concurrent workers.
+// Duplicate locations are only parsed once.
+func parseAll(
+	config *Config,
+	locations []common.Location,
+	workers int,
+) map[common.Location]parseResult {
+
+	results := make(map[common.Location]parseResult, len(locations))
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	type job struct {
+		location common.Location
+		result   parseResult
+	}
+
+	jobs := make(chan common.Location)
+	done := make(chan job)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for location := range jobs {
+				done <- job{
+					location: location,
+					result:   parse(config, location),
+				}
+			}
+		}()
+	}
+
+	go func() {
+		seen := make(map[common.Location]struct{}, len(locations))
+		for _, location := range locations {
+			if _, ok := seen[location]; ok {
+				continue
+			}
+			seen[location] = struct{}{}
+			jobs <- location
+		}
+		close(jobs)
+
+		wg.Wait()
+		close(done)
+	}()
+
+	for job := range done {
+		results[job.location] = job.result
+	}
+
+	return results
+}
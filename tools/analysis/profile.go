@@ -0,0 +1,50 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"github.com/onflow/cadence/sema"
+)
+
+// Profile is a named set of lint rules, which are checked when programs are loaded, see Config.Profile.
+// Diagnostics of the lint rules are reported as warnings of the checker, see sema.Checker.Warnings
+type Profile struct {
+	Name      string
+	LintRules []*sema.LintRule
+}
+
+// DefaultProfile does not check any lint rules
+var DefaultProfile = &Profile{
+	Name: "default",
+}
+
+// AuditProfile checks additional rules which are intended for audited codebases,
+// e.g. that resource destruction is documented
+var AuditProfile = &Profile{
+	Name: "audit",
+	LintRules: []*sema.LintRule{
+		sema.ResourceDestructionDocumentationLintRule,
+	},
+}
+
+// Profiles are the available profiles, by name
+var Profiles = map[string]*Profile{
+	DefaultProfile.Name: DefaultProfile,
+	AuditProfile.Name:   AuditProfile,
+}
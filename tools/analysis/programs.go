@@ -20,6 +20,8 @@ package analysis
 
 import (
 	"fmt"
+	"slices"
+	"sync"
 
 	"github.com/onflow/cadence/ast"
 	"github.com/onflow/cadence/common"
@@ -32,9 +34,73 @@ type Programs struct {
 	Programs                  map[common.Location]*Program
 	CryptoContractElaboration *sema.Elaboration
 	CryptoContractLocation    func() common.Location
+	// parsed contains the results of parsing ahead of loading, see Parse
+	parsed map[common.Location]parseResult
+	// imports contains the locations imported by each checked program, see ImportGraph
+	imports map[common.Location][]common.Location
+	// mutex guards Programs, CryptoContractElaboration, parsed, and imports,
+	// which are accessed concurrently when programs are checked concurrently
+	mutex sync.Mutex
 }
 
-type importResolutionResults map[common.Location]bool
+// importChain is the chain of imports which led to the program currently being loaded,
+// starting with the entry point program
+type importChain struct {
+	locations []common.Location
+}
+
+func newImportChain(location common.Location) *importChain {
+	return &importChain{
+		// Entry point program is also currently in check.
+		locations: []common.Location{location},
+	}
+}
+
+func (c *importChain) push(location common.Location) {
+	c.locations = append(c.locations, location)
+}
+
+func (c *importChain) pop() {
+	c.locations = c.locations[:len(c.locations)-1]
+}
+
+// cycle returns the import cycle which importing the given location would form, if any:
+// the chain starting at the given location, followed by the given location again
+func (c *importChain) cycle(location common.Location) []common.Location {
+	for i, chainLocation := range c.locations {
+		if chainLocation != location {
+			continue
+		}
+
+		cycle := make([]common.Location, 0, len(c.locations)-i+1)
+		cycle = append(cycle, c.locations[i:]...)
+		return append(cycle, location)
+	}
+
+	return nil
+}
+
+// Parse resolves and parses the given locations concurrently,
+// using at most config.ParseConcurrency workers.
+//
+// The results are retained until the locations are loaded using Load,
+// so that only checking is performed sequentially.
+// Resolution and parsing errors are reported per location, when the location is loaded.
+func (programs *Programs) Parse(config *Config, locations ...common.Location) {
+	results := parseAll(config, locations, config.ParseConcurrency)
+
+	programs.mutex.Lock()
+	defer programs.mutex.Unlock()
+
+	if programs.parsed == nil {
+		programs.parsed = results
+		return
+	}
+
+	for location, result := range results { //nolint:maprange
+		programs.parsed[location] = result
+	}
+}
 
 func (programs *Programs) Load(config *Config, location common.Location) error {
 	return programs.load(
@@ -42,10 +108,7 @@ func (programs *Programs) Load(config *Config, location common.Location) error {
 		location,
 		nil,
 		ast.Range{},
-		importResolutionResults{
-			// Entry point program is also currently in check.
-			location: true,
-		},
+		newImportChain(location),
 	)
 }
 
@@ -54,9 +117,9 @@ func (programs *Programs) load(
 	location common.Location,
 	importingLocation common.Location,
 	importRange ast.Range,
-	seenImports importResolutionResults,
+	chain *importChain,
 ) error {
-	if programs.Programs[location] != nil {
+	if programs.get(location) != nil {
 		return nil
 	}
 
@@ -68,12 +131,27 @@ func (programs *Programs) load(
 		}
 	}
 
-	code, err := config.ResolveCode(location, importingLocation, importRange)
-	if err != nil {
-		return err
+	var code []byte
+	var program *ast.Program
+	var err error
+
+	if result, ok := programs.takeParsed(location); ok {
+		if result.resolveErr != nil {
+			return result.resolveErr
+		}
+
+		code = result.code
+		program = result.program
+		err = result.parseErr
+	} else {
+		code, err = config.ResolveCode(location, importingLocation, importRange)
+		if err != nil {
+			return err
+		}
+
+		program, err = parser.ParseProgram(nil, code, parser.Config{})
 	}
 
-	program, err := parser.ParseProgram(nil, code, parser.Config{})
 	if err != nil {
 		wrappedErr := wrapError(err)
 		loadError = wrappedErr
@@ -91,7 +169,7 @@ func (programs *Programs) load(
 
 	var checker *sema.Checker
 	if config.Mode&NeedTypes != 0 {
-		checker, err = programs.check(config, program, location, seenImports)
+		checker, err = programs.check(config, program, location, chain)
 		if err != nil {
 			wrappedErr := wrapError(err)
 			if loadError == nil {
@@ -110,22 +188,61 @@ func (programs *Programs) load(
 		}
 	}
 
-	programs.Programs[location] = &Program{
+	programs.set(location, &Program{
 		Location:  location,
 		Code:      code,
 		Program:   program,
 		Checker:   checker,
 		LoadError: loadError,
-	}
+	})
 
 	return nil
 }
 
+func (programs *Programs) get(location common.Location) *Program {
+	programs.mutex.Lock()
+	defer programs.mutex.Unlock()
+
+	return programs.Programs[location]
+}
+
+func (programs *Programs) set(location common.Location, program *Program) {
+	programs.mutex.Lock()
+	defer programs.mutex.Unlock()
+
+	programs.Programs[location] = program
+}
+
+func (programs *Programs) takeParsed(location common.Location) (parseResult, bool) {
+	programs.mutex.Lock()
+	defer programs.mutex.Unlock()
+
+	result, ok := programs.parsed[location]
+	if ok {
+		delete(programs.parsed, location)
+	}
+	return result, ok
+}
+
+func (programs *Programs) cryptoContractElaboration() *sema.Elaboration {
+	programs.mutex.Lock()
+	defer programs.mutex.Unlock()
+
+	return programs.CryptoContractElaboration
+}
+
+func (programs *Programs) setCryptoContractElaboration(elaboration *sema.Elaboration) {
+	programs.mutex.Lock()
+	defer programs.mutex.Unlock()
+
+	programs.CryptoContractElaboration = elaboration
+}
+
 func (programs *Programs) check(
 	config *Config,
 	program *ast.Program,
 	location common.Location,
-	seenImports importResolutionResults,
+	chain *importChain,
 ) (
 	*sema.Checker,
 	error,
@@ -135,6 +252,13 @@ func (programs *Programs) check(
 		baseValueActivation.DeclareValue(value)
 	}
 
+	var imports []common.Location
+
+	var lintRules []*sema.LintRule
+	if config.Profile != nil {
+		lintRules = config.Profile.LintRules
+	}
+
 	checker, err := sema.NewChecker(
 		program,
 		location,
@@ -149,6 +273,7 @@ func (programs *Programs) check(
 			),
 			PositionInfoEnabled:        config.Mode&NeedPositionInfo != 0,
 			ExtendedElaborationEnabled: config.Mode&NeedExtendedElaboration != 0,
+			LintRules:                  lintRules,
 			ImportHandler: func(
 				checker *sema.Checker,
 				importedLocation common.Location,
@@ -158,10 +283,12 @@ func (programs *Programs) check(
 				var elaboration *sema.Elaboration
 				var loadError error
 
+				imports = append(imports, importedLocation)
+
 				switch importedLocation {
 				case stdlib.CryptoContractLocation:
 					// If the elaboration for the crypto contract is available, take it.
-					elaboration = programs.CryptoContractElaboration
+					elaboration = programs.cryptoContractElaboration()
 					if elaboration != nil {
 						break
 					}
@@ -178,26 +305,27 @@ func (programs *Programs) check(
 
 					// Memoize the crypto contract's elaboration, for subsequent uses.
 					defer func() {
-						programs.CryptoContractElaboration = elaboration
+						programs.setCryptoContractElaboration(elaboration)
 					}()
 
 					fallthrough
 				default:
-					if seenImports[importedLocation] {
+					if cycle := chain.cycle(importedLocation); cycle != nil {
 						return nil, &sema.CyclicImportsError{
 							Location: importedLocation,
+							Cycle:    cycle,
 							Range:    importRange,
 						}
 					}
-					seenImports[importedLocation] = true
-					defer delete(seenImports, importedLocation)
+					chain.push(importedLocation)
+					defer chain.pop()
 
-					err := programs.load(config, importedLocation, location, importRange, seenImports)
+					err := programs.load(config, importedLocation, location, importRange, chain)
 					if err != nil {
 						return nil, err
 					}
 
-					program := programs.Programs[importedLocation]
+					program := programs.get(importedLocation)
 					checker := program.Checker
 
 					// If the imported program has a checker, use its elaboration for the import
@@ -224,6 +352,9 @@ func (programs *Programs) check(
 	}
 
 	err = checker.Check()
+
+	programs.setImports(location, imports)
+
 	if err != nil {
 		return checker, err
 	}
@@ -231,6 +362,32 @@ func (programs *Programs) check(
 	return checker, nil
 }
 
+func (programs *Programs) setImports(location common.Location, imports []common.Location) {
+	programs.mutex.Lock()
+	defer programs.mutex.Unlock()
+
+	if programs.imports == nil {
+		programs.imports = map[common.Location][]common.Location{}
+	}
+	programs.imports[location] = imports
+}
+
+// ImportGraph returns the import graph of the checked programs:
+// For each program, the locations of the programs it imports, in import order.
+// Imports of the crypto contract are recorded as stdlib.CryptoContractLocation.
+//
+// The graph may contain cycles, if programs have cyclic imports
+func (programs *Programs) ImportGraph() map[common.Location][]common.Location {
+	programs.mutex.Lock()
+	defer programs.mutex.Unlock()
+
+	graph := make(map[common.Location][]common.Location, len(programs.imports))
+	for location, imports := range programs.imports { //nolint:maprange
+		graph[location] = slices.Clone(imports)
+	}
+	return graph
+}
+
 func (programs *Programs) Get(location common.Location) *Program {
-	return programs.Programs[location]
+	return programs.get(location)
 }
@@ -0,0 +1,412 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package astjson translates between the JSON encoding of programs' ASTs
+// produced by Cadence versions before v1.0 (the legacy encoding), and the current encoding.
+//
+// This allows tools which stored legacy AST dumps, e.g. explorers or analytics,
+// to migrate them without having to re-fetch and re-parse the programs.
+//
+// The translation covers the node kinds which changed:
+// access modifiers, function purity, reference types, restricted (now intersection) types,
+// function conditions, and interface conformances.
+// All other nodes are passed through unchanged.
+package astjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// UnsupportedError is returned when a node cannot be represented in the target encoding,
+// e.g. an entitlement access modifier in the legacy encoding
+type UnsupportedError struct {
+	NodeType string
+	Message  string
+}
+
+var _ error = UnsupportedError{}
+
+func (e UnsupportedError) Error() string {
+	if e.NodeType == "" {
+		return fmt.Sprintf("unsupported node: %s", e.Message)
+	}
+	return fmt.Sprintf("unsupported node of type %s: %s", e.NodeType, e.Message)
+}
+
+const (
+	typeKey = "Type"
+
+	accessKey           = "Access"
+	purityKey           = "Purity"
+	purityAnnotationKey = "PurityAnnotation"
+	conformancesKey     = "Conformances"
+	preConditionsKey    = "PreConditions"
+	postConditionsKey   = "PostConditions"
+	conditionsKey       = "Conditions"
+	testKey             = "Test"
+	messageKey          = "Message"
+	kindKey             = "Kind"
+	startPosKey         = "StartPos"
+	endPosKey           = "EndPos"
+
+	legacyAuthorizedKey     = "Authorized"
+	authorizedKey           = "LegacyAuthorized"
+	authorizationKey        = "Authorization"
+	legacyRestrictedKey     = "RestrictedType"
+	restrictedKey           = "LegacyRestrictedType"
+	legacyRestrictionsKey   = "Restrictions"
+	intersectionTypesKey    = "Types"
+	legacyRestrictedType    = "RestrictedType"
+	intersectionType        = "IntersectionType"
+	referenceType           = "ReferenceType"
+	functionType            = "FunctionType"
+	functionDeclaration     = "FunctionDeclaration"
+	functionExpression      = "FunctionExpression"
+	interfaceDeclaration    = "InterfaceDeclaration"
+	testCondition           = "TestCondition"
+	purityUnspecified       = "Unspecified"
+	legacyConditionKindPre  = "ConditionKindPre"
+	legacyConditionKindPost = "ConditionKindPost"
+)
+
+// legacyAccesses maps the legacy names of access modifiers to the current names
+var legacyAccesses = map[string]string{
+	"AccessNotSpecified":   "AccessNotSpecified",
+	"AccessPrivate":        "AccessSelf",
+	"AccessContract":       "AccessContract",
+	"AccessAccount":        "AccessAccount",
+	"AccessPublic":         "AccessAll",
+	"AccessPublicSettable": "AccessPubSettableLegacy",
+}
+
+// accesses maps the current names of access modifiers to the legacy names
+var accesses = map[string]string{
+	"AccessNotSpecified":      "AccessNotSpecified",
+	"AccessSelf":              "AccessPrivate",
+	"AccessContract":          "AccessContract",
+	"AccessAccount":           "AccessAccount",
+	"AccessAll":               "AccessPublic",
+	"AccessPubSettableLegacy": "AccessPublicSettable",
+}
+
+// unsupportedLegacyNodeTypes are the types of nodes which have no legacy encoding
+var unsupportedLegacyNodeTypes = map[string]struct{}{
+	"EntitlementDeclaration":        {},
+	"EntitlementMappingDeclaration": {},
+	"EmitCondition":                 {},
+	"StringTemplateExpression":      {},
+}
+
+// FromLegacy translates the given AST in the legacy JSON encoding to the current JSON encoding
+func FromLegacy(data []byte) ([]byte, error) {
+	return translate(data, fromLegacy)
+}
+
+// ToLegacy translates the given AST in the current JSON encoding to the legacy JSON encoding.
+// An UnsupportedError is returned if the AST contains nodes which cannot be represented
+// in the legacy encoding
+func ToLegacy(data []byte) ([]byte, error) {
+	return translate(data, toLegacy)
+}
+
+func translate(data []byte, translateNode func(node map[string]any) error) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Preserve numbers as-is, e.g. the values of large integer literals
+	decoder.UseNumber()
+
+	var value any
+	err := decoder.Decode(&value)
+	if err != nil {
+		return nil, err
+	}
+
+	err = walk(value, translateNode)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(value)
+}
+
+// walk calls the given function for all objects in the given JSON value, in depth-first order,
+// children before their parents
+func walk(value any, f func(node map[string]any) error) error {
+	switch value := value.(type) {
+	case []any:
+		for _, element := range value {
+			err := walk(element, f)
+			if err != nil {
+				return err
+			}
+		}
+
+	case map[string]any:
+		// Visit the children in a deterministic order,
+		// so the first reported error is deterministic
+		keys := make([]string, 0, len(value))
+		for key := range value { //nolint:maprange
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			err := walk(value[key], f)
+			if err != nil {
+				return err
+			}
+		}
+
+		return f(value)
+	}
+
+	return nil
+}
+
+func nodeType(node map[string]any) string {
+	typ, _ := node[typeKey].(string)
+	return typ
+}
+
+func renameKey(node map[string]any, oldKey, newKey string) {
+	value, ok := node[oldKey]
+	if !ok {
+		return
+	}
+	delete(node, oldKey)
+	node[newKey] = value
+}
+
+func fromLegacy(node map[string]any) error {
+	typ := nodeType(node)
+
+	if access, ok := node[accessKey].(string); ok {
+		newAccess, ok := legacyAccesses[access]
+		if !ok {
+			return UnsupportedError{
+				NodeType: typ,
+				Message:  fmt.Sprintf("unknown legacy access %q", access),
+			}
+		}
+		node[accessKey] = newAccess
+	}
+
+	switch typ {
+	case functionDeclaration, functionExpression:
+		if _, ok := node[purityKey]; !ok {
+			node[purityKey] = purityUnspecified
+		}
+
+	case functionType:
+		if _, ok := node[purityAnnotationKey]; !ok {
+			node[purityAnnotationKey] = purityUnspecified
+		}
+
+	case interfaceDeclaration:
+		if _, ok := node[conformancesKey]; !ok {
+			node[conformancesKey] = []any{}
+		}
+
+	case referenceType:
+		renameKey(node, legacyAuthorizedKey, authorizedKey)
+		if _, ok := node[authorizedKey]; !ok {
+			node[authorizedKey] = false
+		}
+		node[authorizationKey] = nil
+
+	case legacyRestrictedType:
+		node[typeKey] = intersectionType
+		renameKey(node, legacyRestrictedKey, restrictedKey)
+		renameKey(node, legacyRestrictionsKey, intersectionTypesKey)
+	}
+
+	for _, key := range []string{preConditionsKey, postConditionsKey} {
+		legacyConditions, ok := node[key].([]any)
+		if !ok {
+			continue
+		}
+		conditions, err := conditionsFromLegacy(legacyConditions)
+		if err != nil {
+			return err
+		}
+		node[key] = conditions
+	}
+
+	return nil
+}
+
+// conditionsFromLegacy translates a legacy list of conditions to a conditions node.
+//
+// NOTE: The legacy encoding does not contain the range of the `pre` or `post` block,
+// so the range of the conditions node is the range of the conditions it contains
+func conditionsFromLegacy(legacyConditions []any) (map[string]any, error) {
+	conditions := make([]any, 0, len(legacyConditions))
+
+	for _, legacyCondition := range legacyConditions {
+		condition, ok := legacyCondition.(map[string]any)
+		if !ok {
+			return nil, UnsupportedError{
+				Message: "condition must be an object",
+			}
+		}
+
+		test, _ := condition[testKey].(map[string]any)
+		if test == nil {
+			return nil, UnsupportedError{
+				Message: "condition is missing test",
+			}
+		}
+
+		delete(condition, kindKey)
+		condition[typeKey] = testCondition
+		condition[startPosKey] = test[startPosKey]
+
+		condition[endPosKey] = test[endPosKey]
+		if message, ok := condition[messageKey].(map[string]any); ok {
+			condition[endPosKey] = message[endPosKey]
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	result := map[string]any{
+		conditionsKey: conditions,
+	}
+
+	if len(conditions) > 0 {
+		first := conditions[0].(map[string]any)
+		last := conditions[len(conditions)-1].(map[string]any)
+		result[startPosKey] = first[startPosKey]
+		result[endPosKey] = last[endPosKey]
+	}
+
+	return result, nil
+}
+
+func toLegacy(node map[string]any) error {
+	typ := nodeType(node)
+
+	if _, ok := unsupportedLegacyNodeTypes[typ]; ok {
+		return UnsupportedError{
+			NodeType: typ,
+			Message:  "node type has no legacy encoding",
+		}
+	}
+
+	if access, ok := node[accessKey]; ok && access != nil {
+		accessName, _ := access.(string)
+		legacyAccess, ok := accesses[accessName]
+		if !ok {
+			return UnsupportedError{
+				NodeType: typ,
+				Message:  fmt.Sprintf("access %v has no legacy encoding", access),
+			}
+		}
+		node[accessKey] = legacyAccess
+	}
+
+	switch typ {
+	case functionDeclaration, functionExpression:
+		err := removeUnspecifiedPurity(node, typ, purityKey)
+		if err != nil {
+			return err
+		}
+
+	case functionType:
+		err := removeUnspecifiedPurity(node, typ, purityAnnotationKey)
+		if err != nil {
+			return err
+		}
+
+	case interfaceDeclaration:
+		if conformances, ok := node[conformancesKey].([]any); ok && len(conformances) > 0 {
+			return UnsupportedError{
+				NodeType: typ,
+				Message:  "interface conformances have no legacy encoding",
+			}
+		}
+		delete(node, conformancesKey)
+
+	case referenceType:
+		if node[authorizationKey] != nil {
+			return UnsupportedError{
+				NodeType: typ,
+				Message:  "entitlement authorization has no legacy encoding",
+			}
+		}
+		delete(node, authorizationKey)
+		renameKey(node, authorizedKey, legacyAuthorizedKey)
+
+	case intersectionType:
+		node[typeKey] = legacyRestrictedType
+		renameKey(node, restrictedKey, legacyRestrictedKey)
+		renameKey(node, intersectionTypesKey, legacyRestrictionsKey)
+	}
+
+	for key, kind := range map[string]string{ //nolint:maprange
+		preConditionsKey:  legacyConditionKindPre,
+		postConditionsKey: legacyConditionKindPost,
+	} {
+		conditions, ok := node[key].(map[string]any)
+		if !ok {
+			continue
+		}
+		node[key] = conditionsToLegacy(conditions, kind)
+	}
+
+	return nil
+}
+
+func removeUnspecifiedPurity(node map[string]any, typ string, key string) error {
+	if purity, ok := node[key]; ok && purity != purityUnspecified {
+		return UnsupportedError{
+			NodeType: typ,
+			Message:  fmt.Sprintf("purity %v has no legacy encoding", purity),
+		}
+	}
+	delete(node, key)
+	return nil
+}
+
+// conditionsToLegacy translates a conditions node to a legacy list of conditions.
+// The conditions have already been translated, i.e. are all test conditions
+func conditionsToLegacy(conditions map[string]any, kind string) []any {
+	elements, _ := conditions[conditionsKey].([]any)
+
+	legacyConditions := make([]any, 0, len(elements))
+
+	for _, element := range elements {
+		condition, ok := element.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		legacyConditions = append(
+			legacyConditions,
+			map[string]any{
+				kindKey:    kind,
+				testKey:    condition[testKey],
+				messageKey: condition[messageKey],
+			},
+		)
+	}
+
+	return legacyConditions
+}
@@ -0,0 +1,220 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package astjson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/old_parser"
+	"github.com/onflow/cadence/parser"
+)
+
+func TestRoundTrip(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      pub resource interface I {
+          pub fun foo(): auth &R{I}
+      }
+
+      pub resource R: I {
+          priv let xs: [Int]
+          pub(set) var y: Int
+
+          init() {
+              self.xs = [1, 18446744073709551616]
+              self.y = 0
+          }
+
+          pub fun foo(): auth &R{I} {
+              let f: ((Int): Int) = fun (x: Int): Int { return x }
+              return &self as auth &R{I}
+          }
+      }
+    `
+
+	program, err := old_parser.ParseProgram(nil, []byte(code), old_parser.Config{})
+	require.NoError(t, err)
+
+	current, err := json.Marshal(program)
+	require.NoError(t, err)
+
+	legacy, err := ToLegacy(current)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(legacy), `"Access":"AccessPublic"`)
+	assert.Contains(t, string(legacy), `"Access":"AccessPrivate"`)
+	assert.Contains(t, string(legacy), `"Access":"AccessPublicSettable"`)
+	assert.Contains(t, string(legacy), `"Type":"RestrictedType"`)
+	assert.Contains(t, string(legacy), `"Restrictions":[`)
+	assert.Contains(t, string(legacy), `"Authorized":true`)
+	assert.Contains(t, string(legacy), `18446744073709551616`)
+	assert.NotContains(t, string(legacy), `IntersectionType`)
+	assert.NotContains(t, string(legacy), `Purity`)
+	assert.NotContains(t, string(legacy), `Authorization`)
+	assert.NotContains(t, string(legacy), `Conformances":[]`)
+
+	translated, err := FromLegacy(legacy)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(current), string(translated))
+}
+
+func TestFromLegacyConditions(t *testing.T) {
+
+	t.Parallel()
+
+	const legacy = `
+      {
+        "Type": "FunctionBlock",
+        "Block": {
+          "Type": "Block",
+          "Statements": [],
+          "StartPos": {"Offset": 0, "Line": 1, "Column": 0},
+          "EndPos": {"Offset": 30, "Line": 1, "Column": 30}
+        },
+        "PreConditions": [
+          {
+            "Kind": "ConditionKindPre",
+            "Test": {
+              "Type": "BoolExpression",
+              "Value": true,
+              "StartPos": {"Offset": 10, "Line": 1, "Column": 10},
+              "EndPos": {"Offset": 13, "Line": 1, "Column": 13}
+            },
+            "Message": {
+              "Type": "StringExpression",
+              "Value": "fail",
+              "StartPos": {"Offset": 17, "Line": 1, "Column": 17},
+              "EndPos": {"Offset": 22, "Line": 1, "Column": 22}
+            }
+          }
+        ],
+        "StartPos": {"Offset": 0, "Line": 1, "Column": 0},
+        "EndPos": {"Offset": 30, "Line": 1, "Column": 30}
+      }
+    `
+
+	const expected = `
+      {
+        "Type": "FunctionBlock",
+        "Block": {
+          "Type": "Block",
+          "Statements": [],
+          "StartPos": {"Offset": 0, "Line": 1, "Column": 0},
+          "EndPos": {"Offset": 30, "Line": 1, "Column": 30}
+        },
+        "PreConditions": {
+          "Conditions": [
+            {
+              "Type": "TestCondition",
+              "Test": {
+                "Type": "BoolExpression",
+                "Value": true,
+                "StartPos": {"Offset": 10, "Line": 1, "Column": 10},
+                "EndPos": {"Offset": 13, "Line": 1, "Column": 13}
+              },
+              "Message": {
+                "Type": "StringExpression",
+                "Value": "fail",
+                "StartPos": {"Offset": 17, "Line": 1, "Column": 17},
+                "EndPos": {"Offset": 22, "Line": 1, "Column": 22}
+              },
+              "StartPos": {"Offset": 10, "Line": 1, "Column": 10},
+              "EndPos": {"Offset": 22, "Line": 1, "Column": 22}
+            }
+          ],
+          "StartPos": {"Offset": 10, "Line": 1, "Column": 10},
+          "EndPos": {"Offset": 22, "Line": 1, "Column": 22}
+        },
+        "StartPos": {"Offset": 0, "Line": 1, "Column": 0},
+        "EndPos": {"Offset": 30, "Line": 1, "Column": 30}
+      }
+    `
+
+	translated, err := FromLegacy([]byte(legacy))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, expected, string(translated))
+
+	roundTripped, err := ToLegacy(translated)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, legacy, string(roundTripped))
+}
+
+func TestToLegacyUnsupported(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(name string, code string, nodeType string) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			program, err := parser.ParseProgram(nil, []byte(code), parser.Config{})
+			require.NoError(t, err)
+
+			current, err := json.Marshal(program)
+			require.NoError(t, err)
+
+			_, err = ToLegacy(current)
+			var unsupportedErr UnsupportedError
+			require.ErrorAs(t, err, &unsupportedErr)
+			assert.Equal(t, nodeType, unsupportedErr.NodeType)
+		})
+	}
+
+	test("view function", `view fun test() {}`, "FunctionDeclaration")
+	test("entitlement declaration", `entitlement E`, "EntitlementDeclaration")
+	test(
+		"entitlement access",
+		`
+          entitlement E
+          access(E) fun test() {}
+        `,
+		"EntitlementDeclaration",
+	)
+	test(
+		"entitled reference",
+		`let r: auth(E) &Int = &1`,
+		"ReferenceType",
+	)
+	test(
+		"interface conformance",
+		`
+          struct interface I {}
+          struct interface J: I {}
+        `,
+		"InterfaceDeclaration",
+	)
+	test(
+		"emit condition",
+		`
+          fun test() {
+              post { emit E() }
+          }
+        `,
+		"EmitCondition",
+	)
+}
@@ -24,6 +24,7 @@ import (
 	"io"
 	"log"
 	"reflect"
+	"runtime"
 	"strings"
 
 	"github.com/onflow/cadence/ast"
@@ -65,6 +66,8 @@ func (c *ContractsChecker) CheckCSV(csvReader io.Reader) {
 		contractNames,
 		nil,
 	)
+	analysisConfig.ParseConcurrency = runtime.NumCPU()
+	analysisConfig.CheckConcurrency = runtime.NumCPU()
 
 	c.analyze(analysisConfig, locations)
 }
@@ -142,8 +145,14 @@ func (c *ContractsChecker) analyze(
 		},
 	}
 
+	log.Println("Parsing contracts ...")
+
+	programs.Parse(config, locations...)
+
 	log.Println("Checking contracts ...")
 
+	programs.LoadConcurrently(config, locations...)
+
 	for _, location := range locations {
 		log.Printf("Checking %s", location.Description())
 
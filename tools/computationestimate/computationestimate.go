@@ -0,0 +1,570 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package computationestimate statically estimates an upper bound of the computation of functions,
+// so contract authors get early feedback about functions which are likely to exceed transaction limits.
+//
+// The estimate uses the computation kinds metered by the interpreter,
+// i.e. statements, loop iterations, and function invocations, weighted with configurable weights.
+//
+// The number of iterations of loops is determined heuristically:
+// loops over constant-sized arrays, array literals, and ranges with literal bounds,
+// and while loops which compare with an integer literal, e.g. `while i < 10`, are bounded.
+// All other loops, e.g. loops over variable-sized arrays or dictionaries, are reported as unbounded,
+// and are assumed to iterate Config.DefaultLoopIterations times.
+//
+// Calls of global functions and of functions of the same composite (i.e. through `self`)
+// include the estimated computation of the called function. Recursive calls are reported as unbounded.
+package computationestimate
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/sema"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+// Weights are the weights of the computation kinds
+type Weights map[common.ComputationKind]uint64
+
+// DefaultWeights weigh each statement, loop iteration, and function invocation equally
+var DefaultWeights = Weights{
+	common.ComputationKindStatement:          1,
+	common.ComputationKindLoop:               1,
+	common.ComputationKindFunctionInvocation: 1,
+}
+
+// Config configures the estimation
+type Config struct {
+	// Weights are the weights of the computation kinds.
+	// When nil, DefaultWeights are used
+	Weights Weights
+	// DefaultLoopIterations is the assumed number of iterations of unbounded loops
+	DefaultLoopIterations uint64
+}
+
+// DefaultConfig is the default configuration of the estimation
+var DefaultConfig = Config{
+	Weights:               DefaultWeights,
+	DefaultLoopIterations: 100,
+}
+
+// UnboundedLoop is a loop, or a recursive call, for which no bound could be determined
+type UnboundedLoop struct {
+	Position ast.Position `json:"position"`
+	Reason   string       `json:"reason"`
+}
+
+// Function is the estimated computation of a function
+type Function struct {
+	// ContainerTypeID is the type ID of the composite or interface type which declares the function,
+	// or empty for global functions and transactions
+	ContainerTypeID common.TypeID `json:"containerTypeID,omitempty"`
+	Name            string        `json:"name"`
+	Position        ast.Position  `json:"position"`
+	// Computation is the estimated upper bound of the computation of the function.
+	// If the function is not bounded, unbounded loops are assumed to iterate Config.DefaultLoopIterations times
+	Computation uint64 `json:"computation"`
+	// Bounded is false if the function, or a function it calls, contains an unbounded loop or recursion
+	Bounded bool `json:"bounded"`
+	// UnboundedLoops are the unbounded loops and recursive calls in the function itself
+	UnboundedLoops []UnboundedLoop `json:"unboundedLoops,omitempty"`
+}
+
+// Report is the estimated computation of the functions of a program
+type Report struct {
+	Location  string     `json:"location"`
+	Functions []Function `json:"functions"`
+}
+
+// Analyzer reports the estimated computation of the functions of the analyzed program as its result,
+// using DefaultConfig
+var Analyzer = &analysis.Analyzer{
+	Description: "Estimates an upper bound of the computation of functions, and reports unbounded loops",
+	Run: func(pass *analysis.Pass) interface{} {
+		program := pass.Program
+		return Analyze(program.Location, program.Program, program.Checker.Elaboration, DefaultConfig)
+	},
+}
+
+// Analyze returns the estimated computation of the functions of the given checked program.
+// Functions are reported in declaration order.
+//
+// The sizes of constant-sized arrays are only known if the program was checked with extended elaboration,
+// see sema.Config.ExtendedElaborationEnabled
+func Analyze(
+	location common.Location,
+	program *ast.Program,
+	elaboration *sema.Elaboration,
+	config Config,
+) *Report {
+
+	if config.Weights == nil {
+		config.Weights = DefaultWeights
+	}
+
+	estimator := &estimator{
+		config:          config,
+		elaboration:     elaboration,
+		globalFunctions: map[string]*ast.FunctionDeclaration{},
+		functions:       map[*ast.FunctionDeclaration]*functionEstimate{},
+		containers:      map[*ast.FunctionDeclaration]*ast.Members{},
+	}
+
+	for _, declaration := range program.FunctionDeclarations() {
+		estimator.globalFunctions[declaration.Identifier.Identifier] = declaration
+	}
+
+	report := &Report{
+		Location:  location.String(),
+		Functions: []Function{},
+	}
+
+	addFunction := func(containerTypeID common.TypeID, name string, declaration *ast.FunctionDeclaration) {
+		if declaration == nil || declaration.FunctionBlock == nil {
+			return
+		}
+
+		estimate := estimator.function(declaration)
+
+		report.Functions = append(
+			report.Functions,
+			Function{
+				ContainerTypeID: containerTypeID,
+				Name:            name,
+				Position:        declaration.Identifier.Pos,
+				Computation:     estimate.computation,
+				Bounded:         estimate.bounded,
+				UnboundedLoops:  estimate.unboundedLoops,
+			},
+		)
+	}
+
+	var addMembers func(containerType sema.Type, members *ast.Members)
+	addMembers = func(containerType sema.Type, members *ast.Members) {
+		var containerTypeID common.TypeID
+		if containerType != nil {
+			containerTypeID = containerType.ID()
+		}
+
+		for _, declaration := range members.SpecialFunctions() {
+			estimator.containers[declaration.FunctionDeclaration] = members
+		}
+		for _, declaration := range members.Functions() {
+			estimator.containers[declaration] = members
+		}
+
+		for _, declaration := range members.SpecialFunctions() {
+			addFunction(
+				containerTypeID,
+				declaration.Kind.Keywords(),
+				declaration.FunctionDeclaration,
+			)
+		}
+		for _, declaration := range members.Functions() {
+			addFunction(
+				containerTypeID,
+				declaration.Identifier.Identifier,
+				declaration,
+			)
+		}
+
+		for _, declaration := range members.Composites() {
+			addMembers(elaboration.CompositeDeclarationType(declaration), declaration.Members)
+		}
+		for _, declaration := range members.Attachments() {
+			addMembers(elaboration.CompositeDeclarationType(declaration), declaration.Members)
+		}
+		for _, declaration := range members.Interfaces() {
+			addMembers(elaboration.InterfaceDeclarationType(declaration), declaration.Members)
+		}
+	}
+
+	for _, declaration := range program.Declarations() {
+		switch declaration := declaration.(type) {
+		case *ast.FunctionDeclaration:
+			addFunction("", declaration.Identifier.Identifier, declaration)
+
+		case *ast.CompositeDeclaration:
+			addMembers(elaboration.CompositeDeclarationType(declaration), declaration.Members)
+
+		case *ast.AttachmentDeclaration:
+			addMembers(elaboration.CompositeDeclarationType(declaration), declaration.Members)
+
+		case *ast.InterfaceDeclaration:
+			addMembers(elaboration.InterfaceDeclarationType(declaration), declaration.Members)
+
+		case *ast.TransactionDeclaration:
+			if declaration.Prepare != nil {
+				addFunction("", "prepare", declaration.Prepare.FunctionDeclaration)
+			}
+			if declaration.Execute != nil {
+				addFunction("", "execute", declaration.Execute.FunctionDeclaration)
+			}
+		}
+	}
+
+	return report
+}
+
+type functionEstimate struct {
+	computation    uint64
+	bounded        bool
+	unboundedLoops []UnboundedLoop
+	inProgress     bool
+}
+
+type estimator struct {
+	config          Config
+	elaboration     *sema.Elaboration
+	globalFunctions map[string]*ast.FunctionDeclaration
+	functions       map[*ast.FunctionDeclaration]*functionEstimate
+	// containers are the members of the composites which declare the functions
+	containers map[*ast.FunctionDeclaration]*ast.Members
+	// current is the estimate of the function which is currently estimated
+	current *functionEstimate
+	// currentMembers are the members of the composite which declares the current function, if any
+	currentMembers *ast.Members
+}
+
+func (e *estimator) weight(kind common.ComputationKind) uint64 {
+	return e.config.Weights[kind]
+}
+
+func (e *estimator) function(declaration *ast.FunctionDeclaration) *functionEstimate {
+	estimate, ok := e.functions[declaration]
+	if ok {
+		return estimate
+	}
+
+	estimate = &functionEstimate{
+		bounded:    true,
+		inProgress: true,
+	}
+	e.functions[declaration] = estimate
+
+	previous, previousMembers := e.current, e.currentMembers
+	e.current, e.currentMembers = estimate, e.containers[declaration]
+	defer func() {
+		e.current, e.currentMembers = previous, previousMembers
+	}()
+
+	estimate.computation = e.block(declaration.FunctionBlock.Block)
+	estimate.inProgress = false
+
+	return estimate
+}
+
+func (e *estimator) reportUnbounded(element ast.HasPosition, reason string) {
+	e.current.bounded = false
+	e.current.unboundedLoops = append(
+		e.current.unboundedLoops,
+		UnboundedLoop{
+			Position: element.StartPosition(),
+			Reason:   reason,
+		},
+	)
+}
+
+func (e *estimator) block(block *ast.Block) uint64 {
+	if block == nil {
+		return 0
+	}
+	return e.statements(block.Statements)
+}
+
+func (e *estimator) statements(statements []ast.Statement) (computation uint64) {
+	for _, statement := range statements {
+		computation = add(computation, e.statement(statement))
+	}
+	return
+}
+
+func (e *estimator) statement(statement ast.Statement) uint64 {
+	computation := e.weight(common.ComputationKindStatement)
+
+	switch statement := statement.(type) {
+	case *ast.IfStatement:
+		computation = add(computation, e.element(statement.Test))
+		return add(
+			computation,
+			max(e.block(statement.Then), e.block(statement.Else)),
+		)
+
+	case *ast.SwitchStatement:
+		computation = add(computation, e.element(statement.Expression))
+		var cases uint64
+		for _, switchCase := range statement.Cases {
+			caseComputation := e.statements(switchCase.Statements)
+			if switchCase.Expression != nil {
+				caseComputation = add(caseComputation, e.element(switchCase.Expression))
+			}
+			cases = max(cases, caseComputation)
+		}
+		return add(computation, cases)
+
+	case *ast.WhileStatement:
+		iterations, ok := e.whileIterations(statement)
+		if !ok {
+			e.reportUnbounded(statement, "while loop without a constant bound")
+			iterations = e.config.DefaultLoopIterations
+		}
+		iteration := add(
+			add(e.weight(common.ComputationKindLoop), e.element(statement.Test)),
+			e.block(statement.Block),
+		)
+		return add(computation, mul(iterations, iteration))
+
+	case *ast.ForStatement:
+		computation = add(computation, e.element(statement.Value))
+		iterations, ok := e.forIterations(statement)
+		if !ok {
+			valueType := e.elaboration.ExpressionTypes(statement.Value).ActualType
+			reason := "loop over a collection without a static size"
+			if valueType != nil {
+				reason = fmt.Sprintf(
+					"loop over a collection of type `%s`, which has no static size",
+					valueType.QualifiedString(),
+				)
+			}
+			e.reportUnbounded(statement, reason)
+			iterations = e.config.DefaultLoopIterations
+		}
+		iteration := add(e.weight(common.ComputationKindLoop), e.block(statement.Block))
+		return add(computation, mul(iterations, iteration))
+
+	default:
+		return add(computation, e.element(statement))
+	}
+}
+
+// element returns the computation of the invocations in the given element,
+// e.g. in an expression, or in a statement without nested blocks.
+// Function expressions and nested function declarations are not invoked when they are declared,
+// so they are skipped
+func (e *estimator) element(element ast.Element) (computation uint64) {
+	if element == nil {
+		return 0
+	}
+
+	ast.Inspect(element, func(element ast.Element) bool {
+		switch element := element.(type) {
+		case nil:
+			return false
+
+		case *ast.FunctionExpression, *ast.FunctionDeclaration:
+			return false
+
+		case *ast.InvocationExpression:
+			computation = add(computation, e.weight(common.ComputationKindFunctionInvocation))
+			computation = add(computation, e.invocation(element))
+		}
+
+		return true
+	})
+
+	return
+}
+
+// invocation returns the computation of the function called by the given invocation,
+// if it is a function declared in the program
+func (e *estimator) invocation(invocation *ast.InvocationExpression) uint64 {
+	declaration := e.invokedFunction(invocation.InvokedExpression)
+	if declaration == nil || declaration.FunctionBlock == nil {
+		return 0
+	}
+
+	estimate := e.function(declaration)
+	if estimate.inProgress {
+		e.reportUnbounded(
+			invocation,
+			fmt.Sprintf("recursive call of `%s`", declaration.Identifier.Identifier),
+		)
+		return 0
+	}
+
+	if !estimate.bounded {
+		e.current.bounded = false
+	}
+
+	return estimate.computation
+}
+
+func (e *estimator) invokedFunction(expression ast.Expression) *ast.FunctionDeclaration {
+	switch expression := expression.(type) {
+	case *ast.IdentifierExpression:
+		return e.globalFunctions[expression.Identifier.Identifier]
+
+	case *ast.MemberExpression:
+		if e.currentMembers == nil {
+			return nil
+		}
+
+		identifierExpression, ok := expression.Expression.(*ast.IdentifierExpression)
+		if !ok || identifierExpression.Identifier.Identifier != sema.SelfIdentifier {
+			return nil
+		}
+
+		return e.currentMembers.FunctionsByIdentifier()[expression.Identifier.Identifier]
+	}
+
+	return nil
+}
+
+// forIterations returns the number of iterations of the given for-in loop,
+// if the iterated value has a static size
+func (e *estimator) forIterations(statement *ast.ForStatement) (uint64, bool) {
+	switch value := statement.Value.(type) {
+	case *ast.ArrayExpression:
+		return uint64(len(value.Values)), true
+
+	case *ast.InvocationExpression:
+		return rangeIterations(value)
+	}
+
+	valueType := e.elaboration.ExpressionTypes(statement.Value).ActualType
+	if constantSizedType, ok := valueType.(*sema.ConstantSizedType); ok {
+		return uint64(constantSizedType.Size), true
+	}
+
+	return 0, false
+}
+
+const inclusiveRangeConstructorName = "InclusiveRange"
+
+// rangeIterations returns the number of elements of the given range construction,
+// if its bounds and step are literals, e.g. `InclusiveRange(1, 10, step: 2)`
+func rangeIterations(invocation *ast.InvocationExpression) (uint64, bool) {
+	identifierExpression, ok := invocation.InvokedExpression.(*ast.IdentifierExpression)
+	if !ok || identifierExpression.Identifier.Identifier != inclusiveRangeConstructorName {
+		return 0, false
+	}
+
+	arguments := invocation.Arguments
+	if len(arguments) < 2 || len(arguments) > 3 {
+		return 0, false
+	}
+
+	start, ok := integerLiteral(arguments[0].Expression)
+	if !ok {
+		return 0, false
+	}
+
+	end, ok := integerLiteral(arguments[1].Expression)
+	if !ok {
+		return 0, false
+	}
+
+	step := big.NewInt(1)
+	if len(arguments) == 3 {
+		step, ok = integerLiteral(arguments[2].Expression)
+		if !ok || step.Sign() == 0 {
+			return 0, false
+		}
+	}
+
+	count := new(big.Int).Sub(end, start)
+	count.Abs(count)
+	count.Quo(count, new(big.Int).Abs(step))
+	count.Add(count, big.NewInt(1))
+
+	if !count.IsUint64() {
+		return math.MaxUint64, true
+	}
+	return count.Uint64(), true
+}
+
+// whileIterations returns the number of iterations of the given while loop,
+// if its condition compares with an integer literal, e.g. `i < 10`.
+//
+// NOTE: This is a heuristic, which assumes the loop counts from zero in steps of one
+func (e *estimator) whileIterations(statement *ast.WhileStatement) (uint64, bool) {
+	binaryExpression, ok := statement.Test.(*ast.BinaryExpression)
+	if !ok {
+		return 0, false
+	}
+
+	var bound ast.Expression
+	var inclusive bool
+
+	switch binaryExpression.Operation {
+	case ast.OperationLess:
+		bound = binaryExpression.Right
+	case ast.OperationLessEqual:
+		bound, inclusive = binaryExpression.Right, true
+	case ast.OperationGreater:
+		bound = binaryExpression.Left
+	case ast.OperationGreaterEqual:
+		bound, inclusive = binaryExpression.Left, true
+	default:
+		return 0, false
+	}
+
+	value, ok := integerLiteral(bound)
+	if !ok || value.Sign() < 0 {
+		return 0, false
+	}
+
+	if inclusive {
+		value = new(big.Int).Add(value, big.NewInt(1))
+	}
+
+	if !value.IsUint64() {
+		return math.MaxUint64, true
+	}
+	return value.Uint64(), true
+}
+
+func integerLiteral(expression ast.Expression) (*big.Int, bool) {
+	switch expression := expression.(type) {
+	case *ast.IntegerExpression:
+		return expression.Value, true
+
+	case *ast.UnaryExpression:
+		if expression.Operation != ast.OperationMinus {
+			return nil, false
+		}
+		value, ok := integerLiteral(expression.Expression)
+		if !ok {
+			return nil, false
+		}
+		return new(big.Int).Neg(value), true
+	}
+
+	return nil, false
+}
+
+// add returns the sum of a and b, saturated at the maximum uint64 value
+func add(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
+}
+
+// mul returns the product of a and b, saturated at the maximum uint64 value
+func mul(a, b uint64) uint64 {
+	if a != 0 && b > math.MaxUint64/a {
+		return math.MaxUint64
+	}
+	return a * b
+}
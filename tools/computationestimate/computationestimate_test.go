@@ -0,0 +1,227 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package computationestimate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/sema"
+	"github.com/onflow/cadence/stdlib"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+	"github.com/onflow/cadence/tools/computationestimate"
+)
+
+func analyze(t *testing.T, code string) map[string]computationestimate.Function {
+	baseValueActivation := sema.NewVariableActivation(sema.BaseValueActivation)
+	baseValueActivation.DeclareValue(stdlib.InclusiveRangeConstructorFunction)
+
+	checker, err := ParseAndCheckWithOptions(t,
+		code,
+		ParseAndCheckOptions{
+			Config: &sema.Config{
+				BaseValueActivationHandler: func(_ common.Location) *sema.VariableActivation {
+					return baseValueActivation
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	report := computationestimate.Analyze(
+		checker.Location,
+		checker.Program,
+		checker.Elaboration,
+		computationestimate.Config{
+			DefaultLoopIterations: 10,
+		},
+	)
+
+	functions := map[string]computationestimate.Function{}
+	for _, function := range report.Functions {
+		functions[string(function.ContainerTypeID)+"."+function.Name] = function
+	}
+	return functions
+}
+
+func TestAnalyze(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("straight-line code", func(t *testing.T) {
+
+		t.Parallel()
+
+		functions := analyze(t, `
+          fun f(): Int {
+              let x = 1
+              if x > 0 {
+                  return g()
+              } else {
+                  let y = 2
+                  let z = 3
+                  return y + z
+              }
+          }
+
+          fun g(): Int {
+              return 1
+          }
+        `)
+
+		g := functions[".g"]
+		assert.Equal(t, uint64(1), g.Computation)
+		assert.True(t, g.Bounded)
+
+		// let, if, and the else branch, which is more expensive than the then branch
+		f := functions[".f"]
+		assert.Equal(t, uint64(5), f.Computation)
+		assert.True(t, f.Bounded)
+		assert.Empty(t, f.UnboundedLoops)
+	})
+
+	t.Run("bounded loops", func(t *testing.T) {
+
+		t.Parallel()
+
+		functions := analyze(t, `
+          fun array() {
+              for x in [1, 2, 3] {
+                  let y = x
+              }
+          }
+
+          fun constantSized(xs: [Int; 4]) {
+              for x in xs {
+                  let y = x
+              }
+          }
+
+          fun range() {
+              for x in InclusiveRange(1, 10, step: 3) {
+                  let y = x
+              }
+          }
+
+          fun whileLoop() {
+              var i = 0
+              while i < 5 {
+                  i = i + 1
+              }
+          }
+        `)
+
+		// for statement + iterations * (loop + let)
+		assert.Equal(t, uint64(1+3*2), functions[".array"].Computation)
+		assert.Equal(t, uint64(1+4*2), functions[".constantSized"].Computation)
+		// for statement + InclusiveRange invocation + iterations (1, 4, 7, 10) * (loop + let)
+		assert.Equal(t, uint64(1+1+4*2), functions[".range"].Computation)
+		// var + while statement + iterations * (loop + assignment)
+		assert.Equal(t, uint64(1+1+5*2), functions[".whileLoop"].Computation)
+
+		for name, function := range functions { //nolint:maprange
+			assert.True(t, function.Bounded, name)
+			assert.Empty(t, function.UnboundedLoops, name)
+		}
+	})
+
+	t.Run("unbounded loops", func(t *testing.T) {
+
+		t.Parallel()
+
+		functions := analyze(t, `
+          fun sum(xs: [Int]): Int {
+              var sum = 0
+              for x in xs {
+                  sum = sum + x
+              }
+              return sum
+          }
+
+          fun caller(): Int {
+              return sum(xs: [])
+          }
+
+          fun whileLoop(n: Int) {
+              var i = 0
+              while i < n {
+                  i = i + 1
+              }
+          }
+        `)
+
+		sum := functions[".sum"]
+		assert.False(t, sum.Bounded)
+		// var + for statement + default iterations * (loop + assignment) + return
+		assert.Equal(t, uint64(1+1+10*2+1), sum.Computation)
+		require.Len(t, sum.UnboundedLoops, 1)
+		assert.Equal(t,
+			"loop over a collection of type `[Int]`, which has no static size",
+			sum.UnboundedLoops[0].Reason,
+		)
+		assert.Equal(t, 4, sum.UnboundedLoops[0].Position.Line)
+
+		// The caller is unbounded, but the loop is reported for the callee
+		caller := functions[".caller"]
+		assert.False(t, caller.Bounded)
+		assert.Empty(t, caller.UnboundedLoops)
+		assert.Equal(t, uint64(1+1)+sum.Computation, caller.Computation)
+
+		whileLoop := functions[".whileLoop"]
+		assert.False(t, whileLoop.Bounded)
+		require.Len(t, whileLoop.UnboundedLoops, 1)
+		assert.Equal(t, "while loop without a constant bound", whileLoop.UnboundedLoops[0].Reason)
+	})
+
+	t.Run("recursion and members", func(t *testing.T) {
+
+		t.Parallel()
+
+		functions := analyze(t, `
+          access(all) contract C {
+
+              access(all) fun helper(): Int {
+                  return 1
+              }
+
+              access(all) fun callsHelper(): Int {
+                  return self.helper()
+              }
+
+              access(all) fun recursive(_ n: Int): Int {
+                  return self.recursive(n - 1)
+              }
+          }
+        `)
+
+		const prefix = "S.test.C."
+
+		callsHelper := functions[prefix+"callsHelper"]
+		assert.True(t, callsHelper.Bounded)
+		assert.Equal(t, uint64(1+1+1), callsHelper.Computation)
+
+		recursive := functions[prefix+"recursive"]
+		assert.False(t, recursive.Bounded)
+		require.Len(t, recursive.UnboundedLoops, 1)
+		assert.Equal(t, "recursive call of `recursive`", recursive.UnboundedLoops[0].Reason)
+	})
+}
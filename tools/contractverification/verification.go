@@ -0,0 +1,381 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package contractverification verifies that local contract sources reproduce deployed contracts.
+//
+// Both the local and the deployed code are parsed and pretty-printed,
+// so differences in formatting and comments are ignored.
+// Imports of the local code which refer to contracts by name (e.g. `import "Foo"`)
+// or by file path (e.g. `import Foo from "./Foo.cdc"`) are substituted with address imports,
+// using the configured contract addresses.
+package contractverification
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/parser"
+)
+
+// Source is the local source code of a contract
+type Source struct {
+	// Path is the path of the source file, used for reporting
+	Path string
+	Code []byte
+}
+
+// Config is the configuration of a verification
+type Config struct {
+	// Addresses are the addresses of contracts, by name.
+	// They determine the accounts the local contracts are expected to be deployed to,
+	// and are used to substitute the imports of the local code
+	Addresses map[string]common.Address
+	// GetDeployedCode returns the code of the contract deployed at the given location.
+	// If no contract is deployed, nil is returned
+	GetDeployedCode func(location common.AddressLocation) ([]byte, error)
+}
+
+// Status is the outcome of the verification of a contract
+type Status string
+
+const (
+	// StatusVerified indicates that the local source reproduces the deployed contract
+	StatusVerified Status = "verified"
+	// StatusMismatch indicates that the local source differs from the deployed contract
+	StatusMismatch Status = "mismatch"
+	// StatusNotDeployed indicates that no contract is deployed at the expected location
+	StatusNotDeployed Status = "not deployed"
+	// StatusFailed indicates that the contract could not be verified,
+	// e.g. because the code could not be parsed, or its address is unknown
+	StatusFailed Status = "failed"
+)
+
+// Difference is the first line in which the normalized local and deployed code differ
+type Difference struct {
+	// Line is the line number in the normalized code, starting at 1
+	Line     int
+	Local    string
+	Deployed string
+}
+
+// Result is the result of the verification of a contract
+type Result struct {
+	Path     string
+	Location common.AddressLocation
+	Status   Status
+	// ExactMatch is true if the local and deployed code are identical, without normalization
+	ExactMatch bool `json:",omitempty"`
+	// Difference is the first difference of the normalized codes, if the status is StatusMismatch
+	Difference *Difference `json:",omitempty"`
+	// Error is the reason why the verification failed, if the status is StatusFailed
+	Error string `json:",omitempty"`
+}
+
+// Report is the result of a verification
+type Report struct {
+	// Results are the results of all verified contracts, sorted by location
+	Results []Result
+}
+
+// Verified returns true if all contracts were verified
+func (r *Report) Verified() bool {
+	for _, result := range r.Results {
+		if result.Status != StatusVerified {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify verifies that the given local sources reproduce the deployed contracts.
+// Sources which do not declare a contract or contract interface,
+// e.g. transactions and scripts, are skipped.
+// An error is only returned if the deployed code cannot be retrieved.
+func Verify(sources []Source, config Config) (*Report, error) {
+	report := &Report{}
+
+	for _, source := range sources {
+		result, skip, err := verify(source, config)
+		if err != nil {
+			return nil, err
+		}
+		if skip {
+			continue
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	sort.SliceStable(
+		report.Results,
+		func(i, j int) bool {
+			return report.Results[i].Location.ID() < report.Results[j].Location.ID()
+		},
+	)
+
+	return report, nil
+}
+
+func verify(source Source, config Config) (result Result, skip bool, err error) {
+	result.Path = source.Path
+
+	localProgram, err := parser.ParseProgram(nil, source.Code, parser.Config{})
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = fmt.Sprintf("failed to parse local code: %s", err)
+		return result, false, nil
+	}
+
+	name, ok := contractName(localProgram)
+	if !ok {
+		return result, true, nil
+	}
+
+	address, ok := config.Addresses[name]
+	if !ok {
+		result.Status = StatusFailed
+		result.Error = fmt.Sprintf("unknown address of contract %s", name)
+		return result, false, nil
+	}
+
+	location := common.AddressLocation{
+		Address: address,
+		Name:    name,
+	}
+	result.Location = location
+
+	deployedCode, err := config.GetDeployedCode(location)
+	if err != nil {
+		return result, false, fmt.Errorf("failed to get deployed code of %s: %w", location, err)
+	}
+
+	if len(deployedCode) == 0 {
+		result.Status = StatusNotDeployed
+		return result, false, nil
+	}
+
+	result.ExactMatch = bytes.Equal(source.Code, deployedCode)
+
+	deployedProgram, err := parser.ParseProgram(nil, deployedCode, parser.Config{})
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = fmt.Sprintf("failed to parse deployed code: %s", err)
+		return result, false, nil
+	}
+
+	localCode, err := normalize(localProgram, config.Addresses)
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return result, false, nil
+	}
+
+	// The imports of the deployed code are not substituted,
+	// as they must already refer to the expected addresses
+	normalizedDeployedCode, err := normalize(deployedProgram, nil)
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return result, false, nil
+	}
+
+	result.Difference = difference(localCode, normalizedDeployedCode)
+	if result.Difference != nil {
+		result.Status = StatusMismatch
+	} else {
+		result.Status = StatusVerified
+	}
+
+	return result, false, nil
+}
+
+// contractName returns the name of the contract or contract interface declared by the program, if any
+func contractName(program *ast.Program) (string, bool) {
+	if contractDeclaration := program.SoleContractDeclaration(); contractDeclaration != nil {
+		return contractDeclaration.Identifier.Identifier, true
+	}
+
+	if interfaceDeclaration := program.SoleContractInterfaceDeclaration(); interfaceDeclaration != nil {
+		return interfaceDeclaration.Identifier.Identifier, true
+	}
+
+	return "", false
+}
+
+const normalizedMaxLineWidth = 80
+
+const normalizedIndent = "    "
+
+// normalize pretty-prints the given program.
+//
+// Imports are split into one import per imported identifier, and sorted,
+// so the grouping and order of imports is ignored.
+// If addresses are given, imports of contracts by name or file path are substituted with address imports.
+func normalize(program *ast.Program, addresses map[string]common.Address) (string, error) {
+	var importDocs []string
+	var declarationDocs []prettier.Doc
+
+	for _, declaration := range program.Declarations() {
+		importDeclaration, ok := declaration.(*ast.ImportDeclaration)
+		if !ok {
+			declarationDocs = append(declarationDocs, declaration.Doc())
+			continue
+		}
+
+		imports, err := normalizeImport(importDeclaration, addresses)
+		if err != nil {
+			return "", err
+		}
+
+		for _, normalizedImport := range imports {
+			importDocs = append(importDocs, normalizedImport.String())
+		}
+	}
+
+	sort.Strings(importDocs)
+
+	var builder strings.Builder
+
+	for _, importDoc := range importDocs {
+		builder.WriteString(importDoc)
+		builder.WriteString("\n")
+	}
+
+	if len(importDocs) > 0 && len(declarationDocs) > 0 {
+		builder.WriteString("\n")
+	}
+
+	prettier.Prettier(
+		&builder,
+		prettier.Join(
+			prettier.Concat{
+				prettier.HardLine{},
+				prettier.HardLine{},
+			},
+			declarationDocs...,
+		),
+		normalizedMaxLineWidth,
+		normalizedIndent,
+	)
+
+	return builder.String(), nil
+}
+
+// normalizeImport returns one import declaration for each identifier imported by the given declaration
+func normalizeImport(
+	declaration *ast.ImportDeclaration,
+	addresses map[string]common.Address,
+) ([]*ast.ImportDeclaration, error) {
+
+	identifiers := declaration.Identifiers
+
+	// An import without identifiers, e.g. `import "Foo"`,
+	// imports the contract with the name given by the location
+
+	if len(identifiers) == 0 {
+		switch location := declaration.Location.(type) {
+		case common.StringLocation:
+			identifiers = []ast.Identifier{
+				{Identifier: importedContractName(string(location))},
+			}
+
+		case common.IdentifierLocation:
+			identifiers = []ast.Identifier{
+				{Identifier: string(location)},
+			}
+
+		default:
+			return []*ast.ImportDeclaration{declaration}, nil
+		}
+	}
+
+	imports := make([]*ast.ImportDeclaration, 0, len(identifiers))
+
+	for _, identifier := range identifiers {
+		location := declaration.Location
+
+		if addresses != nil {
+			if address, ok := addresses[identifier.Identifier]; ok {
+				location = common.AddressLocation{
+					Address: address,
+					Name:    identifier.Identifier,
+				}
+			}
+		}
+
+		if _, ok := location.(common.AddressLocation); !ok {
+			return nil, fmt.Errorf(
+				"cannot substitute import of %s from %s: unknown address",
+				identifier.Identifier,
+				ast.LocationDoc(declaration.Location),
+			)
+		}
+
+		imports = append(
+			imports,
+			&ast.ImportDeclaration{
+				Identifiers: []ast.Identifier{
+					{Identifier: identifier.Identifier},
+				},
+				Location: location,
+			},
+		)
+	}
+
+	return imports, nil
+}
+
+// importedContractName returns the name of the contract imported from the given string location,
+// which is either the name of the contract, e.g. "Foo", or a file path, e.g. "./Foo.cdc"
+func importedContractName(location string) string {
+	return strings.TrimSuffix(path.Base(location), ".cdc")
+}
+
+// difference returns the first line in which the given codes differ, if any
+func difference(local, deployed string) *Difference {
+	localLines := strings.Split(local, "\n")
+	deployedLines := strings.Split(deployed, "\n")
+
+	lineCount := max(len(localLines), len(deployedLines))
+
+	for i := 0; i < lineCount; i++ {
+		var localLine, deployedLine string
+		if i < len(localLines) {
+			localLine = localLines[i]
+		}
+		if i < len(deployedLines) {
+			deployedLine = deployedLines[i]
+		}
+
+		if localLine != deployedLine {
+			return &Difference{
+				Line:     i + 1,
+				Local:    localLine,
+				Deployed: deployedLine,
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package contractverification_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/tools/contractverification"
+)
+
+func TestVerify(t *testing.T) {
+
+	t.Parallel()
+
+	address1 := common.MustBytesToAddress([]byte{0x1})
+	address2 := common.MustBytesToAddress([]byte{0x2})
+
+	deployedCodes := map[common.AddressLocation][]byte{
+		{Address: address1, Name: "Foo"}: []byte(`
+          access(all) contract Foo {
+              access(all) fun answer(): Int { return 42 }
+          }
+        `),
+		{Address: address2, Name: "Bar"}: []byte(`
+          import Baz, Foo from 0x1
+
+          access(all) contract Bar {
+              access(all) fun answer(): Int { return Foo.answer() }
+          }
+        `),
+	}
+
+	verify := func(t *testing.T, sources ...contractverification.Source) *contractverification.Report {
+		report, err := contractverification.Verify(
+			sources,
+			contractverification.Config{
+				Addresses: map[string]common.Address{
+					"Foo": address1,
+					"Baz": address1,
+					"Bar": address2,
+				},
+				GetDeployedCode: func(location common.AddressLocation) ([]byte, error) {
+					return deployedCodes[location], nil
+				},
+			},
+		)
+		require.NoError(t, err)
+		return report
+	}
+
+	t.Run("verified, exact match", func(t *testing.T) {
+
+		t.Parallel()
+
+		report := verify(t,
+			contractverification.Source{
+				Path: "Foo.cdc",
+				Code: deployedCodes[common.AddressLocation{Address: address1, Name: "Foo"}],
+			},
+		)
+
+		require.Len(t, report.Results, 1)
+		result := report.Results[0]
+		assert.Equal(t, contractverification.StatusVerified, result.Status)
+		assert.True(t, result.ExactMatch)
+		assert.True(t, report.Verified())
+	})
+
+	t.Run("verified, formatting and imports", func(t *testing.T) {
+
+		t.Parallel()
+
+		report := verify(t,
+			contractverification.Source{
+				Path: "contracts/Bar.cdc",
+				Code: []byte(`
+                  import "Foo"
+                  import Baz from "./Baz.cdc"
+
+                  // A comment
+                  access(all)
+                  contract Bar {
+
+                      access(all) fun answer(): Int {
+                          return Foo.answer()
+                      }
+                  }
+                `),
+			},
+			contractverification.Source{
+				Path: "transactions/test.cdc",
+				Code: []byte(`transaction {}`),
+			},
+		)
+
+		require.Len(t, report.Results, 1)
+		result := report.Results[0]
+		assert.Equal(t, "contracts/Bar.cdc", result.Path)
+		assert.Equal(t,
+			common.AddressLocation{Address: address2, Name: "Bar"},
+			result.Location,
+		)
+		assert.Equal(t, contractverification.StatusVerified, result.Status)
+		assert.False(t, result.ExactMatch)
+		assert.Nil(t, result.Difference)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+
+		t.Parallel()
+
+		report := verify(t,
+			contractverification.Source{
+				Path: "Foo.cdc",
+				Code: []byte(`
+                  access(all) contract Foo {
+                      access(all) fun answer(): Int { return 43 }
+                  }
+                `),
+			},
+		)
+
+		require.Len(t, report.Results, 1)
+		result := report.Results[0]
+		assert.Equal(t, contractverification.StatusMismatch, result.Status)
+		require.NotNil(t, result.Difference)
+		assert.Contains(t, result.Difference.Local, "43")
+		assert.Contains(t, result.Difference.Deployed, "42")
+		assert.False(t, report.Verified())
+	})
+
+	t.Run("verified, substituted import address", func(t *testing.T) {
+
+		t.Parallel()
+
+		report := verify(t,
+			contractverification.Source{
+				Path: "Bar.cdc",
+				Code: []byte(`
+                  import Foo from 0x3
+                  import Baz from 0x1
+
+                  access(all) contract Bar {
+                      access(all) fun answer(): Int { return Foo.answer() }
+                  }
+                `),
+			},
+		)
+
+		require.Len(t, report.Results, 1)
+		assert.Equal(t, contractverification.StatusVerified, report.Results[0].Status)
+	})
+
+	t.Run("not deployed", func(t *testing.T) {
+
+		t.Parallel()
+
+		report := verify(t,
+			contractverification.Source{
+				Path: "Baz.cdc",
+				Code: []byte(`access(all) contract Baz {}`),
+			},
+		)
+
+		require.Len(t, report.Results, 1)
+		assert.Equal(t, contractverification.StatusNotDeployed, report.Results[0].Status)
+	})
+
+	t.Run("failed, unknown address", func(t *testing.T) {
+
+		t.Parallel()
+
+		report := verify(t,
+			contractverification.Source{
+				Path: "Qux.cdc",
+				Code: []byte(`access(all) contract Qux {}`),
+			},
+		)
+
+		require.Len(t, report.Results, 1)
+		result := report.Results[0]
+		assert.Equal(t, contractverification.StatusFailed, result.Status)
+		assert.Contains(t, result.Error, "unknown address")
+	})
+
+	t.Run("failed, unresolved import", func(t *testing.T) {
+
+		t.Parallel()
+
+		report := verify(t,
+			contractverification.Source{
+				Path: "Foo.cdc",
+				Code: []byte(`
+                  import "Qux"
+
+                  access(all) contract Foo {
+                      access(all) fun answer(): Int { return 42 }
+                  }
+                `),
+			},
+		)
+
+		require.Len(t, report.Results, 1)
+		result := report.Results[0]
+		assert.Equal(t, contractverification.StatusFailed, result.Status)
+		assert.Contains(t, result.Error, "cannot substitute import of Qux")
+	})
+}
@@ -0,0 +1,407 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package editorgrammar generates grammars for editors from the parser's grammar metadata,
+// i.e. from its keywords, operators, and punctuation,
+// so the grammars used for syntax highlighting do not drift from the language.
+//
+// It generates TextMate grammars, used e.g. by Visual Studio Code,
+// and tree-sitter highlight queries.
+package editorgrammar
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/onflow/cadence/parser"
+)
+
+const scopeSuffix = ".cadence"
+
+// keywordScopes are the TextMate scopes of keywords which are not declaration or modifier keywords,
+// which have the scope keywordScopeDefault
+var keywordScopes = map[string]string{
+	parser.KeywordIf:       "keyword.control",
+	parser.KeywordElse:     "keyword.control",
+	parser.KeywordWhile:    "keyword.control",
+	parser.KeywordFor:      "keyword.control",
+	parser.KeywordIn:       "keyword.control",
+	parser.KeywordBreak:    "keyword.control",
+	parser.KeywordContinue: "keyword.control",
+	parser.KeywordReturn:   "keyword.control",
+	parser.KeywordSwitch:   "keyword.control",
+	parser.KeywordCase:     "keyword.control",
+	parser.KeywordDefault:  "keyword.control",
+	parser.KeywordTrue:     "constant.language",
+	parser.KeywordFalse:    "constant.language",
+	parser.KeywordNil:      "constant.language",
+	parser.KeywordSelf:     "variable.language",
+	parser.KeywordAs:       "keyword.operator",
+}
+
+const keywordScopeDefault = "keyword.other"
+
+// bracketScopes are the TextMate scopes of punctuation which is not an operator,
+// which has the scope operatorScope
+var bracketScopes = map[string]string{
+	"(": "punctuation.section.parens",
+	")": "punctuation.section.parens",
+	"{": "punctuation.section.braces",
+	"}": "punctuation.section.braces",
+	"[": "punctuation.section.brackets",
+	"]": "punctuation.section.brackets",
+	",": "punctuation.separator",
+	";": "punctuation.terminator",
+	":": "punctuation.separator",
+	".": "punctuation.accessor",
+}
+
+const operatorScope = "keyword.operator"
+
+// TextMateRule is a rule of a TextMate grammar
+type TextMateRule struct {
+	Name     string                  `json:"name,omitempty"`
+	Match    string                  `json:"match,omitempty"`
+	Begin    string                  `json:"begin,omitempty"`
+	End      string                  `json:"end,omitempty"`
+	Include  string                  `json:"include,omitempty"`
+	Captures map[string]TextMateRule `json:"captures,omitempty"`
+	Patterns []TextMateRule          `json:"patterns,omitempty"`
+}
+
+// TextMateGrammar is a TextMate grammar
+type TextMateGrammar struct {
+	Name       string                  `json:"name"`
+	ScopeName  string                  `json:"scopeName"`
+	FileTypes  []string                `json:"fileTypes"`
+	Patterns   []TextMateRule          `json:"patterns"`
+	Repository map[string]TextMateRule `json:"repository"`
+}
+
+// NewTextMateGrammar returns a TextMate grammar for the given parser grammar.
+//
+// Hard keywords are always highlighted as keywords.
+// Contextual keywords are not highlighted, as they may be used as identifiers anywhere
+func NewTextMateGrammar(grammar *parser.Grammar) *TextMateGrammar {
+	return &TextMateGrammar{
+		Name:      "Cadence",
+		ScopeName: "source.cadence",
+		FileTypes: []string{"cdc"},
+		Patterns: []TextMateRule{
+			{Include: "#comments"},
+			{Include: "#strings"},
+			{Include: "#numbers"},
+			{Include: "#declarations"},
+			{Include: "#keywords"},
+			{Include: "#operators"},
+			{Include: "#punctuation"},
+		},
+		Repository: map[string]TextMateRule{
+			"comments":     commentsRule(),
+			"strings":      stringsRule(),
+			"numbers":      numbersRule(),
+			"declarations": declarationsRule(),
+			"keywords":     keywordsRule(grammar),
+			"operators":    operatorsRule(grammar),
+			"punctuation":  punctuationRule(grammar),
+		},
+	}
+}
+
+func commentsRule() TextMateRule {
+	return TextMateRule{
+		Patterns: []TextMateRule{
+			{
+				Name:  "comment.line.documentation" + scopeSuffix,
+				Match: `///.*$`,
+			},
+			{
+				Name:  "comment.line.double-slash" + scopeSuffix,
+				Match: `//.*$`,
+			},
+			{
+				// Block comments may be nested
+				Name:  "comment.block" + scopeSuffix,
+				Begin: `/\*`,
+				End:   `\*/`,
+				Patterns: []TextMateRule{
+					{Include: "#comments"},
+				},
+			},
+		},
+	}
+}
+
+func stringsRule() TextMateRule {
+	escapes := TextMateRule{
+		Name:  "constant.character.escape" + scopeSuffix,
+		Match: `\\(?:[0\\tnr"']|u\{[0-9a-fA-F]+\})`,
+	}
+
+	return TextMateRule{
+		Patterns: []TextMateRule{
+			{
+				// Raw strings have no escapes or templates, and may span multiple lines
+				Name:  "string.quoted.triple" + scopeSuffix,
+				Begin: `"""`,
+				End:   `"""`,
+			},
+			{
+				Name:  "string.quoted.double" + scopeSuffix,
+				Begin: `"`,
+				End:   `"`,
+				Patterns: []TextMateRule{
+					escapes,
+					{
+						Name:  "meta.template.expression" + scopeSuffix,
+						Begin: `\\\(`,
+						End:   `\)`,
+						Patterns: []TextMateRule{
+							{Include: "$self"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func numbersRule() TextMateRule {
+	return TextMateRule{
+		Patterns: []TextMateRule{
+			{
+				Name:  "constant.numeric.integer.binary" + scopeSuffix,
+				Match: `\b0b[01_]+\b`,
+			},
+			{
+				Name:  "constant.numeric.integer.octal" + scopeSuffix,
+				Match: `\b0o[0-7_]+\b`,
+			},
+			{
+				Name:  "constant.numeric.integer.hexadecimal" + scopeSuffix,
+				Match: `\b0x[0-9a-fA-F_]+\b`,
+			},
+			{
+				Name:  "constant.numeric.decimal" + scopeSuffix,
+				Match: `\b[0-9][0-9_]*\.[0-9][0-9_]*\b`,
+			},
+			{
+				Name:  "constant.numeric.integer.decimal" + scopeSuffix,
+				Match: `\b[0-9][0-9_]*\b`,
+			},
+		},
+	}
+}
+
+const identifierPattern = `[A-Za-z_][A-Za-z0-9_]*`
+
+func declarationsRule() TextMateRule {
+	return TextMateRule{
+		Patterns: []TextMateRule{
+			{
+				Match: fmt.Sprintf(`\b(%s)\s+(%s)`, parser.KeywordFun, identifierPattern),
+				Captures: map[string]TextMateRule{
+					"1": {Name: keywordScopeDefault + scopeSuffix},
+					"2": {Name: "entity.name.function" + scopeSuffix},
+				},
+			},
+			{
+				Match: fmt.Sprintf(
+					`\b(%s)\s+(%s)`,
+					alternatives([]string{
+						parser.KeywordStruct,
+						parser.KeywordResource,
+						parser.KeywordContract,
+						parser.KeywordEnum,
+						parser.KeywordEvent,
+						parser.KeywordAttachment,
+						parser.KeywordEntitlement,
+					}),
+					identifierPattern,
+				),
+				Captures: map[string]TextMateRule{
+					"1": {Name: keywordScopeDefault + scopeSuffix},
+					"2": {Name: "entity.name.type" + scopeSuffix},
+				},
+			},
+		},
+	}
+}
+
+func keywordsRule(grammar *parser.Grammar) TextMateRule {
+
+	// Group the hard keywords by scope
+
+	keywordsByScope := map[string][]string{}
+
+	for _, keyword := range grammar.Keywords {
+		if keyword.Kind != parser.GrammarKeywordKindHard {
+			continue
+		}
+
+		scope, ok := keywordScopes[keyword.Keyword]
+		if !ok {
+			scope = keywordScopeDefault
+		}
+
+		keywordsByScope[scope] = append(keywordsByScope[scope], keyword.Keyword)
+	}
+
+	scopes := make([]string, 0, len(keywordsByScope))
+	for scope := range keywordsByScope { //nolint:maprange
+		scopes = append(scopes, scope)
+	}
+	slices.Sort(scopes)
+
+	var patterns []TextMateRule
+
+	for _, scope := range scopes {
+		patterns = append(patterns, TextMateRule{
+			Name:  scope + scopeSuffix,
+			Match: fmt.Sprintf(`\b%s\b`, alternatives(keywordsByScope[scope])),
+		})
+	}
+
+	return TextMateRule{
+		Patterns: patterns,
+	}
+}
+
+func operatorsRule(grammar *parser.Grammar) TextMateRule {
+	var operators []string
+
+	for _, symbol := range grammar.Punctuation {
+		if _, ok := bracketScopes[symbol]; ok {
+			continue
+		}
+		operators = append(operators, symbol)
+	}
+
+	return TextMateRule{
+		Name:  operatorScope + scopeSuffix,
+		Match: alternatives(operators),
+	}
+}
+
+func punctuationRule(grammar *parser.Grammar) TextMateRule {
+	var patterns []TextMateRule
+
+	for _, symbol := range grammar.Punctuation {
+		scope, ok := bracketScopes[symbol]
+		if !ok {
+			continue
+		}
+		patterns = append(patterns, TextMateRule{
+			Name:  scope + scopeSuffix,
+			Match: regexp.QuoteMeta(symbol),
+		})
+	}
+
+	return TextMateRule{
+		Patterns: patterns,
+	}
+}
+
+// alternatives returns a regular expression group which matches any of the given literal strings.
+// Longer strings are matched first, so e.g. `<-!` is matched before `<-`
+func alternatives(literals []string) string {
+	sorted := slices.Clone(literals)
+	slices.SortStableFunc(sorted, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return strings.Compare(a, b)
+	})
+
+	quoted := make([]string, 0, len(sorted))
+	for _, literal := range sorted {
+		quoted = append(quoted, regexp.QuoteMeta(literal))
+	}
+
+	return "(?:" + strings.Join(quoted, "|") + ")"
+}
+
+// TreeSitterHighlights returns tree-sitter highlight queries (`highlights.scm`)
+// for the keywords, operators, and punctuation of the given parser grammar.
+//
+// The queries match anonymous nodes, i.e. they assume that the tree-sitter grammar
+// uses the same literal tokens as the parser
+func TreeSitterHighlights(grammar *parser.Grammar) string {
+	var builder strings.Builder
+
+	writeGroup := func(capture string, literals []string) {
+		if len(literals) == 0 {
+			return
+		}
+
+		builder.WriteString("[\n")
+		for _, literal := range literals {
+			builder.WriteString("  ")
+			builder.WriteString(treeSitterString(literal))
+			builder.WriteString("\n")
+		}
+		builder.WriteString("] @")
+		builder.WriteString(capture)
+		builder.WriteString("\n\n")
+	}
+
+	var keywords, controlKeywords, constants []string
+
+	for _, keyword := range grammar.Keywords {
+		if keyword.Kind != parser.GrammarKeywordKindHard {
+			continue
+		}
+
+		switch keywordScopes[keyword.Keyword] {
+		case "keyword.control":
+			controlKeywords = append(controlKeywords, keyword.Keyword)
+		case "constant.language":
+			constants = append(constants, keyword.Keyword)
+		case "variable.language":
+			// `self` is an identifier in the tree
+			continue
+		default:
+			keywords = append(keywords, keyword.Keyword)
+		}
+	}
+
+	var operators, punctuation []string
+
+	for _, symbol := range grammar.Punctuation {
+		if _, ok := bracketScopes[symbol]; ok {
+			punctuation = append(punctuation, symbol)
+		} else {
+			operators = append(operators, symbol)
+		}
+	}
+
+	writeGroup("keyword", keywords)
+	writeGroup("keyword.control", controlKeywords)
+	writeGroup("constant.builtin", constants)
+	writeGroup("operator", operators)
+	writeGroup("punctuation", punctuation)
+
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+func treeSitterString(literal string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + replacer.Replace(literal) + `"`
+}
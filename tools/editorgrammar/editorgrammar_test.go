@@ -0,0 +1,149 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package editorgrammar
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/parser"
+)
+
+// matchingScopes returns the scopes of all rules of the TextMate grammar
+// which match the whole given text
+func matchingScopes(t *testing.T, rule TextMateRule, text string) (scopes []string) {
+	if rule.Match != "" {
+		pattern, err := regexp.Compile(`^(?:` + rule.Match + `)$`)
+		require.NoError(t, err)
+
+		if pattern.MatchString(text) {
+			scopes = append(scopes, rule.Name)
+		}
+	}
+
+	for _, nested := range rule.Patterns {
+		scopes = append(scopes, matchingScopes(t, nested, text)...)
+	}
+
+	return
+}
+
+func TestTextMateGrammar(t *testing.T) {
+
+	t.Parallel()
+
+	grammar := parser.NewGrammar()
+	textMateGrammar := NewTextMateGrammar(grammar)
+
+	t.Run("keywords", func(t *testing.T) {
+		t.Parallel()
+
+		keywords := textMateGrammar.Repository["keywords"]
+
+		for _, keyword := range grammar.Keywords {
+			scopes := matchingScopes(t, keywords, keyword.Keyword)
+
+			switch keyword.Kind {
+			case parser.GrammarKeywordKindHard:
+				assert.Len(t, scopes, 1, keyword.Keyword)
+			case parser.GrammarKeywordKindContextual:
+				assert.Empty(t, scopes, keyword.Keyword)
+			}
+		}
+
+		assert.Equal(t,
+			[]string{"keyword.control.cadence"},
+			matchingScopes(t, keywords, "while"),
+		)
+		assert.Equal(t,
+			[]string{"constant.language.cadence"},
+			matchingScopes(t, keywords, "nil"),
+		)
+		assert.Empty(t, matchingScopes(t, keywords, "foo"))
+	})
+
+	t.Run("operators and punctuation", func(t *testing.T) {
+		t.Parallel()
+
+		operators := textMateGrammar.Repository["operators"]
+		punctuation := textMateGrammar.Repository["punctuation"]
+
+		for _, symbol := range grammar.Punctuation {
+			scopes := append(
+				matchingScopes(t, operators, symbol),
+				matchingScopes(t, punctuation, symbol)...,
+			)
+			assert.Len(t, scopes, 1, symbol)
+		}
+
+		assert.Equal(t,
+			[]string{"keyword.operator.cadence"},
+			matchingScopes(t, operators, "<-!"),
+		)
+		assert.Equal(t,
+			[]string{"punctuation.section.braces.cadence"},
+			matchingScopes(t, punctuation, "{"),
+		)
+	})
+
+	t.Run("numbers", func(t *testing.T) {
+		t.Parallel()
+
+		numbers := textMateGrammar.Repository["numbers"]
+
+		for text, scope := range map[string]string{ //nolint:maprange
+			"0b101":   "constant.numeric.integer.binary.cadence",
+			"0o17":    "constant.numeric.integer.octal.cadence",
+			"0xFF_00": "constant.numeric.integer.hexadecimal.cadence",
+			"1_000":   "constant.numeric.integer.decimal.cadence",
+			"1.5":     "constant.numeric.decimal.cadence",
+		} {
+			assert.Contains(t, matchingScopes(t, numbers, text), scope, text)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		encoded, err := json.Marshal(textMateGrammar)
+		require.NoError(t, err)
+
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(encoded, &decoded))
+
+		assert.Equal(t, "source.cadence", decoded["scopeName"])
+	})
+}
+
+func TestTreeSitterHighlights(t *testing.T) {
+
+	t.Parallel()
+
+	highlights := TreeSitterHighlights(parser.NewGrammar())
+
+	assert.Contains(t, highlights, "\n  \"fun\"\n")
+	assert.Contains(t, highlights, "] @keyword.control\n")
+	assert.Contains(t, highlights, "\n  \"<-!\"\n")
+	assert.Contains(t, highlights, "\n  \"\\\\(\"\n")
+	assert.NotContains(t, highlights, "\"from\"")
+}
@@ -0,0 +1,234 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package entitlementflow reports the authorization surface of the contracts of a program.
+//
+// For each function which can be called from outside of its contract,
+// i.e. each function with `access(all)` or entitlement access,
+// it reports which entitlements are required to call the function,
+// and which entitlements the function may propagate
+// through the references and capabilities it returns.
+package entitlementflow
+
+import (
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/sema"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+// Authorization is a set of entitlements
+type Authorization struct {
+	// Entitlements are the type IDs of the entitlements, in declaration order
+	Entitlements []common.TypeID `json:"entitlements,omitempty"`
+	// Disjunction is true if any one of the entitlements is sufficient,
+	// and false if all entitlements are required
+	Disjunction bool `json:"disjunction,omitempty"`
+	// Mapping is the type ID of the entitlement mapping, if the authorization is mapped.
+	// The entitlements are then the result of applying the mapping
+	// to the entitlements the function is called with
+	Mapping common.TypeID `json:"mapping,omitempty"`
+}
+
+// ReturnedAuthorization is an authorized reference type
+// which occurs in the return type of a function,
+// e.g. directly, in an optional, or as the borrow type of a capability
+type ReturnedAuthorization struct {
+	Authorization
+	// Type is the authorized reference type, e.g. `auth(E) &R`
+	Type string `json:"type"`
+}
+
+// Function is the entitlement flow of a function
+type Function struct {
+	// ContainerTypeID is the type ID of the composite or interface type which declares the function
+	ContainerTypeID common.TypeID `json:"containerTypeID"`
+	Name            string        `json:"name"`
+	Position        ast.Position  `json:"position"`
+	// Access is the declared access of the function, e.g. `access(all)`
+	Access string `json:"access"`
+	// Required is the authorization required to call the function,
+	// or nil if the function has `access(all)`
+	Required *Authorization `json:"required,omitempty"`
+	// Returned are the authorizations which may be propagated through the function's result
+	Returned []ReturnedAuthorization `json:"returned,omitempty"`
+}
+
+// Report is the entitlement flow of a program
+type Report struct {
+	Location  string     `json:"location"`
+	Functions []Function `json:"functions"`
+}
+
+// Analyzer reports the entitlement flow of the analyzed program as its result
+var Analyzer = &analysis.Analyzer{
+	Description: "Reports the entitlements required by functions, and the entitlements they may return",
+	Run: func(pass *analysis.Pass) interface{} {
+		program := pass.Program
+		return Analyze(program.Location, program.Program, program.Checker.Elaboration)
+	},
+}
+
+// Analyze returns the entitlement flow of the given checked program.
+// Functions are reported in declaration order
+func Analyze(
+	location common.Location,
+	program *ast.Program,
+	elaboration *sema.Elaboration,
+) *Report {
+
+	report := &Report{
+		Location:  location.String(),
+		Functions: []Function{},
+	}
+
+	visit := func(ty sema.Type) {
+		var members *sema.StringMemberOrderedMap
+
+		switch ty := ty.(type) {
+		case *sema.CompositeType:
+			members = ty.Members
+		case *sema.InterfaceType:
+			members = ty.Members
+		default:
+			return
+		}
+
+		if members == nil {
+			return
+		}
+
+		members.Foreach(func(_ string, member *sema.Member) {
+			// Skip built-in functions, like getType
+			if member.DeclarationKind != common.DeclarationKindFunction ||
+				member.Predeclared {
+
+				return
+			}
+
+			function, ok := analyzeFunction(ty, member)
+			if ok {
+				report.Functions = append(report.Functions, function)
+			}
+		})
+	}
+
+	for _, declaration := range program.Declarations() {
+		var ty sema.Type
+
+		switch declaration := declaration.(type) {
+		case *ast.CompositeDeclaration:
+			ty = elaboration.CompositeDeclarationType(declaration)
+		case *ast.AttachmentDeclaration:
+			ty = elaboration.CompositeDeclarationType(declaration)
+		case *ast.InterfaceDeclaration:
+			ty = elaboration.InterfaceDeclarationType(declaration)
+		}
+
+		if ty == nil {
+			continue
+		}
+
+		sema.VisitThisAndNested(ty, visit)
+	}
+
+	return report
+}
+
+func analyzeFunction(containerType sema.Type, member *sema.Member) (Function, bool) {
+
+	var required *Authorization
+
+	switch access := member.Access.(type) {
+	case sema.PrimitiveAccess:
+		if access != sema.PrimitiveAccess(ast.AccessAll) {
+			return Function{}, false
+		}
+
+	default:
+		required = newAuthorization(access)
+	}
+
+	function := Function{
+		ContainerTypeID: containerType.ID(),
+		Name:            member.Identifier.Identifier,
+		Position:        member.Identifier.Pos,
+		Access:          member.Access.QualifiedKeyword(),
+		Required:        required,
+	}
+
+	functionType, ok := member.TypeAnnotation.Type.(*sema.FunctionType)
+	if ok {
+		function.Returned = returnedAuthorizations(functionType.ReturnTypeAnnotation.Type)
+	}
+
+	return function, true
+}
+
+// returnedAuthorizations returns the authorizations of all authorized reference types
+// which occur in the given type
+func returnedAuthorizations(ty sema.Type) (result []ReturnedAuthorization) {
+	ty.Map(
+		nil,
+		map[*sema.TypeParameter]*sema.TypeParameter{},
+		func(ty sema.Type) sema.Type {
+			referenceType, ok := ty.(*sema.ReferenceType)
+			if !ok {
+				return ty
+			}
+
+			authorization := newAuthorization(referenceType.Authorization)
+			if authorization == nil {
+				return ty
+			}
+
+			result = append(
+				result,
+				ReturnedAuthorization{
+					Authorization: *authorization,
+					Type:          referenceType.QualifiedString(),
+				},
+			)
+
+			return ty
+		},
+	)
+	return
+}
+
+// newAuthorization returns the authorization for the given access,
+// or nil if the access is not an entitlement access
+func newAuthorization(access sema.Access) *Authorization {
+	switch access := access.(type) {
+	case sema.EntitlementSetAccess:
+		authorization := &Authorization{
+			Disjunction: access.SetKind == sema.Disjunction,
+		}
+		access.Entitlements.Foreach(func(entitlementType *sema.EntitlementType, _ struct{}) {
+			authorization.Entitlements = append(authorization.Entitlements, entitlementType.ID())
+		})
+		return authorization
+
+	case *sema.EntitlementMapAccess:
+		return &Authorization{
+			Mapping: access.Type.ID(),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entitlementflow_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	. "github.com/onflow/cadence/test_utils/sema_utils"
+	"github.com/onflow/cadence/tools/entitlementflow"
+)
+
+func TestAnalyze(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      access(all) contract C {
+
+          access(all) entitlement E
+          access(all) entitlement F
+
+          access(all) entitlement mapping M {
+              E -> F
+          }
+
+          access(all) resource interface I {
+              access(E | F) fun withdraw(): @R
+          }
+
+          access(all) resource R: I {
+
+              access(mapping M) let nested: auth(mapping M) &R?
+
+              init() {
+                  self.nested = nil
+              }
+
+              access(E | F) fun withdraw(): @R {
+                  return <- create R()
+              }
+
+              access(E, F) fun borrow(): auth(E) &R {
+                  return &self as auth(E) &R
+              }
+
+              access(mapping M) fun mapped(): auth(mapping M) &R? {
+                  return self.nested
+              }
+
+              access(all) fun capabilities(): [Capability<auth(F) &R>] {
+                  return []
+              }
+
+              access(all) fun unauthorized(): &R {
+                  return &self
+              }
+
+              access(self) fun selfOnly() {}
+
+              access(contract) fun contractOnly() {}
+          }
+      }
+    `)
+	require.NoError(t, err)
+
+	report := entitlementflow.Analyze(
+		checker.Location,
+		checker.Program,
+		checker.Elaboration,
+	)
+
+	entitlementsEOrF := &entitlementflow.Authorization{
+		Entitlements: []common.TypeID{"S.test.C.E", "S.test.C.F"},
+		Disjunction:  true,
+	}
+
+	assert.Equal(t,
+		&entitlementflow.Report{
+			Location: "test",
+			Functions: []entitlementflow.Function{
+				{
+					ContainerTypeID: "S.test.C.I",
+					Name:            "withdraw",
+					Position:        ast.Position{Offset: 263, Line: 12, Column: 32},
+					Access:          "access(C.E | C.F)",
+					Required:        entitlementsEOrF,
+				},
+				{
+					ContainerTypeID: "S.test.C.R",
+					Name:            "withdraw",
+					Position:        ast.Position{Offset: 503, Line: 23, Column: 32},
+					Access:          "access(C.E | C.F)",
+					Required:        entitlementsEOrF,
+				},
+				{
+					ContainerTypeID: "S.test.C.R",
+					Name:            "borrow",
+					Position:        ast.Position{Offset: 607, Line: 27, Column: 31},
+					Access:          "access(C.E, C.F)",
+					Required: &entitlementflow.Authorization{
+						Entitlements: []common.TypeID{"S.test.C.E", "S.test.C.F"},
+					},
+					Returned: []entitlementflow.ReturnedAuthorization{
+						{
+							Authorization: entitlementflow.Authorization{
+								Entitlements: []common.TypeID{"S.test.C.E"},
+							},
+							Type: "auth(C.E) &C.R",
+						},
+					},
+				},
+				{
+					ContainerTypeID: "S.test.C.R",
+					Name:            "mapped",
+					Position:        ast.Position{Offset: 728, Line: 31, Column: 36},
+					Access:          "access(mapping C.M)",
+					Required: &entitlementflow.Authorization{
+						Mapping: "S.test.C.M",
+					},
+					Returned: []entitlementflow.ReturnedAuthorization{
+						{
+							Authorization: entitlementflow.Authorization{
+								Mapping: "S.test.C.M",
+							},
+							Type: "auth(mapping C.M) &C.R",
+						},
+					},
+				},
+				{
+					ContainerTypeID: "S.test.C.R",
+					Name:            "capabilities",
+					Position:        ast.Position{Offset: 844, Line: 35, Column: 30},
+					Access:          "access(all)",
+					Returned: []entitlementflow.ReturnedAuthorization{
+						{
+							Authorization: entitlementflow.Authorization{
+								Entitlements: []common.TypeID{"S.test.C.F"},
+							},
+							Type: "auth(C.F) &C.R",
+						},
+					},
+				},
+				{
+					ContainerTypeID: "S.test.C.R",
+					Name:            "unauthorized",
+					Position:        ast.Position{Offset: 962, Line: 39, Column: 30},
+					Access:          "access(all)",
+				},
+			},
+		},
+		report,
+	)
+
+	encoded, err := json.Marshal(report.Functions[2])
+	require.NoError(t, err)
+
+	assert.JSONEq(t,
+		`
+          {
+            "containerTypeID": "S.test.C.R",
+            "name": "borrow",
+            "position": {"Offset": 607, "Line": 27, "Column": 31},
+            "access": "access(C.E, C.F)",
+            "required": {
+              "entitlements": ["S.test.C.E", "S.test.C.F"]
+            },
+            "returned": [
+              {
+                "entitlements": ["S.test.C.E"],
+                "type": "auth(C.E) &C.R"
+              }
+            ]
+          }
+        `,
+		string(encoded),
+	)
+}
@@ -0,0 +1,69 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fork
+
+import (
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/overlay"
+	"github.com/onflow/cadence/tools/snapshot"
+)
+
+// Ledger is a ledger which reads from a snapshot, and writes to an overlay.
+// Registers written to the overlay shadow the registers of the snapshot,
+// which is never modified.
+//
+// A ledger is itself a snapshot, so it can be forked again, see Fork.
+type Ledger struct {
+	*overlay.Ledger
+}
+
+// NewLedger returns a new ledger which forks the given snapshot
+func NewLedger(base overlay.Snapshot) *Ledger {
+	return &Ledger{
+		Ledger: overlay.NewLedger(base),
+	}
+}
+
+// Fork returns a new ledger which forks this ledger.
+// Writes to the new ledger do not affect this ledger
+func (l *Ledger) Fork() *Ledger {
+	return NewLedger(l)
+}
+
+// NewStorage returns a new storage backed by this ledger
+func (l *Ledger) NewStorage(
+	memoryGauge common.MemoryGauge,
+	config runtime.StorageConfig,
+) *runtime.Storage {
+	return runtime.NewStorage(l, memoryGauge, config)
+}
+
+// Writes returns the registers written to the overlay, sorted by owner and key.
+// Registers which were removed have an empty value
+func (l *Ledger) Writes() []snapshot.Register {
+	writes := l.Ledger.Writes()
+
+	registers := make([]snapshot.Register, 0, len(writes))
+	for _, write := range writes {
+		registers = append(registers, snapshot.Register(write))
+	}
+
+	return registers
+}
@@ -0,0 +1,291 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fork
+
+import (
+	"testing"
+
+	"github.com/onflow/atree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/overlay"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+	"github.com/onflow/cadence/tools/snapshot"
+)
+
+func TestForkedLedger(t *testing.T) {
+
+	t.Parallel()
+
+	owner := common.MustBytesToAddress([]byte{0x1})
+
+	newBase := func() *Registers {
+		registers := NewRegisters()
+		registers.Add(snapshot.Register{
+			Owner: owner,
+			Key:   "a",
+			Value: []byte{1},
+		})
+		registers.Add(snapshot.Register{
+			Owner: owner,
+			Key:   "b",
+			Value: []byte{2},
+		})
+		registers.Add(snapshot.Register{
+			Owner: owner,
+			Key:   string(atree.SlabIndexToLedgerKey(atree.SlabIndex{0, 0, 0, 0, 0, 0, 0, 5})),
+			Value: []byte{3},
+		})
+		return registers
+	}
+
+	getValue := func(t *testing.T, snapshot overlay.Snapshot, key string) []byte {
+		value, err := snapshot.GetValue(owner[:], []byte(key))
+		require.NoError(t, err)
+		return value
+	}
+
+	t.Run("overlay", func(t *testing.T) {
+
+		t.Parallel()
+
+		base := newBase()
+		ledger := NewLedger(base)
+
+		assert.Equal(t, []byte{1}, getValue(t, ledger, "a"))
+
+		require.NoError(t, ledger.SetValue(owner[:], []byte("a"), []byte{4}))
+		require.NoError(t, ledger.SetValue(owner[:], []byte("b"), nil))
+		require.NoError(t, ledger.SetValue(owner[:], []byte("c"), []byte{5}))
+
+		assert.Equal(t, []byte{4}, getValue(t, ledger, "a"))
+		assert.Empty(t, getValue(t, ledger, "b"))
+		assert.Equal(t, []byte{5}, getValue(t, ledger, "c"))
+
+		exists, err := ledger.ValueExists(owner[:], []byte("b"))
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		// The snapshot is not modified
+
+		assert.Equal(t, []byte{1}, getValue(t, base, "a"))
+		assert.Equal(t, []byte{2}, getValue(t, base, "b"))
+		assert.Empty(t, getValue(t, base, "c"))
+
+		assert.Equal(t,
+			[]snapshot.Register{
+				{Owner: owner, Key: "a", Value: []byte{4}},
+				{Owner: owner, Key: "b", Value: nil},
+				{Owner: owner, Key: "c", Value: []byte{5}},
+			},
+			ledger.Writes(),
+		)
+
+		ledger.Reset()
+
+		assert.Empty(t, ledger.Writes())
+		assert.Equal(t, []byte{1}, getValue(t, ledger, "a"))
+	})
+
+	t.Run("slab indices", func(t *testing.T) {
+
+		t.Parallel()
+
+		ledger := NewLedger(newBase())
+
+		slabIndex, err := ledger.AllocateSlabIndex(owner[:])
+		require.NoError(t, err)
+		assert.Equal(t, atree.SlabIndex{0, 0, 0, 0, 0, 0, 0, 6}, slabIndex)
+
+		slabIndex, err = ledger.AllocateSlabIndex(owner[:])
+		require.NoError(t, err)
+		assert.Equal(t, atree.SlabIndex{0, 0, 0, 0, 0, 0, 0, 7}, slabIndex)
+
+		// Accounts without slabs in the snapshot start at 1
+
+		other := common.MustBytesToAddress([]byte{0x2})
+
+		slabIndex, err = ledger.AllocateSlabIndex(other[:])
+		require.NoError(t, err)
+		assert.Equal(t, atree.SlabIndex{0, 0, 0, 0, 0, 0, 0, 1}, slabIndex)
+	})
+
+	t.Run("fork", func(t *testing.T) {
+
+		t.Parallel()
+
+		ledger := NewLedger(newBase())
+		require.NoError(t, ledger.SetValue(owner[:], []byte("a"), []byte{4}))
+
+		_, err := ledger.AllocateSlabIndex(owner[:])
+		require.NoError(t, err)
+
+		fork := ledger.Fork()
+		require.NoError(t, fork.SetValue(owner[:], []byte("b"), []byte{5}))
+
+		assert.Equal(t, []byte{4}, getValue(t, fork, "a"))
+		assert.Equal(t, []byte{5}, getValue(t, fork, "b"))
+		assert.Equal(t, []byte{2}, getValue(t, ledger, "b"))
+
+		slabIndex, err := fork.AllocateSlabIndex(owner[:])
+		require.NoError(t, err)
+		assert.Equal(t, atree.SlabIndex{0, 0, 0, 0, 0, 0, 0, 7}, slabIndex)
+
+		assert.Equal(t,
+			[]snapshot.Register{
+				{Owner: owner, Key: "b", Value: []byte{5}},
+			},
+			fork.Writes(),
+		)
+	})
+}
+
+func TestForkedLedgerRuntime(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.MustBytesToAddress([]byte{0x1})
+
+	base := NewRegisters()
+
+	newRuntimeInterface := func(ledger atree.Ledger) *TestRuntimeInterface {
+		return &TestRuntimeInterface{
+			Storage: TestLedger{
+				OnGetValue:          ledger.GetValue,
+				OnSetValue:          ledger.SetValue,
+				OnValueExists:       ledger.ValueExists,
+				OnAllocateSlabIndex: ledger.AllocateSlabIndex,
+			},
+			OnGetSigningAccounts: func() ([]runtime.Address, error) {
+				return []runtime.Address{address}, nil
+			},
+			OnEmitEvent: func(_ cadence.Event) error {
+				return nil
+			},
+			OnDecodeArgument: func(b []byte, t cadence.Type) (cadence.Value, error) {
+				return json.Decode(nil, b)
+			},
+		}
+	}
+
+	rt := NewTestInterpreterRuntime()
+	nextTransactionLocation := NewTransactionLocationGenerator()
+	nextScriptLocation := NewScriptLocationGenerator()
+
+	const setTx = `
+      transaction(values: [Int]) {
+          prepare(signer: auth(Storage) &Account) {
+              signer.storage.load<[Int]>(from: /storage/values)
+              signer.storage.save(values, to: /storage/values)
+          }
+      }
+    `
+
+	const getScript = `
+      access(all) fun main(): [Int] {
+          return getAuthAccount<auth(Storage) &Account>(0x1)
+              .storage.copy<[Int]>(from: /storage/values)!
+      }
+    `
+
+	setValues := func(ledger atree.Ledger, values ...int) {
+		arguments := make([]cadence.Value, 0, len(values))
+		for _, value := range values {
+			arguments = append(arguments, cadence.NewInt(value))
+		}
+
+		err := rt.ExecuteTransaction(
+			runtime.Script{
+				Source: []byte(setTx),
+				Arguments: [][]byte{
+					json.MustEncode(
+						cadence.NewArray(arguments).
+							WithType(cadence.NewVariableSizedArrayType(cadence.IntType)),
+					),
+				},
+			},
+			runtime.Context{
+				Interface: newRuntimeInterface(ledger),
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	getValues := func(ledger atree.Ledger) cadence.Value {
+		result, err := rt.ExecuteScript(
+			runtime.Script{
+				Source: []byte(getScript),
+			},
+			runtime.Context{
+				Interface: newRuntimeInterface(ledger),
+				Location:  nextScriptLocation(),
+			},
+		)
+		require.NoError(t, err)
+		return result
+	}
+
+	// Prepare the snapshot by recording the writes of a transaction
+
+	recordingLedger := NewTestLedger(
+		nil,
+		func(owner, key, value []byte) {
+			base.Add(snapshot.Register{
+				Owner: common.MustBytesToAddress(owner),
+				Key:   string(key),
+				Value: value,
+			})
+		},
+	)
+
+	setValues(recordingLedger, 1, 2, 3)
+
+	// Run a transaction on a fork of the snapshot
+
+	ledger := NewLedger(base)
+
+	setValues(ledger, 4, 5)
+
+	assert.NotEmpty(t, ledger.Writes())
+
+	assert.Equal(t,
+		cadence.NewArray([]cadence.Value{
+			cadence.NewInt(4),
+			cadence.NewInt(5),
+		}).WithType(cadence.NewVariableSizedArrayType(cadence.IntType)),
+		getValues(ledger),
+	)
+
+	// The snapshot is not modified
+
+	assert.Equal(t,
+		cadence.NewArray([]cadence.Value{
+			cadence.NewInt(1),
+			cadence.NewInt(2),
+			cadence.NewInt(3),
+		}).WithType(cadence.NewVariableSizedArrayType(cadence.IntType)),
+		getValues(NewLedger(base)),
+	)
+}
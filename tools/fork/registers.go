@@ -0,0 +1,114 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fork runs programs against a "fork" of an execution state snapshot,
+// e.g. of the execution state of mainnet.
+//
+// The snapshot is read-only. A forked Ledger records all writes in an overlay,
+// so the snapshot is never modified, and the overlay can be inspected after execution,
+// e.g. by the testing framework or by tools which simulate transactions.
+//
+// A ledger can be used by a runtime.Storage, see Ledger.NewStorage,
+// or it can back the storage functions of a runtime.Interface.
+package fork
+
+import (
+	"bytes"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/runtime/overlay"
+	"github.com/onflow/cadence/tools/snapshot"
+)
+
+type registerKey struct {
+	owner common.Address
+	key   string
+}
+
+// Registers is a read-only, in-memory snapshot
+type Registers struct {
+	registers       map[registerKey][]byte
+	nextSlabIndices map[common.Address]atree.SlabIndex
+}
+
+var _ overlay.Snapshot = &Registers{}
+
+// NewRegisters returns a new, empty snapshot
+func NewRegisters() *Registers {
+	return &Registers{
+		registers:       map[registerKey][]byte{},
+		nextSlabIndices: map[common.Address]atree.SlabIndex{},
+	}
+}
+
+// ReadRegisters reads all registers of the given iterator into a new snapshot,
+// and closes the iterator when done.
+func ReadRegisters(iterator snapshot.Iterator) (*Registers, error) {
+	registers := NewRegisters()
+
+	err := snapshot.ForEach(iterator, func(register snapshot.Register) error {
+		registers.Add(register)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return registers, nil
+}
+
+// Add adds the given register to the snapshot
+func (r *Registers) Add(register snapshot.Register) {
+	r.registers[registerKey{
+		owner: register.Owner,
+		key:   register.Key,
+	}] = register.Value
+
+	if !atree.LedgerKeyIsSlabKey(register.Key) ||
+		len(register.Key) != len(atree.LedgerBaseStorageSlabPrefix)+len(atree.SlabIndex{}) {
+
+		return
+	}
+
+	var slabIndex atree.SlabIndex
+	copy(slabIndex[:], register.Key[len(atree.LedgerBaseStorageSlabPrefix):])
+
+	next := slabIndex.Next()
+	current := r.nextSlabIndices[register.Owner]
+	if bytes.Compare(next[:], current[:]) > 0 {
+		r.nextSlabIndices[register.Owner] = next
+	}
+}
+
+func (r *Registers) GetValue(owner, key []byte) ([]byte, error) {
+	return r.registers[registerKey{
+		owner: common.MustBytesToAddress(owner),
+		key:   string(key),
+	}], nil
+}
+
+func (r *Registers) NextSlabIndex(owner common.Address) (atree.SlabIndex, error) {
+	nextSlabIndex, ok := r.nextSlabIndices[owner]
+	if !ok {
+		// Slab indices start at 1
+		return atree.SlabIndexUndefined.Next(), nil
+	}
+	return nextSlabIndex, nil
+}
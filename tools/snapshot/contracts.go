@@ -0,0 +1,61 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+
+	"github.com/onflow/cadence/common"
+)
+
+// ReadDeployedContracts reads a CSV file of deployed contracts, with the header location,code,
+// e.g. produced by tools/get-contracts, and returns the codes by location
+func ReadDeployedContracts(path string) (map[common.Location][]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	codes := map[common.Location][]byte{}
+
+	if len(records) == 0 {
+		return codes, nil
+	}
+
+	// Skip header
+	for _, record := range records[1:] {
+		location, _, err := common.DecodeTypeID(nil, record[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid location %s: %w", record[0], err)
+		}
+		codes[location] = []byte(record[1])
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,86 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+)
+
+func TestReadDeployedContracts(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "contracts.csv")
+		err := os.WriteFile(
+			path,
+			[]byte("location,code\nA.0000000000000001.Foo,\"access(all) contract Foo {}\"\n"),
+			0600,
+		)
+		require.NoError(t, err)
+
+		codes, err := ReadDeployedContracts(path)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			map[common.Location][]byte{
+				common.AddressLocation{
+					Address: common.MustBytesToAddress([]byte{0x1}),
+					Name:    "Foo",
+				}: []byte("access(all) contract Foo {}"),
+			},
+			codes,
+		)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "contracts.csv")
+		err := os.WriteFile(path, nil, 0600)
+		require.NoError(t, err)
+
+		codes, err := ReadDeployedContracts(path)
+		require.NoError(t, err)
+		assert.Empty(t, codes)
+	})
+
+	t.Run("invalid location", func(t *testing.T) {
+
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "contracts.csv")
+		err := os.WriteFile(path, []byte("location,code\nA.zz.Foo,\n"), 0600)
+		require.NoError(t, err)
+
+		_, err = ReadDeployedContracts(path)
+		require.Error(t, err)
+	})
+}
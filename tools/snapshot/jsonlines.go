@@ -0,0 +1,175 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/onflow/cadence/common"
+)
+
+// FormatJSONLines is the name of the JSON Lines format.
+//
+// Each line is a JSON object of the form
+// `{"Key":{"KeyParts":[{"Value":"<hex>"}, ...]},"Value":"<hex>"}`.
+// The key parts are either owner and key, or owner, controller, and key.
+// Lines without key parts are skipped.
+//
+// Files with a `.gz` suffix are decompressed.
+const FormatJSONLines = "jsonl"
+
+func init() {
+	RegisterFormat(FormatJSONLines, OpenJSONLinesFile)
+}
+
+type encodedKeyPart struct {
+	Value string
+}
+
+type encodedKey struct {
+	KeyParts []encodedKeyPart
+}
+
+type encodedEntry struct {
+	Value string
+	Key   encodedKey
+}
+
+// OpenJSONLinesFile opens the JSON Lines file at the given path.
+func OpenJSONLinesFile(path string) (Iterator, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	closers := []io.Closer{file}
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+		reader = gzipReader
+		closers = append([]io.Closer{gzipReader}, closers...)
+	}
+
+	iterator := NewJSONLinesIterator(reader)
+	iterator.closers = closers
+	return iterator, nil
+}
+
+// JSONLinesIterator iterates over the registers of a JSON Lines snapshot.
+type JSONLinesIterator struct {
+	decoder *json.Decoder
+	closers []io.Closer
+	// Lines is the number of lines read so far
+	Lines int
+	// EmptyLines is the number of lines without key parts read so far
+	EmptyLines int
+}
+
+var _ Iterator = &JSONLinesIterator{}
+
+// NewJSONLinesIterator returns an iterator over the registers of the JSON Lines snapshot
+// read from the given reader. Closing the iterator does not close the reader.
+func NewJSONLinesIterator(reader io.Reader) *JSONLinesIterator {
+	return &JSONLinesIterator{
+		decoder: json.NewDecoder(bufio.NewReader(reader)),
+	}
+}
+
+func (i *JSONLinesIterator) Next() (Register, error) {
+	for {
+		var entry encodedEntry
+
+		err := i.decoder.Decode(&entry)
+		if err != nil {
+			return Register{}, err
+		}
+
+		i.Lines++
+
+		keyParts := entry.Key.KeyParts
+
+		var ownerPart, keyPart string
+		switch len(keyParts) {
+		case 0:
+			i.EmptyLines++
+			continue
+		case 2:
+			ownerPart = keyParts[0].Value
+			keyPart = keyParts[1].Value
+		case 3:
+			// The second key part is the legacy controller, which is ignored
+			ownerPart = keyParts[0].Value
+			keyPart = keyParts[2].Value
+		default:
+			return Register{}, fmt.Errorf(
+				"invalid number of key parts on line %d: %d",
+				i.Lines,
+				len(keyParts),
+			)
+		}
+
+		owner, err := hex.DecodeString(ownerPart)
+		if err != nil {
+			return Register{}, fmt.Errorf("invalid owner on line %d: %w", i.Lines, err)
+		}
+
+		address, err := common.BytesToAddress(owner)
+		if err != nil {
+			return Register{}, fmt.Errorf("invalid owner on line %d: %w", i.Lines, err)
+		}
+
+		key, err := hex.DecodeString(keyPart)
+		if err != nil {
+			return Register{}, fmt.Errorf("invalid key on line %d: %w", i.Lines, err)
+		}
+
+		value, err := hex.DecodeString(entry.Value)
+		if err != nil {
+			return Register{}, fmt.Errorf("invalid value on line %d: %w", i.Lines, err)
+		}
+
+		return Register{
+			Owner: address,
+			Key:   string(key),
+			Value: value,
+		}, nil
+	}
+}
+
+func (i *JSONLinesIterator) Close() error {
+	for _, closer := range i.closers {
+		err := closer.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
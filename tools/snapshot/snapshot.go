@@ -0,0 +1,160 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package snapshot provides access to execution state snapshots in different formats,
+// e.g. JSON Lines dumps, payload files, or execution state checkpoints,
+// behind one common iterator interface.
+//
+// Formats which require dependencies that are not available in this module,
+// e.g. payload files and checkpoints, which are defined by flow-go,
+// can be registered by the tools that have access to them, using RegisterFormat.
+package snapshot
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/onflow/cadence/common"
+)
+
+// Register is an entry of the execution state: a value stored under a key, owned by an account.
+type Register struct {
+	Owner common.Address
+	Key   string
+	Value []byte
+}
+
+// Iterator iterates over the registers of a snapshot.
+type Iterator interface {
+	// Next returns the next register.
+	// It returns io.EOF if there are no more registers.
+	Next() (Register, error)
+	// Close releases the resources held by the iterator.
+	Close() error
+}
+
+// Opener opens the snapshot at the given path.
+type Opener func(path string) (Iterator, error)
+
+var formatsLock sync.RWMutex
+var formats = map[string]Opener{}
+
+// RegisterFormat registers an opener for snapshots in the format with the given name.
+// It panics if a format with the same name is already registered.
+func RegisterFormat(name string, open Opener) {
+	formatsLock.Lock()
+	defer formatsLock.Unlock()
+
+	if _, ok := formats[name]; ok {
+		panic(fmt.Errorf("snapshot format already registered: %s", name))
+	}
+	formats[name] = open
+}
+
+// Formats returns the names of all registered formats, in sorted order.
+func Formats() []string {
+	formatsLock.RLock()
+	defer formatsLock.RUnlock()
+
+	names := make([]string, 0, len(formats))
+	for name := range formats { //nolint:maprange
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Open opens the snapshot at the given path, in the format with the given name.
+func Open(format string, path string) (Iterator, error) {
+	formatsLock.RLock()
+	open, ok := formats[format]
+	formatsLock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown snapshot format: %s", format)
+	}
+
+	return open(path)
+}
+
+// ForEach calls the given function for each register of the iterator,
+// and closes the iterator when done.
+// Iteration stops at the first error returned by the iterator or the function.
+func ForEach(iterator Iterator, f func(Register) error) (err error) {
+	defer func() {
+		closeErr := iterator.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	for {
+		register, err := iterator.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = f(register)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// FilterOwners returns an iterator which only returns the registers
+// of the given iterator that are owned by one of the given addresses.
+// If no addresses are given, all registers are returned.
+func FilterOwners(iterator Iterator, owners ...common.Address) Iterator {
+	if len(owners) == 0 {
+		return iterator
+	}
+
+	ownerSet := make(map[common.Address]struct{}, len(owners))
+	for _, owner := range owners {
+		ownerSet[owner] = struct{}{}
+	}
+
+	return &ownerFilterIterator{
+		Iterator: iterator,
+		owners:   ownerSet,
+	}
+}
+
+type ownerFilterIterator struct {
+	Iterator
+	owners map[common.Address]struct{}
+}
+
+func (i *ownerFilterIterator) Next() (Register, error) {
+	for {
+		register, err := i.Iterator.Next()
+		if err != nil {
+			return Register{}, err
+		}
+
+		if _, ok := i.owners[register.Owner]; ok {
+			return register, nil
+		}
+	}
+}
@@ -0,0 +1,158 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+)
+
+const testJSONLines = `
+{"Key":{"KeyParts":[]},"Value":""}
+{"Key":{"KeyParts":[{"Value":"0000000000000001"},{"Value":"666f6f"}]},"Value":"0102"}
+{"Key":{"KeyParts":[{"Value":"0000000000000002"},{"Value":""},{"Value":"626172"}]},"Value":"03"}
+`
+
+func collect(t *testing.T, iterator Iterator) []Register {
+	var registers []Register
+	err := ForEach(iterator, func(register Register) error {
+		registers = append(registers, register)
+		return nil
+	})
+	require.NoError(t, err)
+	return registers
+}
+
+func TestJSONLinesIterator(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("all", func(t *testing.T) {
+
+		t.Parallel()
+
+		iterator := NewJSONLinesIterator(strings.NewReader(testJSONLines))
+
+		registers := collect(t, iterator)
+
+		assert.Equal(t,
+			[]Register{
+				{
+					Owner: common.MustBytesToAddress([]byte{0x1}),
+					Key:   "foo",
+					Value: []byte{0x1, 0x2},
+				},
+				{
+					Owner: common.MustBytesToAddress([]byte{0x2}),
+					Key:   "bar",
+					Value: []byte{0x3},
+				},
+			},
+			registers,
+		)
+
+		assert.Equal(t, 3, iterator.Lines)
+		assert.Equal(t, 1, iterator.EmptyLines)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+
+		t.Parallel()
+
+		iterator := FilterOwners(
+			NewJSONLinesIterator(strings.NewReader(testJSONLines)),
+			common.MustBytesToAddress([]byte{0x2}),
+		)
+
+		registers := collect(t, iterator)
+
+		assert.Equal(t,
+			[]Register{
+				{
+					Owner: common.MustBytesToAddress([]byte{0x2}),
+					Key:   "bar",
+					Value: []byte{0x3},
+				},
+			},
+			registers,
+		)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		iterator := NewJSONLinesIterator(strings.NewReader(
+			`{"Key":{"KeyParts":[{"Value":"01"}]},"Value":""}`,
+		))
+
+		_, err := iterator.Next()
+		require.ErrorContains(t, err, "invalid number of key parts on line 1")
+	})
+}
+
+func TestOpen(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("gzipped JSON Lines", func(t *testing.T) {
+
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "state.jsonl.gz")
+
+		file, err := os.Create(path)
+		require.NoError(t, err)
+
+		writer := gzip.NewWriter(file)
+		_, err = writer.Write([]byte(testJSONLines))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		require.NoError(t, file.Close())
+
+		iterator, err := Open(FormatJSONLines, path)
+		require.NoError(t, err)
+
+		registers := collect(t, iterator)
+		assert.Len(t, registers, 2)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := Open("unknown", "")
+		require.ErrorContains(t, err, "unknown snapshot format: unknown")
+	})
+
+	t.Run("formats", func(t *testing.T) {
+
+		t.Parallel()
+
+		assert.Contains(t, Formats(), FormatJSONLines)
+	})
+}
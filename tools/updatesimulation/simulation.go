@@ -0,0 +1,283 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package updatesimulation simulates a contract update against the contracts which depend on it.
+//
+// All contracts of a snapshot of deployed contracts are checked, once with the current code,
+// and once with the code of the updated contract replaced by the proposed code.
+// The contracts which import the updated contract, directly or transitively,
+// and which only fail to check with the proposed code, are reported as broken by the update.
+package updatesimulation
+
+import (
+	goErrors "errors"
+	"fmt"
+	"maps"
+	"sort"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/sema"
+	"github.com/onflow/cadence/tools/analysis"
+)
+
+// Update is a proposed update of a contract
+type Update struct {
+	Location common.AddressLocation
+	Code     []byte
+}
+
+// Config is the configuration of a simulation
+type Config struct {
+	// Codes are the codes of all deployed contracts, by location.
+	// The dependency graph of the contracts is determined from their imports
+	Codes map[common.Location][]byte
+	// CryptoContractElaboration is the elaboration of the Crypto contract,
+	// which is needed if contracts import it
+	CryptoContractElaboration *sema.Elaboration
+}
+
+// Status is the outcome of the simulation for a dependent contract
+type Status string
+
+const (
+	// StatusCompatible indicates that the dependent still checks with the proposed code
+	StatusCompatible Status = "compatible"
+	// StatusBroken indicates that the dependent checks with the current code,
+	// but fails to check with the proposed code
+	StatusBroken Status = "broken"
+	// StatusAlreadyBroken indicates that the dependent already fails to check with the current code
+	StatusAlreadyBroken Status = "already broken"
+)
+
+// Result is the result of the simulation for a dependent contract
+type Result struct {
+	Location common.Location
+	Status   Status
+	// Direct is true if the dependent imports the updated contract directly
+	Direct bool
+	// Errors are the errors of checking the dependent with the proposed code,
+	// if the status is StatusBroken, or with the current code, if the status is StatusAlreadyBroken
+	Errors []string `json:",omitempty"`
+}
+
+// Report is the result of a simulation
+type Report struct {
+	Location common.AddressLocation
+	// Errors are the errors of checking the proposed code itself, if any
+	Errors []string `json:",omitempty"`
+	// Dependents are the results of all dependents of the updated contract, sorted by location
+	Dependents []Result
+}
+
+// Compatible returns true if the proposed code checks, and no dependent is broken by the update
+func (r *Report) Compatible() bool {
+	if len(r.Errors) > 0 {
+		return false
+	}
+	for _, result := range r.Dependents {
+		if result.Status == StatusBroken {
+			return false
+		}
+	}
+	return true
+}
+
+// Broken returns the results of the dependents which are broken by the update
+func (r *Report) Broken() []Result {
+	var broken []Result
+	for _, result := range r.Dependents {
+		if result.Status == StatusBroken {
+			broken = append(broken, result)
+		}
+	}
+	return broken
+}
+
+// Simulate checks the given proposed update against the dependents of the updated contract.
+// Errors of parsing and checking contracts are reported in the report.
+// An error is only returned if the contracts cannot be loaded, e.g. because of an unknown import.
+func Simulate(update Update, config Config) (*Report, error) {
+
+	current, err := load(config.Codes, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check current contracts: %w", err)
+	}
+
+	updatedCodes := maps.Clone(config.Codes)
+	if updatedCodes == nil {
+		updatedCodes = map[common.Location][]byte{}
+	}
+	updatedCodes[update.Location] = update.Code
+
+	updated, err := load(updatedCodes, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check updated contracts: %w", err)
+	}
+
+	report := &Report{
+		Location: update.Location,
+	}
+
+	if program := updated.Get(update.Location); program != nil {
+		report.Errors = errorMessages(program.LoadError)
+	}
+
+	direct, dependents := dependentsOf(update.Location, current.ImportGraph())
+
+	for _, location := range dependents {
+		result := Result{
+			Location: location,
+			Direct:   direct[location],
+		}
+
+		if currentErrors := errorMessages(current.Get(location).LoadError); len(currentErrors) > 0 {
+			result.Status = StatusAlreadyBroken
+			result.Errors = currentErrors
+		} else if updatedErrors := errorMessages(updated.Get(location).LoadError); len(updatedErrors) > 0 {
+			result.Status = StatusBroken
+			result.Errors = updatedErrors
+		} else {
+			result.Status = StatusCompatible
+		}
+
+		report.Dependents = append(report.Dependents, result)
+	}
+
+	return report, nil
+}
+
+// load parses and checks all contracts of the given codes.
+// Errors of parsing and checking are recorded in the programs instead of being returned
+func load(codes map[common.Location][]byte, config Config) (*analysis.Programs, error) {
+	locations := make([]common.Location, 0, len(codes))
+	contractNames := map[common.Address][]string{}
+
+	for location := range codes { //nolint:maprange
+		locations = append(locations, location)
+
+		if addressLocation, ok := location.(common.AddressLocation); ok {
+			contractNames[addressLocation.Address] = append(
+				contractNames[addressLocation.Address],
+				addressLocation.Name,
+			)
+		}
+	}
+
+	sortLocations(locations)
+
+	for _, names := range contractNames { //nolint:maprange
+		sort.Strings(names)
+	}
+
+	analysisConfig := analysis.NewSimpleConfig(
+		analysis.NeedTypes,
+		// The codes are not modified, as no address contracts are resolved on demand
+		codes,
+		contractNames,
+		nil,
+	)
+	analysisConfig.CryptoContractElaboration = config.CryptoContractElaboration
+	analysisConfig.HandleParserError = func(_ analysis.ParsingCheckingError, _ *ast.Program) error {
+		return nil
+	}
+	analysisConfig.HandleCheckerError = func(_ analysis.ParsingCheckingError, _ *sema.Checker) error {
+		return nil
+	}
+
+	return analysis.Load(analysisConfig, locations...)
+}
+
+// dependentsOf returns the locations of all programs in the given import graph
+// which import the given location, directly or transitively, sorted by location,
+// and which of them import it directly
+func dependentsOf(
+	location common.Location,
+	importGraph map[common.Location][]common.Location,
+) (
+	direct map[common.Location]bool,
+	dependents []common.Location,
+) {
+	importers := map[common.Location][]common.Location{}
+	for importer, imports := range importGraph { //nolint:maprange
+		for _, imported := range imports {
+			importers[imported] = append(importers[imported], importer)
+		}
+	}
+
+	direct = map[common.Location]bool{}
+	for _, importer := range importers[location] {
+		direct[importer] = true
+	}
+
+	visited := map[common.Location]struct{}{
+		location: {},
+	}
+	queue := []common.Location{location}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, importer := range importers[current] {
+			if _, ok := visited[importer]; ok {
+				continue
+			}
+			visited[importer] = struct{}{}
+			dependents = append(dependents, importer)
+			queue = append(queue, importer)
+		}
+	}
+
+	sortLocations(dependents)
+
+	return direct, dependents
+}
+
+func sortLocations(locations []common.Location) {
+	sort.Slice(
+		locations,
+		func(i, j int) bool {
+			return locations[i].ID() < locations[j].ID()
+		},
+	)
+}
+
+// errorMessages returns the messages of the given parsing or checking error.
+// The child errors of a checker error are reported individually, with their position
+func errorMessages(err error) []string {
+	if err == nil {
+		return nil
+	}
+
+	var checkerError *sema.CheckerError
+	if !goErrors.As(err, &checkerError) {
+		return []string{err.Error()}
+	}
+
+	messages := make([]string, 0, len(checkerError.Errors))
+	for _, childError := range checkerError.Errors {
+		message := childError.Error()
+		if hasPosition, ok := childError.(ast.HasPosition); ok {
+			position := hasPosition.StartPosition()
+			message = fmt.Sprintf("%d:%d: %s", position.Line, position.Column, message)
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
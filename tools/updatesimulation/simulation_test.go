@@ -0,0 +1,219 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package updatesimulation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/tools/updatesimulation"
+)
+
+func TestSimulate(t *testing.T) {
+
+	t.Parallel()
+
+	address1 := common.MustBytesToAddress([]byte{0x1})
+	address2 := common.MustBytesToAddress([]byte{0x2})
+	address3 := common.MustBytesToAddress([]byte{0x3})
+
+	fooLocation := common.AddressLocation{Address: address1, Name: "Foo"}
+	barLocation := common.AddressLocation{Address: address2, Name: "Bar"}
+	bazLocation := common.AddressLocation{Address: address2, Name: "Baz"}
+	quxLocation := common.AddressLocation{Address: address3, Name: "Qux"}
+	brokenLocation := common.AddressLocation{Address: address3, Name: "Broken"}
+
+	codes := map[common.Location][]byte{
+		fooLocation: []byte(`
+          access(all) contract Foo {
+              access(all) fun answer(): Int { return 42 }
+              access(all) fun question(): String { return "?" }
+          }
+        `),
+		// Bar uses Foo.answer
+		barLocation: []byte(`
+          import Foo from 0x1
+
+          access(all) contract Bar {
+              access(all) fun answer(): Int { return Foo.answer() }
+          }
+        `),
+		// Baz uses Foo.question
+		bazLocation: []byte(`
+          import Foo from 0x1
+
+          access(all) contract Baz {
+              access(all) fun question(): String { return Foo.question() }
+          }
+        `),
+		// Qux depends on Foo only transitively
+		quxLocation: []byte(`
+          import Bar from 0x2
+
+          access(all) contract Qux {
+              access(all) fun answer(): Int { return Bar.answer() }
+          }
+        `),
+		// Broken fails to check, independent of the update
+		brokenLocation: []byte(`
+          import Foo from 0x1
+
+          access(all) contract Broken {
+              access(all) fun answer(): Int { return Foo.unknown() }
+          }
+        `),
+	}
+
+	simulate := func(t *testing.T, code string) *updatesimulation.Report {
+		report, err := updatesimulation.Simulate(
+			updatesimulation.Update{
+				Location: fooLocation,
+				Code:     []byte(code),
+			},
+			updatesimulation.Config{
+				Codes: codes,
+			},
+		)
+		require.NoError(t, err)
+		return report
+	}
+
+	t.Run("compatible", func(t *testing.T) {
+
+		t.Parallel()
+
+		report := simulate(t, `
+          access(all) contract Foo {
+              access(all) fun answer(): Int { return 43 }
+              access(all) fun question(): String { return "?" }
+              access(all) fun other(): Bool { return true }
+          }
+        `)
+
+		require.Empty(t, report.Errors)
+		assert.True(t, report.Compatible())
+		assert.Empty(t, report.Broken())
+
+		assert.Equal(t,
+			[]updatesimulation.Result{
+				{
+					Location: barLocation,
+					Status:   updatesimulation.StatusCompatible,
+					Direct:   true,
+				},
+				{
+					Location: bazLocation,
+					Status:   updatesimulation.StatusCompatible,
+					Direct:   true,
+				},
+				{
+					Location: brokenLocation,
+					Status:   updatesimulation.StatusAlreadyBroken,
+					Direct:   true,
+					Errors:   report.Dependents[2].Errors,
+				},
+				{
+					Location: quxLocation,
+					Status:   updatesimulation.StatusCompatible,
+				},
+			},
+			report.Dependents,
+		)
+
+		brokenErrors := report.Dependents[2].Errors
+		require.Len(t, brokenErrors, 1)
+		assert.Contains(t, brokenErrors[0], "5:57: value of type `&Foo` has no member `unknown`")
+	})
+
+	t.Run("removed function", func(t *testing.T) {
+
+		t.Parallel()
+
+		report := simulate(t, `
+          access(all) contract Foo {
+              access(all) fun question(): String { return "?" }
+          }
+        `)
+
+		require.Empty(t, report.Errors)
+		assert.False(t, report.Compatible())
+
+		broken := report.Broken()
+		require.Len(t, broken, 2)
+
+		assert.Equal(t, barLocation, broken[0].Location)
+		assert.True(t, broken[0].Direct)
+		require.Len(t, broken[0].Errors, 1)
+		assert.Contains(t, broken[0].Errors[0], "5:57: value of type `&Foo` has no member `answer`")
+
+		// Qux is broken, because its import of Bar fails to check
+		assert.Equal(t, quxLocation, broken[1].Location)
+		assert.False(t, broken[1].Direct)
+		require.NotEmpty(t, broken[1].Errors)
+		assert.Contains(t, broken[1].Errors[0], "2:26: checking of imported program `0000000000000002.Bar` failed")
+	})
+
+	t.Run("invalid update", func(t *testing.T) {
+
+		t.Parallel()
+
+		report := simulate(t, `
+          access(all) contract Foo {
+              access(all) fun answer(): Int { return "42" }
+              access(all) fun question(): String { return "?" }
+          }
+        `)
+
+		require.Len(t, report.Errors, 1)
+		assert.Contains(t, report.Errors[0], "mismatched types")
+		assert.False(t, report.Compatible())
+
+		// All dependents which currently check are broken
+		broken := report.Broken()
+		require.Len(t, broken, 3)
+		assert.Equal(t, barLocation, broken[0].Location)
+		assert.Equal(t, bazLocation, broken[1].Location)
+		assert.Equal(t, quxLocation, broken[2].Location)
+	})
+
+	t.Run("no dependents", func(t *testing.T) {
+
+		t.Parallel()
+
+		report, err := updatesimulation.Simulate(
+			updatesimulation.Update{
+				Location: quxLocation,
+				Code: []byte(`
+                  access(all) contract Qux {}
+                `),
+			},
+			updatesimulation.Config{
+				Codes: codes,
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Empty(t, report.Errors)
+		assert.Empty(t, report.Dependents)
+		assert.True(t, report.Compatible())
+	})
+}
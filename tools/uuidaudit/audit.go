@@ -0,0 +1,242 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package uuidaudit audits the resource UUIDs of an execution state snapshot.
+//
+// It finds all resources stored in the accounts of the snapshot,
+// and reports UUIDs which are used by more than one resource,
+// as well as UUIDs which were not issued yet according to the UUID counter,
+// i.e. which are greater than or equal to the counter.
+package uuidaudit
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/runtime"
+)
+
+// Resource is a resource found in the storage of an account
+type Resource struct {
+	UUID   uint64
+	Owner  common.Address
+	Domain common.StorageDomain
+	// Key is the key of the storage map entry which contains the resource,
+	// e.g. the identifier of the storage path
+	Key string
+	// TypeID is the type of the resource
+	TypeID common.TypeID
+}
+
+func (r Resource) String() string {
+	return fmt.Sprintf(
+		"%s (UUID %d) in %s %s/%s",
+		r.TypeID,
+		r.UUID,
+		r.Owner.HexWithPrefix(),
+		r.Domain.Identifier(),
+		r.Key,
+	)
+}
+
+// Duplicate is a UUID which is used by more than one resource
+type Duplicate struct {
+	UUID      uint64
+	Resources []Resource
+}
+
+// Config is the configuration of an audit
+type Config struct {
+	// Counter is the current value of the UUID counter, i.e. the next UUID to be issued.
+	// When not set, UUIDs are not checked against the counter
+	Counter *uint64
+	// Owners are the accounts to audit.
+	// When empty, all accounts are audited
+	Owners []common.Address
+	// StorageFormatV2Enabled determines if accounts may be in storage format v2
+	StorageFormatV2Enabled bool
+}
+
+// Report is the result of an audit
+type Report struct {
+	// ResourceCount is the number of resources found
+	ResourceCount int
+	// MaxUUID is the greatest UUID found
+	MaxUUID uint64
+	// Duplicates are the UUIDs which are used by more than one resource, sorted by UUID
+	Duplicates []Duplicate
+	// NotIssued are the resources which have a UUID that was not issued yet
+	// according to the UUID counter, sorted by UUID
+	NotIssued []Resource
+	// Errors are the errors which occurred when loading the storage of accounts.
+	// The audit continues with the next domain when an error occurs
+	Errors []error `json:"-"`
+}
+
+// Audit finds all resources in the storage of the accounts of the ledger,
+// and reports duplicate UUIDs and UUIDs which were not issued yet.
+func Audit(ledger *Ledger, config Config) (*Report, error) {
+
+	storage := runtime.NewStorage(
+		ledger,
+		nil,
+		runtime.StorageConfig{
+			StorageFormatV2Enabled: config.StorageFormatV2Enabled,
+		},
+	)
+
+	inter, err := interpreter.NewInterpreter(
+		nil,
+		nil,
+		&interpreter.Config{
+			Storage: storage,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := config.Owners
+	if len(owners) == 0 {
+		owners = ledger.Owners()
+	}
+
+	report := &Report{}
+
+	resourcesByUUID := map[uint64][]Resource{}
+
+	addResource := func(resource Resource) {
+		report.ResourceCount++
+		if resource.UUID > report.MaxUUID {
+			report.MaxUUID = resource.UUID
+		}
+
+		resourcesByUUID[resource.UUID] = append(resourcesByUUID[resource.UUID], resource)
+
+		if config.Counter != nil && resource.UUID >= *config.Counter {
+			report.NotIssued = append(report.NotIssued, resource)
+		}
+	}
+
+	for _, owner := range owners {
+		for _, domain := range common.AllStorageDomains {
+			err := auditDomain(inter, storage, owner, domain, addResource)
+			if err != nil {
+				report.Errors = append(
+					report.Errors,
+					fmt.Errorf(
+						"failed to audit domain %s of account %s: %w",
+						domain.Identifier(),
+						owner.HexWithPrefix(),
+						err,
+					),
+				)
+			}
+		}
+	}
+
+	for uuid, resources := range resourcesByUUID { //nolint:maprange
+		if len(resources) < 2 {
+			continue
+		}
+		report.Duplicates = append(
+			report.Duplicates,
+			Duplicate{
+				UUID:      uuid,
+				Resources: resources,
+			},
+		)
+	}
+
+	sort.Slice(report.Duplicates, func(i, j int) bool {
+		return report.Duplicates[i].UUID < report.Duplicates[j].UUID
+	})
+
+	sort.SliceStable(report.NotIssued, func(i, j int) bool {
+		return report.NotIssued[i].UUID < report.NotIssued[j].UUID
+	})
+
+	return report, nil
+}
+
+func auditDomain(
+	inter *interpreter.Interpreter,
+	storage *runtime.Storage,
+	owner common.Address,
+	domain common.StorageDomain,
+	f func(Resource),
+) (err error) {
+
+	// Loading values panics on errors, e.g. missing slabs
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			err, ok = r.(error)
+			if !ok {
+				err = errors.NewUnexpectedError("%v", r)
+			}
+		}
+	}()
+
+	storageMap := storage.GetDomainStorageMap(inter, owner, domain, false)
+	if storageMap == nil {
+		return nil
+	}
+
+	locationRange := interpreter.EmptyLocationRange
+
+	iterator := storageMap.Iterator(nil)
+	for {
+		key, value := iterator.Next()
+		if key == nil {
+			break
+		}
+
+		interpreter.InspectValue(
+			inter,
+			value,
+			func(value interpreter.Value) bool {
+				compositeValue, ok := value.(*interpreter.CompositeValue)
+				if !ok || !compositeValue.IsResourceKinded(inter) {
+					return true
+				}
+
+				uuid := compositeValue.ResourceUUID(inter, locationRange)
+				if uuid == nil {
+					return true
+				}
+
+				f(Resource{
+					UUID:   uint64(*uuid),
+					Owner:  owner,
+					Domain: domain,
+					Key:    fmt.Sprint(key),
+					TypeID: compositeValue.TypeID(),
+				})
+
+				return true
+			},
+			locationRange,
+		)
+	}
+
+	return nil
+}
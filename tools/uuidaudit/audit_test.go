@@ -0,0 +1,188 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package uuidaudit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/runtime"
+	. "github.com/onflow/cadence/test_utils/runtime_utils"
+	"github.com/onflow/cadence/tools/snapshot"
+)
+
+func TestAudit(t *testing.T) {
+
+	t.Parallel()
+
+	contractAddress := common.MustBytesToAddress([]byte{0x1})
+	otherAddress := common.MustBytesToAddress([]byte{0x2})
+
+	const contract = `
+      access(all) contract C {
+
+          access(all) resource R {
+              access(all) let children: @[R]
+
+              init(children: @[R]) {
+                  self.children <- children
+              }
+          }
+
+          access(all) fun createR(children: @[R]): @R {
+              return <- create R(children: <- children)
+          }
+      }
+    `
+
+	const tx = `
+      import C from 0x1
+
+      transaction {
+          prepare(first: auth(Storage) &Account, second: auth(Storage) &Account) {
+              first.storage.save(<- C.createR(children: <- [<- C.createR(children: <- [])]), to: /storage/r)
+              second.storage.save(<- C.createR(children: <- []), to: /storage/r)
+          }
+      }
+    `
+
+	ledger := &Ledger{
+		registers: map[registerKey][]byte{},
+		owners:    map[common.Address]struct{}{},
+	}
+
+	accountCodes := map[common.Location][]byte{}
+
+	var signers []runtime.Address
+
+	// Issue the UUIDs 1, 2, 2 to the resources.
+	// The second UUID is issued twice, simulating a resource duplication bug
+	uuids := []uint64{1, 2, 2}
+
+	runtimeInterface := &TestRuntimeInterface{
+		Storage: NewTestLedger(
+			nil,
+			func(owner, key, value []byte) {
+				ledger.Add(snapshot.Register{
+					Owner: common.MustBytesToAddress(owner),
+					Key:   string(key),
+					Value: value,
+				})
+			},
+		),
+		OnGetSigningAccounts: func() ([]runtime.Address, error) {
+			return signers, nil
+		},
+		OnResolveLocation: NewSingleIdentifierLocationResolver(t),
+		OnGetAccountContractCode: func(location common.AddressLocation) ([]byte, error) {
+			return accountCodes[location], nil
+		},
+		OnUpdateAccountContractCode: func(location common.AddressLocation, code []byte) error {
+			accountCodes[location] = code
+			return nil
+		},
+		OnEmitEvent: func(_ cadence.Event) error {
+			return nil
+		},
+		OnGenerateUUID: func() (uint64, error) {
+			uuid := uuids[0]
+			uuids = uuids[1:]
+			return uuid, nil
+		},
+	}
+
+	rt := NewTestInterpreterRuntime()
+	nextTransactionLocation := NewTransactionLocationGenerator()
+
+	signers = []runtime.Address{contractAddress}
+
+	err := rt.ExecuteTransaction(
+		runtime.Script{
+			Source: DeploymentTransaction("C", []byte(contract)),
+		},
+		runtime.Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	signers = []runtime.Address{contractAddress, otherAddress}
+
+	err = rt.ExecuteTransaction(
+		runtime.Script{
+			Source: []byte(tx),
+		},
+		runtime.Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.NoError(t, err)
+
+	counter := uint64(2)
+
+	report, err := Audit(
+		ledger,
+		Config{
+			Counter: &counter,
+		},
+	)
+	require.NoError(t, err)
+
+	require.Empty(t, report.Errors)
+	assert.Equal(t, 3, report.ResourceCount)
+	assert.Equal(t, uint64(2), report.MaxUUID)
+
+	rType := common.TypeID("A.0000000000000001.C.R")
+
+	assert.Equal(t,
+		[]Duplicate{
+			{
+				UUID: 2,
+				Resources: []Resource{
+					{
+						UUID:   2,
+						Owner:  contractAddress,
+						Domain: common.StorageDomainPathStorage,
+						Key:    "r",
+						TypeID: rType,
+					},
+					{
+						UUID:   2,
+						Owner:  otherAddress,
+						Domain: common.StorageDomainPathStorage,
+						Key:    "r",
+						TypeID: rType,
+					},
+				},
+			},
+		},
+		report.Duplicates,
+	)
+
+	assert.Len(t, report.NotIssued, 2)
+	for _, resource := range report.NotIssued {
+		assert.Equal(t, uint64(2), resource.UUID)
+	}
+}
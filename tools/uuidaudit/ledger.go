@@ -0,0 +1,118 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package uuidaudit
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/errors"
+	"github.com/onflow/cadence/tools/snapshot"
+)
+
+type registerKey struct {
+	owner common.Address
+	key   string
+}
+
+// Ledger is a read-only, in-memory ledger of the registers of a snapshot
+type Ledger struct {
+	registers map[registerKey][]byte
+	owners    map[common.Address]struct{}
+}
+
+var _ atree.Ledger = &Ledger{}
+
+// NewLedger reads all registers of the given iterator into a new ledger,
+// and closes the iterator when done.
+func NewLedger(iterator snapshot.Iterator) (*Ledger, error) {
+	ledger := &Ledger{
+		registers: map[registerKey][]byte{},
+		owners:    map[common.Address]struct{}{},
+	}
+
+	err := snapshot.ForEach(iterator, func(register snapshot.Register) error {
+		ledger.Add(register)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ledger, nil
+}
+
+// Add adds the given register to the ledger
+func (l *Ledger) Add(register snapshot.Register) {
+	l.registers[registerKey{
+		owner: register.Owner,
+		key:   register.Key,
+	}] = register.Value
+	l.owners[register.Owner] = struct{}{}
+}
+
+// Owners returns the addresses of all accounts which own registers, in sorted order
+func (l *Ledger) Owners() []common.Address {
+	owners := make([]common.Address, 0, len(l.owners))
+	for owner := range l.owners { //nolint:maprange
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		return owners[i].Compare(owners[j]) < 0
+	})
+	return owners
+}
+
+// UInt64 returns the value of the given register as a big-endian encoded unsigned integer
+func (l *Ledger) UInt64(owner common.Address, key string) (value uint64, ok bool) {
+	data := l.registers[registerKey{
+		owner: owner,
+		key:   key,
+	}]
+	if len(data) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(data), true
+}
+
+func (l *Ledger) GetValue(owner, key []byte) ([]byte, error) {
+	return l.registers[registerKey{
+		owner: common.MustBytesToAddress(owner),
+		key:   string(key),
+	}], nil
+}
+
+func (l *Ledger) ValueExists(owner, key []byte) (bool, error) {
+	value, err := l.GetValue(owner, key)
+	if err != nil {
+		return false, err
+	}
+	return len(value) > 0, nil
+}
+
+func (l *Ledger) SetValue(_, _, _ []byte) error {
+	return errors.NewUnexpectedError("ledger is read-only")
+}
+
+func (l *Ledger) AllocateSlabIndex(_ []byte) (atree.SlabIndex, error) {
+	return atree.SlabIndexUndefined, errors.NewUnexpectedError("ledger is read-only")
+}